@@ -11,6 +11,7 @@ import (
 
 	"gioui.org/app"
 	"github.com/crypto-power/cryptopower/appos"
+	"github.com/crypto-power/cryptopower/libwallet"
 	libutils "github.com/crypto-power/cryptopower/libwallet/utils"
 	"github.com/crypto-power/cryptopower/version"
 	"github.com/decred/dcrd/dcrutil/v4"
@@ -36,6 +37,7 @@ type config struct {
 	SpendUnconfirmed bool   `long:"spendunconfirmed" description:"Allow the assetsManager to use transactions that have not been confirmed"`
 	Profile          int    `long:"profile" description:"Runs local web server for profiling"`
 	DEXTestAddr      string `long:"dextestaddr" description:"If using the dextest network, set an address for the dex harness to be used as a persistant peer for all new wallets."`
+	DBDriver         string `long:"dbdriver" description:"Wallet database driver {bdb, badgerdb}. Existing wallet data is migrated to the driver. Defaults to the driver of the existing data or the platform default."`
 
 	net libutils.NetworkType
 }
@@ -232,6 +234,10 @@ func loadConfig() (*config, error) {
 		return loadConfigError(fmt.Errorf("network type is not supported: %s", cfg.Network))
 	}
 
+	if cfg.DBDriver != "" && cfg.DBDriver != libwallet.BoltDB && cfg.DBDriver != libwallet.BadgerDB {
+		return loadConfigError(fmt.Errorf("database driver is not supported: %s", cfg.DBDriver))
+	}
+
 	// Parse, validate, and set debug log level(s).
 	if cfg.Quiet {
 		cfg.DebugLevel = "error"
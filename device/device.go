@@ -23,6 +23,17 @@ func (d *Device) SetScreenAwake(isOn bool) error {
 	return d.setScreenAwake(isOn)
 }
 
+// IsMeteredNetwork returns true if the device is connected to a metered
+// network, such as a cellular network.
+func (d *Device) IsMeteredNetwork() (bool, error) {
+	return d.isMeteredNetwork()
+}
+
+// IsCharging returns true if the device is plugged in.
+func (d *Device) IsCharging() (bool, error) {
+	return d.isCharging()
+}
+
 func (d *Device) ProcessEvent(w *app.Window) event.Event {
 	evt := w.Event()
 	switch e := evt.(type) {
@@ -4,6 +4,8 @@ import (
 	"fmt"
 
 	"gioui.org/app"
+	// isActiveNetworkMetered requires the ACCESS_NETWORK_STATE permission.
+	_ "gioui.org/app/permission/networkstate"
 	"gioui.org/io/event"
 	"git.wow.st/gmp/jni"
 )
@@ -70,3 +72,34 @@ func (d *Device) setScreenAwake(isOn bool) error {
 	})
 	return nil
 }
+
+// systemServiceBool calls the boolean method of the Android system service
+// named service, e.g. ConnectivityManager.isActiveNetworkMetered.
+func systemServiceBool(service, method string) (bool, error) {
+	var result bool
+	err := jni.Do(jni.JVMFor(app.JavaVM()), func(env jni.Env) error {
+		context := jni.Object(app.AppContext())
+		getSystemService := jni.GetMethodID(env, jni.GetObjectClass(env, context),
+			"getSystemService", "(Ljava/lang/String;)Ljava/lang/Object;")
+		manager, err := jni.CallObjectMethod(env, context, getSystemService,
+			jni.Value(jni.JavaString(env, service)))
+		if err != nil {
+			return err
+		}
+		if manager == 0 {
+			return ErrNotAvailable
+		}
+		methodID := jni.GetMethodID(env, jni.GetObjectClass(env, manager), method, "()Z")
+		result, err = jni.CallBooleanMethod(env, manager, methodID)
+		return err
+	})
+	return result, err
+}
+
+func (d *Device) isMeteredNetwork() (bool, error) {
+	return systemServiceBool("connectivity", "isActiveNetworkMetered")
+}
+
+func (d *Device) isCharging() (bool, error) {
+	return systemServiceBool("batterymanager", "isCharging")
+}
@@ -2,7 +2,7 @@ package device
 
 /*
 #cgo CFLAGS: -x objective-c
-#cgo LDFLAGS: -framework Foundation
+#cgo LDFLAGS: -framework Foundation -framework SystemConfiguration
 #import "device_ios.h"
 */
 import "C"
@@ -27,6 +27,19 @@ func (d *Device) setScreenAwake(isOn bool) error {
 	return nil
 }
 
+func (d *Device) isMeteredNetwork() (bool, error) {
+	return bool(C.isMeteredNetwork()), nil
+}
+
+// isCharging reads the battery state on the main thread, as UIKit requires.
+func (d *Device) isCharging() (bool, error) {
+	var charging bool
+	d.window.Run(func() {
+		charging = bool(C.isCharging())
+	})
+	return charging, nil
+}
+
 func (d *Device) listenEvents(evt event.Event) {
 	if evt, ok := evt.(app.UIKitViewEvent); ok {
 		d.view = evt.ViewController
@@ -1,3 +1,5 @@
 #import <UIKit/UIKit.h>
 
-BOOL setScreenAwake(BOOL isOn);
\ No newline at end of file
+BOOL setScreenAwake(BOOL isOn);
+BOOL isMeteredNetwork(void);
+BOOL isCharging(void);
\ No newline at end of file
@@ -1,5 +1,30 @@
 #import <device_ios.h>
+#import <SystemConfiguration/SystemConfiguration.h>
+#import <netinet/in.h>
+
 BOOL setScreenAwake(BOOL isOn){
     [UIApplication sharedApplication].idleTimerDisabled = isOn;
     return isOn;
+}
+
+BOOL isMeteredNetwork(void){
+    struct sockaddr_in addr;
+    bzero(&addr, sizeof(addr));
+    addr.sin_len = sizeof(addr);
+    addr.sin_family = AF_INET;
+    SCNetworkReachabilityRef reachability = SCNetworkReachabilityCreateWithAddress(NULL, (const struct sockaddr *)&addr);
+    if (reachability == NULL) {
+        return NO;
+    }
+    SCNetworkReachabilityFlags flags = 0;
+    BOOL ok = SCNetworkReachabilityGetFlags(reachability, &flags);
+    CFRelease(reachability);
+    return ok && (flags & kSCNetworkReachabilityFlagsIsWWAN) != 0;
+}
+
+BOOL isCharging(void){
+    UIDevice *device = [UIDevice currentDevice];
+    device.batteryMonitoringEnabled = YES;
+    UIDeviceBatteryState state = device.batteryState;
+    return state == UIDeviceBatteryStateCharging || state == UIDeviceBatteryStateFull;
 }
\ No newline at end of file
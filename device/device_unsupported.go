@@ -18,4 +18,12 @@ func (d *Device) setScreenAwake(_ bool) error {
 	return ErrNotAvailable
 }
 
+func (d *Device) isMeteredNetwork() (bool, error) {
+	return false, ErrNotAvailable
+}
+
+func (d *Device) isCharging() (bool, error) {
+	return false, ErrNotAvailable
+}
+
 func (d *Device) listenEvents(_ event.Event) {}
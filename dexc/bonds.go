@@ -0,0 +1,119 @@
+package dexc
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"decred.org/dcrdex/client/asset"
+	"decred.org/dcrdex/client/db"
+)
+
+// BondStatus describes where a bond is in its lifetime.
+type BondStatus int
+
+const (
+	// BondStatusPending is a bond that has been broadcast but has not yet
+	// reached the confirmations required by the server.
+	BondStatusPending BondStatus = iota
+	// BondStatusActive is a confirmed bond that counts towards the account
+	// tier.
+	BondStatusActive
+	// BondStatusExpiring is an active bond that will stop counting towards
+	// the account tier within the server's bond expiry window.
+	BondStatusExpiring
+	// BondStatusExpired is a bond that no longer counts towards the account
+	// tier but cannot be refunded yet because its lock time has not passed.
+	BondStatusExpired
+)
+
+// MaxPenaltyComps is the maximum number of penalized tiers that users may ask
+// the DEX client to compensate for by posting additional bonds.
+const MaxPenaltyComps = 20
+
+// expiringBondWindow is how long before expiry an active bond is reported as
+// expiring.
+const expiringBondWindow = 24 * time.Hour
+
+// String returns a human-readable representation of the bond status.
+func (s BondStatus) String() string {
+	switch s {
+	case BondStatusPending:
+		return "Pending"
+	case BondStatusActive:
+		return "Active"
+	case BondStatusExpiring:
+		return "Expiring"
+	case BondStatusExpired:
+		return "Expired"
+	default:
+		return "Unknown"
+	}
+}
+
+// BondInfo is a bond posted to a DEX server along with its current status.
+type BondInfo struct {
+	*db.Bond
+	Status BondStatus
+	// ExpiresAt is the time after which the bond no longer counts towards
+	// the account tier.
+	ExpiresAt time.Time
+	// RefundableAt is the time after which the bond can be refunded.
+	RefundableAt time.Time
+}
+
+// Bonds returns the unrefunded bonds posted to the DEX server at host sorted by
+// their expiry time. The app password is required because the bonds are read
+// from the account's encrypted records.
+func (dc *DEXClient) Bonds(pw []byte, host string) ([]*BondInfo, error) {
+	xc, err := dc.Exchange(host)
+	if err != nil {
+		return nil, err
+	}
+
+	_, bonds, err := dc.AccountExport(pw, host)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving bonds for %s: %w", host, err)
+	}
+
+	pending := make(map[string]bool, len(xc.Auth.PendingBonds))
+	for _, pb := range xc.Auth.PendingBonds {
+		pending[pb.CoinID] = true
+	}
+
+	now := time.Now()
+	bondExpiry := time.Duration(xc.BondExpiry) * time.Second
+	var bondInfos []*BondInfo
+	for _, bond := range bonds {
+		if bond.Refunded {
+			continue
+		}
+
+		refundableAt := time.Unix(int64(bond.LockTime), 0)
+		info := &BondInfo{
+			Bond:         bond,
+			ExpiresAt:    refundableAt.Add(-bondExpiry),
+			RefundableAt: refundableAt,
+		}
+
+		coinID, _ := asset.DecodeCoinID(bond.AssetID, bond.CoinID)
+		switch {
+		case pending[coinID] || !bond.Confirmed:
+			info.Status = BondStatusPending
+		case !now.Before(info.ExpiresAt):
+			info.Status = BondStatusExpired
+		case info.ExpiresAt.Sub(now) < expiringBondWindow:
+			info.Status = BondStatusExpiring
+		default:
+			info.Status = BondStatusActive
+		}
+
+		bondInfos = append(bondInfos, info)
+	}
+
+	sort.Slice(bondInfos, func(i, j int) bool {
+		return bondInfos[i].ExpiresAt.Before(bondInfos[j].ExpiresAt)
+	})
+
+	return bondInfos, nil
+}
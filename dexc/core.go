@@ -44,9 +44,16 @@ type DEXClient struct {
 	loggedIn        bool
 	shutdownChan    <-chan struct{}
 	bondBufferCache sync.Map
+	triggers        *triggerOrders
+	trades          *tradeCache
 	log             dex.Logger
+	tradeCheck      TradeCheck
 }
 
+// TradeCheck returns an error if the account of the Cryptopower wallet
+// funding a DEX order can't send amount in swaps.
+type TradeCheck func(walletID int, account int32, amount uint64) error
+
 func (dc *DEXClient) InitWithPassword(pw []byte, seed *string) error {
 	_, err := dc.InitializeClient(pw, seed)
 	return err
@@ -70,6 +77,21 @@ func (dc *DEXClient) Login(pw []byte) error {
 		return err
 	}
 	dc.loggedIn = true
+	// Orders are loaded again from the database after login.
+	dc.trades.reset()
+	dc.startTriggerOrders()
+	return nil
+}
+
+// Logout logs out of the DEX client. Pending trigger orders are not placed
+// until the next login.
+func (dc *DEXClient) Logout() error {
+	err := dc.Core.Logout()
+	if err != nil {
+		return err
+	}
+	dc.loggedIn = false
+	dc.stopTriggerOrders()
 	return nil
 }
 
@@ -83,6 +105,7 @@ func (dc *DEXClient) WaitForShutdown() <-chan struct{} {
 }
 
 func (dc *DEXClient) Shutdown() {
+	dc.stopTriggerOrders()
 	dc.cancelFn()
 }
 
@@ -157,11 +180,12 @@ func (dc *DEXClient) BondsFeeBuffer(assetID uint32) uint64 {
 	return 0
 }
 
-// Start prepares and starts the DEX client.
+// Start prepares and starts the DEX client. Orders are only placed if
+// tradeCheck, which may be nil, allows them.
 //
 // NOTE: "lang" will be changed to the default language (en) if the the DEX
 // client does not have support for it.
-func Start(ctx context.Context, root, lang, logDir, logLvl string, net libutils.NetworkType, maxLogZips int) (*DEXClient, error) {
+func Start(ctx context.Context, root, lang, logDir, logLvl string, net libutils.NetworkType, maxLogZips int, tradeCheck TradeCheck) (*DEXClient, error) {
 	dexNet, err := parseDEXNet(net)
 	if err != nil {
 		return nil, fmt.Errorf("error parsing network: %w", err)
@@ -192,7 +216,10 @@ func Start(ctx context.Context, root, lang, logDir, logLvl string, net libutils.
 		dbPath:       dbPath,
 		Core:         clientCore,
 		shutdownChan: shutdownChan,
+		triggers:     newTriggerOrders(root),
+		trades:       newTradeCache(),
 		log:          logger,
+		tradeCheck:   tradeCheck,
 	}
 
 	dc.ctx, dc.cancelFn = context.WithCancel(ctx)
@@ -204,6 +231,7 @@ func Start(ctx context.Context, root, lang, logDir, logLvl string, net libutils.
 		dc.cancelFn() // don't leak context
 		dc.Core = nil // do this after all shutdownChan listeners must've stopped waiting
 	}()
+	go dc.watchTradeUpdates(clientCore.NotificationFeed())
 
 	return dc, nil
 }
@@ -0,0 +1,55 @@
+package dexc
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	dexdb "decred.org/dcrdex/client/db"
+	bolt "go.etcd.io/bbolt"
+)
+
+// The bucket of the DEX wallets in the DEX client database and the key of
+// the wallet settings in the bucket of each wallet.
+var (
+	walletsBucket = []byte("wallets")
+	walletKey     = []byte("wallet")
+)
+
+// RemapWalletIDs replaces the Cryptopower wallet IDs in the settings of the
+// DEX wallets saved in the DEX client database at dbPath. walletIDs maps the
+// saved IDs to the new ones. The database must not be open.
+func RemapWalletIDs(dbPath string, walletIDs map[int]int) error {
+	db, err := bolt.Open(dbPath, 0o600, &bolt.Options{Timeout: time.Second})
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	return db.Update(func(tx *bolt.Tx) error {
+		wallets := tx.Bucket(walletsBucket)
+		if wallets == nil {
+			return nil
+		}
+		return wallets.ForEach(func(wid, _ []byte) error {
+			bucket := wallets.Bucket(wid)
+			if bucket == nil {
+				return nil
+			}
+			wallet, err := dexdb.DecodeWallet(bucket.Get(walletKey))
+			if err != nil {
+				return fmt.Errorf("wallet %x: %w", wid, err)
+			}
+			walletID, err := strconv.Atoi(wallet.Settings[WalletIDConfigKey])
+			if err != nil {
+				return nil
+			}
+			newID, ok := walletIDs[walletID]
+			if !ok || newID == walletID {
+				return nil
+			}
+			wallet.Settings[WalletIDConfigKey] = strconv.Itoa(newID)
+			return bucket.Put(walletKey, wallet.Encode())
+		})
+	})
+}
@@ -0,0 +1,68 @@
+package dexc
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	dexdb "decred.org/dcrdex/client/db"
+	bolt "go.etcd.io/bbolt"
+)
+
+func TestRemapWalletIDs(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), DBFileName)
+	db, err := bolt.Open(dbPath, 0o600, &bolt.Options{Timeout: time.Second})
+	if err != nil {
+		t.Fatal(err)
+	}
+	walletIDs := map[uint32]string{42: "1", 0: "2", 2: "7"}
+	err = db.Update(func(tx *bolt.Tx) error {
+		wallets, err := tx.CreateBucket(walletsBucket)
+		if err != nil {
+			return err
+		}
+		for assetID, walletID := range walletIDs {
+			wallet := &dexdb.Wallet{AssetID: assetID, Settings: map[string]string{WalletIDConfigKey: walletID}}
+			bucket, err := wallets.CreateBucket(wallet.ID())
+			if err != nil {
+				return err
+			}
+			if err := bucket.Put(walletKey, wallet.Encode()); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	db.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := RemapWalletIDs(dbPath, map[int]int{1: 3, 2: 1}); err != nil {
+		t.Fatal(err)
+	}
+
+	db, err = bolt.Open(dbPath, 0o600, &bolt.Options{ReadOnly: true, Timeout: time.Second})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	want := map[uint32]string{42: "3", 0: "1", 2: "7"}
+	err = db.View(func(tx *bolt.Tx) error {
+		wallets := tx.Bucket(walletsBucket)
+		for assetID, walletID := range want {
+			wid := (&dexdb.Wallet{AssetID: assetID}).ID()
+			wallet, err := dexdb.DecodeWallet(wallets.Bucket(wid).Get(walletKey))
+			if err != nil {
+				return err
+			}
+			if got := wallet.Settings[WalletIDConfigKey]; got != walletID {
+				t.Errorf("asset %d: wallet ID %s, want %s", assetID, got, walletID)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
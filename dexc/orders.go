@@ -0,0 +1,89 @@
+package dexc
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+
+	"decred.org/dcrdex/client/core"
+	"decred.org/dcrdex/dex/calc"
+	"decred.org/dcrdex/dex/order"
+)
+
+// Trade places the order described by form if the trade check the client was
+// started with allows it. The swaps of an order pay new contract addresses
+// and can't be held back once it is matched, so the spending policy of the
+// funding wallet account is checked before the order is placed.
+func (dc *DEXClient) Trade(pw []byte, form *core.TradeForm) (*core.Order, error) {
+	if err := dc.checkTrade(form); err != nil {
+		return nil, err
+	}
+	return dc.Core.Trade(pw, form)
+}
+
+func (dc *DEXClient) checkTrade(form *core.TradeForm) error {
+	if dc.tradeCheck == nil {
+		return nil
+	}
+
+	// The quantity of market buy orders is in units of the quote asset.
+	assetID, amount := form.Quote, form.Qty
+	switch {
+	case form.Sell:
+		assetID = form.Base
+	case form.IsLimit:
+		amount = calc.BaseToQuote(form.Rate, form.Qty)
+	}
+
+	settings, err := dc.WalletSettings(assetID)
+	if err != nil {
+		return err
+	}
+	walletID, err := strconv.Atoi(settings[WalletIDConfigKey])
+	if err != nil {
+		// Not a Cryptopower wallet.
+		return nil
+	}
+	account, err := strconv.ParseInt(settings[WalletAccountNumberConfigKey], 10, 32)
+	if err != nil {
+		return fmt.Errorf("error parsing account number: %w", err)
+	}
+	return dc.tradeCheck(walletID, int32(account), amount)
+}
+
+// CancelAll requests the cancellation of all standing limit orders placed on
+// the DEX server at host. If market is not nil, only orders of that market are
+// canceled. The number of orders for which cancellation was requested is
+// returned along with any errors encountered canceling the other orders.
+func (dc *DEXClient) CancelAll(host string, market *TradeMarket) (int, error) {
+	activeOrders, _, err := dc.ActiveOrders()
+	if err != nil {
+		return 0, fmt.Errorf("error retrieving active orders: %w", err)
+	}
+
+	var canceled int
+	var errs []error
+	for _, ord := range activeOrders[host] {
+		if market != nil && (ord.BaseID != market.BaseID || ord.QuoteID != market.QuoteID) {
+			continue
+		}
+		if !cancelable(ord) {
+			continue
+		}
+
+		if err := dc.Cancel(ord.ID); err != nil {
+			errs = append(errs, fmt.Errorf("error canceling order %s: %w", ord.ID, err))
+			continue
+		}
+		canceled++
+	}
+
+	return canceled, errors.Join(errs...)
+}
+
+// cancelable returns true if ord is a standing limit order that is not yet
+// being canceled.
+func cancelable(ord *core.Order) bool {
+	return ord.Type == order.LimitOrderType && ord.TimeInForce == order.StandingTiF &&
+		ord.Status <= order.OrderStatusBooked && !ord.Cancelling && len(ord.ID) > 0
+}
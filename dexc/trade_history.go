@@ -0,0 +1,587 @@
+package dexc
+
+import (
+	"encoding/csv"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"decred.org/dcrdex/client/asset"
+	"decred.org/dcrdex/client/core"
+	"decred.org/dcrdex/dex"
+	"decred.org/dcrdex/dex/calc"
+	"decred.org/dcrdex/dex/order"
+)
+
+// ExportFormat is a file format that trade history can be exported to.
+type ExportFormat string
+
+const (
+	ExportFormatCSV  ExportFormat = "csv"
+	ExportFormatJSON ExportFormat = "json"
+)
+
+// DefaultTradeHistoryPageSize is the number of trades returned per page if a
+// TradeHistoryFilter does not specify a page size.
+const DefaultTradeHistoryPageSize = 20
+
+// ordersBatchSize is the number of orders requested from the DEX client at a
+// time when loading the trade history.
+const ordersBatchSize = 100
+
+// TradeMarket identifies a market by its base and quote asset IDs.
+type TradeMarket struct {
+	BaseID  uint32
+	QuoteID uint32
+}
+
+// TradeHistoryFilter selects the trades returned by TradeHistory. Empty fields
+// do not restrict the results.
+type TradeHistoryFilter struct {
+	Hosts    []string
+	Market   *TradeMarket
+	Statuses []order.OrderStatus
+	// From and To restrict trades to those submitted within the time range.
+	From time.Time
+	To   time.Time
+	// Page is the zero-based index of the page to return. Pagination is
+	// ignored when exporting trades or computing profit and loss.
+	Page     int
+	PageSize int
+}
+
+// MatchDetails describes a match of a trade along with the transactions
+// created to settle it.
+type MatchDetails struct {
+	MatchID string    `json:"matchID"`
+	Status  string    `json:"status"`
+	Side    string    `json:"side"`
+	Time    time.Time `json:"time"`
+	// Rate is the match rate in atomic units as used by the DEX.
+	Rate uint64 `json:"rate"`
+	// BaseQty and QuoteQty are the atomic amounts of the base and quote
+	// assets exchanged in the match.
+	BaseQty  uint64 `json:"baseQty"`
+	QuoteQty uint64 `json:"quoteQty"`
+
+	SwapTxID          string `json:"swapTxID,omitempty"`
+	CounterSwapTxID   string `json:"counterSwapTxID,omitempty"`
+	RedeemTxID        string `json:"redeemTxID,omitempty"`
+	CounterRedeemTxID string `json:"counterRedeemTxID,omitempty"`
+	RefundTxID        string `json:"refundTxID,omitempty"`
+
+	// SwapFees and RedeemFees are the match's share of the fees paid by the
+	// order, prorated by the quantity matched. Swap fees are paid in the
+	// asset sold and redeem fees in the asset bought.
+	SwapFees   uint64 `json:"swapFees"`
+	RedeemFees uint64 `json:"redeemFees"`
+
+	// Settled is true if the match was redeemed. Refunded is true if the
+	// swap was refunded.
+	Settled  bool `json:"settled"`
+	Refunded bool `json:"refunded"`
+}
+
+// TradeRecord is an order placed on a DEX server and the details of its
+// matches.
+type TradeRecord struct {
+	*core.Order
+	Time         time.Time       `json:"time"`
+	MatchDetails []*MatchDetails `json:"matchDetails"`
+}
+
+// FromAssetID returns the ID of the asset sold by the trade.
+func (t *TradeRecord) FromAssetID() uint32 {
+	if t.Sell {
+		return t.BaseID
+	}
+	return t.QuoteID
+}
+
+// ToAssetID returns the ID of the asset bought by the trade.
+func (t *TradeRecord) ToAssetID() uint32 {
+	if t.Sell {
+		return t.QuoteID
+	}
+	return t.BaseID
+}
+
+// TradeHistory is a page of trades.
+type TradeHistory struct {
+	Trades []*TradeRecord
+	// Total is the number of trades matching the filter across all pages.
+	Total    int
+	Page     int
+	PageSize int
+}
+
+// HasNextPage returns true if there are trades after this page.
+func (h *TradeHistory) HasNextPage() bool {
+	return (h.Page+1)*h.PageSize < h.Total
+}
+
+// MarketPnL is the profit and loss of the settled matches of a market on a DEX
+// server. All amounts are in atomic units.
+type MarketPnL struct {
+	Host        string `json:"host"`
+	MarketID    string `json:"market"`
+	BaseID      uint32 `json:"baseID"`
+	QuoteID     uint32 `json:"quoteID"`
+	BaseSymbol  string `json:"baseSymbol"`
+	QuoteSymbol string `json:"quoteSymbol"`
+	Trades      int    `json:"trades"`
+
+	BaseBought    uint64 `json:"baseBought"`
+	BaseSold      uint64 `json:"baseSold"`
+	QuoteSpent    uint64 `json:"quoteSpent"`
+	QuoteReceived uint64 `json:"quoteReceived"`
+	// BaseFees and QuoteFees are the swap and redeem fees paid in the base
+	// and quote assets.
+	BaseFees  uint64 `json:"baseFees"`
+	QuoteFees uint64 `json:"quoteFees"`
+}
+
+// RealizedPnL returns the realized profit or loss in atomic units of the quote
+// asset. It is the difference between the average sell and buy rates applied
+// to the base quantity that was both bought and sold, excluding fees.
+func (p *MarketPnL) RealizedPnL() int64 {
+	if p.BaseBought == 0 || p.BaseSold == 0 {
+		return 0
+	}
+
+	closedQty := min(p.BaseBought, p.BaseSold)
+	avgBuyRate := float64(p.QuoteSpent) / float64(p.BaseBought)
+	avgSellRate := float64(p.QuoteReceived) / float64(p.BaseSold)
+	return int64((avgSellRate - avgBuyRate) * float64(closedQty))
+}
+
+// TradeHistory returns the page of trades that match filter, newest first.
+func (dc *DEXClient) TradeHistory(filter *TradeHistoryFilter) (*TradeHistory, error) {
+	if filter == nil {
+		filter = new(TradeHistoryFilter)
+	}
+
+	trades, err := dc.filteredTrades(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	history := &TradeHistory{
+		Total:    len(trades),
+		Page:     filter.Page,
+		PageSize: filter.PageSize,
+	}
+	if history.PageSize <= 0 {
+		history.PageSize = DefaultTradeHistoryPageSize
+	}
+
+	start := history.Page * history.PageSize
+	if start < 0 || start >= len(trades) {
+		return history, nil
+	}
+	end := min(start+history.PageSize, len(trades))
+	history.Trades = trades[start:end]
+	return history, nil
+}
+
+// TradePnL returns the profit and loss of each market with trades that match
+// filter.
+func (dc *DEXClient) TradePnL(filter *TradeHistoryFilter) ([]*MarketPnL, error) {
+	trades, err := dc.filteredTrades(filter)
+	if err != nil {
+		return nil, err
+	}
+	return MarketsPnL(trades), nil
+}
+
+// ExportTradeHistory writes the trades that match filter to w in the provided
+// format. The CSV format has one row per match and uses conventional units
+// suitable for tax reporting. The JSON format includes the full order
+// details.
+func (dc *DEXClient) ExportTradeHistory(w io.Writer, format ExportFormat, filter *TradeHistoryFilter) error {
+	trades, err := dc.filteredTrades(filter)
+	if err != nil {
+		return err
+	}
+
+	switch format {
+	case ExportFormatCSV:
+		return WriteTradesCSV(w, trades)
+	case ExportFormatJSON:
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(trades)
+	default:
+		return fmt.Errorf("unsupported export format %q", format)
+	}
+}
+
+// filteredTrades returns all trades that match filter, newest first.
+func (dc *DEXClient) filteredTrades(filter *TradeHistoryFilter) ([]*TradeRecord, error) {
+	if filter == nil {
+		filter = new(TradeHistoryFilter)
+	}
+
+	allTrades, err := dc.trades.all(dc)
+	if err != nil {
+		return nil, err
+	}
+
+	var trades []*TradeRecord
+	for _, trade := range allTrades {
+		if filter.matches(trade) {
+			trades = append(trades, trade)
+		}
+	}
+
+	sort.SliceStable(trades, func(i, j int) bool {
+		return trades[i].Time.After(trades[j].Time)
+	})
+	return trades, nil
+}
+
+// matches returns true if trade is selected by the filter.
+func (filter *TradeHistoryFilter) matches(trade *TradeRecord) bool {
+	if len(filter.Hosts) > 0 && !slices.Contains(filter.Hosts, trade.Host) {
+		return false
+	}
+	if filter.Market != nil && (trade.BaseID != filter.Market.BaseID || trade.QuoteID != filter.Market.QuoteID) {
+		return false
+	}
+	if len(filter.Statuses) > 0 && !slices.Contains(filter.Statuses, trade.Status) {
+		return false
+	}
+	if !filter.From.IsZero() && trade.Time.Before(filter.From) {
+		return false
+	}
+	if !filter.To.IsZero() && trade.Time.After(filter.To) {
+		return false
+	}
+	return true
+}
+
+// tradeCache holds the trade records of every order so that each page of the
+// trade history doesn't reload all orders from the DEX client database. The
+// orders are read once and then kept up to date from the DEX client
+// notifications.
+type tradeCache struct {
+	mtx    sync.Mutex
+	loaded bool
+	trades map[string]*TradeRecord
+	// stale are the IDs of orders with new matches that are reloaded the
+	// next time the trades are read.
+	stale map[string]bool
+}
+
+func newTradeCache() *tradeCache {
+	return &tradeCache{
+		trades: make(map[string]*TradeRecord),
+		stale:  make(map[string]bool),
+	}
+}
+
+// all returns the cached trades in no particular order, loading all orders
+// from the DEX client database on first use.
+func (tc *tradeCache) all(dc *DEXClient) ([]*TradeRecord, error) {
+	tc.mtx.Lock()
+	defer tc.mtx.Unlock()
+
+	if !tc.loaded {
+		orderFilter := &core.OrderFilter{N: ordersBatchSize}
+		for {
+			orders, err := dc.Orders(orderFilter)
+			if err != nil {
+				return nil, fmt.Errorf("error retrieving orders: %w", err)
+			}
+
+			for _, ord := range orders {
+				tc.trades[ord.ID.String()] = newTradeRecord(ord)
+			}
+
+			if len(orders) < ordersBatchSize {
+				break
+			}
+			orderFilter.Offset = orders[len(orders)-1].ID
+		}
+		tc.loaded = true
+		clear(tc.stale)
+	}
+
+	for oid := range tc.stale {
+		oidB, err := hex.DecodeString(oid)
+		if err != nil {
+			return nil, err
+		}
+		ord, err := dc.Order(oidB)
+		if err != nil {
+			return nil, fmt.Errorf("error retrieving order %s: %w", oid, err)
+		}
+		tc.trades[oid] = newTradeRecord(ord)
+		delete(tc.stale, oid)
+	}
+
+	trades := make([]*TradeRecord, 0, len(tc.trades))
+	for _, trade := range tc.trades {
+		trades = append(trades, trade)
+	}
+	return trades, nil
+}
+
+// update applies an order or match notification to the cached trades. Nothing
+// is done until the trades are loaded.
+func (tc *tradeCache) update(note core.Notification) {
+	tc.mtx.Lock()
+	defer tc.mtx.Unlock()
+	if !tc.loaded {
+		return
+	}
+
+	switch n := note.(type) {
+	case *core.OrderNote:
+		// In-flight orders don't have an ID until the server accepts them.
+		if n.Order == nil || len(n.Order.ID) == 0 || n.Order.Type == order.CancelOrderType {
+			return
+		}
+		oid := n.Order.ID.String()
+		tc.trades[oid] = newTradeRecord(n.Order)
+		delete(tc.stale, oid)
+	case *core.MatchNote:
+		tc.stale[n.OrderID.String()] = true
+	}
+}
+
+// reset drops the cached trades so that they are loaded again.
+func (tc *tradeCache) reset() {
+	tc.mtx.Lock()
+	defer tc.mtx.Unlock()
+	tc.loaded = false
+	clear(tc.trades)
+	clear(tc.stale)
+}
+
+// watchTradeUpdates keeps the trade cache up to date until the DEX client is
+// shut down. It MUST be run in a goroutine.
+func (dc *DEXClient) watchTradeUpdates(feed *core.NoteFeed) {
+	defer feed.ReturnFeed()
+	for {
+		select {
+		case <-dc.ctx.Done():
+			return
+		case note, ok := <-feed.C:
+			if !ok {
+				return
+			}
+			dc.trades.update(note)
+		}
+	}
+}
+
+// newTradeRecord creates a TradeRecord for ord.
+func newTradeRecord(ord *core.Order) *TradeRecord {
+	trade := &TradeRecord{
+		Order: ord,
+		Time:  time.UnixMilli(int64(ord.SubmitTime)),
+	}
+
+	var swapFees, redeemFees uint64
+	if ord.FeesPaid != nil {
+		swapFees, redeemFees = ord.FeesPaid.Swap, ord.FeesPaid.Redemption
+	}
+
+	for _, match := range ord.Matches {
+		if match.IsCancel {
+			continue
+		}
+
+		details := &MatchDetails{
+			MatchID:           match.MatchID.String(),
+			Status:            match.Status.String(),
+			Side:              match.Side.String(),
+			Time:              time.UnixMilli(int64(match.Stamp)),
+			Rate:              match.Rate,
+			BaseQty:           match.Qty,
+			QuoteQty:          calc.BaseToQuote(match.Rate, match.Qty),
+			SwapTxID:          coinID(match.Swap),
+			CounterSwapTxID:   coinID(match.CounterSwap),
+			RedeemTxID:        coinID(match.Redeem),
+			CounterRedeemTxID: coinID(match.CounterRedeem),
+			RefundTxID:        coinID(match.Refund),
+			Refunded:          match.Refund != nil,
+		}
+		details.Settled = !details.Refunded && (match.Redeem != nil || match.Status >= order.MatchComplete)
+
+		if ord.Filled > 0 {
+			share := float64(match.Qty) / float64(ord.Filled)
+			details.SwapFees = uint64(float64(swapFees) * share)
+			details.RedeemFees = uint64(float64(redeemFees) * share)
+		}
+
+		trade.MatchDetails = append(trade.MatchDetails, details)
+	}
+
+	return trade
+}
+
+func coinID(coin *core.Coin) string {
+	if coin == nil {
+		return ""
+	}
+	return coin.StringID
+}
+
+// MarketsPnL computes the profit and loss of the settled matches of trades,
+// grouped by host and market. The result is sorted by host and market.
+func MarketsPnL(trades []*TradeRecord) []*MarketPnL {
+	pnls := make(map[string]*MarketPnL)
+	for _, trade := range trades {
+		key := trade.Host + "/" + trade.MarketID
+		pnl, ok := pnls[key]
+		if !ok {
+			pnl = &MarketPnL{
+				Host:        trade.Host,
+				MarketID:    trade.MarketID,
+				BaseID:      trade.BaseID,
+				QuoteID:     trade.QuoteID,
+				BaseSymbol:  trade.BaseSymbol,
+				QuoteSymbol: trade.QuoteSymbol,
+			}
+		}
+
+		var settled bool
+		for _, match := range trade.MatchDetails {
+			if !match.Settled {
+				continue
+			}
+
+			settled = true
+			if trade.Sell {
+				pnl.BaseSold += match.BaseQty
+				pnl.QuoteReceived += match.QuoteQty
+				pnl.BaseFees += match.SwapFees
+				pnl.QuoteFees += match.RedeemFees
+			} else {
+				pnl.BaseBought += match.BaseQty
+				pnl.QuoteSpent += match.QuoteQty
+				pnl.QuoteFees += match.SwapFees
+				pnl.BaseFees += match.RedeemFees
+			}
+		}
+
+		if settled {
+			pnl.Trades++
+			pnls[key] = pnl
+		}
+	}
+
+	result := make([]*MarketPnL, 0, len(pnls))
+	for _, pnl := range pnls {
+		result = append(result, pnl)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Host != result[j].Host {
+			return result[i].Host < result[j].Host
+		}
+		return result[i].MarketID < result[j].MarketID
+	})
+	return result
+}
+
+// tradesCSVHeader are the columns of trade history CSV exports.
+var tradesCSVHeader = []string{
+	"Time", "Host", "Market", "Order ID", "Order Type", "Side", "Match ID",
+	"Match Status", "Rate", "Base Amount", "Base Asset", "Quote Amount",
+	"Quote Asset", "Swap Tx", "Counter Swap Tx", "Redeem Tx",
+	"Counter Redeem Tx", "Refund Tx", "Swap Fee", "Swap Fee Asset",
+	"Redeem Fee", "Redeem Fee Asset",
+}
+
+// WriteTradesCSV writes one row per match of trades to w. Amounts are in
+// conventional units.
+func WriteTradesCSV(w io.Writer, trades []*TradeRecord) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(tradesCSVHeader); err != nil {
+		return err
+	}
+
+	for _, trade := range trades {
+		baseUnitInfo, quoteUnitInfo := unitInfo(trade.BaseID, trade.BaseSymbol), unitInfo(trade.QuoteID, trade.QuoteSymbol)
+		fromSymbol, toSymbol := unitInfo(trade.FromAssetID(), "").Conventional.Unit, unitInfo(trade.ToAssetID(), "").Conventional.Unit
+		fromFactor, toFactor := baseUnitInfo.Conventional.ConversionFactor, quoteUnitInfo.Conventional.ConversionFactor
+		if !trade.Sell {
+			fromFactor, toFactor = toFactor, fromFactor
+		}
+
+		side := "buy"
+		if trade.Sell {
+			side = "sell"
+		}
+
+		for _, match := range trade.MatchDetails {
+			rate := calc.ConventionalRate(match.Rate, baseUnitInfo, quoteUnitInfo)
+			err := cw.Write([]string{
+				match.Time.UTC().Format(time.RFC3339),
+				trade.Host,
+				trade.MarketID,
+				trade.ID.String(),
+				trade.Type.String(),
+				side,
+				match.MatchID,
+				match.Status,
+				formatFloat(rate),
+				formatAtoms(match.BaseQty, baseUnitInfo.Conventional.ConversionFactor),
+				baseUnitInfo.Conventional.Unit,
+				formatAtoms(match.QuoteQty, quoteUnitInfo.Conventional.ConversionFactor),
+				quoteUnitInfo.Conventional.Unit,
+				match.SwapTxID,
+				match.CounterSwapTxID,
+				match.RedeemTxID,
+				match.CounterRedeemTxID,
+				match.RefundTxID,
+				formatAtoms(match.SwapFees, fromFactor),
+				fromSymbol,
+				formatAtoms(match.RedeemFees, toFactor),
+				toSymbol,
+			})
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+// unitInfo returns the unit info of assetID. Assets unknown to the DEX client
+// are assumed to use 1e8 atoms per conventional unit.
+func unitInfo(assetID uint32, symbol string) dex.UnitInfo {
+	ui, err := asset.UnitInfo(assetID)
+	if err == nil {
+		return ui
+	}
+
+	if symbol == "" {
+		symbol = dex.BipIDSymbol(assetID)
+	}
+	return dex.UnitInfo{
+		AtomicUnit: "atoms",
+		Conventional: dex.Denomination{
+			Unit:             symbol,
+			ConversionFactor: 1e8,
+		},
+	}
+}
+
+func formatAtoms(atoms, conversionFactor uint64) string {
+	return formatFloat(float64(atoms) / float64(conversionFactor))
+}
+
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
@@ -0,0 +1,171 @@
+package dexc
+
+import (
+	"bytes"
+	"encoding/csv"
+	"testing"
+	"time"
+
+	"decred.org/dcrdex/client/core"
+	"decred.org/dcrdex/dex"
+	"decred.org/dcrdex/dex/calc"
+	"decred.org/dcrdex/dex/order"
+)
+
+func testTrade(host string, sell bool, rate, qty uint64, status order.MatchStatus, refunded bool) *TradeRecord {
+	match := &core.Match{
+		Rate:   rate,
+		Qty:    qty,
+		Status: status,
+		Swap:   &core.Coin{StringID: "swap"},
+	}
+	if refunded {
+		match.Refund = &core.Coin{StringID: "refund"}
+	}
+
+	return newTradeRecord(&core.Order{
+		Host:        host,
+		BaseID:      42,
+		BaseSymbol:  "dcr",
+		QuoteID:     0,
+		QuoteSymbol: "btc",
+		MarketID:    "dcr_btc",
+		Sell:        sell,
+		Qty:         qty,
+		Filled:      qty,
+		Matches:     []*core.Match{match},
+		FeesPaid:    &core.FeeBreakdown{Swap: 1000, Redemption: 500},
+	})
+}
+
+func TestMarketsPnL(t *testing.T) {
+	const buyRate, sellRate = calc.RateEncodingFactor / 100, calc.RateEncodingFactor / 50
+	trades := []*TradeRecord{
+		testTrade("a.org", false, buyRate, 10e8, order.MatchComplete, false),
+		testTrade("a.org", true, sellRate, 5e8, order.MatchConfirmed, false),
+		testTrade("a.org", true, sellRate, 5e8, order.MakerSwapCast, false),
+		testTrade("a.org", true, sellRate, 5e8, order.MakerSwapCast, true),
+		testTrade("b.org", false, buyRate, 1e8, order.MatchComplete, false),
+	}
+
+	pnls := MarketsPnL(trades)
+	if len(pnls) != 2 {
+		t.Fatalf("expected 2 markets, got %d", len(pnls))
+	}
+
+	pnl := pnls[0]
+	if pnl.Host != "a.org" || pnl.Trades != 2 {
+		t.Fatalf("unexpected market %s with %d trades", pnl.Host, pnl.Trades)
+	}
+	if pnl.BaseBought != 10e8 || pnl.BaseSold != 5e8 {
+		t.Fatalf("unexpected base amounts: bought %d, sold %d", pnl.BaseBought, pnl.BaseSold)
+	}
+	if pnl.QuoteSpent != 1e7 || pnl.QuoteReceived != 1e7 {
+		t.Fatalf("unexpected quote amounts: spent %d, received %d", pnl.QuoteSpent, pnl.QuoteReceived)
+	}
+	// Bought at 0.01 and sold 5 DCR at 0.02.
+	if got := pnl.RealizedPnL(); got != 5e6 {
+		t.Fatalf("expected realized pnl of 5e6, got %d", got)
+	}
+	if pnl.QuoteFees != 1500 || pnl.BaseFees != 1500 {
+		t.Fatalf("unexpected fees: base %d, quote %d", pnl.BaseFees, pnl.QuoteFees)
+	}
+
+	if got := pnls[1].RealizedPnL(); got != 0 {
+		t.Fatalf("expected no realized pnl without sells, got %d", got)
+	}
+}
+
+func TestWriteTradesCSV(t *testing.T) {
+	trades := []*TradeRecord{
+		testTrade("a.org", true, calc.RateEncodingFactor/50, 5e8, order.MatchComplete, false),
+	}
+
+	var buf bytes.Buffer
+	if err := WriteTradesCSV(&buf, trades); err != nil {
+		t.Fatal(err)
+	}
+
+	records, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != 2 {
+		t.Fatalf("expected header and 1 row, got %d records", len(records))
+	}
+
+	row := records[1]
+	if row[5] != "sell" || row[8] != "0.02" || row[9] != "5" || row[11] != "0.1" || row[13] != "swap" {
+		t.Fatalf("unexpected row %v", row)
+	}
+}
+
+func TestTradeHistoryFilterMatches(t *testing.T) {
+	trade := testTrade("a.org", true, calc.RateEncodingFactor/50, 5e8, order.MatchComplete, false)
+	trade.Status = order.OrderStatusExecuted
+	trade.Time = time.Unix(1700000000, 0)
+
+	tests := []struct {
+		name   string
+		filter *TradeHistoryFilter
+		want   bool
+	}{
+		{"empty", &TradeHistoryFilter{}, true},
+		{"host", &TradeHistoryFilter{Hosts: []string{"b.org", "a.org"}}, true},
+		{"other host", &TradeHistoryFilter{Hosts: []string{"b.org"}}, false},
+		{"market", &TradeHistoryFilter{Market: &TradeMarket{BaseID: 42, QuoteID: 0}}, true},
+		{"other market", &TradeHistoryFilter{Market: &TradeMarket{BaseID: 0, QuoteID: 42}}, false},
+		{"status", &TradeHistoryFilter{Statuses: []order.OrderStatus{order.OrderStatusExecuted}}, true},
+		{"other status", &TradeHistoryFilter{Statuses: []order.OrderStatus{order.OrderStatusBooked}}, false},
+		{"in range", &TradeHistoryFilter{From: trade.Time.Add(-time.Hour), To: trade.Time.Add(time.Hour)}, true},
+		{"before range", &TradeHistoryFilter{From: trade.Time.Add(time.Hour)}, false},
+		{"after range", &TradeHistoryFilter{To: trade.Time.Add(-time.Hour)}, false},
+	}
+
+	for _, test := range tests {
+		if got := test.filter.matches(trade); got != test.want {
+			t.Errorf("%s: expected %v, got %v", test.name, test.want, got)
+		}
+	}
+}
+
+func TestTradeCacheUpdate(t *testing.T) {
+	tc := newTradeCache()
+	ord := &core.Order{ID: dex.Bytes{1}, Host: "a.org", Status: order.OrderStatusBooked}
+
+	// Notifications are ignored until the trades are loaded.
+	tc.update(&core.OrderNote{Order: ord})
+	if len(tc.trades) != 0 {
+		t.Fatalf("expected no cached trades before loading, got %d", len(tc.trades))
+	}
+
+	tc.loaded = true
+	tc.update(&core.OrderNote{Order: ord})
+	if trade := tc.trades[ord.ID.String()]; trade == nil || trade.Status != order.OrderStatusBooked {
+		t.Fatalf("order note was not cached")
+	}
+
+	// In-flight orders have no ID yet.
+	tc.update(&core.OrderNote{Order: &core.Order{Host: "a.org"}})
+	if len(tc.trades) != 1 {
+		t.Fatalf("expected 1 cached trade, got %d", len(tc.trades))
+	}
+
+	tc.update(&core.MatchNote{OrderID: ord.ID})
+	if !tc.stale[ord.ID.String()] {
+		t.Fatalf("match note did not mark the order as stale")
+	}
+
+	// A newer order note replaces the stale record.
+	executed := *ord
+	executed.Status = order.OrderStatusExecuted
+	tc.update(&core.OrderNote{Order: &executed})
+	if tc.stale[ord.ID.String()] || tc.trades[ord.ID.String()].Status != order.OrderStatusExecuted {
+		t.Fatalf("order note did not refresh the stale order")
+	}
+
+	tc.reset()
+	if tc.loaded || len(tc.trades) != 0 {
+		t.Fatalf("reset did not drop the cached trades")
+	}
+}
@@ -0,0 +1,461 @@
+package dexc
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"decred.org/dcrdex/client/core"
+	"decred.org/dcrdex/client/orderbook"
+	"decred.org/dcrdex/dex/encode"
+)
+
+// TriggerOrdersFileName is the file that client-side trigger orders are
+// persisted to, in the same directory as the DEX client database.
+const TriggerOrdersFileName = "trigger_orders.json"
+
+// TriggerType is the kind of price movement that places a trigger order.
+type TriggerType int
+
+const (
+	// TriggerStopLoss places the order when the price moves against the
+	// trade direction past the trigger rate, i.e. the best bid falls to the
+	// trigger rate for sells or the best ask rises to the trigger rate for
+	// buys.
+	TriggerStopLoss TriggerType = iota
+	// TriggerTakeProfit places the order when the price moves in favour of
+	// the trade direction past the trigger rate, i.e. the best bid rises to
+	// the trigger rate for sells or the best ask falls to the trigger rate
+	// for buys.
+	TriggerTakeProfit
+)
+
+// String returns a human-readable representation of the trigger type.
+func (t TriggerType) String() string {
+	switch t {
+	case TriggerStopLoss:
+		return "Stop-loss"
+	case TriggerTakeProfit:
+		return "Take-profit"
+	default:
+		return "Unknown"
+	}
+}
+
+// TriggerStatus is the state of a trigger order.
+type TriggerStatus int
+
+const (
+	// TriggerStatusPending is a trigger order whose trigger rate has not been
+	// reached.
+	TriggerStatusPending TriggerStatus = iota
+	// TriggerStatusPlaced is a trigger order that was triggered and
+	// submitted to the DEX server.
+	TriggerStatusPlaced
+	// TriggerStatusFailed is a trigger order that was triggered but could
+	// not be submitted to the DEX server.
+	TriggerStatusFailed
+)
+
+// String returns a human-readable representation of the trigger status.
+func (s TriggerStatus) String() string {
+	switch s {
+	case TriggerStatusPending:
+		return "Pending"
+	case TriggerStatusPlaced:
+		return "Placed"
+	case TriggerStatusFailed:
+		return "Failed"
+	default:
+		return "Unknown"
+	}
+}
+
+// TriggerOrder is an order that is held by the client and only submitted to
+// the DEX server once the market reaches the trigger rate.
+type TriggerOrder struct {
+	ID   string      `json:"id"`
+	Type TriggerType `json:"type"`
+	// TriggerRate is the message rate of the market's best opposing order
+	// at which the order is placed.
+	TriggerRate uint64          `json:"triggerRate"`
+	Form        *core.TradeForm `json:"form"`
+	CreatedAt   time.Time       `json:"createdAt"`
+	Status      TriggerStatus   `json:"status"`
+	// OrderID is the ID of the order submitted once triggered.
+	OrderID     string    `json:"orderID,omitempty"`
+	TriggeredAt time.Time `json:"triggeredAt,omitempty"`
+	Error       string    `json:"error,omitempty"`
+}
+
+// Triggered returns true if the order should be placed given the best bid and
+// ask rates of the market. A zero rate means that side of the book is empty.
+func (t *TriggerOrder) Triggered(bestBid, bestAsk uint64) bool {
+	// Sells fill against bids and buys fill against asks.
+	rate := bestAsk
+	if t.Form.Sell {
+		rate = bestBid
+	}
+	if rate == 0 {
+		return false
+	}
+
+	// A sell stop-loss and buy take-profit trigger when the rate falls.
+	falling := t.Form.Sell == (t.Type == TriggerStopLoss)
+	if falling {
+		return rate <= t.TriggerRate
+	}
+	return rate >= t.TriggerRate
+}
+
+// marketKey identifies the market of the order.
+func (t *TriggerOrder) marketKey() string {
+	return fmt.Sprintf("%s|%d|%d", t.Form.Host, t.Form.Base, t.Form.Quote)
+}
+
+// triggerOrders holds the client-side trigger orders and watches the order
+// books of markets with pending trigger orders while the DEX client is logged
+// in.
+type triggerOrders struct {
+	mtx    sync.Mutex
+	path   string
+	loaded bool
+	orders map[string]*TriggerOrder
+	// active is true while the DEX client is logged in. Triggered orders are
+	// placed using the wallets unlocked by the login, so the app password is
+	// never held by the trigger orders.
+	active bool
+	// placing are the IDs of the triggered orders being submitted.
+	placing map[string]bool
+	// watchers are the order book feeds of markets with pending orders,
+	// keyed by market.
+	watchers map[string]*bookWatcher
+}
+
+// bookWatcher is an order book subscription used to evaluate trigger orders.
+type bookWatcher struct {
+	feed core.BookFeed
+	quit chan struct{}
+}
+
+// stop closes the feed and stops the goroutine reading from it. Closing a
+// BookFeed does not close its update channel.
+func (w *bookWatcher) stop() {
+	w.feed.Close()
+	close(w.quit)
+}
+
+func newTriggerOrders(dir string) *triggerOrders {
+	return &triggerOrders{
+		path:     filepath.Join(dir, TriggerOrdersFileName),
+		orders:   make(map[string]*TriggerOrder),
+		placing:  make(map[string]bool),
+		watchers: make(map[string]*bookWatcher),
+	}
+}
+
+// load reads persisted trigger orders. The mutex MUST be held.
+func (to *triggerOrders) load() error {
+	if to.loaded {
+		return nil
+	}
+
+	b, err := os.ReadFile(to.path)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("error reading trigger orders: %w", err)
+	}
+
+	if len(b) > 0 {
+		var orders []*TriggerOrder
+		if err := json.Unmarshal(b, &orders); err != nil {
+			return fmt.Errorf("error decoding trigger orders: %w", err)
+		}
+		for _, ord := range orders {
+			to.orders[ord.ID] = ord
+		}
+	}
+
+	to.loaded = true
+	return nil
+}
+
+// save persists the trigger orders. The mutex MUST be held.
+func (to *triggerOrders) save() error {
+	orders := make([]*TriggerOrder, 0, len(to.orders))
+	for _, ord := range to.orders {
+		orders = append(orders, ord)
+	}
+	sort.Slice(orders, func(i, j int) bool {
+		return orders[i].CreatedAt.Before(orders[j].CreatedAt)
+	})
+
+	b, err := json.MarshalIndent(orders, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	// Write to a temporary file first so a crash cannot corrupt the saved
+	// orders.
+	tmpPath := to.path + ".tmp"
+	if err := os.WriteFile(tmpPath, b, 0600); err != nil {
+		return fmt.Errorf("error writing trigger orders: %w", err)
+	}
+	return os.Rename(tmpPath, to.path)
+}
+
+// AddTriggerOrder saves an order that will be placed when the market reaches
+// triggerRate. The app password is only used to log in, the order is placed
+// while the DEX client remains logged in.
+func (dc *DEXClient) AddTriggerOrder(pw []byte, triggerType TriggerType, triggerRate uint64, form *core.TradeForm) (*TriggerOrder, error) {
+	if triggerRate == 0 {
+		return nil, errors.New("trigger rate must be greater than zero")
+	}
+	if _, err := dc.Exchange(form.Host); err != nil {
+		return nil, err
+	}
+	if err := dc.checkTrade(form); err != nil {
+		return nil, err
+	}
+	if err := dc.Login(pw); err != nil {
+		return nil, err
+	}
+
+	ord := &TriggerOrder{
+		ID:          hex.EncodeToString(encode.RandomBytes(8)),
+		Type:        triggerType,
+		TriggerRate: triggerRate,
+		Form:        form,
+		CreatedAt:   time.Now(),
+	}
+
+	to := dc.triggers
+	to.mtx.Lock()
+	defer to.mtx.Unlock()
+	if err := to.load(); err != nil {
+		return nil, err
+	}
+
+	to.orders[ord.ID] = ord
+	if err := to.save(); err != nil {
+		delete(to.orders, ord.ID)
+		return nil, err
+	}
+
+	dc.watchTriggerMarkets()
+	return ord, nil
+}
+
+// TriggerOrders returns the saved trigger orders, newest first.
+func (dc *DEXClient) TriggerOrders() ([]*TriggerOrder, error) {
+	to := dc.triggers
+	to.mtx.Lock()
+	defer to.mtx.Unlock()
+	if err := to.load(); err != nil {
+		return nil, err
+	}
+
+	orders := make([]*TriggerOrder, 0, len(to.orders))
+	for _, ord := range to.orders {
+		o := *ord
+		orders = append(orders, &o)
+	}
+	sort.Slice(orders, func(i, j int) bool {
+		return orders[i].CreatedAt.After(orders[j].CreatedAt)
+	})
+	return orders, nil
+}
+
+// RemoveTriggerOrder deletes the trigger order with the provided ID. Pending
+// orders will no longer be placed.
+func (dc *DEXClient) RemoveTriggerOrder(id string) error {
+	to := dc.triggers
+	to.mtx.Lock()
+	defer to.mtx.Unlock()
+	if err := to.load(); err != nil {
+		return err
+	}
+
+	if _, ok := to.orders[id]; !ok {
+		return fmt.Errorf("trigger order %s not found", id)
+	}
+
+	delete(to.orders, id)
+	if err := to.save(); err != nil {
+		return err
+	}
+
+	dc.watchTriggerMarkets()
+	return nil
+}
+
+// startTriggerOrders starts watching the markets of pending trigger orders.
+// It MUST be called after logging in.
+func (dc *DEXClient) startTriggerOrders() {
+	to := dc.triggers
+	to.mtx.Lock()
+	defer to.mtx.Unlock()
+	if err := to.load(); err != nil {
+		dc.log.Errorf("Trigger orders will not be placed: %v", err)
+		return
+	}
+
+	to.active = true
+	dc.watchTriggerMarkets()
+}
+
+// stopTriggerOrders stops watching markets.
+func (dc *DEXClient) stopTriggerOrders() {
+	to := dc.triggers
+	to.mtx.Lock()
+	defer to.mtx.Unlock()
+
+	for key, w := range to.watchers {
+		w.stop()
+		delete(to.watchers, key)
+	}
+	to.active = false
+}
+
+// watchTriggerMarkets subscribes to the order books of markets with pending
+// trigger orders and unsubscribes from markets without any. Nothing is watched
+// until the DEX client is logged in. The triggers mutex MUST be held.
+func (dc *DEXClient) watchTriggerMarkets() {
+	to := dc.triggers
+	if !to.active {
+		return
+	}
+
+	pendingMarkets := make(map[string]*TriggerOrder)
+	for _, ord := range to.orders {
+		if ord.Status == TriggerStatusPending {
+			pendingMarkets[ord.marketKey()] = ord
+		}
+	}
+
+	for key, w := range to.watchers {
+		if pendingMarkets[key] == nil {
+			w.stop()
+			delete(to.watchers, key)
+		}
+	}
+
+	for key, ord := range pendingMarkets {
+		if to.watchers[key] != nil {
+			continue
+		}
+
+		book, feed, err := dc.SyncBook(ord.Form.Host, ord.Form.Base, ord.Form.Quote)
+		if err != nil {
+			dc.log.Errorf("Error syncing %s order book for trigger orders: %v", key, err)
+			continue
+		}
+
+		w := &bookWatcher{feed: feed, quit: make(chan struct{})}
+		to.watchers[key] = w
+		go dc.watchTriggerMarket(key, book, w)
+	}
+}
+
+// watchTriggerMarket checks the trigger orders of a market whenever its order
+// book is updated. It MUST be run in a goroutine.
+func (dc *DEXClient) watchTriggerMarket(key string, book *orderbook.OrderBook, w *bookWatcher) {
+	// Check the book as synced in case the trigger rate was already reached.
+	dc.checkTriggerOrders(key, book)
+	for {
+		select {
+		case <-dc.ctx.Done():
+			return
+		case <-w.quit:
+			return
+		case _, ok := <-w.feed.Next():
+			if !ok {
+				return
+			}
+			dc.checkTriggerOrders(key, book)
+		}
+	}
+}
+
+// checkTriggerOrders places the pending orders of a market that have reached
+// their trigger rate. The orders are placed without holding the triggers mutex
+// because submitting an order can take a while.
+func (dc *DEXClient) checkTriggerOrders(key string, book *orderbook.OrderBook) {
+	bestRate := func(sell bool) uint64 {
+		orders, _, err := book.BestNOrders(1, sell)
+		if err != nil || len(orders) == 0 {
+			return 0
+		}
+		return orders[0].Rate
+	}
+	bestBid, bestAsk := bestRate(false), bestRate(true)
+
+	to := dc.triggers
+	to.mtx.Lock()
+	if !to.active {
+		to.mtx.Unlock()
+		return
+	}
+
+	var triggered []*TriggerOrder
+	for _, ord := range to.orders {
+		if ord.Status != TriggerStatusPending || to.placing[ord.ID] || ord.marketKey() != key || !ord.Triggered(bestBid, bestAsk) {
+			continue
+		}
+		to.placing[ord.ID] = true
+		triggered = append(triggered, ord)
+	}
+	to.mtx.Unlock()
+
+	if len(triggered) == 0 {
+		return
+	}
+
+	type tradeResult struct {
+		orderID     string
+		err         error
+		triggeredAt time.Time
+	}
+	results := make(map[string]*tradeResult, len(triggered))
+	for _, ord := range triggered {
+		res := &tradeResult{triggeredAt: time.Now()}
+		results[ord.ID] = res
+		// The orders are placed with the wallets unlocked at login. The trade
+		// fails if a wallet was locked since, and the failure is recorded.
+		placed, err := dc.Trade(nil, ord.Form)
+		if err != nil {
+			dc.log.Errorf("Error placing %s trigger order %s: %v", ord.Type, ord.ID, err)
+			res.err = err
+			continue
+		}
+		dc.log.Infof("Placed %s trigger order %s as order %s", ord.Type, ord.ID, placed.ID)
+		res.orderID = placed.ID.String()
+	}
+
+	to.mtx.Lock()
+	defer to.mtx.Unlock()
+	for id, res := range results {
+		delete(to.placing, id)
+		ord, ok := to.orders[id]
+		if !ok {
+			continue // removed while being placed
+		}
+
+		ord.TriggeredAt = res.triggeredAt
+		if res.err != nil {
+			ord.Status, ord.Error = TriggerStatusFailed, res.err.Error()
+		} else {
+			ord.Status, ord.OrderID = TriggerStatusPlaced, res.orderID
+		}
+	}
+
+	if err := to.save(); err != nil {
+		dc.log.Errorf("Error saving trigger orders: %v", err)
+	}
+	dc.watchTriggerMarkets()
+}
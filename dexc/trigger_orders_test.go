@@ -0,0 +1,41 @@
+package dexc
+
+import (
+	"testing"
+
+	"decred.org/dcrdex/client/core"
+)
+
+func TestTriggerOrderTriggered(t *testing.T) {
+	const triggerRate = 100
+
+	tests := []struct {
+		name             string
+		triggerType      TriggerType
+		sell             bool
+		bestBid, bestAsk uint64
+		want             bool
+	}{
+		{"sell stop-loss above rate", TriggerStopLoss, true, 101, 90, false},
+		{"sell stop-loss at rate", TriggerStopLoss, true, 100, 120, true},
+		{"sell stop-loss empty bids", TriggerStopLoss, true, 0, 90, false},
+		{"sell take-profit below rate", TriggerTakeProfit, true, 99, 120, false},
+		{"sell take-profit above rate", TriggerTakeProfit, true, 110, 120, true},
+		{"buy stop-loss below rate", TriggerStopLoss, false, 110, 99, false},
+		{"buy stop-loss above rate", TriggerStopLoss, false, 90, 101, true},
+		{"buy take-profit above rate", TriggerTakeProfit, false, 90, 101, false},
+		{"buy take-profit at rate", TriggerTakeProfit, false, 110, 100, true},
+		{"buy take-profit empty asks", TriggerTakeProfit, false, 90, 0, false},
+	}
+
+	for _, tt := range tests {
+		ord := &TriggerOrder{
+			Type:        tt.triggerType,
+			TriggerRate: triggerRate,
+			Form:        &core.TradeForm{Sell: tt.sell},
+		}
+		if got := ord.Triggered(tt.bestBid, tt.bestAsk); got != tt.want {
+			t.Errorf("%s: expected %v, got %v", tt.name, tt.want, got)
+		}
+	}
+}
@@ -0,0 +1,185 @@
+package dexc
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+
+	"decred.org/dcrdex/client/core"
+	"decred.org/dcrdex/dex/calc"
+	"decred.org/dcrdex/dex/order"
+)
+
+// WalletFunds is the balance of a Cryptopower wallet account used by the DEX
+// client, broken down by the funds locked by DEX trading.
+type WalletFunds struct {
+	AssetID       uint32
+	Symbol        string
+	WalletID      int
+	AccountNumber int32
+	// Available is the balance that can be spent from the account.
+	Available uint64
+	Immature  uint64
+	// Locked is the wallet balance that cannot be spent, including
+	// OrderLocked.
+	Locked uint64
+	// OrderLocked is the amount reserved to fund the swaps of booked orders.
+	OrderLocked uint64
+	// ContractLocked is the amount in unredeemed and unrefunded swap
+	// contracts. It is not part of the account balance.
+	ContractLocked uint64
+	// BondLocked is the amount in unrefunded fidelity bonds. It is not part
+	// of the account balance.
+	BondLocked uint64
+}
+
+// DEXLocked returns the total funds of the account locked by DEX orders,
+// swaps and bonds.
+func (f *WalletFunds) DEXLocked() uint64 {
+	return f.OrderLocked + f.ContractLocked + f.BondLocked
+}
+
+// SwapTx is a swap contract that was sent from a DEX wallet and has not been
+// redeemed or refunded yet.
+type SwapTx struct {
+	Host     string
+	MarketID string
+	OrderID  string
+	MatchID  string
+	Sell     bool
+	Status   order.MatchStatus
+	// Amount is the value of the swap contract in the asset of the wallet.
+	Amount uint64
+	TxID   string
+	// Stamp is the server's match time in milliseconds.
+	Stamp uint64
+}
+
+// WalletFunds returns the funds of the wallet account used by the DEX client
+// for assetID.
+func (dc *DEXClient) WalletFunds(assetID uint32) (*WalletFunds, error) {
+	state := dc.WalletState(assetID)
+	if state == nil {
+		return nil, fmt.Errorf("no DEX wallet for asset %d", assetID)
+	}
+
+	settings, err := dc.WalletSettings(assetID)
+	if err != nil {
+		return nil, err
+	}
+
+	walletID, err := strconv.Atoi(settings[WalletIDConfigKey])
+	if err != nil {
+		return nil, fmt.Errorf("error parsing wallet ID: %w", err)
+	}
+
+	accountNumber, err := strconv.Atoi(settings[WalletAccountNumberConfigKey])
+	if err != nil {
+		return nil, fmt.Errorf("error parsing account number: %w", err)
+	}
+
+	funds := &WalletFunds{
+		AssetID:       assetID,
+		Symbol:        state.Symbol,
+		WalletID:      walletID,
+		AccountNumber: int32(accountNumber),
+	}
+	if bal := state.Balance; bal != nil {
+		if bal.Balance != nil {
+			funds.Available, funds.Immature, funds.Locked = bal.Available, bal.Immature, bal.Locked
+		}
+		funds.OrderLocked, funds.ContractLocked, funds.BondLocked = bal.OrderLocked, bal.ContractLocked, bal.BondLocked
+	}
+	return funds, nil
+}
+
+// AllWalletFunds returns the funds of every wallet account used by the DEX
+// client, ordered by asset ID.
+func (dc *DEXClient) AllWalletFunds() ([]*WalletFunds, error) {
+	states := dc.Wallets()
+	sort.Slice(states, func(i, j int) bool {
+		return states[i].AssetID < states[j].AssetID
+	})
+
+	allFunds := make([]*WalletFunds, 0, len(states))
+	for _, state := range states {
+		funds, err := dc.WalletFunds(state.AssetID)
+		if err != nil {
+			return nil, err
+		}
+		allFunds = append(allFunds, funds)
+	}
+	return allFunds, nil
+}
+
+// AccountWalletFunds returns the funds of the wallet account if it is used by
+// the DEX client. It returns nil, nil if the account is not used by the DEX
+// client.
+func (dc *DEXClient) AccountWalletFunds(walletID int, accountNumber int32) (*WalletFunds, error) {
+	for _, state := range dc.Wallets() {
+		funds, err := dc.WalletFunds(state.AssetID)
+		if err != nil {
+			return nil, err
+		}
+		if funds.WalletID == walletID && funds.AccountNumber == accountNumber {
+			return funds, nil
+		}
+	}
+	return nil, nil
+}
+
+// InFlightSwaps returns the swap contracts sent from the DEX wallet for
+// assetID that have not been redeemed or refunded, newest first.
+func (dc *DEXClient) InFlightSwaps(assetID uint32) ([]*SwapTx, error) {
+	activeOrders, _, err := dc.ActiveOrders()
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving active orders: %w", err)
+	}
+
+	var swaps []*SwapTx
+	for host, orders := range activeOrders {
+		for _, ord := range orders {
+			fromAssetID := ord.QuoteID
+			if ord.Sell {
+				fromAssetID = ord.BaseID
+			}
+			if fromAssetID != assetID {
+				continue
+			}
+
+			for _, match := range ord.Matches {
+				if !inFlightSwap(match) {
+					continue
+				}
+
+				amount := match.Qty
+				if !ord.Sell {
+					amount = calc.BaseToQuote(match.Rate, match.Qty)
+				}
+				swaps = append(swaps, &SwapTx{
+					Host:     host,
+					MarketID: ord.MarketID,
+					OrderID:  ord.ID.String(),
+					MatchID:  match.MatchID.String(),
+					Sell:     ord.Sell,
+					Status:   match.Status,
+					Amount:   amount,
+					TxID:     match.Swap.StringID,
+					Stamp:    match.Stamp,
+				})
+			}
+		}
+	}
+
+	sort.Slice(swaps, func(i, j int) bool {
+		return swaps[i].Stamp > swaps[j].Stamp
+	})
+	return swaps, nil
+}
+
+// inFlightSwap returns true if our swap contract for the match has been sent
+// but has not yet been redeemed by the counterparty or refunded.
+func inFlightSwap(match *core.Match) bool {
+	return match.Active && !match.IsCancel && match.Swap != nil && match.Refund == nil &&
+		match.CounterRedeem == nil && match.Status < order.MatchComplete
+}
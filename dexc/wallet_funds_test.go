@@ -0,0 +1,31 @@
+package dexc
+
+import (
+	"testing"
+
+	"decred.org/dcrdex/client/core"
+	"decred.org/dcrdex/dex/order"
+)
+
+func TestInFlightSwap(t *testing.T) {
+	swap := &core.Coin{StringID: "swap"}
+	tests := []struct {
+		name  string
+		match *core.Match
+		want  bool
+	}{
+		{"no swap yet", &core.Match{Active: true, Status: order.NewlyMatched}, false},
+		{"swap cast", &core.Match{Active: true, Status: order.MakerSwapCast, Swap: swap}, true},
+		{"counterparty redeemed", &core.Match{Active: true, Status: order.MakerRedeemed, Swap: swap, CounterRedeem: swap}, false},
+		{"refunded", &core.Match{Active: true, Status: order.TakerSwapCast, Swap: swap, Refund: swap}, false},
+		{"complete", &core.Match{Active: true, Status: order.MatchComplete, Swap: swap}, false},
+		{"inactive", &core.Match{Status: order.MakerSwapCast, Swap: swap}, false},
+		{"cancel", &core.Match{Active: true, IsCancel: true, Status: order.MakerSwapCast, Swap: swap}, false},
+	}
+
+	for _, tt := range tests {
+		if got := inFlightSwap(tt.match); got != tt.want {
+			t.Errorf("%s: expected %v, got %v", tt.name, tt.want, got)
+		}
+	}
+}
@@ -0,0 +1,476 @@
+package libwallet
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"decred.org/dcrwallet/v4/errors"
+	"github.com/crypto-power/cryptopower/dexc"
+	sharedW "github.com/crypto-power/cryptopower/libwallet/assets/wallet"
+	"github.com/crypto-power/cryptopower/libwallet/instantswap"
+	"github.com/crypto-power/cryptopower/libwallet/utils"
+	bolt "go.etcd.io/bbolt"
+)
+
+const appBackupVersion byte = 1
+
+// appBackupMagic identifies app backup archives.
+var appBackupMagic = []byte("CPWBAK")
+
+// App and wallet config keys that are not restored from an app backup.
+var (
+	appBackupSkippedAppKeys = map[string]bool{
+		walletStartupPassphraseField:          true,
+		metadataKeysField:                     true,
+		duressProfileField:                    true,
+		sharedW.IsStartupSecuritySetConfigKey: true,
+		sharedW.StartupSecurityTypeConfigKey:  true,
+		sharedW.UseBiometricConfigKey:         true,
+		sharedW.DBDriverConfigKey:             true,
+	}
+	// Multisig addresses must be imported into the restored wallet, so
+	// multisig wallets are set up again instead.
+	appBackupSkippedWalletKeys = map[string]bool{
+		sharedW.MultisigConfigKey:               true,
+		sharedW.RestoredTxLabelsConfigKey:       true,
+		sharedW.PendingWalletDBRestoreConfigKey: true,
+	}
+)
+
+// AppBackup is the content of an app backup archive.
+type AppBackup struct {
+	Version   byte                       `json:"version"`
+	NetType   utils.NetworkType          `json:"nettype"`
+	CreatedAt int64                      `json:"createdat"`
+	AppConfig map[string]json.RawMessage `json:"appconfig"`
+	Wallets   []*WalletBackup            `json:"wallets"`
+	Orders    []*instantswap.Order       `json:"orders"`
+	// DEXDB is a copy of the DEX client database.
+	DEXDB []byte `json:"dexdb,omitempty"`
+}
+
+// WalletBackup holds what is needed to recreate a wallet from an app backup.
+type WalletBackup struct {
+	ID   int             `json:"id"`
+	Name string          `json:"name"`
+	Type utils.AssetType `json:"type"`
+	// Seed is empty for watch-only wallets and wallets whose seed was
+	// deleted after it was verified. These wallets are restored as watch-only
+	// wallets from XPub.
+	Seed                  string                     `json:"seed,omitempty"`
+	XPub                  string                     `json:"xpub,omitempty"`
+	PrivatePassphraseType int32                      `json:"privatepassphrasetype"`
+	Accounts              []*AccountBackup           `json:"accounts"`
+	Config                map[string]json.RawMessage `json:"config"`
+	TxLabels              map[string]string          `json:"txlabels,omitempty"`
+	// SeedDeleted is set for the wallets that could spend but whose seed was
+	// deleted, they can't spend once restored.
+	SeedDeleted bool `json:"seeddeleted,omitempty"`
+}
+
+// AccountBackup is a wallet account saved in an app backup.
+type AccountBackup struct {
+	Number int32  `json:"number"`
+	Name   string `json:"name"`
+}
+
+// ExportAppBackup writes an archive of all the wallets, their metadata and
+// the app data to filePath, encrypted with backupPassphrase.
+// walletPassphrases maps the ID of each wallet that has a seed to its private
+// passphrase, which is used to decrypt the seed.
+func (mgr *AssetsManager) ExportAppBackup(filePath, backupPassphrase string, walletPassphrases map[int]string) error {
+	if backupPassphrase == "" {
+		return errors.New(utils.ErrInvalidPassphrase)
+	}
+
+	backup := &AppBackup{
+		Version:   appBackupVersion,
+		NetType:   mgr.NetType(),
+		CreatedAt: time.Now().Unix(),
+	}
+
+	var err error
+	backup.AppConfig, err = mgr.appConfigValues()
+	if err != nil {
+		return fmt.Errorf("error reading app config: %v", err)
+	}
+
+	for _, wallet := range mgr.AllWallets() {
+		walletBackup, err := backupWallet(wallet, walletPassphrases[wallet.GetWalletID()])
+		if err != nil {
+			return fmt.Errorf("%s: %w", wallet.GetWalletName(), err)
+		}
+		backup.Wallets = append(backup.Wallets, walletBackup)
+	}
+
+	backup.Orders, err = mgr.InstantSwap.GetOrdersRaw(0, 0, false, "", "")
+	if err != nil {
+		return err
+	}
+
+	if mgr.DEXDBExists() {
+		backup.DEXDB, err = mgr.readDEXDB()
+		if err != nil {
+			return fmt.Errorf("error reading DEX database: %v", err)
+		}
+	}
+
+	archive, err := encodeAppBackup(backup, backupPassphrase)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(filePath), utils.UserFilePerm); err != nil {
+		return err
+	}
+	return os.WriteFile(filePath, archive, utils.UserFilePerm)
+}
+
+// backupWallet collects the data needed to recreate wallet.
+func backupWallet(wallet sharedW.Asset, privatePassphrase string) (*WalletBackup, error) {
+	walletBackup := &WalletBackup{
+		ID:                    wallet.GetWalletID(),
+		Name:                  wallet.GetWalletName(),
+		Type:                  wallet.GetAssetType(),
+		PrivatePassphraseType: wallet.GetPrivatePassphraseType(),
+		TxLabels:              make(map[string]string),
+	}
+
+	if wallet.HasWalletSeed() {
+		seed, err := wallet.DecryptSeed(privatePassphrase)
+		if err != nil {
+			return nil, err
+		}
+		walletBackup.Seed = seed
+	} else {
+		xpub, err := wallet.GetExtendedPubKey(0)
+		if err != nil {
+			return nil, err
+		}
+		walletBackup.XPub = xpub
+		walletBackup.SeedDeleted = !wallet.IsWatchingOnlyWallet()
+	}
+
+	accounts, err := wallet.GetAccountsRaw()
+	if err != nil {
+		return nil, err
+	}
+	for _, account := range accounts.Accounts {
+		// Imported accounts are not derived from the seed.
+		if account.Number < 0 || account.Number == math.MaxInt32 {
+			continue
+		}
+		walletBackup.Accounts = append(walletBackup.Accounts, &AccountBackup{
+			Number: account.Number,
+			Name:   account.Name,
+		})
+	}
+
+	walletBackup.Config, err = wallet.UserConfigValues()
+	if err != nil {
+		return nil, err
+	}
+
+	txs, err := wallet.GetTransactionsRaw(0, 0, utils.TxFilterAll, true, "")
+	if err != nil {
+		return nil, err
+	}
+	for _, tx := range txs {
+		if tx.Label != "" {
+			walletBackup.TxLabels[tx.Hash] = tx.Label
+		}
+	}
+
+	return walletBackup, nil
+}
+
+// ImportAppBackup recreates the wallets and app data saved in the app backup
+// archive at filePath. The restored wallets are encrypted with
+// privatePassphrase. Wallets that already exist are not restored again.
+// The names of the wallets whose seed was deleted are returned, they are
+// restored as watch-only wallets that can't spend until they are restored
+// from their seed.
+func (mgr *AssetsManager) ImportAppBackup(filePath, backupPassphrase, privatePassphrase string, privatePassphraseType int32) (seedDeleted []string, err error) {
+	archive, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	backup, err := decodeAppBackup(archive, backupPassphrase)
+	if err != nil {
+		return nil, err
+	}
+
+	if backup.NetType != mgr.NetType() {
+		return nil, fmt.Errorf("the backup is for %s, not %s", backup.NetType, mgr.NetType())
+	}
+
+	for key, value := range backup.AppConfig {
+		if !appBackupSkippedAppKeys[key] {
+			mgr.SaveAppConfigValue(key, value)
+		}
+	}
+
+	// walletIDs maps the wallet IDs in the backup to the restored wallets.
+	walletIDs := make(map[int]int, len(backup.Wallets))
+	for _, walletBackup := range backup.Wallets {
+		wallet, err := mgr.restoreWalletBackup(walletBackup, privatePassphrase, privatePassphraseType)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", walletBackup.Name, err)
+		}
+		walletIDs[walletBackup.ID] = wallet.GetWalletID()
+		if walletBackup.SeedDeleted && wallet.IsWatchingOnlyWallet() {
+			seedDeleted = append(seedDeleted, wallet.GetWalletName())
+		}
+	}
+
+	for _, order := range backup.Orders {
+		order.SourceWalletID = walletIDs[order.SourceWalletID]
+		order.DestinationWalletID = walletIDs[order.DestinationWalletID]
+		if err := mgr.InstantSwap.ImportOrder(order); err != nil {
+			log.Errorf("Error importing order %s: %v", order.UUID, err)
+		}
+	}
+
+	// Never overwrite the database of a DEX client that was already set up.
+	if len(backup.DEXDB) > 0 && !mgr.DEXDBExists() {
+		if err := mgr.restoreDEXDB(backup.DEXDB, walletIDs); err != nil {
+			return nil, fmt.Errorf("error restoring DEX database: %v", err)
+		}
+	}
+
+	return seedDeleted, nil
+}
+
+// readDEXDB returns a consistent copy of the DEX database.
+func (mgr *AssetsManager) readDEXDB() ([]byte, error) {
+	// The database is locked by the DEX client while it runs.
+	if dc := mgr.DexClient(); dc != nil && dc.IsInitialized() {
+		tmp, err := os.CreateTemp(mgr.RootDir(), dexc.DBFileName+".backup-*")
+		if err != nil {
+			return nil, err
+		}
+		tmp.Close()
+		defer os.Remove(tmp.Name())
+
+		if err := dc.BackupDB(tmp.Name(), true, false); err != nil {
+			return nil, err
+		}
+		return os.ReadFile(tmp.Name())
+	}
+
+	db, err := bolt.Open(filepath.Join(mgr.RootDir(), dexc.DBFileName), utils.UserFilePerm, &bolt.Options{ReadOnly: true, Timeout: time.Second})
+	if err != nil {
+		return nil, err
+	}
+	defer db.Close()
+
+	var buf bytes.Buffer
+	err = db.View(func(tx *bolt.Tx) error {
+		_, err := tx.WriteTo(&buf)
+		return err
+	})
+	return buf.Bytes(), err
+}
+
+// restoreDEXDB writes the DEX database saved in an app backup, with the DEX
+// wallets pointing at the restored wallets. walletIDs maps the wallet IDs in
+// the backup to the restored wallets.
+func (mgr *AssetsManager) restoreDEXDB(data []byte, walletIDs map[int]int) error {
+	dbPath := filepath.Join(mgr.RootDir(), dexc.DBFileName)
+	tmpPath := dbPath + ".restore"
+	if err := os.WriteFile(tmpPath, data, utils.UserFilePerm); err != nil {
+		return err
+	}
+	if err := dexc.RemapWalletIDs(tmpPath, walletIDs); err != nil {
+		os.Remove(tmpPath)
+		return err
+	}
+	return os.Rename(tmpPath, dbPath)
+}
+
+// restoreWalletBackup recreates a wallet from walletBackup or returns the
+// existing wallet if it was already restored.
+func (mgr *AssetsManager) restoreWalletBackup(walletBackup *WalletBackup, privatePassphrase string, privatePassphraseType int32) (sharedW.Asset, error) {
+	var existingID int
+	var err error
+	wordSeedType := sharedW.WordSeedType(len(strings.Fields(walletBackup.Seed)))
+	if walletBackup.Seed != "" {
+		existingID, err = mgr.WalletWithSeed(walletBackup.Type, walletBackup.Seed, wordSeedType)
+	} else {
+		existingID, err = mgr.WalletWithXPub(walletBackup.Type, walletBackup.XPub)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if existingID != -1 {
+		return mgr.WalletWithID(existingID), nil
+	}
+
+	name, err := mgr.availableWalletName(walletBackup.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	var wallet sharedW.Asset
+	if walletBackup.Seed != "" {
+		wallet, err = mgr.RestoreWallet(walletBackup.Type, name, walletBackup.Seed, privatePassphrase, privatePassphraseType, wordSeedType)
+	} else {
+		wallet, err = mgr.createWatchOnlyWallet(walletBackup.Type, name, walletBackup.XPub)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	for key, value := range walletBackup.Config {
+		if !appBackupSkippedWalletKeys[key] {
+			wallet.SaveUserConfigValue(key, value)
+		}
+	}
+	if len(walletBackup.TxLabels) > 0 {
+		wallet.SaveUserConfigValue(sharedW.RestoredTxLabelsConfigKey, walletBackup.TxLabels)
+	}
+
+	// Account failures are not fatal, the funds are recovered with the seed.
+	if err := restoreAccounts(wallet, walletBackup.Accounts, privatePassphrase); err != nil {
+		log.Errorf("Error restoring the accounts of %s: %v", name, err)
+	}
+
+	return wallet, nil
+}
+
+// restoreAccounts creates the accounts missing from wallet in number order,
+// so that they get the same numbers they had, and restores the account names.
+func restoreAccounts(wallet sharedW.Asset, accounts []*AccountBackup, privatePassphrase string) error {
+	existing, err := wallet.GetAccountsRaw()
+	if err != nil {
+		return err
+	}
+	names := make(map[int32]string, len(existing.Accounts))
+	for _, account := range existing.Accounts {
+		names[account.Number] = account.Name
+	}
+
+	sort.Slice(accounts, func(i, j int) bool { return accounts[i].Number < accounts[j].Number })
+	for _, account := range accounts {
+		name, ok := names[account.Number]
+		switch {
+		case !ok && !wallet.IsWatchingOnlyWallet():
+			number, err := wallet.CreateNewAccount(account.Name, privatePassphrase)
+			if err != nil {
+				return err
+			}
+			if number != account.Number {
+				log.Warnf("Account %q restored as account %d instead of %d", account.Name, number, account.Number)
+			}
+		case ok && name != account.Name:
+			if err := wallet.RenameAccount(account.Number, account.Name); err != nil {
+				log.Errorf("Error renaming account %d: %v", account.Number, err)
+			}
+		}
+	}
+	return nil
+}
+
+// availableWalletName returns name, with a numeric suffix if a wallet with
+// that name already exists.
+func (mgr *AssetsManager) availableWalletName(name string) (string, error) {
+	candidate := name
+	for i := 2; ; i++ {
+		exists, err := mgr.DoesWalletNameExist(candidate)
+		if err != nil || !exists {
+			return candidate, err
+		}
+		candidate = fmt.Sprintf("%s (%d)", name, i)
+	}
+}
+
+func (mgr *AssetsManager) createWatchOnlyWallet(assetType utils.AssetType, name, xpub string) (sharedW.Asset, error) {
+	switch assetType {
+	case utils.BTCWalletAsset:
+		return mgr.CreateNewBTCWatchOnlyWallet(name, xpub)
+	case utils.DCRWalletAsset:
+		return mgr.CreateNewDCRWatchOnlyWallet(name, xpub)
+	case utils.LTCWalletAsset:
+		return mgr.CreateNewLTCWatchOnlyWallet(name, xpub)
+	default:
+		return nil, utils.ErrAssetUnknown
+	}
+}
+
+// appConfigValues returns the raw values of all the app config keys.
+func (mgr *AssetsManager) appConfigValues() (map[string]json.RawMessage, error) {
+	values := make(map[string]json.RawMessage)
+	err := mgr.params.DB.Bolt.View(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket([]byte(appConfigBucketName))
+		if bucket == nil {
+			return nil
+		}
+		return bucket.ForEach(func(k, v []byte) error {
+			values[string(k)] = append(json.RawMessage(nil), v...)
+			return nil
+		})
+	})
+	return values, err
+}
+
+// encodeAppBackup serializes, compresses and encrypts backup.
+func encodeAppBackup(backup *AppBackup, passphrase string) ([]byte, error) {
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	if err := json.NewEncoder(zw).Encode(backup); err != nil {
+		return nil, err
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+
+	encrypted, err := utils.EncryptWithPassphrase([]byte(passphrase), buf.Bytes())
+	if err != nil {
+		return nil, err
+	}
+
+	archive := append([]byte(nil), appBackupMagic...)
+	archive = append(archive, appBackupVersion)
+	return append(archive, encrypted...), nil
+}
+
+// decodeAppBackup reverses encodeAppBackup.
+func decodeAppBackup(archive []byte, passphrase string) (*AppBackup, error) {
+	headerLen := len(appBackupMagic) + 1
+	if len(archive) < headerLen || !bytes.Equal(archive[:len(appBackupMagic)], appBackupMagic) {
+		return nil, errors.E(errors.Invalid, "not a backup file")
+	}
+	if version := archive[len(appBackupMagic)]; version != appBackupVersion {
+		return nil, errors.E(errors.Invalid, fmt.Sprintf("unsupported backup version %d", version))
+	}
+
+	compressed, err := utils.DecryptWithPassphrase([]byte(passphrase), archive[headerLen:])
+	if err != nil {
+		return nil, err
+	}
+
+	zr, err := gzip.NewReader(bytes.NewReader(compressed))
+	if err != nil {
+		return nil, err
+	}
+	data, err := io.ReadAll(zr)
+	if err != nil {
+		return nil, err
+	}
+
+	backup := new(AppBackup)
+	if err := json.Unmarshal(data, backup); err != nil {
+		return nil, err
+	}
+	return backup, nil
+}
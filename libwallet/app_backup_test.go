@@ -0,0 +1,51 @@
+package libwallet
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/crypto-power/cryptopower/libwallet/utils"
+)
+
+func TestAppBackupEncoding(t *testing.T) {
+	backup := &AppBackup{
+		Version:   appBackupVersion,
+		NetType:   utils.Testnet,
+		AppConfig: map[string]json.RawMessage{"dark_mode": json.RawMessage("true")},
+		Wallets: []*WalletBackup{{
+			ID:       3,
+			Name:     "savings",
+			Type:     utils.BTCWalletAsset,
+			Seed:     "abandon abandon ability",
+			Accounts: []*AccountBackup{{Number: 1, Name: "spending"}},
+			TxLabels: map[string]string{"abcd": "rent"},
+		}},
+	}
+
+	archive, err := encodeAppBackup(backup, "secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := decodeAppBackup(archive, "wrong"); err == nil {
+		t.Fatal("backup decrypted with the wrong passphrase")
+	}
+	if _, err := decodeAppBackup(archive[1:], "secret"); err == nil {
+		t.Fatal("archive without header decoded")
+	}
+
+	decoded, err := decodeAppBackup(archive, "secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if decoded.NetType != backup.NetType || string(decoded.AppConfig["dark_mode"]) != "true" {
+		t.Fatalf("unexpected app data %+v", decoded)
+	}
+	if len(decoded.Wallets) != 1 {
+		t.Fatalf("expected 1 wallet, got %d", len(decoded.Wallets))
+	}
+	wallet := decoded.Wallets[0]
+	if wallet.Seed != backup.Wallets[0].Seed || wallet.Accounts[0].Name != "spending" || wallet.TxLabels["abcd"] != "rent" {
+		t.Fatalf("unexpected wallet %+v", wallet)
+	}
+}
@@ -0,0 +1,205 @@
+package btc
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	sharedW "github.com/crypto-power/cryptopower/libwallet/assets/wallet"
+	"github.com/crypto-power/cryptopower/libwallet/assets/wallet/walletdata"
+	"github.com/crypto-power/cryptopower/libwallet/utils"
+	"github.com/lightninglabs/neutrino"
+)
+
+// sharedChainService is the neutrino chain service shared by the BTC wallets
+// that don't connect to specific peers. It keeps a single store of block
+// headers and filters and a single set of peers, and every wallet rescans
+// from it with its own chain client.
+type sharedChainService struct {
+	*neutrino.ChainService
+	db           *walletdata.BTCDB
+	dialerCancel context.CancelFunc
+	// started is set once a wallet starts syncing from the chain service.
+	// A chain service that never started can't be stopped.
+	started atomic.Bool
+
+	mu sync.RWMutex
+	// bannedHosts are the hosts banned by the wallets sharing the chain
+	// service, which it refuses to connect to.
+	bannedHosts map[string]struct{}
+	// users are the wallets sharing the chain service, by ID.
+	users map[int]*sharedW.Wallet
+}
+
+// Stop stops the chain service, disconnecting its peers, and closes its
+// database.
+func (cs *sharedChainService) Stop() error {
+	var err error
+	if cs.started.Load() {
+		err = cs.ChainService.Stop()
+	}
+	cs.dialerCancel()
+	if dbErr := cs.db.Close(); err == nil {
+		err = dbErr
+	}
+	return err
+}
+
+// start starts the chain service unless a wallet sharing it already did.
+func (cs *sharedChainService) start() error {
+	if !cs.started.CompareAndSwap(false, true) {
+		return nil
+	}
+	return cs.ChainService.Start()
+}
+
+// isBanned returns true if a wallet sharing the chain service banned the
+// host of the peer at addr.
+func (cs *sharedChainService) isBanned(addr string) bool {
+	cs.mu.RLock()
+	defer cs.mu.RUnlock()
+	_, banned := cs.bannedHosts[sharedW.PeerHost(addr)]
+	return banned
+}
+
+// setBanned bans or unbans the host of the peer at addr for the wallets
+// sharing the chain service.
+func (cs *sharedChainService) setBanned(addr string, banned bool) {
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+	if banned {
+		cs.bannedHosts[sharedW.PeerHost(addr)] = struct{}{}
+	} else {
+		delete(cs.bannedHosts, sharedW.PeerHost(addr))
+	}
+}
+
+// setUser adds the wallet to the wallets sharing the chain service, or
+// removes it from them.
+func (cs *sharedChainService) setUser(wallet *sharedW.Wallet, uses bool) {
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+	if uses {
+		cs.users[wallet.ID] = wallet
+	} else {
+		delete(cs.users, wallet.ID)
+	}
+}
+
+// addSyncData counts n bytes exchanged with the peers of the chain service
+// towards the data usage of the wallets sharing it, split evenly as they
+// sync the same headers and filters.
+func (cs *sharedChainService) addSyncData(n int) {
+	cs.mu.RLock()
+	defer cs.mu.RUnlock()
+	if len(cs.users) == 0 {
+		return
+	}
+	share := n / len(cs.users)
+	for _, wallet := range cs.users {
+		wallet.AddSyncData(share)
+	}
+}
+
+// newSharedChainService creates the chain service shared by the BTC wallets
+// in the shared chain data dir.
+func (asset *Asset) newSharedChainService() (sharedW.ChainBackend, error) {
+	dataDir := asset.SharedChainDataDir()
+	if err := os.MkdirAll(dataDir, utils.UserFilePerm); err != nil {
+		return nil, err
+	}
+	db, err := walletdata.OpenBTCDB(filepath.Join(dataDir, walletdata.BTCDBName))
+	if err != nil {
+		return nil, err
+	}
+
+	dialerCtx, dialerCancel := context.WithCancel(context.Background())
+	cs := &sharedChainService{db: db, dialerCancel: dialerCancel, bannedHosts: make(map[string]struct{}),
+		users: make(map[int]*sharedW.Wallet)}
+	chainService, err := neutrino.NewChainService(neutrino.Config{
+		DataDir:       dataDir,
+		Database:      db,
+		ChainParams:   *asset.chainParams,
+		PersistToDisk: true, // keep cfilter headers on disk for efficient rescanning
+		Dialer:        peerDialer(dialerCtx, cs.isBanned, cs.addSyncData),
+		// See loadChainService.
+		BroadcastTimeout: 6 * time.Second,
+	})
+	if err != nil {
+		dialerCancel()
+		db.Close()
+		return nil, fmt.Errorf("couldn't create the shared Neutrino ChainService: %v", err)
+	}
+
+	log.Infof("Created the chain service shared by the %s wallets", asset.GetAssetType())
+	cs.ChainService = chainService
+	return cs, nil
+}
+
+// acquireSharedChainService returns the chain service shared with the other
+// BTC wallets, creating it if no other wallet uses it.
+func (asset *Asset) acquireSharedChainService() (*neutrino.ChainService, error) {
+	backend, err := asset.ChainBackends().Acquire(asset.SharedChainBackendKey(), asset.newSharedChainService)
+	if err != nil {
+		return nil, err
+	}
+
+	shared := backend.(*sharedChainService)
+	for _, host := range asset.BannedPeers() {
+		shared.setBanned(host, true)
+	}
+	shared.setUser(asset.Wallet, true)
+	asset.RemoveNeutrinoChainData()
+
+	// The connections of the shared chain service are not this wallet's to
+	// cancel.
+	asset.dailerCtx, asset.dailerCancel = context.WithCancel(context.Background())
+
+	asset.syncData.mu.Lock()
+	asset.syncData.sharedChainService = shared
+	asset.syncData.chainServiceStopped = false
+	asset.syncData.mu.Unlock()
+
+	return shared.ChainService, nil
+}
+
+// releaseSharedChainService releases the chain service shared with the other
+// wallets if the wallet holds it, and returns true if it did.
+func (asset *Asset) releaseSharedChainService() bool {
+	asset.syncData.mu.Lock()
+	shared := asset.syncData.sharedChainService
+	asset.syncData.sharedChainService = nil
+	if shared != nil {
+		asset.syncData.chainServiceStopped = true
+	}
+	asset.syncData.mu.Unlock()
+
+	if shared == nil {
+		return false
+	}
+	shared.setUser(asset.Wallet, false)
+	asset.ChainBackends().Release(asset.SharedChainBackendKey())
+	return true
+}
+
+// stopChainService stops the chain service of the wallet, or releases it if
+// it is shared with other wallets, in which case it keeps running for them.
+func (asset *Asset) stopChainService() error {
+	if asset.releaseSharedChainService() {
+		return nil
+	}
+
+	asset.syncData.mu.Lock()
+	stopped := asset.syncData.chainServiceStopped
+	asset.syncData.chainServiceStopped = true
+	asset.syncData.mu.Unlock()
+
+	if stopped {
+		return nil
+	}
+	return asset.chainClient.CS.Stop()
+}
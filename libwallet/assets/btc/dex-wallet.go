@@ -43,10 +43,10 @@ var dexLogLevel = dex.LevelError
 
 // DEXWallet wraps *wallet.Wallet and implements dexbtc.Wallet.
 type DEXWallet struct {
-	w                 *wallet.Wallet
-	acctNum           int32
-	cl                *btcChainService
-	syncStatusChecker SyncStatusChecker
+	w       *wallet.Wallet
+	acctNum int32
+	cl      *btcChainService
+	helper  WalletHelper
 	*dexbtc.BlockFiltersScanner
 }
 
@@ -105,18 +105,25 @@ type SyncStatusChecker interface {
 	IsSynced() bool
 }
 
+// WalletHelper is the asset wrapping the wallet of the DEX wallet.
+type WalletHelper interface {
+	SyncStatusChecker
+	PublishWithPolicy(msgTx *wire.MsgTx, account int32) error
+}
+
 var _ dexbtc.CustomWallet = (*DEXWallet)(nil)
 var _ dexbtc.BlockInfoReader = (*DEXWallet)(nil)
+var _ WalletHelper = (*Asset)(nil)
 
 // NewDEXWallet returns a new *DEXWallet.
-func NewDEXWallet(w *wallet.Wallet, acctNum int32, nc *chain.NeutrinoClient, syncStatusChecker SyncStatusChecker) *DEXWallet {
+func NewDEXWallet(w *wallet.Wallet, acctNum int32, nc *chain.NeutrinoClient, helper WalletHelper) *DEXWallet {
 	dw := &DEXWallet{
 		w:       w,
 		acctNum: acctNum,
 		cl: &btcChainService{
 			NeutrinoClient: nc,
 		},
-		syncStatusChecker: syncStatusChecker,
+		helper: helper,
 	}
 
 	dw.BlockFiltersScanner = dexbtc.NewBlockFiltersScanner(dw, dexLogger{Logger: log})
@@ -154,7 +161,7 @@ func (dw *DEXWallet) SendRawTransaction(tx *wire.MsgTx) (*chainhash.Hash, error)
 		now spent.
 	*/
 
-	err := dw.w.PublishTransaction(tx, "")
+	err := dw.helper.PublishWithPolicy(tx, dw.acctNum)
 	if err != nil {
 		return nil, err
 	}
@@ -235,7 +242,7 @@ func (dw *DEXWallet) GetChainHeight() (int32, error) {
 
 // Part of dexbtc.Wallet interface.
 func (dw *DEXWallet) PeerCount() (uint32, error) {
-	if !dw.syncStatusChecker.IsSyncing() && !dw.syncStatusChecker.IsSynced() {
+	if !dw.helper.IsSyncing() && !dw.helper.IsSynced() {
 		return 0, nil // avoid expensive call to dw.cl.Peers()
 	}
 
@@ -244,7 +251,7 @@ func (dw *DEXWallet) PeerCount() (uint32, error) {
 
 // syncHeight is the best known sync height among peers.
 func (dw *DEXWallet) syncHeight() int32 {
-	if !dw.syncStatusChecker.IsSyncing() && !dw.syncStatusChecker.IsSynced() {
+	if !dw.helper.IsSyncing() && !dw.helper.IsSynced() {
 		return 0 // avoid expensive call to dw.cl.Peers()
 	}
 
@@ -267,7 +274,7 @@ func (dw *DEXWallet) syncHeight() int32 {
 func (dw *DEXWallet) SyncStatus() (*asset.SyncStatus, error) {
 	walletBlock := dw.syncedTo()
 	return &asset.SyncStatus{
-		Synced:         dw.syncStatusChecker.IsSynced(),
+		Synced:         dw.helper.IsSynced(),
 		TargetHeight:   uint64(dw.syncHeight()),
 		StartingBlocks: 0,
 		Blocks:         uint64(walletBlock.Height),
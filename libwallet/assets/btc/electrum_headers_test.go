@@ -0,0 +1,70 @@
+package btc
+
+import (
+	"testing"
+	"time"
+
+	"github.com/btcsuite/btcd/blockchain"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcd/wire"
+)
+
+// mineHeader returns a header extending prev with the given bits, its nonce
+// searched for a hash meeting the target of bits.
+func mineHeader(t *testing.T, prev *wire.BlockHeader, bits uint32) *wire.BlockHeader {
+	header := &wire.BlockHeader{
+		Version:   4,
+		PrevBlock: prev.BlockHash(),
+		Timestamp: prev.Timestamp.Add(time.Minute),
+		Bits:      bits,
+	}
+	target := blockchain.CompactToBig(bits)
+	for nonce := uint32(0); nonce < 1<<20; nonce++ {
+		header.Nonce = nonce
+		hash := header.BlockHash()
+		if blockchain.HashToBig(&hash).Cmp(target) <= 0 {
+			return header
+		}
+	}
+	t.Fatalf("no nonce found for bits %x", bits)
+	return nil
+}
+
+func TestElectrumHeaderDifficulty(t *testing.T) {
+	params := &chaincfg.SimNetParams
+	c := newElectrumClient(params, nil, nil)
+
+	genesis := params.GenesisBlock.Header
+	if err := c.storeHeaders(0, []*wire.BlockHeader{&genesis}); err != nil {
+		t.Fatal(err)
+	}
+	c.anchor, c.tip = 0, 0
+
+	headers := []*wire.BlockHeader{mineHeader(t, &genesis, genesis.Bits)}
+	for i := 1; i < 20; i++ {
+		headers = append(headers, mineHeader(t, headers[i-1], genesis.Bits))
+	}
+	if err := c.checkDifficulty(1, headers); err != nil {
+		t.Fatalf("valid headers rejected: %v", err)
+	}
+	if err := c.storeHeaders(1, headers); err != nil {
+		t.Fatal(err)
+	}
+	c.tip = int32(len(headers))
+
+	// The header meets the proof of work of its bits, but they are harder
+	// than the retarget rules allow.
+	tip := headers[len(headers)-1]
+	wrongBits := mineHeader(t, tip, 0x1f7fffff)
+	if err := c.checkHeader(wrongBits, c.tip+1); err != nil {
+		t.Fatalf("header proof of work rejected: %v", err)
+	}
+	if err := c.checkDifficulty(c.tip+1, []*wire.BlockHeader{wrongBits}); err == nil {
+		t.Fatal("header with the wrong bits accepted")
+	}
+
+	// Headers without their ancestors can't be checked.
+	if err := c.checkDifficulty(c.tip+2, []*wire.BlockHeader{wrongBits}); err == nil {
+		t.Fatal("header without a known parent accepted")
+	}
+}
@@ -0,0 +1,1450 @@
+package btc
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"decred.org/dcrwallet/v4/errors"
+	"github.com/btcsuite/btcd/blockchain"
+	"github.com/btcsuite/btcd/btcjson"
+	"github.com/btcsuite/btcd/btcutil"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/btcsuite/btcwallet/chain"
+	"github.com/btcsuite/btcwallet/waddrmgr"
+	"github.com/btcsuite/btcwallet/wtxmgr"
+	sharedW "github.com/crypto-power/cryptopower/libwallet/assets/wallet"
+	"github.com/crypto-power/cryptopower/libwallet/internal/electrum"
+	"github.com/crypto-power/cryptopower/libwallet/utils"
+	"golang.org/x/sync/errgroup"
+)
+
+const (
+	// electrumHeadersChunk is the number of headers fetched per request.
+	electrumHeadersChunk = 2016
+	// electrumMaxReorgDepth is the deepest reorganization of the server
+	// chain the client follows.
+	electrumMaxReorgDepth = 100
+	// electrumWorkers is the number of requests sent concurrently to a
+	// server when querying many scripts or transactions.
+	electrumWorkers = 8
+
+	// electrumMedianTimeBlocks is the number of blocks the timestamp of a
+	// header must be after the median time of.
+	electrumMedianTimeBlocks = 11
+
+	electrumRequestTimeout = time.Minute
+	electrumRetryInterval  = 10 * time.Second
+)
+
+var errElectrumNotConnected = errors.New("not connected to an electrum server")
+
+// electrumClient is the chain client of a wallet syncing from Electrum
+// servers. The headers served are synced from the last network checkpoint,
+// which they must link to, and checked for proof of work and for the
+// difficulty the retarget rules expect. The wallet transactions are checked
+// for inclusion in them with merkle proofs. The servers are tried in order
+// and the next one is connected to when the connection drops.
+type electrumClient struct {
+	chainParams *chaincfg.Params
+	cfg         *sharedW.ElectrumConfig
+	// pin pins the certificate of a server on the first connection.
+	pin func(address, fingerprint string)
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+
+	connMu     sync.RWMutex
+	conn       *electrum.Conn
+	nextServer int
+
+	headersMu sync.RWMutex
+	headers   map[int32]*wire.BlockHeader
+	hashes    map[int32]chainhash.Hash
+	heights   map[chainhash.Hash]int32
+	tip       int32
+	// anchor is the height of the checkpoint the chain is synced from. The
+	// headers after it are checked for their difficulty, the headers before
+	// it are linked to it by their hashes.
+	anchor int32
+	// base is the lowest height of the cached headers.
+	base int32
+
+	watchMu sync.Mutex
+	// statuses are the history statuses of the watched script hashes.
+	statuses  map[string]string
+	histories map[string][]*electrum.HistoryItem
+	// txHeights are the heights the wallet was notified of transactions at,
+	// 0 for mempool transactions.
+	txHeights map[chainhash.Hash]int32
+	// watchFrom is the height history changes are notified from. Older
+	// transactions are found by rescans.
+	watchFrom    int32
+	notifyBlocks bool
+
+	ntfnMu     sync.Mutex
+	ntfnQueue  []interface{}
+	ntfnSignal chan struct{}
+	ntfns      chan interface{}
+}
+
+var _ chain.Interface = (*electrumClient)(nil)
+
+func newElectrumClient(chainParams *chaincfg.Params, cfg *sharedW.ElectrumConfig,
+	pin func(address, fingerprint string),
+) *electrumClient {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &electrumClient{
+		chainParams: chainParams,
+		cfg:         cfg,
+		pin:         pin,
+		ctx:         ctx,
+		cancel:      cancel,
+		headers:     make(map[int32]*wire.BlockHeader),
+		hashes:      make(map[int32]chainhash.Hash),
+		heights:     make(map[chainhash.Hash]int32),
+		tip:         -1,
+		statuses:    make(map[string]string),
+		histories:   make(map[string][]*electrum.HistoryItem),
+		txHeights:   make(map[chainhash.Hash]int32),
+		ntfnSignal:  make(chan struct{}, 1),
+		ntfns:       make(chan interface{}),
+	}
+}
+
+// dialElectrumServer connects to server and checks that it serves the chain
+// of chainParams.
+func dialElectrumServer(ctx context.Context, chainParams *chaincfg.Params, server *sharedW.ElectrumServer,
+	torProxy string,
+) (*electrum.Conn, error) {
+	conn, err := electrum.Dial(ctx, server.Address, &electrum.Options{
+		CertFingerprint: server.CertFingerprint,
+		TorProxy:        torProxy,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	reqCtx, cancel := context.WithTimeout(ctx, electrumRequestTimeout)
+	defer cancel()
+	features, err := conn.Features(reqCtx)
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+	if features.GenesisHash != chainParams.GenesisHash.String() {
+		conn.Close()
+		return nil, errors.New(utils.ErrElectrumWrongNetwork)
+	}
+	return conn, nil
+}
+
+// TestElectrumServer connects to server and returns the fingerprint of its
+// TLS certificate, to be compared with the one published by its operator.
+func (asset *Asset) TestElectrumServer(server *sharedW.ElectrumServer, torProxy string) (string, error) {
+	cfg := &sharedW.ElectrumConfig{Servers: []*sharedW.ElectrumServer{server}, TorProxy: torProxy}
+	if err := cfg.Validate(asset.GetAssetType()); err != nil {
+		return "", err
+	}
+
+	ctx, cancel := asset.ShutdownContextWithCancel()
+	defer cancel()
+	conn, err := dialElectrumServer(ctx, asset.chainParams, server, torProxy)
+	if err != nil {
+		return "", err
+	}
+	conn.Close()
+	return conn.CertFingerprint(), nil
+}
+
+// SetElectrumConfig sets the Electrum servers the wallet syncs from, or
+// switches the wallet back to SPV if cfg is nil. The sync is restarted if the
+// wallet is connected.
+func (asset *Asset) SetElectrumConfig(cfg *sharedW.ElectrumConfig) error {
+	if err := asset.Wallet.SetElectrumConfig(cfg); err != nil {
+		return err
+	}
+	asset.syncBackendChanged()
+	return nil
+}
+
+// startElectrumSync syncs the wallet from the Electrum servers in cfg.
+func (asset *Asset) startElectrumSync(cfg *sharedW.ElectrumConfig) error {
+	client := newElectrumClient(asset.chainParams, cfg, func(address, fingerprint string) {
+		if err := asset.PinElectrumCertificate(address, fingerprint); err != nil {
+			log.Errorf("pinning the certificate of %s failed: %v", address, err)
+		}
+	})
+	return asset.startRemoteSync(client, "electrum servers")
+}
+
+// fetchElectrumFeeRate queries the fee estimates of the Electrum servers in
+// cfg, through the connection of the sync if the wallet is syncing.
+func (asset *Asset) fetchElectrumFeeRate(cfg *sharedW.ElectrumConfig) ([]sharedW.FeeEstimate, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), electrumRequestTimeout)
+	defer cancel()
+
+	var conn *electrum.Conn
+	if client, ok := asset.rpcChainClient().(*electrumClient); ok {
+		conn = client.currentConn()
+	}
+	if conn == nil {
+		var err error
+		for _, server := range cfg.Servers {
+			if conn, err = dialElectrumServer(ctx, asset.chainParams, server, cfg.TorProxy); err == nil {
+				break
+			}
+		}
+		if conn == nil {
+			return nil, err
+		}
+		defer conn.Close()
+	}
+
+	results := make([]sharedW.FeeEstimate, 0, len(rpcFeeTargets))
+	for _, target := range rpcFeeTargets {
+		feerate, err := conn.EstimateFee(ctx, target)
+		if err != nil {
+			return nil, fmt.Errorf("fetching electrum fee estimates failed: %v", err)
+		}
+		// The server returns -1 if its node has no estimate for the target.
+		if feerate <= 0 {
+			continue
+		}
+
+		// Fee rate returned is in BTC/kvB units.
+		amount, err := btcutil.NewAmount(feerate)
+		if err != nil {
+			continue
+		}
+		results = append(results, sharedW.FeeEstimate{
+			ConfirmedBlocks: int32(target),
+			Feerate:         Amount(amount),
+		})
+	}
+	return results, nil
+}
+
+// Start connects to the first reachable server and starts following its
+// chain.
+func (c *electrumClient) Start() error {
+	if err := c.connect(); err != nil {
+		return err
+	}
+
+	c.wg.Add(2)
+	go c.notificationHandler()
+	go c.handler()
+
+	c.enqueue(chain.ClientConnected{})
+	return nil
+}
+
+// Stop disconnects from the server.
+func (c *electrumClient) Stop() {
+	c.cancel()
+	if conn := c.currentConn(); conn != nil {
+		conn.Close()
+	}
+}
+
+// WaitForShutdown blocks until the client is stopped.
+func (c *electrumClient) WaitForShutdown() {
+	c.wg.Wait()
+}
+
+func (c *electrumClient) currentConn() *electrum.Conn {
+	c.connMu.RLock()
+	defer c.connMu.RUnlock()
+	return c.conn
+}
+
+func (c *electrumClient) requestContext() (context.Context, context.CancelFunc) {
+	return context.WithTimeout(c.ctx, electrumRequestTimeout)
+}
+
+// connect connects to the first reachable server, starting with the server
+// after the last one connected to, and syncs the headers of its chain.
+func (c *electrumClient) connect() error {
+	var err error
+	servers := c.cfg.Servers
+	for i := range servers {
+		index := (c.nextServer + i) % len(servers)
+		server := servers[index]
+
+		var conn *electrum.Conn
+		conn, err = dialElectrumServer(c.ctx, c.chainParams, server, c.cfg.TorProxy)
+		if err != nil {
+			log.Warnf("Connecting to electrum server %s failed: %v", server.Address, err)
+			continue
+		}
+		if server.CertFingerprint == "" {
+			server.CertFingerprint = conn.CertFingerprint()
+			c.pin(server.Address, server.CertFingerprint)
+		}
+
+		ctx, cancel := c.requestContext()
+		var tip *electrum.HeaderNotification
+		tip, err = conn.SubscribeHeaders(ctx)
+		cancel()
+		if err == nil {
+			err = c.advanceTip(conn, tip.Height)
+		}
+		if err != nil {
+			conn.Close()
+			log.Warnf("Syncing headers from electrum server %s failed: %v", server.Address, err)
+			continue
+		}
+
+		c.connMu.Lock()
+		c.conn = conn
+		c.nextServer = index + 1
+		c.connMu.Unlock()
+		log.Infof("Connected to electrum server %s at height %d", server.Address, tip.Height)
+		return nil
+	}
+	return err
+}
+
+// handler handles the notifications of the server, and reconnects when the
+// connection drops.
+func (c *electrumClient) handler() {
+	defer c.wg.Done()
+
+	for {
+		c.serve(c.currentConn())
+		if c.ctx.Err() != nil {
+			return
+		}
+
+		c.connMu.Lock()
+		c.conn = nil
+		c.connMu.Unlock()
+
+		for {
+			log.Info("Reconnecting to the electrum servers")
+			err := c.connect()
+			if err == nil {
+				err = c.resubscribe()
+			}
+			if err == nil {
+				break
+			}
+			log.Errorf("Reconnecting to the electrum servers failed: %v", err)
+
+			select {
+			case <-time.After(electrumRetryInterval):
+			case <-c.ctx.Done():
+				return
+			}
+		}
+	}
+}
+
+// serve handles the notifications of conn until it is closed. Notifications
+// that can't be handled close the connection, so that another server is
+// used.
+func (c *electrumClient) serve(conn *electrum.Conn) {
+	for {
+		select {
+		case ntfn, ok := <-conn.Notifications():
+			if !ok {
+				return
+			}
+
+			var err error
+			switch n := ntfn.(type) {
+			case *electrum.HeaderNotification:
+				err = c.advanceTip(conn, n.Height)
+			case *electrum.ScriptHashNotification:
+				err = c.scriptHashChanged(conn, n.ScriptHash, n.Status)
+			}
+			if err != nil {
+				log.Errorf("Handling electrum notification failed: %v", err)
+				conn.Close()
+			}
+
+		case <-c.ctx.Done():
+			conn.Close()
+			return
+		}
+	}
+}
+
+// resubscribe subscribes to the history changes of the watched script hashes
+// on a new connection, handling the changes missed while disconnected.
+func (c *electrumClient) resubscribe() error {
+	conn := c.currentConn()
+	if conn == nil {
+		return errElectrumNotConnected
+	}
+
+	c.watchMu.Lock()
+	scriptHashes := make([]string, 0, len(c.statuses))
+	for scriptHash := range c.statuses {
+		scriptHashes = append(scriptHashes, scriptHash)
+	}
+	c.watchMu.Unlock()
+
+	statuses, err := c.subscribe(conn, scriptHashes)
+	if err != nil {
+		return err
+	}
+	for scriptHash, status := range statuses {
+		if err := c.scriptHashChanged(conn, scriptHash, status); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// enqueue queues a notification for the wallet. Notifications are queued
+// without bound as the wallet doesn't read them while it rescans.
+func (c *electrumClient) enqueue(ntfn interface{}) {
+	c.ntfnMu.Lock()
+	c.ntfnQueue = append(c.ntfnQueue, ntfn)
+	c.ntfnMu.Unlock()
+
+	select {
+	case c.ntfnSignal <- struct{}{}:
+	default:
+	}
+}
+
+func (c *electrumClient) notificationHandler() {
+	defer c.wg.Done()
+	defer close(c.ntfns)
+
+	for {
+		c.ntfnMu.Lock()
+		if len(c.ntfnQueue) == 0 {
+			c.ntfnMu.Unlock()
+			select {
+			case <-c.ntfnSignal:
+				continue
+			case <-c.ctx.Done():
+				return
+			}
+		}
+		ntfn := c.ntfnQueue[0]
+		c.ntfnQueue[0] = nil
+		c.ntfnQueue = c.ntfnQueue[1:]
+		c.ntfnMu.Unlock()
+
+		select {
+		case c.ntfns <- ntfn:
+		case <-c.ctx.Done():
+			return
+		}
+	}
+}
+
+// fetchHeaders fetches count headers from height start and checks their proof
+// of work and that they form a chain.
+func (c *electrumClient) fetchHeaders(conn *electrum.Conn, start, count int32) ([]*wire.BlockHeader, error) {
+	ctx, cancel := c.requestContext()
+	defer cancel()
+	rawHeaders, err := conn.BlockHeaders(ctx, start, count)
+	if err != nil {
+		return nil, err
+	}
+	if len(rawHeaders) == 0 {
+		return nil, fmt.Errorf("no headers served from height %d", start)
+	}
+
+	headers := make([]*wire.BlockHeader, len(rawHeaders))
+	for i, rawHeader := range rawHeaders {
+		header := new(wire.BlockHeader)
+		if err := header.Deserialize(bytes.NewReader(rawHeader)); err != nil {
+			return nil, err
+		}
+		if err := c.checkHeader(header, start+int32(i)); err != nil {
+			return nil, err
+		}
+		if i > 0 && header.PrevBlock != headers[i-1].BlockHash() {
+			return nil, fmt.Errorf("header at height %d doesn't connect to its parent", start+int32(i))
+		}
+		headers[i] = header
+	}
+	return headers, nil
+}
+
+// checkHeader checks that header has the proof of work its difficulty bits
+// claim, within the network limit, and matches the checkpoint at height if
+// any.
+func (c *electrumClient) checkHeader(header *wire.BlockHeader, height int32) error {
+	target := blockchain.CompactToBig(header.Bits)
+	if target.Sign() <= 0 || target.Cmp(c.chainParams.PowLimit) > 0 {
+		return fmt.Errorf("header at height %d has an invalid target", height)
+	}
+	hash := header.BlockHash()
+	if blockchain.HashToBig(&hash).Cmp(target) > 0 {
+		return fmt.Errorf("header at height %d has insufficient proof of work", height)
+	}
+
+	for _, checkpoint := range c.chainParams.Checkpoints {
+		if checkpoint.Height == height && *checkpoint.Hash != hash {
+			return fmt.Errorf("header at height %d doesn't match the checkpoint", height)
+		}
+	}
+	return nil
+}
+
+// checkDifficulty checks that the headers after the anchor, from height
+// start, have the difficulty the retarget rules expect from their ancestors,
+// and timestamps after the median time of the blocks before them. The caller
+// must hold headersMu.
+func (c *electrumClient) checkDifficulty(start int32, headers []*wire.BlockHeader) error {
+	lookup := func(height int32) *wire.BlockHeader {
+		if i := height - start; i >= 0 && i < int32(len(headers)) {
+			return headers[i]
+		}
+		return c.headers[height]
+	}
+
+	chainCtx := &electrumChainCtx{chainParams: c.chainParams}
+	for i, header := range headers {
+		height := start + int32(i)
+		if height <= c.anchor {
+			continue
+		}
+		parent := newElectrumHeaderCtx(height-1, lookup)
+		if parent == nil {
+			return fmt.Errorf("header at height %d has no known parent", height)
+		}
+		err := blockchain.CheckBlockHeaderContext(header, parent, blockchain.BFNone, chainCtx, false)
+		if err != nil {
+			return fmt.Errorf("header at height %d is invalid: %v", height, err)
+		}
+	}
+	return nil
+}
+
+// electrumHeaderCtx is a header of the server chain, whose ancestors are
+// looked up for the checks of the headers that follow it.
+type electrumHeaderCtx struct {
+	header *wire.BlockHeader
+	height int32
+	lookup func(height int32) *wire.BlockHeader
+}
+
+// newElectrumHeaderCtx returns the header at height, or nil if it isn't
+// known.
+func newElectrumHeaderCtx(height int32, lookup func(height int32) *wire.BlockHeader) blockchain.HeaderCtx {
+	if height < 0 {
+		return nil
+	}
+	header := lookup(height)
+	if header == nil {
+		return nil
+	}
+	return &electrumHeaderCtx{header: header, height: height, lookup: lookup}
+}
+
+// Height implements blockchain.HeaderCtx.
+func (h *electrumHeaderCtx) Height() int32 { return h.height }
+
+// Bits implements blockchain.HeaderCtx.
+func (h *electrumHeaderCtx) Bits() uint32 { return h.header.Bits }
+
+// Timestamp implements blockchain.HeaderCtx.
+func (h *electrumHeaderCtx) Timestamp() int64 { return h.header.Timestamp.Unix() }
+
+// Parent implements blockchain.HeaderCtx.
+func (h *electrumHeaderCtx) Parent() blockchain.HeaderCtx {
+	return newElectrumHeaderCtx(h.height-1, h.lookup)
+}
+
+// RelativeAncestorCtx implements blockchain.HeaderCtx.
+func (h *electrumHeaderCtx) RelativeAncestorCtx(distance int32) blockchain.HeaderCtx {
+	return newElectrumHeaderCtx(h.height-distance, h.lookup)
+}
+
+// electrumChainCtx provides the retarget rules of the network to the checks
+// of the headers.
+type electrumChainCtx struct {
+	chainParams *chaincfg.Params
+}
+
+// ChainParams implements blockchain.ChainCtx.
+func (c *electrumChainCtx) ChainParams() *chaincfg.Params { return c.chainParams }
+
+// BlocksPerRetarget implements blockchain.ChainCtx.
+func (c *electrumChainCtx) BlocksPerRetarget() int32 {
+	return int32(c.chainParams.TargetTimespan / c.chainParams.TargetTimePerBlock)
+}
+
+// MinRetargetTimespan implements blockchain.ChainCtx.
+func (c *electrumChainCtx) MinRetargetTimespan() int64 {
+	return int64(c.chainParams.TargetTimespan/time.Second) / c.chainParams.RetargetAdjustmentFactor
+}
+
+// MaxRetargetTimespan implements blockchain.ChainCtx.
+func (c *electrumChainCtx) MaxRetargetTimespan() int64 {
+	return int64(c.chainParams.TargetTimespan/time.Second) * c.chainParams.RetargetAdjustmentFactor
+}
+
+// VerifyCheckpoint implements blockchain.ChainCtx.
+func (c *electrumChainCtx) VerifyCheckpoint(height int32, hash *chainhash.Hash) bool {
+	for _, checkpoint := range c.chainParams.Checkpoints {
+		if checkpoint.Height == height {
+			return *checkpoint.Hash == *hash
+		}
+	}
+	return true
+}
+
+// FindPreviousCheckpoint implements blockchain.ChainCtx. The chain never
+// reorganizes past its anchor, deep reorganizations are refused.
+func (c *electrumChainCtx) FindPreviousCheckpoint() (blockchain.HeaderCtx, error) {
+	return nil, nil
+}
+
+// storeHeaders caches headers from height start. The caller must hold
+// headersMu.
+func (c *electrumClient) storeHeaders(start int32, headers []*wire.BlockHeader) error {
+	if prevHash, ok := c.hashes[start-1]; ok && headers[0].PrevBlock != prevHash {
+		return fmt.Errorf("header at height %d doesn't connect to the chain", start)
+	}
+	for i, header := range headers {
+		height := start + int32(i)
+		hash := header.BlockHash()
+		c.headers[height] = header
+		c.hashes[height] = hash
+		c.heights[hash] = height
+	}
+	return nil
+}
+
+// resetHeaders drops every cached header. The caller must hold headersMu.
+func (c *electrumClient) resetHeaders() {
+	c.headers = make(map[int32]*wire.BlockHeader)
+	c.hashes = make(map[int32]chainhash.Hash)
+	c.heights = make(map[chainhash.Hash]int32)
+	c.tip = -1
+}
+
+// anchorChain syncs the headers from the last checkpoint at or below height,
+// or from the genesis block, to the server tip at height. The headers start
+// before the checkpoint, back to the start of its retarget period, so that
+// the difficulty of the headers after it can be checked. The caller must hold
+// headersMu.
+func (c *electrumClient) anchorChain(conn *electrum.Conn, height int32) error {
+	anchor, anchorHash := int32(0), c.chainParams.GenesisHash
+	for i := range c.chainParams.Checkpoints {
+		checkpoint := &c.chainParams.Checkpoints[i]
+		if checkpoint.Height <= height && checkpoint.Height > anchor {
+			anchor, anchorHash = checkpoint.Height, checkpoint.Hash
+		}
+	}
+	start := max(anchor-electrumMedianTimeBlocks, 0)
+	start -= start % (&electrumChainCtx{chainParams: c.chainParams}).BlocksPerRetarget()
+
+	c.anchor = anchor
+	for next := start; next <= height; {
+		headers, err := c.fetchHeaders(conn, next, min(height-next+1, electrumHeadersChunk))
+		if err == nil {
+			if i := anchor - next; i >= 0 && i < int32(len(headers)) && headers[i].BlockHash() != *anchorHash {
+				err = fmt.Errorf("header at height %d doesn't match the checkpoint", anchor)
+			}
+		}
+		if err == nil {
+			err = c.checkDifficulty(next, headers)
+		}
+		if err == nil {
+			err = c.storeHeaders(next, headers)
+		}
+		if err != nil {
+			c.resetHeaders()
+			return err
+		}
+		next += int32(len(headers))
+		c.tip = next - 1
+	}
+	c.base = start
+	return nil
+}
+
+// forgetHeader drops the header at height from the cache. The caller must
+// hold headersMu.
+func (c *electrumClient) forgetHeader(height int32) {
+	delete(c.heights, c.hashes[height])
+	delete(c.hashes, height)
+	delete(c.headers, height)
+}
+
+// blockMeta returns the block at height. The caller must hold headersMu.
+func (c *electrumClient) blockMeta(height int32) wtxmgr.BlockMeta {
+	return wtxmgr.BlockMeta{
+		Block: wtxmgr.Block{Hash: c.hashes[height], Height: height},
+		Time:  c.headers[height].Timestamp,
+	}
+}
+
+// advanceTip follows the server chain up to its tip at height. Blocks that
+// were reorganized out of the server chain are disconnected first.
+func (c *electrumClient) advanceTip(conn *electrum.Conn, height int32) error {
+	c.headersMu.Lock()
+	defer c.headersMu.Unlock()
+
+	var ntfns []interface{}
+	defer func() {
+		c.watchMu.Lock()
+		notifyBlocks := c.notifyBlocks
+		c.watchMu.Unlock()
+		if notifyBlocks {
+			for _, ntfn := range ntfns {
+				c.enqueue(ntfn)
+			}
+		}
+	}()
+
+	if c.tip < 0 {
+		return c.anchorChain(conn, height)
+	}
+
+	// Find the last block of the local chain still in the server chain.
+	fork := c.tip
+	if height < fork {
+		fork = height
+	}
+	for ; ; fork-- {
+		if fork < 0 || c.tip-fork > electrumMaxReorgDepth {
+			return fmt.Errorf("reorganization deeper than %d blocks", electrumMaxReorgDepth)
+		}
+		localHash, ok := c.hashes[fork]
+		if !ok {
+			break
+		}
+		headers, err := c.fetchHeaders(conn, fork, 1)
+		if err != nil {
+			return err
+		}
+		if headers[0].BlockHash() == localHash {
+			break
+		}
+	}
+
+	for ; c.tip > fork; c.tip-- {
+		ntfns = append(ntfns, chain.BlockDisconnected(c.blockMeta(c.tip)))
+		c.forgetHeader(c.tip)
+	}
+
+	for c.tip < height {
+		count := height - c.tip
+		if count > electrumHeadersChunk {
+			count = electrumHeadersChunk
+		}
+		headers, err := c.fetchHeaders(conn, c.tip+1, count)
+		if err != nil {
+			return err
+		}
+		if err := c.checkDifficulty(c.tip+1, headers); err != nil {
+			return err
+		}
+		if err := c.storeHeaders(c.tip+1, headers); err != nil {
+			return err
+		}
+		for range headers {
+			c.tip++
+			ntfns = append(ntfns, chain.BlockConnected(c.blockMeta(c.tip)))
+		}
+	}
+	return nil
+}
+
+// header returns the header at height. The headers below the cached chain
+// are fetched down to height, each chunk linking to the chunk above it by the
+// hash of its last header.
+func (c *electrumClient) header(height int32) (*wire.BlockHeader, chainhash.Hash, error) {
+	for {
+		c.headersMu.RLock()
+		header, ok := c.headers[height]
+		hash := c.hashes[height]
+		tip, base := c.tip, c.base
+		c.headersMu.RUnlock()
+		if ok {
+			return header, hash, nil
+		}
+		if height < 0 || height > tip || height >= base {
+			return nil, hash, fmt.Errorf("no block at height %d", height)
+		}
+
+		conn := c.currentConn()
+		if conn == nil {
+			return nil, hash, errElectrumNotConnected
+		}
+		start := max(base-electrumHeadersChunk, 0)
+		headers, err := c.fetchHeaders(conn, start, base-start)
+		if err != nil {
+			return nil, hash, err
+		}
+
+		c.headersMu.Lock()
+		if c.base == base {
+			next, ok := c.headers[base]
+			if !ok || int32(len(headers)) != base-start || next.PrevBlock != headers[len(headers)-1].BlockHash() {
+				c.headersMu.Unlock()
+				return nil, hash, fmt.Errorf("headers at height %d don't connect to the chain", start)
+			}
+			if err := c.storeHeaders(start, headers); err != nil {
+				c.headersMu.Unlock()
+				return nil, hash, err
+			}
+			c.base = start
+		}
+		c.headersMu.Unlock()
+	}
+}
+
+// blockHeight returns the height of the cached block with hash.
+func (c *electrumClient) blockHeight(hash *chainhash.Hash) (int32, error) {
+	c.headersMu.RLock()
+	defer c.headersMu.RUnlock()
+	height, ok := c.heights[*hash]
+	if !ok {
+		return 0, fmt.Errorf("unknown block %s", hash)
+	}
+	return height, nil
+}
+
+func (c *electrumClient) tipMeta() (wtxmgr.BlockMeta, error) {
+	c.headersMu.RLock()
+	defer c.headersMu.RUnlock()
+	if c.tip < 0 {
+		return wtxmgr.BlockMeta{}, errElectrumNotConnected
+	}
+	return c.blockMeta(c.tip), nil
+}
+
+// subscribe subscribes to the history changes of scriptHashes and returns
+// their current statuses.
+func (c *electrumClient) subscribe(conn *electrum.Conn, scriptHashes []string) (map[string]string, error) {
+	statuses := make(map[string]string, len(scriptHashes))
+	var mu sync.Mutex
+
+	g, ctx := errgroup.WithContext(c.ctx)
+	g.SetLimit(electrumWorkers)
+	for _, scriptHash := range scriptHashes {
+		scriptHash := scriptHash
+		g.Go(func() error {
+			reqCtx, cancel := context.WithTimeout(ctx, electrumRequestTimeout)
+			defer cancel()
+			status, err := conn.SubscribeScriptHash(reqCtx, scriptHash)
+			if err != nil {
+				return err
+			}
+			mu.Lock()
+			statuses[scriptHash] = status
+			mu.Unlock()
+			return nil
+		})
+	}
+	return statuses, g.Wait()
+}
+
+// watch subscribes to the history changes of the scripts of addrs that
+// aren't watched yet. Their current history is left to rescans.
+func (c *electrumClient) watch(addrs []btcutil.Address) error {
+	conn := c.currentConn()
+	if conn == nil {
+		return errElectrumNotConnected
+	}
+
+	var scriptHashes []string
+	c.watchMu.Lock()
+	for _, scriptHash := range addrScriptHashes(addrs) {
+		if _, ok := c.statuses[scriptHash]; !ok {
+			scriptHashes = append(scriptHashes, scriptHash)
+		}
+	}
+	c.watchMu.Unlock()
+
+	statuses, err := c.subscribe(conn, scriptHashes)
+	if err != nil {
+		return err
+	}
+	c.watchMu.Lock()
+	for scriptHash, status := range statuses {
+		if _, ok := c.statuses[scriptHash]; !ok {
+			c.statuses[scriptHash] = status
+		}
+	}
+	c.watchMu.Unlock()
+	return nil
+}
+
+// addrScriptHashes returns the script hashes of the scripts paying to addrs.
+func addrScriptHashes(addrs []btcutil.Address) []string {
+	scriptHashes := make([]string, 0, len(addrs))
+	for _, addr := range addrs {
+		pkScript, err := txscript.PayToAddrScript(addr)
+		if err != nil {
+			continue
+		}
+		scriptHashes = append(scriptHashes, electrum.ScriptHash(pkScript))
+	}
+	return scriptHashes
+}
+
+// fetchHistories returns the histories of scriptHashes, from the cache unless
+// refresh is set.
+func (c *electrumClient) fetchHistories(conn *electrum.Conn, scriptHashes []string,
+	refresh bool,
+) (map[string][]*electrum.HistoryItem, error) {
+	histories := make(map[string][]*electrum.HistoryItem, len(scriptHashes))
+	var missing []string
+	c.watchMu.Lock()
+	for _, scriptHash := range scriptHashes {
+		if history, ok := c.histories[scriptHash]; ok && !refresh {
+			histories[scriptHash] = history
+		} else {
+			missing = append(missing, scriptHash)
+		}
+	}
+	c.watchMu.Unlock()
+
+	var mu sync.Mutex
+	g, ctx := errgroup.WithContext(c.ctx)
+	g.SetLimit(electrumWorkers)
+	for _, scriptHash := range missing {
+		scriptHash := scriptHash
+		g.Go(func() error {
+			reqCtx, cancel := context.WithTimeout(ctx, electrumRequestTimeout)
+			defer cancel()
+			history, err := conn.History(reqCtx, scriptHash)
+			if err != nil {
+				return err
+			}
+			mu.Lock()
+			histories[scriptHash] = history
+			mu.Unlock()
+			return nil
+		})
+	}
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+
+	c.watchMu.Lock()
+	for _, scriptHash := range missing {
+		c.histories[scriptHash] = histories[scriptHash]
+	}
+	c.watchMu.Unlock()
+	return histories, nil
+}
+
+// scriptHashChanged notifies the wallet of the transactions of a watched
+// script hash whose history status changed.
+func (c *electrumClient) scriptHashChanged(conn *electrum.Conn, scriptHash, status string) error {
+	c.watchMu.Lock()
+	knownStatus, watched := c.statuses[scriptHash]
+	watchFrom := c.watchFrom
+	c.watchMu.Unlock()
+	if !watched || knownStatus == status {
+		return nil
+	}
+
+	histories, err := c.fetchHistories(conn, []string{scriptHash}, true)
+	if err != nil {
+		return err
+	}
+	var items []*electrum.HistoryItem
+	for _, item := range histories[scriptHash] {
+		if item.Height <= 0 || item.Height >= watchFrom {
+			items = append(items, item)
+		}
+	}
+	if err := c.notifyTxs(conn, items, false); err != nil {
+		return err
+	}
+
+	c.watchMu.Lock()
+	c.statuses[scriptHash] = status
+	c.watchMu.Unlock()
+	return nil
+}
+
+// relevantTx is a wallet transaction and the block it was mined in.
+type relevantTx struct {
+	tx     *wire.MsgTx
+	height int32
+	pos    int
+}
+
+// fetchTx fetches the transaction with txid and checks its inclusion in the
+// block at height with a merkle proof, unless it is unmined.
+func (c *electrumClient) fetchTx(ctx context.Context, conn *electrum.Conn, txid string, height int32) (*relevantTx, error) {
+	rawTx, err := conn.Transaction(ctx, txid)
+	if err != nil {
+		return nil, err
+	}
+	tx := new(wire.MsgTx)
+	if err := tx.Deserialize(bytes.NewReader(rawTx)); err != nil {
+		return nil, err
+	}
+	txHash := tx.TxHash()
+	if txHash.String() != txid {
+		return nil, fmt.Errorf("server returned transaction %s for %s", txHash, txid)
+	}
+	if height <= 0 {
+		return &relevantTx{tx: tx}, nil
+	}
+
+	header, _, err := c.header(height)
+	if err != nil {
+		return nil, err
+	}
+	proof, err := conn.TransactionMerkle(ctx, txid, height)
+	if err != nil {
+		return nil, err
+	}
+	root, err := electrum.MerkleRoot(txHash, proof)
+	if err != nil {
+		return nil, err
+	}
+	if chainhash.Hash(root) != header.MerkleRoot {
+		return nil, fmt.Errorf("transaction %s is not in block %d", txid, height)
+	}
+	return &relevantTx{tx: tx, height: height, pos: proof.Pos}, nil
+}
+
+// fetchTxs fetches the transactions of items, in the order they were mined.
+func (c *electrumClient) fetchTxs(conn *electrum.Conn, items []*electrum.HistoryItem) ([]*relevantTx, error) {
+	txs := make([]*relevantTx, len(items))
+	g, ctx := errgroup.WithContext(c.ctx)
+	g.SetLimit(electrumWorkers)
+	for i, item := range items {
+		i, item := i, item
+		g.Go(func() error {
+			reqCtx, cancel := context.WithTimeout(ctx, electrumRequestTimeout)
+			defer cancel()
+			tx, err := c.fetchTx(reqCtx, conn, item.TxHash, item.Height)
+			txs[i] = tx
+			return err
+		})
+	}
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+
+	// Parents come before their children in blocks, and mempool
+	// transactions with confirmed inputs before those without.
+	sort.SliceStable(txs, func(i, j int) bool {
+		hi, hj := txs[i].height, txs[j].height
+		switch {
+		case hi == hj:
+			return txs[i].pos < txs[j].pos
+		case hi <= 0 || hj <= 0:
+			return hi > hj
+		default:
+			return hi < hj
+		}
+	})
+	return txs, nil
+}
+
+// notifyTxs notifies the wallet of the transactions of items, unless it was
+// already notified of them at the same height and force isn't set.
+func (c *electrumClient) notifyTxs(conn *electrum.Conn, items []*electrum.HistoryItem, force bool) error {
+	seen := make(map[string]bool, len(items))
+	var pending []*electrum.HistoryItem
+	var maxHeight int32
+	c.watchMu.Lock()
+	for _, item := range items {
+		if seen[item.TxHash] {
+			continue
+		}
+		seen[item.TxHash] = true
+		if item.Height < 0 {
+			item.Height = 0
+		}
+
+		txHash, err := chainhash.NewHashFromStr(item.TxHash)
+		if err != nil {
+			c.watchMu.Unlock()
+			return err
+		}
+		if notifiedHeight, ok := c.txHeights[*txHash]; ok && !force &&
+			(notifiedHeight == item.Height || item.Height == 0) {
+			continue
+		}
+		pending = append(pending, item)
+		if item.Height > maxHeight {
+			maxHeight = item.Height
+		}
+	}
+	c.watchMu.Unlock()
+	if len(pending) == 0 {
+		return nil
+	}
+
+	// The history may list blocks the tip notification wasn't received for
+	// yet.
+	c.headersMu.RLock()
+	tip := c.tip
+	c.headersMu.RUnlock()
+	if maxHeight > tip {
+		if err := c.advanceTip(conn, maxHeight); err != nil {
+			return err
+		}
+	}
+
+	txs, err := c.fetchTxs(conn, pending)
+	if err != nil {
+		return err
+	}
+	for _, tx := range txs {
+		received := time.Now()
+		var block *wtxmgr.BlockMeta
+		if tx.height > 0 {
+			header, hash, err := c.header(tx.height)
+			if err != nil {
+				return err
+			}
+			block = &wtxmgr.BlockMeta{
+				Block: wtxmgr.Block{Hash: hash, Height: tx.height},
+				Time:  header.Timestamp,
+			}
+			received = header.Timestamp
+		}
+		rec, err := wtxmgr.NewTxRecordFromMsgTx(tx.tx, received)
+		if err != nil {
+			return err
+		}
+		c.enqueue(chain.RelevantTx{TxRecord: rec, Block: block})
+
+		c.watchMu.Lock()
+		c.txHeights[rec.Hash] = tx.height
+		c.watchMu.Unlock()
+	}
+	return nil
+}
+
+// rescan notifies the wallet of the transactions of scriptHashes mined from
+// startHeight, retrying on another server if the connection drops.
+func (c *electrumClient) rescan(startHeight int32, scriptHashes []string) {
+	defer c.wg.Done()
+
+	for {
+		err := c.rescanScriptHashes(startHeight, scriptHashes)
+		if err == nil {
+			return
+		}
+		log.Errorf("Electrum rescan failed, retrying: %v", err)
+
+		select {
+		case <-time.After(electrumRetryInterval):
+		case <-c.ctx.Done():
+			return
+		}
+	}
+}
+
+func (c *electrumClient) rescanScriptHashes(startHeight int32, scriptHashes []string) error {
+	conn := c.currentConn()
+	if conn == nil {
+		return errElectrumNotConnected
+	}
+
+	statuses, err := c.subscribe(conn, scriptHashes)
+	if err != nil {
+		return err
+	}
+	histories, err := c.fetchHistories(conn, scriptHashes, true)
+	if err != nil {
+		return err
+	}
+
+	var items []*electrum.HistoryItem
+	for _, history := range histories {
+		for _, item := range history {
+			if item.Height <= 0 || item.Height >= startHeight {
+				items = append(items, item)
+			}
+		}
+	}
+	if err := c.notifyTxs(conn, items, true); err != nil {
+		return err
+	}
+
+	c.watchMu.Lock()
+	for scriptHash, status := range statuses {
+		c.statuses[scriptHash] = status
+	}
+	c.watchMu.Unlock()
+
+	tip, err := c.tipMeta()
+	if err != nil {
+		return err
+	}
+	c.enqueue(&chain.RescanFinished{Hash: &tip.Hash, Height: tip.Height, Time: tip.Time})
+	return nil
+}
+
+// GetBestBlock returns the tip of the server chain.
+func (c *electrumClient) GetBestBlock() (*chainhash.Hash, int32, error) {
+	tip, err := c.tipMeta()
+	if err != nil {
+		return nil, 0, err
+	}
+	return &tip.Hash, tip.Height, nil
+}
+
+// GetBlock isn't supported as Electrum servers don't serve blocks.
+func (c *electrumClient) GetBlock(*chainhash.Hash) (*wire.MsgBlock, error) {
+	return nil, errors.New("electrum servers don't serve blocks")
+}
+
+// GetBlockHash returns the hash of the block at height.
+func (c *electrumClient) GetBlockHash(height int64) (*chainhash.Hash, error) {
+	_, hash, err := c.header(int32(height))
+	if err != nil {
+		return nil, err
+	}
+	return &hash, nil
+}
+
+// GetBlockHeader returns the header of the cached block with hash.
+func (c *electrumClient) GetBlockHeader(hash *chainhash.Hash) (*wire.BlockHeader, error) {
+	height, err := c.blockHeight(hash)
+	if err != nil {
+		return nil, err
+	}
+	header, _, err := c.header(height)
+	return header, err
+}
+
+// IsCurrent returns true while connected to a server, whose tip is the tip of
+// the chain.
+func (c *electrumClient) IsCurrent() bool {
+	c.headersMu.RLock()
+	defer c.headersMu.RUnlock()
+	return c.currentConn() != nil && c.tip >= 0
+}
+
+// FilterBlocks returns the transactions of the first block of req paying to
+// or spending from the addresses of req, using the histories of their
+// scripts.
+func (c *electrumClient) FilterBlocks(req *chain.FilterBlocksRequest) (*chain.FilterBlocksResponse, error) {
+	if len(req.Blocks) == 0 {
+		return nil, nil
+	}
+	conn := c.currentConn()
+	if conn == nil {
+		return nil, errElectrumNotConnected
+	}
+
+	type filterAddr struct {
+		addr     btcutil.Address
+		index    waddrmgr.ScopedIndex
+		external bool
+		internal bool
+	}
+	addrs := make(map[string]*filterAddr)
+	for index, addr := range req.ExternalAddrs {
+		if scriptHashes := addrScriptHashes([]btcutil.Address{addr}); len(scriptHashes) == 1 {
+			addrs[scriptHashes[0]] = &filterAddr{addr: addr, index: index, external: true}
+		}
+	}
+	for index, addr := range req.InternalAddrs {
+		if scriptHashes := addrScriptHashes([]btcutil.Address{addr}); len(scriptHashes) == 1 {
+			addrs[scriptHashes[0]] = &filterAddr{addr: addr, index: index, internal: true}
+		}
+	}
+	// Spends of the watched outputs are in the history of their scripts.
+	for _, addr := range req.WatchedOutPoints {
+		if scriptHashes := addrScriptHashes([]btcutil.Address{addr}); len(scriptHashes) == 1 {
+			if _, ok := addrs[scriptHashes[0]]; !ok {
+				addrs[scriptHashes[0]] = &filterAddr{addr: addr}
+			}
+		}
+	}
+
+	scriptHashes := make([]string, 0, len(addrs))
+	for scriptHash := range addrs {
+		scriptHashes = append(scriptHashes, scriptHash)
+	}
+	histories, err := c.fetchHistories(conn, scriptHashes, false)
+	if err != nil {
+		return nil, err
+	}
+
+	batchIndexes := make(map[int32]int, len(req.Blocks))
+	for i, block := range req.Blocks {
+		batchIndexes[block.Height] = i
+	}
+	batchIndex := -1
+	for _, history := range histories {
+		for _, item := range history {
+			if i, ok := batchIndexes[item.Height]; ok && (batchIndex < 0 || i < batchIndex) {
+				batchIndex = i
+			}
+		}
+	}
+	if batchIndex < 0 {
+		return nil, nil
+	}
+
+	block := req.Blocks[batchIndex]
+	resp := &chain.FilterBlocksResponse{
+		BatchIndex:         uint32(batchIndex),
+		BlockMeta:          block,
+		FoundExternalAddrs: make(map[waddrmgr.KeyScope]map[uint32]struct{}),
+		FoundInternalAddrs: make(map[waddrmgr.KeyScope]map[uint32]struct{}),
+		FoundOutPoints:     make(map[wire.OutPoint]btcutil.Address),
+	}
+	found := func(addrs map[waddrmgr.KeyScope]map[uint32]struct{}, index waddrmgr.ScopedIndex) {
+		if addrs[index.Scope] == nil {
+			addrs[index.Scope] = make(map[uint32]struct{})
+		}
+		addrs[index.Scope][index.Index] = struct{}{}
+	}
+
+	var items []*electrum.HistoryItem
+	seen := make(map[string]bool)
+	for scriptHash, history := range histories {
+		for _, item := range history {
+			if item.Height != block.Height {
+				continue
+			}
+			addr := addrs[scriptHash]
+			if addr.external {
+				found(resp.FoundExternalAddrs, addr.index)
+			}
+			if addr.internal {
+				found(resp.FoundInternalAddrs, addr.index)
+			}
+			if !seen[item.TxHash] {
+				seen[item.TxHash] = true
+				items = append(items, item)
+			}
+		}
+	}
+
+	txs, err := c.fetchTxs(conn, items)
+	if err != nil {
+		return nil, err
+	}
+	for _, tx := range txs {
+		txHash := tx.tx.TxHash()
+		for i, txOut := range tx.tx.TxOut {
+			addr, ok := addrs[electrum.ScriptHash(txOut.PkScript)]
+			if ok && (addr.external || addr.internal) {
+				resp.FoundOutPoints[wire.OutPoint{Hash: txHash, Index: uint32(i)}] = addr.addr
+			}
+		}
+		resp.RelevantTxns = append(resp.RelevantTxns, tx.tx)
+	}
+	return resp, nil
+}
+
+// BlockStamp returns the tip of the server chain.
+func (c *electrumClient) BlockStamp() (*waddrmgr.BlockStamp, error) {
+	tip, err := c.tipMeta()
+	if err != nil {
+		return nil, err
+	}
+	return &waddrmgr.BlockStamp{Hash: tip.Hash, Height: tip.Height, Timestamp: tip.Time}, nil
+}
+
+// SendRawTransaction broadcasts tx through the server.
+func (c *electrumClient) SendRawTransaction(tx *wire.MsgTx, _ bool) (*chainhash.Hash, error) {
+	conn := c.currentConn()
+	if conn == nil {
+		return nil, errElectrumNotConnected
+	}
+
+	var buf bytes.Buffer
+	if err := tx.Serialize(&buf); err != nil {
+		return nil, err
+	}
+	ctx, cancel := c.requestContext()
+	defer cancel()
+	if _, err := conn.Broadcast(ctx, buf.Bytes()); err != nil {
+		return nil, c.MapRPCErr(err)
+	}
+	txHash := tx.TxHash()
+	return &txHash, nil
+}
+
+// Rescan notifies the wallet of the transactions paying to addrs or spending
+// outpoints mined from the block with startHash, then sends a RescanFinished
+// notification. It returns once the rescan is started.
+func (c *electrumClient) Rescan(startHash *chainhash.Hash, addrs []btcutil.Address,
+	outpoints map[wire.OutPoint]btcutil.Address,
+) error {
+	startHeight, err := c.blockHeight(startHash)
+	if err != nil {
+		return err
+	}
+	for _, addr := range outpoints {
+		addrs = append(addrs, addr)
+	}
+
+	c.watchMu.Lock()
+	if c.watchFrom == 0 || startHeight < c.watchFrom {
+		c.watchFrom = startHeight
+	}
+	c.watchMu.Unlock()
+
+	c.wg.Add(1)
+	go c.rescan(startHeight, addrScriptHashes(addrs))
+	return nil
+}
+
+// NotifyReceived watches addrs for new transactions.
+func (c *electrumClient) NotifyReceived(addrs []btcutil.Address) error {
+	return c.watch(addrs)
+}
+
+// NotifyBlocks enables the notification of connected and disconnected blocks.
+func (c *electrumClient) NotifyBlocks() error {
+	c.watchMu.Lock()
+	c.notifyBlocks = true
+	c.watchMu.Unlock()
+	return nil
+}
+
+// Notifications returns the channel the wallet notifications are sent on.
+func (c *electrumClient) Notifications() <-chan interface{} {
+	return c.ntfns
+}
+
+// BackEnd returns the name of the backend.
+func (c *electrumClient) BackEnd() string {
+	return "electrum"
+}
+
+// TestMempoolAccept isn't supported by Electrum servers.
+func (c *electrumClient) TestMempoolAccept([]*wire.MsgTx, float64) ([]*btcjson.TestMempoolAcceptResult, error) {
+	return nil, chain.ErrUnimplemented
+}
+
+// MapRPCErr maps the errors relayed by the server from its bitcoind node to
+// the errors of the chain package.
+func (c *electrumClient) MapRPCErr(err error) error {
+	normalize := func(s string) string {
+		return strings.ToLower(strings.ReplaceAll(s, "-", " "))
+	}
+	msg := normalize(err.Error())
+	for bitcoindErr, matchedErr := range chain.Bitcoind28ErrMap {
+		if strings.Contains(msg, normalize(bitcoindErr)) {
+			return matchedErr
+		}
+	}
+	for rpcErr := chain.RPCErr(0); rpcErr.Error() != "unknown error"; rpcErr++ {
+		if strings.Contains(msg, normalize(rpcErr.Error())) {
+			return rpcErr
+		}
+	}
+	return err
+}
@@ -2,13 +2,12 @@ package btc
 
 import (
 	"fmt"
-	"net/http"
-	"sort"
-	"strconv"
 	"sync"
 
 	"decred.org/dcrwallet/v4/errors"
+	"github.com/btcsuite/btcd/blockchain"
 	"github.com/btcsuite/btcd/btcutil"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
 	sharedW "github.com/crypto-power/cryptopower/libwallet/assets/wallet"
 	"github.com/crypto-power/cryptopower/libwallet/utils"
 )
@@ -18,6 +17,22 @@ const (
 	MainnetAPIFeeRateURL = "https://blockstream.info/api/fee-estimates"
 	// TestnetAPIFeeRateURL defines the URL to fetch the testnet fee rate from.
 	TestnetAPIFeeRateURL = "https://blockstream.info/testnet/api/fee-estimates"
+	// MainnetMempoolFeeRateURL defines the mempool.space URL to fetch the
+	// mainnet fee rate from.
+	MainnetMempoolFeeRateURL = "https://mempool.space/api/v1/fees/recommended"
+	// TestnetMempoolFeeRateURL defines the mempool.space URL to fetch the
+	// testnet fee rate from.
+	TestnetMempoolFeeRateURL = "https://mempool.space/testnet/api/v1/fees/recommended"
+	// MainnetBlockcypherFeeRateURL defines the blockcypher URL to fetch the
+	// mainnet fee rate from.
+	MainnetBlockcypherFeeRateURL = "https://api.blockcypher.com/v1/btc/main"
+	// TestnetBlockcypherFeeRateURL defines the blockcypher URL to fetch the
+	// testnet fee rate from.
+	TestnetBlockcypherFeeRateURL = "https://api.blockcypher.com/v1/btc/test3"
+
+	// recentFeeBlocks is the number of recent blocks fee rates are estimated
+	// from when syncing with neutrino.
+	recentFeeBlocks = 6
 
 	// Since the introduction of segwit account, a different tx size measument was
 	// introduced (Sat/VB). When sending a transaction from the legacy account,
@@ -42,6 +57,20 @@ const (
 	MinFeeRatePerkvB btcutil.Amount = 1000 // Equals to 1 sat/vB.
 )
 
+// feeRateAPIs are the HTTP APIs queried for fee estimates on each network.
+var feeRateAPIs = map[utils.NetworkType][]sharedW.FeeRateAPI{
+	utils.Mainnet: {
+		{Name: "blockstream.info", URL: MainnetAPIFeeRateURL, Format: sharedW.EsploraFeeRates},
+		{Name: "mempool.space", URL: MainnetMempoolFeeRateURL, Format: sharedW.MempoolFeeRates},
+		{Name: "blockcypher", URL: MainnetBlockcypherFeeRateURL, Format: sharedW.BlockcypherFeeRates},
+	},
+	utils.Testnet: {
+		{Name: "blockstream.info", URL: TestnetAPIFeeRateURL, Format: sharedW.EsploraFeeRates},
+		{Name: "mempool.space", URL: TestnetMempoolFeeRateURL, Format: sharedW.MempoolFeeRates},
+		{Name: "blockcypher", URL: TestnetBlockcypherFeeRateURL, Format: sharedW.BlockcypherFeeRates},
+	},
+}
+
 // feeEstimateCache helps to cache the resolved fee rate until a new
 // block is mined
 type feeEstimateCache struct {
@@ -54,60 +83,125 @@ type feeEstimateCache struct {
 	// LastBestblock defines the last height when results were cached. This
 	// helps to keep the API calls to under control.
 	LastBestblock int32
+	// blockFeeRates are the fee rates of the recent blocks, by block hash.
+	blockFeeRates map[chainhash.Hash]blockFeeRate
 
 	mu sync.RWMutex
 }
 
-// fetchAPIFeeRate queries the API fee rate.
-func (asset *Asset) fetchAPIFeeRate() ([]sharedW.FeeEstimate, error) {
-	var feerateURL string
-	net := asset.NetType()
-	switch net {
-	case utils.Mainnet:
-		feerateURL = MainnetAPIFeeRateURL
-	case utils.Testnet:
-		feerateURL = TestnetAPIFeeRateURL
-	default:
-		return nil, fmt.Errorf("%v network is not supported", net)
+// feeEstimateProviders returns the sources of the fee estimates of the
+// wallet: the full node or Electrum servers it syncs from, or the recent
+// blocks it fetched when syncing with neutrino and the HTTP APIs of its
+// network. The wallets syncing from a full node or Electrum servers don't
+// query the HTTP APIs, which would learn of the wallet.
+func (asset *Asset) feeEstimateProviders() []sharedW.FeeEstimateProvider {
+	var providers []sharedW.FeeEstimateProvider
+	if cfg := asset.RPCConfig(); cfg != nil {
+		providers = append(providers, sharedW.NewFeeEstimateProvider("full node", func() ([]sharedW.FeeEstimate, error) {
+			return asset.fetchNodeFeeRate(cfg)
+		}))
+	}
+	if cfg := asset.ElectrumConfig(); cfg != nil {
+		providers = append(providers, sharedW.NewFeeEstimateProvider("electrum servers", func() ([]sharedW.FeeEstimate, error) {
+			return asset.fetchElectrumFeeRate(cfg)
+		}))
+	}
+	if len(providers) > 0 {
+		return providers
 	}
 
-	resp := make(map[string]float64, 0)
+	if asset.chainClient != nil && asset.IsSynced() {
+		providers = append(providers, sharedW.NewFeeEstimateProvider("recent blocks", asset.recentBlocksFeeRate))
+	}
+	for _, api := range feeRateAPIs[asset.NetType()] {
+		providers = append(providers, api.Provider(asset.ToAmount))
+	}
+	return providers
+}
 
-	req := &utils.ReqConfig{
-		Method:  http.MethodGet,
-		HTTPURL: feerateURL,
+// fetchAPIFeeRate returns the median of the fee estimates of every source
+// for each confirmation target.
+func (asset *Asset) fetchAPIFeeRate() ([]sharedW.FeeEstimate, error) {
+	providers := asset.feeEstimateProviders()
+	if len(providers) == 0 {
+		return nil, fmt.Errorf("%v network is not supported", asset.NetType())
 	}
+	return sharedW.MedianFeeEstimates(providers, sharedW.FeeEstimateTargets, asset.ToAmount)
+}
 
-	if _, err := utils.HTTPRequest(req, &resp); err != nil {
-		return nil, fmt.Errorf("fetching API fee estimates failed: %v", err)
+// blockFeeRate is the average fee rate of a recent block, cached so that
+// only the blocks mined since the last estimate are fetched.
+type blockFeeRate struct {
+	prevHash chainhash.Hash
+	// rate is 0 for blocks without fees, such as empty blocks.
+	rate int64
+}
+
+// recentBlocksFeeRate estimates the fee rates from the average fee rate of the
+// last recentFeeBlocks blocks, fetched from the neutrino peers. The blocks
+// count towards the data the wallet syncs, they aren't fetched when the sync
+// policy doesn't let the wallet sync.
+func (asset *Asset) recentBlocksFeeRate() ([]sharedW.FeeEstimate, error) {
+	if gate := asset.SyncGate(); gate != nil {
+		if err := gate.Check(); err != nil {
+			return nil, err
+		}
 	}
 
-	// if no data was returned, return an error.
-	if len(resp) == 0 {
-		return nil, errors.New("API fee estimates not found")
+	bestHash, bestHeight, err := asset.chainClient.GetBestBlock()
+	if err != nil {
+		return nil, err
 	}
 
-	results := make([]sharedW.FeeEstimate, 0, len(resp))
+	asset.fees.mu.RLock()
+	cached := asset.fees.blockFeeRates
+	asset.fees.mu.RUnlock()
+
+	blockRates := make(map[chainhash.Hash]blockFeeRate, recentFeeBlocks)
+	rates := make([]int64, 0, recentFeeBlocks)
+	hash := *bestHash
+	for height := bestHeight; height > bestHeight-recentFeeBlocks && height > 0; height-- {
+		blockRate, ok := cached[hash]
+		if !ok {
+			block, err := asset.chainClient.GetBlock(&hash)
+			if err != nil {
+				return nil, err
+			}
+			blockRate.prevHash = block.Header.PrevBlock
+
+			// The coinbase collects the subsidy and the fees of the block.
+			var fees int64
+			for _, txOut := range block.Transactions[0].TxOut {
+				fees += txOut.Value
+			}
+			fees -= blockchain.CalcBlockSubsidy(height, asset.chainParams)
+			vsize := (blockchain.GetBlockWeight(btcutil.NewBlock(block)) + blockchain.WitnessScaleFactor - 1) /
+				blockchain.WitnessScaleFactor
+			if fees > 0 && vsize > 0 {
+				blockRate.rate = max(fees*1000/vsize, int64(MinFeeRatePerkvB))
+			}
+		}
+		blockRates[hash] = blockRate
+		hash = blockRate.prevHash
 
-	// Fee rate returned is in Sat/vB units.
-	for blocks, feerate := range resp {
-		vals, err := strconv.ParseInt(blocks, 10, 64)
-		if err != nil {
-			// Invalid blocks confirmation found ignore it,
-			continue
+		// Blocks without fees tell nothing.
+		if blockRate.rate > 0 {
+			rates = append(rates, blockRate.rate)
 		}
+	}
+
+	asset.fees.mu.Lock()
+	asset.fees.blockFeeRates = blockRates
+	asset.fees.mu.Unlock()
 
-		results = append(results, sharedW.FeeEstimate{
-			ConfirmedBlocks: int32(vals),
-			// Fee rate conversion from Sat/vB to Sat/kvB is at the rate of
-			// 1000 Sat/kvB == 1 Sat/vB
-			Feerate: Amount(int(feerate * 1000.0)),
-		})
+	if len(rates) == 0 {
+		return nil, errors.New("no recent blocks with fees")
 	}
-	return results, nil
+	return sharedW.BlockFeeEstimates(rates, asset.ToAmount), nil
 }
 
-// GetAPIFeeEstimateRate returns the fee estimates from the API.
+// GetAPIFeeEstimateRate returns the fee estimates for each confirmation
+// target, sorted by target.
 func (asset *Asset) GetAPIFeeEstimateRate() (feerates []sharedW.FeeEstimate, err error) {
 	asset.fees.mu.RLock()
 	feerates = asset.fees.APIFeeRates
@@ -129,15 +223,6 @@ func (asset *Asset) GetAPIFeeEstimateRate() (feerates []sharedW.FeeEstimate, err
 		return nil, errors.New("API feerates not available")
 	}
 
-	sort.Slice(feerates, func(i, j int) bool {
-		return feerates[i].ConfirmedBlocks < feerates[j].ConfirmedBlocks
-	})
-
-	if len(feerates) > 5 {
-		// TODO: subject to confirmation! => persist top five fee rates only.
-		feerates = feerates[:5]
-	}
-
 	asset.fees.mu.Lock()
 	asset.fees.APIFeeRates = feerates
 	asset.fees.LastBestblock = asset.GetBestBlockHeight()
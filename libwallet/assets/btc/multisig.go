@@ -0,0 +1,693 @@
+package btc
+
+import (
+	"encoding/hex"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"decred.org/dcrwallet/v4/errors"
+	"github.com/btcsuite/btcd/btcjson"
+	"github.com/btcsuite/btcd/btcutil"
+	"github.com/btcsuite/btcd/btcutil/hdkeychain"
+	"github.com/btcsuite/btcd/btcutil/psbt"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/btcsuite/btcwallet/waddrmgr"
+	"github.com/btcsuite/btcwallet/wallet/txrules"
+	"github.com/btcsuite/btcwallet/walletdb"
+	sharedW "github.com/crypto-power/cryptopower/libwallet/assets/wallet"
+	"github.com/crypto-power/cryptopower/libwallet/utils"
+)
+
+// multisigScope returns the key scope of the keys dedicated to multisig,
+// which uses the BIP48 purpose so that the multisig keys aren't derived from
+// the paths of the wallet's single-sig addresses.
+func multisigScope() waddrmgr.KeyScope {
+	return waddrmgr.KeyScope{Purpose: 48, Coin: GetScope().Coin}
+}
+
+// DeriveMultisigXPub returns the xpub of the default account of the key
+// scope dedicated to multisig, m/48'/coin'/0', creating the scope with the
+// private passphrase the first time. The xpub is stored to be shared with the
+// cosigners and used by SetupMultisig.
+func (asset *Asset) DeriveMultisigXPub(privatePassphrase string) (string, error) {
+	if xpub := asset.MultisigXPub(); xpub != "" {
+		return xpub, nil
+	}
+	if !asset.WalletOpened() {
+		return "", utils.ErrBTCNotInitialized
+	}
+	if asset.IsWatchingOnlyWallet() {
+		return "", errors.New(utils.ErrWalletIsWatchOnly)
+	}
+
+	wallet := asset.Internal().BTC
+	if _, err := wallet.Manager.FetchScopedKeyManager(multisigScope()); err != nil {
+		lock := make(chan time.Time, 1)
+		defer func() {
+			lock <- time.Time{}
+		}()
+		if err := wallet.Unlock([]byte(privatePassphrase), lock); err != nil {
+			log.Errorf("unlocking the wallet failed: %v", err)
+			return "", errors.New(utils.ErrInvalidPassphrase)
+		}
+
+		err = walletdb.Update(wallet.Database(), func(dbtx walletdb.ReadWriteTx) error {
+			ns := dbtx.ReadWriteBucket(wAddrMgrBkt)
+			_, err := wallet.Manager.NewScopedKeyManager(ns, multisigScope(), waddrmgr.ScopeAddrSchema{
+				ExternalAddrType: waddrmgr.WitnessPubKey,
+				InternalAddrType: waddrmgr.WitnessPubKey,
+			})
+			return err
+		})
+		if err != nil {
+			return "", fmt.Errorf("creating the multisig key scope failed: %v", err)
+		}
+	}
+
+	props, err := wallet.AccountProperties(multisigScope(), DefaultAccountNum)
+	if err != nil {
+		return "", err
+	}
+	xpub := props.AccountPubKey.String()
+	return xpub, asset.SaveMultisigXPub(xpub)
+}
+
+// SetupMultisig turns the wallet into an m-of-n P2WSH multisig wallet. The
+// xpub derived by DeriveMultisigXPub is added to the provided cosigners.
+// Only a wallet without transactions can be set up as a multisig wallet.
+func (asset *Asset) SetupMultisig(required int, cosigners []*sharedW.Cosigner) error {
+	if !asset.WalletOpened() {
+		return utils.ErrBTCNotInitialized
+	}
+
+	if asset.IsMultisig() {
+		return errors.E(errors.Exist, "wallet is already a multisig wallet")
+	}
+
+	txCount, err := asset.CountTransactions(utils.TxFilterAll)
+	if err != nil {
+		return err
+	}
+	if txCount > 0 {
+		return errors.E(errors.Invalid, "only a wallet without transactions can be set up as a multisig wallet")
+	}
+
+	localXPub := asset.MultisigXPub()
+	if localXPub == "" {
+		return errors.E(errors.Invalid, "the multisig xpub must be derived first")
+	}
+
+	cfg := &sharedW.MultisigConfig{
+		Required:      required,
+		LocalXPub:     localXPub,
+		DedicatedKeys: true,
+		Cosigners:     []*sharedW.Cosigner{{Name: asset.GetWalletName(), XPub: localXPub}},
+	}
+	for _, cosigner := range cosigners {
+		if cosigner.XPub == localXPub {
+			continue
+		}
+		if _, err := asset.multisigPubKey(cosigner.XPub, 0, 0); err != nil {
+			return errors.E(errors.Invalid, fmt.Sprintf("invalid xpub for cosigner %q: %v", cosigner.Name, err))
+		}
+		cfg.Cosigners = append(cfg.Cosigners, cosigner)
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return err
+	}
+
+	if err := asset.importMultisigAddresses(cfg); err != nil {
+		return err
+	}
+	return asset.SaveMultisigConfig(cfg)
+}
+
+// NewMultisigAddress returns a new receive address of the multisig wallet.
+func (asset *Asset) NewMultisigAddress() (string, error) {
+	return asset.nextMultisigAddress(sharedW.MultisigExternalBranch)
+}
+
+// MultisigBalance returns the spendable balance of the multisig addresses.
+func (asset *Asset) MultisigBalance() (sharedW.AssetAmount, error) {
+	cfg := asset.MultisigConfig()
+	if cfg == nil {
+		return nil, errors.New(utils.ErrNotMultisigWallet)
+	}
+
+	unspents, err := asset.multisigUnspents(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	var total btcutil.Amount
+	for _, unspent := range unspents {
+		amount, _ := btcutil.NewAmount(unspent.Amount)
+		total += amount
+	}
+	return Amount(total), nil
+}
+
+// CreateMultisigProposal creates a transaction spending the multisig funds
+// to address and returns it as a base64 encoded PSBT to be signed by the
+// cosigners.
+func (asset *Asset) CreateMultisigProposal(address string, amount int64, sendMax bool) (string, error) {
+	cfg := asset.MultisigConfig()
+	if cfg == nil {
+		return "", errors.New(utils.ErrNotMultisigWallet)
+	}
+
+	if err := asset.validateSendAmount(sendMax, amount); err != nil {
+		return "", err
+	}
+
+	destAddr, err := btcutil.DecodeAddress(address, asset.chainParams)
+	if err != nil {
+		return "", utils.TranslateError(err)
+	}
+	destScript, err := txscript.PayToAddrScript(destAddr)
+	if err != nil {
+		return "", err
+	}
+
+	unspents, err := asset.multisigUnspents(cfg)
+	if err != nil {
+		return "", err
+	}
+	if !sendMax {
+		// Spend the largest outputs first to keep the transaction small.
+		sort.Slice(unspents, func(i, j int) bool { return unspents[i].Amount > unspents[j].Amount })
+	}
+
+	feeRate := btcutil.Amount(asset.GetUserFeeRate().ToInt())
+	tx := wire.NewMsgTx(wire.TxVersion)
+	destOutput := wire.NewTxOut(amount, destScript)
+	// A placeholder change output is used in the fee estimation.
+	changeOutput := wire.NewTxOut(0, destScript)
+
+	var (
+		totalInput btcutil.Amount
+		fee        btcutil.Amount
+		prevOuts   []*wire.TxOut
+		scripts    [][]byte
+	)
+	for _, unspent := range unspents {
+		addrInfo := cfg.AddressInfo(unspent.Address)
+		txHash, err := chainhash.NewHashFromStr(unspent.TxID)
+		if err != nil {
+			return "", err
+		}
+		pkScript, err := hex.DecodeString(unspent.ScriptPubKey)
+		if err != nil {
+			return "", err
+		}
+		script, err := hex.DecodeString(addrInfo.Script)
+		if err != nil {
+			return "", err
+		}
+
+		value, _ := btcutil.NewAmount(unspent.Amount)
+		tx.AddTxIn(wire.NewTxIn(wire.NewOutPoint(txHash, unspent.Vout), nil, nil))
+		prevOuts = append(prevOuts, wire.NewTxOut(int64(value), pkScript))
+		scripts = append(scripts, script)
+		totalInput += value
+
+		if sendMax {
+			continue
+		}
+		fee = multisigTxFee(len(tx.TxIn), []*wire.TxOut{destOutput, changeOutput}, cfg, feeRate)
+		if totalInput >= btcutil.Amount(amount)+fee {
+			break
+		}
+	}
+
+	if sendMax {
+		fee = multisigTxFee(len(tx.TxIn), []*wire.TxOut{destOutput}, cfg, feeRate)
+		destOutput.Value = int64(totalInput - fee)
+	} else if totalInput < btcutil.Amount(amount)+fee {
+		return "", errors.New(utils.ErrInsufficientBalance)
+	}
+
+	if len(tx.TxIn) == 0 || destOutput.Value <= 0 {
+		return "", errors.New(utils.ErrInsufficientBalance)
+	}
+	if err := txrules.CheckOutput(destOutput, feeRate); err != nil {
+		return "", fmt.Errorf("main txOut validation failed %v", err)
+	}
+	tx.AddTxOut(destOutput)
+
+	if !sendMax {
+		changeOutput.Value = int64(totalInput - fee - btcutil.Amount(amount))
+		// Dust change is added to the fee.
+		if !txrules.IsDustOutput(changeOutput, txrules.DefaultRelayFeePerKb) {
+			changeAddress, err := asset.nextMultisigAddress(sharedW.MultisigInternalBranch)
+			if err != nil {
+				return "", err
+			}
+			changeAddr, err := btcutil.DecodeAddress(changeAddress, asset.chainParams)
+			if err != nil {
+				return "", err
+			}
+			changeOutput.PkScript, err = txscript.PayToAddrScript(changeAddr)
+			if err != nil {
+				return "", err
+			}
+			tx.AddTxOut(changeOutput)
+		}
+	}
+
+	packet, err := psbt.NewFromUnsignedTx(tx)
+	if err != nil {
+		return "", err
+	}
+	updater, err := psbt.NewUpdater(packet)
+	if err != nil {
+		return "", err
+	}
+	for i := range tx.TxIn {
+		if err := updater.AddInWitnessUtxo(prevOuts[i], i); err != nil {
+			return "", err
+		}
+		if err := updater.AddInWitnessScript(scripts[i], i); err != nil {
+			return "", err
+		}
+	}
+	return packet.B64Encode()
+}
+
+// MultisigProposalInfo decodes a multisig transaction proposal.
+func (asset *Asset) MultisigProposalInfo(proposal string) (*sharedW.MultisigProposalInfo, error) {
+	cfg := asset.MultisigConfig()
+	if cfg == nil {
+		return nil, errors.New(utils.ErrNotMultisigWallet)
+	}
+
+	packet, err := psbt.NewFromRawBytes(strings.NewReader(strings.TrimSpace(proposal)), true)
+	if err != nil {
+		return nil, errors.E(errors.Invalid, fmt.Sprintf("invalid proposal: %v", err))
+	}
+
+	fee, err := packet.GetTxFee()
+	if err != nil {
+		return nil, err
+	}
+
+	info := &sharedW.MultisigProposalInfo{
+		TxID:       packet.UnsignedTx.TxHash().String(),
+		Fee:        int64(fee),
+		Required:   cfg.Required,
+		Signatures: math.MaxInt32,
+	}
+
+	for _, txOut := range packet.UnsignedTx.TxOut {
+		output := &sharedW.MultisigOutput{Amount: txOut.Value}
+		_, addrs, _, err := txscript.ExtractPkScriptAddrs(txOut.PkScript, asset.chainParams)
+		if err == nil && len(addrs) > 0 {
+			output.Address = addrs[0].String()
+			output.IsChange = cfg.AddressInfo(output.Address) != nil
+		}
+		info.Outputs = append(info.Outputs, output)
+	}
+
+	for i, pInput := range packet.Inputs {
+		addrInfo, err := asset.multisigInputAddress(cfg, &pInput)
+		if err != nil {
+			return nil, err
+		}
+
+		signatures := len(pInput.PartialSigs)
+		if len(pInput.FinalScriptWitness) > 0 {
+			signatures = cfg.Required
+		}
+		info.Signatures = min(info.Signatures, signatures)
+
+		if i > 0 {
+			continue
+		}
+		localPubKey, err := asset.multisigPubKey(cfg.LocalXPub, addrInfo.Branch, addrInfo.Index)
+		if err != nil {
+			return nil, err
+		}
+		for _, sig := range pInput.PartialSigs {
+			if string(sig.PubKey) == string(localPubKey) {
+				info.SignedByLocal = true
+			}
+		}
+	}
+	if len(packet.Inputs) == 0 {
+		info.Signatures = 0
+	}
+	return info, nil
+}
+
+// SignMultisigProposal adds the wallet's signatures to a multisig transaction
+// proposal and returns the updated proposal.
+func (asset *Asset) SignMultisigProposal(proposal, privatePassphrase string) (string, error) {
+	cfg := asset.MultisigConfig()
+	if cfg == nil {
+		return "", errors.New(utils.ErrNotMultisigWallet)
+	}
+	if asset.IsWatchingOnlyWallet() {
+		return "", errors.New(utils.ErrWalletIsWatchOnly)
+	}
+
+	packet, err := psbt.NewFromRawBytes(strings.NewReader(strings.TrimSpace(proposal)), true)
+	if err != nil {
+		return "", errors.E(errors.Invalid, fmt.Sprintf("invalid proposal: %v", err))
+	}
+
+	lock := make(chan time.Time, 1)
+	defer func() {
+		lock <- time.Time{}
+	}()
+	if err := asset.Internal().BTC.Unlock([]byte(privatePassphrase), lock); err != nil {
+		log.Errorf("unlocking the wallet failed: %v", err)
+		return "", errors.New(utils.ErrInvalidPassphrase)
+	}
+
+	tx := packet.UnsignedTx
+	prevOutFetcher := txscript.NewMultiPrevOutFetcher(nil)
+	for i, pInput := range packet.Inputs {
+		if pInput.WitnessUtxo == nil {
+			return "", errors.E(errors.Invalid, "proposal input is missing its previous output")
+		}
+		prevOutFetcher.AddPrevOut(tx.TxIn[i].PreviousOutPoint, pInput.WitnessUtxo)
+	}
+	sigHashes := txscript.NewTxSigHashes(tx, prevOutFetcher)
+
+	updater, err := psbt.NewUpdater(packet)
+	if err != nil {
+		return "", err
+	}
+
+	err = walletdb.View(asset.Internal().BTC.Database(), func(dbtx walletdb.ReadTx) error {
+		ns := dbtx.ReadBucket(wAddrMgrBkt)
+		scope := GetScope()
+		if cfg.DedicatedKeys {
+			scope = multisigScope()
+		}
+		scopedMgr, err := asset.Internal().BTC.Manager.FetchScopedKeyManager(scope)
+		if err != nil {
+			return err
+		}
+
+		for i := range packet.Inputs {
+			pInput := &packet.Inputs[i]
+			addrInfo, err := asset.multisigInputAddress(cfg, pInput)
+			if err != nil {
+				return err
+			}
+			if len(pInput.PartialSigs) >= cfg.Required {
+				continue
+			}
+
+			managedAddr, err := scopedMgr.DeriveFromKeyPath(ns, waddrmgr.DerivationPath{
+				InternalAccount: DefaultAccountNum,
+				Account:         DefaultAccountNum,
+				Branch:          addrInfo.Branch,
+				Index:           addrInfo.Index,
+			})
+			if err != nil {
+				return err
+			}
+			pubKeyAddr, ok := managedAddr.(waddrmgr.ManagedPubKeyAddress)
+			if !ok {
+				return fmt.Errorf("unexpected address type %T", managedAddr)
+			}
+			privKey, err := pubKeyAddr.PrivKey()
+			if err != nil {
+				return err
+			}
+
+			pubKey := privKey.PubKey().SerializeCompressed()
+			signed := false
+			for _, sig := range pInput.PartialSigs {
+				signed = signed || string(sig.PubKey) == string(pubKey)
+			}
+			if signed {
+				continue
+			}
+
+			script, err := hex.DecodeString(addrInfo.Script)
+			if err != nil {
+				return err
+			}
+			sig, err := txscript.RawTxInWitnessSignature(tx, sigHashes, i, pInput.WitnessUtxo.Value,
+				script, txscript.SigHashAll, privKey)
+			if err != nil {
+				return err
+			}
+			outcome, err := updater.Sign(i, sig, pubKey, nil, script)
+			if err != nil {
+				return err
+			}
+			if outcome != psbt.SignSuccesful {
+				return fmt.Errorf("signing input %d failed: %v", i, outcome)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+	return packet.B64Encode()
+}
+
+// BroadcastMultisigProposal finalizes a fully signed multisig transaction
+// proposal and publishes it to the network.
+func (asset *Asset) BroadcastMultisigProposal(proposal, transactionLabel string) (string, error) {
+	if !asset.WalletOpened() {
+		return "", utils.ErrBTCNotInitialized
+	}
+
+	packet, err := psbt.NewFromRawBytes(strings.NewReader(strings.TrimSpace(proposal)), true)
+	if err != nil {
+		return "", errors.E(errors.Invalid, fmt.Sprintf("invalid proposal: %v", err))
+	}
+
+	if err := psbt.MaybeFinalizeAll(packet); err != nil {
+		return "", err
+	}
+	if !packet.IsComplete() {
+		return "", errors.E(errors.Invalid, "the proposal does not have enough signatures")
+	}
+
+	msgTx, err := psbt.Extract(packet)
+	if err != nil {
+		return "", err
+	}
+
+	// The multisig sends spend the shared addresses rather than an account,
+	// they are limited by the policy of the whole wallet.
+	err = asset.publishWithPolicy(msgTx, sharedW.AllAccounts, asset.externalOutputs(msgTx), transactionLabel, true)
+	if err != nil && err.Error() == utils.ErrSendDelayed {
+		return "", err
+	}
+	return msgTx.TxHash().String(), utils.TranslateError(err)
+}
+
+// nextMultisigAddress returns the next unused multisig address of branch and
+// imports the addresses that should now be watched.
+func (asset *Asset) nextMultisigAddress(branch uint32) (string, error) {
+	var address string
+	err := asset.UpdateMultisigConfig(func(cfg *sharedW.MultisigConfig) error {
+		index := cfg.NextExternalIndex
+		if branch == sharedW.MultisigInternalBranch {
+			index = cfg.NextInternalIndex
+			cfg.NextInternalIndex++
+		} else {
+			cfg.NextExternalIndex++
+		}
+
+		if err := asset.importMultisigAddresses(cfg); err != nil {
+			return err
+		}
+		for _, addr := range cfg.Addresses {
+			if addr.Branch == branch && addr.Index == index {
+				address = addr.Address
+			}
+		}
+		return nil
+	})
+	return address, err
+}
+
+// importMultisigAddresses imports the witness scripts of the multisig
+// addresses within the gap limit of both branches, so that the wallet watches
+// them, and adds them to cfg.
+func (asset *Asset) importMultisigAddresses(cfg *sharedW.MultisigConfig) error {
+	var (
+		newAddrs []*sharedW.MultisigAddress
+		scripts  [][]byte
+		addrs    []btcutil.Address
+	)
+	for _, branch := range []uint32{sharedW.MultisigExternalBranch, sharedW.MultisigInternalBranch} {
+		next := cfg.NextExternalIndex
+		if branch == sharedW.MultisigInternalBranch {
+			next = cfg.NextInternalIndex
+		}
+
+		for _, index := range cfg.MissingIndexes(branch, next) {
+			script, err := asset.multisigScript(cfg, branch, index)
+			if err != nil {
+				return err
+			}
+			scriptHash := chainhash.HashB(script)
+			addr, err := btcutil.NewAddressWitnessScriptHash(scriptHash, asset.chainParams)
+			if err != nil {
+				return err
+			}
+
+			scripts = append(scripts, script)
+			addrs = append(addrs, addr)
+			newAddrs = append(newAddrs, &sharedW.MultisigAddress{
+				Address: addr.String(),
+				Branch:  branch,
+				Index:   index,
+				Script:  hex.EncodeToString(script),
+			})
+		}
+	}
+
+	if len(newAddrs) == 0 {
+		return nil
+	}
+
+	wallet := asset.Internal().BTC
+	err := walletdb.Update(wallet.Database(), func(dbtx walletdb.ReadWriteTx) error {
+		ns := dbtx.ReadWriteBucket(wAddrMgrBkt)
+		scopedMgr, err := wallet.Manager.FetchScopedKeyManager(GetScope())
+		if err != nil {
+			return err
+		}
+
+		// The scripts are new, there is no history to rescan before the
+		// current sync height.
+		bs := wallet.Manager.SyncedTo()
+		for _, script := range scripts {
+			_, err := scopedMgr.ImportWitnessScript(ns, script, &bs, 0, false)
+			if err != nil && !waddrmgr.IsError(err, waddrmgr.ErrDuplicateAddress) {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("importing multisig scripts failed: %v", err)
+	}
+
+	if chainClient := wallet.ChainClient(); chainClient != nil {
+		if err := chainClient.NotifyReceived(addrs); err != nil {
+			log.Errorf("watching multisig addresses failed: %v", err)
+		}
+	}
+
+	cfg.Addresses = append(cfg.Addresses, newAddrs...)
+	return nil
+}
+
+// multisigScript returns the BIP67 sorted m-of-n multisig script of the
+// cosigner keys at branch/index.
+func (asset *Asset) multisigScript(cfg *sharedW.MultisigConfig, branch, index uint32) ([]byte, error) {
+	pubKeys := make([][]byte, 0, len(cfg.Cosigners))
+	for _, xpub := range cfg.XPubs() {
+		pubKey, err := asset.multisigPubKey(xpub, branch, index)
+		if err != nil {
+			return nil, err
+		}
+		pubKeys = append(pubKeys, pubKey)
+	}
+	sharedW.SortPubKeys(pubKeys)
+
+	builder := txscript.NewScriptBuilder().AddInt64(int64(cfg.Required))
+	for _, pubKey := range pubKeys {
+		builder.AddData(pubKey)
+	}
+	builder.AddInt64(int64(len(pubKeys))).AddOp(txscript.OP_CHECKMULTISIG)
+	return builder.Script()
+}
+
+// multisigPubKey derives the compressed public key at branch/index of xpub.
+func (asset *Asset) multisigPubKey(xpub string, branch, index uint32) ([]byte, error) {
+	key, err := hdkeychain.NewKeyFromString(strings.TrimSpace(xpub))
+	if err != nil {
+		return nil, err
+	}
+	if key.IsPrivate() || !key.IsForNet(asset.chainParams) {
+		return nil, errors.E(errors.Invalid, "xpub is not an extended public key for this network")
+	}
+
+	branchKey, err := key.Derive(branch)
+	if err != nil {
+		return nil, err
+	}
+	childKey, err := branchKey.Derive(index)
+	if err != nil {
+		return nil, err
+	}
+	pubKey, err := childKey.ECPubKey()
+	if err != nil {
+		return nil, err
+	}
+	return pubKey.SerializeCompressed(), nil
+}
+
+// multisigUnspents returns the spendable outputs paid to the multisig
+// addresses.
+func (asset *Asset) multisigUnspents(cfg *sharedW.MultisigConfig) ([]*btcjson.ListUnspentResult, error) {
+	unspents, err := asset.Internal().BTC.ListUnspent(asset.RequiredConfirmations(), math.MaxInt32, "")
+	if err != nil {
+		return nil, err
+	}
+
+	multisigUnspents := make([]*btcjson.ListUnspentResult, 0, len(unspents))
+	for _, unspent := range unspents {
+		if cfg.AddressInfo(unspent.Address) != nil {
+			multisigUnspents = append(multisigUnspents, unspent)
+		}
+	}
+	return multisigUnspents, nil
+}
+
+// multisigInputAddress returns the multisig address spent by a proposal
+// input.
+func (asset *Asset) multisigInputAddress(cfg *sharedW.MultisigConfig, pInput *psbt.PInput) (*sharedW.MultisigAddress, error) {
+	if pInput.WitnessUtxo == nil {
+		return nil, errors.E(errors.Invalid, "proposal input is missing its previous output")
+	}
+
+	_, addrs, _, err := txscript.ExtractPkScriptAddrs(pInput.WitnessUtxo.PkScript, asset.chainParams)
+	if err != nil || len(addrs) != 1 {
+		return nil, errors.E(errors.Invalid, "proposal input is not a multisig output")
+	}
+
+	addrInfo := cfg.AddressInfo(addrs[0].String())
+	if addrInfo == nil {
+		return nil, errors.E(errors.Invalid, fmt.Sprintf("proposal spends unknown address %s", addrs[0]))
+	}
+	return addrInfo, nil
+}
+
+// multisigTxFee estimates the fee of a transaction spending numInputs P2WSH
+// multisig outputs to outputs.
+func multisigTxFee(numInputs int, outputs []*wire.TxOut, cfg *sharedW.MultisigConfig, feeRate btcutil.Amount) btcutil.Amount {
+	// OP_m <n * pubkey push> OP_n OP_CHECKMULTISIG
+	scriptSize := 1 + len(cfg.Cosigners)*(1+33) + 2
+	// Item count, the empty CHECKMULTISIG dummy, m signatures and the script.
+	witnessSize := 1 + 1 + cfg.Required*(1+73) + wire.VarIntSerializeSize(uint64(scriptSize)) + scriptSize
+
+	baseSize := 8 + wire.VarIntSerializeSize(uint64(numInputs)) + wire.VarIntSerializeSize(uint64(len(outputs))) +
+		numInputs*(32+4+1+4)
+	for _, output := range outputs {
+		baseSize += output.SerializeSize()
+	}
+
+	// The segwit marker and flag bytes add 2 to the weight.
+	weight := baseSize*4 + 2 + numInputs*witnessSize
+	return txrules.FeeForSerializeSize(feeRate, (weight+3)/4)
+}
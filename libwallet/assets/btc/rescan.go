@@ -7,13 +7,20 @@ import (
 
 	"decred.org/dcrwallet/v4/errors"
 	"github.com/btcsuite/btcd/btcutil"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
 	"github.com/btcsuite/btcwallet/waddrmgr"
 	w "github.com/btcsuite/btcwallet/wallet"
 	"github.com/btcsuite/btcwallet/walletdb"
+	"github.com/btcsuite/btcwallet/wtxmgr"
 	sharedW "github.com/crypto-power/cryptopower/libwallet/assets/wallet"
 	"github.com/crypto-power/cryptopower/libwallet/utils"
 )
 
+// rescanProgressInterval is how often the progress of a rescan is saved.
+const rescanProgressInterval = 10 * time.Second
+
 // SetBlocksRescanProgressListener sets the blocks rescan progress listener.
 func (asset *Asset) SetBlocksRescanProgressListener(blocksRescanProgressListener *sharedW.BlocksRescanProgressListener) {
 	asset.blocksRescanProgressListener = blocksRescanProgressListener
@@ -27,10 +34,39 @@ func (asset *Asset) RescanBlocks() error {
 // RescanBlocksFromHeight rescans the blockchain for all addresses in the wallet
 // starting from the provided block height.
 func (asset *Asset) RescanBlocksFromHeight(startHeight int32) error {
-	return asset.rescanBlocks(startHeight, nil)
+	return asset.rescan(&sharedW.RescanState{StartHeight: startHeight, ScannedThrough: startHeight})
+}
+
+// RescanAddresses rescans the blockchain from startHeight for the provided
+// addresses only, such as the addresses of newly imported keys.
+func (asset *Asset) RescanAddresses(startHeight int32, addrs []string) error {
+	return asset.rescan(&sharedW.RescanState{StartHeight: startHeight, ScannedThrough: startHeight, Addresses: addrs})
+}
+
+// ResumeRescan resumes the rescan that was interrupted, if any.
+func (asset *Asset) ResumeRescan() error {
+	state := asset.PendingRescan()
+	if state == nil {
+		return nil
+	}
+	return asset.rescan(state)
+}
+
+// rescan rescans the blockchain from where the rescan got to, saving its
+// progress so that it resumes if interrupted.
+func (asset *Asset) rescan(state *sharedW.RescanState) error {
+	addrs := make([]btcutil.Address, 0, len(state.Addresses))
+	for _, addr := range state.Addresses {
+		address, err := decodeAddress(addr, asset.chainParams)
+		if err != nil {
+			return errors.E(utils.ErrInvalidAddress)
+		}
+		addrs = append(addrs, address)
+	}
+	return asset.rescanBlocks(state, addrs)
 }
 
-func (asset *Asset) rescanBlocks(startHeight int32, addrs []btcutil.Address) error {
+func (asset *Asset) rescanBlocks(state *sharedW.RescanState, addrs []btcutil.Address) error {
 	if !asset.IsConnectedToBitcoinNetwork() {
 		return errors.E(utils.ErrNotConnected)
 	}
@@ -47,18 +83,25 @@ func (asset *Asset) rescanBlocks(startHeight int32, addrs []btcutil.Address) err
 		return errors.E(utils.ErrSyncAlreadyInProgress)
 	}
 
-	bs, err := asset.getblockStamp(startHeight)
+	bs, err := asset.getblockStamp(state.ScannedThrough)
 	if err != nil {
 		return err
 	}
 
-	if addrs == nil {
-		addrs = []btcutil.Address{}
+	// The wallet is synced to the blocks rescanned from here, which tells
+	// how far the rescan got. It is also where the wallet syncs from if the
+	// rescan is interrupted.
+	err = walletdb.Update(asset.Internal().BTC.Database(), func(dbtx walletdb.ReadWriteTx) error {
+		return asset.Internal().BTC.Manager.SetSyncedTo(dbtx.ReadWriteBucket(wAddrMgrBkt), bs)
+	})
+	if err != nil {
+		return err
 	}
 
 	asset.syncData.mu.Lock()
 	asset.syncData.isRescan = true
 	asset.syncData.rescanStartTime = time.Now()
+	asset.syncData.rescanStartHeight = nil
 	asset.syncData.mu.Unlock()
 
 	job := &w.RescanJob{
@@ -67,23 +110,12 @@ func (asset *Asset) rescanBlocks(startHeight int32, addrs []btcutil.Address) err
 		BlockStamp: *bs,
 	}
 
-	// It submits a rescan job without blocking on finishing the rescan.
-	// The rescan success or failure is logged elsewhere, and the channel
-	// is not required to be read, so discard the return value.
+	// It submits a rescan job without blocking on finishing the rescan,
+	// which is tracked in the background.
 	errChan := asset.Internal().BTC.SubmitRescan(job)
 
-	// Listen for the rescan finish event and update it.
-	go func() {
-		for err := range errChan {
-			if err != nil {
-				log.Errorf("rescan job failed: %v", err)
-			}
-		}
-
-		asset.syncData.mu.Lock()
-		asset.syncData.isRescan = false
-		asset.syncData.mu.Unlock()
-	}()
+	asset.SavePendingRescan(state)
+	go asset.trackRescan(state, errChan)
 
 	// Attempt to start up the notifications handler.
 	if atomic.CompareAndSwapUint32(&asset.syncData.syncstarted, stop, start) {
@@ -93,6 +125,112 @@ func (asset *Asset) rescanBlocks(startHeight int32, addrs []btcutil.Address) err
 	return nil
 }
 
+// trackRescan saves the progress of a rescan until the wallet is synced back
+// to the best block when the rescan started. The job submitted only reports
+// that the chain client started the rescan, the height the wallet is synced to
+// follows the blocks rescanned.
+func (asset *Asset) trackRescan(state *sharedW.RescanState, errChan <-chan error) {
+	ctx, _ := asset.ShutdownContextWithCancel()
+	bestBlockHeight := asset.GetBestBlockHeight()
+	ticker := time.NewTicker(rescanProgressInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case err := <-errChan:
+			if err == nil {
+				// Started, a nil channel is never ready again.
+				errChan = nil
+				continue
+			}
+
+			asset.syncData.mu.Lock()
+			asset.syncData.isRescan = false
+			asset.syncData.mu.Unlock()
+			// The rescan resumes the next time the wallet syncs.
+			log.Errorf("rescan job failed: %v", err)
+			return
+
+		case <-ticker.C:
+			asset.syncData.mu.Lock()
+			if !asset.syncData.isRescan {
+				// Canceled.
+				asset.syncData.mu.Unlock()
+				return
+			}
+			height := asset.Internal().BTC.Manager.SyncedTo().Height
+			finished, progressed := state.Advance(height, bestBlockHeight)
+			if finished {
+				asset.syncData.isRescan = false
+				asset.ClearPendingRescan()
+			} else if progressed {
+				asset.SavePendingRescan(state)
+			}
+			asset.syncData.mu.Unlock()
+
+			if !finished {
+				asset.updateRescanProgress(height)
+				continue
+			}
+			if asset.blocksRescanProgressListener != nil {
+				asset.blocksRescanProgressListener.OnBlocksRescanEnded(asset.ID, nil)
+			}
+			return
+
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// stopRescanJob stops the rescan the chain client runs by restarting it from
+// the best block for every address and unspent output of the wallet. The
+// wallet then keeps being notified of its transactions in new blocks, which a
+// rescan for some addresses only would have stopped.
+func (asset *Asset) stopRescanJob() error {
+	if !asset.WalletOpened() || (asset.rpcChainClient() == nil && asset.chainClient == nil) {
+		return nil
+	}
+	chainClient := asset.chainSource()
+
+	wallet := asset.Internal().BTC
+	var (
+		addrs   []btcutil.Address
+		unspent []wtxmgr.Credit
+	)
+	err := walletdb.View(wallet.Database(), func(dbtx walletdb.ReadTx) error {
+		err := wallet.Manager.ForEachActiveAddress(dbtx.ReadBucket(wAddrMgrBkt), func(addr btcutil.Address) error {
+			addrs = append(addrs, addr)
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		unspent, err = wallet.TxStore.UnspentOutputs(dbtx.ReadBucket(wTxMgrBkt))
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	outPoints := make(map[wire.OutPoint]btcutil.Address, len(unspent))
+	for _, output := range unspent {
+		_, outputAddrs, _, err := txscript.ExtractPkScriptAddrs(output.PkScript, asset.chainParams)
+		if err != nil || len(outputAddrs) == 0 {
+			continue
+		}
+		outPoints[output.OutPoint] = outputAddrs[0]
+	}
+
+	bs, err := asset.getblockStamp(asset.GetBestBlockHeight())
+	if err != nil {
+		return err
+	}
+	// The rescan from the best block finishes at once, which also
+	// completes the rescan job of the wallet.
+	return chainClient.Rescan(&bs.Hash, addrs, outPoints)
+}
+
 // IsRescanning returns true if the wallet is currently rescanning the blockchain.
 func (asset *Asset) IsRescanning() bool {
 	asset.syncData.mu.RLock()
@@ -101,17 +239,56 @@ func (asset *Asset) IsRescanning() bool {
 	return asset.syncData.isRescan
 }
 
-// CancelRescan cancels the current rescan.
+// CancelRescan cancels the current rescan. The rescan resumes the next time
+// the wallet syncs, such as after the app restarts.
 func (asset *Asset) CancelRescan() {
 	asset.syncData.mu.Lock()
+	wasRescanning := asset.syncData.isRescan
+	asset.syncData.isRescan = false
+	asset.syncData.mu.Unlock()
+
+	if wasRescanning {
+		asset.stopRescan()
+	}
+
+	if asset.blocksRescanProgressListener != nil {
+		asset.blocksRescanProgressListener.OnBlocksRescanEnded(asset.ID, nil)
+	}
+}
+
+// DiscardRescan cancels the current rescan at the request of the user and
+// forgets it, so that it doesn't resume.
+func (asset *Asset) DiscardRescan() {
+	asset.syncData.mu.Lock()
+	wasRescanning := asset.syncData.isRescan
 	asset.syncData.isRescan = false
+	// The progress of the rescan is saved under the lock, so it can't be
+	// saved again once forgotten.
+	asset.ClearPendingRescan()
 	asset.syncData.mu.Unlock()
 
+	if wasRescanning {
+		asset.stopRescan()
+	}
+
 	if asset.blocksRescanProgressListener != nil {
 		asset.blocksRescanProgressListener.OnBlocksRescanEnded(asset.ID, nil)
 	}
 }
 
+// stopRescan stops the rescan job in the background, unless the wallet is
+// shutting down and the chain client with it.
+func (asset *Asset) stopRescan() {
+	if ctx, _ := asset.ShutdownContextWithCancel(); ctx.Err() != nil {
+		return
+	}
+	go func() {
+		if err := asset.stopRescanJob(); err != nil {
+			log.Errorf("Stopping the rescan failed: %v", err)
+		}
+	}()
+}
+
 // forceRescan forces a full rescan with active address discovery on wallet
 // restart by setting the "synced to" field to nil.
 func (asset *Asset) forceRescan() {
@@ -120,14 +297,9 @@ func (asset *Asset) forceRescan() {
 		ns := dbtx.ReadWriteBucket(wAddrMgrBkt)
 
 		if asset.IsRestored && !asset.ContainsDiscoveredAccounts() {
-			// Force restored wallets on initial run to restore from genesis block.
-			block := asset.Internal().BTC.ChainParams().GenesisBlock
-
-			bs := waddrmgr.BlockStamp{
-				Height:    0,
-				Hash:      block.BlockHash(),
-				Timestamp: block.Header.Timestamp,
-			}
+			// Force restored wallets on initial run to restore from genesis
+			// block, or from the checkpoint before their birthday if known.
+			bs := asset.restoreBirthdayBlock()
 
 			// Setting the verification to true, requests the upstream not to
 			// attempt checking for a better birthday block. This check causes
@@ -161,6 +333,51 @@ func (asset *Asset) forceRescan() {
 	asset.handleSyncUIUpdate()
 }
 
+// restoreBirthdayBlock returns the block a restored wallet is first rescanned
+// from: the last checkpoint of the chain params before its birthday if it was
+// restored from a date, or else the genesis block.
+//
+// Only the rescan starts there. The SPV header sync still fetches the headers
+// from the genesis block: neutrino keeps its headers indexed by height from
+// the genesis block and checks each difficulty retarget against the headers
+// before it, so it can't start from a checkpoint. The Electrum backend syncs
+// its headers from the last checkpoint instead.
+func (asset *Asset) restoreBirthdayBlock() waddrmgr.BlockStamp {
+	genesis := asset.chainParams.GenesisBlock
+	genesisStamp := waddrmgr.BlockStamp{
+		Height:    0,
+		Hash:      genesis.BlockHash(),
+		Timestamp: genesis.Header.Timestamp,
+	}
+
+	birthday := asset.RestoreBirthday()
+	if birthday.IsZero() {
+		return genesisStamp
+	}
+
+	network := asset.NetType()
+	genesisTimestamp, targetTimePerBlock := GetGenesisTimestamp(network), GetTargetTimePerBlock(network)
+	height := sharedW.EstimateBlockHeight(genesisTimestamp, targetTimePerBlock, birthday.Add(-sharedW.RestoreBirthdayMargin))
+	// The checkpoints of the chain params are sorted by height.
+	var checkpoint *chaincfg.Checkpoint
+	for i := range asset.chainParams.Checkpoints {
+		if asset.chainParams.Checkpoints[i].Height > height {
+			break
+		}
+		checkpoint = &asset.chainParams.Checkpoints[i]
+	}
+	if checkpoint == nil {
+		return genesisStamp
+	}
+
+	log.Infof("(%v) Restoring from checkpoint block %d", asset.GetWalletName(), checkpoint.Height)
+	return waddrmgr.BlockStamp{
+		Height:    checkpoint.Height,
+		Hash:      *checkpoint.Hash,
+		Timestamp: time.Unix(genesisTimestamp+int64(checkpoint.Height)*targetTimePerBlock, 0),
+	}
+}
+
 // updateAssetBirthday updates the appropriate birthday and birthday block
 // immediately after initial rescan is completed.
 func (asset *Asset) updateAssetBirthday() {
@@ -328,14 +545,14 @@ func (asset *Asset) getblockStamp(height int32) (*waddrmgr.BlockStamp, error) {
 		return nil, fmt.Errorf("invalid block height provided: Error: %v", err)
 	}
 
-	block, err := asset.chainClient.GetBlock(startHash)
+	header, err := asset.chainSource().GetBlockHeader(startHash)
 	if err != nil {
 		return nil, fmt.Errorf("invalid block hash provided: Error: %v", err)
 	}
 
 	return &waddrmgr.BlockStamp{
-		Hash:      block.BlockHash(),
+		Hash:      *startHash,
 		Height:    height,
-		Timestamp: block.Header.Timestamp,
+		Timestamp: header.Timestamp,
 	}, nil
 }
@@ -35,6 +35,8 @@ const (
 
 var wAddrMgrBkt = []byte("waddrmgr")
 
+var wTxMgrBkt = []byte("wtxmgr")
+
 // GetScope returns the key scope that will be used within the waddrmgr to
 // create an HD chain for deriving all of our required keys. A different
 // scope is used for each specific coin type.
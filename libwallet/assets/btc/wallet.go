@@ -265,6 +265,9 @@ func (asset *Asset) SafelyCancelSync() {
 	if asset.IsConnectedToNetwork() {
 		// Chain is either syncing or is synced.
 		asset.CancelSync()
+	} else {
+		// The shared chain service is acquired when the wallet is loaded.
+		asset.releaseSharedChainService()
 	}
 
 	loadWallet := asset.Internal().BTC
@@ -322,6 +325,13 @@ func (asset *Asset) ConnectedPeers() int32 {
 	if !asset.IsConnectedToNetwork() {
 		return -1
 	}
+	if asset.isRPCMode() {
+		// The wallet only connects to the full node it syncs from.
+		if asset.rpcChainClient() == nil {
+			return 0
+		}
+		return 1
+	}
 	return asset.chainClient.CS.(ExtraNeutrinoChainService).ConnectedCount()
 }
 
@@ -332,6 +342,10 @@ func (asset *Asset) IsConnectedToNetwork() bool {
 
 // GetBestBlock returns the best block.
 func (asset *Asset) GetBestBlock() *sharedW.BlockInfo {
+	if asset.isRPCMode() {
+		return asset.rpcBestBlock()
+	}
+
 	block, err := asset.chainClient.CS.BestBlock()
 	if err != nil {
 		log.Error("GetBestBlock hash for BTC failed, Err: ", err)
@@ -356,7 +370,13 @@ func (asset *Asset) GetBestBlockTimeStamp() int64 {
 
 // GetBlockHeight returns the block height for the given block hash.
 func (asset *Asset) GetBlockHeight(hash chainhash.Hash) (int32, error) {
-	height, err := asset.chainClient.GetBlockHeight(&hash)
+	var height int32
+	var err error
+	if rpcClient := asset.rpcChainClient(); rpcClient != nil {
+		height, err = rpcBlockHeight(rpcClient, &hash)
+	} else {
+		height, err = asset.chainClient.GetBlockHeight(&hash)
+	}
 	if err != nil {
 		log.Warn("GetBlockHeight for BTC failed, Err: %v", err)
 		return -1, err
@@ -366,7 +386,7 @@ func (asset *Asset) GetBlockHeight(hash chainhash.Hash) (int32, error) {
 
 // GetBlockHash returns the block hash for the given block height.
 func (asset *Asset) GetBlockHash(height int64) (*chainhash.Hash, error) {
-	blockhash, err := asset.chainClient.GetBlockHash(height)
+	blockhash, err := asset.chainSource().GetBlockHash(height)
 	if err != nil {
 		log.Warn("GetBlockHash for BTC failed, Err: %v", err)
 		return nil, err
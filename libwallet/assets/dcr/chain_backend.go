@@ -0,0 +1,73 @@
+package dcr
+
+import (
+	"net"
+	"os"
+	"path/filepath"
+
+	sharedW "github.com/crypto-power/cryptopower/libwallet/assets/wallet"
+	"github.com/crypto-power/cryptopower/libwallet/utils"
+	"github.com/decred/dcrd/addrmgr/v2"
+)
+
+// peersFileName is the name of the file an address manager saves its peers
+// to in its data dir.
+const peersFileName = "peers.json"
+
+// sharedPeerSet is the set of network peers known to the DCR wallets that
+// don't connect to specific peers. The peers any of the wallets learns of
+// are offered to all of them, and saved once in the shared chain data dir.
+//
+// Unlike the BTC and LTC wallets, the DCR wallets can't share their SPV
+// syncer or their connections: dcrwallet keeps the block headers and filters
+// in the wallet database, and a syncer and its local peer drive a single
+// wallet.
+type sharedPeerSet struct {
+	*addrmgr.AddrManager
+}
+
+// newSharedPeerSet creates the peer set shared by the DCR wallets in the
+// shared chain data dir.
+func (asset *Asset) newSharedPeerSet() (sharedW.ChainBackend, error) {
+	dataDir := asset.SharedChainDataDir()
+	if err := os.MkdirAll(dataDir, utils.UserFilePerm); err != nil {
+		return nil, err
+	}
+
+	amgr := addrmgr.New(dataDir, net.LookupIP) // TODO: be mindful of tor
+	amgr.Start()
+	log.Infof("Created the peer set shared by the %s wallets", asset.GetAssetType())
+	return &sharedPeerSet{AddrManager: amgr}, nil
+}
+
+// addrManager returns the address manager the SPV syncer of the wallet picks
+// its peers from, and true if it is shared with the other DCR wallets and
+// must be released with releaseSharedPeerSet once the sync ends.
+func (asset *Asset) addrManager(persistentPeers []string) (*addrmgr.AddrManager, bool) {
+	// Wallets that don't connect to specific or preferred peers share a peer
+	// set. The preferred peers of a wallet are its own and aren't added to
+	// the shared peer set.
+	if len(persistentPeers) == 0 && len(asset.PreferredPeers()) == 0 && asset.ChainBackends() != nil {
+		backend, err := asset.ChainBackends().Acquire(asset.SharedChainBackendKey(), asset.newSharedPeerSet)
+		if err == nil {
+			// The peers the wallet saved before it shared the peer set
+			// are no longer used.
+			peersFile := filepath.Join(asset.DataDir(), peersFileName)
+			if err := os.Remove(peersFile); err != nil && !os.IsNotExist(err) {
+				log.Errorf("Error removing the wallet peers file: %v", err)
+			}
+			return backend.(*sharedPeerSet).AddrManager, true
+		}
+		log.Errorf("Failed to use the shared peer set: %v", err)
+	}
+
+	amgr := addrmgr.New(asset.DataDir(), net.LookupIP) // TODO: be mindful of tor
+	asset.addPreferredPeers(amgr)
+	return amgr, false
+}
+
+// releaseSharedPeerSet releases the peer set shared with the other DCR
+// wallets, which is stopped once no wallet syncs from it.
+func (asset *Asset) releaseSharedPeerSet() {
+	asset.ChainBackends().Release(asset.SharedChainBackendKey())
+}
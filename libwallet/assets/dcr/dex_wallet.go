@@ -46,6 +46,7 @@ type WalletHelper interface {
 	IsAccountMixerActive() bool
 	UnmixedAccountNumber() int32
 	MixedAccountNumber() int32
+	PublishWithPolicy(ctx context.Context, msgTx *wire.MsgTx, account int32) (*chainhash.Hash, error)
 }
 
 var _ dexdcr.Wallet = (*DEXWallet)(nil)
@@ -337,11 +338,7 @@ func (dw *DEXWallet) SignRawTransaction(ctx context.Context, baseTx *wire.MsgTx)
 // network.
 // Part of the Wallet interface.
 func (dw *DEXWallet) SendRawTransaction(ctx context.Context, tx *wire.MsgTx, _ bool) (*chainhash.Hash, error) {
-	syncer, err := dw.w.NetworkBackend()
-	if err != nil {
-		return nil, err
-	}
-	return dw.w.PublishTransaction(ctx, tx, syncer)
+	return dw.helper.PublishWithPolicy(ctx, tx, dw.tradingAccountNumber)
 }
 
 // GetBestBlock returns the hash and height of the wallet's best block.
@@ -0,0 +1,655 @@
+package dcr
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"decred.org/dcrwallet/v4/errors"
+	"decred.org/dcrwallet/v4/rpc/jsonrpc/types"
+	"decred.org/dcrwallet/v4/wallet/txrules"
+	sharedW "github.com/crypto-power/cryptopower/libwallet/assets/wallet"
+	"github.com/crypto-power/cryptopower/libwallet/utils"
+	"github.com/decred/dcrd/chaincfg/chainhash"
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/decred/dcrd/dcrec/secp256k1/v4/ecdsa"
+	"github.com/decred/dcrd/dcrutil/v4"
+	"github.com/decred/dcrd/hdkeychain/v3"
+	"github.com/decred/dcrd/txscript/v4"
+	"github.com/decred/dcrd/txscript/v4/stdaddr"
+	"github.com/decred/dcrd/txscript/v4/stdscript"
+	"github.com/decred/dcrd/wire"
+)
+
+// multisigProposal is a P2SH multisig transaction shared between the
+// cosigners. Decred has no PSBT equivalent, so the inputs' previous outputs
+// and the partial signatures are carried alongside the unsigned transaction.
+type multisigProposal struct {
+	Tx     string                   `json:"tx"`
+	Inputs []*multisigProposalInput `json:"inputs"`
+}
+
+type multisigProposalInput struct {
+	Amount       int64  `json:"amount"`
+	Address      string `json:"address"`
+	RedeemScript string `json:"redeemscript"`
+	// Signatures maps the hex encoded public keys of the cosigners that
+	// signed the input to their hex encoded signatures.
+	Signatures map[string]string `json:"signatures"`
+}
+
+// multisigBranch is the hardened branch of the default account that the keys
+// dedicated to multisig are derived from. The wallet only derives its own
+// addresses from the unhardened branches 0 and 1 of its accounts, so the
+// multisig keys never match a single-sig address.
+const multisigBranch = hdkeychain.HardenedKeyStart + 48
+
+// DeriveMultisigXPub returns the xpub of the keys dedicated to multisig,
+// m/44'/coin'/0'/48', which can only be derived with the private passphrase.
+// The xpub is stored to be shared with the cosigners and used by
+// SetupMultisig.
+func (asset *Asset) DeriveMultisigXPub(privatePassphrase string) (string, error) {
+	if xpub := asset.MultisigXPub(); xpub != "" {
+		return xpub, nil
+	}
+	if !asset.WalletOpened() {
+		return "", utils.ErrDCRNotInitialized
+	}
+	if asset.IsWatchingOnlyWallet() {
+		return "", errors.New(utils.ErrWalletIsWatchOnly)
+	}
+
+	multisigXPriv, err := asset.multisigXPriv(privatePassphrase, true)
+	if err != nil {
+		return "", err
+	}
+	defer multisigXPriv.Zero()
+
+	xpub := multisigXPriv.Neuter().String()
+	return xpub, asset.SaveMultisigXPub(xpub)
+}
+
+// multisigXPriv unlocks the wallet with privatePassphrase and returns the
+// private key that the multisig keys are derived from. Wallets set up without
+// dedicated keys derive them from the default account.
+func (asset *Asset) multisigXPriv(privatePassphrase string, dedicatedKeys bool) (*hdkeychain.ExtendedKey, error) {
+	lock := make(chan time.Time, 1)
+	defer func() {
+		lock <- time.Time{}
+	}()
+
+	ctx, _ := asset.ShutdownContextWithCancel()
+	if err := asset.Internal().DCR.Unlock(ctx, []byte(privatePassphrase), lock); err != nil {
+		log.Error(err)
+		return nil, errors.New(utils.ErrInvalidPassphrase)
+	}
+
+	acctXPriv, err := asset.Internal().DCR.AccountXpriv(ctx, DefaultAccountNum)
+	if err != nil || !dedicatedKeys {
+		return acctXPriv, err
+	}
+	defer acctXPriv.Zero()
+	return acctXPriv.Child(multisigBranch)
+}
+
+// SetupMultisig turns the wallet into an m-of-n P2SH multisig wallet. The
+// xpub derived by DeriveMultisigXPub is added to the provided cosigners.
+// Only a wallet without transactions can be set up as a multisig wallet.
+func (asset *Asset) SetupMultisig(required int, cosigners []*sharedW.Cosigner) error {
+	if !asset.WalletOpened() {
+		return utils.ErrDCRNotInitialized
+	}
+
+	if asset.IsMultisig() {
+		return errors.E(errors.Exist, "wallet is already a multisig wallet")
+	}
+
+	txCount, err := asset.CountTransactions(utils.TxFilterAll)
+	if err != nil {
+		return err
+	}
+	if txCount > 0 {
+		return errors.E(errors.Invalid, "only a wallet without transactions can be set up as a multisig wallet")
+	}
+
+	localXPub := asset.MultisigXPub()
+	if localXPub == "" {
+		return errors.E(errors.Invalid, "the multisig xpub must be derived first")
+	}
+
+	cfg := &sharedW.MultisigConfig{
+		Required:      required,
+		LocalXPub:     localXPub,
+		DedicatedKeys: true,
+		Cosigners:     []*sharedW.Cosigner{{Name: asset.GetWalletName(), XPub: localXPub}},
+	}
+	for _, cosigner := range cosigners {
+		if cosigner.XPub == localXPub {
+			continue
+		}
+		if _, err := asset.multisigPubKey(cosigner.XPub, 0, 0); err != nil {
+			return errors.E(errors.Invalid, fmt.Sprintf("invalid xpub for cosigner %q: %v", cosigner.Name, err))
+		}
+		cfg.Cosigners = append(cfg.Cosigners, cosigner)
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return err
+	}
+
+	if err := asset.importMultisigAddresses(cfg); err != nil {
+		return err
+	}
+	return asset.SaveMultisigConfig(cfg)
+}
+
+// NewMultisigAddress returns a new receive address of the multisig wallet.
+func (asset *Asset) NewMultisigAddress() (string, error) {
+	return asset.nextMultisigAddress(sharedW.MultisigExternalBranch)
+}
+
+// MultisigBalance returns the spendable balance of the multisig addresses.
+func (asset *Asset) MultisigBalance() (sharedW.AssetAmount, error) {
+	cfg := asset.MultisigConfig()
+	if cfg == nil {
+		return nil, errors.New(utils.ErrNotMultisigWallet)
+	}
+
+	unspents, err := asset.multisigUnspents(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	var total dcrutil.Amount
+	for _, unspent := range unspents {
+		amount, _ := dcrutil.NewAmount(unspent.Amount)
+		total += amount
+	}
+	return Amount(total), nil
+}
+
+// CreateMultisigProposal creates a transaction spending the multisig funds
+// to address and returns it as a base64 encoded proposal to be signed by the
+// cosigners.
+func (asset *Asset) CreateMultisigProposal(address string, amount int64, sendMax bool) (string, error) {
+	cfg := asset.MultisigConfig()
+	if cfg == nil {
+		return "", errors.New(utils.ErrNotMultisigWallet)
+	}
+
+	if err := asset.validateSendAmount(sendMax, amount); err != nil {
+		return "", err
+	}
+
+	destOutput, err := asset.multisigTxOut(address, amount)
+	if err != nil {
+		return "", err
+	}
+
+	unspents, err := asset.multisigUnspents(cfg)
+	if err != nil {
+		return "", err
+	}
+	if !sendMax {
+		// Spend the largest outputs first to keep the transaction small.
+		sort.Slice(unspents, func(i, j int) bool { return unspents[i].Amount > unspents[j].Amount })
+	}
+
+	relayFee := asset.Internal().DCR.RelayFee()
+	tx := wire.NewMsgTx()
+	// A placeholder change output is used in the fee estimation.
+	changeOutput := &wire.TxOut{PkScript: destOutput.PkScript, Version: destOutput.Version}
+
+	var (
+		totalInput dcrutil.Amount
+		fee        dcrutil.Amount
+		inputs     []*multisigProposalInput
+	)
+	for _, unspent := range unspents {
+		txHash, err := chainhash.NewHashFromStr(unspent.TxID)
+		if err != nil {
+			return "", err
+		}
+
+		value, _ := dcrutil.NewAmount(unspent.Amount)
+		outPoint := wire.NewOutPoint(txHash, unspent.Vout, unspent.Tree)
+		tx.AddTxIn(wire.NewTxIn(outPoint, int64(value), nil))
+		inputs = append(inputs, &multisigProposalInput{
+			Amount:       int64(value),
+			Address:      unspent.Address,
+			RedeemScript: cfg.AddressInfo(unspent.Address).Script,
+			Signatures:   make(map[string]string),
+		})
+		totalInput += value
+
+		if sendMax {
+			continue
+		}
+		fee = multisigTxFee(len(tx.TxIn), []*wire.TxOut{destOutput, changeOutput}, cfg, relayFee)
+		if totalInput >= dcrutil.Amount(amount)+fee {
+			break
+		}
+	}
+
+	if sendMax {
+		fee = multisigTxFee(len(tx.TxIn), []*wire.TxOut{destOutput}, cfg, relayFee)
+		destOutput.Value = int64(totalInput - fee)
+	} else if totalInput < dcrutil.Amount(amount)+fee {
+		return "", errors.New(utils.ErrInsufficientBalance)
+	}
+
+	if len(tx.TxIn) == 0 || destOutput.Value <= 0 {
+		return "", errors.New(utils.ErrInsufficientBalance)
+	}
+	if err := txrules.CheckOutput(destOutput, relayFee); err != nil {
+		return "", fmt.Errorf("main txOut validation failed %v", err)
+	}
+	tx.AddTxOut(destOutput)
+
+	if !sendMax {
+		changeValue := int64(totalInput - fee - dcrutil.Amount(amount))
+		changeOutput.Value = changeValue
+		// Dust change is added to the fee.
+		if !txrules.IsDustOutput(changeOutput, relayFee) {
+			changeAddress, err := asset.nextMultisigAddress(sharedW.MultisigInternalBranch)
+			if err != nil {
+				return "", err
+			}
+			changeOutput, err = asset.multisigTxOut(changeAddress, changeValue)
+			if err != nil {
+				return "", err
+			}
+			tx.AddTxOut(changeOutput)
+		}
+	}
+
+	return encodeMultisigProposal(tx, inputs)
+}
+
+// MultisigProposalInfo decodes a multisig transaction proposal.
+func (asset *Asset) MultisigProposalInfo(proposal string) (*sharedW.MultisigProposalInfo, error) {
+	cfg := asset.MultisigConfig()
+	if cfg == nil {
+		return nil, errors.New(utils.ErrNotMultisigWallet)
+	}
+
+	tx, inputs, err := decodeMultisigProposal(proposal)
+	if err != nil {
+		return nil, err
+	}
+
+	var totalInput, totalOutput int64
+	info := &sharedW.MultisigProposalInfo{
+		TxID:       tx.TxHash().String(),
+		Required:   cfg.Required,
+		Signatures: math.MaxInt32,
+	}
+
+	for _, txOut := range tx.TxOut {
+		output := &sharedW.MultisigOutput{Amount: txOut.Value}
+		_, addrs := stdscript.ExtractAddrs(txOut.Version, txOut.PkScript, asset.chainParams)
+		if len(addrs) > 0 {
+			output.Address = addrs[0].String()
+			output.IsChange = cfg.AddressInfo(output.Address) != nil
+		}
+		info.Outputs = append(info.Outputs, output)
+		totalOutput += txOut.Value
+	}
+
+	for i, input := range inputs {
+		addrInfo := cfg.AddressInfo(input.Address)
+		if addrInfo == nil {
+			return nil, errors.E(errors.Invalid, fmt.Sprintf("proposal spends unknown address %s", input.Address))
+		}
+
+		totalInput += input.Amount
+		info.Signatures = min(info.Signatures, len(input.Signatures))
+
+		if i > 0 {
+			continue
+		}
+		localPubKey, err := asset.multisigPubKey(cfg.LocalXPub, addrInfo.Branch, addrInfo.Index)
+		if err != nil {
+			return nil, err
+		}
+		_, info.SignedByLocal = input.Signatures[hex.EncodeToString(localPubKey)]
+	}
+	if len(inputs) == 0 {
+		info.Signatures = 0
+	}
+
+	info.Fee = totalInput - totalOutput
+	return info, nil
+}
+
+// SignMultisigProposal adds the wallet's signatures to a multisig transaction
+// proposal and returns the updated proposal.
+func (asset *Asset) SignMultisigProposal(proposal, privatePassphrase string) (string, error) {
+	cfg := asset.MultisigConfig()
+	if cfg == nil {
+		return "", errors.New(utils.ErrNotMultisigWallet)
+	}
+	if asset.IsWatchingOnlyWallet() {
+		return "", errors.New(utils.ErrWalletIsWatchOnly)
+	}
+
+	tx, inputs, err := decodeMultisigProposal(proposal)
+	if err != nil {
+		return "", err
+	}
+
+	multisigXPriv, err := asset.multisigXPriv(privatePassphrase, cfg.DedicatedKeys)
+	if err != nil {
+		return "", err
+	}
+	defer multisigXPriv.Zero()
+
+	for i, input := range inputs {
+		addrInfo := cfg.AddressInfo(input.Address)
+		if addrInfo == nil {
+			return "", errors.E(errors.Invalid, fmt.Sprintf("proposal spends unknown address %s", input.Address))
+		}
+		if len(input.Signatures) >= cfg.Required {
+			continue
+		}
+
+		branchKey, err := multisigXPriv.Child(addrInfo.Branch)
+		if err != nil {
+			return "", err
+		}
+		childKey, err := branchKey.Child(addrInfo.Index)
+		if err != nil {
+			return "", err
+		}
+		privKeyBytes, err := childKey.SerializedPrivKey()
+		if err != nil {
+			return "", err
+		}
+
+		pubKey := hex.EncodeToString(childKey.SerializedPubKey())
+		if _, signed := input.Signatures[pubKey]; signed {
+			continue
+		}
+
+		redeemScript, err := hex.DecodeString(addrInfo.Script)
+		if err != nil {
+			return "", err
+		}
+		sigHash, err := txscript.CalcSignatureHash(redeemScript, txscript.SigHashAll, tx, i, nil)
+		if err != nil {
+			return "", err
+		}
+		sig := ecdsa.Sign(secp256k1.PrivKeyFromBytes(privKeyBytes), sigHash).Serialize()
+		input.Signatures[pubKey] = hex.EncodeToString(append(sig, byte(txscript.SigHashAll)))
+	}
+
+	return encodeMultisigProposal(tx, inputs)
+}
+
+// BroadcastMultisigProposal builds the signature scripts of a fully signed
+// multisig transaction proposal and publishes it to the network.
+func (asset *Asset) BroadcastMultisigProposal(proposal, transactionLabel string) (string, error) {
+	if !asset.WalletOpened() {
+		return "", utils.ErrDCRNotInitialized
+	}
+
+	_, err := asset.Internal().DCR.NetworkBackend()
+	if err != nil {
+		log.Error(err)
+		return "", err
+	}
+
+	tx, inputs, err := decodeMultisigProposal(proposal)
+	if err != nil {
+		return "", err
+	}
+
+	for i, input := range inputs {
+		redeemScript, err := hex.DecodeString(input.RedeemScript)
+		if err != nil {
+			return "", err
+		}
+
+		details := stdscript.ExtractMultiSigScriptDetailsV0(redeemScript, true)
+		if !details.Valid {
+			return "", errors.E(errors.Invalid, "proposal input is not a multisig output")
+		}
+
+		// Decred's OP_CHECKMULTISIG does not consume an extra stack item,
+		// so the signatures are followed directly by the redeem script.
+		builder := txscript.NewScriptBuilder()
+		var signatures int
+		for _, pubKey := range details.PubKeys {
+			sig, ok := input.Signatures[hex.EncodeToString(pubKey)]
+			if !ok || signatures == int(details.RequiredSigs) {
+				continue
+			}
+			sigBytes, err := hex.DecodeString(sig)
+			if err != nil {
+				return "", err
+			}
+			builder.AddData(sigBytes)
+			signatures++
+		}
+		if signatures < int(details.RequiredSigs) {
+			return "", errors.E(errors.Invalid, "the proposal does not have enough signatures")
+		}
+
+		sigScript, err := builder.AddData(redeemScript).Script()
+		if err != nil {
+			return "", err
+		}
+		tx.TxIn[i].SignatureScript = sigScript
+	}
+
+	ctx, _ := asset.ShutdownContextWithCancel()
+	// The multisig sends spend the shared addresses rather than an account,
+	// they are limited by the policy of the whole wallet.
+	txHash, err := asset.publishWithPolicy(ctx, tx, sharedW.AllAccounts, asset.externalOutputs(tx), transactionLabel, true)
+	if err != nil {
+		return "", utils.TranslateError(err)
+	}
+	return txHash.String(), nil
+}
+
+// nextMultisigAddress returns the next unused multisig address of branch and
+// imports the addresses that should now be watched.
+func (asset *Asset) nextMultisigAddress(branch uint32) (string, error) {
+	var address string
+	err := asset.UpdateMultisigConfig(func(cfg *sharedW.MultisigConfig) error {
+		index := cfg.NextExternalIndex
+		if branch == sharedW.MultisigInternalBranch {
+			index = cfg.NextInternalIndex
+			cfg.NextInternalIndex++
+		} else {
+			cfg.NextExternalIndex++
+		}
+
+		if err := asset.importMultisigAddresses(cfg); err != nil {
+			return err
+		}
+		for _, addr := range cfg.Addresses {
+			if addr.Branch == branch && addr.Index == index {
+				address = addr.Address
+			}
+		}
+		return nil
+	})
+	return address, err
+}
+
+// importMultisigAddresses imports the redeem scripts of the multisig addresses
+// within the gap limit of both branches, so that the wallet watches them, and
+// adds them to cfg.
+func (asset *Asset) importMultisigAddresses(cfg *sharedW.MultisigConfig) error {
+	ctx, _ := asset.ShutdownContextWithCancel()
+	for _, branch := range []uint32{sharedW.MultisigExternalBranch, sharedW.MultisigInternalBranch} {
+		next := cfg.NextExternalIndex
+		if branch == sharedW.MultisigInternalBranch {
+			next = cfg.NextInternalIndex
+		}
+
+		for _, index := range cfg.MissingIndexes(branch, next) {
+			script, err := asset.multisigScript(cfg, branch, index)
+			if err != nil {
+				return err
+			}
+			addr, err := stdaddr.NewAddressScriptHashV0(script, asset.chainParams)
+			if err != nil {
+				return err
+			}
+
+			err = asset.Internal().DCR.ImportScript(ctx, script)
+			if err != nil && !errors.Is(err, errors.Exist) {
+				return fmt.Errorf("importing multisig script failed: %v", err)
+			}
+
+			cfg.Addresses = append(cfg.Addresses, &sharedW.MultisigAddress{
+				Address: addr.String(),
+				Branch:  branch,
+				Index:   index,
+				Script:  hex.EncodeToString(script),
+			})
+		}
+	}
+	return nil
+}
+
+// multisigScript returns the BIP67 sorted m-of-n multisig script of the
+// cosigner keys at branch/index.
+func (asset *Asset) multisigScript(cfg *sharedW.MultisigConfig, branch, index uint32) ([]byte, error) {
+	pubKeys := make([][]byte, 0, len(cfg.Cosigners))
+	for _, xpub := range cfg.XPubs() {
+		pubKey, err := asset.multisigPubKey(xpub, branch, index)
+		if err != nil {
+			return nil, err
+		}
+		pubKeys = append(pubKeys, pubKey)
+	}
+	sharedW.SortPubKeys(pubKeys)
+
+	builder := txscript.NewScriptBuilder().AddInt64(int64(cfg.Required))
+	for _, pubKey := range pubKeys {
+		builder.AddData(pubKey)
+	}
+	builder.AddInt64(int64(len(pubKeys))).AddOp(txscript.OP_CHECKMULTISIG)
+	return builder.Script()
+}
+
+// multisigPubKey derives the compressed public key at branch/index of xpub.
+func (asset *Asset) multisigPubKey(xpub string, branch, index uint32) ([]byte, error) {
+	key, err := hdkeychain.NewKeyFromString(strings.TrimSpace(xpub), asset.chainParams)
+	if err != nil {
+		return nil, err
+	}
+	if key.IsPrivate() {
+		return nil, errors.E(errors.Invalid, "xpub is not an extended public key")
+	}
+
+	branchKey, err := key.Child(branch)
+	if err != nil {
+		return nil, err
+	}
+	childKey, err := branchKey.Child(index)
+	if err != nil {
+		return nil, err
+	}
+	return childKey.SerializedPubKey(), nil
+}
+
+// multisigUnspents returns the spendable outputs paid to the multisig
+// addresses.
+func (asset *Asset) multisigUnspents(cfg *sharedW.MultisigConfig) ([]*types.ListUnspentResult, error) {
+	ctx, _ := asset.ShutdownContextWithCancel()
+	return asset.Internal().DCR.ListUnspent(ctx, asset.RequiredConfirmations(), math.MaxInt32, cfg.AddressSet(), "")
+}
+
+// multisigTxOut returns an output paying amount to address.
+func (asset *Asset) multisigTxOut(address string, amount int64) (*wire.TxOut, error) {
+	addr, err := stdaddr.DecodeAddress(address, asset.chainParams)
+	if err != nil {
+		return nil, utils.TranslateError(err)
+	}
+	version, pkScript := addr.PaymentScript()
+	return &wire.TxOut{Value: amount, Version: version, PkScript: pkScript}, nil
+}
+
+// multisigTxFee estimates the fee of a transaction spending numInputs P2SH
+// multisig outputs to outputs.
+func multisigTxFee(numInputs int, outputs []*wire.TxOut, cfg *sharedW.MultisigConfig, relayFee dcrutil.Amount) dcrutil.Amount {
+	// OP_m <n * pubkey push> OP_n OP_CHECKMULTISIG
+	redeemScriptSize := 1 + len(cfg.Cosigners)*(1+33) + 2
+	redeemScriptPushSize := 1
+	if redeemScriptSize > txscript.OP_DATA_75 {
+		redeemScriptPushSize = 2
+	}
+	sigScriptSize := cfg.Required*(1+73) + redeemScriptPushSize + redeemScriptSize
+
+	// Version, locktime, expiry and the prefix and witness input counts.
+	size := 4 + 4 + 4 + 2*wire.VarIntSerializeSize(uint64(numInputs)) + wire.VarIntSerializeSize(uint64(len(outputs)))
+	// Outpoint, tree and sequence of the prefix, value, block height, block
+	// index and signature script of the witness.
+	size += numInputs * (32 + 4 + 1 + 4 + 8 + 4 + 4 + wire.VarIntSerializeSize(uint64(sigScriptSize)) + sigScriptSize)
+	for _, output := range outputs {
+		size += output.SerializeSize()
+	}
+	return txrules.FeeForSerializeSize(relayFee, size)
+}
+
+func encodeMultisigProposal(tx *wire.MsgTx, inputs []*multisigProposalInput) (string, error) {
+	var txBuf bytes.Buffer
+	txBuf.Grow(tx.SerializeSize())
+	if err := tx.Serialize(&txBuf); err != nil {
+		return "", err
+	}
+
+	proposal, err := json.Marshal(&multisigProposal{
+		Tx:     hex.EncodeToString(txBuf.Bytes()),
+		Inputs: inputs,
+	})
+	if err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(proposal), nil
+}
+
+func decodeMultisigProposal(proposal string) (*wire.MsgTx, []*multisigProposalInput, error) {
+	invalidProposal := func(err error) error {
+		return errors.E(errors.Invalid, fmt.Sprintf("invalid proposal: %v", err))
+	}
+
+	b, err := base64.StdEncoding.DecodeString(strings.TrimSpace(proposal))
+	if err != nil {
+		return nil, nil, invalidProposal(err)
+	}
+
+	var p multisigProposal
+	if err := json.Unmarshal(b, &p); err != nil {
+		return nil, nil, invalidProposal(err)
+	}
+
+	txBytes, err := hex.DecodeString(p.Tx)
+	if err != nil {
+		return nil, nil, invalidProposal(err)
+	}
+	tx := new(wire.MsgTx)
+	if err := tx.Deserialize(bytes.NewReader(txBytes)); err != nil {
+		return nil, nil, invalidProposal(err)
+	}
+
+	if len(p.Inputs) != len(tx.TxIn) {
+		return nil, nil, invalidProposal(fmt.Errorf("%d inputs, expected %d", len(p.Inputs), len(tx.TxIn)))
+	}
+	for _, input := range p.Inputs {
+		if input.Signatures == nil {
+			input.Signatures = make(map[string]string)
+		}
+	}
+	return tx, p.Inputs, nil
+}
@@ -0,0 +1,194 @@
+package dcr
+
+import (
+	"context"
+	"net"
+	"strconv"
+	"sync"
+	"sync/atomic"
+
+	"decred.org/dcrwallet/v4/errors"
+	"decred.org/dcrwallet/v4/p2p"
+	"decred.org/dcrwallet/v4/spv"
+	sharedW "github.com/crypto-power/cryptopower/libwallet/assets/wallet"
+	"github.com/crypto-power/cryptopower/libwallet/utils"
+	"github.com/decred/dcrd/addrmgr/v2"
+	"github.com/decred/dcrd/wire"
+)
+
+// peerConn counts the bytes sent to and received from a remote peer, which
+// dcrwallet doesn't track, and reports them to countData.
+type peerConn struct {
+	net.Conn
+	traffic   *peerTraffic
+	countData func(n int)
+
+	bytesSent     atomic.Uint64
+	bytesReceived atomic.Uint64
+	closeOnce     sync.Once
+}
+
+func (c *peerConn) Read(b []byte) (int, error) {
+	n, err := c.Conn.Read(b)
+	c.bytesReceived.Add(uint64(n))
+	c.countData(n)
+	return n, err
+}
+
+func (c *peerConn) Write(b []byte) (int, error) {
+	n, err := c.Conn.Write(b)
+	c.bytesSent.Add(uint64(n))
+	c.countData(n)
+	return n, err
+}
+
+func (c *peerConn) Close() error {
+	c.closeOnce.Do(func() {
+		c.traffic.remove(c)
+	})
+	return c.Conn.Close()
+}
+
+// peerTraffic tracks the open connections to remote peers by their address.
+type peerTraffic struct {
+	mu    sync.RWMutex
+	conns map[string]*peerConn
+}
+
+func (t *peerTraffic) add(c *peerConn) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.conns == nil {
+		t.conns = make(map[string]*peerConn)
+	}
+	t.conns[c.RemoteAddr().String()] = c
+}
+
+func (t *peerTraffic) remove(c *peerConn) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	addr := c.RemoteAddr().String()
+	if t.conns[addr] == c {
+		delete(t.conns, addr)
+	}
+}
+
+// bytes returns the bytes sent to and received from the peer at addr.
+func (t *peerTraffic) bytes(addr string) (sent, received uint64) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	if c, ok := t.conns[addr]; ok {
+		return c.bytesSent.Load(), c.bytesReceived.Load()
+	}
+	return 0, 0
+}
+
+// dialPeer dials the peers and seeders of the SPV syncer. It refuses to
+// connect to banned peers and counts the traffic of the connections, which
+// is also counted towards the sync data usage of the wallet.
+func (asset *Asset) dialPeer(ctx context.Context, network, addr string) (net.Conn, error) {
+	if err := asset.CheckPeerAllowed(addr); err != nil {
+		return nil, err
+	}
+
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, network, addr)
+	if err != nil {
+		return nil, err
+	}
+
+	c := &peerConn{Conn: conn, traffic: &asset.syncData.peerTraffic, countData: asset.AddSyncData}
+	c.traffic.add(c)
+	return c, nil
+}
+
+// addPreferredPeers adds the preferred peers of the wallet to the address
+// manager of the SPV syncer as known good addresses, so that they are
+// favoured when it picks the peers to connect to. They are not made
+// persistent peers as the syncer would then connect to them exclusively.
+func (asset *Asset) addPreferredPeers(amgr *addrmgr.AddrManager) {
+	for _, addr := range asset.PreferredPeers() {
+		host, portStr, err := net.SplitHostPort(addr)
+		if err != nil {
+			continue
+		}
+		port, err := strconv.ParseUint(portStr, 10, 16)
+		if err != nil {
+			continue
+		}
+		na, err := amgr.HostToNetAddress(host, uint16(port), wire.SFNodeNetwork)
+		if err != nil {
+			log.Debugf("Adding preferred peer %s failed: %v", addr, err)
+			continue
+		}
+		amgr.AddAddresses([]*addrmgr.NetAddress{na}, na)
+		if err := amgr.Good(na); err != nil {
+			log.Debugf("Marking preferred peer %s as good failed: %v", addr, err)
+		}
+	}
+}
+
+// remotePeers returns the peers of a wallet connected to the network with
+// SPV.
+func (asset *Asset) remotePeers() (map[string]*p2p.RemotePeer, error) {
+	if !asset.IsConnectedToDecredNetwork() || asset.syncData.activeSyncData == nil {
+		return nil, errors.New(utils.ErrNotConnected)
+	}
+
+	syncer, ok := asset.syncData.activeSyncData.syncer.(*spv.Syncer)
+	if !ok {
+		return nil, nil
+	}
+	return syncer.GetRemotePeers(), nil
+}
+
+// DisconnectPeer disconnects the peer at addr. The wallet may connect to it
+// again later.
+func (asset *Asset) DisconnectPeer(addr string) error {
+	peers, err := asset.remotePeers()
+	if err != nil {
+		return err
+	}
+	for _, rp := range peers {
+		if rp.RemoteAddr().String() == addr {
+			rp.Disconnect(errors.New("disconnected by the user"))
+			return nil
+		}
+	}
+	return errors.New(utils.ErrPeerNotFound)
+}
+
+// BanPeer disconnects the peers with the host of addr and refuses to connect
+// to them again until they are unbanned.
+func (asset *Asset) BanPeer(addr string) error {
+	if err := asset.SetPeerBanned(addr, true); err != nil {
+		return err
+	}
+
+	// The wallet is not necessarily connected.
+	peers, _ := asset.remotePeers()
+	host := sharedW.PeerHost(addr)
+	for _, rp := range peers {
+		if sharedW.PeerHost(rp.RemoteAddr().String()) == host {
+			rp.Disconnect(errors.New("banned by the user"))
+		}
+	}
+	return nil
+}
+
+// UnbanPeer allows the wallet to connect to the peers with the host of addr
+// again.
+func (asset *Asset) UnbanPeer(addr string) error {
+	return asset.SetPeerBanned(addr, false)
+}
+
+// SetPeerPreferred adds the peer at addr to the peers the wallet favours
+// connecting to, or removes it from them. Preferred peers take effect the
+// next time the wallet syncs.
+func (asset *Asset) SetPeerPreferred(addr string, preferred bool) error {
+	addr, err := sharedW.PeerAddressWithPort(addr, asset.chainParams.DefaultPort)
+	if err != nil {
+		return err
+	}
+	return asset.Wallet.SetPeerPreferred(addr, preferred)
+}
@@ -0,0 +1,280 @@
+package dcr
+
+import (
+	"bytes"
+	"strings"
+	"time"
+
+	"decred.org/dcrwallet/v4/errors"
+	w "decred.org/dcrwallet/v4/wallet"
+	"decred.org/dcrwallet/v4/wallet/txauthor"
+	"decred.org/dcrwallet/v4/wallet/txrules"
+	"decred.org/dcrwallet/v4/wallet/txsizes"
+	sharedW "github.com/crypto-power/cryptopower/libwallet/assets/wallet"
+	"github.com/crypto-power/cryptopower/libwallet/txhelper"
+	"github.com/crypto-power/cryptopower/libwallet/utils"
+	"github.com/decred/dcrd/chaincfg/chainhash"
+	"github.com/decred/dcrd/chaincfg/v3"
+	"github.com/decred/dcrd/dcrec"
+	"github.com/decred/dcrd/dcrutil/v4"
+	"github.com/decred/dcrd/txscript/v4/stdaddr"
+	"github.com/decred/dcrd/wire"
+)
+
+// sweepBatchSize is the number of matching blocks fetched at once when
+// scanning the chain for the outputs of a swept key.
+const sweepBatchSize = 100
+
+// ImportPrivateKey imports the WIF encoded private key into the imported
+// account and rescans the chain from startHeight for the transactions of its
+// address, which is returned. If the rescan can't start, it is saved to run
+// once the wallet is synced again.
+func (asset *Asset) ImportPrivateKey(wif, privatePassphrase string, startHeight int32) ([]string, error) {
+	key, err := asset.decodeWIF(wif)
+	if err != nil {
+		return nil, err
+	}
+
+	if !asset.IsSynced() {
+		return nil, errors.E(utils.ErrNotSynced)
+	}
+	// The rescan running would drop the rescan of the key once done.
+	if asset.IsRescanning() {
+		return nil, errors.E(utils.ErrSyncAlreadyInProgress)
+	}
+
+	lock := make(chan time.Time, 1)
+	defer func() {
+		lock <- time.Time{}
+	}()
+
+	ctx, _ := asset.ShutdownContextWithCancel()
+	err = asset.Internal().DCR.Unlock(ctx, []byte(privatePassphrase), lock)
+	if err != nil {
+		log.Error(err)
+		return nil, errors.New(utils.ErrInvalidPassphrase)
+	}
+
+	addr, err := asset.Internal().DCR.ImportPrivateKey(ctx, key)
+	if errors.Is(err, errors.Exist) {
+		return nil, errors.E(utils.ErrExist)
+	} else if err != nil {
+		return nil, err
+	}
+
+	addrs := []string{addr}
+	if err := asset.RescanAddresses(startHeight, addrs); err != nil {
+		log.Errorf("Rescanning for the imported key failed, it resumes once synced: %v", err)
+		asset.QueuePendingRescan(&sharedW.RescanState{StartHeight: startHeight, ScannedThrough: startHeight, Addresses: addrs})
+	}
+	return addrs, nil
+}
+
+// PrepareKeySweep signs a transaction sending the funds of the WIF encoded
+// private key to the current address of account, without importing the key.
+// The chain is scanned from startHeight for the confirmed outputs of the key.
+// The transaction is only published by PublishKeySweep, once its amount and
+// fee are confirmed.
+func (asset *Asset) PrepareKeySweep(wif string, account, startHeight int32) (*sharedW.SweepResult, error) {
+	key, err := asset.decodeWIF(wif)
+	if err != nil {
+		return nil, err
+	}
+
+	if !asset.IsSynced() {
+		return nil, errors.E(utils.ErrNotSynced)
+	}
+
+	keyAddr, err := stdaddr.NewAddressPubKeyHashEcdsaSecp256k1V0(dcrutil.Hash160(key.PubKey()), asset.chainParams)
+	if err != nil {
+		return nil, err
+	}
+	_, pkScript := keyAddr.PaymentScript()
+	unspents, err := asset.scanKeyOutputs(pkScript, startHeight)
+	if err != nil {
+		return nil, err
+	}
+	if len(unspents) == 0 {
+		return nil, errors.E(utils.ErrNoFundsToSweep)
+	}
+
+	address, err := asset.CurrentAddress(account)
+	if err != nil {
+		return nil, err
+	}
+	sweepTx, err := buildSweepTx(key, unspents, address, txrules.DefaultRelayFeePerKb, asset.chainParams)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	if err := sweepTx.Tx.Serialize(&buf); err != nil {
+		return nil, err
+	}
+	amount := dcrutil.Amount(sweepTx.Tx.TxOut[sweepTx.ChangeIndex].Value)
+	return &sharedW.SweepResult{
+		TxHash:   sweepTx.Tx.TxHash().String(),
+		Account:  account,
+		Address:  address,
+		Amount:   Amount(amount),
+		Fee:      Amount(sweepTx.TotalInput - amount),
+		Inputs:   len(sweepTx.Tx.TxIn),
+		SignedTx: buf.Bytes(),
+	}, nil
+}
+
+// PublishKeySweep publishes the sweep of a private key signed by
+// PrepareKeySweep.
+func (asset *Asset) PublishKeySweep(sweep *sharedW.SweepResult) error {
+	tx := new(wire.MsgTx)
+	if err := tx.Deserialize(bytes.NewReader(sweep.SignedTx)); err != nil {
+		return err
+	}
+	// The sweep pays the wallet, it only goes through the spending policy
+	// like every other send.
+	ctx, _ := asset.ShutdownContextWithCancel()
+	if _, err := asset.publishWithPolicy(ctx, tx, sweep.Account, asset.externalOutputs(tx), "", false); err != nil {
+		return utils.TranslateError(err)
+	}
+	return nil
+}
+
+// buildSweepTx returns the transaction spending every output of the key in
+// unspents to address, the funds less the fee going to address as change.
+func buildSweepTx(key *dcrutil.WIF, unspents map[wire.OutPoint]*wire.TxOut, address string, relayFeePerKb dcrutil.Amount,
+	chainParams *chaincfg.Params) (*txauthor.AuthoredTx, error) {
+	inputDetail := &txauthor.InputDetail{
+		Inputs:            make([]*wire.TxIn, 0, len(unspents)),
+		Scripts:           make([][]byte, 0, len(unspents)),
+		RedeemScriptSizes: make([]int, 0, len(unspents)),
+	}
+	for outPoint, txOut := range unspents {
+		inputDetail.Amount += dcrutil.Amount(txOut.Value)
+		inputDetail.Inputs = append(inputDetail.Inputs, wire.NewTxIn(&outPoint, txOut.Value, nil))
+		inputDetail.Scripts = append(inputDetail.Scripts, txOut.PkScript)
+		inputDetail.RedeemScriptSizes = append(inputDetail.RedeemScriptSizes, txsizes.RedeemP2PKHSigScriptSize)
+	}
+	inputSource := func(dcrutil.Amount) (*txauthor.InputDetail, error) {
+		return inputDetail, nil
+	}
+	changeSource, err := txhelper.MakeTxChangeSource(address, chainParams)
+	if err != nil {
+		return nil, err
+	}
+
+	unsignedTx, err := txauthor.NewUnsignedTransaction(nil, relayFeePerKb, inputSource, changeSource, chainParams.MaxTxSize)
+	if errors.Is(err, errors.InsufficientBalance) {
+		// The funds of the key don't cover the fee of the sweep.
+		return nil, errors.E(utils.ErrNoFundsToSweep)
+	} else if err != nil {
+		return nil, err
+	}
+	if unsignedTx.ChangeIndex < 0 {
+		// The funds left after the fee would be dust.
+		return nil, errors.E(utils.ErrNoFundsToSweep)
+	}
+
+	secrets := &keySecrets{key: key, chainParams: chainParams}
+	if err := txauthor.AddAllInputScripts(unsignedTx.Tx, unsignedTx.PrevScripts, secrets); err != nil {
+		log.Errorf("signing the sweep of the key failed: %v", err)
+		return nil, err
+	}
+	return unsignedTx, nil
+}
+
+// decodeWIF decodes a WIF encoded secp256k1 private key of the wallet's
+// network.
+func (asset *Asset) decodeWIF(wif string) (*dcrutil.WIF, error) {
+	key, err := dcrutil.DecodeWIF(strings.TrimSpace(wif), asset.chainParams.PrivateKeyID)
+	if err != nil || key.DSA() != dcrec.STEcdsaSecp256k1 {
+		return nil, errors.E(utils.ErrInvalidPrivateKey)
+	}
+	return key, nil
+}
+
+// scanKeyOutputs scans the blocks from startHeight for the regular tree
+// outputs paying to pkScript that are still unspent. The blocks are matched
+// against the compact filters kept by the wallet, which also commit to the
+// scripts spent by each block, and only the matching blocks are fetched.
+func (asset *Asset) scanKeyOutputs(pkScript []byte, startHeight int32) (map[wire.OutPoint]*wire.TxOut, error) {
+	n, err := asset.Internal().DCR.NetworkBackend()
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, _ := asset.ShutdownContextWithCancel()
+	_, tipHeight := asset.Internal().DCR.MainChainTip(ctx)
+	watch := [][]byte{pkScript}
+
+	var matches []*chainhash.Hash
+	for height := max(startHeight, 0); height <= tipHeight; height++ {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+
+		block, err := asset.Internal().DCR.BlockInfo(ctx, w.NewBlockIdentifierFromHeight(height))
+		if err != nil {
+			return nil, err
+		}
+		key, filter, err := asset.Internal().DCR.CFilterV2(ctx, &block.Hash)
+		if err != nil {
+			return nil, err
+		}
+		if filter.MatchAny(key, watch) {
+			matches = append(matches, &block.Hash)
+		}
+	}
+
+	unspents := make(map[wire.OutPoint]*wire.TxOut)
+	for len(matches) > 0 {
+		batch := matches[:min(len(matches), sweepBatchSize)]
+		matches = matches[len(batch):]
+
+		blocks, err := n.Blocks(ctx, batch)
+		if err != nil {
+			return nil, err
+		}
+		for _, block := range blocks {
+			// Tickets may spend the outputs of the key too.
+			for _, tx := range block.STransactions {
+				for _, txIn := range tx.TxIn {
+					delete(unspents, txIn.PreviousOutPoint)
+				}
+			}
+			for _, tx := range block.Transactions {
+				for _, txIn := range tx.TxIn {
+					delete(unspents, txIn.PreviousOutPoint)
+				}
+				txHash := tx.TxHash()
+				for i, txOut := range tx.TxOut {
+					if string(txOut.PkScript) == string(pkScript) {
+						outPoint := wire.OutPoint{Hash: txHash, Index: uint32(i), Tree: wire.TxTreeRegular}
+						unspents[outPoint] = txOut
+					}
+				}
+			}
+		}
+	}
+	return unspents, nil
+}
+
+// keySecrets signs the inputs spending the outputs of a swept key.
+type keySecrets struct {
+	key         *dcrutil.WIF
+	chainParams *chaincfg.Params
+}
+
+// GetKey implements sign.KeyDB.
+func (s *keySecrets) GetKey(stdaddr.Address) ([]byte, dcrec.SignatureType, bool, error) {
+	return s.key.PrivKey(), s.key.DSA(), true, nil
+}
+
+// GetScript implements sign.ScriptDB. Swept keys have no scripts.
+func (s *keySecrets) GetScript(stdaddr.Address) ([]byte, error) {
+	return nil, errors.E(errors.NotExist, "no script for a swept key")
+}
+
+// ChainParams implements txauthor.SecretsSource.
+func (s *keySecrets) ChainParams() *chaincfg.Params {
+	return s.chainParams
+}
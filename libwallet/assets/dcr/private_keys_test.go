@@ -0,0 +1,108 @@
+package dcr
+
+import (
+	"testing"
+
+	"github.com/crypto-power/cryptopower/libwallet/utils"
+	"github.com/decred/dcrd/chaincfg/chainhash"
+	"github.com/decred/dcrd/chaincfg/v3"
+	"github.com/decred/dcrd/dcrec"
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/decred/dcrd/dcrutil/v4"
+	"github.com/decred/dcrd/txscript/v4"
+	"github.com/decred/dcrd/txscript/v4/stdaddr"
+	"github.com/decred/dcrd/wire"
+)
+
+func newTestWIF(t *testing.T, params *chaincfg.Params) *dcrutil.WIF {
+	privKey, err := secp256k1.GeneratePrivateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	wif, err := dcrutil.NewWIF(privKey.Serialize(), params.PrivateKeyID, dcrec.STEcdsaSecp256k1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return wif
+}
+
+func TestDecodeWIF(t *testing.T) {
+	params := chaincfg.MainNetParams()
+	asset := &Asset{chainParams: params}
+	key := newTestWIF(t, params)
+
+	decoded, err := asset.decodeWIF(" " + key.String() + "\n")
+	if err != nil {
+		t.Fatalf("decoding a mainnet key: %v", err)
+	}
+	if string(decoded.PrivKey()) != string(key.PrivKey()) {
+		t.Fatal("decoded key differs")
+	}
+
+	testnetKey := newTestWIF(t, chaincfg.TestNet3Params())
+	for _, wif := range []string{testnetKey.String(), "not a key", ""} {
+		if _, err := asset.decodeWIF(wif); err == nil || err.Error() != utils.ErrInvalidPrivateKey {
+			t.Errorf("decoding %q: got error %v, want %s", wif, err, utils.ErrInvalidPrivateKey)
+		}
+	}
+}
+
+func TestBuildSweepTx(t *testing.T) {
+	params := chaincfg.MainNetParams()
+	key := newTestWIF(t, params)
+	keyAddr, err := stdaddr.NewAddressPubKeyHashEcdsaSecp256k1V0(dcrutil.Hash160(key.PubKey()), params)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, pkScript := keyAddr.PaymentScript()
+
+	unspents := make(map[wire.OutPoint]*wire.TxOut)
+	var totalInput int64
+	for i := 0; i < 3; i++ {
+		outPoint := wire.OutPoint{Hash: chainhash.Hash{byte(i + 1)}, Index: uint32(i), Tree: wire.TxTreeRegular}
+		txOut := wire.NewTxOut(int64(i+1)*1e6, pkScript)
+		unspents[outPoint] = txOut
+		totalInput += txOut.Value
+	}
+
+	wallet := newTestWIF(t, params)
+	walletAddr, err := stdaddr.NewAddressPubKeyHashEcdsaSecp256k1V0(dcrutil.Hash160(wallet.PubKey()), params)
+	if err != nil {
+		t.Fatal(err)
+	}
+	address := walletAddr.String()
+	sweepTx, err := buildSweepTx(key, unspents, address, 1e4, params)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tx := sweepTx.Tx
+	if len(tx.TxIn) != len(unspents) || len(tx.TxOut) != 1 || sweepTx.ChangeIndex != 0 {
+		t.Fatalf("got %d inputs and %d outputs, want %d inputs and 1 output", len(tx.TxIn), len(tx.TxOut), len(unspents))
+	}
+	fee := totalInput - tx.TxOut[0].Value
+	if fee <= 0 || int64(sweepTx.TotalInput) != totalInput {
+		t.Fatalf("got fee %d of total input %d", fee, sweepTx.TotalInput)
+	}
+	_, walletScript := walletAddr.PaymentScript()
+	if string(tx.TxOut[0].PkScript) != string(walletScript) {
+		t.Fatalf("the sweep doesn't pay %s", address)
+	}
+
+	// Every input spends its output of the key.
+	for i := range tx.TxIn {
+		vm, err := txscript.NewEngine(pkScript, tx, i, txscript.ScriptVerifySigPushOnly, 0, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := vm.Execute(); err != nil {
+			t.Fatalf("input %d: %v", i, err)
+		}
+	}
+
+	// Funds that don't cover the fee can't be swept.
+	dust := map[wire.OutPoint]*wire.TxOut{{Index: 0}: wire.NewTxOut(100, pkScript)}
+	if _, err := buildSweepTx(key, dust, address, 1e4, params); err == nil || err.Error() != utils.ErrNoFundsToSweep {
+		t.Fatalf("got error %v, want %s", err, utils.ErrNoFundsToSweep)
+	}
+}
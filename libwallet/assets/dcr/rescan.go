@@ -7,8 +7,11 @@ import (
 
 	"decred.org/dcrwallet/v4/errors"
 	w "decred.org/dcrwallet/v4/wallet"
+	"decred.org/dcrwallet/v4/wallet/udb"
 	sharedW "github.com/crypto-power/cryptopower/libwallet/assets/wallet"
+	"github.com/crypto-power/cryptopower/libwallet/utils"
 	"github.com/crypto-power/cryptopower/ui/values"
+	"github.com/decred/dcrd/txscript/v4/stdaddr"
 )
 
 func (asset *Asset) RescanBlocks() error {
@@ -16,6 +19,33 @@ func (asset *Asset) RescanBlocks() error {
 }
 
 func (asset *Asset) RescanBlocksFromHeight(startHeight int32) error {
+	return asset.rescan(&sharedW.RescanState{StartHeight: startHeight, ScannedThrough: startHeight})
+}
+
+// RescanAddresses rescans the blockchain from startHeight for the provided
+// addresses. dcrwallet rescans for all the addresses of the wallet, which
+// the provided addresses must belong to.
+func (asset *Asset) RescanAddresses(startHeight int32, addrs []string) error {
+	for _, addr := range addrs {
+		if _, err := stdaddr.DecodeAddress(addr, asset.chainParams); err != nil {
+			return errors.E(utils.ErrInvalidAddress)
+		}
+	}
+	return asset.rescan(&sharedW.RescanState{StartHeight: startHeight, ScannedThrough: startHeight, Addresses: addrs})
+}
+
+// ResumeRescan resumes the rescan that was interrupted, if any.
+func (asset *Asset) ResumeRescan() error {
+	state := asset.PendingRescan()
+	if state == nil {
+		return nil
+	}
+	return asset.rescan(state)
+}
+
+// rescan rescans the blockchain from where the rescan got to, saving its
+// progress so that it resumes if interrupted.
+func (asset *Asset) rescan(state *sharedW.RescanState) error {
 	netBackend, err := asset.Internal().DCR.NetworkBackend()
 	if err != nil {
 		return errors.E(values.String(values.StrConnectNetworkFailed))
@@ -30,22 +60,30 @@ func (asset *Asset) RescanBlocksFromHeight(startHeight int32) error {
 			asset.syncData.mu.Lock()
 			asset.syncData.rescanning = false
 			asset.syncData.cancelRescan = nil
+			canceled := asset.syncData.rescanCanceled
 			asset.syncData.mu.Unlock()
+
+			if canceled {
+				asset.ClearPendingRescan()
+			}
 		}()
 
 		ctx, cancel := asset.ShutdownContextWithCancel()
 
 		asset.syncData.mu.Lock()
 		asset.syncData.rescanning = true
+		asset.syncData.rescanCanceled = false
 		asset.syncData.cancelRescan = cancel
 		asset.syncData.mu.Unlock()
 
+		asset.SavePendingRescan(state)
+
 		if asset.blocksRescanProgressListener != nil {
 			asset.blocksRescanProgressListener.OnBlocksRescanStarted(asset.ID)
 		}
 
 		progress := make(chan w.RescanProgress, 1)
-		go asset.Internal().DCR.RescanProgressFromHeight(ctx, netBackend, startHeight, progress)
+		go asset.Internal().DCR.RescanProgressFromHeight(ctx, netBackend, state.ScannedThrough, progress)
 
 		rescanStartTime := time.Now()
 
@@ -58,6 +96,9 @@ func (asset *Asset) RescanBlocksFromHeight(startHeight int32) error {
 				return
 			}
 
+			state.ScannedThrough = p.ScannedThrough
+			asset.SavePendingRescan(state)
+
 			rescanProgressReport := &sharedW.HeadersRescanProgressReport{
 				CurrentRescanHeight: p.ScannedThrough,
 				TotalHeadersToScan:  asset.GetBestBlockHeight(),
@@ -98,11 +139,13 @@ func (asset *Asset) RescanBlocksFromHeight(startHeight int32) error {
 			}
 		}
 
+		asset.ClearPendingRescan()
+
 		var err error
-		if startHeight == 0 {
+		if state.StartHeight == 0 {
 			err = asset.reindexTransactions()
 		} else {
-			err = asset.GetWalletDataDb().SaveLastIndexPoint(startHeight)
+			err = asset.GetWalletDataDb().SaveLastIndexPoint(state.StartHeight)
 			if err != nil {
 				if asset.blocksRescanProgressListener != nil {
 					asset.blocksRescanProgressListener.OnBlocksRescanEnded(asset.ID, err)
@@ -120,6 +163,8 @@ func (asset *Asset) RescanBlocksFromHeight(startHeight int32) error {
 	return nil
 }
 
+// CancelRescan cancels the current rescan. The rescan resumes the next time
+// the wallet syncs, such as after the app restarts.
 func (asset *Asset) CancelRescan() {
 	asset.syncData.mu.Lock()
 	defer asset.syncData.mu.Unlock()
@@ -131,6 +176,24 @@ func (asset *Asset) CancelRescan() {
 	}
 }
 
+// DiscardRescan cancels the current rescan at the request of the user and
+// forgets it, so that it doesn't resume.
+func (asset *Asset) DiscardRescan() {
+	asset.syncData.mu.Lock()
+	defer asset.syncData.mu.Unlock()
+	if asset.syncData.cancelRescan == nil {
+		asset.ClearPendingRescan()
+		return
+	}
+
+	// The rescan is forgotten once it stopped saving its progress.
+	asset.syncData.rescanCanceled = true
+	asset.syncData.cancelRescan()
+	asset.syncData.cancelRescan = nil
+
+	log.Info("Rescan discarded.")
+}
+
 func (asset *Asset) IsRescanning() bool {
 	asset.syncData.mu.RLock()
 	defer asset.syncData.mu.RUnlock()
@@ -140,3 +203,21 @@ func (asset *Asset) IsRescanning() bool {
 func (asset *Asset) SetBlocksRescanProgressListener(blocksRescanProgressListener *sharedW.BlocksRescanProgressListener) {
 	asset.blocksRescanProgressListener = blocksRescanProgressListener
 }
+
+// SetRestoreBirthday sets the date the keys of a restored wallet were first
+// used. The wallet then skips the blocks mined before that date when it
+// first syncs.
+func (asset *Asset) SetRestoreBirthday(birthday time.Time) error {
+	if !asset.WalletOpened() {
+		return utils.ErrDCRNotInitialized
+	}
+	if err := asset.Wallet.SetRestoreBirthday(birthday); err != nil {
+		return err
+	}
+
+	ctx, _ := asset.ShutdownContextWithCancel()
+	return asset.Internal().DCR.SetBirthState(ctx, &udb.BirthdayState{
+		Time:        birthday.Add(-sharedW.RestoreBirthdayMargin),
+		SetFromTime: true,
+	})
+}
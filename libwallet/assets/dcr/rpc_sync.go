@@ -0,0 +1,137 @@
+package dcr
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"net"
+	"time"
+
+	"decred.org/dcrwallet/v4/chain"
+	"decred.org/dcrwallet/v4/errors"
+	w "decred.org/dcrwallet/v4/wallet"
+	sharedW "github.com/crypto-power/cryptopower/libwallet/assets/wallet"
+	"github.com/crypto-power/cryptopower/libwallet/utils"
+	"github.com/jrick/wsrpc/v2"
+)
+
+// rpcTestTimeout bounds the time a connection test to dcrd takes.
+const rpcTestTimeout = 30 * time.Second
+
+// chainSyncer is implemented by the SPV syncer and by the syncer of the
+// wallets syncing from dcrd over RPC.
+type chainSyncer interface {
+	w.NetworkBackend
+	Run(ctx context.Context) error
+	Synced(ctx context.Context) (bool, int32)
+}
+
+// rpcDefaultPort returns the default port of the dcrd RPC server on the
+// wallet network.
+func (asset *Asset) rpcDefaultPort() string {
+	switch asset.NetType() {
+	case utils.Mainnet:
+		return "9109"
+	case utils.Testnet:
+		return "19109"
+	default:
+		return "19556"
+	}
+}
+
+// newRPCSyncer returns the syncer of the wallet from the dcrd node in cfg.
+func (asset *Asset) newRPCSyncer(cfg *sharedW.RPCConfig) *chain.Syncer {
+	syncer := chain.NewSyncer(asset.Internal().DCR, &chain.RPCOptions{
+		Address:     cfg.Host,
+		DefaultPort: asset.rpcDefaultPort(),
+		User:        cfg.User,
+		Pass:        cfg.Password,
+		CA:          []byte(cfg.Certificate),
+	})
+	syncer.SetCallbacks(&chain.Callbacks{
+		Synced: asset.syncedWallet,
+		FetchMissingCFiltersStarted: func() {
+			// The syncer reports progress once it is connected to dcrd, its
+			// only peer.
+			asset.handlePeerCountUpdate(1)
+			asset.fetchCFiltersStarted()
+		},
+		FetchMissingCFiltersProgress: asset.fetchCFiltersProgress,
+		FetchMissingCFiltersFinished: asset.fetchCFiltersEnded,
+		FetchHeadersStarted:          asset.fetchHeadersStarted,
+		FetchHeadersProgress:         asset.fetchHeadersProgress,
+		FetchHeadersFinished:         asset.fetchHeadersFinished,
+		DiscoverAddressesStarted:     asset.discoverAddressesStarted,
+		DiscoverAddressesFinished:    asset.discoverAddressesFinished,
+		RescanStarted:                asset.rescanStarted,
+		RescanProgress:               asset.rescanProgress,
+		RescanFinished:               asset.rescanFinished,
+	})
+	return syncer
+}
+
+// TestRPCConnection checks that the dcrd node in cfg accepts the RPC
+// credentials and runs on the wallet network.
+func (asset *Asset) TestRPCConnection(cfg *sharedW.RPCConfig) error {
+	if err := cfg.Validate(asset.GetAssetType()); err != nil {
+		return err
+	}
+
+	host := cfg.Host
+	if _, _, err := net.SplitHostPort(host); err != nil {
+		host = net.JoinHostPort(host, asset.rpcDefaultPort())
+	}
+
+	opts := []wsrpc.Option{wsrpc.WithBasicAuth(cfg.User, cfg.Password)}
+	if cfg.Certificate != "" {
+		pool := x509.NewCertPool()
+		pool.AppendCertsFromPEM([]byte(cfg.Certificate))
+		opts = append(opts, wsrpc.WithTLSConfig(&tls.Config{
+			MinVersion: tls.VersionTLS12,
+			RootCAs:    pool,
+		}))
+	}
+
+	shutdownCtx, _ := asset.ShutdownContextWithCancel()
+	ctx, cancel := context.WithTimeout(shutdownCtx, rpcTestTimeout)
+	defer cancel()
+
+	client, err := wsrpc.Dial(ctx, "wss://"+host+"/ws", opts...)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	var genesisHash string
+	if err := client.Call(ctx, "getblockhash", &genesisHash, 0); err != nil {
+		return err
+	}
+	if genesisHash != asset.chainParams.GenesisHash.String() {
+		return errors.New(utils.ErrRPCWrongNetwork)
+	}
+	return nil
+}
+
+// SetRPCConfig sets the dcrd node the wallet syncs from, or switches the
+// wallet back to SPV if cfg is nil. The sync is restarted if the wallet is
+// connected.
+func (asset *Asset) SetRPCConfig(cfg *sharedW.RPCConfig) error {
+	if err := asset.Wallet.SetRPCConfig(cfg); err != nil {
+		return err
+	}
+
+	if asset.IsConnectedToDecredNetwork() {
+		go func() {
+			if err := asset.RestartSpvSync(); err != nil {
+				log.Error(err)
+			}
+		}()
+	}
+	return nil
+}
+
+// TestElectrumServer always fails as Decred wallets can't sync from Electrum
+// servers.
+func (asset *Asset) TestElectrumServer(_ *sharedW.ElectrumServer, _ string) (string, error) {
+	return "", errors.E(errors.Invalid, "dcr wallets can't sync from electrum servers")
+}
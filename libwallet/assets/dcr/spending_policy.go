@@ -0,0 +1,169 @@
+package dcr
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"time"
+
+	sharedW "github.com/crypto-power/cryptopower/libwallet/assets/wallet"
+	"github.com/crypto-power/cryptopower/libwallet/utils"
+	"github.com/decred/dcrd/chaincfg/chainhash"
+	"github.com/decred/dcrd/txscript/v4/stdscript"
+	"github.com/decred/dcrd/wire"
+)
+
+// spendOutputs returns the outputs of tx paying the send destinations.
+func (asset *Asset) spendOutputs(tx *wire.MsgTx) []*sharedW.SpendOutput {
+	destinations := make(map[string]bool, len(asset.TxAuthoredInfo.destinations))
+	for _, destination := range asset.TxAuthoredInfo.destinations {
+		destinations[destination.Address] = true
+	}
+
+	outputs := make([]*sharedW.SpendOutput, 0, len(tx.TxOut))
+	for _, txOut := range tx.TxOut {
+		_, addrs := stdscript.ExtractAddrs(txOut.Version, txOut.PkScript, asset.chainParams)
+		if len(addrs) > 0 && destinations[addrs[0].String()] {
+			outputs = append(outputs, &sharedW.SpendOutput{Address: addrs[0].String(), Amount: txOut.Value})
+		}
+	}
+	return outputs
+}
+
+// externalOutputs returns the outputs of tx paying addresses that don't
+// belong to the wallet, the sends of a tx that has no send destinations.
+func (asset *Asset) externalOutputs(tx *wire.MsgTx) []*sharedW.SpendOutput {
+	outputs := make([]*sharedW.SpendOutput, 0, len(tx.TxOut))
+	for _, txOut := range tx.TxOut {
+		_, addrs := stdscript.ExtractAddrs(txOut.Version, txOut.PkScript, asset.chainParams)
+		if len(addrs) > 0 && !asset.HaveAddress(addrs[0].String()) {
+			outputs = append(outputs, &sharedW.SpendOutput{Address: addrs[0].String(), Amount: txOut.Value})
+		}
+	}
+	return outputs
+}
+
+// publishWithPolicy broadcasts the signed msgTx, sending outputs from
+// account, if the spending policy allows it and saves its label. If canDelay
+// is set, a send the policy holds back is stored with its inputs locked, so
+// that other sends don't spend them in the meantime, and ErrSendDelayed is
+// returned.
+func (asset *Asset) publishWithPolicy(ctx context.Context, msgTx *wire.MsgTx, account int32, outputs []*sharedW.SpendOutput, label string, canDelay bool) (*chainhash.Hash, error) {
+	n, err := asset.Internal().DCR.NetworkBackend()
+	if err != nil {
+		return nil, err
+	}
+
+	var hold *sharedW.DelayedSend
+	if canDelay {
+		var buf bytes.Buffer
+		buf.Grow(msgTx.SerializeSize())
+		if err := msgTx.Serialize(&buf); err != nil {
+			return nil, err
+		}
+		hold = &sharedW.DelayedSend{
+			Hash:  msgTx.TxHash().String(),
+			Label: label,
+			RawTx: hex.EncodeToString(buf.Bytes()),
+		}
+	}
+
+	var txHash *chainhash.Hash
+	err = asset.SendWithPolicy(account, outputs, hold, func() (err error) {
+		txHash, err = asset.Internal().DCR.PublishTransaction(ctx, msgTx, n)
+		return err
+	})
+	if err != nil {
+		if err.Error() == utils.ErrSendDelayed {
+			asset.lockInputs(msgTx, true)
+		}
+		return nil, err
+	}
+	return txHash, asset.updateTxLabel(txHash, label)
+}
+
+// PublishWithPolicy broadcasts the signed msgTx, sent from account by a user
+// of the wallet such as the DEX, if the spending policy allows its outputs
+// paying other wallets. Sends the policy would hold back are refused.
+func (asset *Asset) PublishWithPolicy(ctx context.Context, msgTx *wire.MsgTx, account int32) (*chainhash.Hash, error) {
+	return asset.publishWithPolicy(ctx, msgTx, account, asset.externalOutputs(msgTx), "", false)
+}
+
+func (asset *Asset) lockInputs(msgTx *wire.MsgTx, lock bool) {
+	for _, txIn := range msgTx.TxIn {
+		op := txIn.PreviousOutPoint
+		if lock {
+			asset.Internal().DCR.LockOutpoint(&op.Hash, op.Index)
+		} else {
+			asset.Internal().DCR.UnlockOutpoint(&op.Hash, op.Index)
+		}
+	}
+}
+
+func decodeDelayedSend(send *sharedW.DelayedSend) (*wire.MsgTx, error) {
+	rawTx, err := hex.DecodeString(send.RawTx)
+	if err != nil {
+		return nil, err
+	}
+	msgTx := new(wire.MsgTx)
+	if err := msgTx.Deserialize(bytes.NewReader(rawTx)); err != nil {
+		return nil, err
+	}
+	return msgTx, nil
+}
+
+// CancelDelayedSend drops the delayed send with the tx hash before it is
+// broadcast.
+func (asset *Asset) CancelDelayedSend(hash string) error {
+	send, err := asset.RemoveDelayedSend(hash)
+	if err != nil {
+		return err
+	}
+	if msgTx, err := decodeDelayedSend(send); err == nil {
+		asset.lockInputs(msgTx, false)
+	}
+	return nil
+}
+
+// PublishDueDelayedSends broadcasts the delayed sends whose cancel window is
+// over and keeps the inputs of the others locked.
+func (asset *Asset) PublishDueDelayedSends() {
+	if !asset.WalletOpened() {
+		return
+	}
+	n, err := asset.Internal().DCR.NetworkBackend()
+	if err != nil {
+		return
+	}
+
+	ctx, _ := asset.ShutdownContextWithCancel()
+	now := time.Now()
+	for _, send := range asset.DelayedSends() {
+		msgTx, err := decodeDelayedSend(send)
+		if err != nil {
+			log.Errorf("Error decoding delayed send %s: %v", send.Hash, err)
+			continue
+		}
+		if !send.Due(now) {
+			// Locked outpoints are forgotten when the wallet is closed.
+			asset.lockInputs(msgTx, true)
+			continue
+		}
+
+		// A send cancelled meanwhile is no longer found and not broadcast.
+		err = asset.PublishDelayedSend(send.Hash, func(send *sharedW.DelayedSend) error {
+			asset.lockInputs(msgTx, false)
+			txHash, err := asset.Internal().DCR.PublishTransaction(ctx, msgTx, n)
+			if err != nil {
+				return err
+			}
+			if err := asset.updateTxLabel(txHash, send.Label); err != nil {
+				log.Errorf("Error saving the label of delayed send %s: %v", send.Hash, err)
+			}
+			return nil
+		})
+		if err != nil && err.Error() != utils.ErrNotExist {
+			log.Errorf("Error broadcasting delayed send %s: %v", send.Hash, err)
+		}
+	}
+}
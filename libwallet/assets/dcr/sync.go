@@ -37,8 +37,12 @@ type SyncData struct {
 	restartSyncRequested bool
 
 	rescanning          bool
+	rescanCanceled      bool // the rescan was canceled by the user
 	numOfConnectedPeers int32
 
+	// peerTraffic counts the bytes exchanged with the SPV peers.
+	peerTraffic peerTraffic
+
 	activeSyncData *activeSyncData
 }
 
@@ -80,7 +84,7 @@ func (s *SyncData) generalSyncProgress() *sharedW.GeneralSyncProgress {
 
 // reading/writing of properties of this struct are protected by syncData.mu.
 type activeSyncData struct {
-	syncer    *spv.Syncer
+	syncer    chainSyncer
 	syncStage utils.SyncStage
 
 	addressDiscoveryCompletedOrCanceled chan bool
@@ -196,6 +200,10 @@ func (asset *Asset) SpvSync() error {
 		return errors.New(utils.ErrSyncAlreadyInProgress)
 	}
 
+	if err := asset.CheckSyncAllowed(); err != nil {
+		return err
+	}
+
 	peerAddresses := asset.ReadStringConfigValueForKey(sharedW.SpvPersistentPeerAddressesConfigKey, "")
 	validPeerAddresses, errs := sharedW.ParseWalletPeers(peerAddresses, asset.chainParams.DefaultPort)
 	for _, err := range errs { // Log errors if any
@@ -213,18 +221,28 @@ func (asset *Asset) SpvSync() error {
 	asset.waitingForHeaders = true
 	asset.syncing = true
 
-	addr := &net.TCPAddr{IP: net.ParseIP("::1"), Port: 0}
-	addrManager := addrmgr.New(asset.DataDir(), net.LookupIP) // TODO: be mindful of tor
-	lp := p2p.NewLocalPeer(asset.chainParams, addr, addrManager)
-
-	// Set the node to only connect to remote peers whose advertised best block
-	// height is greater than the currently synced.
-	lp.RequirePeerHeight(asset.GetBestBlockHeight())
-
-	syncer := spv.NewSyncer(asset.Internal().DCR, lp)
-	syncer.SetNotifications(asset.spvSyncNotificationCallbacks())
-	if len(validPeerAddresses) > 0 {
-		syncer.SetPersistentPeers(validPeerAddresses)
+	var syncer chainSyncer
+	var sharedPeers bool
+	rpcConfig := asset.RPCConfig()
+	if rpcConfig != nil {
+		syncer = asset.newRPCSyncer(rpcConfig)
+	} else {
+		addr := &net.TCPAddr{IP: net.ParseIP("::1"), Port: 0}
+		var addrManager *addrmgr.AddrManager
+		addrManager, sharedPeers = asset.addrManager(validPeerAddresses)
+		lp := p2p.NewLocalPeer(asset.chainParams, addr, addrManager)
+		lp.SetDialFunc(asset.dialPeer)
+
+		// Set the node to only connect to remote peers whose advertised best block
+		// height is greater than the currently synced.
+		lp.RequirePeerHeight(asset.GetBestBlockHeight())
+
+		spvSyncer := spv.NewSyncer(asset.Internal().DCR, lp)
+		spvSyncer.SetNotifications(asset.spvSyncNotificationCallbacks())
+		if len(validPeerAddresses) > 0 {
+			spvSyncer.SetPersistentPeers(validPeerAddresses)
+		}
+		syncer = spvSyncer
 	}
 
 	ctx, cancel := asset.ShutdownContextWithCancel()
@@ -258,6 +276,12 @@ func (asset *Asset) SpvSync() error {
 				asset.notifySyncError(syncError)
 			}
 		}
+		if rpcConfig != nil {
+			asset.handlePeerCountUpdate(0)
+		}
+		if sharedPeers {
+			asset.releaseSharedPeerSet()
+		}
 
 		// Close the syncer channel after the syncer.Run stops.
 		close(asset.syncData.syncCanceled)
@@ -380,7 +404,11 @@ func (asset *Asset) PeerInfoRaw() ([]sharedW.PeerInfo, error) {
 		return nil, errors.New(utils.ErrNotConnected)
 	}
 
-	syncer := asset.syncData.activeSyncData.syncer
+	// Wallets syncing from dcrd have no peers of their own.
+	syncer, ok := asset.syncData.activeSyncData.syncer.(*spv.Syncer)
+	if !ok {
+		return []sharedW.PeerInfo{}, nil
+	}
 
 	infos := make([]sharedW.PeerInfo, 0, len(syncer.GetRemotePeers()))
 	for _, rp := range syncer.GetRemotePeers() {
@@ -392,8 +420,12 @@ func (asset *Asset) PeerInfoRaw() ([]sharedW.PeerInfo, error) {
 			Version:        rp.Pver(),
 			SubVer:         rp.UA(),
 			StartingHeight: int64(rp.InitialHeight()),
+			LastBlock:      int64(rp.LastHeight()),
 			BanScore:       int32(rp.BanScore()),
+			Preferred:      asset.IsPeerPreferred(rp.RemoteAddr().String()),
 		}
+		// dcrwallet doesn't measure the latency of its peers.
+		info.BytesSent, info.BytesReceived = asset.syncData.peerTraffic.bytes(info.Addr)
 
 		infos = append(infos, info)
 	}
@@ -76,7 +76,7 @@ func (asset *Asset) fetchCFiltersProgress(startCFiltersHeight, endCFiltersHeight
 	}
 
 	var cfiltersFetchData = &sharedW.CFiltersFetchProgressReport{
-		GeneralSyncProgress:       &sharedW.GeneralSyncProgress{},
+		GeneralSyncProgress:       &sharedW.GeneralSyncProgress{SyncDataUsage: asset.SyncDataUsage()},
 		TotalFetchedCFiltersCount: endCFiltersHeight - startCFiltersHeight,
 	}
 
@@ -253,6 +253,7 @@ func (asset *Asset) fetchHeadersProgress(lastFetchedHeaderHeight int32, _ int64)
 		GeneralSyncProgress: &sharedW.GeneralSyncProgress{
 			TotalSyncProgress:  syncProgress,
 			TotalTimeRemaining: secondsToDuration(timeRemaining),
+			SyncDataUsage:      asset.SyncDataUsage(),
 		},
 	}
 	headersFetchedData.TotalHeadersToFetch = asset.syncData.bestBlockHeight
@@ -394,6 +395,7 @@ func (asset *Asset) updateAddressDiscoveryProgress() {
 				GeneralSyncProgress: &sharedW.GeneralSyncProgress{
 					TotalSyncProgress:  totalProgressPercent,
 					TotalTimeRemaining: totalTimeRemaining,
+					SyncDataUsage:      asset.SyncDataUsage(),
 				},
 			}
 			addressDiscoveryData.AddressDiscoveryProgress = int32(discoveryProgress)
@@ -508,7 +510,7 @@ func (asset *Asset) rescanProgress(rescannedThrough int32) {
 	totalElapsedTime := totalElapsedTimePreRescans + elapsedRescanTime
 
 	headersRescanData := &sharedW.HeadersRescanProgressReport{
-		GeneralSyncProgress: &sharedW.GeneralSyncProgress{},
+		GeneralSyncProgress: &sharedW.GeneralSyncProgress{SyncDataUsage: asset.SyncDataUsage()},
 	}
 	headersRescanData.TotalHeadersToScan = totalHeadersToScan
 	headersRescanData.RescanProgress = int32(rescanRate * 100)
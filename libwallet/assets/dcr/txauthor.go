@@ -213,7 +213,7 @@ func (asset *Asset) Broadcast(privatePassphrase, transactionLabel string) (strin
 		return "", utils.ErrDCRNotInitialized
 	}
 
-	n, err := asset.Internal().DCR.NetworkBackend()
+	_, err := asset.Internal().DCR.NetworkBackend()
 	if err != nil {
 		log.Error(err)
 		return "", err
@@ -228,6 +228,13 @@ func (asset *Asset) Broadcast(privatePassphrase, transactionLabel string) (strin
 		unsignedTx.RandomizeChangePosition()
 	}
 
+	account := int32(asset.TxAuthoredInfo.sourceAccountNumber)
+	outputs := asset.spendOutputs(unsignedTx.Tx)
+	// Refuse the sends the spending policy forbids before signing them.
+	if _, err := asset.CheckSpend(account, outputs); err != nil {
+		return "", err
+	}
+
 	var txBuf bytes.Buffer
 	txBuf.Grow(unsignedTx.Tx.SerializeSize())
 	err = unsignedTx.Tx.Serialize(&txBuf)
@@ -284,11 +291,11 @@ func (asset *Asset) Broadcast(privatePassphrase, transactionLabel string) (strin
 		return "", err
 	}
 
-	txHash, err := asset.Internal().DCR.PublishTransaction(ctx, &msgTx, n)
+	txHash, err := asset.publishWithPolicy(ctx, &msgTx, account, outputs, transactionLabel, true)
 	if err != nil {
 		return "", utils.TranslateError(err)
 	}
-	return txHash.String(), asset.updateTxLabel(txHash, transactionLabel)
+	return txHash.String(), nil
 }
 
 // updateTxLabel saves the tx label in the local instance.
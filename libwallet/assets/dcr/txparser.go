@@ -74,6 +74,7 @@ func (asset *Asset) decodeTransactionWithTxSummary(txSummary *w.TransactionSumma
 	if err != nil {
 		return nil, err
 	}
+	decodedTx.Label = asset.RestoredTxLabel(decodedTx.Hash)
 
 	if decodedTx.TicketSpentHash != "" {
 		ticketPurchaseTx, err := asset.GetTransactionRaw(decodedTx.TicketSpentHash)
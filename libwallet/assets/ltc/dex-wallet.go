@@ -58,11 +58,11 @@ const (
 
 // DEXWallet wraps *wallet.Wallet and implements dexbtc.BTCWallet.
 type DEXWallet struct {
-	w                 *wallet.Wallet
-	acctNum           int32
-	cl                *ChainService
-	btcParams         *chaincfg.Params
-	syncStatusChecker SyncStatusChecker
+	w         *wallet.Wallet
+	acctNum   int32
+	cl        *ChainService
+	btcParams *chaincfg.Params
+	helper    WalletHelper
 	*dexbtc.BlockFiltersScanner
 }
 
@@ -121,17 +121,24 @@ type SyncStatusChecker interface {
 	IsSynced() bool
 }
 
+// WalletHelper is the asset wrapping the wallet of the DEX wallet.
+type WalletHelper interface {
+	SyncStatusChecker
+	PublishWithPolicy(msgTx *ltcwire.MsgTx, account int32) error
+}
+
 var _ dexbtc.CustomWallet = (*DEXWallet)(nil)
 var _ dexbtc.BlockInfoReader = (*DEXWallet)(nil)
+var _ WalletHelper = (*Asset)(nil)
 
 // NewDEXWallet returns a new *DEXWallet.
-func NewDEXWallet(w *wallet.Wallet, acctNum int32, cl *ChainService, btcParams *chaincfg.Params, syncStatusChecker SyncStatusChecker) *DEXWallet {
+func NewDEXWallet(w *wallet.Wallet, acctNum int32, cl *ChainService, btcParams *chaincfg.Params, helper WalletHelper) *DEXWallet {
 	dw := &DEXWallet{
-		w:                 w,
-		acctNum:           acctNum,
-		cl:                cl,
-		btcParams:         btcParams,
-		syncStatusChecker: syncStatusChecker,
+		w:         w,
+		acctNum:   acctNum,
+		cl:        cl,
+		btcParams: btcParams,
+		helper:    helper,
 	}
 
 	dw.BlockFiltersScanner = dexbtc.NewBlockFiltersScanner(dw, dexLogger{Logger: log})
@@ -179,7 +186,7 @@ func (dw *DEXWallet) SendRawTransaction(tx *wire.MsgTx) (*chainhash.Hash, error)
 		return nil, err
 	}
 
-	err = dw.w.PublishTransaction(ltcTx, "")
+	err = dw.helper.PublishWithPolicy(ltcTx, dw.acctNum)
 	if err != nil {
 		return nil, err
 	}
@@ -262,7 +269,7 @@ func (dw *DEXWallet) GetChainHeight() (int32, error) {
 
 // Part of dexbtc.Wallet interface.
 func (dw *DEXWallet) PeerCount() (uint32, error) {
-	if !dw.syncStatusChecker.IsSyncing() && !dw.syncStatusChecker.IsSynced() {
+	if !dw.helper.IsSyncing() && !dw.helper.IsSynced() {
 		return 0, nil // avoid expensive call to dw.cl.Peers()
 	}
 
@@ -271,7 +278,7 @@ func (dw *DEXWallet) PeerCount() (uint32, error) {
 
 // syncHeight is the best known sync height among peers.
 func (dw *DEXWallet) syncHeight() int32 {
-	if !dw.syncStatusChecker.IsSyncing() && !dw.syncStatusChecker.IsSynced() {
+	if !dw.helper.IsSyncing() && !dw.helper.IsSynced() {
 		return 0 // avoid expensive call to dw.cl.Peers()
 	}
 
@@ -294,7 +301,7 @@ func (dw *DEXWallet) syncHeight() int32 {
 func (dw *DEXWallet) SyncStatus() (*asset.SyncStatus, error) {
 	walletBlock := dw.syncedTo()
 	return &asset.SyncStatus{
-		Synced:         dw.syncStatusChecker.IsSynced(),
+		Synced:         dw.helper.IsSynced(),
 		TargetHeight:   uint64(dw.syncHeight()),
 		StartingBlocks: 0,
 		Blocks:         uint64(walletBlock.Height),
@@ -2,24 +2,28 @@ package ltc
 
 import (
 	"fmt"
-	"net/http"
-	"sort"
-	"strconv"
 	"sync"
 
 	"decred.org/dcrwallet/v4/errors"
 	sharedW "github.com/crypto-power/cryptopower/libwallet/assets/wallet"
 	"github.com/crypto-power/cryptopower/libwallet/utils"
+	"github.com/ltcsuite/ltcd/blockchain"
+	"github.com/ltcsuite/ltcd/chaincfg/chainhash"
 	"github.com/ltcsuite/ltcd/ltcutil"
 )
 
-// TODO: Update this file for Litecoin
-
 const (
 	// MainnetAPIFeeRateURL defines the URL to fetch the mainnet fee rate from.
-	MainnetAPIFeeRateURL = "https://blockstream.info/api/fee-estimates" // TODO: Update for Litecoin
+	MainnetAPIFeeRateURL = "https://litecoinspace.org/api/v1/fees/recommended"
 	// TestnetAPIFeeRateURL defines the URL to fetch the testnet fee rate from.
-	TestnetAPIFeeRateURL = "https://blockstream.info/testnet/api/fee-estimates" // TODO: Update for Litecoin
+	TestnetAPIFeeRateURL = "https://litecoinspace.org/testnet/api/v1/fees/recommended"
+	// MainnetBlockcypherFeeRateURL defines the blockcypher URL to fetch the
+	// mainnet fee rate from. Blockcypher doesn't serve the litecoin testnet.
+	MainnetBlockcypherFeeRateURL = "https://api.blockcypher.com/v1/ltc/main"
+
+	// recentFeeBlocks is the number of recent blocks fee rates are estimated
+	// from when syncing with neutrino.
+	recentFeeBlocks = 6
 
 	// Since the introduction of segwit account, a different tx size measument was
 	// introduced (Lit/VB). When sending a transaction from the legacy account,
@@ -44,6 +48,17 @@ const (
 	MinFeeRatePerkvB ltcutil.Amount = 1000 // Equals to 1 lit/vB.
 )
 
+// feeRateAPIs are the HTTP APIs queried for fee estimates on each network.
+var feeRateAPIs = map[utils.NetworkType][]sharedW.FeeRateAPI{
+	utils.Mainnet: {
+		{Name: "litecoinspace.org", URL: MainnetAPIFeeRateURL, Format: sharedW.MempoolFeeRates},
+		{Name: "blockcypher", URL: MainnetBlockcypherFeeRateURL, Format: sharedW.BlockcypherFeeRates},
+	},
+	utils.Testnet: {
+		{Name: "litecoinspace.org", URL: TestnetAPIFeeRateURL, Format: sharedW.MempoolFeeRates},
+	},
+}
+
 // feeEstimateCache helps to cache the resolved fee rate until a new
 // block is mined
 type feeEstimateCache struct {
@@ -56,60 +71,125 @@ type feeEstimateCache struct {
 	// LastBestblock defines the last height when results were cached. This
 	// helps to keep the API calls to under control.
 	LastBestblock int32
+	// blockFeeRates are the fee rates of the recent blocks, by block hash.
+	blockFeeRates map[chainhash.Hash]blockFeeRate
 
 	mu sync.RWMutex
 }
 
-// fetchAPIFeeRate queries the API fee rate.
-func (asset *Asset) fetchAPIFeeRate() ([]sharedW.FeeEstimate, error) {
-	var feerateURL string
-	net := asset.NetType()
-	switch net {
-	case utils.Mainnet:
-		feerateURL = MainnetAPIFeeRateURL
-	case utils.Testnet:
-		feerateURL = TestnetAPIFeeRateURL
-	default:
-		return nil, fmt.Errorf("%v network is not supported", net)
+// feeEstimateProviders returns the sources of the fee estimates of the
+// wallet: the full node or Electrum servers it syncs from, or the recent
+// blocks it fetched when syncing with neutrino and the HTTP APIs of its
+// network. The wallets syncing from a full node or Electrum servers don't
+// query the HTTP APIs, which would learn of the wallet.
+func (asset *Asset) feeEstimateProviders() []sharedW.FeeEstimateProvider {
+	var providers []sharedW.FeeEstimateProvider
+	if cfg := asset.RPCConfig(); cfg != nil {
+		providers = append(providers, sharedW.NewFeeEstimateProvider("full node", func() ([]sharedW.FeeEstimate, error) {
+			return asset.fetchNodeFeeRate(cfg)
+		}))
+	}
+	if cfg := asset.ElectrumConfig(); cfg != nil {
+		providers = append(providers, sharedW.NewFeeEstimateProvider("electrum servers", func() ([]sharedW.FeeEstimate, error) {
+			return asset.fetchElectrumFeeRate(cfg)
+		}))
+	}
+	if len(providers) > 0 {
+		return providers
 	}
 
-	resp := make(map[string]float64, 0)
+	if asset.chainClient != nil && asset.IsSynced() {
+		providers = append(providers, sharedW.NewFeeEstimateProvider("recent blocks", asset.recentBlocksFeeRate))
+	}
+	for _, api := range feeRateAPIs[asset.NetType()] {
+		providers = append(providers, api.Provider(asset.ToAmount))
+	}
+	return providers
+}
 
-	req := &utils.ReqConfig{
-		Method:  http.MethodGet,
-		HTTPURL: feerateURL,
+// fetchAPIFeeRate returns the median of the fee estimates of every source
+// for each confirmation target.
+func (asset *Asset) fetchAPIFeeRate() ([]sharedW.FeeEstimate, error) {
+	providers := asset.feeEstimateProviders()
+	if len(providers) == 0 {
+		return nil, fmt.Errorf("%v network is not supported", asset.NetType())
 	}
+	return sharedW.MedianFeeEstimates(providers, sharedW.FeeEstimateTargets, asset.ToAmount)
+}
+
+// blockFeeRate is the average fee rate of a recent block, cached so that
+// only the blocks mined since the last estimate are fetched.
+type blockFeeRate struct {
+	prevHash chainhash.Hash
+	// rate is 0 for blocks without fees, such as empty blocks.
+	rate int64
+}
 
-	if _, err := utils.HTTPRequest(req, &resp); err != nil {
-		return nil, fmt.Errorf("fetching API fee estimates failed: %v", err)
+// recentBlocksFeeRate estimates the fee rates from the average fee rate of the
+// last recentFeeBlocks blocks, fetched from the neutrino peers. The blocks
+// count towards the data the wallet syncs, they aren't fetched when the sync
+// policy doesn't let the wallet sync.
+func (asset *Asset) recentBlocksFeeRate() ([]sharedW.FeeEstimate, error) {
+	if gate := asset.SyncGate(); gate != nil {
+		if err := gate.Check(); err != nil {
+			return nil, err
+		}
 	}
 
-	// if no data was returned, return an error.
-	if len(resp) == 0 {
-		return nil, errors.New("API fee estimates not found")
+	bestHash, bestHeight, err := asset.chainClient.GetBestBlock()
+	if err != nil {
+		return nil, err
 	}
 
-	results := make([]sharedW.FeeEstimate, 0, len(resp))
+	asset.fees.mu.RLock()
+	cached := asset.fees.blockFeeRates
+	asset.fees.mu.RUnlock()
+
+	blockRates := make(map[chainhash.Hash]blockFeeRate, recentFeeBlocks)
+	rates := make([]int64, 0, recentFeeBlocks)
+	hash := *bestHash
+	for height := bestHeight; height > bestHeight-recentFeeBlocks && height > 0; height-- {
+		blockRate, ok := cached[hash]
+		if !ok {
+			block, err := asset.chainClient.GetBlock(&hash)
+			if err != nil {
+				return nil, err
+			}
+			blockRate.prevHash = block.Header.PrevBlock
+
+			// The coinbase collects the subsidy and the fees of the block.
+			var fees int64
+			for _, txOut := range block.Transactions[0].TxOut {
+				fees += txOut.Value
+			}
+			fees -= blockchain.CalcBlockSubsidy(height, asset.chainParams)
+			vsize := (blockchain.GetBlockWeight(ltcutil.NewBlock(block)) + blockchain.WitnessScaleFactor - 1) /
+				blockchain.WitnessScaleFactor
+			if fees > 0 && vsize > 0 {
+				blockRate.rate = max(fees*1000/vsize, int64(MinFeeRatePerkvB))
+			}
+		}
+		blockRates[hash] = blockRate
+		hash = blockRate.prevHash
 
-	// Fee rate returned is in lit/vB units.
-	for blocks, feerate := range resp {
-		vals, err := strconv.ParseInt(blocks, 10, 64)
-		if err != nil {
-			// Invalid blocks confirmation found ignore it,
-			continue
+		// Blocks without fees tell nothing.
+		if blockRate.rate > 0 {
+			rates = append(rates, blockRate.rate)
 		}
+	}
+
+	asset.fees.mu.Lock()
+	asset.fees.blockFeeRates = blockRates
+	asset.fees.mu.Unlock()
 
-		results = append(results, sharedW.FeeEstimate{
-			ConfirmedBlocks: int32(vals),
-			// Fee rate conversion from lit/vB to lit/kvB is at the rate of
-			// 1000 lit/kvB == 1 lit/vB
-			Feerate: Amount(int(feerate * 1000.0)),
-		})
+	if len(rates) == 0 {
+		return nil, errors.New("no recent blocks with fees")
 	}
-	return results, nil
+	return sharedW.BlockFeeEstimates(rates, asset.ToAmount), nil
 }
 
-// GetAPIFeeEstimateRate returns the fee estimates from the API.
+// GetAPIFeeEstimateRate returns the fee estimates for each confirmation
+// target, sorted by target.
 func (asset *Asset) GetAPIFeeEstimateRate() (feerates []sharedW.FeeEstimate, err error) {
 	asset.fees.mu.RLock()
 	feerates = asset.fees.APIFeeRates
@@ -131,15 +211,6 @@ func (asset *Asset) GetAPIFeeEstimateRate() (feerates []sharedW.FeeEstimate, err
 		return nil, errors.New("API feerates not available")
 	}
 
-	sort.Slice(feerates, func(i, j int) bool {
-		return feerates[i].ConfirmedBlocks < feerates[j].ConfirmedBlocks
-	})
-
-	if len(feerates) > 5 {
-		// TODO: subject to confirmation! => persist top five fee rates only.
-		feerates = feerates[:5]
-	}
-
 	asset.fees.mu.Lock()
 	asset.fees.APIFeeRates = feerates
 	asset.fees.LastBestblock = asset.GetBestBlockHeight()
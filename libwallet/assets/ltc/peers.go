@@ -0,0 +1,208 @@
+package ltc
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sort"
+	"time"
+
+	"decred.org/dcrwallet/v4/errors"
+	sharedW "github.com/crypto-power/cryptopower/libwallet/assets/wallet"
+	"github.com/crypto-power/cryptopower/libwallet/utils"
+	neutrino "github.com/dcrlabs/ltcwallet/spv"
+)
+
+// peersQueryTimeout is how long the chain service is waited on for its peers,
+// as the query stalls while the chain service is busy.
+const peersQueryTimeout = 2 * time.Second
+
+// peerDialer returns the dialer of a chain service, which refuses to connect
+// to the peers isBanned reports and reports the data exchanged with the
+// peers to countData.
+func peerDialer(ctx context.Context, isBanned func(addr string) bool, countData func(n int)) utils.Dailer {
+	dial := utils.DialerFunc(ctx)
+	return func(addr net.Addr) (net.Conn, error) {
+		if isBanned(addr.String()) {
+			return nil, errors.New(utils.ErrPeerBanned)
+		}
+		conn, err := dial(addr)
+		if err != nil {
+			return nil, err
+		}
+		return utils.NewCountingConn(conn, countData), nil
+	}
+}
+
+// spvChainService returns the chain service of a wallet connected to the
+// network with SPV, or nil. The chain service is not queried before the
+// wallet connects as its queries never return until it is started.
+func (asset *Asset) spvChainService() *neutrino.ChainService {
+	if !asset.IsConnectedToNetwork() || asset.isRPCMode() {
+		return nil
+	}
+	return asset.cl
+}
+
+// serverPeers returns the peers the wallet is connected to.
+func (asset *Asset) serverPeers() []*neutrino.ServerPeer {
+	cs := asset.spvChainService()
+	if cs == nil {
+		return nil
+	}
+
+	peersChan := make(chan []*neutrino.ServerPeer, 1)
+	go func() {
+		peersChan <- cs.Peers()
+	}()
+	select {
+	case peers := <-peersChan:
+		return peers
+	case <-time.After(peersQueryTimeout):
+		return nil
+	}
+}
+
+// PeerInfoRaw returns the peers the wallet is connected to. Wallets syncing
+// from a full node or Electrum servers have no peers.
+func (asset *Asset) PeerInfoRaw() ([]sharedW.PeerInfo, error) {
+	if !asset.IsConnectedToNetwork() {
+		return nil, errors.New(utils.ErrNotConnected)
+	}
+
+	peers := asset.serverPeers()
+	infos := make([]sharedW.PeerInfo, 0, len(peers))
+	for _, p := range peers {
+		stats := p.StatsSnapshot()
+		var localAddr string
+		if addr := p.LocalAddr(); addr != nil {
+			localAddr = addr.String()
+		}
+		infos = append(infos, sharedW.PeerInfo{
+			ID:             stats.ID,
+			Addr:           stats.Addr,
+			AddrLocal:      localAddr,
+			Services:       fmt.Sprintf("%08d", uint64(stats.Services)),
+			Version:        stats.Version,
+			SubVer:         stats.UserAgent,
+			StartingHeight: int64(stats.StartingHeight),
+			BanScore:       sharedW.NoBanScore,
+			LastBlock:      int64(stats.LastBlock),
+			Latency:        time.Duration(stats.LastPingMicros) * time.Microsecond,
+			BytesSent:      stats.BytesSent,
+			BytesReceived:  stats.BytesRecv,
+			Preferred:      asset.IsPeerPreferred(stats.Addr),
+		})
+	}
+
+	sort.Slice(infos, func(i, j int) bool {
+		return infos[i].ID < infos[j].ID
+	})
+	return infos, nil
+}
+
+// DisconnectPeer disconnects the peer at addr. The wallet may connect to it
+// again later.
+func (asset *Asset) DisconnectPeer(addr string) error {
+	cs := asset.spvChainService()
+	if cs == nil {
+		return errors.New(utils.ErrNotConnected)
+	}
+	if err := cs.DisconnectNodeByAddr(addr); err != nil {
+		return errors.New(utils.ErrPeerNotFound)
+	}
+	return nil
+}
+
+// dropPeers disconnects the peers with the host of addr, including
+// persistent peers which would otherwise be reconnected to.
+func (asset *Asset) dropPeers(addr string) {
+	cs := asset.spvChainService()
+	if cs == nil {
+		return
+	}
+	host := sharedW.PeerHost(addr)
+	for _, p := range asset.serverPeers() {
+		if sharedW.PeerHost(p.Addr()) != host {
+			continue
+		}
+		if err := cs.RemoveNodeByAddr(p.Addr()); err != nil {
+			_ = cs.DisconnectNodeByAddr(p.Addr())
+		}
+	}
+}
+
+// BanPeer disconnects the peers with the host of addr and refuses to connect
+// to them again until they are unbanned.
+func (asset *Asset) BanPeer(addr string) error {
+	if err := asset.SetPeerBanned(addr, true); err != nil {
+		return err
+	}
+
+	asset.syncData.mu.RLock()
+	shared := asset.syncData.sharedChainService
+	asset.syncData.mu.RUnlock()
+	if shared != nil {
+		shared.setBanned(addr, true)
+	}
+
+	asset.dropPeers(addr)
+	return nil
+}
+
+// UnbanPeer allows the wallet to connect to the peers with the host of addr
+// again.
+func (asset *Asset) UnbanPeer(addr string) error {
+	if err := asset.SetPeerBanned(addr, false); err != nil {
+		return err
+	}
+
+	asset.syncData.mu.RLock()
+	shared := asset.syncData.sharedChainService
+	asset.syncData.mu.RUnlock()
+	if shared != nil {
+		shared.setBanned(addr, false)
+	}
+	return nil
+}
+
+// SetPeerPreferred adds the peer at addr to the peers the wallet keeps a
+// connection to, or removes it from them.
+func (asset *Asset) SetPeerPreferred(addr string, preferred bool) error {
+	addr, err := sharedW.PeerAddressWithPort(addr, asset.chainParams.DefaultPort)
+	if err != nil {
+		return err
+	}
+	if err := asset.Wallet.SetPeerPreferred(addr, preferred); err != nil {
+		return err
+	}
+
+	// The preferred peers of a wallet that isn't connected are connected to
+	// when it syncs.
+	cs := asset.spvChainService()
+	if cs == nil {
+		return nil
+	}
+	if preferred {
+		if err := cs.ConnectNode(addr, true); err != nil {
+			log.Debugf("Connecting to preferred peer %s failed: %v", addr, err)
+		}
+	} else if err := cs.RemoveNodeByAddr(addr); err != nil {
+		log.Debugf("Removing preferred peer %s failed: %v", addr, err)
+	}
+	return nil
+}
+
+// connectPreferredPeers keeps connections to the preferred peers of the
+// wallet.
+func (asset *Asset) connectPreferredPeers() {
+	cs := asset.spvChainService()
+	if cs == nil {
+		return
+	}
+	for _, addr := range asset.PreferredPeers() {
+		if err := cs.ConnectNode(addr, true); err != nil {
+			log.Debugf("Connecting to preferred peer %s failed: %v", addr, err)
+		}
+	}
+}
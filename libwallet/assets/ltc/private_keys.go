@@ -0,0 +1,330 @@
+package ltc
+
+import (
+	"bytes"
+	"strings"
+	"time"
+
+	"decred.org/dcrwallet/v4/errors"
+	sharedW "github.com/crypto-power/cryptopower/libwallet/assets/wallet"
+	"github.com/crypto-power/cryptopower/libwallet/txhelper"
+	"github.com/crypto-power/cryptopower/libwallet/utils"
+	"github.com/dcrlabs/ltcwallet/chain"
+	"github.com/dcrlabs/ltcwallet/waddrmgr"
+	"github.com/dcrlabs/ltcwallet/wallet/txauthor"
+	"github.com/dcrlabs/ltcwallet/wtxmgr"
+	"github.com/ltcsuite/ltcd/btcec/v2"
+	"github.com/ltcsuite/ltcd/chaincfg"
+	"github.com/ltcsuite/ltcd/ltcutil"
+	"github.com/ltcsuite/ltcd/txscript"
+	"github.com/ltcsuite/ltcd/wire"
+)
+
+// sweepBatchSize is the number of blocks filtered at once when scanning the
+// chain for the outputs of a swept key.
+const sweepBatchSize = 2000
+
+// ImportPrivateKey imports the WIF encoded private key into the imported
+// account and rescans the chain from startHeight for the transactions of its
+// addresses, which are returned. The legacy address of the key is imported,
+// and its native and nested segwit addresses too if the key is compressed,
+// the same addresses a sweep of the key looks for. If the rescan can't start,
+// it is saved to run once the wallet is synced again.
+func (asset *Asset) ImportPrivateKey(wif, privatePassphrase string, startHeight int32) ([]string, error) {
+	key, err := asset.decodeWIF(wif)
+	if err != nil {
+		return nil, err
+	}
+
+	if !asset.IsSynced() {
+		return nil, errors.E(utils.ErrNotSynced)
+	}
+	// The rescan running would drop the rescan of the key once done.
+	if asset.IsRescanning() {
+		return nil, errors.E(utils.ErrSyncAlreadyInProgress)
+	}
+
+	bs, err := asset.getblockStamp(startHeight)
+	if err != nil {
+		return nil, err
+	}
+
+	lock := make(chan time.Time, 1)
+	defer func() {
+		lock <- time.Time{}
+	}()
+
+	err = asset.Internal().LTC.Unlock([]byte(privatePassphrase), lock)
+	if err != nil {
+		log.Errorf("unlocking the wallet failed: %v", err)
+		return nil, errors.New(utils.ErrInvalidPassphrase)
+	}
+
+	scopes := []waddrmgr.KeyScope{waddrmgr.KeyScopeBIP0044}
+	if key.CompressPubKey {
+		scopes = append(scopes, GetScope(), waddrmgr.KeyScopeBIP0049Plus)
+	}
+
+	addrs := make([]string, 0, len(scopes))
+	for _, scope := range scopes {
+		stamp := *bs
+		addr, err := asset.Internal().LTC.ImportPrivateKey(scope, key, &stamp, false)
+		if waddrmgr.IsError(err, waddrmgr.ErrDuplicateAddress) {
+			return nil, errors.E(utils.ErrExist)
+		} else if err != nil {
+			return nil, err
+		}
+		addrs = append(addrs, addr)
+	}
+
+	if err := asset.RescanAddresses(startHeight, addrs); err != nil {
+		log.Errorf("Rescanning for the imported key failed, it resumes once synced: %v", err)
+		asset.QueuePendingRescan(&sharedW.RescanState{StartHeight: startHeight, ScannedThrough: startHeight, Addresses: addrs})
+	}
+	return addrs, nil
+}
+
+// PrepareKeySweep signs a transaction sending the funds of the WIF encoded
+// private key to the current address of account, without importing the key.
+// The chain is scanned from startHeight for the confirmed outputs of the key.
+// The transaction is only published by PublishKeySweep, once its amount and
+// fee are confirmed.
+func (asset *Asset) PrepareKeySweep(wif string, account, startHeight int32) (*sharedW.SweepResult, error) {
+	key, err := asset.decodeWIF(wif)
+	if err != nil {
+		return nil, err
+	}
+
+	if !asset.IsSynced() {
+		return nil, errors.E(utils.ErrNotSynced)
+	}
+
+	keyAddrs, err := keyAddresses(key, asset.chainParams)
+	if err != nil {
+		return nil, err
+	}
+	unspents, err := asset.scanKeyOutputs(keyAddrs, startHeight)
+	if err != nil {
+		return nil, err
+	}
+	if len(unspents) == 0 {
+		return nil, errors.E(utils.ErrNoFundsToSweep)
+	}
+
+	address, err := asset.CurrentAddress(account)
+	if err != nil {
+		return nil, err
+	}
+	feeRate := ltcutil.Amount(asset.GetUserFeeRate().ToInt())
+	sweepTx, err := buildSweepTx(key, unspents, address, feeRate, asset.chainParams)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	if err := sweepTx.Tx.Serialize(&buf); err != nil {
+		return nil, err
+	}
+	amount := ltcutil.Amount(sweepTx.Tx.TxOut[sweepTx.ChangeIndex].Value)
+	return &sharedW.SweepResult{
+		TxHash:   sweepTx.Tx.TxHash().String(),
+		Account:  account,
+		Address:  address,
+		Amount:   Amount(amount),
+		Fee:      Amount(sweepTx.TotalInput - amount),
+		Inputs:   len(sweepTx.Tx.TxIn),
+		SignedTx: buf.Bytes(),
+	}, nil
+}
+
+// PublishKeySweep publishes the sweep of a private key signed by
+// PrepareKeySweep.
+func (asset *Asset) PublishKeySweep(sweep *sharedW.SweepResult) error {
+	tx := wire.NewMsgTx(wire.TxVersion)
+	if err := tx.Deserialize(bytes.NewReader(sweep.SignedTx)); err != nil {
+		return err
+	}
+	// The sweep pays the wallet, it only goes through the spending policy
+	// like every other send.
+	if err := asset.publishWithPolicy(tx, sweep.Account, asset.externalOutputs(tx), "", false); err != nil {
+		return utils.TranslateError(err)
+	}
+	return nil
+}
+
+// buildSweepTx returns the transaction spending every output of the key in
+// unspents to address, the funds less the fee going to address as change.
+func buildSweepTx(key *ltcutil.WIF, unspents map[wire.OutPoint]*wire.TxOut, address string, feeRate ltcutil.Amount,
+	chainParams *chaincfg.Params) (*txauthor.AuthoredTx, error) {
+	var (
+		totalInput  ltcutil.Amount
+		inputs      = make([]*wire.TxIn, 0, len(unspents))
+		inputValues = make([]ltcutil.Amount, 0, len(unspents))
+		pkScripts   = make([][]byte, 0, len(unspents))
+	)
+	for outPoint, txOut := range unspents {
+		totalInput += ltcutil.Amount(txOut.Value)
+		inputs = append(inputs, wire.NewTxIn(&outPoint, nil, nil))
+		inputValues = append(inputValues, ltcutil.Amount(txOut.Value))
+		pkScripts = append(pkScripts, txOut.PkScript)
+	}
+	inputSource := func(ltcutil.Amount) (ltcutil.Amount, []*wire.TxIn, []ltcutil.Amount, [][]byte, error) {
+		return totalInput, inputs, inputValues, pkScripts, nil
+	}
+	changeSource, err := txhelper.MakeLTCTxChangeSource(address, chainParams)
+	if err != nil {
+		return nil, err
+	}
+
+	unsignedTx, err := txauthor.NewUnsignedTransaction(nil, feeRate, inputSource, changeSource)
+	var inputSourceErr txauthor.InputSourceError
+	if errors.As(err, &inputSourceErr) {
+		// The funds of the key don't cover the fee of the sweep.
+		return nil, errors.E(utils.ErrNoFundsToSweep)
+	} else if err != nil {
+		return nil, err
+	}
+	if unsignedTx.ChangeIndex < 0 {
+		// The funds left after the fee would be dust.
+		return nil, errors.E(utils.ErrNoFundsToSweep)
+	}
+
+	secrets := &keySecrets{key: key, chainParams: chainParams}
+	err = txauthor.AddAllInputScripts(unsignedTx.Tx, unsignedTx.PrevScripts, unsignedTx.PrevInputValues, secrets)
+	if err != nil {
+		log.Errorf("signing the sweep of the key failed: %v", err)
+		return nil, err
+	}
+	return unsignedTx, nil
+}
+
+// decodeWIF decodes a WIF encoded private key of the wallet's network.
+func (asset *Asset) decodeWIF(wif string) (*ltcutil.WIF, error) {
+	key, err := ltcutil.DecodeWIF(strings.TrimSpace(wif))
+	if err != nil || !key.IsForNet(asset.chainParams) {
+		return nil, errors.E(utils.ErrInvalidPrivateKey)
+	}
+	return key, nil
+}
+
+// keyAddresses returns the addresses the key could have received funds on:
+// its legacy address and, if the key is compressed, its native and nested
+// segwit addresses.
+func keyAddresses(key *ltcutil.WIF, chainParams *chaincfg.Params) ([]ltcutil.Address, error) {
+	pubKeyHash := ltcutil.Hash160(key.SerializePubKey())
+	p2pkh, err := ltcutil.NewAddressPubKeyHash(pubKeyHash, chainParams)
+	if err != nil {
+		return nil, err
+	}
+	if !key.CompressPubKey {
+		return []ltcutil.Address{p2pkh}, nil
+	}
+
+	p2wpkh, err := ltcutil.NewAddressWitnessPubKeyHash(pubKeyHash, chainParams)
+	if err != nil {
+		return nil, err
+	}
+	witnessProgram, err := txscript.PayToAddrScript(p2wpkh)
+	if err != nil {
+		return nil, err
+	}
+	np2wpkh, err := ltcutil.NewAddressScriptHash(witnessProgram, chainParams)
+	if err != nil {
+		return nil, err
+	}
+	return []ltcutil.Address{p2pkh, p2wpkh, np2wpkh}, nil
+}
+
+// scanKeyOutputs scans the blocks from startHeight for the outputs paying to
+// addrs that are still unspent. The blocks are filtered by the chain client
+// the wallet syncs with, which only fetches the blocks that match.
+func (asset *Asset) scanKeyOutputs(addrs []ltcutil.Address, startHeight int32) (map[wire.OutPoint]*wire.TxOut, error) {
+	chainClient := asset.chainSource()
+	_, bestHeight, err := chainClient.GetBestBlock()
+	if err != nil {
+		return nil, err
+	}
+
+	req := &chain.FilterBlocksRequest{
+		ExternalAddrs:    make(map[waddrmgr.ScopedIndex]ltcutil.Address, len(addrs)),
+		WatchedOutPoints: make(map[wire.OutPoint]ltcutil.Address),
+	}
+	scripts := make(map[string]ltcutil.Address, len(addrs))
+	for i, addr := range addrs {
+		script, err := txscript.PayToAddrScript(addr)
+		if err != nil {
+			return nil, err
+		}
+		scripts[string(script)] = addr
+		req.ExternalAddrs[waddrmgr.ScopedIndex{Scope: GetScope(), Index: uint32(i)}] = addr
+	}
+
+	ctx, _ := asset.ShutdownContextWithCancel()
+	unspents := make(map[wire.OutPoint]*wire.TxOut)
+	for height := max(startHeight, 0); height <= bestHeight; height += sweepBatchSize {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+
+		end := min(height+sweepBatchSize-1, bestHeight)
+		blocks := make([]wtxmgr.BlockMeta, 0, end-height+1)
+		for h := height; h <= end; h++ {
+			hash, err := chainClient.GetBlockHash(int64(h))
+			if err != nil {
+				return nil, err
+			}
+			blocks = append(blocks, wtxmgr.BlockMeta{Block: wtxmgr.Block{Hash: *hash, Height: h}})
+		}
+
+		// FilterBlocks returns the transactions of the first matching
+		// block, the rest of the batch is filtered again after it.
+		for len(blocks) > 0 {
+			req.Blocks = blocks
+			resp, err := chainClient.FilterBlocks(req)
+			if err != nil {
+				return nil, err
+			}
+			if resp == nil {
+				break
+			}
+
+			for _, tx := range resp.RelevantTxns {
+				for _, txIn := range tx.TxIn {
+					delete(unspents, txIn.PreviousOutPoint)
+					delete(req.WatchedOutPoints, txIn.PreviousOutPoint)
+				}
+				txHash := tx.TxHash()
+				for i, txOut := range tx.TxOut {
+					if addr, ok := scripts[string(txOut.PkScript)]; ok {
+						outPoint := wire.OutPoint{Hash: txHash, Index: uint32(i)}
+						unspents[outPoint] = txOut
+						req.WatchedOutPoints[outPoint] = addr
+					}
+				}
+			}
+			blocks = blocks[resp.BatchIndex+1:]
+		}
+	}
+	return unspents, nil
+}
+
+// keySecrets signs the inputs spending the outputs of a swept key.
+type keySecrets struct {
+	key         *ltcutil.WIF
+	chainParams *chaincfg.Params
+}
+
+// GetKey implements txscript.KeyDB.
+func (s *keySecrets) GetKey(ltcutil.Address) (*btcec.PrivateKey, bool, error) {
+	return s.key.PrivKey, s.key.CompressPubKey, nil
+}
+
+// GetScript implements txscript.ScriptDB. Swept keys have no scripts.
+func (s *keySecrets) GetScript(ltcutil.Address) ([]byte, error) {
+	return nil, errors.E(errors.NotExist, "no script for a swept key")
+}
+
+// ChainParams implements txauthor.SecretsSource.
+func (s *keySecrets) ChainParams() *chaincfg.Params {
+	return s.chainParams
+}
@@ -0,0 +1,144 @@
+package ltc
+
+import (
+	"testing"
+
+	"github.com/crypto-power/cryptopower/libwallet/utils"
+	"github.com/ltcsuite/ltcd/btcec/v2"
+	"github.com/ltcsuite/ltcd/chaincfg"
+	"github.com/ltcsuite/ltcd/chaincfg/chainhash"
+	"github.com/ltcsuite/ltcd/ltcutil"
+	"github.com/ltcsuite/ltcd/txscript"
+	"github.com/ltcsuite/ltcd/wire"
+)
+
+func newTestWIF(t *testing.T, compressed bool) *ltcutil.WIF {
+	privKey, err := btcec.NewPrivateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	wif, err := ltcutil.NewWIF(privKey, &chaincfg.MainNetParams, compressed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return wif
+}
+
+func TestDecodeWIF(t *testing.T) {
+	asset := &Asset{chainParams: &chaincfg.MainNetParams}
+	key := newTestWIF(t, true)
+
+	decoded, err := asset.decodeWIF(" " + key.String() + "\n")
+	if err != nil {
+		t.Fatalf("decoding a mainnet key: %v", err)
+	}
+	if !decoded.PrivKey.Key.Equals(&key.PrivKey.Key) || !decoded.CompressPubKey {
+		t.Fatal("decoded key differs")
+	}
+
+	testnetKey, err := ltcutil.NewWIF(key.PrivKey, &chaincfg.TestNet4Params, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, wif := range []string{testnetKey.String(), "not a key", ""} {
+		if _, err := asset.decodeWIF(wif); err == nil || err.Error() != utils.ErrInvalidPrivateKey {
+			t.Errorf("decoding %q: got error %v, want %s", wif, err, utils.ErrInvalidPrivateKey)
+		}
+	}
+}
+
+func TestKeyAddresses(t *testing.T) {
+	addrs, err := keyAddresses(newTestWIF(t, true), &chaincfg.MainNetParams)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(addrs) != 3 {
+		t.Fatalf("got %d addresses for a compressed key, want 3", len(addrs))
+	}
+	if _, ok := addrs[0].(*ltcutil.AddressPubKeyHash); !ok {
+		t.Errorf("got %T, want a legacy address", addrs[0])
+	}
+	if _, ok := addrs[1].(*ltcutil.AddressWitnessPubKeyHash); !ok {
+		t.Errorf("got %T, want a native segwit address", addrs[1])
+	}
+	if _, ok := addrs[2].(*ltcutil.AddressScriptHash); !ok {
+		t.Errorf("got %T, want a nested segwit address", addrs[2])
+	}
+
+	addrs, err = keyAddresses(newTestWIF(t, false), &chaincfg.MainNetParams)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(addrs) != 1 {
+		t.Fatalf("got %d addresses for an uncompressed key, want 1", len(addrs))
+	}
+}
+
+func TestBuildSweepTx(t *testing.T) {
+	params := &chaincfg.MainNetParams
+	key := newTestWIF(t, true)
+	keyAddrs, err := keyAddresses(key, params)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// An output of the key on each of its addresses.
+	unspents := make(map[wire.OutPoint]*wire.TxOut)
+	prevOuts := txscript.NewMultiPrevOutFetcher(nil)
+	var totalInput int64
+	for i, addr := range keyAddrs {
+		pkScript, err := txscript.PayToAddrScript(addr)
+		if err != nil {
+			t.Fatal(err)
+		}
+		outPoint := wire.OutPoint{Hash: chainhash.Hash{byte(i + 1)}, Index: uint32(i)}
+		txOut := wire.NewTxOut(int64(i+1)*100000, pkScript)
+		unspents[outPoint] = txOut
+		prevOuts.AddPrevOut(outPoint, txOut)
+		totalInput += txOut.Value
+	}
+
+	walletAddrs, err := keyAddresses(newTestWIF(t, true), params)
+	if err != nil {
+		t.Fatal(err)
+	}
+	address := walletAddrs[1].String()
+	sweepTx, err := buildSweepTx(key, unspents, address, 1000, params)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tx := sweepTx.Tx
+	if len(tx.TxIn) != len(unspents) || len(tx.TxOut) != 1 || sweepTx.ChangeIndex != 0 {
+		t.Fatalf("got %d inputs and %d outputs, want %d inputs and 1 output", len(tx.TxIn), len(tx.TxOut), len(unspents))
+	}
+	fee := totalInput - tx.TxOut[0].Value
+	if fee <= 0 || int64(sweepTx.TotalInput) != totalInput {
+		t.Fatalf("got fee %d of total input %d", fee, sweepTx.TotalInput)
+	}
+	_, outAddrs, _, err := txscript.ExtractPkScriptAddrs(tx.TxOut[0].PkScript, params)
+	if err != nil || len(outAddrs) != 1 || outAddrs[0].String() != address {
+		t.Fatalf("the sweep doesn't pay %s", address)
+	}
+
+	// Every input spends its output of the key.
+	sigHashes := txscript.NewTxSigHashes(tx, prevOuts)
+	for i, txIn := range tx.TxIn {
+		prevOut := prevOuts.FetchPrevOutput(txIn.PreviousOutPoint)
+		vm, err := txscript.NewEngine(prevOut.PkScript, tx, i, txscript.StandardVerifyFlags,
+			nil, sigHashes, prevOut.Value, prevOuts)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := vm.Execute(); err != nil {
+			t.Fatalf("input %d: %v", i, err)
+		}
+	}
+
+	// Funds that don't cover the fee can't be swept.
+	pkScript, _ := txscript.PayToAddrScript(keyAddrs[0])
+	dust := map[wire.OutPoint]*wire.TxOut{{Index: 0}: wire.NewTxOut(100, pkScript)}
+	if _, err := buildSweepTx(key, dust, address, 1000, params); err == nil || err.Error() != utils.ErrNoFundsToSweep {
+		t.Fatalf("got error %v, want %s", err, utils.ErrNoFundsToSweep)
+	}
+}
@@ -0,0 +1,342 @@
+package ltc
+
+import (
+	"fmt"
+	"net"
+	"sync/atomic"
+	"time"
+
+	"decred.org/dcrwallet/v4/errors"
+	sharedW "github.com/crypto-power/cryptopower/libwallet/assets/wallet"
+	"github.com/crypto-power/cryptopower/libwallet/utils"
+	"github.com/dcrlabs/ltcwallet/chain"
+	"github.com/ltcsuite/ltcd/btcjson"
+	"github.com/ltcsuite/ltcd/chaincfg/chainhash"
+	"github.com/ltcsuite/ltcd/ltcutil"
+	"github.com/ltcsuite/ltcd/rpcclient"
+)
+
+const (
+	// rpcPollingInterval is how often litecoind is polled for new blocks and
+	// transactions.
+	rpcPollingInterval = 10 * time.Second
+	// rpcReconnectAttempts is the number of times the connection to ltcd is
+	// retried before the sync fails.
+	rpcReconnectAttempts = 5
+)
+
+// rpcFeeTargets are the confirmation targets the node is asked fee estimates
+// for.
+var rpcFeeTargets = []int64{1, 2, 3, 6, 12}
+
+// litecoindClient is the chain client of a wallet syncing from litecoind. It
+// stops the connection to litecoind along with the client.
+type litecoindClient struct {
+	*chain.BitcoindClient
+	conn *chain.BitcoindConn
+	// tunnel is the TLS tunnel to the proxy in front of litecoind, if any.
+	tunnel *sharedW.TLSTunnel
+}
+
+// Stop stops the client and disconnects from litecoind.
+func (c *litecoindClient) Stop() {
+	c.BitcoindClient.Stop()
+	c.conn.Stop()
+	if c.tunnel != nil {
+		c.tunnel.Close()
+	}
+}
+
+// rpcHost returns the host:port of the RPC server of the node in cfg, adding
+// the default RPC port of the node on the wallet network if it is missing.
+func (asset *Asset) rpcHost(cfg *sharedW.RPCConfig) string {
+	if _, _, err := net.SplitHostPort(cfg.Host); err == nil {
+		return cfg.Host
+	}
+
+	port := "9334"
+	mainnet := asset.NetType() == utils.Mainnet
+	switch {
+	case cfg.NodeType == sharedW.RPCNodeLitecoind && mainnet:
+		port = "9332"
+	case cfg.NodeType == sharedW.RPCNodeLitecoind:
+		port = "19332"
+	case !mainnet:
+		port = "19334"
+	}
+	return net.JoinHostPort(cfg.Host, port)
+}
+
+// dialRPC returns a client of the RPC server of the node in cfg that sends
+// each request in its own HTTP POST.
+func (asset *Asset) dialRPC(cfg *sharedW.RPCConfig) (*rpcclient.Client, error) {
+	return rpcclient.New(&rpcclient.ConnConfig{
+		Host:         asset.rpcHost(cfg),
+		User:         cfg.User,
+		Pass:         cfg.Password,
+		HTTPPostMode: true,
+		DisableTLS:   !cfg.UsesTLS(),
+		Certificates: []byte(cfg.Certificate),
+	}, nil)
+}
+
+// TestRPCConnection checks that the node in cfg accepts the RPC credentials
+// and runs on the wallet network.
+func (asset *Asset) TestRPCConnection(cfg *sharedW.RPCConfig) error {
+	if err := cfg.Validate(asset.GetAssetType()); err != nil {
+		return err
+	}
+
+	client, err := asset.dialRPC(cfg)
+	if err != nil {
+		return err
+	}
+	defer client.Shutdown()
+
+	genesisHash, err := client.GetBlockHash(0)
+	if err != nil {
+		return err
+	}
+	if !genesisHash.IsEqual(asset.chainParams.GenesisHash) {
+		return errors.New(utils.ErrRPCWrongNetwork)
+	}
+	return nil
+}
+
+// SetRPCConfig sets the full node the wallet syncs from, or switches the
+// wallet back to SPV if cfg is nil. The sync is restarted if the wallet is
+// connected.
+func (asset *Asset) SetRPCConfig(cfg *sharedW.RPCConfig) error {
+	if err := asset.Wallet.SetRPCConfig(cfg); err != nil {
+		return err
+	}
+	asset.syncBackendChanged()
+	return nil
+}
+
+// usesRemoteBackend returns true if the wallet syncs from a full node or from
+// Electrum servers instead of SPV.
+func (asset *Asset) usesRemoteBackend() bool {
+	return asset.RPCConfig() != nil || asset.ElectrumConfig() != nil
+}
+
+// syncBackendChanged restarts the sync of a connected wallet after the source
+// it syncs from changed.
+func (asset *Asset) syncBackendChanged() {
+	// The cached fee estimates may come from the other source.
+	asset.fees.mu.Lock()
+	asset.fees.APIFeeRates = nil
+	asset.fees.LastBestblock = 0
+	asset.fees.mu.Unlock()
+
+	go func() {
+		isPrevConnected := asset.IsConnectedToNetwork()
+		if isPrevConnected {
+			asset.CancelSync()
+		}
+
+		asset.syncData.mu.Lock()
+		asset.syncData.rpcMode = asset.usesRemoteBackend()
+		asset.syncData.mu.Unlock()
+
+		if isPrevConnected {
+			if err := asset.SpvSync(); err != nil {
+				log.Error(err)
+			}
+		}
+	}()
+}
+
+// newRPCChainClient connects to the node in cfg and returns the chain client
+// the wallet syncs with.
+func (asset *Asset) newRPCChainClient(cfg *sharedW.RPCConfig) (chain.Interface, error) {
+	if cfg.NodeType != sharedW.RPCNodeLitecoind {
+		return chain.NewRPCClient(asset.chainParams, asset.rpcHost(cfg), cfg.User, cfg.Password,
+			[]byte(cfg.Certificate), false, rpcReconnectAttempts)
+	}
+
+	// The litecoind chain client only speaks plain HTTP, so a node behind a TLS
+	// proxy is reached through a local tunnel.
+	host := asset.rpcHost(cfg)
+	var tunnel *sharedW.TLSTunnel
+	if cfg.UsesTLS() {
+		var err error
+		tunnel, err = sharedW.NewTLSTunnel(host, []byte(cfg.Certificate))
+		if err != nil {
+			return nil, err
+		}
+		host = tunnel.Addr()
+	}
+
+	// litecoind is polled as ZMQ is rarely exposed to remote wallets.
+	conn, err := chain.NewBitcoindConn(&chain.BitcoindConfig{
+		ChainParams: asset.chainParams,
+		Host:        host,
+		User:        cfg.User,
+		Pass:        cfg.Password,
+		PollingConfig: &chain.PollingConfig{
+			BlockPollingInterval: rpcPollingInterval,
+			TxPollingInterval:    rpcPollingInterval,
+		},
+	})
+	if err == nil {
+		err = conn.Start()
+	}
+	if err != nil {
+		if tunnel != nil {
+			tunnel.Close()
+		}
+		return nil, err
+	}
+	return &litecoindClient{BitcoindClient: conn.NewBitcoindClient(), conn: conn, tunnel: tunnel}, nil
+}
+
+// startRPCSync syncs the wallet from the node in cfg.
+func (asset *Asset) startRPCSync(cfg *sharedW.RPCConfig) error {
+	rpcClient, err := asset.newRPCChainClient(cfg)
+	if err != nil {
+		asset.CancelSync()
+		log.Errorf("couldn't connect to the %s node: %v", cfg.NodeType, err)
+		return err
+	}
+	return asset.startRemoteSync(rpcClient, fmt.Sprintf("%s at %s", cfg.NodeType, cfg.Host))
+}
+
+// startRemoteSync starts rpcClient and syncs the wallet from source, the full
+// node or Electrum servers it connects to.
+func (asset *Asset) startRemoteSync(rpcClient chain.Interface, source string) error {
+	if err := rpcClient.Start(); err != nil {
+		asset.CancelSync()
+		log.Errorf("couldn't connect to the %s: %v", source, err)
+		return err
+	}
+
+	asset.syncData.mu.Lock()
+	asset.syncData.rpcClient = rpcClient
+	asset.syncData.mu.Unlock()
+
+	if err := rpcClient.NotifyBlocks(); err != nil {
+		log.Errorf("subscribing to notifications failed: %v", err)
+		return err
+	}
+
+	if atomic.CompareAndSwapUint32(&asset.syncData.syncstarted, stop, start) {
+		go asset.handleNotifications()
+	}
+
+	log.Infof("Synchronizing wallet (%s) with %s...", asset.GetWalletName(), source)
+	asset.Internal().LTC.SynchronizeRPC(rpcClient)
+
+	return nil
+}
+
+// rpcChainClient returns the chain client connected to the node or Electrum
+// servers the wallet syncs from, or nil if the wallet syncs with SPV.
+func (asset *Asset) rpcChainClient() chain.Interface {
+	asset.syncData.mu.RLock()
+	defer asset.syncData.mu.RUnlock()
+	return asset.syncData.rpcClient
+}
+
+// isRPCMode returns true if the wallet syncs from a full node or Electrum
+// servers.
+func (asset *Asset) isRPCMode() bool {
+	asset.syncData.mu.RLock()
+	defer asset.syncData.mu.RUnlock()
+	return asset.syncData.rpcMode
+}
+
+// chainSource returns the chain client block data is read from.
+func (asset *Asset) chainSource() chain.Interface {
+	if rpcClient := asset.rpcChainClient(); rpcClient != nil {
+		return rpcClient
+	}
+	return asset.chainClient
+}
+
+// rpcSyncHeight returns the height the wallet is synced to and whether it is
+// synced with the node it syncs from. The best block of the node is the
+// target of the sync.
+func (asset *Asset) rpcSyncHeight(rpcClient chain.Interface) (int32, bool, error) {
+	_, nodeHeight, err := rpcClient.GetBestBlock()
+	if err != nil {
+		return 0, false, err
+	}
+
+	asset.syncData.mu.Lock()
+	asset.syncData.bestBlockHeight = nodeHeight
+	asset.syncData.mu.Unlock()
+
+	syncedTo := asset.Internal().LTC.Manager.SyncedTo()
+	isCurrent := rpcClient.IsCurrent() && asset.Internal().LTC.ChainSynced() && syncedTo.Height >= nodeHeight
+	return syncedTo.Height, isCurrent, nil
+}
+
+// rpcBestBlock returns the block the wallet is synced to. When syncing from a
+// full node, the wallet follows the node tip as blocks are connected, which
+// spares a round trip to the node on every call.
+func (asset *Asset) rpcBestBlock() *sharedW.BlockInfo {
+	if !asset.WalletOpened() {
+		return sharedW.InvalidBlock
+	}
+	syncedTo := asset.Internal().LTC.Manager.SyncedTo()
+	return &sharedW.BlockInfo{Height: syncedTo.Height, Timestamp: syncedTo.Timestamp.Unix()}
+}
+
+// rpcBlockHeight returns the height of the block with hash on the node the
+// wallet syncs from.
+func rpcBlockHeight(rpcClient chain.Interface, hash *chainhash.Hash) (int32, error) {
+	switch c := rpcClient.(type) {
+	case *litecoindClient:
+		return c.GetBlockHeight(hash)
+	case *chain.RPCClient:
+		header, err := c.GetBlockHeaderVerbose(hash)
+		if err != nil {
+			return 0, err
+		}
+		return header.Height, nil
+	case *electrumClient:
+		return c.blockHeight(hash)
+	default:
+		return 0, errors.Errorf("unsupported chain client %T", rpcClient)
+	}
+}
+
+// fetchNodeFeeRate queries the fee estimates of the node in cfg.
+func (asset *Asset) fetchNodeFeeRate(cfg *sharedW.RPCConfig) ([]sharedW.FeeEstimate, error) {
+	client, err := asset.dialRPC(cfg)
+	if err != nil {
+		return nil, err
+	}
+	defer client.Shutdown()
+
+	results := make([]sharedW.FeeEstimate, 0, len(rpcFeeTargets))
+	for _, target := range rpcFeeTargets {
+		// litecoind only implements estimatesmartfee and ltcd only
+		// estimatefee.
+		var feerate float64
+		res, err := client.EstimateSmartFee(target, &btcjson.EstimateModeConservative)
+		if err == nil && res.FeeRate != nil {
+			feerate = *res.FeeRate
+		} else if feerate, err = client.EstimateFee(target); err != nil {
+			return nil, fmt.Errorf("fetching node fee estimates failed: %v", err)
+		}
+
+		// The node has no estimate for the target until it saw enough
+		// blocks.
+		if feerate <= 0 {
+			continue
+		}
+
+		// Fee rate returned is in LTC/kvB units.
+		amount, err := ltcutil.NewAmount(feerate)
+		if err != nil {
+			continue
+		}
+		results = append(results, sharedW.FeeEstimate{
+			ConfirmedBlocks: int32(target),
+			Feerate:         Amount(amount),
+		})
+	}
+	return results, nil
+}
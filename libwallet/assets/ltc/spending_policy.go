@@ -0,0 +1,143 @@
+package ltc
+
+import (
+	"bytes"
+	"encoding/hex"
+	"time"
+
+	sharedW "github.com/crypto-power/cryptopower/libwallet/assets/wallet"
+	"github.com/crypto-power/cryptopower/libwallet/utils"
+	"github.com/ltcsuite/ltcd/txscript"
+	"github.com/ltcsuite/ltcd/wire"
+)
+
+// spendOutputs returns the outputs of tx paying the send destinations.
+func (asset *Asset) spendOutputs(tx *wire.MsgTx) []*sharedW.SpendOutput {
+	destinations := make(map[string]bool, len(asset.TxAuthoredInfo.destinations))
+	for _, destination := range asset.TxAuthoredInfo.destinations {
+		destinations[destination.Address] = true
+	}
+
+	outputs := make([]*sharedW.SpendOutput, 0, len(tx.TxOut))
+	for _, txOut := range tx.TxOut {
+		_, addrs, _, err := txscript.ExtractPkScriptAddrs(txOut.PkScript, asset.chainParams)
+		if err == nil && len(addrs) > 0 && destinations[addrs[0].String()] {
+			outputs = append(outputs, &sharedW.SpendOutput{Address: addrs[0].String(), Amount: txOut.Value})
+		}
+	}
+	return outputs
+}
+
+// externalOutputs returns the outputs of tx paying addresses that don't
+// belong to the wallet, the sends of a tx that has no send destinations.
+func (asset *Asset) externalOutputs(tx *wire.MsgTx) []*sharedW.SpendOutput {
+	outputs := make([]*sharedW.SpendOutput, 0, len(tx.TxOut))
+	for _, txOut := range tx.TxOut {
+		_, addrs, _, err := txscript.ExtractPkScriptAddrs(txOut.PkScript, asset.chainParams)
+		if err == nil && len(addrs) > 0 && !asset.HaveAddress(addrs[0].String()) {
+			outputs = append(outputs, &sharedW.SpendOutput{Address: addrs[0].String(), Amount: txOut.Value})
+		}
+	}
+	return outputs
+}
+
+// publishWithPolicy broadcasts the signed msgTx, sending outputs from
+// account, if the spending policy allows it. If canDelay is set, a send the
+// policy holds back is stored with its inputs locked, so that other sends
+// don't spend them in the meantime, and ErrSendDelayed is returned.
+func (asset *Asset) publishWithPolicy(msgTx *wire.MsgTx, account int32, outputs []*sharedW.SpendOutput, label string, canDelay bool) error {
+	var hold *sharedW.DelayedSend
+	if canDelay {
+		var buf bytes.Buffer
+		buf.Grow(msgTx.SerializeSize())
+		if err := msgTx.Serialize(&buf); err != nil {
+			return err
+		}
+		hold = &sharedW.DelayedSend{
+			Hash:  msgTx.TxHash().String(),
+			Label: label,
+			RawTx: hex.EncodeToString(buf.Bytes()),
+		}
+	}
+
+	err := asset.SendWithPolicy(account, outputs, hold, func() error {
+		return asset.Internal().LTC.PublishTransaction(msgTx, label)
+	})
+	if err != nil && err.Error() == utils.ErrSendDelayed {
+		asset.lockInputs(msgTx, true)
+	}
+	return err
+}
+
+// PublishWithPolicy broadcasts the signed msgTx, sent from account by a user
+// of the wallet such as the DEX, if the spending policy allows its outputs
+// paying other wallets. Sends the policy would hold back are refused.
+func (asset *Asset) PublishWithPolicy(msgTx *wire.MsgTx, account int32) error {
+	return asset.publishWithPolicy(msgTx, account, asset.externalOutputs(msgTx), "", false)
+}
+
+func (asset *Asset) lockInputs(msgTx *wire.MsgTx, lock bool) {
+	for _, txIn := range msgTx.TxIn {
+		if lock {
+			asset.Internal().LTC.LockOutpoint(txIn.PreviousOutPoint)
+		} else {
+			asset.Internal().LTC.UnlockOutpoint(txIn.PreviousOutPoint)
+		}
+	}
+}
+
+func decodeDelayedSend(send *sharedW.DelayedSend) (*wire.MsgTx, error) {
+	rawTx, err := hex.DecodeString(send.RawTx)
+	if err != nil {
+		return nil, err
+	}
+	msgTx := new(wire.MsgTx)
+	if err := msgTx.Deserialize(bytes.NewReader(rawTx)); err != nil {
+		return nil, err
+	}
+	return msgTx, nil
+}
+
+// CancelDelayedSend drops the delayed send with the tx hash before it is
+// broadcast.
+func (asset *Asset) CancelDelayedSend(hash string) error {
+	send, err := asset.RemoveDelayedSend(hash)
+	if err != nil {
+		return err
+	}
+	if msgTx, err := decodeDelayedSend(send); err == nil {
+		asset.lockInputs(msgTx, false)
+	}
+	return nil
+}
+
+// PublishDueDelayedSends broadcasts the delayed sends whose cancel window is
+// over and keeps the inputs of the others locked.
+func (asset *Asset) PublishDueDelayedSends() {
+	if !asset.WalletOpened() {
+		return
+	}
+
+	now := time.Now()
+	for _, send := range asset.DelayedSends() {
+		msgTx, err := decodeDelayedSend(send)
+		if err != nil {
+			log.Errorf("Error decoding delayed send %s: %v", send.Hash, err)
+			continue
+		}
+		if !send.Due(now) {
+			// Locked outpoints are forgotten when the wallet is closed.
+			asset.lockInputs(msgTx, true)
+			continue
+		}
+
+		// A send cancelled meanwhile is no longer found and not broadcast.
+		err = asset.PublishDelayedSend(send.Hash, func(send *sharedW.DelayedSend) error {
+			asset.lockInputs(msgTx, false)
+			return asset.Internal().LTC.PublishTransaction(msgTx, send.Label)
+		})
+		if err != nil && err.Error() != utils.ErrNotExist {
+			log.Errorf("Error broadcasting delayed send %s: %v", send.Hash, err)
+		}
+	}
+}
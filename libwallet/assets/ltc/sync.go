@@ -35,6 +35,15 @@ type SyncData struct {
 	bestBlockHeight     int32 // Synced peers best block height.
 	syncstarted         uint32
 	chainServiceStopped bool
+	// sharedChainService is set if the chain service is shared with the
+	// other wallets.
+	sharedChainService *sharedChainService
+
+	// rpcMode is true if the wallet syncs from a full node or Electrum
+	// servers instead of SPV, and rpcClient is connected to them while the
+	// wallet syncs.
+	rpcMode   bool
+	rpcClient chain.Interface
 
 	syncing  bool
 	synced   bool
@@ -117,6 +126,12 @@ func (asset *Asset) RemoveSyncProgressListener(uniqueIdentifier string) {
 // bestServerPeerBlockHeight accesses the connected peers and requests for the
 // last synced block height.
 func (asset *Asset) bestServerPeerBlockHeight() {
+	// The best block of the node the wallet syncs from is set when the sync
+	// progress is polled.
+	if asset.syncData.rpcClient != nil {
+		return
+	}
+
 	serverPeers := asset.cl.Peers()
 	for _, p := range serverPeers {
 		if p.LastBlock() > asset.syncData.bestBlockHeight {
@@ -177,6 +192,7 @@ func (asset *Asset) updateSyncProgress(rawBlockHeight int32) {
 		GeneralSyncProgress: &sharedW.GeneralSyncProgress{
 			TotalSyncProgress:  syncProgess,
 			TotalTimeRemaining: timeRemaining,
+			SyncDataUsage:      asset.SyncDataUsage(),
 		},
 	}
 	headersFetchProgress.TotalHeadersToFetch = asset.syncData.bestBlockHeight
@@ -308,6 +324,10 @@ func (asset *Asset) prepareChain() error {
 
 	asset.chainClient = chain.NewNeutrinoClient(asset.chainParams, asset.cl)
 
+	asset.syncData.mu.Lock()
+	asset.syncData.rpcMode = asset.usesRemoteBackend()
+	asset.syncData.mu.Unlock()
+
 	return nil
 }
 
@@ -329,6 +349,11 @@ func (asset *Asset) loadChainService() (chainService *neutrino.ChainService, err
 		asset.chainParams.DNSSeeds = append(asset.chainParams.DNSSeeds, chaincfg.DNSSeed{Host: "testnet-seed.ltc.xurious.com", HasFiltering: true})
 	}
 
+	// Wallets that don't connect to specific peers share a chain service.
+	if len(validPeerAddresses) == 0 && asset.ChainBackends() != nil {
+		return asset.acquireSharedChainService()
+	}
+
 	asset.dailerCtx, asset.dailerCancel = asset.ShutdownContextWithCancel()
 	chainService, err = neutrino.NewChainService(neutrino.Config{
 		DataDir:       asset.DataDir(),
@@ -338,7 +363,7 @@ func (asset *Asset) loadChainService() (chainService *neutrino.ChainService, err
 		ConnectPeers:  validPeerAddresses,
 		AddPeers:      asset.setSeedPeers(),
 		// Dailer function helps to better control the dailer functionality.
-		Dialer: utils.DialerFunc(asset.dailerCtx),
+		Dialer: peerDialer(asset.dailerCtx, asset.IsPeerBanned, asset.AddSyncData),
 		// WARNING: PublishTransaction currently uses the entire duration
 		// because if an external bug, but even if the resolved, a typical
 		// inv/getdata round trip is ~4 seconds, so we set this so neutrino does
@@ -399,25 +424,37 @@ func (asset *Asset) stopSync() {
 
 	// 2. shutdown the chain client.
 	asset.chainClient.Stop() // If active, attempt to shut it down.
+	rpcMode, rpcClient := asset.isRPCMode(), asset.rpcChainClient()
+	if rpcClient != nil {
+		rpcClient.Stop()
+	}
 
 	if asset.WalletOpened() {
 		// Neutrino performs explicit chain service start but never explicit
 		// chain service stop thus the need to have it done here when stopping
 		// a wallet sync.
 		// 3. Disabling the peers connectivity allows the upstream handleChainNotification
-		// goroutine to return.
-		if err := asset.chainClient.CS.Stop(); err != nil {
-			// ignore the error and proceed with shutdown.
-			log.Errorf("Stopping chain client failed: %v", err)
+		// goroutine to return. A shared chain service is only stopped once
+		// the last wallet using it releases it. The chain service is not
+		// started when syncing from a full node.
+		if !rpcMode {
+			if err := asset.stopChainService(); err != nil {
+				// ignore the error and proceed with shutdown.
+				log.Errorf("Stopping chain client failed: %v", err)
+			}
 		}
-
-		asset.syncData.chainServiceStopped = true
 		// 4. Wait for the upstream wallet to shutdown completely.
 		loadedAsset.WaitForShutdown()
 	}
 
 	// 5. Wait for the chain client to shutdown
 	asset.chainClient.WaitForShutdown()
+	if rpcClient != nil {
+		rpcClient.WaitForShutdown()
+		asset.syncData.mu.Lock()
+		asset.syncData.rpcClient = nil
+		asset.syncData.mu.Unlock()
+	}
 
 	// Declares that the sync context is done and goroutines listening to it
 	// should exit. The shutdown protocol will eventually attempt to end this
@@ -443,6 +480,13 @@ func (asset *Asset) stopSync() {
 // startSync initiates the full chain sync starting protocols. It attempts to
 // restart the chain service if it hasn't been initialized.
 func (asset *Asset) startSync() error {
+	if cfg := asset.RPCConfig(); cfg != nil {
+		return asset.startRPCSync(cfg)
+	}
+	if cfg := asset.ElectrumConfig(); cfg != nil {
+		return asset.startElectrumSync(cfg)
+	}
+
 	g, _ := errgroup.WithContext(asset.syncCtx)
 
 	if asset.syncData.chainServiceStopped {
@@ -450,9 +494,21 @@ func (asset *Asset) startSync() error {
 		if err != nil {
 			return err
 		}
+		asset.cl = chainService
 		asset.chainClient.CS = chainService
 	}
 
+	asset.syncData.mu.RLock()
+	shared := asset.syncData.sharedChainService
+	asset.syncData.mu.RUnlock()
+	if shared != nil {
+		// Start the shared chain service through its wrapper, which tracks
+		// that it has to be stopped.
+		if err := shared.start(); err != nil {
+			return err
+		}
+	}
+
 	// Chain client performs explicit chain service start up thus no need
 	// to re-initialize it.
 	g.Go(asset.chainClient.Start)
@@ -463,6 +519,8 @@ func (asset *Asset) startSync() error {
 		return err
 	}
 
+	go asset.connectPreferredPeers()
+
 	// Subscribe to chainclient notifications.
 	if err := asset.chainClient.NotifyBlocks(); err != nil {
 		log.Errorf("subscribing to notifications failed: %v", err)
@@ -514,15 +572,20 @@ func (asset *Asset) waitForSyncCompletion() {
 	for {
 		select {
 		case <-t.C:
-			block, err := asset.chainClient.CS.BestBlock()
+			height, isCurrent, err := asset.syncHeight()
 			if err != nil {
 				log.Error("GetBestBlock hash for LTC failed, Err: ", err)
+				continue
+			}
+			if height < 0 {
+				// Still connecting to the node the wallet syncs from.
+				continue
 			}
-			asset.updateSyncProgress(block.Height)
-			asset.updateRescanProgress(block.Height)
+			asset.updateSyncProgress(height)
+			asset.updateRescanProgress(height)
 
-			if asset.chainClient.IsCurrent() {
-				asset.rescanFinished(block.Height)
+			if isCurrent {
+				asset.rescanFinished(height)
 
 				asset.syncData.mu.Lock()
 				asset.syncData.synced = true
@@ -539,6 +602,25 @@ func (asset *Asset) waitForSyncCompletion() {
 	}
 }
 
+// syncHeight returns the height the wallet is synced to and whether the chain
+// client considers itself synced with the network. The height is -1 until
+// the wallet connects to the full node it syncs from.
+func (asset *Asset) syncHeight() (int32, bool, error) {
+	if asset.isRPCMode() {
+		rpcClient := asset.rpcChainClient()
+		if rpcClient == nil {
+			return -1, false, nil
+		}
+		return asset.rpcSyncHeight(rpcClient)
+	}
+
+	block, err := asset.chainClient.CS.BestBlock()
+	if err != nil {
+		return 0, false, err
+	}
+	return block.Height, asset.chainClient.IsCurrent(), nil
+}
+
 // SpvSync initiates the full chain sync starting protocols. It attempts to
 // restart the chain service if it hasn't been initialized.
 func (asset *Asset) SpvSync() (err error) {
@@ -551,6 +633,10 @@ func (asset *Asset) SpvSync() (err error) {
 		return errors.New(utils.ErrSyncAlreadyInProgress)
 	}
 
+	if err := asset.CheckSyncAllowed(); err != nil {
+		return err
+	}
+
 	// Initialize all progress report data.
 	asset.initSyncProgressData()
 
@@ -563,6 +649,7 @@ func (asset *Asset) SpvSync() (err error) {
 	asset.syncData.mu.Lock()
 	asset.syncData.syncing = true
 	asset.syncData.synced = false
+	asset.syncData.rpcMode = asset.usesRemoteBackend()
 	asset.syncData.mu.Unlock()
 
 	// Set wallet synced state to true when chainclient considers itself
@@ -598,11 +685,12 @@ func (asset *Asset) reloadChainService() error {
 		asset.CancelSync()
 	}
 
-	_ = asset.chainClient.CS.Stop()
+	_ = asset.stopChainService()
 	chainService, err := asset.loadChainService()
 	if err != nil {
 		return err
 	}
+	asset.cl = chainService
 	asset.chainClient.CS = chainService
 
 	// If the asset is previously connected to the network call SpvSync to
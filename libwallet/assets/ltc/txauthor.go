@@ -270,6 +270,13 @@ func (asset *Asset) Broadcast(privatePassphrase, transactionLabel string) (strin
 		unsignedTx.RandomizeChangePosition()
 	}
 
+	account := int32(asset.TxAuthoredInfo.sourceAccountNumber)
+	outputs := asset.spendOutputs(unsignedTx.Tx)
+	// Refuse the sends the spending policy forbids before signing them.
+	if _, err := asset.CheckSpend(account, outputs); err != nil {
+		return "", err
+	}
+
 	// Test encode and decode the tx to check its validity after being signed.
 	msgTx := unsignedTx.Tx
 
@@ -343,7 +350,10 @@ func (asset *Asset) Broadcast(privatePassphrase, transactionLabel string) (strin
 		return "", err
 	}
 
-	err = asset.Internal().LTC.PublishTransaction(msgTx, transactionLabel)
+	err = asset.publishWithPolicy(msgTx, account, outputs, transactionLabel, true)
+	if err != nil && err.Error() == utils.ErrSendDelayed {
+		return "", err
+	}
 	txHash := msgTx.TxHash()
 	return txHash.String(), utils.TranslateError(err)
 }
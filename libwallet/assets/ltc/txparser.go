@@ -28,6 +28,11 @@ func (asset *Asset) decodeTransactionWithTxSummary(blockheight int32, txsummary
 	outputs, totalOutputsAmount := asset.decodeTxOutputs(decodedTx, txsummary.MyOutputs)
 	amount, direction := txhelper.TransactionAmountAndDirection(totalInputsAmount, totalOutputsAmount, int64(txsummary.Fee))
 
+	label := txsummary.Label
+	if label == "" {
+		label = asset.RestoredTxLabel(txsummary.Hash.String())
+	}
+
 	return &sharedW.Transaction{
 		Hash:        txsummary.Hash.String(),
 		Type:        txType,
@@ -40,7 +45,7 @@ func (asset *Asset) decodeTransactionWithTxSummary(blockheight int32, txsummary
 		Fee:      int64(txsummary.Fee),
 		FeeRate:  int64(feeRate),
 		Size:     txSize,
-		Label:    txsummary.Label,
+		Label:    label,
 
 		Direction: direction,
 		Amount:    amount,
@@ -281,6 +281,9 @@ func (asset *Asset) SafelyCancelSync() {
 	if asset.IsConnectedToNetwork() {
 		// Chain is either syncing or is synced.
 		asset.CancelSync()
+	} else {
+		// The shared chain service is acquired when the wallet is loaded.
+		asset.releaseSharedChainService()
 	}
 
 	loadWallet := asset.Internal().LTC
@@ -340,6 +343,13 @@ func (asset *Asset) ConnectedPeers() int32 {
 	if !asset.IsConnectedToNetwork() {
 		return -1
 	}
+	if asset.isRPCMode() {
+		// The wallet only connects to the full node it syncs from.
+		if asset.rpcChainClient() == nil {
+			return 0
+		}
+		return 1
+	}
 
 	return int32(len(asset.cl.Peers()))
 }
@@ -351,6 +361,10 @@ func (asset *Asset) IsConnectedToNetwork() bool {
 
 // GetBestBlock returns the best block.
 func (asset *Asset) GetBestBlock() *sharedW.BlockInfo {
+	if asset.isRPCMode() {
+		return asset.rpcBestBlock()
+	}
+
 	block, err := asset.chainClient.CS.BestBlock()
 	if err != nil {
 		log.Error("GetBestBlock hash for LTC failed, Err: ", err)
@@ -375,7 +389,13 @@ func (asset *Asset) GetBestBlockTimeStamp() int64 {
 
 // GetBlockHeight returns the block height for the given block hash.
 func (asset *Asset) GetBlockHeight(hash chainhash.Hash) (int32, error) {
-	height, err := asset.chainClient.GetBlockHeight(&hash)
+	var height int32
+	var err error
+	if rpcClient := asset.rpcChainClient(); rpcClient != nil {
+		height, err = rpcBlockHeight(rpcClient, &hash)
+	} else {
+		height, err = asset.chainClient.GetBlockHeight(&hash)
+	}
 	if err != nil {
 		log.Warn("GetBlockHeight for LTC failed, Err: %v", err)
 		return -1, err
@@ -385,7 +405,7 @@ func (asset *Asset) GetBlockHeight(hash chainhash.Hash) (int32, error) {
 
 // GetBlockHash returns the block hash for the given block height.
 func (asset *Asset) GetBlockHash(height int64) (*chainhash.Hash, error) {
-	blockhash, err := asset.chainClient.GetBlockHash(height)
+	blockhash, err := asset.chainSource().GetBlockHash(height)
 	if err != nil {
 		log.Warn("GetBlockHash for LTC failed, Err: %v", err)
 		return nil, err
@@ -2,6 +2,8 @@ package wallet
 
 import (
 	"context"
+	"encoding/json"
+	"time"
 
 	"github.com/crypto-power/cryptopower/libwallet/internal/loader"
 	"github.com/crypto-power/cryptopower/libwallet/utils"
@@ -14,12 +16,35 @@ type Asset interface {
 	IsSyncing() bool
 	SpvSync() error
 	CancelRescan()
+	DiscardRescan()
 	CancelSync()
 	IsRescanning() bool
 	RescanBlocks() error
+	RescanBlocksFromHeight(startHeight int32) error
+	RescanAddresses(startHeight int32, addrs []string) error
+	ResumeRescan() error
+	PendingRescan() *RescanState
 	ConnectedPeers() int32
 	RemovePeers()
 	SetSpecificPeer(address string)
+	PeerInfoRaw() ([]PeerInfo, error)
+	DisconnectPeer(addr string) error
+	BannedPeers() []string
+	BanPeer(addr string) error
+	UnbanPeer(addr string) error
+	PreferredPeers() []string
+	SetPeerPreferred(addr string, preferred bool) error
+	CheckSyncAllowed() error
+	SyncDataUsage() uint64
+	FlushSyncDataUsage()
+	SetRestoreBirthday(birthday time.Time) error
+	RestoreBirthday() time.Time
+	RPCConfig() *RPCConfig
+	SetRPCConfig(cfg *RPCConfig) error
+	TestRPCConnection(cfg *RPCConfig) error
+	ElectrumConfig() *ElectrumConfig
+	SetElectrumConfig(cfg *ElectrumConfig) error
+	TestElectrumServer(server *ElectrumServer, torProxy string) (string, error)
 	GetExtendedPubKey(account int32) (string, error)
 	IsSyncShuttingDown() bool
 	EnableSyncShuttingDown()
@@ -30,6 +55,11 @@ type Asset interface {
 	IsWaiting() bool
 	WalletOpened() bool
 	OpenWallet() error
+	BackupWalletDB() (string, error)
+	WalletDBBackups() ([]*WalletDBBackup, error)
+	RestoreWalletDB(backupPath string) error
+	RecodeMetadata() error
+	UpgradeMetadata() error
 	GetWalletID() int
 	GetWalletName() string
 	IsWatchingOnlyWallet() bool
@@ -38,6 +68,9 @@ type Asset interface {
 	RenameWallet(newName string) error
 	DecryptSeed(privatePassphrase string) (string, error)
 	VerifySeedForWallet(seedMnemonic, privpass string) (bool, error)
+	SeedCheckPositions(privpass string) ([]int, error)
+	VerifySeedWords(words map[int]string, privpass string) (bool, error)
+	LastSeedCheck() time.Time
 	ChangePrivatePassphraseForWallet(oldPrivatePassphrase, newPrivatePassphrase string, privatePassphraseType int32) error
 	GetPrivatePassphraseType() int32
 
@@ -93,6 +126,7 @@ type Asset interface {
 	VerifyMessage(address, message, signatureBase64 string) (bool, error)
 
 	SaveUserConfigValue(key string, value interface{})
+	UserConfigValues() (map[string]json.RawMessage, error)
 	ReadUserConfigValue(key string, valueOut interface{}) error
 
 	SetBoolConfigValueForKey(key string, value bool)
@@ -118,4 +152,29 @@ type Asset interface {
 	RemoveSendDestination(id int)
 	SendDestination(id int) *TransactionDestination
 	UpdateSendDestination(id int, address string, atomAmount int64, sendMax bool) error
+
+	SpendingPolicies() SpendingPolicies
+	SpendingPolicy(account int32) *SpendingPolicy
+	SetSpendingPolicy(account int32, policy *SpendingPolicy, privatePassphrase string) error
+	ApproveDestinations(addresses []string) error
+	CheckDEXSwaps(account int32, amount int64) error
+	DelayedSends() []*DelayedSend
+	CancelDelayedSend(hash string) error
+	PublishDueDelayedSends()
+
+	IsMultisig() bool
+	MultisigConfig() *MultisigConfig
+	MultisigXPub() string
+	DeriveMultisigXPub(privatePassphrase string) (string, error)
+	SetupMultisig(required int, cosigners []*Cosigner) error
+	NewMultisigAddress() (string, error)
+	MultisigBalance() (AssetAmount, error)
+	CreateMultisigProposal(address string, amount int64, sendMax bool) (string, error)
+	MultisigProposalInfo(proposal string) (*MultisigProposalInfo, error)
+	SignMultisigProposal(proposal, privatePassphrase string) (string, error)
+	BroadcastMultisigProposal(proposal, transactionLabel string) (string, error)
+
+	ImportPrivateKey(wif, privatePassphrase string, startHeight int32) ([]string, error)
+	PrepareKeySweep(wif string, account, startHeight int32) (*SweepResult, error)
+	PublishKeySweep(sweep *SweepResult) error
 }
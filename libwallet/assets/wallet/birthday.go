@@ -0,0 +1,42 @@
+package wallet
+
+import (
+	"time"
+
+	"decred.org/dcrwallet/v4/errors"
+	"github.com/crypto-power/cryptopower/libwallet/utils"
+)
+
+// RestoreBirthdayMargin is how long before the birthday of a restored wallet
+// its rescan starts, as the block height of a date is only estimated.
+const RestoreBirthdayMargin = 7 * 24 * time.Hour
+
+// EstimateBlockHeight estimates the height of the block mined at date on a
+// chain whose genesis block was mined at genesisTimestamp and whose blocks
+// are mined every targetTimePerBlock seconds.
+func EstimateBlockHeight(genesisTimestamp, targetTimePerBlock int64, date time.Time) int32 {
+	if targetTimePerBlock <= 0 || date.Unix() <= genesisTimestamp {
+		return 0
+	}
+	return int32((date.Unix() - genesisTimestamp) / targetTimePerBlock)
+}
+
+// SetRestoreBirthday sets the date the keys of a restored wallet were first
+// used, so that it starts rescanning the chain near that date instead of from
+// the genesis block. It must be set before the restored wallet first syncs.
+func (wallet *Wallet) SetRestoreBirthday(birthday time.Time) error {
+	if !wallet.IsRestored || wallet.ContainsDiscoveredAccounts() {
+		return errors.New(utils.ErrInvalid)
+	}
+	return wallet.walletConfigSave(RestoreBirthdayConfigKey, birthday.Unix())
+}
+
+// RestoreBirthday returns the date set with SetRestoreBirthday, or the zero
+// time if the wallet is rescanned from the genesis block.
+func (wallet *Wallet) RestoreBirthday() time.Time {
+	var birthday int64
+	if err := wallet.ReadUserConfigValue(RestoreBirthdayConfigKey, &birthday); err != nil || birthday == 0 {
+		return time.Time{}
+	}
+	return time.Unix(birthday, 0)
+}
@@ -0,0 +1,143 @@
+package wallet
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// SharedChainDirName is the name of the data dir of the chain backend shared
+// by the wallets of an asset, next to the wallets data dirs.
+const SharedChainDirName = "chain"
+
+var (
+	// neutrinoChainFiles are the header files written by a neutrino chain
+	// service in its data dir.
+	neutrinoChainFiles = []string{"block_headers.bin", "reg_filter_headers.bin"}
+	// neutrinoChainBuckets are the buckets of the header index, the filters
+	// and the banned peers of a neutrino chain service.
+	neutrinoChainBuckets = [][]byte{[]byte("header-index"), []byte("filter-store"), []byte("ban-store")}
+)
+
+// ChainBackend is a chain service, with its headers and filters store and its
+// peers, that the wallets of an asset sync from.
+type ChainBackend interface {
+	Stop() error
+}
+
+type chainBackendRef struct {
+	backend ChainBackend
+	refs    int
+}
+
+// ChainBackends holds the chain backends shared by the wallets of the same
+// asset. A backend is created by the first wallet that acquires it and
+// stopped when the last wallet releases it, so that a backend is never used
+// after it is stopped.
+type ChainBackends struct {
+	mu       sync.Mutex
+	backends map[string]*chainBackendRef
+}
+
+// NewChainBackends creates an empty set of shared chain backends.
+func NewChainBackends() *ChainBackends {
+	return &ChainBackends{backends: make(map[string]*chainBackendRef)}
+}
+
+// Acquire returns the backend stored under key, creating it with create if
+// no wallet holds it. Every successful Acquire must be paired with a Release.
+func (cb *ChainBackends) Acquire(key string, create func() (ChainBackend, error)) (ChainBackend, error) {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	ref, ok := cb.backends[key]
+	if !ok {
+		backend, err := create()
+		if err != nil {
+			return nil, err
+		}
+		ref = &chainBackendRef{backend: backend}
+		cb.backends[key] = ref
+	}
+	ref.refs++
+	return ref.backend, nil
+}
+
+// Release drops a reference to the backend stored under key and stops the
+// backend if no other wallet holds it.
+func (cb *ChainBackends) Release(key string) {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	ref, ok := cb.backends[key]
+	if !ok {
+		return
+	}
+	ref.refs--
+	if ref.refs > 0 {
+		return
+	}
+	delete(cb.backends, key)
+	if err := ref.backend.Stop(); err != nil {
+		log.Errorf("Error stopping the %s chain backend: %v", key, err)
+	}
+}
+
+// Shutdown stops every backend regardless of the wallets holding it.
+func (cb *ChainBackends) Shutdown() {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	for key, ref := range cb.backends {
+		if err := ref.backend.Stop(); err != nil {
+			log.Errorf("Error stopping the %s chain backend: %v", key, err)
+		}
+		delete(cb.backends, key)
+	}
+}
+
+// ChainBackends returns the chain backends the wallet shares with the other
+// wallets of the same asset, or nil if it doesn't share any.
+func (wallet *Wallet) ChainBackends() *ChainBackends {
+	wallet.mu.RLock()
+	defer wallet.mu.RUnlock()
+	return wallet.chainBackends
+}
+
+// SharedChainBackendKey returns the key of the chain backend shared by the
+// wallets of the asset and network of the wallet.
+func (wallet *Wallet) SharedChainBackendKey() string {
+	return wallet.Type.ToStringLower() + "-" + string(wallet.netType)
+}
+
+// SharedChainDataDir returns the data dir of the chain backend shared by the
+// wallets of the asset and network of the wallet.
+func (wallet *Wallet) SharedChainDataDir() string {
+	wallet.mu.RLock()
+	defer wallet.mu.RUnlock()
+	return filepath.Join(wallet.assetDir(), SharedChainDirName)
+}
+
+// RemoveNeutrinoChainData deletes the headers, filters and banned peers stored
+// by the wallet's own neutrino chain service, which are left unused once the
+// wallet syncs from the chain service shared with the other wallets.
+func (wallet *Wallet) RemoveNeutrinoChainData() {
+	removed := false
+	for _, name := range neutrinoChainFiles {
+		err := os.Remove(filepath.Join(wallet.DataDir(), name))
+		if err != nil && !os.IsNotExist(err) {
+			log.Errorf("Error removing the wallet chain data: %v", err)
+		}
+		removed = removed || err == nil
+	}
+	// The buckets are only left in wallets that had their own chain service
+	// files.
+	if !removed {
+		return
+	}
+	if err := wallet.GetWalletDataDb().DropBuckets(neutrinoChainBuckets...); err != nil {
+		log.Errorf("Error removing the wallet chain data: %v", err)
+		return
+	}
+	log.Infof("Removed the chain data of wallet %d, which syncs from the shared chain service", wallet.ID)
+}
@@ -0,0 +1,56 @@
+package wallet
+
+import "testing"
+
+type testChainBackend struct {
+	stopped int
+}
+
+func (b *testChainBackend) Stop() error {
+	b.stopped++
+	return nil
+}
+
+func TestChainBackendsRefCount(t *testing.T) {
+	backends := NewChainBackends()
+	created := 0
+	create := func() (ChainBackend, error) {
+		created++
+		return new(testChainBackend), nil
+	}
+
+	first, err := backends.Acquire("btc-mainnet", create)
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := backends.Acquire("btc-mainnet", create)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if first != second || created != 1 {
+		t.Fatalf("got %d backends, want one shared backend", created)
+	}
+
+	backends.Release("btc-mainnet")
+	if first.(*testChainBackend).stopped != 0 {
+		t.Fatal("backend stopped while a wallet still holds it")
+	}
+	backends.Release("btc-mainnet")
+	if first.(*testChainBackend).stopped != 1 {
+		t.Fatal("backend not stopped after the last release")
+	}
+
+	// A stopped backend is never handed out again.
+	third, err := backends.Acquire("btc-mainnet", create)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if third == first || created != 2 {
+		t.Fatal("stopped backend handed out again")
+	}
+
+	backends.Shutdown()
+	if third.(*testChainBackend).stopped != 1 {
+		t.Fatal("backend not stopped on shutdown")
+	}
+}
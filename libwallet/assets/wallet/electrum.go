@@ -0,0 +1,145 @@
+package wallet
+
+import (
+	"encoding/hex"
+	"fmt"
+	"net"
+	"strings"
+
+	"decred.org/dcrwallet/v4/errors"
+	"github.com/crypto-power/cryptopower/libwallet/utils"
+)
+
+// ElectrumServer is an Electrum server a wallet syncs from.
+type ElectrumServer struct {
+	// Address is the host:port of the TLS port of the server.
+	Address string `json:"address"`
+	// CertFingerprint is the hex encoded SHA-256 fingerprint of the TLS
+	// certificate of the server. It is pinned on the first connection if it
+	// is empty, and the server is refused if it presents another
+	// certificate.
+	CertFingerprint string `json:"certfingerprint"`
+}
+
+// ElectrumConfig holds the Electrum servers a wallet syncs from instead of
+// SPV. The servers are tried in order and the headers they serve are checked
+// for proof of work.
+type ElectrumConfig struct {
+	Servers []*ElectrumServer `json:"servers"`
+	// TorProxy is the host:port of the SOCKS5 proxy of a Tor client the
+	// servers are connected through. The servers are connected to directly
+	// if it is empty.
+	TorProxy string `json:"torproxy"`
+}
+
+// ElectrumSupported returns true if the wallets of assetType can sync from
+// Electrum servers.
+func ElectrumSupported(assetType utils.AssetType) bool {
+	return assetType == utils.BTCWalletAsset || assetType == utils.LTCWalletAsset
+}
+
+// ParseElectrumServers parses a list of servers, one per line, each given as
+// its host:port optionally followed by the fingerprint of its certificate.
+func ParseElectrumServers(list string) ([]*ElectrumServer, error) {
+	var servers []*ElectrumServer
+	for _, line := range strings.Split(list, "\n") {
+		fields := strings.Fields(line)
+		switch len(fields) {
+		case 0:
+			continue
+		case 1, 2:
+			server := &ElectrumServer{Address: fields[0]}
+			if len(fields) == 2 {
+				server.CertFingerprint = strings.ToLower(fields[1])
+			}
+			servers = append(servers, server)
+		default:
+			return nil, errors.E(errors.Invalid, fmt.Sprintf("invalid electrum server %q", line))
+		}
+	}
+	return servers, nil
+}
+
+// FormatElectrumServers formats servers in the format read by
+// ParseElectrumServers.
+func FormatElectrumServers(servers []*ElectrumServer) string {
+	lines := make([]string, 0, len(servers))
+	for _, server := range servers {
+		lines = append(lines, strings.TrimSpace(server.Address+" "+server.CertFingerprint))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Validate checks that the wallets of assetType can sync from the servers in
+// cfg.
+func (cfg *ElectrumConfig) Validate(assetType utils.AssetType) error {
+	if !ElectrumSupported(assetType) {
+		return errors.E(errors.Invalid, fmt.Sprintf("%s wallets can't sync from electrum servers", assetType))
+	}
+	if len(cfg.Servers) == 0 {
+		return errors.E(errors.Invalid, "at least one electrum server is required")
+	}
+
+	for _, server := range cfg.Servers {
+		host, port, err := net.SplitHostPort(server.Address)
+		if err != nil || host == "" || port == "" {
+			return errors.E(errors.Invalid, fmt.Sprintf("electrum server %q is not a host:port", server.Address))
+		}
+		if strings.HasSuffix(host, ".onion") && cfg.TorProxy == "" {
+			return errors.E(errors.Invalid, fmt.Sprintf("electrum server %q requires a tor proxy", server.Address))
+		}
+		if server.CertFingerprint != "" {
+			fingerprint, err := hex.DecodeString(server.CertFingerprint)
+			if err != nil || len(fingerprint) != 32 {
+				return errors.E(errors.Invalid, fmt.Sprintf("invalid certificate fingerprint for %q", server.Address))
+			}
+		}
+	}
+
+	if cfg.TorProxy != "" {
+		if _, _, err := net.SplitHostPort(cfg.TorProxy); err != nil {
+			return errors.E(errors.Invalid, fmt.Sprintf("tor proxy %q is not a host:port", cfg.TorProxy))
+		}
+	}
+	return nil
+}
+
+// ElectrumConfig returns the Electrum servers the wallet syncs from, or nil
+// if the wallet doesn't sync from Electrum servers.
+func (wallet *Wallet) ElectrumConfig() *ElectrumConfig {
+	var cfg *ElectrumConfig
+	_ = wallet.ReadUserConfigValue(ElectrumSyncConfigKey, &cfg)
+	return cfg
+}
+
+// SetElectrumConfig validates and stores the Electrum servers the wallet
+// syncs from, replacing any full node set with SetRPCConfig. A nil cfg
+// switches the wallet back to SPV. The new settings apply from the next sync.
+func (wallet *Wallet) SetElectrumConfig(cfg *ElectrumConfig) error {
+	if cfg == nil {
+		return wallet.walletConfigDelete(ElectrumSyncConfigKey)
+	}
+	if err := cfg.Validate(wallet.Type); err != nil {
+		return err
+	}
+	if err := wallet.walletConfigDelete(RPCSyncConfigKey); err != nil {
+		return err
+	}
+	return wallet.walletConfigSave(ElectrumSyncConfigKey, cfg)
+}
+
+// PinElectrumCertificate pins the certificate fingerprint of the server at
+// address if no certificate is pinned for it yet.
+func (wallet *Wallet) PinElectrumCertificate(address, fingerprint string) error {
+	cfg := wallet.ElectrumConfig()
+	if cfg == nil {
+		return nil
+	}
+	for _, server := range cfg.Servers {
+		if server.Address == address && server.CertFingerprint == "" {
+			server.CertFingerprint = fingerprint
+			return wallet.walletConfigSave(ElectrumSyncConfigKey, cfg)
+		}
+	}
+	return nil
+}
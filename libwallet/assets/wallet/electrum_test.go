@@ -0,0 +1,60 @@
+package wallet
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/crypto-power/cryptopower/libwallet/utils"
+)
+
+const testFingerprint = "3b8f1c2a9d4e5f60718293a4b5c6d7e8f90123456789abcdef0123456789abcd"
+
+func TestParseElectrumServers(t *testing.T) {
+	list := "electrum.example:50002\n\n  node.example:50002 " + strings.ToUpper(testFingerprint) + "\n"
+	servers, err := ParseElectrumServers(list)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(servers) != 2 || servers[0].CertFingerprint != "" || servers[1].CertFingerprint != testFingerprint {
+		t.Fatalf("unexpected servers %+v", servers)
+	}
+
+	want := "electrum.example:50002\nnode.example:50002 " + testFingerprint
+	if got := FormatElectrumServers(servers); got != want {
+		t.Fatalf("FormatElectrumServers = %q, want %q", got, want)
+	}
+
+	if _, err := ParseElectrumServers("a.example:50002 fingerprint extra"); err == nil {
+		t.Fatal("expected an error for a line with extra fields")
+	}
+}
+
+func TestElectrumConfigValidate(t *testing.T) {
+	server := func(address, fingerprint string) []*ElectrumServer {
+		return []*ElectrumServer{{Address: address, CertFingerprint: fingerprint}}
+	}
+
+	tests := []struct {
+		name      string
+		assetType utils.AssetType
+		cfg       ElectrumConfig
+		wantErr   bool
+	}{
+		{"btc server", utils.BTCWalletAsset, ElectrumConfig{Servers: server("electrum.example:50002", "")}, false},
+		{"ltc pinned server", utils.LTCWalletAsset, ElectrumConfig{Servers: server("electrum.example:50002", testFingerprint)}, false},
+		{"onion server through tor", utils.BTCWalletAsset, ElectrumConfig{server("abc.onion:50002", ""), "127.0.0.1:9050"}, false},
+		{"dcr wallet", utils.DCRWalletAsset, ElectrumConfig{Servers: server("electrum.example:50002", "")}, true},
+		{"no servers", utils.BTCWalletAsset, ElectrumConfig{}, true},
+		{"missing port", utils.BTCWalletAsset, ElectrumConfig{Servers: server("electrum.example", "")}, true},
+		{"onion server without tor", utils.BTCWalletAsset, ElectrumConfig{Servers: server("abc.onion:50002", "")}, true},
+		{"short fingerprint", utils.BTCWalletAsset, ElectrumConfig{Servers: server("electrum.example:50002", "abcd")}, true},
+		{"invalid tor proxy", utils.BTCWalletAsset, ElectrumConfig{server("electrum.example:50002", ""), "localhost"}, true},
+	}
+
+	for _, test := range tests {
+		err := test.cfg.Validate(test.assetType)
+		if (err != nil) != test.wantErr {
+			t.Errorf("%s: got error %v, want error %v", test.name, err, test.wantErr)
+		}
+	}
+}
@@ -0,0 +1,253 @@
+package wallet
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"sync"
+
+	"decred.org/dcrwallet/v4/errors"
+	"github.com/crypto-power/cryptopower/libwallet/utils"
+)
+
+// FeeEstimateTargets are the confirmation targets, in blocks, fee rates are
+// estimated for.
+var FeeEstimateTargets = []int32{1, 2, 3, 6, 12, 24, 144}
+
+// FeeEstimateProvider is a source of fee estimates, such as an HTTP API or
+// the node the wallet syncs from.
+type FeeEstimateProvider interface {
+	// Name identifies the provider in the logs.
+	Name() string
+	// FeeEstimates returns the fee rates, in atoms per kvB, for a transaction
+	// to confirm within the blocks of each estimate.
+	FeeEstimates() ([]FeeEstimate, error)
+}
+
+type feeEstimateFunc struct {
+	name  string
+	fetch func() ([]FeeEstimate, error)
+}
+
+func (f *feeEstimateFunc) Name() string                         { return f.name }
+func (f *feeEstimateFunc) FeeEstimates() ([]FeeEstimate, error) { return f.fetch() }
+
+// NewFeeEstimateProvider returns a provider named name that fetches its
+// estimates with fetch.
+func NewFeeEstimateProvider(name string, fetch func() ([]FeeEstimate, error)) FeeEstimateProvider {
+	return &feeEstimateFunc{name: name, fetch: fetch}
+}
+
+// FeeRateAPIFormat is the response format of a fee rate HTTP API.
+type FeeRateAPIFormat uint8
+
+const (
+	// EsploraFeeRates is the format of the esplora fee-estimates endpoint, a
+	// map of confirmation targets to fee rates in atoms per vB.
+	EsploraFeeRates FeeRateAPIFormat = iota
+	// MempoolFeeRates is the format of the mempool fees/recommended
+	// endpoint, fee rates in atoms per vB for a few named priorities.
+	MempoolFeeRates
+	// BlockcypherFeeRates is the format of the blockcypher chain endpoint,
+	// fee rates in atoms per kB for high, medium and low priorities.
+	BlockcypherFeeRates
+)
+
+// FeeRateAPI is an HTTP API serving fee estimates.
+type FeeRateAPI struct {
+	Name   string
+	URL    string
+	Format FeeRateAPIFormat
+}
+
+// Provider returns a provider querying the API, toAmount converting its fee
+// rates to amounts of the asset.
+func (api FeeRateAPI) Provider(toAmount func(int64) AssetAmount) FeeEstimateProvider {
+	return NewFeeEstimateProvider(api.Name, func() ([]FeeEstimate, error) {
+		rates, err := api.fetch()
+		if err != nil {
+			return nil, err
+		}
+		estimates := make([]FeeEstimate, 0, len(rates))
+		for target, rate := range rates {
+			if rate > 0 {
+				estimates = append(estimates, FeeEstimate{ConfirmedBlocks: target, Feerate: toAmount(rate)})
+			}
+		}
+		return estimates, nil
+	})
+}
+
+// fetch queries the API for its fee rates in atoms per kvB by confirmation
+// target.
+func (api FeeRateAPI) fetch() (map[int32]int64, error) {
+	req := &utils.ReqConfig{
+		Method:  http.MethodGet,
+		HTTPURL: api.URL,
+	}
+
+	// Fee rates per vB are converted to per kvB at the rate of 1000 per kvB
+	// == 1 per vB.
+	rates := make(map[int32]int64)
+	switch api.Format {
+	case EsploraFeeRates:
+		resp := make(map[string]float64)
+		if _, err := utils.HTTPRequest(req, &resp); err != nil {
+			return nil, err
+		}
+		for blocks, feerate := range resp {
+			target, err := strconv.ParseInt(blocks, 10, 32)
+			if err != nil {
+				// Invalid blocks confirmation found ignore it.
+				continue
+			}
+			rates[int32(target)] = int64(feerate * 1000)
+		}
+
+	case MempoolFeeRates:
+		var resp struct {
+			FastestFee  float64 `json:"fastestFee"`
+			HalfHourFee float64 `json:"halfHourFee"`
+			HourFee     float64 `json:"hourFee"`
+			EconomyFee  float64 `json:"economyFee"`
+		}
+		if _, err := utils.HTTPRequest(req, &resp); err != nil {
+			return nil, err
+		}
+		// The priorities are named after bitcoin's block times but are
+		// projected from the next blocks of the mempool, on any chain.
+		rates[1] = int64(resp.FastestFee * 1000)
+		rates[3] = int64(resp.HalfHourFee * 1000)
+		rates[6] = int64(resp.HourFee * 1000)
+		rates[144] = int64(resp.EconomyFee * 1000)
+
+	case BlockcypherFeeRates:
+		var resp struct {
+			High   int64 `json:"high_fee_per_kb"`
+			Medium int64 `json:"medium_fee_per_kb"`
+			Low    int64 `json:"low_fee_per_kb"`
+		}
+		if _, err := utils.HTTPRequest(req, &resp); err != nil {
+			return nil, err
+		}
+		// High targets 1-2 blocks, medium 3-6 blocks and low 7 or more.
+		rates[2] = resp.High
+		rates[6] = resp.Medium
+		rates[12] = resp.Low
+
+	default:
+		return nil, fmt.Errorf("unknown fee rate API format %d", api.Format)
+	}
+	return rates, nil
+}
+
+// MedianFeeEstimates queries the providers at once and returns the median of
+// their fee rates for each of targets, sorted by target. Providers that fail
+// are skipped, an error is only returned if none succeeds.
+func MedianFeeEstimates(providers []FeeEstimateProvider, targets []int32, toAmount func(int64) AssetAmount) ([]FeeEstimate, error) {
+	results := make([][]FeeEstimate, len(providers))
+	var wg sync.WaitGroup
+	for i, provider := range providers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			estimates, err := provider.FeeEstimates()
+			if err != nil {
+				log.Warnf("fetching the fee estimates of %s failed: %v", provider.Name(), err)
+				return
+			}
+			results[i] = estimates
+		}()
+	}
+	wg.Wait()
+
+	feerates := medianFeeRates(results, targets)
+	if len(feerates) == 0 {
+		return nil, errors.New("fee estimates not available")
+	}
+
+	estimates := make([]FeeEstimate, 0, len(feerates))
+	for target, rate := range feerates {
+		estimates = append(estimates, FeeEstimate{ConfirmedBlocks: target, Feerate: toAmount(rate)})
+	}
+	sort.Slice(estimates, func(i, j int) bool {
+		return estimates[i].ConfirmedBlocks < estimates[j].ConfirmedBlocks
+	})
+	return estimates, nil
+}
+
+// BlockFeeEstimates estimates the fee rates from the average fee rates, in
+// atoms per kvB, of recent blocks. The average fee rate of a block is above
+// the lowest rate it included, so a transaction paying the median average is
+// expected to confirm in the next block rather than one paying the highest
+// average. The lower quartile is expected to confirm within half of the
+// blocks and the lowest average within all of them.
+func BlockFeeEstimates(rates []int64, toAmount func(int64) AssetAmount) []FeeEstimate {
+	if len(rates) == 0 {
+		return nil
+	}
+	rates = append([]int64(nil), rates...)
+	sort.Slice(rates, func(i, j int) bool { return rates[i] > rates[j] })
+
+	n := len(rates)
+	targets := []struct {
+		blocks int32
+		rate   int64
+	}{
+		{1, rates[n/2]},
+		{int32(n+1) / 2, rates[n*3/4]},
+		{int32(n), rates[n-1]},
+	}
+	estimates := make([]FeeEstimate, 0, len(targets))
+	for _, target := range targets {
+		if len(estimates) > 0 && target.blocks <= estimates[len(estimates)-1].ConfirmedBlocks {
+			continue
+		}
+		estimates = append(estimates, FeeEstimate{ConfirmedBlocks: target.blocks, Feerate: toAmount(target.rate)})
+	}
+	return estimates
+}
+
+// medianFeeRates returns the median of the fee rates of each source for each
+// of targets. A source's rate for a target is that of its slowest estimate
+// confirming within the target, as a transaction paying it is expected to
+// confirm in time. Targets which wouldn't cost less than a faster target are
+// left out.
+func medianFeeRates(sources [][]FeeEstimate, targets []int32) map[int32]int64 {
+	targets = append([]int32(nil), targets...)
+	sort.Slice(targets, func(i, j int) bool { return targets[i] < targets[j] })
+
+	feerates := make(map[int32]int64, len(targets))
+	var fasterRate int64
+	for _, target := range targets {
+		rates := make([]int64, 0, len(sources))
+		for _, estimates := range sources {
+			var blocks int32
+			var rate int64
+			for _, estimate := range estimates {
+				if estimate.ConfirmedBlocks <= target && estimate.ConfirmedBlocks > blocks && estimate.Feerate != nil {
+					blocks, rate = estimate.ConfirmedBlocks, estimate.Feerate.ToInt()
+				}
+			}
+			if rate > 0 {
+				rates = append(rates, rate)
+			}
+		}
+		if len(rates) == 0 {
+			continue
+		}
+
+		sort.Slice(rates, func(i, j int) bool { return rates[i] < rates[j] })
+		median := rates[len(rates)/2]
+		if len(rates)%2 == 0 {
+			median = (rates[len(rates)/2-1] + median) / 2
+		}
+		if fasterRate > 0 && median >= fasterRate {
+			continue
+		}
+		feerates[target] = median
+		fasterRate = median
+	}
+	return feerates
+}
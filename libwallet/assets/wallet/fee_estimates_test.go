@@ -0,0 +1,90 @@
+package wallet
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+)
+
+type testAmount int64
+
+func (a testAmount) ToCoin() float64              { return float64(a) }
+func (a testAmount) String() string               { return strconv.FormatInt(int64(a), 10) }
+func (a testAmount) MulF64(f float64) AssetAmount { return testAmount(float64(a) * f) }
+func (a testAmount) ToInt() int64                 { return int64(a) }
+
+func toTestAmount(v int64) AssetAmount { return testAmount(v) }
+
+func estimates(rates map[int32]int64) func() ([]FeeEstimate, error) {
+	return func() ([]FeeEstimate, error) {
+		var estimates []FeeEstimate
+		for blocks, rate := range rates {
+			estimates = append(estimates, FeeEstimate{ConfirmedBlocks: blocks, Feerate: testAmount(rate)})
+		}
+		return estimates, nil
+	}
+}
+
+func TestMedianFeeEstimates(t *testing.T) {
+	providers := []FeeEstimateProvider{
+		NewFeeEstimateProvider("a", estimates(map[int32]int64{1: 30000, 3: 20000, 6: 10000})),
+		NewFeeEstimateProvider("b", estimates(map[int32]int64{1: 40000, 2: 25000, 6: 12000})),
+		NewFeeEstimateProvider("c", estimates(map[int32]int64{2: 50000, 12: 9000})),
+		NewFeeEstimateProvider("failing", func() ([]FeeEstimate, error) {
+			return nil, errors.New("unreachable")
+		}),
+	}
+
+	got, err := MedianFeeEstimates(providers, []int32{1, 2, 3, 6, 12, 24}, toTestAmount)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Only a and b estimate a single block, the median leaves out c's
+	// outlier and 24 blocks wouldn't be cheaper than 12 blocks.
+	want := map[int32]int64{1: 35000, 2: 30000, 3: 25000, 6: 12000, 12: 10000}
+	if len(got) != len(want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	for i, estimate := range got {
+		if i > 0 && estimate.ConfirmedBlocks <= got[i-1].ConfirmedBlocks {
+			t.Fatalf("estimates not sorted by target: %v", got)
+		}
+		if rate, ok := want[estimate.ConfirmedBlocks]; !ok || estimate.Feerate.ToInt() != rate {
+			t.Errorf("target %d: got %d, want %d", estimate.ConfirmedBlocks, estimate.Feerate.ToInt(), rate)
+		}
+	}
+
+	failing := providers[len(providers)-1:]
+	if _, err := MedianFeeEstimates(failing, FeeEstimateTargets, toTestAmount); err == nil {
+		t.Fatal("expected an error without any estimates")
+	}
+}
+
+func TestBlockFeeEstimates(t *testing.T) {
+	rates := []int64{5000, 60000, 20000, 10000, 8000, 30000}
+	got := BlockFeeEstimates(rates, toTestAmount)
+
+	// The priciest block doesn't set the rate of the next block.
+	want := []FeeEstimate{
+		{ConfirmedBlocks: 1, Feerate: testAmount(10000)},
+		{ConfirmedBlocks: 3, Feerate: testAmount(8000)},
+		{ConfirmedBlocks: 6, Feerate: testAmount(5000)},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i].ConfirmedBlocks != want[i].ConfirmedBlocks || got[i].Feerate.ToInt() != want[i].Feerate.ToInt() {
+			t.Errorf("estimate %d: got %d blocks at %d, want %d blocks at %d", i, got[i].ConfirmedBlocks,
+				got[i].Feerate.ToInt(), want[i].ConfirmedBlocks, want[i].Feerate.ToInt())
+		}
+	}
+
+	if got := BlockFeeEstimates([]int64{5000}, toTestAmount); len(got) != 1 || got[0].ConfirmedBlocks != 1 {
+		t.Fatalf("single block: got %v", got)
+	}
+	if got := BlockFeeEstimates(nil, toTestAmount); got != nil {
+		t.Fatalf("no blocks: got %v", got)
+	}
+}
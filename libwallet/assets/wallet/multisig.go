@@ -0,0 +1,248 @@
+package wallet
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+
+	"decred.org/dcrwallet/v4/errors"
+	"github.com/asdine/storm"
+	"github.com/crypto-power/cryptopower/libwallet/utils"
+)
+
+const (
+	// MaxMultisigCosigners is the maximum number of public keys allowed in a
+	// standard multisig script.
+	MaxMultisigCosigners = 15
+
+	// MultisigAddressGap is the number of addresses past the last used index
+	// that are imported on each branch, so that funds sent to addresses given
+	// out by the other cosigners are also watched.
+	MultisigAddressGap uint32 = 20
+
+	// MultisigExternalBranch and MultisigInternalBranch are the branches of
+	// the cosigner xpubs used to derive receive and change addresses.
+	MultisigExternalBranch uint32 = 0
+	MultisigInternalBranch uint32 = 1
+)
+
+// Cosigner is a participant of a multisig wallet.
+type Cosigner struct {
+	Name string `json:"name"`
+	// XPub is the extended public key of the cosigner's account that the
+	// multisig keys are derived from.
+	XPub string `json:"xpub"`
+}
+
+// MultisigAddress is a multisig address that was imported by the wallet.
+type MultisigAddress struct {
+	Address string `json:"address"`
+	Branch  uint32 `json:"branch"`
+	Index   uint32 `json:"index"`
+	// Script is the hex encoded redeem script (DCR) or witness script
+	// (BTC/LTC) of the address.
+	Script string `json:"script"`
+}
+
+// MultisigConfig describes an m-of-n multisig wallet.
+type MultisigConfig struct {
+	// Required is the number of cosigner signatures required to spend.
+	Required int `json:"required"`
+	// Cosigners includes the local cosigner.
+	Cosigners []*Cosigner `json:"cosigners"`
+	// LocalXPub is the xpub of the wallet's keys dedicated to multisig, or
+	// of the wallet's default account if DedicatedKeys is false.
+	LocalXPub string `json:"localxpub"`
+	// DedicatedKeys is false for the wallets set up before the multisig
+	// keys were derived apart from the keys of the default account.
+	DedicatedKeys bool `json:"dedicatedkeys"`
+
+	Addresses         []*MultisigAddress `json:"addresses"`
+	NextExternalIndex uint32             `json:"nextexternalindex"`
+	NextInternalIndex uint32             `json:"nextinternalindex"`
+}
+
+// Validate checks that the config describes a valid m-of-n multisig wallet.
+func (cfg *MultisigConfig) Validate() error {
+	n := len(cfg.Cosigners)
+	if n < 2 || n > MaxMultisigCosigners {
+		return errors.E(errors.Invalid, fmt.Sprintf("a multisig wallet must have between 2 and %d cosigners", MaxMultisigCosigners))
+	}
+	if cfg.Required < 1 || cfg.Required > n {
+		return errors.E(errors.Invalid, fmt.Sprintf("required signatures must be between 1 and %d", n))
+	}
+
+	var hasLocal bool
+	seen := make(map[string]bool, n)
+	for _, cosigner := range cfg.Cosigners {
+		if cosigner.XPub == "" {
+			return errors.E(errors.Invalid, "missing cosigner xpub")
+		}
+		if seen[cosigner.XPub] {
+			return errors.E(errors.Invalid, "duplicate cosigner xpub")
+		}
+		seen[cosigner.XPub] = true
+		hasLocal = hasLocal || cosigner.XPub == cfg.LocalXPub
+	}
+	if !hasLocal {
+		return errors.E(errors.Invalid, "the wallet's own xpub is not a cosigner")
+	}
+	return nil
+}
+
+// String returns the m-of-n description of the config.
+func (cfg *MultisigConfig) String() string {
+	return fmt.Sprintf("%d-of-%d", cfg.Required, len(cfg.Cosigners))
+}
+
+// XPubs returns the xpubs of all the cosigners.
+func (cfg *MultisigConfig) XPubs() []string {
+	xpubs := make([]string, 0, len(cfg.Cosigners))
+	for _, cosigner := range cfg.Cosigners {
+		xpubs = append(xpubs, cosigner.XPub)
+	}
+	return xpubs
+}
+
+// AddressInfo returns the imported multisig address matching address, or nil
+// if the address is not part of the wallet.
+func (cfg *MultisigConfig) AddressInfo(address string) *MultisigAddress {
+	for _, addr := range cfg.Addresses {
+		if addr.Address == address {
+			return addr
+		}
+	}
+	return nil
+}
+
+// AddressSet returns the addresses imported by the wallet.
+func (cfg *MultisigConfig) AddressSet() map[string]struct{} {
+	set := make(map[string]struct{}, len(cfg.Addresses))
+	for _, addr := range cfg.Addresses {
+		set[addr.Address] = struct{}{}
+	}
+	return set
+}
+
+// MissingIndexes returns the indexes of branch that must be imported for the
+// wallet to watch MultisigAddressGap addresses past next.
+func (cfg *MultisigConfig) MissingIndexes(branch, next uint32) []uint32 {
+	imported := make(map[uint32]bool)
+	for _, addr := range cfg.Addresses {
+		if addr.Branch == branch {
+			imported[addr.Index] = true
+		}
+	}
+
+	var missing []uint32
+	for index := uint32(0); index < next+MultisigAddressGap; index++ {
+		if !imported[index] {
+			missing = append(missing, index)
+		}
+	}
+	return missing
+}
+
+// MultisigOutput is an output of a multisig transaction proposal.
+type MultisigOutput struct {
+	Address string
+	Amount  int64
+	// IsChange is true if the output pays back to the multisig wallet.
+	IsChange bool
+}
+
+// MultisigProposalInfo describes a multisig transaction proposal shared
+// between the cosigners.
+type MultisigProposalInfo struct {
+	TxID    string
+	Outputs []*MultisigOutput
+	Fee     int64
+	// Signatures is the lowest number of signatures collected by any of the
+	// transaction inputs.
+	Signatures int
+	Required   int
+	// SignedByLocal is true if the wallet has signed the proposal.
+	SignedByLocal bool
+}
+
+// Complete returns true if enough signatures were collected to broadcast the
+// proposal.
+func (info *MultisigProposalInfo) Complete() bool {
+	return info.Signatures >= info.Required
+}
+
+// IsMultisig returns true if the wallet is an m-of-n multisig wallet.
+func (wallet *Wallet) IsMultisig() bool {
+	return wallet.MultisigConfig() != nil
+}
+
+// MultisigConfig returns the multisig config of the wallet or nil if the
+// wallet is not a multisig wallet.
+func (wallet *Wallet) MultisigConfig() *MultisigConfig {
+	cfg := new(MultisigConfig)
+	if err := wallet.ReadUserConfigValue(MultisigConfigKey, cfg); err != nil || cfg.Required == 0 {
+		return nil
+	}
+	return cfg
+}
+
+// MultisigXPub returns the xpub of the wallet's keys dedicated to multisig
+// that is shared with the cosigners, or an empty string if it wasn't derived
+// with DeriveMultisigXPub yet.
+func (wallet *Wallet) MultisigXPub() string {
+	var xpub string
+	if err := wallet.ReadUserConfigValue(MultisigXPubConfigKey, &xpub); err != nil {
+		return ""
+	}
+	return xpub
+}
+
+// SaveMultisigXPub stores the xpub of the wallet's keys dedicated to
+// multisig, which can't be derived again without the private passphrase.
+func (wallet *Wallet) SaveMultisigXPub(xpub string) error {
+	return wallet.walletConfigSave(MultisigXPubConfigKey, xpub)
+}
+
+// SaveMultisigConfig validates and stores the multisig config of the wallet.
+// It fails if the wallet is already a multisig wallet.
+func (wallet *Wallet) SaveMultisigConfig(cfg *MultisigConfig) error {
+	if err := cfg.Validate(); err != nil {
+		return err
+	}
+
+	wallet.multisigMu.Lock()
+	defer wallet.multisigMu.Unlock()
+
+	if wallet.IsMultisig() {
+		return errors.E(errors.Exist, "wallet is already a multisig wallet")
+	}
+	return wallet.walletConfigSave(MultisigConfigKey, cfg)
+}
+
+// UpdateMultisigConfig applies update to the stored multisig config of the
+// wallet. The config is not saved if update returns an error.
+func (wallet *Wallet) UpdateMultisigConfig(update func(cfg *MultisigConfig) error) error {
+	wallet.multisigMu.Lock()
+	defer wallet.multisigMu.Unlock()
+
+	cfg := new(MultisigConfig)
+	if err := wallet.walletConfigRead(MultisigConfigKey, cfg); err != nil {
+		if err == storm.ErrNotFound {
+			return errors.New(utils.ErrNotExist)
+		}
+		return err
+	}
+
+	if err := update(cfg); err != nil {
+		return err
+	}
+	return wallet.walletConfigSave(MultisigConfigKey, cfg)
+}
+
+// SortPubKeys sorts serialized public keys lexicographically as described in
+// BIP67, so that every cosigner derives the same multisig script.
+func SortPubKeys(pubKeys [][]byte) {
+	sort.Slice(pubKeys, func(i, j int) bool {
+		return bytes.Compare(pubKeys[i], pubKeys[j]) < 0
+	})
+}
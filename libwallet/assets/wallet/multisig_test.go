@@ -0,0 +1,64 @@
+package wallet
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestMultisigConfigValidate(t *testing.T) {
+	cosigners := []*Cosigner{{Name: "a", XPub: "xpubA"}, {Name: "b", XPub: "xpubB"}, {Name: "c", XPub: "xpubC"}}
+	tests := []struct {
+		name    string
+		cfg     *MultisigConfig
+		wantErr bool
+	}{
+		{"2-of-3", &MultisigConfig{Required: 2, Cosigners: cosigners, LocalXPub: "xpubA"}, false},
+		{"3-of-3", &MultisigConfig{Required: 3, Cosigners: cosigners, LocalXPub: "xpubC"}, false},
+		{"too many required", &MultisigConfig{Required: 4, Cosigners: cosigners, LocalXPub: "xpubA"}, true},
+		{"zero required", &MultisigConfig{Required: 0, Cosigners: cosigners, LocalXPub: "xpubA"}, true},
+		{"single cosigner", &MultisigConfig{Required: 1, Cosigners: cosigners[:1], LocalXPub: "xpubA"}, true},
+		{"local missing", &MultisigConfig{Required: 2, Cosigners: cosigners, LocalXPub: "xpubD"}, true},
+		{"duplicate xpub", &MultisigConfig{Required: 2, Cosigners: append([]*Cosigner{{Name: "d", XPub: "xpubA"}}, cosigners...), LocalXPub: "xpubA"}, true},
+	}
+
+	for _, test := range tests {
+		err := test.cfg.Validate()
+		if (err != nil) != test.wantErr {
+			t.Errorf("%s: unexpected error %v", test.name, err)
+		}
+	}
+}
+
+func TestMultisigConfigMissingIndexes(t *testing.T) {
+	cfg := &MultisigConfig{
+		Addresses: []*MultisigAddress{
+			{Branch: MultisigExternalBranch, Index: 0},
+			{Branch: MultisigExternalBranch, Index: 2},
+			{Branch: MultisigInternalBranch, Index: 1},
+		},
+	}
+
+	missing := cfg.MissingIndexes(MultisigExternalBranch, 1)
+	if len(missing) != int(MultisigAddressGap)-1 {
+		t.Fatalf("expected %d missing indexes, got %d", MultisigAddressGap-1, len(missing))
+	}
+	for _, index := range missing {
+		if index == 0 || index == 2 {
+			t.Fatalf("imported index %d reported as missing", index)
+		}
+	}
+	if missing[0] != 1 || missing[len(missing)-1] != MultisigAddressGap {
+		t.Fatalf("unexpected missing range %d..%d", missing[0], missing[len(missing)-1])
+	}
+}
+
+func TestSortPubKeys(t *testing.T) {
+	keys := [][]byte{{0x03, 0x02}, {0x02, 0xff}, {0x03, 0x01}}
+	SortPubKeys(keys)
+	want := [][]byte{{0x02, 0xff}, {0x03, 0x01}, {0x03, 0x02}}
+	for i := range keys {
+		if !bytes.Equal(keys[i], want[i]) {
+			t.Fatalf("key %d: got %x, want %x", i, keys[i], want[i])
+		}
+	}
+}
@@ -0,0 +1,125 @@
+package wallet
+
+import (
+	"net"
+	"strings"
+
+	"decred.org/dcrwallet/v4/errors"
+	"github.com/crypto-power/cryptopower/libwallet/utils"
+)
+
+// PeerHost returns the host of the peer at addr, which bans apply to so that
+// a banned peer can't reconnect from another port.
+func PeerHost(addr string) string {
+	if host, _, err := net.SplitHostPort(addr); err == nil {
+		return host
+	}
+	return addr
+}
+
+// peersInclude returns true if peers includes a peer with the host of addr
+// if byHost is set, or addr itself otherwise.
+func peersInclude(peers []string, addr string, byHost bool) bool {
+	for _, peer := range peers {
+		if peer == addr || (byHost && PeerHost(peer) == PeerHost(addr)) {
+			return true
+		}
+	}
+	return false
+}
+
+// updatePeers adds addr to peers or removes it from them.
+func updatePeers(peers []string, addr string, include bool) []string {
+	updated := make([]string, 0, len(peers)+1)
+	for _, peer := range peers {
+		if peer != addr {
+			updated = append(updated, peer)
+		}
+	}
+	if include {
+		updated = append(updated, addr)
+	}
+	return updated
+}
+
+// BannedPeers returns the hosts of the peers the wallet refuses to connect
+// to.
+func (wallet *Wallet) BannedPeers() []string {
+	var peers []string
+	_ = wallet.ReadUserConfigValue(SpvBannedPeersConfigKey, &peers)
+	return peers
+}
+
+// IsPeerBanned returns true if the host of the peer at addr is banned.
+func (wallet *Wallet) IsPeerBanned(addr string) bool {
+	return peersInclude(wallet.BannedPeers(), addr, true)
+}
+
+// CheckPeerAllowed returns an error if the wallet must not connect to the
+// peer at addr because it is banned.
+func (wallet *Wallet) CheckPeerAllowed(addr string) error {
+	if wallet.IsPeerBanned(addr) {
+		return errors.New(utils.ErrPeerBanned)
+	}
+	return nil
+}
+
+// SetPeerBanned bans or unbans the host of the peer at addr. A banned host
+// is no longer a preferred peer.
+func (wallet *Wallet) SetPeerBanned(addr string, banned bool) error {
+	host := PeerHost(addr)
+	if host == "" {
+		return errors.New(utils.ErrInvalidPeers)
+	}
+	if banned {
+		preferred := wallet.PreferredPeers()
+		for _, peer := range preferred {
+			if PeerHost(peer) == host {
+				preferred = updatePeers(preferred, peer, false)
+			}
+		}
+		if err := wallet.walletConfigSave(SpvPreferredPeersConfigKey, preferred); err != nil {
+			return err
+		}
+	}
+	return wallet.walletConfigSave(SpvBannedPeersConfigKey, updatePeers(wallet.BannedPeers(), host, banned))
+}
+
+// PreferredPeers returns the host:port of the peers the wallet favours
+// connecting to.
+func (wallet *Wallet) PreferredPeers() []string {
+	var peers []string
+	_ = wallet.ReadUserConfigValue(SpvPreferredPeersConfigKey, &peers)
+	return peers
+}
+
+// IsPeerPreferred returns true if the peer at addr is a preferred peer.
+func (wallet *Wallet) IsPeerPreferred(addr string) bool {
+	return peersInclude(wallet.PreferredPeers(), addr, false)
+}
+
+// SetPeerPreferred adds the peer at addr, a host:port, to the preferred
+// peers or removes it from them. Banned peers can't be preferred.
+func (wallet *Wallet) SetPeerPreferred(addr string, preferred bool) error {
+	if _, _, err := net.SplitHostPort(addr); err != nil {
+		return errors.New(utils.ErrInvalidPeers)
+	}
+	if preferred && wallet.IsPeerBanned(addr) {
+		return errors.New(utils.ErrPeerBanned)
+	}
+	return wallet.walletConfigSave(SpvPreferredPeersConfigKey, updatePeers(wallet.PreferredPeers(), addr, preferred))
+}
+
+// PeerAddressWithPort returns addr with defaultPort added if it has no port,
+// or an error if it is not a valid peer address.
+func PeerAddressWithPort(addr, defaultPort string) (string, error) {
+	addr = strings.TrimSpace(addr)
+	if strings.Contains(addr, ";") {
+		return "", errors.New(utils.ErrInvalidPeers)
+	}
+	peers, errs := ParseWalletPeers(addr, defaultPort)
+	if len(errs) > 0 || len(peers) != 1 {
+		return "", errors.New(utils.ErrInvalidPeers)
+	}
+	return peers[0], nil
+}
@@ -0,0 +1,54 @@
+package wallet
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestPeerHost(t *testing.T) {
+	tests := map[string]string{
+		"10.0.0.1:9108":   "10.0.0.1",
+		"[::1]:8333":      "::1",
+		"node.example":    "node.example",
+		"node.example:99": "node.example",
+	}
+	for addr, want := range tests {
+		if got := PeerHost(addr); got != want {
+			t.Errorf("PeerHost(%q) = %q, want %q", addr, got, want)
+		}
+	}
+}
+
+func TestUpdatePeers(t *testing.T) {
+	peers := updatePeers(nil, "10.0.0.1:9108", true)
+	peers = updatePeers(peers, "10.0.0.2:9108", true)
+	peers = updatePeers(peers, "10.0.0.1:9108", true)
+	if want := []string{"10.0.0.2:9108", "10.0.0.1:9108"}; !reflect.DeepEqual(peers, want) {
+		t.Fatalf("updatePeers = %v, want %v", peers, want)
+	}
+
+	if !peersInclude(peers, "10.0.0.1:18333", true) {
+		t.Fatal("expected a peer with the same host to be included by host")
+	}
+	if peersInclude(peers, "10.0.0.1:18333", false) {
+		t.Fatal("expected a peer on another port not to be included")
+	}
+
+	peers = updatePeers(peers, "10.0.0.2:9108", false)
+	if want := []string{"10.0.0.1:9108"}; !reflect.DeepEqual(peers, want) {
+		t.Fatalf("updatePeers = %v, want %v", peers, want)
+	}
+}
+
+func TestPeerAddressWithPort(t *testing.T) {
+	addr, err := PeerAddressWithPort(" 10.0.0.1 ", "9108")
+	if err != nil || addr != "10.0.0.1:9108" {
+		t.Fatalf("PeerAddressWithPort = %q, %v", addr, err)
+	}
+	if _, err := PeerAddressWithPort("10.0.0.1;10.0.0.2", "9108"); err == nil {
+		t.Fatal("expected an error for several peers")
+	}
+	if _, err := PeerAddressWithPort("", "9108"); err == nil {
+		t.Fatal("expected an error for an empty address")
+	}
+}
@@ -0,0 +1,19 @@
+package wallet
+
+// SweepResult is a transaction sweeping the funds of a private key, such as
+// the key of a paper wallet, into the wallet. It is signed but only published
+// once the amount and fee are confirmed.
+type SweepResult struct {
+	TxHash string
+	// Account is the wallet account the funds are swept to.
+	Account int32
+	// Address is the wallet address the funds are swept to.
+	Address string
+	// Amount is the amount received by the wallet, after the fee.
+	Amount AssetAmount
+	Fee    AssetAmount
+	// Inputs is the number of outputs of the key that are spent.
+	Inputs int
+	// SignedTx is the serialized signed transaction.
+	SignedTx []byte
+}
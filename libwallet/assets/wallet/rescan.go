@@ -0,0 +1,86 @@
+package wallet
+
+import "github.com/asdine/storm"
+
+// RescanState is a rescan of the blockchain requested by the user. It is saved
+// as the rescan progresses so that an interrupted rescan resumes where it
+// stopped the next time the wallet syncs.
+type RescanState struct {
+	// StartHeight is the height the rescan was requested from.
+	StartHeight int32 `json:"startHeight"`
+	// ScannedThrough is the height the rescan got to.
+	ScannedThrough int32 `json:"scannedThrough"`
+	// Addresses restricts the rescan to these addresses, if any.
+	Addresses []string `json:"addresses,omitempty"`
+}
+
+// Advance moves the rescan to the height the wallet is synced to while it
+// rescans. The rescan is finished once the wallet is synced back to the best
+// block when it started, it otherwise progressed if it got past the height
+// it last scanned through.
+func (state *RescanState) Advance(syncedTo, bestBlockHeight int32) (finished, progressed bool) {
+	if syncedTo >= bestBlockHeight {
+		return true, false
+	}
+	if syncedTo > state.ScannedThrough {
+		state.ScannedThrough = syncedTo
+		return false, true
+	}
+	return false, false
+}
+
+// PendingRescan returns the rescan of the wallet that is in progress or was
+// interrupted, or nil if there is none.
+func (wallet *Wallet) PendingRescan() *RescanState {
+	state := new(RescanState)
+	if err := wallet.ReadUserConfigValue(RescanStateConfigKey, state); err != nil {
+		return nil
+	}
+	return state
+}
+
+// SavePendingRescan saves the progress of a rescan.
+func (wallet *Wallet) SavePendingRescan(state *RescanState) {
+	if err := wallet.walletConfigSave(RescanStateConfigKey, state); err != nil {
+		log.Errorf("Error saving the rescan progress of wallet %d: %v", wallet.ID, err)
+	}
+}
+
+// ClearPendingRescan forgets the rescan of the wallet once it is complete or
+// cancelled.
+func (wallet *Wallet) ClearPendingRescan() {
+	if err := wallet.walletConfigDelete(RescanStateConfigKey); err != nil && err != storm.ErrNotFound {
+		log.Errorf("Error clearing the rescan progress of wallet %d: %v", wallet.ID, err)
+	}
+}
+
+// QueuePendingRescan saves a rescan that couldn't start so that it runs once
+// the wallet is synced again. It is merged with the pending rescan, if any, so
+// that neither is lost.
+func (wallet *Wallet) QueuePendingRescan(state *RescanState) {
+	if pending := wallet.PendingRescan(); pending != nil {
+		state = mergeRescans(pending, state)
+	}
+	wallet.SavePendingRescan(state)
+}
+
+// mergeRescans returns a rescan covering the blocks and addresses of both a
+// and b.
+func mergeRescans(a, b *RescanState) *RescanState {
+	merged := &RescanState{
+		StartHeight:    min(a.StartHeight, b.StartHeight),
+		ScannedThrough: min(a.ScannedThrough, b.ScannedThrough),
+	}
+	// A rescan for every address covers the addresses of the other.
+	if len(a.Addresses) == 0 || len(b.Addresses) == 0 {
+		return merged
+	}
+	seen := make(map[string]bool, len(a.Addresses)+len(b.Addresses))
+	for _, addr := range append(append([]string{}, a.Addresses...), b.Addresses...) {
+		if !seen[addr] {
+			seen[addr] = true
+			merged.Addresses = append(merged.Addresses, addr)
+		}
+	}
+	return merged
+}
@@ -0,0 +1,53 @@
+package wallet
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestMergeRescans(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b *RescanState
+		want *RescanState
+	}{{
+		name: "addresses",
+		a:    &RescanState{StartHeight: 100, ScannedThrough: 150, Addresses: []string{"a", "b"}},
+		b:    &RescanState{StartHeight: 120, ScannedThrough: 120, Addresses: []string{"b", "c"}},
+		want: &RescanState{StartHeight: 100, ScannedThrough: 120, Addresses: []string{"a", "b", "c"}},
+	}, {
+		name: "every address",
+		a:    &RescanState{StartHeight: 10, ScannedThrough: 500},
+		b:    &RescanState{StartHeight: 200, ScannedThrough: 200, Addresses: []string{"a"}},
+		want: &RescanState{StartHeight: 10, ScannedThrough: 200},
+	}}
+
+	for _, test := range tests {
+		if got := mergeRescans(test.a, test.b); !reflect.DeepEqual(got, test.want) {
+			t.Errorf("%s: got %+v, want %+v", test.name, got, test.want)
+		}
+	}
+}
+
+func TestRescanStateAdvance(t *testing.T) {
+	state := &RescanState{StartHeight: 100, ScannedThrough: 100}
+	tests := []struct {
+		syncedTo             int32
+		finished, progressed bool
+		scannedThrough       int32
+	}{
+		// The wallet isn't rewound to the start of the rescan yet.
+		{50, false, false, 100},
+		{180, false, true, 180},
+		{150, false, false, 180},
+		{500, true, false, 180},
+	}
+
+	for _, test := range tests {
+		finished, progressed := state.Advance(test.syncedTo, 500)
+		if finished != test.finished || progressed != test.progressed || state.ScannedThrough != test.scannedThrough {
+			t.Fatalf("synced to %d: got finished %v, progressed %v, scanned through %d", test.syncedTo,
+				finished, progressed, state.ScannedThrough)
+		}
+	}
+}
@@ -0,0 +1,120 @@
+package wallet
+
+import (
+	"encoding/pem"
+	"fmt"
+	"strings"
+
+	"decred.org/dcrwallet/v4/errors"
+	"github.com/crypto-power/cryptopower/libwallet/utils"
+)
+
+// Full node implementations a wallet can sync from over RPC.
+const (
+	RPCNodeDcrd      = "dcrd"
+	RPCNodeBtcd      = "btcd"
+	RPCNodeBitcoind  = "bitcoind"
+	RPCNodeLtcd      = "ltcd"
+	RPCNodeLitecoind = "litecoind"
+)
+
+// RPCNodeTypes returns the full node implementations the wallets of
+// assetType can sync from.
+func RPCNodeTypes(assetType utils.AssetType) []string {
+	switch assetType {
+	case utils.DCRWalletAsset:
+		return []string{RPCNodeDcrd}
+	case utils.BTCWalletAsset:
+		return []string{RPCNodeBtcd, RPCNodeBitcoind}
+	case utils.LTCWalletAsset:
+		return []string{RPCNodeLtcd, RPCNodeLitecoind}
+	default:
+		return nil
+	}
+}
+
+// RPCConfig holds the connection settings of the trusted full node a wallet
+// syncs from instead of SPV. Sync, fee estimation and broadcasting then go
+// through the node.
+type RPCConfig struct {
+	// Host is the host:port of the node RPC server. The default RPC port of
+	// the network is used if the port is missing.
+	Host     string `json:"host"`
+	User     string `json:"user"`
+	Password string `json:"password"`
+	// Certificate is the PEM encoded TLS certificate of the RPC server. The
+	// system certificates are used if it is empty.
+	Certificate string `json:"certificate"`
+	NodeType    string `json:"nodetype"`
+	// TLSProxy connects to bitcoind or litecoind over TLS through a TLS
+	// terminating proxy in front of the node, as they only serve RPC over
+	// plain HTTP. The other nodes always use TLS.
+	TLSProxy bool `json:"tlsproxy"`
+}
+
+// Validate checks that cfg describes a node the wallets of assetType can
+// sync from.
+func (cfg *RPCConfig) Validate(assetType utils.AssetType) error {
+	if strings.TrimSpace(cfg.Host) == "" {
+		return errors.E(errors.Invalid, "the RPC host is required")
+	}
+	if cfg.User == "" || cfg.Password == "" {
+		return errors.E(errors.Invalid, "the RPC user and password are required")
+	}
+
+	supported := false
+	for _, nodeType := range RPCNodeTypes(assetType) {
+		supported = supported || nodeType == cfg.NodeType
+	}
+	if !supported {
+		return errors.E(errors.Invalid, fmt.Sprintf("unsupported node type %q for %s wallets", cfg.NodeType, assetType))
+	}
+
+	if cfg.Certificate == "" {
+		return nil
+	}
+	if !cfg.UsesTLS() {
+		return errors.E(errors.Invalid, fmt.Sprintf("%s doesn't serve RPC over TLS, a certificate needs a TLS proxy", cfg.NodeType))
+	}
+	if block, _ := pem.Decode([]byte(cfg.Certificate)); block == nil {
+		return errors.E(errors.Invalid, "the RPC certificate is not PEM encoded")
+	}
+	return nil
+}
+
+// UsesTLS returns true if the RPC server of the node is connected to over
+// TLS.
+func (cfg *RPCConfig) UsesTLS() bool {
+	return !cfg.NeedsTLSProxy() || cfg.TLSProxy
+}
+
+// NeedsTLSProxy returns true if the node only serves RPC over plain HTTP and
+// can only be reached over TLS through a proxy.
+func (cfg *RPCConfig) NeedsTLSProxy() bool {
+	return cfg.NodeType == RPCNodeBitcoind || cfg.NodeType == RPCNodeLitecoind
+}
+
+// RPCConfig returns the settings of the full node the wallet syncs from, or
+// nil if the wallet syncs using SPV.
+func (wallet *Wallet) RPCConfig() *RPCConfig {
+	var cfg *RPCConfig
+	_ = wallet.ReadUserConfigValue(RPCSyncConfigKey, &cfg)
+	return cfg
+}
+
+// SetRPCConfig validates and stores the settings of the full node the wallet
+// syncs from, replacing any Electrum servers set with SetElectrumConfig. A nil
+// cfg switches the wallet back to SPV. The new settings apply from the next
+// sync.
+func (wallet *Wallet) SetRPCConfig(cfg *RPCConfig) error {
+	if cfg == nil {
+		return wallet.walletConfigDelete(RPCSyncConfigKey)
+	}
+	if err := cfg.Validate(wallet.Type); err != nil {
+		return err
+	}
+	if err := wallet.walletConfigDelete(ElectrumSyncConfigKey); err != nil {
+		return err
+	}
+	return wallet.walletConfigSave(RPCSyncConfigKey, cfg)
+}
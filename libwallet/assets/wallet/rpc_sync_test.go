@@ -0,0 +1,37 @@
+package wallet
+
+import (
+	"testing"
+
+	"github.com/crypto-power/cryptopower/libwallet/utils"
+)
+
+const testCertificate = `-----BEGIN CERTIFICATE-----
+MIIBszCCAVmgAwIBAgIRAKCnTUhGTGxY
+-----END CERTIFICATE-----`
+
+func TestRPCConfigValidate(t *testing.T) {
+	tests := []struct {
+		name      string
+		assetType utils.AssetType
+		cfg       RPCConfig
+		wantErr   bool
+	}{
+		{"dcrd", utils.DCRWalletAsset, RPCConfig{"127.0.0.1", "user", "pass", testCertificate, RPCNodeDcrd, false}, false},
+		{"btcd without certificate", utils.BTCWalletAsset, RPCConfig{"node.example", "user", "pass", "", RPCNodeBtcd, false}, false},
+		{"litecoind", utils.LTCWalletAsset, RPCConfig{"127.0.0.1:9332", "user", "pass", "", RPCNodeLitecoind, false}, false},
+		{"missing host", utils.DCRWalletAsset, RPCConfig{" ", "user", "pass", "", RPCNodeDcrd, false}, true},
+		{"missing password", utils.BTCWalletAsset, RPCConfig{"127.0.0.1", "user", "", "", RPCNodeBtcd, false}, true},
+		{"node of another asset", utils.BTCWalletAsset, RPCConfig{"127.0.0.1", "user", "pass", "", RPCNodeLtcd, false}, true},
+		{"bitcoind with certificate", utils.BTCWalletAsset, RPCConfig{"127.0.0.1", "user", "pass", testCertificate, RPCNodeBitcoind, false}, true},
+		{"bitcoind behind a TLS proxy", utils.BTCWalletAsset, RPCConfig{"127.0.0.1", "user", "pass", testCertificate, RPCNodeBitcoind, true}, false},
+		{"invalid certificate", utils.DCRWalletAsset, RPCConfig{"127.0.0.1", "user", "pass", "not a certificate", RPCNodeDcrd, false}, true},
+	}
+
+	for _, test := range tests {
+		err := test.cfg.Validate(test.assetType)
+		if (err != nil) != test.wantErr {
+			t.Errorf("%s: got error %v, want error %v", test.name, err, test.wantErr)
+		}
+	}
+}
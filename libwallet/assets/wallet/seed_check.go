@@ -0,0 +1,90 @@
+package wallet
+
+import (
+	"math/rand"
+	"sort"
+	"strings"
+	"time"
+
+	"decred.org/dcrwallet/v4/errors"
+	"github.com/crypto-power/cryptopower/libwallet/utils"
+)
+
+// SeedCheckWords is the number of seed words a seed knowledge check asks for.
+const SeedCheckWords = 4
+
+// SeedCheckPositions returns the positions, starting at 1, of SeedCheckWords
+// words of the wallet seed picked at random for a seed knowledge check.
+func (wallet *Wallet) SeedCheckPositions(privpass string) ([]int, error) {
+	wallet.mu.RLock()
+	defer wallet.mu.RUnlock()
+
+	if wallet.EncryptedMnemonic == nil {
+		return nil, errors.New(utils.ErrNoSeed)
+	}
+	mnemonic, err := decryptWalletMnemonic([]byte(privpass), wallet.EncryptedMnemonic)
+	if err != nil {
+		return nil, err
+	}
+
+	positions := rand.Perm(len(strings.Fields(mnemonic)))[:SeedCheckWords]
+	for i := range positions {
+		positions[i]++
+	}
+	sort.Ints(positions)
+	return positions, nil
+}
+
+// VerifySeedWords checks the words of the wallet seed at the positions,
+// starting at 1, they are keyed by. A successful check is recorded as the
+// last seed check of the wallet.
+func (wallet *Wallet) VerifySeedWords(words map[int]string, privpass string) (bool, error) {
+	wallet.mu.RLock()
+	defer wallet.mu.RUnlock()
+
+	if wallet.EncryptedMnemonic == nil {
+		return false, errors.New(utils.ErrNoSeed)
+	}
+	mnemonic, err := decryptWalletMnemonic([]byte(privpass), wallet.EncryptedMnemonic)
+	if err != nil {
+		return false, err
+	}
+
+	if !seedWordsMatch(mnemonic, words) {
+		return false, errors.New(utils.ErrInvalid)
+	}
+	wallet.recordSeedCheck()
+	return true, nil
+}
+
+// seedWordsMatch returns true if every word matches the word of mnemonic at
+// its position.
+func seedWordsMatch(mnemonic string, words map[int]string) bool {
+	seedWords := strings.Fields(mnemonic)
+	if len(words) == 0 {
+		return false
+	}
+	for position, word := range words {
+		if position < 1 || position > len(seedWords) ||
+			!strings.EqualFold(strings.TrimSpace(word), seedWords[position-1]) {
+			return false
+		}
+	}
+	return true
+}
+
+// LastSeedCheck returns when the user last proved they have the wallet seed,
+// either by verifying the whole seed or a seed knowledge check. It is the
+// zero time if they never did.
+func (wallet *Wallet) LastSeedCheck() time.Time {
+	lastCheck := wallet.ReadLongConfigValueForKey(LastSeedCheckConfigKey, 0)
+	if lastCheck == 0 {
+		return time.Time{}
+	}
+	return time.Unix(lastCheck, 0)
+}
+
+func (wallet *Wallet) recordSeedCheck() {
+	wallet.SetLongConfigValueForKey(LastSeedCheckConfigKey, time.Now().Unix())
+	wallet.SetLongConfigValueForKey(SeedCheckSnoozedUntilConfigKey, 0)
+}
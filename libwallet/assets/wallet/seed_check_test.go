@@ -0,0 +1,25 @@
+package wallet
+
+import "testing"
+
+func TestSeedWordsMatch(t *testing.T) {
+	mnemonic := "abandon ability able about above absent absorb abstract absurd abuse access accident"
+	tests := []struct {
+		name  string
+		words map[int]string
+		want  bool
+	}{
+		{"match", map[int]string{1: "abandon", 5: "above", 12: "accident"}, true},
+		{"case and spaces", map[int]string{2: " Ability "}, true},
+		{"wrong word", map[int]string{1: "abandon", 3: "about"}, false},
+		{"out of range", map[int]string{13: "accident"}, false},
+		{"zero position", map[int]string{0: "abandon"}, false},
+		{"no words", nil, false},
+	}
+
+	for _, test := range tests {
+		if got := seedWordsMatch(mnemonic, test.words); got != test.want {
+			t.Errorf("%s: got %v, want %v", test.name, got, test.want)
+		}
+	}
+}
@@ -0,0 +1,278 @@
+package wallet
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"strings"
+
+	"decred.org/dcrwallet/v4/errors"
+	"decred.org/dcrwallet/v4/walletseed"
+	"github.com/crypto-power/cryptopower/libwallet/assets/wallet/wordlist"
+	"github.com/crypto-power/cryptopower/libwallet/internal/shamir"
+	"github.com/tyler-smith/go-bip39"
+)
+
+// Seed shares split a wallet seed so that any threshold of them restore it
+// while fewer reveal nothing about it. A share is encoded with the BIP39
+// word list as:
+//
+//	version (4 bits) | seed type (4 bits)
+//	identifier (16 bits), the same for all the shares of a seed
+//	threshold - 1 (4 bits) | share index (4 bits)
+//	share value (16 or 32 bytes, the size of the seed)
+//	checksum (32 bits), the first bytes of the SHA256 of the above
+//
+// 12-word seeds give 18-word shares, 24 and 33-word seeds give 30-word shares.
+//
+// This format is specific to this wallet. It is not SLIP-39 and the shares
+// don't interoperate with wallets implementing it.
+
+const (
+	// MaxSeedShares is the maximum number of shares a seed can be split
+	// into.
+	MaxSeedShares = 16
+
+	seedShareVersion      = 1
+	seedShareHeaderSize   = 4
+	seedShareChecksumSize = 4
+	seedShareWordBits     = 11
+)
+
+// SeedShare is a decoded seed share.
+type SeedShare struct {
+	ID        uint16
+	Threshold int
+	// Index is the position of the share, starting at 1.
+	Index    int
+	SeedType WordSeedType
+
+	value []byte
+}
+
+func seedTypeCode(seedType WordSeedType) byte {
+	switch seedType {
+	case WordSeed12:
+		return 1
+	case WordSeed24:
+		return 2
+	case WordSeed33:
+		return 3
+	default:
+		return 0
+	}
+}
+
+func seedTypeFromCode(code byte) WordSeedType {
+	switch code {
+	case 1:
+		return WordSeed12
+	case 2:
+		return WordSeed24
+	case 3:
+		return WordSeed33
+	default:
+		return NoneWordSeed
+	}
+}
+
+// seedEntropySize returns the size of the entropy encoded by the seeds of
+// seedType.
+func seedEntropySize(seedType WordSeedType) int {
+	if seedType == WordSeed12 {
+		return 16
+	}
+	return 32
+}
+
+// seedEntropy returns the entropy encoded by a mnemonic of seedType.
+func seedEntropy(seedMnemonic string, seedType WordSeedType) ([]byte, error) {
+	seedMnemonic = strings.Join(strings.Fields(seedMnemonic), " ")
+	var entropy []byte
+	var err error
+	switch seedType {
+	case WordSeed12, WordSeed24:
+		entropy, err = bip39.EntropyFromMnemonic(seedMnemonic)
+	case WordSeed33:
+		entropy, err = walletseed.DecodeUserInput(seedMnemonic)
+	default:
+		return nil, errors.E(errors.Invalid, "unsupported seed type")
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(entropy) != seedEntropySize(seedType) {
+		return nil, errors.E(errors.Invalid, "the seed doesn't match its type")
+	}
+	return entropy, nil
+}
+
+// seedMnemonic encodes entropy as a mnemonic of seedType.
+func seedMnemonic(entropy []byte, seedType WordSeedType) (string, error) {
+	if seedType == WordSeed33 {
+		return walletseed.EncodeMnemonic(entropy), nil
+	}
+	return bip39.NewMnemonic(entropy)
+}
+
+// SplitSeed splits the seed mnemonic of seedType into the given number of
+// shares, any threshold of which restore the seed with CombineSeedShares.
+func SplitSeed(seedMnemonic string, seedType WordSeedType, threshold, shares int) ([]string, error) {
+	if threshold < 2 || threshold > shares || shares > MaxSeedShares {
+		return nil, errors.E(errors.Invalid, "the threshold must be at least 2 and at most the number of shares")
+	}
+
+	entropy, err := seedEntropy(seedMnemonic, seedType)
+	if err != nil {
+		return nil, err
+	}
+
+	values, err := shamir.Split(entropy, threshold, shares)
+	if err != nil {
+		return nil, err
+	}
+
+	var id [2]byte
+	if _, err := rand.Read(id[:]); err != nil {
+		return nil, err
+	}
+
+	encoded := make([]string, len(values))
+	for i, value := range values {
+		encoded[i] = encodeSeedShare(&SeedShare{
+			ID:        binary.BigEndian.Uint16(id[:]),
+			Threshold: threshold,
+			Index:     i + 1,
+			SeedType:  seedType,
+			value:     value,
+		})
+	}
+	return encoded, nil
+}
+
+func encodeSeedShare(share *SeedShare) string {
+	data := []byte{seedShareVersion<<4 | seedTypeCode(share.SeedType)}
+	data = binary.BigEndian.AppendUint16(data, share.ID)
+	data = append(data, byte(share.Threshold-1)<<4|byte(share.Index-1))
+	data = append(data, share.value...)
+	checksum := sha256.Sum256(data)
+	data = append(data, checksum[:seedShareChecksumSize]...)
+
+	words := wordlist.BIP39WordList()
+	numWords := (len(data)*8 + seedShareWordBits - 1) / seedShareWordBits
+	encoded := make([]string, numWords)
+	for i := range encoded {
+		var index int
+		for bit := i * seedShareWordBits; bit < (i+1)*seedShareWordBits; bit++ {
+			index <<= 1
+			if bit < len(data)*8 && data[bit/8]&(0x80>>(bit%8)) != 0 {
+				index |= 1
+			}
+		}
+		encoded[i] = words[index]
+	}
+	return strings.Join(encoded, " ")
+}
+
+// DecodeSeedShare decodes a share created by SplitSeed and verifies its
+// checksum.
+func DecodeSeedShare(share string) (*SeedShare, error) {
+	invalid := errors.E(errors.Invalid, "invalid seed share")
+
+	wordIndexes := make(map[string]int)
+	for i, word := range wordlist.BIP39WordList() {
+		wordIndexes[word] = i
+	}
+
+	fields := strings.Fields(strings.ToLower(share))
+	bits := make([]bool, 0, len(fields)*seedShareWordBits)
+	for _, word := range fields {
+		index, ok := wordIndexes[word]
+		if !ok {
+			return nil, errors.E(errors.Invalid, "invalid seed share word "+word)
+		}
+		for bit := seedShareWordBits - 1; bit >= 0; bit-- {
+			bits = append(bits, index&(1<<bit) != 0)
+		}
+	}
+
+	data := make([]byte, len(bits)/8)
+	for i, set := range bits[:len(data)*8] {
+		if set {
+			data[i/8] |= 0x80 >> (i % 8)
+		}
+	}
+	if len(data) == 0 || data[0]>>4 != seedShareVersion {
+		return nil, invalid
+	}
+
+	seedType := seedTypeFromCode(data[0] & 0x0f)
+	if seedType == NoneWordSeed {
+		return nil, invalid
+	}
+	size := seedShareHeaderSize + seedEntropySize(seedType) + seedShareChecksumSize
+	numWords := (size*8 + seedShareWordBits - 1) / seedShareWordBits
+	if len(fields) != numWords {
+		return nil, invalid
+	}
+	// The padding bits must be unset.
+	for _, set := range bits[size*8:] {
+		if set {
+			return nil, invalid
+		}
+	}
+
+	payload := data[:size-seedShareChecksumSize]
+	checksum := sha256.Sum256(payload)
+	if !bytes.Equal(checksum[:seedShareChecksumSize], data[size-seedShareChecksumSize:size]) {
+		return nil, errors.E(errors.Invalid, "invalid seed share checksum")
+	}
+
+	return &SeedShare{
+		ID:        binary.BigEndian.Uint16(payload[1:3]),
+		Threshold: int(payload[3]>>4) + 1,
+		Index:     int(payload[3]&0x0f) + 1,
+		SeedType:  seedType,
+		value:     payload[seedShareHeaderSize:],
+	}, nil
+}
+
+// CombineSeedShares restores the seed mnemonic from at least the threshold
+// number of shares of the same seed.
+func CombineSeedShares(shares []string) (string, WordSeedType, error) {
+	if len(shares) == 0 {
+		return "", NoneWordSeed, errors.E(errors.Invalid, "no seed shares")
+	}
+
+	var first *SeedShare
+	xs := make([]byte, 0, len(shares))
+	values := make([][]byte, 0, len(shares))
+	for _, encoded := range shares {
+		share, err := DecodeSeedShare(encoded)
+		if err != nil {
+			return "", NoneWordSeed, err
+		}
+		if first == nil {
+			first = share
+		} else if share.ID != first.ID || share.Threshold != first.Threshold || share.SeedType != first.SeedType {
+			return "", NoneWordSeed, errors.E(errors.Invalid, "the seed shares belong to different seeds")
+		}
+		xs = append(xs, byte(share.Index))
+		values = append(values, share.value)
+	}
+
+	if len(shares) < first.Threshold {
+		return "", NoneWordSeed, errors.E(errors.Invalid, "not enough seed shares")
+	}
+
+	entropy, err := shamir.Combine(xs, values)
+	if err != nil {
+		return "", NoneWordSeed, errors.E(errors.Invalid, err)
+	}
+	mnemonic, err := seedMnemonic(entropy, first.SeedType)
+	if err != nil {
+		return "", NoneWordSeed, err
+	}
+	return mnemonic, first.SeedType, nil
+}
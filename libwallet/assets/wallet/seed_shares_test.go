@@ -0,0 +1,83 @@
+package wallet
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSeedShares(t *testing.T) {
+	tests := []struct {
+		seedType WordSeedType
+		words    int
+	}{
+		{WordSeed12, 18},
+		{WordSeed24, 30},
+		{WordSeed33, 30},
+	}
+
+	for _, test := range tests {
+		seed, err := generateMnemonic(test.seedType)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		shares, err := SplitSeed(seed, test.seedType, 3, 5)
+		if err != nil {
+			t.Fatalf("%d-word seed: %v", test.seedType, err)
+		}
+		for _, share := range shares {
+			if n := len(strings.Fields(share)); n != test.words {
+				t.Fatalf("%d-word seed: got %d-word share, want %d words", test.seedType, n, test.words)
+			}
+		}
+
+		restored, seedType, err := CombineSeedShares([]string{shares[4], shares[0], shares[2]})
+		if err != nil {
+			t.Fatalf("%d-word seed: %v", test.seedType, err)
+		}
+		if restored != seed || seedType != test.seedType {
+			t.Fatalf("%d-word seed: restored %q (%d words), want %q", test.seedType, restored, seedType, seed)
+		}
+
+		if _, _, err := CombineSeedShares(shares[:2]); err == nil {
+			t.Fatalf("%d-word seed: restored from fewer shares than the threshold", test.seedType)
+		}
+	}
+}
+
+func TestDecodeSeedShare(t *testing.T) {
+	seed, err := generateMnemonic(WordSeed12)
+	if err != nil {
+		t.Fatal(err)
+	}
+	shares, err := SplitSeed(seed, WordSeed12, 2, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	share, err := DecodeSeedShare(shares[1])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if share.Index != 2 || share.Threshold != 2 || share.SeedType != WordSeed12 {
+		t.Fatalf("unexpected share %+v", share)
+	}
+
+	// Swapping two words breaks the checksum.
+	words := strings.Fields(shares[1])
+	words[4], words[5] = words[5], words[4]
+	if words[4] != words[5] {
+		if _, err := DecodeSeedShare(strings.Join(words, " ")); err == nil {
+			t.Fatal("decoded a share with swapped words")
+		}
+	}
+
+	// Shares of different seeds can't be combined.
+	other, err := SplitSeed(seed, WordSeed12, 2, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := CombineSeedShares([]string{shares[0], other[1]}); err == nil {
+		t.Fatal("combined shares of different splits")
+	}
+}
@@ -0,0 +1,449 @@
+package wallet
+
+import (
+	"time"
+
+	"decred.org/dcrwallet/v4/errors"
+	"github.com/crypto-power/cryptopower/libwallet/utils"
+)
+
+// AllAccounts keys the spending policy that applies to the accounts without a
+// policy of their own.
+const AllAccounts int32 = -1
+
+const (
+	spendLimitDay  = 24 * time.Hour
+	spendLimitWeek = 7 * spendLimitDay
+)
+
+// SpendingPolicy restricts the sends from a wallet account. Amounts are in
+// atoms and a zero amount disables the restriction it sets.
+type SpendingPolicy struct {
+	DailyLimit  int64 `json:"dailylimit"`
+	WeeklyLimit int64 `json:"weeklylimit"`
+	// WhitelistOnly restricts sends to the addresses in Whitelist.
+	WhitelistOnly bool     `json:"whitelistonly"`
+	Whitelist     []string `json:"whitelist"`
+	// Sends of more than DelayThreshold are broadcast DelaySeconds after
+	// they are signed and can be cancelled until then.
+	DelayThreshold int64 `json:"delaythreshold"`
+	DelaySeconds   int64 `json:"delayseconds"`
+	// ConfirmNewDestinations rejects sends to addresses the wallet never sent
+	// to until they are approved with ApproveDestinations.
+	ConfirmNewDestinations bool `json:"confirmnewdestinations"`
+}
+
+// SpendingPolicies are the spending policies of a wallet keyed by account
+// number, or AllAccounts for the policy of the whole wallet.
+type SpendingPolicies map[int32]*SpendingPolicy
+
+// SpendOutput is an output of a send paying a destination address.
+type SpendOutput struct {
+	Address string `json:"address"`
+	Amount  int64  `json:"amount"`
+}
+
+// spendRecord is a broadcast send counted against the spend limits.
+type spendRecord struct {
+	Account int32 `json:"account"`
+	Amount  int64 `json:"amount"`
+	Time    int64 `json:"time"`
+}
+
+// DelayedSend is a signed send held back by the delay of a spending policy.
+type DelayedSend struct {
+	Hash        string         `json:"hash"`
+	Account     int32          `json:"account"`
+	Outputs     []*SpendOutput `json:"outputs"`
+	Label       string         `json:"label"`
+	RawTx       string         `json:"rawtx"`
+	BroadcastAt int64          `json:"broadcastat"`
+}
+
+// Validate checks that the policy restrictions are consistent.
+func (policy *SpendingPolicy) Validate() error {
+	if policy.DailyLimit < 0 || policy.WeeklyLimit < 0 || policy.DelayThreshold < 0 || policy.DelaySeconds < 0 {
+		return errors.E(errors.Invalid, "spending policy amounts and delays cannot be negative")
+	}
+	if policy.WhitelistOnly && len(policy.Whitelist) == 0 {
+		return errors.E(errors.Invalid, "a whitelist-only policy needs at least one whitelisted address")
+	}
+	if policy.DelayThreshold > 0 && policy.DelaySeconds == 0 {
+		return errors.E(errors.Invalid, "a delay threshold needs a delay")
+	}
+	return nil
+}
+
+// Delay returns how long sends of more than DelayThreshold are held back.
+func (policy *SpendingPolicy) Delay() time.Duration {
+	return time.Duration(policy.DelaySeconds) * time.Second
+}
+
+// IsLoosenedBy returns true if replacing the policy with policy, or removing
+// it if policy is nil, would allow a send the policy forbids or sends it holds
+// back to go out sooner.
+func (old *SpendingPolicy) IsLoosenedBy(policy *SpendingPolicy) bool {
+	if old == nil {
+		return false
+	}
+	if policy == nil {
+		return true
+	}
+
+	// A zero limit or threshold disables its restriction.
+	loosened := func(old, new int64) bool {
+		return old > 0 && (new == 0 || new > old)
+	}
+	if loosened(old.DailyLimit, policy.DailyLimit) || loosened(old.WeeklyLimit, policy.WeeklyLimit) ||
+		loosened(old.DelayThreshold, policy.DelayThreshold) ||
+		(old.DelayThreshold > 0 && policy.DelaySeconds < old.DelaySeconds) ||
+		(old.WhitelistOnly && !policy.WhitelistOnly) ||
+		(old.ConfirmNewDestinations && !policy.ConfirmNewDestinations) {
+		return true
+	}
+
+	// Whitelisted addresses bypass both the whitelist and the approval of new
+	// destinations.
+	if old.WhitelistOnly || old.ConfirmNewDestinations {
+		whitelist := make(map[string]bool, len(old.Whitelist))
+		for _, address := range old.Whitelist {
+			whitelist[address] = true
+		}
+		for _, address := range policy.Whitelist {
+			if !whitelist[address] {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// check returns an error if the policy forbids sending outputs after
+// spentDay and spentWeek were sent in the last day and week, and otherwise
+// how long the send must be held back. known holds the addresses that don't
+// need to be approved as new destinations.
+func (policy *SpendingPolicy) check(outputs []*SpendOutput, spentDay, spentWeek int64, known map[string]bool) (time.Duration, error) {
+	whitelist := make(map[string]bool, len(policy.Whitelist))
+	for _, address := range policy.Whitelist {
+		whitelist[address] = true
+	}
+
+	var amount int64
+	for _, output := range outputs {
+		amount += output.Amount
+		if policy.WhitelistOnly && !whitelist[output.Address] {
+			return 0, errors.New(utils.ErrDestinationNotWhitelisted)
+		}
+		if policy.ConfirmNewDestinations && !whitelist[output.Address] && !known[output.Address] {
+			return 0, errors.New(utils.ErrNewDestination)
+		}
+	}
+
+	if (policy.DailyLimit > 0 && spentDay+amount > policy.DailyLimit) ||
+		(policy.WeeklyLimit > 0 && spentWeek+amount > policy.WeeklyLimit) {
+		return 0, errors.New(utils.ErrSpendLimitExceeded)
+	}
+
+	if policy.DelayThreshold > 0 && amount > policy.DelayThreshold {
+		return policy.Delay(), nil
+	}
+	return 0, nil
+}
+
+// SpendingPolicies returns the spending policies of the wallet.
+func (wallet *Wallet) SpendingPolicies() SpendingPolicies {
+	policies := make(SpendingPolicies)
+	_ = wallet.ReadUserConfigValue(SpendingPoliciesConfigKey, &policies)
+	return policies
+}
+
+// SpendingPolicy returns the policy restricting the sends from account: its
+// own policy, else the policy of the whole wallet, else nil.
+func (wallet *Wallet) SpendingPolicy(account int32) *SpendingPolicy {
+	policies := wallet.SpendingPolicies()
+	if policy, ok := policies[account]; ok {
+		return policy
+	}
+	return policies[AllAccounts]
+}
+
+// SetSpendingPolicy validates and stores the spending policy of account, or
+// of the whole wallet if account is AllAccounts. A nil policy removes it. The
+// private passphrase of the wallet is only required to loosen or remove a
+// policy.
+func (wallet *Wallet) SetSpendingPolicy(account int32, policy *SpendingPolicy, privatePassphrase string) error {
+	if policy != nil {
+		if err := policy.Validate(); err != nil {
+			return err
+		}
+	}
+
+	wallet.spendingMu.Lock()
+	defer wallet.spendingMu.Unlock()
+
+	policies := wallet.SpendingPolicies()
+	if policies[account].IsLoosenedBy(policy) {
+		if err := wallet.verifyPrivatePassphrase(privatePassphrase); err != nil {
+			return err
+		}
+	}
+	if policy == nil {
+		delete(policies, account)
+	} else {
+		policies[account] = policy
+	}
+	return wallet.walletConfigSave(SpendingPoliciesConfigKey, policies)
+}
+
+// verifyPrivatePassphrase returns an error if privatePassphrase isn't the
+// private passphrase of the wallet. The wallet is left locked or unlocked as
+// it was.
+func (wallet *Wallet) verifyPrivatePassphrase(privatePassphrase string) error {
+	wasLocked := wallet.IsLocked()
+	if err := wallet.UnlockWallet(privatePassphrase); err != nil {
+		return err
+	}
+	if wasLocked {
+		wallet.LockWallet()
+	}
+	return nil
+}
+
+// ApproveDestinations marks addresses as known destinations that sends to
+// don't need approving again.
+func (wallet *Wallet) ApproveDestinations(addresses []string) error {
+	wallet.spendingMu.Lock()
+	defer wallet.spendingMu.Unlock()
+
+	return wallet.addKnownDestinations(addresses)
+}
+
+func (wallet *Wallet) knownDestinations() map[string]bool {
+	var addresses []string
+	_ = wallet.walletConfigRead(KnownDestinationsConfigKey, &addresses)
+	known := make(map[string]bool, len(addresses))
+	for _, address := range addresses {
+		known[address] = true
+	}
+	return known
+}
+
+func (wallet *Wallet) addKnownDestinations(addresses []string) error {
+	known := wallet.knownDestinations()
+	all := make([]string, 0, len(known)+len(addresses))
+	for address := range known {
+		all = append(all, address)
+	}
+	for _, address := range addresses {
+		if !known[address] {
+			known[address] = true
+			all = append(all, address)
+		}
+	}
+	return wallet.walletConfigSave(KnownDestinationsConfigKey, all)
+}
+
+func (wallet *Wallet) spendHistory() []*spendRecord {
+	var records []*spendRecord
+	_ = wallet.walletConfigRead(SpendHistoryConfigKey, &records)
+	return records
+}
+
+// spentSince returns the amount sent from account since the given time by
+// the records and the delayed sends. Sends from every account are counted if
+// account is AllAccounts.
+func spentSince(records []*spendRecord, delayed []*DelayedSend, account int32, since time.Time) int64 {
+	var spent int64
+	for _, record := range records {
+		if (account == AllAccounts || record.Account == account) && record.Time >= since.Unix() {
+			spent += record.Amount
+		}
+	}
+	for _, send := range delayed {
+		if account == AllAccounts || send.Account == account {
+			spent += send.Amount()
+		}
+	}
+	return spent
+}
+
+// CheckSpend returns an error if the spending policy of account forbids
+// sending outputs, and otherwise how long the send would be held back. It
+// lets a send be refused before it is signed, the send must still go through
+// SendWithPolicy.
+func (wallet *Wallet) CheckSpend(account int32, outputs []*SpendOutput) (time.Duration, error) {
+	wallet.spendingMu.Lock()
+	defer wallet.spendingMu.Unlock()
+
+	return wallet.checkSpend(account, outputs)
+}
+
+// checkSpend is CheckSpend for callers holding spendingMu.
+func (wallet *Wallet) checkSpend(account int32, outputs []*SpendOutput) (time.Duration, error) {
+	policies := wallet.SpendingPolicies()
+	policy, ok := policies[account]
+	if !ok {
+		// The limits of the whole wallet count the sends from every account.
+		policy, account = policies[AllAccounts], AllAccounts
+	}
+	if policy == nil {
+		return 0, nil
+	}
+
+	now := time.Now()
+	records, delayed := wallet.spendHistory(), wallet.DelayedSends()
+	spentDay := spentSince(records, delayed, account, now.Add(-spendLimitDay))
+	spentWeek := spentSince(records, delayed, account, now.Add(-spendLimitWeek))
+	return policy.check(outputs, spentDay, spentWeek, wallet.knownDestinations())
+}
+
+// CheckDEXSwaps returns an error if the spending policy of account would
+// refuse the swaps of a DEX order of amount. The swaps pay new contract
+// addresses and can't be held back, and a swap that fails once the order is
+// matched is penalized by the DEX server, so the order must not be placed.
+func (wallet *Wallet) CheckDEXSwaps(account int32, amount int64) error {
+	wallet.spendingMu.Lock()
+	defer wallet.spendingMu.Unlock()
+
+	if policy := wallet.SpendingPolicy(account); policy != nil && (policy.WhitelistOnly || policy.ConfirmNewDestinations) {
+		return errors.New(utils.ErrDEXTradeRestricted)
+	}
+	delay, err := wallet.checkSpend(account, []*SpendOutput{{Amount: amount}})
+	if err != nil {
+		return err
+	}
+	if delay > 0 {
+		return errors.New(utils.ErrSendNotDelayable)
+	}
+	return nil
+}
+
+// SendWithPolicy broadcasts a send of outputs from account with publish if
+// the spending policy of account allows it, and counts it against the spend
+// limits. The send is checked, broadcast and counted under one lock so that
+// concurrent sends can't exceed the limits together.
+//
+// If the policy holds the send back, hold is stored in place of broadcasting
+// the send, to be broadcast by PublishDelayedSend once its delay is over, and
+// ErrSendDelayed is returned. Sends that can't be held back, with a nil hold,
+// are refused. Every path broadcasting the sends of a wallet must go through
+// SendWithPolicy.
+func (wallet *Wallet) SendWithPolicy(account int32, outputs []*SpendOutput, hold *DelayedSend, publish func() error) error {
+	wallet.spendingMu.Lock()
+	defer wallet.spendingMu.Unlock()
+
+	delay, err := wallet.checkSpend(account, outputs)
+	if err != nil {
+		return err
+	}
+	if delay > 0 {
+		if hold == nil {
+			return errors.New(utils.ErrSendNotDelayable)
+		}
+		hold.Account, hold.Outputs = account, outputs
+		hold.BroadcastAt = time.Now().Add(delay).Unix()
+		if err := wallet.walletConfigSave(DelayedSendsConfigKey, append(wallet.DelayedSends(), hold)); err != nil {
+			return err
+		}
+		return errors.New(utils.ErrSendDelayed)
+	}
+
+	if err := publish(); err != nil {
+		return err
+	}
+	wallet.recordSpend(account, outputs)
+	return nil
+}
+
+func (wallet *Wallet) recordSpend(account int32, outputs []*SpendOutput) {
+	now := time.Now()
+	var amount int64
+	addresses := make([]string, 0, len(outputs))
+	for _, output := range outputs {
+		amount += output.Amount
+		addresses = append(addresses, output.Address)
+	}
+
+	// Records older than the longest limit period are no longer needed.
+	records := []*spendRecord{{Account: account, Amount: amount, Time: now.Unix()}}
+	for _, record := range wallet.spendHistory() {
+		if record.Time >= now.Add(-spendLimitWeek).Unix() {
+			records = append(records, record)
+		}
+	}
+
+	if err := wallet.walletConfigSave(SpendHistoryConfigKey, records); err != nil {
+		log.Errorf("Error saving the spend history of wallet %d: %v", wallet.ID, err)
+	}
+	if err := wallet.addKnownDestinations(addresses); err != nil {
+		log.Errorf("Error saving the known destinations of wallet %d: %v", wallet.ID, err)
+	}
+}
+
+// Amount returns the amount sent to the outputs of the send.
+func (send *DelayedSend) Amount() int64 {
+	var amount int64
+	for _, output := range send.Outputs {
+		amount += output.Amount
+	}
+	return amount
+}
+
+// Due returns true if the cancel window of the send is over.
+func (send *DelayedSend) Due(now time.Time) bool {
+	return now.Unix() >= send.BroadcastAt
+}
+
+// DelayedSends returns the sends held back by a spending policy delay.
+func (wallet *Wallet) DelayedSends() []*DelayedSend {
+	var sends []*DelayedSend
+	_ = wallet.walletConfigRead(DelayedSendsConfigKey, &sends)
+	return sends
+}
+
+// RemoveDelayedSend removes the delayed send with the tx hash and returns it.
+func (wallet *Wallet) RemoveDelayedSend(hash string) (*DelayedSend, error) {
+	wallet.spendingMu.Lock()
+	defer wallet.spendingMu.Unlock()
+
+	return wallet.removeDelayedSend(hash)
+}
+
+// PublishDelayedSend removes the delayed send with the tx hash and broadcasts
+// it with publish, counting it against the spend limits, under the lock of
+// SendWithPolicy. A send that fails to broadcast is dropped.
+func (wallet *Wallet) PublishDelayedSend(hash string, publish func(*DelayedSend) error) error {
+	wallet.spendingMu.Lock()
+	defer wallet.spendingMu.Unlock()
+
+	send, err := wallet.removeDelayedSend(hash)
+	if err != nil {
+		return err
+	}
+	if err := publish(send); err != nil {
+		return err
+	}
+	wallet.recordSpend(send.Account, send.Outputs)
+	return nil
+}
+
+func (wallet *Wallet) removeDelayedSend(hash string) (*DelayedSend, error) {
+	var removed *DelayedSend
+	sends := wallet.DelayedSends()
+	remaining := make([]*DelayedSend, 0, len(sends))
+	for _, send := range sends {
+		if send.Hash == hash {
+			removed = send
+			continue
+		}
+		remaining = append(remaining, send)
+	}
+	if removed == nil {
+		return nil, errors.New(utils.ErrNotExist)
+	}
+
+	if err := wallet.walletConfigSave(DelayedSendsConfigKey, remaining); err != nil {
+		return nil, err
+	}
+	return removed, nil
+}
@@ -0,0 +1,118 @@
+package wallet
+
+import (
+	"testing"
+	"time"
+
+	"github.com/crypto-power/cryptopower/libwallet/utils"
+)
+
+func TestSpendingPolicyCheck(t *testing.T) {
+	policy := &SpendingPolicy{
+		DailyLimit:             100,
+		WeeklyLimit:            300,
+		DelayThreshold:         50,
+		DelaySeconds:           3600,
+		ConfirmNewDestinations: true,
+		Whitelist:              []string{"whitelisted"},
+	}
+	known := map[string]bool{"known": true}
+
+	tests := []struct {
+		name       string
+		policy     *SpendingPolicy
+		outputs    []*SpendOutput
+		spentDay   int64
+		spentWeek  int64
+		wantDelay  time.Duration
+		wantErrStr string
+	}{
+		{"small send", policy, []*SpendOutput{{"known", 10}}, 0, 0, 0, ""},
+		{"whitelisted destination", policy, []*SpendOutput{{"whitelisted", 10}}, 0, 0, 0, ""},
+		{"new destination", policy, []*SpendOutput{{"new", 10}}, 0, 0, 0, utils.ErrNewDestination},
+		{"delayed send", policy, []*SpendOutput{{"known", 30}, {"whitelisted", 30}}, 0, 0, time.Hour, ""},
+		{"daily limit", policy, []*SpendOutput{{"known", 10}}, 95, 95, 0, utils.ErrSpendLimitExceeded},
+		{"weekly limit", policy, []*SpendOutput{{"known", 10}}, 0, 295, 0, utils.ErrSpendLimitExceeded},
+		{"not whitelisted", &SpendingPolicy{WhitelistOnly: true, Whitelist: []string{"whitelisted"}},
+			[]*SpendOutput{{"known", 10}}, 0, 0, 0, utils.ErrDestinationNotWhitelisted},
+	}
+
+	for _, test := range tests {
+		delay, err := test.policy.check(test.outputs, test.spentDay, test.spentWeek, known)
+		if test.wantErrStr != "" {
+			if err == nil || err.Error() != test.wantErrStr {
+				t.Fatalf("%s: got error %v, want %s", test.name, err, test.wantErrStr)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("%s: %v", test.name, err)
+		}
+		if delay != test.wantDelay {
+			t.Fatalf("%s: got delay %v, want %v", test.name, delay, test.wantDelay)
+		}
+	}
+}
+
+func TestSpentSince(t *testing.T) {
+	now := time.Now()
+	records := []*spendRecord{
+		{Account: 0, Amount: 10, Time: now.Add(-time.Hour).Unix()},
+		{Account: 1, Amount: 20, Time: now.Add(-time.Hour).Unix()},
+		{Account: 0, Amount: 40, Time: now.Add(-3 * spendLimitDay).Unix()},
+	}
+	delayed := []*DelayedSend{{Account: 1, Outputs: []*SpendOutput{{"a", 5}, {"b", 5}}}}
+
+	if spent := spentSince(records, delayed, 0, now.Add(-spendLimitDay)); spent != 10 {
+		t.Fatalf("account 0 daily spend: got %d, want 10", spent)
+	}
+	if spent := spentSince(records, delayed, 0, now.Add(-spendLimitWeek)); spent != 50 {
+		t.Fatalf("account 0 weekly spend: got %d, want 50", spent)
+	}
+	if spent := spentSince(records, delayed, AllAccounts, now.Add(-spendLimitDay)); spent != 40 {
+		t.Fatalf("wallet daily spend: got %d, want 40", spent)
+	}
+}
+
+func TestSpendingPolicyIsLoosenedBy(t *testing.T) {
+	old := &SpendingPolicy{
+		DailyLimit:             100,
+		WeeklyLimit:            300,
+		DelayThreshold:         50,
+		DelaySeconds:           3600,
+		ConfirmNewDestinations: true,
+		Whitelist:              []string{"whitelisted"},
+	}
+	with := func(change func(policy *SpendingPolicy)) *SpendingPolicy {
+		policy := *old
+		change(&policy)
+		return &policy
+	}
+
+	tests := []struct {
+		name   string
+		old    *SpendingPolicy
+		policy *SpendingPolicy
+		want   bool
+	}{
+		{"no policy", nil, old, false},
+		{"same policy", old, with(func(*SpendingPolicy) {}), false},
+		{"removed", old, nil, true},
+		{"lower limit", old, with(func(p *SpendingPolicy) { p.DailyLimit = 50 }), false},
+		{"higher limit", old, with(func(p *SpendingPolicy) { p.WeeklyLimit = 400 }), true},
+		{"disabled limit", old, with(func(p *SpendingPolicy) { p.DailyLimit = 0 }), true},
+		{"higher threshold", old, with(func(p *SpendingPolicy) { p.DelayThreshold = 60 }), true},
+		{"shorter delay", old, with(func(p *SpendingPolicy) { p.DelaySeconds = 60 }), true},
+		{"longer delay", old, with(func(p *SpendingPolicy) { p.DelaySeconds = 7200 }), false},
+		{"no confirmation", old, with(func(p *SpendingPolicy) { p.ConfirmNewDestinations = false }), true},
+		{"whitelist only", old, with(func(p *SpendingPolicy) { p.WhitelistOnly = true }), false},
+		{"whitelisted address", old, with(func(p *SpendingPolicy) { p.Whitelist = []string{"whitelisted", "new"} }), true},
+		{"unwhitelisted address", old, with(func(p *SpendingPolicy) { p.Whitelist = nil }), false},
+	}
+
+	for _, test := range tests {
+		if got := test.old.IsLoosenedBy(test.policy); got != test.want {
+			t.Fatalf("%s: got %v, want %v", test.name, got, test.want)
+		}
+	}
+}
@@ -0,0 +1,298 @@
+package wallet
+
+import (
+	"sync"
+	"time"
+
+	"decred.org/dcrwallet/v4/errors"
+	"github.com/crypto-power/cryptopower/libwallet/utils"
+)
+
+// syncDataFlushSize is the amount of sync data a wallet counts before saving
+// its data usage, so that the usage isn't saved on every read from a peer.
+const syncDataFlushSize = 1 << 20
+
+// SyncPolicy restricts when the wallets sync, to spare the data and battery
+// of mobile devices.
+type SyncPolicy struct {
+	// UnmeteredOnly only lets the wallets sync on unmetered networks such
+	// as Wi-Fi.
+	UnmeteredOnly bool `json:"unmeteredOnly"`
+	// ChargingOnly only lets the wallets sync while the device is charging.
+	ChargingOnly bool `json:"chargingOnly"`
+	// MonthlyDataBudget is the number of bytes the wallets may sync in a
+	// calendar month before syncing is paused, or 0 for no limit.
+	MonthlyDataBudget uint64 `json:"monthlyDataBudget"`
+}
+
+// SyncConditions are the network and power conditions of the device, as
+// reported by the platform. Platforms that can't tell report the zero value,
+// an unmetered network while charging, so that they are never restricted.
+type SyncConditions struct {
+	Metered   bool
+	OnBattery bool
+}
+
+// SyncDataUsage is the data a wallet synced during a calendar month.
+type SyncDataUsage struct {
+	Month string `json:"month"` // YYYY-MM
+	Bytes uint64 `json:"bytes"`
+}
+
+func currentMonth() string {
+	return time.Now().Format("2006-01")
+}
+
+// SyncGate decides whether the wallets may sync under the sync policy and
+// the current conditions of the device. It is shared by all the wallets as
+// the data budget applies to their total usage. Wallets refused a sync are
+// remembered so that they can be resumed once syncing is allowed.
+type SyncGate struct {
+	mu         sync.Mutex
+	policy     SyncPolicy
+	conditions SyncConditions
+	month      string
+	usage      map[int]uint64 // by wallet ID, for the current month
+	waiting    map[int]struct{}
+	onChange   func()
+}
+
+// NewSyncGate creates a gate that lets the wallets sync until a policy is
+// set.
+func NewSyncGate() *SyncGate {
+	return &SyncGate{
+		month:   currentMonth(),
+		usage:   make(map[int]uint64),
+		waiting: make(map[int]struct{}),
+	}
+}
+
+// SetOnChange sets the function called when syncing may have become allowed
+// or disallowed.
+func (g *SyncGate) SetOnChange(onChange func()) {
+	g.mu.Lock()
+	g.onChange = onChange
+	g.mu.Unlock()
+}
+
+func (g *SyncGate) notifyChange() {
+	g.mu.Lock()
+	onChange := g.onChange
+	g.mu.Unlock()
+	if onChange != nil {
+		go onChange()
+	}
+}
+
+// Policy returns the sync policy.
+func (g *SyncGate) Policy() SyncPolicy {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.policy
+}
+
+// SetPolicy sets the sync policy.
+func (g *SyncGate) SetPolicy(policy SyncPolicy) {
+	g.mu.Lock()
+	g.policy = policy
+	g.mu.Unlock()
+	g.notifyChange()
+}
+
+// Conditions returns the last reported conditions of the device.
+func (g *SyncGate) Conditions() SyncConditions {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.conditions
+}
+
+// SetConditions records the conditions of the device.
+func (g *SyncGate) SetConditions(conditions SyncConditions) {
+	g.mu.Lock()
+	changed := g.conditions.Metered != conditions.Metered || g.conditions.OnBattery != conditions.OnBattery
+	g.conditions = conditions
+	g.mu.Unlock()
+	if changed {
+		g.notifyChange()
+	}
+}
+
+// resetMonth clears the usage of a past month. g.mu must be held.
+func (g *SyncGate) resetMonth() {
+	if month := currentMonth(); month != g.month {
+		g.month = month
+		g.usage = make(map[int]uint64)
+	}
+}
+
+// DataUsage returns the data the wallets synced this month.
+func (g *SyncGate) DataUsage() uint64 {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.resetMonth()
+	var total uint64
+	for _, bytes := range g.usage {
+		total += bytes
+	}
+	return total
+}
+
+// setUsage records the data the wallet with walletID synced in month, and
+// notifies a change if it exhausted the data budget.
+func (g *SyncGate) setUsage(walletID int, usage SyncDataUsage) {
+	g.mu.Lock()
+	g.resetMonth()
+	if usage.Month != g.month {
+		g.mu.Unlock()
+		return
+	}
+	wasAllowed := g.check() == nil
+	g.usage[walletID] = usage.Bytes
+	exhausted := wasAllowed && g.check() != nil
+	g.mu.Unlock()
+	if exhausted {
+		g.notifyChange()
+	}
+}
+
+// check returns the reason syncing is not allowed, if any. g.mu must be
+// held.
+func (g *SyncGate) check() error {
+	switch {
+	case g.policy.UnmeteredOnly && g.conditions.Metered:
+		return errors.New(utils.ErrSyncMeteredNetwork)
+	case g.policy.ChargingOnly && g.conditions.OnBattery:
+		return errors.New(utils.ErrSyncNotCharging)
+	}
+	if g.policy.MonthlyDataBudget > 0 {
+		var total uint64
+		for _, bytes := range g.usage {
+			total += bytes
+		}
+		if total >= g.policy.MonthlyDataBudget {
+			return errors.New(utils.ErrSyncDataBudgetExceeded)
+		}
+	}
+	return nil
+}
+
+// Check returns the reason syncing is not allowed, if any.
+func (g *SyncGate) Check() error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.resetMonth()
+	return g.check()
+}
+
+// Wait remembers that the wallet with walletID should sync once syncing is
+// allowed.
+func (g *SyncGate) Wait(walletID int) {
+	g.mu.Lock()
+	g.waiting[walletID] = struct{}{}
+	g.mu.Unlock()
+}
+
+// TakeWaiting returns the IDs of the wallets waiting to sync and forgets
+// them.
+func (g *SyncGate) TakeWaiting() []int {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	ids := make([]int, 0, len(g.waiting))
+	for id := range g.waiting {
+		ids = append(ids, id)
+	}
+	g.waiting = make(map[int]struct{})
+	return ids
+}
+
+// SyncGate returns the gate deciding whether the wallet may sync, or nil if
+// it syncs unrestricted.
+func (wallet *Wallet) SyncGate() *SyncGate {
+	wallet.mu.RLock()
+	defer wallet.mu.RUnlock()
+	return wallet.syncGate
+}
+
+// CheckSyncAllowed returns an error if the sync policy doesn't let the
+// wallet sync now. The wallet then syncs once it is allowed.
+func (wallet *Wallet) CheckSyncAllowed() error {
+	gate := wallet.SyncGate()
+	if gate == nil {
+		return nil
+	}
+	if err := gate.Check(); err != nil {
+		gate.Wait(wallet.ID)
+		return err
+	}
+	return nil
+}
+
+// SyncDataUsage returns the data the wallet synced this month.
+func (wallet *Wallet) SyncDataUsage() uint64 {
+	wallet.syncUsageMu.Lock()
+	defer wallet.syncUsageMu.Unlock()
+	return wallet.loadSyncUsage().Bytes
+}
+
+// loadSyncUsage returns the data usage of the wallet this month, reading it
+// from the wallet config the first time. wallet.syncUsageMu must be held.
+func (wallet *Wallet) loadSyncUsage() *SyncDataUsage {
+	if wallet.syncUsage == nil {
+		usage := new(SyncDataUsage)
+		_ = wallet.ReadUserConfigValue(SyncDataUsageConfigKey, usage)
+		wallet.syncUsage = usage
+		if gate := wallet.SyncGate(); gate != nil {
+			gate.setUsage(wallet.ID, *usage)
+		}
+	}
+	if month := currentMonth(); wallet.syncUsage.Month != month {
+		wallet.syncUsage = &SyncDataUsage{Month: month}
+	}
+	return wallet.syncUsage
+}
+
+// AddSyncData counts n bytes sent to or received from the network while
+// syncing towards the data usage of the wallet.
+func (wallet *Wallet) AddSyncData(n int) {
+	if n <= 0 {
+		return
+	}
+
+	wallet.syncUsageMu.Lock()
+	usage := wallet.loadSyncUsage()
+	usage.Bytes += uint64(n)
+	wallet.unsavedSyncData += uint64(n)
+	save := wallet.unsavedSyncData >= syncDataFlushSize
+	if save {
+		wallet.unsavedSyncData = 0
+	}
+	snapshot := *usage
+	wallet.syncUsageMu.Unlock()
+
+	if gate := wallet.SyncGate(); gate != nil {
+		gate.setUsage(wallet.ID, snapshot)
+	}
+	if save {
+		wallet.saveSyncUsage(snapshot)
+	}
+}
+
+// FlushSyncDataUsage saves the data usage of the wallet.
+func (wallet *Wallet) FlushSyncDataUsage() {
+	wallet.syncUsageMu.Lock()
+	if wallet.syncUsage == nil || wallet.unsavedSyncData == 0 {
+		wallet.syncUsageMu.Unlock()
+		return
+	}
+	wallet.unsavedSyncData = 0
+	snapshot := *wallet.syncUsage
+	wallet.syncUsageMu.Unlock()
+
+	wallet.saveSyncUsage(snapshot)
+}
+
+func (wallet *Wallet) saveSyncUsage(usage SyncDataUsage) {
+	if err := wallet.walletConfigSave(SyncDataUsageConfigKey, usage); err != nil {
+		log.Errorf("Error saving the sync data usage of wallet %d: %v", wallet.ID, err)
+	}
+}
@@ -0,0 +1,58 @@
+package wallet
+
+import (
+	"testing"
+
+	"github.com/crypto-power/cryptopower/libwallet/utils"
+)
+
+func TestSyncGate(t *testing.T) {
+	gate := NewSyncGate()
+	changes := make(chan struct{}, 10)
+	gate.SetOnChange(func() { changes <- struct{}{} })
+
+	if err := gate.Check(); err != nil {
+		t.Fatalf("a gate without a policy refused to sync: %v", err)
+	}
+
+	gate.SetPolicy(SyncPolicy{UnmeteredOnly: true, ChargingOnly: true, MonthlyDataBudget: 100})
+	<-changes
+
+	gate.SetConditions(SyncConditions{Metered: true})
+	<-changes
+	if err := gate.Check(); err == nil || err.Error() != utils.ErrSyncMeteredNetwork {
+		t.Fatalf("expected %s, got %v", utils.ErrSyncMeteredNetwork, err)
+	}
+
+	gate.SetConditions(SyncConditions{OnBattery: true})
+	<-changes
+	if err := gate.Check(); err == nil || err.Error() != utils.ErrSyncNotCharging {
+		t.Fatalf("expected %s, got %v", utils.ErrSyncNotCharging, err)
+	}
+
+	gate.SetConditions(SyncConditions{})
+	<-changes
+	gate.setUsage(1, SyncDataUsage{Month: currentMonth(), Bytes: 60})
+	gate.setUsage(2, SyncDataUsage{Month: "2000-01", Bytes: 1000}) // a past month
+	if err := gate.Check(); err != nil {
+		t.Fatalf("unexpected refusal under the data budget: %v", err)
+	}
+
+	gate.setUsage(2, SyncDataUsage{Month: currentMonth(), Bytes: 40})
+	<-changes // the budget is used up
+	if err := gate.Check(); err == nil || err.Error() != utils.ErrSyncDataBudgetExceeded {
+		t.Fatalf("expected %s, got %v", utils.ErrSyncDataBudgetExceeded, err)
+	}
+	if usage := gate.DataUsage(); usage != 100 {
+		t.Fatalf("DataUsage = %d, want 100", usage)
+	}
+
+	gate.Wait(1)
+	gate.Wait(2)
+	if waiting := gate.TakeWaiting(); len(waiting) != 2 {
+		t.Fatalf("TakeWaiting = %v, want 2 wallets", waiting)
+	}
+	if waiting := gate.TakeWaiting(); len(waiting) != 0 {
+		t.Fatalf("TakeWaiting = %v after taking the waiting wallets", waiting)
+	}
+}
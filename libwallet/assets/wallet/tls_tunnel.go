@@ -0,0 +1,150 @@
+package wallet
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io"
+	"net"
+	"sync"
+	"time"
+)
+
+// tlsDialTimeout is how long the TLS connection to the remote server of a
+// tunnel may take to be established.
+const tlsDialTimeout = 30 * time.Second
+
+// TLSTunnel forwards the connections made to a loopback listener to a remote
+// server over TLS. It is used to reach bitcoind and litecoind, which only
+// serve RPC over plain HTTP, through a TLS terminating proxy in front of the
+// node, as their chain clients can't be configured to use TLS.
+type TLSTunnel struct {
+	listener  net.Listener
+	remote    string
+	tlsConfig *tls.Config
+
+	mtx    sync.Mutex
+	conns  map[net.Conn]struct{}
+	closed bool
+	wg     sync.WaitGroup
+}
+
+// NewTLSTunnel starts a tunnel to the remote host:port. The server must
+// present a certificate signed by certificate, or by the system certificate
+// authorities if certificate is empty.
+func NewTLSTunnel(remote string, certificate []byte) (*TLSTunnel, error) {
+	host, _, err := net.SplitHostPort(remote)
+	if err != nil {
+		return nil, err
+	}
+
+	tlsConfig := &tls.Config{
+		ServerName: host,
+		MinVersion: tls.VersionTLS12,
+	}
+	if len(certificate) > 0 {
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(certificate) {
+			return nil, fmt.Errorf("invalid TLS certificate")
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, err
+	}
+
+	t := &TLSTunnel{
+		listener:  listener,
+		remote:    remote,
+		tlsConfig: tlsConfig,
+		conns:     make(map[net.Conn]struct{}),
+	}
+	t.wg.Add(1)
+	go t.acceptConnections()
+	return t, nil
+}
+
+// Addr returns the loopback host:port that clients connect to.
+func (t *TLSTunnel) Addr() string {
+	return t.listener.Addr().String()
+}
+
+// Close stops the tunnel and closes the forwarded connections.
+func (t *TLSTunnel) Close() {
+	t.mtx.Lock()
+	t.closed = true
+	t.listener.Close()
+	for conn := range t.conns {
+		conn.Close()
+	}
+	t.mtx.Unlock()
+	t.wg.Wait()
+}
+
+func (t *TLSTunnel) acceptConnections() {
+	defer t.wg.Done()
+	for {
+		conn, err := t.listener.Accept()
+		if err != nil {
+			return
+		}
+		if !t.track(conn) {
+			return
+		}
+
+		t.wg.Add(1)
+		go t.forward(conn)
+	}
+}
+
+// track records conn so it is closed along with the tunnel. It returns false
+// and closes conn if the tunnel is already closed.
+func (t *TLSTunnel) track(conn net.Conn) bool {
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+	if t.closed {
+		conn.Close()
+		return false
+	}
+	t.conns[conn] = struct{}{}
+	return true
+}
+
+func (t *TLSTunnel) untrack(conn net.Conn) {
+	t.mtx.Lock()
+	delete(t.conns, conn)
+	t.mtx.Unlock()
+	conn.Close()
+}
+
+// forward copies the data of the local connection to the remote server over
+// TLS and back until either side closes its connection.
+func (t *TLSTunnel) forward(local net.Conn) {
+	defer t.wg.Done()
+	defer t.untrack(local)
+
+	dialer := &net.Dialer{Timeout: tlsDialTimeout}
+	remote, err := tls.DialWithDialer(dialer, "tcp", t.remote, t.tlsConfig)
+	if err != nil {
+		log.Errorf("TLS connection to %s failed: %v", t.remote, err)
+		return
+	}
+	if !t.track(remote) {
+		return
+	}
+	defer t.untrack(remote)
+
+	done := make(chan struct{}, 2)
+	go func() {
+		_, _ = io.Copy(remote, local)
+		done <- struct{}{}
+	}()
+	go func() {
+		_, _ = io.Copy(local, remote)
+		done <- struct{}{}
+	}()
+	// Closing both connections once either side is done ends the other copy.
+	<-done
+}
@@ -0,0 +1,54 @@
+package wallet
+
+import (
+	"encoding/pem"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestTLSTunnel(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = io.WriteString(w, "pong")
+	}))
+	defer server.Close()
+
+	remote := strings.TrimPrefix(server.URL, "https://")
+	cert := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+
+	tunnel, err := NewTLSTunnel(remote, cert)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer tunnel.Close()
+
+	// The plain HTTP request reaches the TLS server through the tunnel.
+	resp, err := http.Get("http://" + tunnel.Addr())
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(body) != "pong" {
+		t.Fatalf("unexpected response %q", body)
+	}
+
+	// The server certificate isn't signed by a system authority.
+	untrusted, err := NewTLSTunnel(remote, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer untrusted.Close()
+	if _, err := http.Get("http://" + untrusted.Addr()); err == nil {
+		t.Fatal("expected the untrusted server to be rejected")
+	}
+
+	if _, err := NewTLSTunnel(remote, []byte("not a certificate")); err == nil {
+		t.Fatal("expected an invalid certificate to be rejected")
+	}
+}
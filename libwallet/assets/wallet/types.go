@@ -6,6 +6,7 @@ import (
 	"github.com/asdine/storm"
 	btchdkeychain "github.com/btcsuite/btcd/btcutil/hdkeychain"
 	"github.com/crypto-power/cryptopower/libwallet/assets/wallet/wordlist"
+	"github.com/crypto-power/cryptopower/libwallet/internal/dbcrypt"
 	"github.com/crypto-power/cryptopower/libwallet/utils"
 	"github.com/decred/dcrd/dcrutil/v4"
 )
@@ -39,12 +40,20 @@ type WConfig struct {
 // InitParams defines the basic parameters required to instantiate any
 // wallet interface.
 type InitParams struct {
-	RootDir     string
-	NetType     utils.NetworkType
-	DB          *storm.DB
-	DbDriver    string
+	RootDir  string
+	NetType  utils.NetworkType
+	DB       *storm.DB
+	DbDriver string
+	// DBCodec encodes the values of the metadata databases. It encrypts them
+	// when the metadata encryption is enabled.
+	DBCodec     *dbcrypt.Codec
 	LogDir      string
 	DEXTestAddr string
+	// ChainBackends holds the chain backends shared by the wallets of the
+	// same asset.
+	ChainBackends *ChainBackends
+	// SyncGate decides whether the wallets may sync under the sync policy.
+	SyncGate *SyncGate
 }
 
 // AuthInfo defines the complete information required to either create a
@@ -213,6 +222,10 @@ type ScopeAddrSchema struct {
 	InternalAddrType AddressType
 }
 
+// NoBanScore is the ban score of peers that don't track one. Neutrino peers
+// are banned right away when they misbehave.
+const NoBanScore = -1
+
 type PeerInfo struct {
 	ID             int32  `json:"id"`
 	Addr           string `json:"addr"`
@@ -221,7 +234,18 @@ type PeerInfo struct {
 	Version        uint32 `json:"version"`
 	SubVer         string `json:"sub_ver"`
 	StartingHeight int64  `json:"starting_height"`
-	BanScore       int32  `json:"ban_score"`
+	// BanScore is the ban score of the peer, or NoBanScore for peers that
+	// don't track one.
+	BanScore int32 `json:"ban_score"`
+	// LastBlock is the last block height the peer announced.
+	LastBlock int64 `json:"last_block"`
+	// Latency is the round trip time of the last ping to the peer, or 0 if
+	// it is unknown.
+	Latency       time.Duration `json:"latency"`
+	BytesSent     uint64        `json:"bytes_sent"`
+	BytesReceived uint64        `json:"bytes_received"`
+	// Preferred is true if the wallet favours connecting to the peer.
+	Preferred bool `json:"preferred"`
 }
 
 /** begin sync-related types */
@@ -241,6 +265,8 @@ type SyncProgressListener struct {
 type GeneralSyncProgress struct {
 	TotalSyncProgress  int32         `json:"totalSyncProgress"`
 	TotalTimeRemaining time.Duration `json:"totalTimeRemainingSeconds"`
+	// SyncDataUsage is the data the wallet synced this month, in bytes.
+	SyncDataUsage uint64 `json:"syncDataUsage"`
 }
 
 type CFiltersFetchProgressReport struct {
@@ -293,14 +319,19 @@ type BlocksRescanProgressListener struct {
 }
 
 // Transaction is used with storm for tx indexing operations.
-// For faster queries, the `Hash`, `Type` and `Direction` fields are indexed.
+// The transactions are keyed by ID rather than by Hash and aren't indexed by
+// any other field, since storm writes the keys and the indexed values as is
+// even if the metadata is encrypted.
 type Transaction struct {
-	Hash          string `storm:"id,unique" json:"hash"`
-	Type          string `storm:"index" json:"type,omitempty"`
+	// ID is the Hash or, if the metadata is encrypted, a keyed hash of it.
+	// It is set by the wallet data database.
+	ID            string `storm:"id" json:"id,omitempty"`
+	Hash          string `json:"hash"`
+	Type          string `json:"type,omitempty"`
 	Hex           string `json:"hex"`
-	Timestamp     int64  `storm:"index" json:"timestamp"`
-	BlockHeight   int32  `storm:"index" json:"block_height"`
-	TicketSpender string `storm:"index" json:"ticket_spender,omitempty"` // (DCR Field)
+	Timestamp     int64  `json:"timestamp"`
+	BlockHeight   int32  `json:"block_height"`
+	TicketSpender string `json:"ticket_spender,omitempty"` // (DCR Field)
 
 	MixDenomination int64 `json:"mix_denom,omitempty"` // (DCR Field)
 	MixCount        int32 `json:"mix_count,omitempty"` // (DCR Field)
@@ -313,7 +344,7 @@ type Transaction struct {
 	Size     int    `json:"size"`
 	Label    string `json:"label"`
 
-	Direction int32       `storm:"index" json:"direction"`
+	Direction int32       `json:"direction"`
 	Amount    int64       `json:"amount"`
 	Inputs    []*TxInput  `json:"inputs"`
 	Outputs   []*TxOutput `json:"outputs"`
@@ -323,7 +354,8 @@ type Transaction struct {
 	LastBlockValid     bool   `json:"last_block_valid,omitempty"`
 	VoteBits           string `json:"vote_bits,omitempty"`
 	VoteReward         int64  `json:"vote_reward,omitempty"`
-	TicketSpentHash    string `storm:"unique" json:"ticket_spent_hash,omitempty"`
+	TicketSpentHash    string `json:"ticket_spent_hash,omitempty"`
+	TicketSpentID      string `storm:"unique" json:"ticket_spent_id,omitempty"` // ID of TicketSpentHash
 	DaysToVoteOrRevoke int32  `json:"days_to_vote_revoke,omitempty"`
 }
 
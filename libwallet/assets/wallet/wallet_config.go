@@ -1,9 +1,14 @@
 package wallet
 
 import (
+	"encoding/json"
 	"fmt"
+	"strconv"
+	"strings"
 
 	"github.com/asdine/storm"
+	"github.com/crypto-power/cryptopower/libwallet/internal/dbcrypt"
+	bolt "go.etcd.io/bbolt"
 )
 
 const (
@@ -29,6 +34,9 @@ const (
 	SyncOnCellularConfigKey             = "always_sync"
 	NetworkModeConfigKey                = "network_mode"
 	SpvPersistentPeerAddressesConfigKey = "spv_peer_addresses"
+	SpvBannedPeersConfigKey             = "spv_banned_peers"
+	SpvPreferredPeersConfigKey          = "spv_preferred_peers"
+	SyncDataUsageConfigKey              = "sync_data_usage"
 	UserAgentConfigKey                  = "user_agent"
 
 	PoliteiaNotificationConfigKey = "politeia_notification"
@@ -44,33 +52,94 @@ const (
 
 	ExchangeSourceDstnTypeConfigKey = "exchange_source_destination_key"
 
-	HideBalanceConfigKey             = "hide_balance"
-	AutoSyncConfigKey                = "autoSync"
-	FetchProposalConfigKey           = "fetch_proposals"
-	SeedBackupNotificationConfigKey  = "seed_backup_notification"
-	ProposalNotificationConfigKey    = "proposal_notification_key"
-	TransactionNotificationConfigKey = "transaction_notification_key"
-	SpendUnmixedFundsKey             = "spend_unmixed_funds"
-	LanguagePreferenceKey            = "app_language"
-	DarkModeConfigKey                = "dark_mode"
-	HideTotalBalanceConfigKey        = "hideTotalUSDBalance"
-	IsCEXFirstVisitConfigKey         = "is_cex_first_visit"
-	DBDriverConfigKey                = "db_driver"
+	HideBalanceConfigKey               = "hide_balance"
+	AutoSyncConfigKey                  = "autoSync"
+	FetchProposalConfigKey             = "fetch_proposals"
+	SeedBackupNotificationConfigKey    = "seed_backup_notification"
+	ProposalNotificationConfigKey      = "proposal_notification_key"
+	TransactionNotificationConfigKey   = "transaction_notification_key"
+	SpendUnmixedFundsKey               = "spend_unmixed_funds"
+	LanguagePreferenceKey              = "app_language"
+	DarkModeConfigKey                  = "dark_mode"
+	HideTotalBalanceConfigKey          = "hideTotalUSDBalance"
+	IsCEXFirstVisitConfigKey           = "is_cex_first_visit"
+	DBDriverConfigKey                  = "db_driver"
+	DEXServerOrderConfigKey            = "dex_server_order"
+	RemovedDEXServersConfigKey         = "removed_dex_servers"
+	MultisigConfigKey                  = "multisig_config"
+	MultisigXPubConfigKey              = "multisig_xpub"
+	RestoredTxLabelsConfigKey          = "restored_tx_labels"
+	PendingWalletDBRestoreConfigKey    = "pending_wallet_db_restore"
+	DisableAutoWalletDBBackupConfigKey = "disable_auto_wallet_db_backup"
+	AutoLockTimeoutConfigKey           = "auto_lock_timeout"
+	SyncPolicyConfigKey                = "sync_policy"
+	LastSeedCheckConfigKey             = "last_seed_check"
+	SeedCheckSnoozedUntilConfigKey     = "seed_check_snoozed_until"
+	SpendingPoliciesConfigKey          = "spending_policies"
+	SpendHistoryConfigKey              = "spend_history"
+	KnownDestinationsConfigKey         = "known_destinations"
+	DelayedSendsConfigKey              = "delayed_sends"
+	RPCSyncConfigKey                   = "rpc_sync"
+	ElectrumSyncConfigKey              = "electrum_sync"
+	SyncHistoryConfigKey               = "sync_history"
+	RestoreBirthdayConfigKey           = "restore_birthday"
+	RescanStateConfigKey               = "rescan_state"
 
 	PassphraseTypePin  int32 = 0
 	PassphraseTypePass int32 = 1
 )
 
+// encryptedConfigKeys are the config keys whose values reveal the wallet's
+// history or credentials. Their values are encrypted with the metadata.
+var encryptedConfigKeys = map[string]bool{
+	MultisigConfigKey:          true,
+	MultisigXPubConfigKey:      true,
+	RestoredTxLabelsConfigKey:  true,
+	SpendingPoliciesConfigKey:  true,
+	SpendHistoryConfigKey:      true,
+	KnownDestinationsConfigKey: true,
+	DelayedSendsConfigKey:      true,
+	RPCSyncConfigKey:           true,
+	ElectrumSyncConfigKey:      true,
+}
+
+// isEncryptedConfigKey returns true if the value of key, a config key
+// prefixed with the wallet ID, is encrypted with the metadata.
+func isEncryptedConfigKey(key []byte) bool {
+	return encryptedConfigKeys[strings.TrimLeft(string(key), "0123456789")]
+}
+
+// RecodeConfig re-encodes the encrypted config values of every wallet in db
+// with the current state of codec.
+func RecodeConfig(db *storm.DB, codec *dbcrypt.Codec) error {
+	return codec.RecodeKeys(db.Bolt, walletsMetadataBucketName, isEncryptedConfigKey)
+}
+
+// configNode returns the node the value of key is read and written with.
+func (wallet *Wallet) configNode(key string) storm.Node {
+	if wallet.dbCodec != nil && encryptedConfigKeys[key] {
+		return wallet.db.WithCodec(wallet.dbCodec)
+	}
+	return wallet.db
+}
+
 // walletConfigSave method manages all the write operations.
 func (wallet *Wallet) walletConfigSave(key string, value interface{}) error {
+	if key == RestoredTxLabelsConfigKey {
+		wallet.restoredTxLabelsMu.Lock()
+		wallet.restoredTxLabels = nil
+		wallet.restoredTxLabelsMu.Unlock()
+	}
+	node := wallet.configNode(key)
 	key = fmt.Sprintf("%d%s", wallet.ID, key)
-	return wallet.db.Set(walletsMetadataBucketName, key, value)
+	return node.Set(walletsMetadataBucketName, key, value)
 }
 
 // walletConfigRead manages all the read operations.
 func (wallet *Wallet) walletConfigRead(key string, valueOut interface{}) error {
+	node := wallet.configNode(key)
 	key = fmt.Sprintf("%d%s", wallet.ID, key)
-	return wallet.db.Get(walletsMetadataBucketName, key, valueOut)
+	return node.Get(walletsMetadataBucketName, key, valueOut)
 }
 
 // walletConfigDelete manages all delete operations.
@@ -107,6 +176,50 @@ func (wallet *Wallet) DeleteUserConfigValueForKey(key string) {
 	}
 }
 
+// UserConfigValues returns the raw values of all the config keys stored at
+// the asset level. Encrypted values are decrypted.
+func (wallet *Wallet) UserConfigValues() (map[string]json.RawMessage, error) {
+	prefix := strconv.Itoa(wallet.ID)
+	values := make(map[string]json.RawMessage)
+	err := wallet.db.Bolt.View(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket([]byte(walletsMetadataBucketName))
+		if bucket == nil {
+			return nil
+		}
+		return bucket.ForEach(func(k, v []byte) error {
+			key, ok := strings.CutPrefix(string(k), prefix)
+			// Keys of wallets whose ID starts with the digits of this
+			// wallet's ID are followed by more digits.
+			if !ok || key == "" || (key[0] >= '0' && key[0] <= '9') {
+				return nil
+			}
+			if wallet.dbCodec != nil {
+				var err error
+				if v, err = wallet.dbCodec.Decrypt(v); err != nil {
+					return err
+				}
+			}
+			values[key] = append(json.RawMessage(nil), v...)
+			return nil
+		})
+	})
+	return values, err
+}
+
+// RestoredTxLabel returns the label of txHash saved in the app backup the
+// wallet was restored from, if any.
+func (wallet *Wallet) RestoredTxLabel(txHash string) string {
+	wallet.restoredTxLabelsMu.Lock()
+	defer wallet.restoredTxLabelsMu.Unlock()
+
+	if wallet.restoredTxLabels == nil {
+		labels := make(map[string]string)
+		_ = wallet.walletConfigRead(RestoredTxLabelsConfigKey, &labels)
+		wallet.restoredTxLabels = labels
+	}
+	return wallet.restoredTxLabels[txHash]
+}
+
 // SetBoolConfigValueForKey stores the boolean value against the provided key
 // at the asset level.
 func (wallet *Wallet) SetBoolConfigValueForKey(key string, value bool) {
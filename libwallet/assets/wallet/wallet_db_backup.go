@@ -0,0 +1,252 @@
+package wallet
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"decred.org/dcrwallet/v4/errors"
+	"github.com/crypto-power/cryptopower/libwallet/badgerdb"
+	"github.com/crypto-power/cryptopower/libwallet/utils"
+)
+
+const (
+	// MaxWalletDBBackups is the number of wallet database backups kept for
+	// each wallet. The oldest backups are deleted first.
+	MaxWalletDBBackups = 5
+
+	// WalletDBBackupInterval is the minimum time between two automatic
+	// wallet database backups.
+	WalletDBBackupInterval = 24 * time.Hour
+
+	walletDBName         = "wallet.db"
+	walletDBBackupsDir   = "backups"
+	walletDBBackupPrefix = "wallet-"
+	// walletDBBackupLayout has nanosecond precision so that backups made
+	// within the same second don't overwrite each other.
+	walletDBBackupLayout = "20060102-150405.000000000"
+	// walletDBBackupParseLayout parses the backup names with and without
+	// the fractional seconds of older backups.
+	walletDBBackupParseLayout = "20060102-150405"
+
+	badgerDBDriver = "badgerdb"
+	// Bolt backups are database files, badger backups are backup streams.
+	boltBackupExt   = ".db"
+	badgerBackupExt = ".badger"
+)
+
+// WalletDBBackup is a backup of a wallet database.
+type WalletDBBackup struct {
+	Path      string
+	CreatedAt time.Time
+	Size      int64
+}
+
+// isBadgerDB returns true if the wallet database uses the badger driver. Only
+// DCR wallets can use the badger driver.
+func (wallet *Wallet) isBadgerDB() bool {
+	return wallet.Type == utils.DCRWalletAsset && wallet.dbDriver == badgerDBDriver
+}
+
+func (wallet *Wallet) walletDBBackupExt() string {
+	if wallet.isBadgerDB() {
+		return badgerBackupExt
+	}
+	return boltBackupExt
+}
+
+func (wallet *Wallet) walletDBDir() string {
+	return filepath.Join(wallet.loader.GetDbDirPath(), strconv.Itoa(wallet.ID))
+}
+
+// BackupWalletDB writes a backup of the wallet database while the wallet is
+// in use and deletes the backups beyond MaxWalletDBBackups. It returns the
+// path of the backup.
+func (wallet *Wallet) BackupWalletDB() (string, error) {
+	wallet.dbBackupMu.Lock()
+	defer wallet.dbBackupMu.Unlock()
+
+	backupDir := filepath.Join(wallet.walletDBDir(), walletDBBackupsDir)
+	if err := os.MkdirAll(backupDir, utils.UserFilePerm); err != nil {
+		return "", err
+	}
+
+	backupPath := filepath.Join(backupDir, walletDBBackupName(time.Now(), wallet.walletDBBackupExt()))
+
+	// Write to a temporary file so that interrupted backups are never
+	// listed.
+	tmpPath := backupPath + ".tmp"
+	file, err := os.OpenFile(tmpPath, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o600)
+	if err != nil {
+		return "", err
+	}
+	err = wallet.loader.CopyWalletDB(file)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(tmpPath, backupPath)
+	}
+	if err != nil {
+		os.Remove(tmpPath)
+		return "", err
+	}
+
+	backups, err := wallet.WalletDBBackups()
+	if err != nil {
+		return backupPath, err
+	}
+	for i := MaxWalletDBBackups; i < len(backups); i++ {
+		if err := os.Remove(backups[i].Path); err != nil {
+			log.Errorf("Error deleting wallet database backup: %v", err)
+		}
+	}
+	return backupPath, nil
+}
+
+// walletDBBackupName returns the file name of a backup made at t.
+func walletDBBackupName(t time.Time, ext string) string {
+	return walletDBBackupPrefix + t.UTC().Format(walletDBBackupLayout) + ext
+}
+
+// parseWalletDBBackupName returns when the backup with the file name was made.
+// It returns false if name isn't the name of a backup.
+func parseWalletDBBackupName(name, ext string) (time.Time, bool) {
+	timestamp, ok := strings.CutPrefix(name, walletDBBackupPrefix)
+	if !ok {
+		return time.Time{}, false
+	}
+	timestamp, ok = strings.CutSuffix(timestamp, ext)
+	if !ok {
+		return time.Time{}, false
+	}
+	createdAt, err := time.Parse(walletDBBackupParseLayout, timestamp)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return createdAt, true
+}
+
+// WalletDBBackups returns the wallet database backups, newest first.
+func (wallet *Wallet) WalletDBBackups() ([]*WalletDBBackup, error) {
+	entries, err := os.ReadDir(filepath.Join(wallet.walletDBDir(), walletDBBackupsDir))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	ext := wallet.walletDBBackupExt()
+	var backups []*WalletDBBackup
+	for _, entry := range entries {
+		name := entry.Name()
+		createdAt, ok := parseWalletDBBackupName(name, ext)
+		if !ok {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			return nil, err
+		}
+		backups = append(backups, &WalletDBBackup{
+			Path:      filepath.Join(wallet.walletDBDir(), walletDBBackupsDir, name),
+			CreatedAt: createdAt,
+			Size:      info.Size(),
+		})
+	}
+
+	sort.Slice(backups, func(i, j int) bool {
+		return backups[i].CreatedAt.After(backups[j].CreatedAt)
+	})
+	return backups, nil
+}
+
+// RestoreWalletDB schedules the restore of the wallet database from the
+// backup at backupPath. The wallet database can't be replaced while it is in
+// use, so the backup is restored the next time the wallet is opened.
+func (wallet *Wallet) RestoreWalletDB(backupPath string) error {
+	if !strings.HasSuffix(backupPath, wallet.walletDBBackupExt()) {
+		return errors.E(errors.Invalid, "the backup was made with a different database driver")
+	}
+	if _, err := os.Stat(backupPath); err != nil {
+		return err
+	}
+	return wallet.walletConfigSave(PendingWalletDBRestoreConfigKey, backupPath)
+}
+
+// applyPendingWalletDBRestore replaces the wallet database with the backup
+// scheduled by RestoreWalletDB, if any. The replaced database is kept until
+// the restored database is opened. It returns true if a backup was restored.
+func (wallet *Wallet) applyPendingWalletDBRestore() (bool, error) {
+	var backupPath string
+	if err := wallet.walletConfigRead(PendingWalletDBRestoreConfigKey, &backupPath); err != nil || backupPath == "" {
+		return false, nil
+	}
+	if err := wallet.walletConfigDelete(PendingWalletDBRestoreConfigKey); err != nil {
+		return false, err
+	}
+
+	log.Infof("Restoring the database of wallet %d from %s", wallet.ID, backupPath)
+
+	dbPath := filepath.Join(wallet.walletDBDir(), walletDBName)
+	replacedPath := dbPath + ".replaced"
+	if err := os.RemoveAll(replacedPath); err != nil {
+		return false, err
+	}
+	if err := os.Rename(dbPath, replacedPath); err != nil {
+		return false, err
+	}
+
+	backup, err := os.Open(backupPath)
+	if err == nil {
+		if wallet.isBadgerDB() {
+			err = badgerdb.Restore(dbPath, backup)
+		} else {
+			err = copyToFile(dbPath, backup)
+		}
+		backup.Close()
+	}
+	if err != nil {
+		wallet.finishWalletDBRestore(false)
+		return false, err
+	}
+	return true, nil
+}
+
+// finishWalletDBRestore deletes the database replaced by a restored backup or,
+// if the restored database can't be used, puts the replaced database back.
+func (wallet *Wallet) finishWalletDBRestore(success bool) {
+	dbPath := filepath.Join(wallet.walletDBDir(), walletDBName)
+	replacedPath := dbPath + ".replaced"
+	if success {
+		if err := os.RemoveAll(replacedPath); err != nil {
+			log.Errorf("Error deleting replaced wallet database: %v", err)
+		}
+		return
+	}
+
+	if err := os.RemoveAll(dbPath); err != nil {
+		log.Errorf("Error deleting restored wallet database: %v", err)
+		return
+	}
+	if err := os.Rename(replacedPath, dbPath); err != nil {
+		log.Errorf("Error putting back replaced wallet database: %v", err)
+	}
+}
+
+func copyToFile(path string, r io.Reader) error {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_EXCL, 0o600)
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(file, r)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
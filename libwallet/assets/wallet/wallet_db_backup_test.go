@@ -0,0 +1,33 @@
+package wallet
+
+import (
+	"testing"
+	"time"
+)
+
+func TestWalletDBBackupName(t *testing.T) {
+	first := time.Date(2026, 1, 2, 3, 4, 5, 100, time.UTC)
+	second := first.Add(time.Millisecond)
+
+	name := walletDBBackupName(first, boltBackupExt)
+	if name == walletDBBackupName(second, boltBackupExt) {
+		t.Fatalf("backups made within the same second have the same name %s", name)
+	}
+
+	createdAt, ok := parseWalletDBBackupName(name, boltBackupExt)
+	if !ok || !createdAt.Equal(first) {
+		t.Fatalf("parsed %s as %v, %v", name, createdAt, ok)
+	}
+
+	// Names without fractional seconds are still listed.
+	createdAt, ok = parseWalletDBBackupName("wallet-20260102-030405.db", boltBackupExt)
+	if !ok || !createdAt.Equal(first.Truncate(time.Second)) {
+		t.Fatalf("parsed the old backup name as %v, %v", createdAt, ok)
+	}
+
+	for _, name := range []string{"wallet-20260102-030405.badger", "other-20260102-030405.db", "wallet-latest.db"} {
+		if _, ok := parseWalletDBBackupName(name, boltBackupExt); ok {
+			t.Errorf("%s was parsed as a backup", name)
+		}
+	}
+}
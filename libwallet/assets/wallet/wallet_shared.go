@@ -14,6 +14,7 @@ import (
 	w "decred.org/dcrwallet/v4/wallet"
 	"github.com/asdine/storm"
 	"github.com/crypto-power/cryptopower/libwallet/assets/wallet/walletdata"
+	"github.com/crypto-power/cryptopower/libwallet/internal/dbcrypt"
 	"github.com/crypto-power/cryptopower/libwallet/internal/loader"
 	"github.com/crypto-power/cryptopower/libwallet/utils"
 )
@@ -26,8 +27,12 @@ type Wallet struct {
 	dbDriver  string
 	rootDir   string
 	db        *storm.DB
+	dbCodec   *dbcrypt.Codec
 	logDir    string
 
+	chainBackends *ChainBackends
+	syncGate      *SyncGate
+
 	EncryptedMnemonic     []byte
 	IsBackedUp            bool
 	IsRestored            bool
@@ -60,6 +65,26 @@ type Wallet struct {
 	isCancelDone chan struct{} // waits until all cancelFuncs functions run.
 	cancelFuncs  []context.CancelFunc
 
+	// multisigMu serializes updates to the multisig config.
+	multisigMu sync.Mutex
+
+	// spendingMu serializes updates to the spend history, known destinations
+	// and delayed sends.
+	spendingMu sync.Mutex
+
+	// restoredTxLabels caches the tx labels of RestoredTxLabel, loaded once
+	// and dropped when they are saved again.
+	restoredTxLabels   map[string]string
+	restoredTxLabelsMu sync.Mutex
+
+	// dbBackupMu serializes wallet database backups.
+	dbBackupMu sync.Mutex
+
+	// syncUsageMu protects the data usage of the wallet syncs.
+	syncUsageMu     sync.Mutex
+	syncUsage       *SyncDataUsage
+	unsavedSyncData uint64
+
 	mu sync.RWMutex
 }
 
@@ -71,6 +96,9 @@ func (wallet *Wallet) Prepare(loader loader.AssetLoader, params *InitParams) (er
 	defer wallet.mu.Unlock()
 
 	wallet.db = params.DB
+	wallet.dbCodec = params.DBCodec
+	wallet.chainBackends = params.ChainBackends
+	wallet.syncGate = params.SyncGate
 	wallet.loader = loader
 	wallet.netType = params.NetType
 	wallet.rootDir = params.RootDir
@@ -116,7 +144,7 @@ func (wallet *Wallet) prepare() (err error) {
 	walletDataDBPath := filepath.Join(wallet.dataDir(), dbName)
 
 	// Initialize the walletDataDb
-	walletDb, err := walletdata.Initialize(walletDataDBPath, &Transaction{})
+	walletDb, err := walletdata.Initialize(walletDataDBPath, &Transaction{}, wallet.dbCodec)
 	if err != nil {
 		log.Error(err.Error())
 		return err
@@ -151,6 +179,24 @@ func (wallet *Wallet) prepare() (err error) {
 	return nil
 }
 
+// RecodeMetadata re-encodes the indexed transactions after the metadata
+// encryption is enabled, disabled or its key is changed.
+func (wallet *Wallet) RecodeMetadata() error {
+	if wallet.walletDataDB == nil {
+		return nil
+	}
+	return wallet.walletDataDB.Recode()
+}
+
+// UpgradeMetadata completes the upgrade of the indexed transactions that
+// couldn't be upgraded while the metadata was locked.
+func (wallet *Wallet) UpgradeMetadata() error {
+	if wallet.walletDataDB == nil {
+		return nil
+	}
+	return wallet.walletDataDB.Upgrade()
+}
+
 func (wallet *Wallet) Shutdown() {
 	// Trigger shuttingDown signal to cancel all contexts created with
 	// `wallet.shutdownContextWithCancel()`.
@@ -208,13 +254,19 @@ func (wallet *Wallet) DataDir() string {
 }
 
 func (wallet *Wallet) dataDir() string {
+	return filepath.Join(wallet.assetDir(), strconv.Itoa(wallet.ID))
+}
+
+// assetDir returns the directory holding the data dirs of the wallets of the
+// same asset and network.
+func (wallet *Wallet) assetDir() string {
 	dirName := ""
 	// testnet datadir takes a special structure to differentiate "testnet4" and "testnet3"
 	// data directory.
 	if wallet.netType == utils.Testnet {
 		dirName = utils.NetDir(wallet.Type, wallet.netType)
 	}
-	return filepath.Join(wallet.rootDir, dirName, wallet.Type.ToStringLower(), strconv.Itoa(wallet.ID))
+	return filepath.Join(wallet.rootDir, dirName, wallet.Type.ToStringLower())
 }
 
 // RootDir returns the root of current wallet bucket. It is exported via the interface
@@ -394,6 +446,9 @@ func CreateNewWallet(pass *AuthInfo, loader loader.AssetLoader,
 		Name:                  pass.Name,
 		db:                    params.DB,
 		dbDriver:              params.DbDriver,
+		dbCodec:               params.DBCodec,
+		chainBackends:         params.ChainBackends,
+		syncGate:              params.SyncGate,
 		rootDir:               params.RootDir,
 		logDir:                params.LogDir,
 		CreatedAt:             time.Now(),
@@ -458,11 +513,14 @@ func CreateWatchOnlyWallet(walletName, extendedPublicKey string, loader loader.A
 	params *InitParams, assetType utils.AssetType,
 ) (*Wallet, error) {
 	wallet := &Wallet{
-		Name:     walletName,
-		db:       params.DB,
-		dbDriver: params.DbDriver,
-		rootDir:  params.RootDir,
-		logDir:   params.LogDir,
+		Name:          walletName,
+		db:            params.DB,
+		dbDriver:      params.DbDriver,
+		dbCodec:       params.DBCodec,
+		chainBackends: params.ChainBackends,
+		syncGate:      params.SyncGate,
+		rootDir:       params.RootDir,
+		logDir:        params.LogDir,
 
 		IsRestored: true,
 		// Setting HasDiscoveredAccounts to false causes address recovery to be
@@ -526,6 +584,9 @@ func RestoreWallet(seedMnemonic string, pass *AuthInfo, loader loader.AssetLoade
 		PrivatePassphraseType: pass.PrivatePassType,
 		db:                    params.DB,
 		dbDriver:              params.DbDriver,
+		dbCodec:               params.DBCodec,
+		chainBackends:         params.ChainBackends,
+		syncGate:              params.SyncGate,
 		rootDir:               params.RootDir,
 		logDir:                params.LogDir,
 
@@ -546,6 +607,8 @@ func RestoreWallet(seedMnemonic string, pass *AuthInfo, loader loader.AssetLoade
 	}); err != nil {
 		return nil, err
 	}
+	// Restoring the wallet proves the user has the seed.
+	wallet.recordSeedCheck()
 	if params.NetType == utils.DEXTest {
 		addr := "127.0.0.1"
 		if params.DEXTestAddr != "" {
@@ -663,12 +726,28 @@ func (wallet *Wallet) IsWatchingOnlyWallet() bool {
 func (wallet *Wallet) OpenWallet() error {
 	pubPass := []byte(w.InsecurePubPassphrase)
 	ctx, _ := wallet.ShutdownContextWithCancel()
-	_, err := wallet.loader.OpenExistingWallet(ctx, strconv.Itoa(wallet.ID), pubPass)
+
+	restored, err := wallet.applyPendingWalletDBRestore()
+	if err != nil {
+		log.Errorf("Error restoring wallet database backup: %v", err)
+	}
+
+	_, err = wallet.loader.OpenExistingWallet(ctx, strconv.Itoa(wallet.ID), pubPass)
+	if err != nil && restored {
+		// Open the database that was in use before the restore.
+		log.Errorf("Error opening restored wallet database: %v", err)
+		wallet.finishWalletDBRestore(false)
+		restored = false
+		_, err = wallet.loader.OpenExistingWallet(ctx, strconv.Itoa(wallet.ID), pubPass)
+	}
 	if err != nil {
 		log.Error(err)
 		return utils.TranslateError(err)
 	}
 
+	if restored {
+		wallet.finishWalletDBRestore(true)
+	}
 	return nil
 }
 
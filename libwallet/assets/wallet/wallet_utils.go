@@ -138,6 +138,7 @@ func (wallet *Wallet) VerifySeedForWallet(seedMnemonic, privpass string) (bool,
 	}
 
 	if decryptedMnemonic == seedMnemonic {
+		wallet.recordSeedCheck()
 		if wallet.IsBackedUp {
 			return true, nil // return early
 		}
@@ -2,6 +2,7 @@ package walletdata
 
 import (
 	"io"
+	"time"
 
 	"github.com/btcsuite/btcwallet/walletdb"
 	"go.etcd.io/bbolt"
@@ -11,6 +12,16 @@ type BTCDB struct {
 	Bolt *bbolt.DB
 }
 
+// OpenBTCDB opens or creates the database at path for a chain service that
+// doesn't store its data in a wallet data database.
+func OpenBTCDB(path string) (*BTCDB, error) {
+	db, err := bbolt.Open(path, 0o600, &bbolt.Options{Timeout: time.Second})
+	if err != nil {
+		return nil, err
+	}
+	return &BTCDB{Bolt: db}, nil
+}
+
 // Enforce db implements the walletdb.Db interface.
 var _ walletdb.DB = (*BTCDB)(nil)
 
@@ -3,8 +3,13 @@ package walletdata
 import (
 	"fmt"
 	"os"
+	"reflect"
+	"time"
 
+	"decred.org/dcrwallet/v4/errors"
 	"github.com/asdine/storm"
+	"github.com/asdine/storm/codec/json"
+	"github.com/crypto-power/cryptopower/libwallet/internal/dbcrypt"
 	bolt "go.etcd.io/bbolt"
 )
 
@@ -17,15 +22,29 @@ const (
 	TxBucketName = "TxIndexInfo"
 	KeyDbVersion = "DbVersion"
 
+	// stormInfoBucketName and stormVersionKey locate storm's version of the
+	// database.
+	stormInfoBucketName = "__storm_db"
+	stormVersionKey     = "version"
+
 	// TxDbVersion is necessary to force re-indexing if changes are made to the structure of data being stored.
 	// Increment this version number if db structure changes such that client apps need to re-index.
-	TxDbVersion uint32 = 3
+	TxDbVersion uint32 = 4
+
+	// unblindedTxDbVersion is the version that keyed and indexed the
+	// transactions by their hashes. Its transactions are re-keyed rather
+	// than re-indexed, since the labels of DCR transactions are only saved
+	// in this database.
+	unblindedTxDbVersion uint32 = 3
 )
 
 type DB struct {
 	BTC            *BTCDB
 	LTC            *LTCDB
 	walletDataDB   *storm.DB
+	codec          *dbcrypt.Codec
+	txData         interface{}
+	txBucketName   string
 	ticketMaturity int32
 	ticketExpiry   int32
 	Path           string
@@ -35,8 +54,11 @@ type DB struct {
 // and checks the database version for compatibility.
 // If there is a version mismatch or the db does not exist at `dbPath`,
 // a new db is created and the current db version number saved to the db.
-func Initialize(dbPath string, txData interface{}) (*DB, error) {
-	walletDataDB, err := openOrCreateDB(dbPath)
+// The transactions are encoded with codec, if it isn't nil, while the
+// database version and the indexing progress are always stored unencrypted
+// since they are read before the codec's key is set.
+func Initialize(dbPath string, txData interface{}, codec *dbcrypt.Codec) (*DB, error) {
+	walletDataDB, err := openOrCreateDB(dbPath, codec)
 	if err != nil {
 		return nil, err
 	}
@@ -52,7 +74,7 @@ func Initialize(dbPath string, txData interface{}) (*DB, error) {
 		return nil, fmt.Errorf("error initializing tx bucket for wallet: %s", err.Error())
 	}
 
-	return &DB{
+	db := &DB{
 		BTC: &BTCDB{
 			Bolt: walletDataDB.Bolt,
 		},
@@ -60,8 +82,18 @@ func Initialize(dbPath string, txData interface{}) (*DB, error) {
 			Bolt: walletDataDB.Bolt,
 		},
 		walletDataDB: walletDataDB,
+		codec:        codec,
+		txData:       txData,
+		txBucketName: reflect.Indirect(reflect.ValueOf(txData)).Type().Name(),
 		Path:         dbPath,
-	}, nil
+	}
+
+	// The transactions of an encrypted database are upgraded once the
+	// metadata is unlocked.
+	if err := db.Upgrade(); err != nil && !errors.Is(err, dbcrypt.ErrLocked) {
+		return nil, fmt.Errorf("error upgrading wallet data database: %s", err.Error())
+	}
+	return db, nil
 }
 
 // SetTicketMaturity sets the ticket maturity value required when filterig txs.
@@ -81,7 +113,103 @@ func (db *DB) Close() error {
 	return db.walletDataDB.Close()
 }
 
-func openOrCreateDB(dbPath string) (*storm.DB, error) {
+// Upgrade re-keys the transactions saved by the version of the database that
+// keyed and indexed them by their hashes. The transactions of an encrypted
+// database can't be upgraded before the codec's key is set.
+func (db *DB) Upgrade() error {
+	var currentDbVersion uint32
+	if err := db.config().Get(TxBucketName, KeyDbVersion, &currentDbVersion); err != nil {
+		return err
+	}
+	if currentDbVersion == TxDbVersion {
+		return nil
+	}
+	if db.codec != nil && db.codec.IsLocked() {
+		return dbcrypt.ErrLocked
+	}
+
+	if err := db.Recode(); err != nil {
+		return err
+	}
+	return db.config().Set(TxBucketName, KeyDbVersion, TxDbVersion)
+}
+
+// Recode re-encodes the transactions with the current state of the codec.
+// The transactions are saved again rather than re-encoded in place since
+// their IDs change with the codec's key.
+func (db *DB) Recode() error {
+	tx, err := db.walletDataDB.Begin(true)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	records := reflect.New(reflect.SliceOf(reflect.TypeOf(db.txData)))
+	if err := tx.All(records.Interface()); err != nil {
+		return err
+	}
+	// Dropping the bucket also deletes the indexes of the older versions.
+	if err := tx.Drop(db.txData); err != nil {
+		return err
+	}
+	if err := tx.Init(db.txData); err != nil {
+		return err
+	}
+
+	records = records.Elem()
+	for i := 0; i < records.Len(); i++ {
+		record := records.Index(i)
+		if err := db.setIDs(record); err != nil {
+			return err
+		}
+		if err := tx.Save(record.Interface()); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+// blindID returns the ID a transaction with the hash is saved with.
+func (db *DB) blindID(hash string) (string, error) {
+	if db.codec == nil {
+		return hash, nil
+	}
+	return db.codec.BlindID(hash)
+}
+
+// setIDs sets the IDs of the transaction record from its hashes.
+func (db *DB) setIDs(record reflect.Value) error {
+	v := reflect.Indirect(record)
+	for hashField, idField := range blindedFields {
+		hash := v.FieldByName(hashField)
+		if !hash.IsValid() {
+			continue
+		}
+		var id string
+		if hash.String() != "" {
+			var err error
+			if id, err = db.blindID(hash.String()); err != nil {
+				return err
+			}
+		}
+		v.FieldByName(idField).SetString(id)
+	}
+	return nil
+}
+
+// config returns the node used to read and write the unencrypted database
+// version and indexing progress.
+func (db *DB) config() storm.Node {
+	return configNode(db.walletDataDB)
+}
+
+func configNode(walletDataDB *storm.DB) storm.Node {
+	return walletDataDB.WithCodec(json.Codec)
+}
+
+func openOrCreateDB(dbPath string, codec *dbcrypt.Codec) (*storm.DB, error) {
 	var isNewDbFile bool
 
 	// first check if db file exists at dbPath, if not we'll need to create it and set the db version
@@ -93,7 +221,7 @@ func openOrCreateDB(dbPath string) (*storm.DB, error) {
 		}
 	}
 
-	walletDataDB, err := storm.Open(dbPath)
+	boltDB, err := bolt.Open(dbPath, 0o600, &bolt.Options{Timeout: time.Second})
 	if err != nil {
 		switch err {
 		case bolt.ErrTimeout:
@@ -104,8 +232,33 @@ func openOrCreateDB(dbPath string) (*storm.DB, error) {
 		}
 	}
 
+	// Storm reads its version of the database with the codec of the
+	// database when it is opened, before the codec's key is set. Earlier
+	// versions encrypted it, so it is deleted for storm to write it again.
+	err = boltDB.Update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket([]byte(stormInfoBucketName))
+		if bucket == nil || !dbcrypt.IsEncrypted(bucket.Get([]byte(stormVersionKey))) {
+			return nil
+		}
+		return bucket.Delete([]byte(stormVersionKey))
+	})
+	if err != nil {
+		boltDB.Close()
+		return nil, fmt.Errorf("error opening wallet data database: %s", err.Error())
+	}
+
+	walletDataDB, err := storm.Open(dbPath, storm.UseDB(boltDB))
+	if err != nil {
+		boltDB.Close()
+		return nil, fmt.Errorf("error opening wallet data database: %s", err.Error())
+	}
+	if codec != nil {
+		// Only the records are encoded with the codec.
+		walletDataDB.Node = walletDataDB.WithCodec(codec)
+	}
+
 	if isNewDbFile {
-		err = walletDataDB.Set(TxBucketName, KeyDbVersion, TxDbVersion)
+		err = configNode(walletDataDB).Set(TxBucketName, KeyDbVersion, TxDbVersion)
 		if err != nil {
 			os.RemoveAll(dbPath)
 			return nil, fmt.Errorf("error initializing wallet data db: %s", err.Error())
@@ -119,23 +272,36 @@ func openOrCreateDB(dbPath string) (*storm.DB, error) {
 // If there's a difference, the current wallet data db file is deleted and a new one created.
 func ensureTxDatabaseVersion(walletDataDB *storm.DB, _ string, txData interface{}) (*storm.DB, error) {
 	var currentDbVersion uint32
-	err := walletDataDB.Get(TxBucketName, KeyDbVersion, &currentDbVersion)
+	err := configNode(walletDataDB).Get(TxBucketName, KeyDbVersion, &currentDbVersion)
 	if err != nil && err != storm.ErrNotFound {
 		// ignore key not found errors as earlier db versions did not set a version number in the db.
 		return nil, fmt.Errorf("error checking wallet data database version: %s", err.Error())
 	}
 
-	if currentDbVersion != TxDbVersion {
+	if currentDbVersion != TxDbVersion && currentDbVersion != unblindedTxDbVersion {
 		if err = walletDataDB.Drop(txData); err != nil {
 			return nil, fmt.Errorf("error deleting outdated wallet data database: %s", err.Error())
 		}
 
-		if err = walletDataDB.Set(TxBucketName, KeyDbVersion, TxDbVersion); err != nil {
+		if err = configNode(walletDataDB).Set(TxBucketName, KeyDbVersion, TxDbVersion); err != nil {
 			return nil, fmt.Errorf("error updating tx db version: %s", err.Error())
 		}
 
-		return walletDataDB, walletDataDB.Set(TxBucketName, KeyEndBlock, 0) // reset tx index
+		return walletDataDB, configNode(walletDataDB).Set(TxBucketName, KeyEndBlock, 0) // reset tx index
 	}
 
 	return walletDataDB, nil
 }
+
+// DropBuckets deletes the top level buckets names from the database, if they
+// exist.
+func (db *DB) DropBuckets(names ...[]byte) error {
+	return db.walletDataDB.Bolt.Update(func(tx *bolt.Tx) error {
+		for _, name := range names {
+			if err := tx.DeleteBucket(name); err != nil && err != bolt.ErrBucketNotFound {
+				return err
+			}
+		}
+		return nil
+	})
+}
@@ -0,0 +1,125 @@
+package walletdata
+
+import (
+	"bytes"
+	"path/filepath"
+	"testing"
+
+	"decred.org/dcrwallet/v4/errors"
+	"github.com/asdine/storm"
+	"github.com/crypto-power/cryptopower/libwallet/internal/dbcrypt"
+	"github.com/kevinburke/nacl"
+	bolt "go.etcd.io/bbolt"
+)
+
+type Transaction struct {
+	ID              string `storm:"id"`
+	Hash            string
+	Label           string
+	Timestamp       int64
+	TicketSpentHash string
+	TicketSpentID   string `storm:"unique"`
+}
+
+func TestUpgradeBlindsTxIDs(t *testing.T) {
+	const (
+		voteHash   = "vote-hash"
+		ticketHash = "ticket-hash"
+	)
+	dbPath := filepath.Join(t.TempDir(), DCRDbName)
+	codec := dbcrypt.NewCodec()
+	key := nacl.NewKey()
+	codec.SetKeys(key, nil)
+	codec.Enable(true)
+
+	// Save the transactions the way version 3 did.
+	{
+		type Transaction struct {
+			Hash            string `storm:"id,unique"`
+			Label           string
+			Timestamp       int64  `storm:"index"`
+			TicketSpentHash string `storm:"unique"`
+		}
+		db, err := storm.Open(dbPath, storm.Codec(codec))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := configNode(db).Set(TxBucketName, KeyDbVersion, unblindedTxDbVersion); err != nil {
+			t.Fatal(err)
+		}
+		for _, tx := range []*Transaction{
+			{Hash: ticketHash, Label: "ticket", Timestamp: 1},
+			{Hash: voteHash, Timestamp: 2, TicketSpentHash: ticketHash},
+		} {
+			if err := db.Save(tx); err != nil {
+				t.Fatal(err)
+			}
+		}
+		db.Close()
+	}
+
+	// The transactions aren't upgraded while the codec is locked.
+	codec.SetKeys(nil, nil)
+	db, err := Initialize(dbPath, &Transaction{}, codec)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := db.Upgrade(); !errors.Is(err, dbcrypt.ErrLocked) {
+		t.Fatalf("expected ErrLocked, got %v", err)
+	}
+
+	codec.SetKeys(key, nil)
+	if err := db.Upgrade(); err != nil {
+		t.Fatal(err)
+	}
+	assertTxs := func() {
+		t.Helper()
+		var tx Transaction
+		if err := db.FindOne("Hash", ticketHash, &tx); err != nil || tx.Label != "ticket" {
+			t.Fatalf("unexpected ticket %+v: %v", tx, err)
+		}
+		if err := db.FindOne("TicketSpentHash", ticketHash, &tx); err != nil || tx.Hash != voteHash {
+			t.Fatalf("unexpected vote %+v: %v", tx, err)
+		}
+		err := db.walletDataDB.Bolt.View(func(btx *bolt.Tx) error {
+			return btx.Bucket([]byte("Transaction")).ForEach(func(k, v []byte) error {
+				if v == nil {
+					return btx.Bucket([]byte("Transaction")).Bucket(k).ForEach(func(k, _ []byte) error {
+						if bytes.Contains(k, []byte("hash")) {
+							t.Fatalf("index key %q written unencrypted", k)
+						}
+						return nil
+					})
+				}
+				if bytes.Contains(k, []byte("hash")) || bytes.Contains(v, []byte("hash")) {
+					t.Fatalf("transaction %q written unencrypted", k)
+				}
+				return nil
+			})
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	assertTxs()
+
+	// The IDs change with the key.
+	newKey := nacl.NewKey()
+	codec.SetKeys(newKey, key)
+	if err := db.Recode(); err != nil {
+		t.Fatal(err)
+	}
+	codec.SetKeys(newKey, nil)
+	assertTxs()
+
+	// New transactions replace the saved ones with the same hash.
+	if _, err := db.SaveOrUpdate(&Transaction{}, &Transaction{Hash: ticketHash, Timestamp: 1}); err != nil {
+		t.Fatal(err)
+	}
+	var txs []*Transaction
+	if err := db.walletDataDB.All(&txs); err != nil || len(txs) != 2 {
+		t.Fatalf("unexpected transactions %v: %v", txs, err)
+	}
+	assertTxs()
+	db.Close()
+}
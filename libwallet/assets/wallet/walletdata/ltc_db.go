@@ -2,6 +2,7 @@ package walletdata
 
 import (
 	"io"
+	"time"
 
 	"github.com/dcrlabs/ltcwallet/walletdb"
 	"go.etcd.io/bbolt"
@@ -11,6 +12,16 @@ type LTCDB struct {
 	Bolt *bbolt.DB
 }
 
+// OpenLTCDB opens or creates the database at path for a chain service that
+// doesn't store its data in a wallet data database.
+func OpenLTCDB(path string) (*LTCDB, error) {
+	db, err := bbolt.Open(path, 0o600, &bbolt.Options{Timeout: time.Second})
+	if err != nil {
+		return nil, err
+	}
+	return &LTCDB{Bolt: db}, nil
+}
+
 // Enforce db implements the walletdb.Db interface.
 var _ walletdb.DB = (*LTCDB)(nil)
 
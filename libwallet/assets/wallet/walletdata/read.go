@@ -12,7 +12,7 @@ const MaxReOrgBlocks = 6
 // Otherwise, 0 is returned to begin indexing from height 0.
 func (db *DB) ReadIndexingStartBlock() (int32, error) {
 	var startBlockHeight int32
-	err := db.walletDataDB.Get(TxBucketName, KeyEndBlock, &startBlockHeight)
+	err := db.config().Get(TxBucketName, KeyEndBlock, &startBlockHeight)
 	if err != nil && err != storm.ErrNotFound {
 		return 0, err
 	}
@@ -71,7 +71,17 @@ func (db *DB) Find(matcher q.Matcher, transactions interface{}) error {
 	return nil
 }
 
+// FindOne finds the transaction whose fieldName field is value. Transactions
+// are looked up by hash with the IDs derived from the hash.
 func (db *DB) FindOne(fieldName string, value interface{}, obj interface{}) error {
+	if idField, ok := blindedFields[fieldName]; ok {
+		hash, _ := value.(string)
+		id, err := db.blindID(hash)
+		if err != nil {
+			return err
+		}
+		return db.walletDataDB.One(idField, id, obj)
+	}
 	return db.walletDataDB.One(fieldName, value, obj)
 }
 
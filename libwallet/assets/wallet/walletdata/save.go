@@ -10,12 +10,22 @@ import (
 
 const KeyEndBlock = "EndBlock"
 
+// blindedFields maps the hash fields of the transactions to the fields of the
+// IDs the transactions are saved and looked up with.
+var blindedFields = map[string]string{
+	"Hash":            "ID",
+	"TicketSpentHash": "TicketSpentID",
+}
+
 // SaveOrUpdate saves a transaction to the database and would overwrite
 // if a transaction with same hash exists
 func (db *DB) SaveOrUpdate(emptyTxPointer, record interface{}) (overwritten bool, err error) {
 	v := reflect.ValueOf(record)
-	txHash := reflect.Indirect(v).FieldByName("Hash").String()
-	err = db.walletDataDB.One("Hash", txHash, emptyTxPointer)
+	if err = db.setIDs(v); err != nil {
+		return
+	}
+	txID := reflect.Indirect(v).FieldByName("ID").String()
+	err = db.walletDataDB.One("ID", txID, emptyTxPointer)
 	if err != nil && err != storm.ErrNotFound {
 		err = errors.Errorf("error checking if record was already indexed: %s", err.Error())
 		return
@@ -44,8 +54,11 @@ func (db *DB) SaveOrUpdate(emptyTxPointer, record interface{}) (overwritten bool
 
 func (db *DB) SaveOrUpdateVspdRecord(emptyTxPointer, record interface{}) (updated bool, err error) {
 	v := reflect.ValueOf(record)
-	txHash := reflect.Indirect(v).FieldByName("Hash").String()
-	err = db.walletDataDB.One("Hash", txHash, emptyTxPointer)
+	if err = db.setIDs(v); err != nil {
+		return
+	}
+	txID := reflect.Indirect(v).FieldByName("ID").String()
+	err = db.walletDataDB.One("ID", txID, emptyTxPointer)
 	if err != nil && err != storm.ErrNotFound {
 		err = errors.Errorf("error checking if record was already indexed: %s", err.Error())
 		return
@@ -62,7 +75,7 @@ func (db *DB) SaveOrUpdateVspdRecord(emptyTxPointer, record interface{}) (update
 
 func (db *DB) LastIndexPoint() (int32, error) {
 	var endBlockHeight int32
-	err := db.walletDataDB.Get(TxBucketName, KeyEndBlock, &endBlockHeight)
+	err := db.config().Get(TxBucketName, KeyEndBlock, &endBlockHeight)
 	if err != nil && err != storm.ErrNotFound {
 		return 0, err
 	}
@@ -71,7 +84,7 @@ func (db *DB) LastIndexPoint() (int32, error) {
 }
 
 func (db *DB) SaveLastIndexPoint(endBlockHeight int32) error {
-	err := db.walletDataDB.Set(TxBucketName, KeyEndBlock, &endBlockHeight)
+	err := db.config().Set(TxBucketName, KeyEndBlock, &endBlockHeight)
 	if err != nil {
 		return fmt.Errorf("error setting block height for last indexed tx: %s", err.Error())
 	}
@@ -113,19 +113,36 @@ func (mgr *AssetsManager) ChangeStartupPassphrase(oldPassphrase, newPassphrase s
 		return err
 	}
 
-	mgr.SaveAppConfigValue(walletStartupPassphraseField, startupPassphraseHash)
+	keys, err := mgr.readMetadataKeys()
+	if err != nil {
+		return err
+	}
+	if keys != nil {
+		// Replace the metadata key along with the passphrase.
+		if err := mgr.rotateMetadataKey(keys, oldPassphrase, newPassphrase, startupPassphraseHash); err != nil {
+			return err
+		}
+	} else {
+		mgr.SaveAppConfigValue(walletStartupPassphraseField, startupPassphraseHash)
+	}
 	mgr.SaveAppConfigValue(sharedW.IsStartupSecuritySetConfigKey, true)
 	mgr.SaveAppConfigValue(sharedW.StartupSecurityTypeConfigKey, passphraseType)
 	return nil
 }
 
-// RemoveStartupPassphrase removes the startup passphrase for the wallet.
+// RemoveStartupPassphrase removes the startup passphrase for the wallet. The
+// metadata databases are decrypted since their key is protected by the
+// passphrase.
 func (mgr *AssetsManager) RemoveStartupPassphrase(oldPassphrase string) error {
 	err := mgr.VerifyStartupPassphrase(oldPassphrase)
 	if err != nil {
 		return err
 	}
 
+	if err := mgr.DisableMetadataEncryption(oldPassphrase); err != nil {
+		return err
+	}
+
 	mgr.appConfigDelete(walletStartupPassphraseField)
 	mgr.SaveAppConfigValue(sharedW.IsStartupSecuritySetConfigKey, false)
 	mgr.appConfigDelete(sharedW.StartupSecurityTypeConfigKey)
@@ -307,6 +324,90 @@ func (mgr *AssetsManager) SetTotalBalanceVisibility(data bool) {
 	mgr.SaveAppConfigValue(sharedW.HideTotalBalanceConfigKey, data)
 }
 
+// GetDEXServerOrder returns the DEX server hosts in the order they were
+// arranged by the user.
+func (mgr *AssetsManager) GetDEXServerOrder() []string {
+	var hosts []string
+	mgr.ReadAppConfigValue(sharedW.DEXServerOrderConfigKey, &hosts)
+	return hosts
+}
+
+// SetDEXServerOrder saves the order in which DEX servers should be displayed.
+func (mgr *AssetsManager) SetDEXServerOrder(hosts []string) {
+	mgr.SaveAppConfigValue(sharedW.DEXServerOrderConfigKey, hosts)
+}
+
+// RemovedDEXServers returns the hosts of the DEX servers removed by the user.
+// The DEX client has no way to delete an account, so removed servers are
+// disabled and hidden from the app.
+func (mgr *AssetsManager) RemovedDEXServers() []string {
+	var hosts []string
+	mgr.ReadAppConfigValue(sharedW.RemovedDEXServersConfigKey, &hosts)
+	return hosts
+}
+
+// IsDEXServerRemoved checks if the DEX server at host was removed by the user.
+func (mgr *AssetsManager) IsDEXServerRemoved(host string) bool {
+	for _, removed := range mgr.RemovedDEXServers() {
+		if removed == host {
+			return true
+		}
+	}
+	return false
+}
+
+// SetDEXServerRemoved marks the DEX server at host as removed or restores it.
+// Removed servers are also dropped from the saved server order.
+func (mgr *AssetsManager) SetDEXServerRemoved(host string, removed bool) {
+	hosts := make([]string, 0)
+	for _, h := range mgr.RemovedDEXServers() {
+		if h != host {
+			hosts = append(hosts, h)
+		}
+	}
+	if !removed {
+		mgr.SaveAppConfigValue(sharedW.RemovedDEXServersConfigKey, hosts)
+		return
+	}
+
+	mgr.SaveAppConfigValue(sharedW.RemovedDEXServersConfigKey, append(hosts, host))
+	order := make([]string, 0)
+	for _, h := range mgr.GetDEXServerOrder() {
+		if h != host {
+			order = append(order, h)
+		}
+	}
+	mgr.SetDEXServerOrder(order)
+}
+
+// IsAutoWalletDBBackupEnabled checks if the wallet databases are backed up
+// periodically. Automatic backups are enabled by default.
+func (mgr *AssetsManager) IsAutoWalletDBBackupEnabled() bool {
+	var disabled bool
+	mgr.ReadAppConfigValue(sharedW.DisableAutoWalletDBBackupConfigKey, &disabled)
+	return !disabled
+}
+
+// SetAutoWalletDBBackup enables or disables the periodic wallet database
+// backups.
+func (mgr *AssetsManager) SetAutoWalletDBBackup(enabled bool) {
+	mgr.SaveAppConfigValue(sharedW.DisableAutoWalletDBBackupConfigKey, !enabled)
+}
+
+// GetAutoLockTimeout returns the period of inactivity after which the app is
+// locked. The app is never locked if it is zero.
+func (mgr *AssetsManager) GetAutoLockTimeout() time.Duration {
+	var minutes int
+	mgr.ReadAppConfigValue(sharedW.AutoLockTimeoutConfigKey, &minutes)
+	return time.Duration(minutes) * time.Minute
+}
+
+// SetAutoLockTimeout sets the period of inactivity after which the app is
+// locked. The timeout is saved in minutes.
+func (mgr *AssetsManager) SetAutoLockTimeout(timeout time.Duration) {
+	mgr.SaveAppConfigValue(sharedW.AutoLockTimeoutConfigKey, int(timeout/time.Minute))
+}
+
 func genKey(prefix, identifier interface{}) string {
 	return fmt.Sprintf("%v-%v", prefix, identifier)
 }
@@ -355,6 +456,13 @@ func (mgr *AssetsManager) GetTargetTimePerBlock(assetType utils.AssetType, netwo
 	}
 }
 
+// BlockHeightForDate estimates the height of the block of the provided asset
+// type mined at date on the current network.
+func (mgr *AssetsManager) BlockHeightForDate(assetType utils.AssetType, date time.Time) int32 {
+	network := mgr.NetType()
+	return sharedW.EstimateBlockHeight(mgr.GetGenesisTimestamp(assetType, network), mgr.GetTargetTimePerBlock(assetType, network), date)
+}
+
 // IsInternalStorageSufficient checks if the available disk space is sufficient for the
 // wallet's operations.
 func (mgr *AssetsManager) IsInternalStorageSufficient(assetType utils.AssetType, network utils.NetworkType) (bool, int64, uint64) {
@@ -19,11 +19,13 @@ import (
 	"github.com/crypto-power/cryptopower/dexc"
 	"github.com/crypto-power/cryptopower/libwallet/ext"
 	"github.com/crypto-power/cryptopower/libwallet/instantswap"
+	"github.com/crypto-power/cryptopower/libwallet/internal/dbcrypt"
 	"github.com/crypto-power/cryptopower/libwallet/internal/politeia"
 	"github.com/crypto-power/cryptopower/libwallet/utils"
 	libutils "github.com/crypto-power/cryptopower/libwallet/utils"
 	"github.com/crypto-power/cryptopower/ui/notification"
 	"github.com/crypto-power/cryptopower/ui/values"
+	"github.com/jrick/logrotate/rotator"
 	bolt "go.etcd.io/bbolt"
 
 	"github.com/crypto-power/cryptopower/libwallet/assets/btc"
@@ -84,6 +86,15 @@ type AssetsManager struct {
 	//TODO: some time need show message for user. Change it if has other solution
 	toast *notification.Toast
 
+	syncProgress  *syncProgressTracker
+	syncHistoryMu sync.Mutex
+
+	// logRotator writes the logs of the assets manager. An assets manager
+	// opened in place of another one, on the switch of the network or to the
+	// decoy profile, writes to its own rotator before the other one is shut
+	// down.
+	logRotator *rotator.Rotator
+
 	NeedMigrate bool
 }
 
@@ -114,6 +125,10 @@ func initializeAssetsFields(rootDir, dbDriver, logDir string, netType utils.Netw
 		NetType:     netType,
 		LogDir:      logDir,
 		DEXTestAddr: dexTestAddr,
+		DBCodec:     dbcrypt.NewCodec(),
+
+		ChainBackends: sharedW.NewChainBackends(),
+		SyncGate:      sharedW.NewSyncGate(),
 	}
 
 	mgr := &AssetsManager{
@@ -146,31 +161,74 @@ func fileExists(filePath string) bool {
 	return true
 }
 
-// NewAssetsManager creates a new AssetsManager instance.
-func NewAssetsManager(rootDir, logDir string, netType utils.NetworkType, dexTestAddr string) (*AssetsManager, error) {
+// NewAssetsManager creates a new AssetsManager instance. dbDriver is the
+// database driver to use, the default driver of the platform is used if it is
+// empty. If the app data was created with the other driver, it is migrated to
+// dbDriver.
+func NewAssetsManager(rootDir, logDir string, netType utils.NetworkType, dexTestAddr, dbDriver string) (*AssetsManager, error) {
 	errors.Separator = ":: "
 	needMigrate := false
 	isMobile := appos.Current().IsMobile()
 
-	dbDriver := BoltDB
-	if isMobile {
-		dbDriver = BadgerDB
+	migrate := dbDriver != ""
+	if !migrate {
+		dbDriver = BoltDB
+		if isMobile {
+			dbDriver = BadgerDB
+		}
+	} else if dbDriver != BoltDB && dbDriver != BadgerDB {
+		return nil, errors.Errorf("unsupported database driver %q", dbDriver)
 	}
 
-	if fileExists(filepath.Join(rootDir, fmt.Sprintf("%s-%s", string(netType), dbDriver))) {
+	dbDir := dbDriverDir(rootDir, netType, dbDriver)
+	otherDBDir := dbDriverDir(rootDir, netType, otherDBDriver(dbDriver))
+	oldDBDir := filepath.Join(rootDir, string(netType))
+	switch {
+	case fileExists(dbDir):
 		// New db
-		rootDir = filepath.Join(rootDir, fmt.Sprintf("%s-%s", string(netType), dbDriver))
-	} else if fileExists(filepath.Join(rootDir, string(netType))) {
-		// old db
+		rootDir = dbDir
+	case fileExists(otherDBDir) && migrate:
+		if err := migrateDBDriver(otherDBDir, otherDBDriver(dbDriver), dbDir, dbDriver, netType); err != nil {
+			return nil, errors.Errorf("failed to migrate to the %s database driver: %v", dbDriver, err)
+		}
+		rootDir = dbDir
+	case fileExists(otherDBDir):
+		// The data was migrated to the other driver.
+		dbDriver = otherDBDriver(dbDriver)
+		rootDir = otherDBDir
+	case fileExists(oldDBDir) && dbDriver == BadgerDB:
+		// old db. Old dbs use the bolt driver.
+		err := migrateDBDriver(oldDBDir, BoltDB, dbDir, dbDriver, netType)
+		if err == nil {
+			rootDir = dbDir
+			break
+		}
+		if migrate {
+			return nil, errors.Errorf("failed to migrate to the %s database driver: %v", dbDriver, err)
+		}
+		// Fall back to recreating the wallets from their seeds.
+		log.Errorf("Error migrating to the %s database driver: %v", dbDriver, err)
 		dbDriver = BoltDB
-		rootDir = filepath.Join(rootDir, string(netType))
+		rootDir = oldDBDir
 		needMigrate = isMobile
-	} else {
+	case fileExists(oldDBDir):
+		// old db
+		rootDir = oldDBDir
+	default:
 		// db is not exist, create new
-		rootDir = filepath.Join(rootDir, fmt.Sprintf("%s-%s", string(netType), dbDriver))
+		rootDir = dbDir
+	}
 
+	mgr, err := openAssetsManager(rootDir, dbDriver, logDir, netType, dexTestAddr)
+	if err != nil {
+		return nil, err
 	}
+	mgr.NeedMigrate = needMigrate
+	return mgr, nil
+}
 
+// openAssetsManager opens the assets manager whose data is in rootDir.
+func openAssetsManager(rootDir, dbDriver, logDir string, netType utils.NetworkType, dexTestAddr string) (*AssetsManager, error) {
 	// Create a root dir that has the path up the network folder.
 	if err := os.MkdirAll(rootDir, utils.UserFilePerm); err != nil {
 		return nil, errors.Errorf("failed to create rootDir: %v", err)
@@ -186,6 +244,7 @@ func NewAssetsManager(rootDir, logDir string, netType utils.NetworkType, dexTest
 	if err := initLogRotator(filepath.Join(rootDir, logFileName)); err != nil {
 		return nil, errors.Errorf("failed to init logRotator: %v", err.Error())
 	}
+	mgr.logRotator = logRotator
 
 	// Attempt to acquire lock on the wallets.db file.
 	mwDB, err := storm.Open(filepath.Join(rootDir, walletsDbName))
@@ -208,19 +267,30 @@ func NewAssetsManager(rootDir, logDir string, netType utils.NetworkType, dexTest
 	if netType == Testnet {
 		politeiaHost = PoliteiaTestnetHost
 	}
-	politeia, err := politeia.New(politeiaHost, mwDB)
+	// The proposals and the instant swap orders are encrypted when the
+	// metadata encryption is enabled.
+	mgr.params.DB = mwDB
+	mgr.params.DBCodec.Enable(mgr.IsMetadataEncryptionEnabled())
+	metadataDB := mwDB.WithCodec(mgr.params.DBCodec)
+	// The proposals and the orders were indexed by readable values by
+	// earlier versions.
+	metadataBuckets := append(append([]string{}, politeia.MetadataBuckets...), instantswap.MetadataBuckets...)
+	if err := dbcrypt.DropIndexes(mwDB.Bolt, metadataBuckets...); err != nil {
+		return nil, err
+	}
+
+	politeia, err := politeia.New(politeiaHost, metadataDB)
 	if err != nil {
 		return nil, err
 	}
 
-	instantSwap, err := instantswap.NewInstantSwap(mwDB)
+	instantSwap, err := instantswap.NewInstantSwap(metadataDB)
 	if err != nil {
 		return nil, err
 	}
 
 	mgr.ConsensusAgenda = dcr.NewConsensusAgenda(mgr.chainsParams.DCR, mwDB)
 
-	mgr.params.DB = mwDB
 	mgr.Politeia = politeia
 	mgr.InstantSwap = instantSwap
 
@@ -244,7 +314,10 @@ func NewAssetsManager(rootDir, logDir string, netType utils.NetworkType, dexTest
 	}
 
 	mgr.listenForShutdown()
-	mgr.NeedMigrate = needMigrate
+	mgr.startSyncPolicy()
+	mgr.startSyncProgress()
+	mgr.startWalletDBBackups()
+	mgr.startDelayedSends()
 	return mgr, nil
 }
 
@@ -271,6 +344,13 @@ func (mgr *AssetsManager) SetToast(toast *notification.Toast) {
 	mgr.toast = toast
 }
 
+// InheritUIContext sets the toast and the DEX context of the app window that
+// other is used in, so that mgr can be used in its place.
+func (mgr *AssetsManager) InheritUIContext(other *AssetsManager) {
+	mgr.toast = other.toast
+	mgr.dexcCtx = other.dexcCtx
+}
+
 func (mgr *AssetsManager) disableConversionExchange() {
 	mgr.SetCurrencyConversionExchange(values.DefaultExchangeValue)
 	if mgr.toast != nil {
@@ -411,9 +491,13 @@ func (mgr *AssetsManager) Shutdown() {
 	for _, wallet := range mgr.AllWallets() {
 		wallet.Shutdown() // Cancels the wallet sync too.
 		wallet.CancelRescan()
+		wallet.FlushSyncDataUsage()
 	}
 	mgr.Assets = new(Assets)
 
+	// Stop the chain backends still held by wallets that were never synced.
+	mgr.params.ChainBackends.Shutdown()
+
 	// Disable all active network connections
 	utils.ShutdownHTTPClients()
 
@@ -425,9 +509,9 @@ func (mgr *AssetsManager) Shutdown() {
 		}
 	}
 
-	if logRotator != nil {
+	if mgr.logRotator != nil {
 		log.Info("Shutting down log rotator")
-		logRotator.Close()
+		mgr.logRotator.Close()
 		log.Info("Shutdown log rotator successfully")
 	}
 }
@@ -464,6 +548,10 @@ func (mgr *AssetsManager) OpenWallets(startupPassphrase string) error {
 		return err
 	}
 
+	if err := mgr.unlockMetadata(startupPassphrase); err != nil {
+		return err
+	}
+
 	for _, wallet := range mgr.AllWallets() {
 		select {
 		case <-mgr.shuttingDown:
@@ -477,9 +565,29 @@ func (mgr *AssetsManager) OpenWallets(startupPassphrase string) error {
 		}
 	}
 
+	mgr.scheduleSeedChecks()
 	return nil
 }
 
+// LockIdleWallets locks the unlocked wallets that aren't needed by a running
+// background job. DCR wallets running the account mixer or the ticket buyer
+// and all wallets while DEX trades are active are left unlocked.
+func (mgr *AssetsManager) LockIdleWallets() {
+	if mgr.DEXCInitialized() && mgr.DexClient().Active() {
+		return
+	}
+
+	for _, wallet := range mgr.AllWallets() {
+		if !wallet.WalletOpened() || wallet.IsLocked() {
+			continue
+		}
+		if dcrAsset, ok := wallet.(*dcr.Asset); ok && (dcrAsset.IsAccountMixerActive() || dcrAsset.IsAutoTicketsPurchaseActive()) {
+			continue
+		}
+		wallet.LockWallet()
+	}
+}
+
 // DCRBadWallets returns a map of all bad DCR wallets.
 func (mgr *AssetsManager) DCRBadWallets() map[int]*sharedW.Wallet {
 	return mgr.Assets.DCR.BadWallets
@@ -579,6 +687,16 @@ func (mgr *AssetsManager) AllWallets() (wallets []sharedW.Asset) {
 	return wallets
 }
 
+// MultisigWallets returns the m-of-n multisig wallets in the assets manager.
+func (mgr *AssetsManager) MultisigWallets() (wallets []sharedW.Asset) {
+	for _, wallet := range mgr.AllWallets() {
+		if wallet.IsMultisig() {
+			wallets = append(wallets, wallet)
+		}
+	}
+	return wallets
+}
+
 // DeleteWallet deletes a wallet from the assets manager.
 func (mgr *AssetsManager) DeleteWallet(walletID int, privPass string) error {
 	wallet := mgr.WalletWithID(walletID)
@@ -598,6 +716,9 @@ func (mgr *AssetsManager) DeleteWallet(walletID int, privPass string) error {
 	case utils.LTCWalletAsset:
 		delete(mgr.Assets.LTC.Wallets, walletID)
 	}
+	if mgr.syncProgress != nil {
+		mgr.syncProgress.forget(walletID)
+	}
 
 	return nil
 }
@@ -789,7 +910,7 @@ func (mgr *AssetsManager) cleanDeletedWallets() {
 		}
 		for _, f := range files {
 			key := wType.ToStringLower() + f.Name()
-			if f.IsDir() && !validWallets[key] {
+			if f.IsDir() && !validWallets[key] && f.Name() != sharedW.SharedChainDirName {
 				deletedWalletDirs = append(deletedWalletDirs, filepath.Join(rootDir, f.Name()))
 			}
 		}
@@ -971,6 +1092,22 @@ func (mgr *AssetsManager) DEXCInitialized() bool {
 	return mgr.dexc != nil && mgr.dexc.IsInitialized()
 }
 
+// DEXAccountFunds returns the funds of the wallet account locked by DEX
+// orders, swaps and bonds. It returns nil if the DEX client is not initialized
+// or does not use the account.
+func (mgr *AssetsManager) DEXAccountFunds(walletID int, accountNumber int32) *dexc.WalletFunds {
+	if !mgr.DEXCInitialized() {
+		return nil
+	}
+
+	funds, err := mgr.DexClient().AccountWalletFunds(walletID, accountNumber)
+	if err != nil {
+		log.Errorf("Error retrieving DEX funds for wallet %d account %d: %v", walletID, accountNumber, err)
+		return nil
+	}
+	return funds
+}
+
 // DEXDBExists will return true if a dex database already exists in the root
 // dir. mgr.RootDir() is the same dir used in creating a new dexc instance.
 func (mgr *AssetsManager) DEXDBExists() bool {
@@ -1007,7 +1144,7 @@ func (mgr *AssetsManager) InitializeDEX() {
 	setDEXWalletLoader(mgr.WalletWithID)
 
 	logDir := filepath.Dir(mgr.LogFile())
-	dexClient, err := dexc.Start(mgr.dexcCtx, mgr.RootDir(), mgr.GetLanguagePreference(), logDir, mgr.GetLogLevels(), mgr.NetType(), 0 /* TODO: Make configurable */)
+	dexClient, err := dexc.Start(mgr.dexcCtx, mgr.RootDir(), mgr.GetLanguagePreference(), logDir, mgr.GetLogLevels(), mgr.NetType(), 0 /* TODO: Make configurable */, mgr.checkDEXTrade)
 	if err != nil {
 		log.Errorf("Error starting DEX client: %v", err)
 		return
@@ -1027,6 +1164,16 @@ func (mgr *AssetsManager) InitializeDEX() {
 	log.Info("DEX client has been initialized successfully...")
 }
 
+// checkDEXTrade returns an error if the spending policy of the wallet account
+// funding a DEX order would refuse its swaps.
+func (mgr *AssetsManager) checkDEXTrade(walletID int, account int32, amount uint64) error {
+	wallet := mgr.WalletWithID(walletID)
+	if wallet == nil {
+		return fmt.Errorf("no wallet exists with ID %d", walletID)
+	}
+	return wallet.CheckDEXSwaps(account, int64(amount))
+}
+
 func (mgr *AssetsManager) DeleteDEXData() error {
 	if !mgr.DEXCInitialized() {
 		return nil // nothing to do.
@@ -601,12 +601,79 @@ func (db *db) BeginReadWriteTx() (walletdb.ReadWriteTx, error) {
 	return db.beginTx(true)
 }
 
-// Copy writes a copy of the database to the provided writer.  This call will
-// start a read-only transaction to perform all operations.
+// Copy writes a copy of the database to the provided writer.  The copy is a
+// badger backup stream of a consistent snapshot of the database, taken while
+// the database remains usable.  It is restored with Restore.
 //
 // This function is part of the walletdb.DB interface implementation.
-func (db *db) Copy(_ io.Writer) error {
-	return errors.E(errors.Invalid, "method not implemented")
+func (db *db) Copy(w io.Writer) error {
+	if db.closed {
+		return errors.E(errors.Invalid, "database is closed")
+	}
+
+	_, err := db.DB.Backup(w, 0)
+	return convertErr(err)
+}
+
+// TopLevelBuckets returns the keys of the top level buckets of a database
+// opened with this driver.  The walletdb interface can only open top level
+// buckets by key, so the keys are needed to copy a database to a database of
+// another driver.
+func TopLevelBuckets(walletDB walletdb.DB) ([][]byte, error) {
+	d, ok := walletDB.(*db)
+	if !ok {
+		return nil, errors.E(errors.Invalid, "not a badgerdb database")
+	}
+	if d.closed {
+		return nil, errors.E(errors.Invalid, "database is closed")
+	}
+
+	var keys [][]byte
+	err := d.DB.View(func(txn *badger.Txn) error {
+		it := txn.NewIterator(badger.DefaultIteratorOptions)
+		defer it.Close()
+		for it.Rewind(); it.Valid(); it.Next() {
+			item := it.Item()
+			if item.UserMeta() != metaBucket {
+				continue
+			}
+			val, err := item.ValueCopy(nil)
+			if err != nil {
+				return err
+			}
+			// Top level buckets are prefixed by their whole key, nested
+			// buckets by the key of their parent bucket.
+			if len(val) > 0 && int(val[0]) == len(item.Key()) {
+				keys = append(keys, item.KeyCopy(nil))
+			}
+		}
+		return nil
+	})
+	return keys, convertErr(err)
+}
+
+// Restore creates a database at dbPath from a backup stream written by Copy.
+// dbPath must not exist.
+func Restore(dbPath string, r io.Reader) error {
+	if fileExists(dbPath) {
+		return errors.E(errors.Exist, "database already exists")
+	}
+
+	d, err := openDB(dbPath, true)
+	if err != nil {
+		return err
+	}
+
+	// Keep in line with the write concurrency of badger.DefaultOptions.
+	const maxPendingWrites = 256
+	err = d.(*db).DB.Load(r, maxPendingWrites)
+	if closeErr := d.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.RemoveAll(dbPath)
+	}
+	return convertErr(err)
 }
 
 // Close cleanly shuts down the database and syncs all data.
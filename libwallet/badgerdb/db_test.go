@@ -0,0 +1,65 @@
+package badgerdb
+
+import (
+	"bytes"
+	"context"
+	"path/filepath"
+	"testing"
+
+	"decred.org/dcrwallet/v4/wallet/walletdb"
+)
+
+func TestCopyRestore(t *testing.T) {
+	dir := t.TempDir()
+	bucketKey, key, value := []byte("bucket"), []byte("key"), []byte("value")
+
+	source, err := openDB(filepath.Join(dir, "source"), true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer source.Close()
+
+	err = walletdb.Update(context.Background(), source, func(tx walletdb.ReadWriteTx) error {
+		bucket, err := tx.CreateTopLevelBucket(bucketKey)
+		if err != nil {
+			return err
+		}
+		return bucket.Put(key, value)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var backup bytes.Buffer
+	if err := source.Copy(&backup); err != nil {
+		t.Fatal(err)
+	}
+
+	restoredPath := filepath.Join(dir, "restored")
+	if err := Restore(restoredPath, bytes.NewReader(backup.Bytes())); err != nil {
+		t.Fatal(err)
+	}
+	if err := Restore(restoredPath, bytes.NewReader(backup.Bytes())); err == nil {
+		t.Fatal("restored over an existing database")
+	}
+
+	restored, err := openDB(restoredPath, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer restored.Close()
+
+	err = walletdb.View(context.Background(), restored, func(tx walletdb.ReadTx) error {
+		bucket := tx.ReadBucket(bucketKey)
+		if bucket == nil {
+			t.Fatal("restored database is missing the bucket")
+		}
+		if got := bucket.Get(key); !bytes.Equal(got, value) {
+			t.Fatalf("expected %q, got %q", value, got)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
@@ -35,6 +35,7 @@ func (mgr *AssetsManager) CreateNewBTCWallet(walletName, privatePassphrase strin
 	}
 
 	mgr.Assets.BTC.Wallets[wallet.GetWalletID()] = wallet
+	mgr.trackSyncProgress(wallet)
 
 	return wallet, nil
 }
@@ -47,6 +48,7 @@ func (mgr *AssetsManager) CreateNewBTCWatchOnlyWallet(walletName, extendedPublic
 	}
 
 	mgr.Assets.BTC.Wallets[wallet.GetWalletID()] = wallet
+	mgr.trackSyncProgress(wallet)
 
 	return wallet, nil
 }
@@ -65,6 +67,7 @@ func (mgr *AssetsManager) RestoreBTCWallet(walletName, seedMnemonic, privatePass
 	}
 
 	mgr.Assets.BTC.Wallets[wallet.GetWalletID()] = wallet
+	mgr.trackSyncProgress(wallet)
 
 	return wallet, nil
 }
@@ -0,0 +1,501 @@
+package libwallet
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"decred.org/dcrwallet/v4/errors"
+	dcrW "decred.org/dcrwallet/v4/wallet"
+	"decred.org/dcrwallet/v4/wallet/walletdb"
+	"github.com/crypto-power/cryptopower/libwallet/badgerdb"
+	"github.com/crypto-power/cryptopower/libwallet/utils"
+	"github.com/decred/dcrd/chaincfg/v3"
+	"github.com/decred/dcrd/dcrutil/v4"
+	bolt "go.etcd.io/bbolt"
+)
+
+const (
+	// migratingDirSuffix marks the directory a migration is written to. The
+	// directory is only renamed to its final name once the migration is
+	// verified.
+	migratingDirSuffix = ".migrating"
+	// migratedDirSuffix marks the directory of the data that was migrated to
+	// another db driver. It is kept so that no data is lost if the migrated
+	// data turns out to be unusable.
+	migratedDirSuffix = "-migrated"
+	// scratchDBSuffix marks the copy of a wallet database that is opened to
+	// summarize it, so that the database being migrated is never written to.
+	scratchDBSuffix = ".scratch"
+
+	// maxPendingWriteSize is the size of the writes after which a migration
+	// transaction is committed. badger rejects transactions that are too
+	// large.
+	maxPendingWriteSize = 1 << 20
+
+	dcrWalletDBName = "wallet.db"
+)
+
+// dbDriverDir returns the directory holding the netType data of the app when
+// the dbDriver database driver is used.
+func dbDriverDir(rootDir string, netType utils.NetworkType, dbDriver string) string {
+	return filepath.Join(rootDir, fmt.Sprintf("%s-%s", string(netType), dbDriver))
+}
+
+// otherDBDriver returns the database driver that isn't dbDriver.
+func otherDBDriver(dbDriver string) string {
+	if dbDriver == BadgerDB {
+		return BoltDB
+	}
+	return BadgerDB
+}
+
+// migrateDBDriver migrates the app data in srcDir, that uses the srcDriver
+// database driver, to dstDir using the dstDriver database driver. The DCR
+// wallet databases are converted by copying their buckets and every other
+// file, including the wallets.db with the wallets config, is copied as is.
+// The balances and transaction counts of the converted wallet databases are
+// verified before the migration is completed. srcDir is renamed with the
+// migratedDirSuffix once the migration is complete.
+func migrateDBDriver(srcDir, srcDriver, dstDir, dstDriver string, netType utils.NetworkType) (err error) {
+	log.Infof("Migrating %s from the %s database driver to the %s database driver", srcDir, srcDriver, dstDriver)
+
+	// Don't migrate the data of a running app.
+	walletsDB, err := bolt.Open(filepath.Join(srcDir, walletsDbName), 0o600, &bolt.Options{Timeout: time.Second})
+	if err != nil {
+		if err == bolt.ErrTimeout {
+			return errors.E(utils.ErrWalletDatabaseInUse)
+		}
+		return err
+	}
+	walletsDB.Close()
+
+	chainParams, err := utils.DCRChainParams(netType)
+	if err != nil {
+		return err
+	}
+
+	// Remove what is left of an interrupted migration.
+	tmpDir := dstDir + migratingDirSuffix
+	if err := os.RemoveAll(tmpDir); err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			os.RemoveAll(tmpDir)
+		}
+	}()
+
+	err = filepath.WalkDir(srcDir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		relPath, err := filepath.Rel(srcDir, path)
+		if err != nil {
+			return err
+		}
+		dstPath := filepath.Join(tmpDir, relPath)
+
+		if isDCRWalletDB(relPath) {
+			if err := migrateDCRWalletDB(path, srcDriver, dstPath, dstDriver, chainParams); err != nil {
+				return fmt.Errorf("error migrating %s: %w", relPath, err)
+			}
+			if entry.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		if entry.IsDir() {
+			return os.MkdirAll(dstPath, utils.UserFilePerm)
+		}
+		return copyFile(path, dstPath)
+	})
+	if err != nil {
+		return err
+	}
+
+	if err = os.Rename(tmpDir, dstDir); err != nil {
+		return err
+	}
+
+	migratedDir := srcDir + migratedDirSuffix
+	if err := os.RemoveAll(migratedDir); err != nil {
+		log.Errorf("Error removing %s: %v", migratedDir, err)
+	}
+	if err := os.Rename(srcDir, migratedDir); err != nil {
+		log.Errorf("Error renaming %s: %v", srcDir, err)
+		return nil
+	}
+
+	log.Infof("Migration to the %s database driver complete. The previous data is kept in %s", dstDriver, migratedDir)
+	return nil
+}
+
+// isDCRWalletDB checks if relPath is the path of a DCR wallet database,
+// i.e. dcr/<wallet id>/wallet.db.
+func isDCRWalletDB(relPath string) bool {
+	parts := strings.Split(filepath.ToSlash(relPath), "/")
+	n := len(parts)
+	if n < 3 || parts[n-1] != dcrWalletDBName || parts[n-3] != utils.DCRWalletAsset.ToStringLower() {
+		return false
+	}
+	_, err := strconv.Atoi(parts[n-2])
+	return err == nil
+}
+
+// migrateDCRWalletDB copies the DCR wallet database at srcPath to a new
+// database at dstPath and verifies that both databases hold the same data.
+func migrateDCRWalletDB(srcPath, srcDriver, dstPath, dstDriver string, chainParams *chaincfg.Params) error {
+	// The wallet may upgrade its database when opened, so the source is
+	// summarized from a scratch copy and left untouched.
+	scratchPath := dstPath + scratchDBSuffix
+	defer os.RemoveAll(scratchPath)
+	if err := copyPath(srcPath, scratchPath); err != nil {
+		return err
+	}
+	srcSummary, err := summarizeDCRWalletDB(srcDriver, scratchPath, chainParams)
+	if err != nil {
+		return err
+	}
+
+	copied, err := copyWalletDB(srcDriver, srcPath, dstDriver, dstPath)
+	if err != nil {
+		return err
+	}
+
+	counted, err := countWalletDBPairs(dstDriver, dstPath)
+	if err != nil {
+		return err
+	}
+	if counted != copied {
+		return fmt.Errorf("%d key/value pairs were copied but %d were found", copied, counted)
+	}
+
+	dstSummary, err := summarizeDCRWalletDB(dstDriver, dstPath, chainParams)
+	if err != nil {
+		return err
+	}
+	return srcSummary.compare(dstSummary)
+}
+
+// dcrWalletDBSummary holds the wallet data compared after a migration.
+type dcrWalletDBSummary struct {
+	balances map[uint32]dcrutil.Amount
+	txCount  int
+}
+
+func (s *dcrWalletDBSummary) compare(migrated *dcrWalletDBSummary) error {
+	if s.txCount != migrated.txCount {
+		return fmt.Errorf("expected %d transactions, found %d", s.txCount, migrated.txCount)
+	}
+	if len(s.balances) != len(migrated.balances) {
+		return fmt.Errorf("expected %d accounts, found %d", len(s.balances), len(migrated.balances))
+	}
+	for account, balance := range s.balances {
+		if migrated.balances[account] != balance {
+			return fmt.Errorf("expected a balance of %v for account %d, found %v", balance, account, migrated.balances[account])
+		}
+	}
+	return nil
+}
+
+// summarizeDCRWalletDB opens the DCR wallet database at path and reads the
+// account balances and the number of transactions.
+func summarizeDCRWalletDB(driver, path string, chainParams *chaincfg.Params) (*dcrWalletDBSummary, error) {
+	ctx := context.Background()
+	db, err := dcrW.OpenDB(driver, path)
+	if err != nil {
+		return nil, err
+	}
+	defer db.Close()
+
+	w, err := dcrW.Open(ctx, &dcrW.Config{
+		DB:            db,
+		PubPassphrase: []byte(dcrW.InsecurePubPassphrase),
+		Params:        chainParams,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	balances, err := w.AccountBalances(ctx, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	summary := &dcrWalletDBSummary{balances: make(map[uint32]dcrutil.Amount, len(balances))}
+	for _, balance := range balances {
+		summary.balances[balance.Account] = balance.Total
+	}
+
+	err = w.GetTransactions(ctx, func(block *dcrW.Block) (bool, error) {
+		summary.txCount += len(block.Transactions)
+		return false, nil
+	}, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return summary, nil
+}
+
+// topLevelBuckets returns the keys of the top level buckets of the database
+// at path. The walletdb interface has no way to list them, so they are read
+// using the driver.
+func topLevelBuckets(driver, path string) ([][]byte, error) {
+	switch driver {
+	case BoltDB:
+		db, err := bolt.Open(path, 0o600, &bolt.Options{ReadOnly: true, Timeout: time.Second})
+		if err != nil {
+			return nil, err
+		}
+		defer db.Close()
+
+		var keys [][]byte
+		err = db.View(func(tx *bolt.Tx) error {
+			return tx.ForEach(func(name []byte, _ *bolt.Bucket) error {
+				keys = append(keys, bytes.Clone(name))
+				return nil
+			})
+		})
+		return keys, err
+
+	case BadgerDB:
+		db, err := walletdb.Open(driver, path)
+		if err != nil {
+			return nil, err
+		}
+		defer db.Close()
+		return badgerdb.TopLevelBuckets(db)
+
+	default:
+		return nil, fmt.Errorf("unsupported database driver %q", driver)
+	}
+}
+
+// copyWalletDB copies every bucket of the walletdb database at srcPath to a
+// new database at dstPath. It returns the number of key/value pairs copied.
+func copyWalletDB(srcDriver, srcPath, dstDriver, dstPath string) (int, error) {
+	buckets, err := topLevelBuckets(srcDriver, srcPath)
+	if err != nil {
+		return 0, err
+	}
+
+	src, err := walletdb.Open(srcDriver, srcPath)
+	if err != nil {
+		return 0, err
+	}
+	defer src.Close()
+
+	dst, err := walletdb.Create(dstDriver, dstPath)
+	if err != nil {
+		return 0, err
+	}
+	defer dst.Close()
+
+	writer := &walletDBWriter{db: dst}
+	err = walletdb.View(context.Background(), src, func(tx walletdb.ReadTx) error {
+		for _, key := range buckets {
+			bucket := tx.ReadBucket(key)
+			if bucket == nil {
+				continue
+			}
+			if err := writer.copyBucket([][]byte{key}, bucket); err != nil {
+				return err
+			}
+		}
+		return writer.commit()
+	})
+	if err != nil {
+		writer.rollback()
+		return 0, err
+	}
+	return writer.pairs, nil
+}
+
+// countWalletDBPairs returns the number of key/value pairs in all the buckets
+// of the walletdb database at path.
+func countWalletDBPairs(driver, path string) (int, error) {
+	buckets, err := topLevelBuckets(driver, path)
+	if err != nil {
+		return 0, err
+	}
+
+	db, err := walletdb.Open(driver, path)
+	if err != nil {
+		return 0, err
+	}
+	defer db.Close()
+
+	var count func(bucket walletdb.ReadBucket) (int, error)
+	count = func(bucket walletdb.ReadBucket) (int, error) {
+		var pairs int
+		err := bucket.ForEach(func(k, v []byte) error {
+			if v == nil {
+				if nested := bucket.NestedReadBucket(k); nested != nil {
+					nestedPairs, err := count(nested)
+					pairs += nestedPairs
+					return err
+				}
+			}
+			pairs++
+			return nil
+		})
+		return pairs, err
+	}
+
+	var pairs int
+	err = walletdb.View(context.Background(), db, func(tx walletdb.ReadTx) error {
+		for _, key := range buckets {
+			bucket := tx.ReadBucket(key)
+			if bucket == nil {
+				continue
+			}
+			bucketPairs, err := count(bucket)
+			if err != nil {
+				return err
+			}
+			pairs += bucketPairs
+		}
+		return nil
+	})
+	return pairs, err
+}
+
+// walletDBWriter writes the buckets copied from a walletdb database. The
+// writes are committed in batches of maxPendingWriteSize.
+type walletDBWriter struct {
+	db           walletdb.DB
+	tx           walletdb.ReadWriteTx
+	pendingBytes int
+	pairs        int
+}
+
+// bucket returns the bucket at path, creating the buckets that don't exist.
+func (w *walletDBWriter) bucket(path [][]byte) (walletdb.ReadWriteBucket, error) {
+	if w.tx == nil {
+		tx, err := w.db.BeginReadWriteTx()
+		if err != nil {
+			return nil, err
+		}
+		w.tx = tx
+	}
+
+	bucket := w.tx.ReadWriteBucket(path[0])
+	if bucket == nil {
+		var err error
+		bucket, err = w.tx.CreateTopLevelBucket(path[0])
+		if err != nil {
+			return nil, err
+		}
+	}
+	for _, key := range path[1:] {
+		var err error
+		bucket, err = bucket.CreateBucketIfNotExists(key)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return bucket, nil
+}
+
+func (w *walletDBWriter) put(path [][]byte, key, value []byte) error {
+	bucket, err := w.bucket(path)
+	if err != nil {
+		return err
+	}
+	if err := bucket.Put(key, value); err != nil {
+		return err
+	}
+
+	w.pairs++
+	w.pendingBytes += len(key) + len(value)
+	if w.pendingBytes >= maxPendingWriteSize {
+		return w.commit()
+	}
+	return nil
+}
+
+// copyBucket copies src and its nested buckets to the bucket at path.
+func (w *walletDBWriter) copyBucket(path [][]byte, src walletdb.ReadBucket) error {
+	// Create the bucket even if it is empty.
+	if _, err := w.bucket(path); err != nil {
+		return err
+	}
+
+	return src.ForEach(func(k, v []byte) error {
+		// Keys and values are only valid until the iteration moves on.
+		key := bytes.Clone(k)
+		if v == nil {
+			if nested := src.NestedReadBucket(key); nested != nil {
+				return w.copyBucket(append(path[:len(path):len(path)], key), nested)
+			}
+			v = []byte{}
+		}
+		return w.put(path, key, bytes.Clone(v))
+	})
+}
+
+func (w *walletDBWriter) commit() error {
+	if w.tx == nil {
+		return nil
+	}
+	err := w.tx.Commit()
+	w.tx = nil
+	w.pendingBytes = 0
+	return err
+}
+
+func (w *walletDBWriter) rollback() {
+	if w.tx != nil {
+		_ = w.tx.Rollback()
+		w.tx = nil
+	}
+}
+
+// copyPath copies the file or directory tree at srcPath to dstPath.
+func copyPath(srcPath, dstPath string) error {
+	return filepath.WalkDir(srcPath, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		relPath, err := filepath.Rel(srcPath, path)
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() {
+			return os.MkdirAll(filepath.Join(dstPath, relPath), utils.UserFilePerm)
+		}
+		return copyFile(path, filepath.Join(dstPath, relPath))
+	})
+}
+
+func copyFile(srcPath, dstPath string) error {
+	src, err := os.Open(srcPath)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+
+	info, err := src.Stat()
+	if err != nil {
+		return err
+	}
+
+	dst, err := os.OpenFile(dstPath, os.O_CREATE|os.O_WRONLY|os.O_EXCL, info.Mode().Perm())
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(dst, src)
+	if closeErr := dst.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
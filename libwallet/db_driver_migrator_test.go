@@ -0,0 +1,147 @@
+package libwallet
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"decred.org/dcrwallet/v4/wallet/walletdb"
+)
+
+func TestCopyWalletDB(t *testing.T) {
+	dir := t.TempDir()
+	srcPath := filepath.Join(dir, "src.db")
+
+	src, err := walletdb.Create(BoltDB, srcPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = walletdb.Update(context.Background(), src, func(tx walletdb.ReadWriteTx) error {
+		top, err := tx.CreateTopLevelBucket([]byte("top"))
+		if err != nil {
+			return err
+		}
+		if err := top.Put([]byte("a"), []byte("1")); err != nil {
+			return err
+		}
+		nested, err := top.CreateBucket([]byte("nested"))
+		if err != nil {
+			return err
+		}
+		if err := nested.Put([]byte("b"), []byte("2")); err != nil {
+			return err
+		}
+		_, err = tx.CreateTopLevelBucket([]byte("empty"))
+		return err
+	})
+	src.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Copy to badger and back to bolt.
+	badgerPath := filepath.Join(dir, "badger.db")
+	copied, err := copyWalletDB(BoltDB, srcPath, BadgerDB, badgerPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if copied != 2 {
+		t.Fatalf("expected 2 pairs copied, got %d", copied)
+	}
+	boltPath := filepath.Join(dir, "bolt.db")
+	if _, err := copyWalletDB(BadgerDB, badgerPath, BoltDB, boltPath); err != nil {
+		t.Fatal(err)
+	}
+
+	counted, err := countWalletDBPairs(BoltDB, boltPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if counted != copied {
+		t.Fatalf("expected %d pairs, found %d", copied, counted)
+	}
+
+	dst, err := walletdb.Open(BoltDB, boltPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer dst.Close()
+	err = walletdb.View(context.Background(), dst, func(tx walletdb.ReadTx) error {
+		if tx.ReadBucket([]byte("empty")) == nil {
+			t.Error("empty bucket was not copied")
+		}
+		top := tx.ReadBucket([]byte("top"))
+		if top == nil {
+			t.Fatal("top bucket was not copied")
+		}
+		if !bytes.Equal(top.Get([]byte("a")), []byte("1")) {
+			t.Error("top bucket value was not copied")
+		}
+		nested := top.NestedReadBucket([]byte("nested"))
+		if nested == nil || !bytes.Equal(nested.Get([]byte("b")), []byte("2")) {
+			t.Error("nested bucket was not copied")
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestIsDCRWalletDB(t *testing.T) {
+	tests := map[string]bool{
+		filepath.Join("dcr", "1", "wallet.db"):             true,
+		filepath.Join("testnet3", "dcr", "2", "wallet.db"): true,
+		filepath.Join("btc", "1", "wallet.db"):             false,
+		filepath.Join("dcr", "backups", "wallet.db"):       false,
+		filepath.Join("dcr", "1", "tx.db"):                 false,
+		"wallet.db":                                        false,
+	}
+	for path, expected := range tests {
+		if isDCRWalletDB(path) != expected {
+			t.Errorf("isDCRWalletDB(%q) != %v", path, expected)
+		}
+	}
+}
+
+func TestCopyPath(t *testing.T) {
+	dir := t.TempDir()
+	srcDir := filepath.Join(dir, "src")
+	if err := os.MkdirAll(filepath.Join(srcDir, "sub"), 0o700); err != nil {
+		t.Fatal(err)
+	}
+	files := map[string]string{
+		"MANIFEST":                     "manifest",
+		filepath.Join("sub", "000001"): "data",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(srcDir, name), []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	dstDir := filepath.Join(dir, "dst")
+	if err := copyPath(srcDir, dstDir); err != nil {
+		t.Fatal(err)
+	}
+	for name, content := range files {
+		b, err := os.ReadFile(filepath.Join(dstDir, name))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(b) != content {
+			t.Errorf("%s: expected %q, got %q", name, content, b)
+		}
+	}
+
+	// A single file is copied too.
+	dstFile := filepath.Join(dir, "manifest.copy")
+	if err := copyPath(filepath.Join(srcDir, "MANIFEST"), dstFile); err != nil {
+		t.Fatal(err)
+	}
+	if b, err := os.ReadFile(dstFile); err != nil || string(b) != "manifest" {
+		t.Fatalf("file copy failed: %q, %v", b, err)
+	}
+}
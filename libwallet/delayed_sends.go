@@ -0,0 +1,36 @@
+package libwallet
+
+import (
+	"context"
+	"time"
+)
+
+// delayedSendCheckInterval is how often the wallets are checked for delayed
+// sends whose cancel window is over.
+const delayedSendCheckInterval = time.Minute
+
+// startDelayedSends starts a loop that broadcasts the sends held back by the
+// spending policy delay of the synced wallets once their cancel window is
+// over. The loop stops when the assets manager shuts down.
+func (mgr *AssetsManager) startDelayedSends() {
+	ctx, cancel := context.WithCancel(context.Background())
+	mgr.cancelFuncs = append(mgr.cancelFuncs, cancel)
+
+	go func() {
+		ticker := time.NewTicker(delayedSendCheckInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				for _, wallet := range mgr.AllWallets() {
+					if wallet.WalletOpened() && wallet.IsSynced() {
+						wallet.PublishDueDelayedSends()
+					}
+				}
+			}
+		}
+	}()
+}
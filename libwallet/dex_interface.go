@@ -1,9 +1,12 @@
 package libwallet
 
 import (
+	"io"
+
 	"decred.org/dcrdex/client/core"
 	"decred.org/dcrdex/client/orderbook"
 	"decred.org/dcrdex/dex"
+	"github.com/crypto-power/cryptopower/dexc"
 )
 
 type DEXClient interface {
@@ -17,19 +20,49 @@ type DEXClient interface {
 	Login(pw []byte) error
 	Logout() error
 	DBPath() string
+	// BackupDB writes a copy of the DEX database to dst.
+	BackupDB(dst string, overwrite, compact bool) error
 	DiscoverAccount(dexAddr string, appPW []byte, certI any) (*core.Exchange, bool, error)
 	GetDEXConfig(dexAddr string, certI any) (*core.Exchange, error)
+	// AddDEX adds a view-only connection to the DEX server at dexAddr. certI
+	// may be the TLS certificate contents ([]byte) or a path to the
+	// certificate file (string). PostBond may be used later to set up a
+	// trading account for the server.
+	AddDEX(appPW []byte, dexAddr string, certI any) error
+	// ToggleAccountStatus disables or re-enables the account for the DEX
+	// server at host. Disabled servers remain in Exchanges but are
+	// disconnected.
+	ToggleAccountStatus(pw []byte, host string, disable bool) error
+	// UpdateCert reconnects to the DEX server at host using a new TLS
+	// certificate.
+	UpdateCert(host string, cert []byte) error
 	BondsFeeBuffer(assetID uint32) uint64
 	HasWallet(assetID int32) bool
 	AddWallet(assetID uint32, settings map[string]string, appPW, walletPW []byte) error
 	SetWalletPassword(appPW []byte, assetID uint32, newPW []byte) error
 	PostBond(form *core.PostBondForm) (*core.PostBondResult, error)
+	// UpdateBondOptions changes the bond asset, target tier, max bonded
+	// amount and penalty compensation settings used to automatically renew
+	// bonds for a DEX server. Setting a zero target tier disables bond
+	// renewal.
+	UpdateBondOptions(form *core.BondOptionsForm) error
+	// Bonds returns the unrefunded bonds posted to the DEX server at host.
+	Bonds(pw []byte, host string) ([]*dexc.BondInfo, error)
 	NotificationFeed() *core.NoteFeed
 	Exchanges() map[string]*core.Exchange
 	Exchange(host string) (*core.Exchange, error)
 	ExportSeed(pw []byte) (string, error)
 	SyncBook(dex string, base, quote uint32) (*orderbook.OrderBook, core.BookFeed, error)
 	Orders(filter *core.OrderFilter) ([]*core.Order, error)
+	// TradeHistory returns a page of trades matching the filter along with
+	// the transactions and fees of their matches.
+	TradeHistory(filter *dexc.TradeHistoryFilter) (*dexc.TradeHistory, error)
+	// TradePnL returns the profit and loss per market of the trades matching
+	// the filter.
+	TradePnL(filter *dexc.TradeHistoryFilter) ([]*dexc.MarketPnL, error)
+	// ExportTradeHistory writes all trades matching the filter to w in the
+	// provided format.
+	ExportTradeHistory(w io.Writer, format dexc.ExportFormat, filter *dexc.TradeHistoryFilter) error
 	ActiveOrders() (map[string][]*core.Order, map[string][]*core.InFlightOrder, error)
 	Active() bool
 	Trade(pw []byte, form *core.TradeForm) (*core.Order, error)
@@ -44,4 +77,29 @@ type DEXClient interface {
 	MaxSell(host string, base, quote uint32) (*core.MaxOrderEstimate, error)
 	PreOrder(form *core.TradeForm) (*core.OrderEstimate, error)
 	Cancel(oid dex.Bytes) error
+	// CancelAll cancels the standing limit orders placed on host, optionally
+	// limited to the provided market, and returns the number of orders
+	// canceled.
+	CancelAll(host string, market *dexc.TradeMarket) (int, error)
+	// AddTriggerOrder saves an order that is placed by the client once the
+	// market reaches triggerRate. Trigger orders are persisted and only
+	// evaluated while the DEX client is logged in.
+	AddTriggerOrder(pw []byte, triggerType dexc.TriggerType, triggerRate uint64, form *core.TradeForm) (*dexc.TriggerOrder, error)
+	// TriggerOrders returns the saved trigger orders.
+	TriggerOrders() ([]*dexc.TriggerOrder, error)
+	// RemoveTriggerOrder deletes a saved trigger order.
+	RemoveTriggerOrder(id string) error
+	// WalletFunds returns the balance of the wallet account used by the DEX
+	// client for assetID, including the funds locked by orders, swaps and
+	// bonds.
+	WalletFunds(assetID uint32) (*dexc.WalletFunds, error)
+	// AllWalletFunds returns the funds of every wallet used by the DEX
+	// client.
+	AllWalletFunds() ([]*dexc.WalletFunds, error)
+	// AccountWalletFunds returns the funds of the wallet account or nil if
+	// the account is not used by the DEX client.
+	AccountWalletFunds(walletID int, accountNumber int32) (*dexc.WalletFunds, error)
+	// InFlightSwaps returns the unredeemed and unrefunded swap contracts sent
+	// from the DEX wallet for assetID.
+	InFlightSwaps(assetID uint32) ([]*dexc.SwapTx, error)
 }
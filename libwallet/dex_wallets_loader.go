@@ -186,6 +186,14 @@ func btcCloneWalletConstructor(isLtc bool) func(settings map[string]string, chai
 			return nil, fmt.Errorf("cannot use watch only wallet for DEX trade")
 		}
 
+		// The DEX wallet reads the chain through the neutrino chain service.
+		if wallet.RPCConfig() != nil {
+			return nil, fmt.Errorf("cannot use a wallet syncing from a full node for DEX trade")
+		}
+		if wallet.ElectrumConfig() != nil {
+			return nil, fmt.Errorf("cannot use a wallet syncing from electrum servers for DEX trade")
+		}
+
 		// Ensure the wallet account exists.
 		accountNumberStr := settings[dexc.WalletAccountNumberConfigKey]
 		acctNum, err := strconv.ParseInt(accountNumberStr, 10, 64)
@@ -199,9 +207,11 @@ func btcCloneWalletConstructor(isLtc bool) func(settings map[string]string, chai
 		}
 
 		if isLtc {
-			return ltc.NewDEXWallet(wallet.Internal().LTC, accountNumber, wallet.(*ltc.Asset).NeutrinoClient(), chainParams, wallet), nil
+			ltcAsset := wallet.(*ltc.Asset)
+			return ltc.NewDEXWallet(wallet.Internal().LTC, accountNumber, ltcAsset.NeutrinoClient(), chainParams, ltcAsset), nil
 		}
 
-		return btc.NewDEXWallet(wallet.Internal().BTC, accountNumber, wallet.(*btc.Asset).NeutrinoClient(), wallet), nil
+		btcAsset := wallet.(*btc.Asset)
+		return btc.NewDEXWallet(wallet.Internal().BTC, accountNumber, btcAsset.NeutrinoClient(), btcAsset), nil
 	}
 }
@@ -0,0 +1,266 @@
+package libwallet
+
+import (
+	"os"
+	"path/filepath"
+
+	"decred.org/dcrwallet/v4/errors"
+	"github.com/asdine/storm"
+	sharedW "github.com/crypto-power/cryptopower/libwallet/assets/wallet"
+	"github.com/crypto-power/cryptopower/libwallet/utils"
+	"golang.org/x/crypto/bcrypt"
+)
+
+const (
+	// duressProfileField is the app config field holding the duress profile.
+	duressProfileField = "duress-profile"
+
+	// duressProfileDir is the dir, in the app data dir, of the decoy data.
+	// It is named like ordinary app data so that it doesn't stand out.
+	duressProfileDir = "cache"
+
+	// duressLogDir is the dir, in the decoy data dir, of the decoy logs.
+	duressLogDir = "logs"
+)
+
+// duressProfileConfigKeys are the app config values copied to a new duress
+// profile so that it looks like the app the user set up.
+var duressProfileConfigKeys = []string{
+	sharedW.DarkModeConfigKey,
+	sharedW.LanguagePreferenceKey,
+	sharedW.CurrencyConversionConfigKey,
+	sharedW.PrivacyModeConfigKey,
+	sharedW.HideBalanceConfigKey,
+	sharedW.AutoLockTimeoutConfigKey,
+}
+
+// duressProfile locates the decoy app data opened by the duress passphrase.
+// The decoy data is a complete app data dir, in the real one, whose startup
+// passphrase is the duress passphrase. Nothing in the decoy data refers to the
+// real one, and the decoy has its own logs.
+type duressProfile struct {
+	// Dir is the random name of the decoy data dir of profiles created next
+	// to the real data dir. It is empty for profiles in duressProfileDir.
+	Dir string
+	// DBDriver is the database driver of profiles next to the real data dir.
+	// Profiles in the real data dir use the driver of the real data.
+	DBDriver string
+}
+
+func (mgr *AssetsManager) readDuressProfile() (*duressProfile, error) {
+	profile := new(duressProfile)
+	err := mgr.params.DB.Get(appConfigBucketName, duressProfileField, profile)
+	if err == storm.ErrNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return profile, nil
+}
+
+func (mgr *AssetsManager) duressProfileRoot(profile *duressProfile) string {
+	if profile.Dir != "" {
+		return filepath.Join(filepath.Dir(mgr.params.RootDir), profile.Dir)
+	}
+	return filepath.Join(mgr.params.RootDir, duressProfileDir)
+}
+
+// IsDuressPassphraseSet returns true if a duress passphrase opens a decoy set
+// of wallets in place of the wallets of this assets manager.
+func (mgr *AssetsManager) IsDuressPassphraseSet() bool {
+	profile, err := mgr.readDuressProfile()
+	if err != nil {
+		log.Errorf("Error reading the duress profile: %v", err)
+	}
+	return profile != nil
+}
+
+// SetDuressPassphrase creates an empty decoy profile opened by unlocking the
+// app with duressPassphrase. The decoy wallets are added by unlocking the app
+// with the duress passphrase, and the duress passphrase is changed by changing
+// the startup passphrase of the decoy profile. Removing that startup
+// passphrase disables the decoy profile.
+func (mgr *AssetsManager) SetDuressPassphrase(startupPassphrase, duressPassphrase string) error {
+	if !mgr.IsStartupSecuritySet() {
+		return errors.E(errors.Invalid, "a startup passphrase is required to set a duress passphrase")
+	}
+	if err := mgr.VerifyStartupPassphrase(startupPassphrase); err != nil {
+		return err
+	}
+	if duressPassphrase == "" || mgr.VerifyStartupPassphrase(duressPassphrase) == nil {
+		return errors.E(errors.Invalid, "the duress passphrase must differ from the startup passphrase")
+	}
+	if mgr.IsDuressPassphraseSet() {
+		return errors.E(errors.Exist, "a duress passphrase is already set")
+	}
+
+	profile := new(duressProfile)
+	rootDir := mgr.duressProfileRoot(profile)
+	if fileExists(rootDir) {
+		return errors.E(errors.Exist, "the decoy data dir already exists")
+	}
+	if err := os.MkdirAll(rootDir, utils.UserFilePerm); err != nil {
+		return err
+	}
+	if err := mgr.initDuressProfile(rootDir, duressPassphrase); err != nil {
+		os.RemoveAll(rootDir)
+		return err
+	}
+
+	if err := mgr.params.DB.Set(appConfigBucketName, duressProfileField, profile); err != nil {
+		os.RemoveAll(rootDir)
+		return err
+	}
+	return nil
+}
+
+// initDuressProfile creates the wallets database of a decoy profile whose
+// startup passphrase is duressPassphrase.
+func (mgr *AssetsManager) initDuressProfile(rootDir, duressPassphrase string) error {
+	passphraseHash, err := bcrypt.GenerateFromPassword([]byte(duressPassphrase), bcrypt.DefaultCost)
+	if err != nil {
+		return err
+	}
+
+	db, err := storm.Open(filepath.Join(rootDir, walletsDbName))
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+	if err := db.Init(&sharedW.Wallet{}); err != nil {
+		return err
+	}
+
+	tx, err := db.Begin(true)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+	for _, key := range duressProfileConfigKeys {
+		value, err := mgr.params.DB.GetBytes(appConfigBucketName, key)
+		if err == storm.ErrNotFound {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		if err := tx.SetBytes(appConfigBucketName, key, value); err != nil {
+			return err
+		}
+	}
+	if err := tx.Set(appConfigBucketName, walletStartupPassphraseField, passphraseHash); err != nil {
+		return err
+	}
+	if err := tx.Set(appConfigBucketName, sharedW.IsStartupSecuritySetConfigKey, true); err != nil {
+		return err
+	}
+	if err := tx.Set(appConfigBucketName, sharedW.StartupSecurityTypeConfigKey, sharedW.PassphraseTypePass); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// RemoveDuressPassphrase deletes the decoy profile and its wallets.
+func (mgr *AssetsManager) RemoveDuressPassphrase(startupPassphrase string) error {
+	if err := mgr.VerifyStartupPassphrase(startupPassphrase); err != nil {
+		return err
+	}
+	profile, err := mgr.readDuressProfile()
+	if err != nil || profile == nil {
+		return err
+	}
+
+	if err := os.RemoveAll(mgr.duressProfileRoot(profile)); err != nil {
+		return err
+	}
+	mgr.appConfigDelete(duressProfileField)
+	return nil
+}
+
+// OpenDuressProfile opens the decoy profile if passphrase is the duress
+// passphrase. The decoy assets manager returned, with its wallets opened, must
+// be used in place of this assets manager, which must then be shut down. The
+// error returned for any other passphrase is the same as for a wrong startup
+// passphrase.
+func (mgr *AssetsManager) OpenDuressProfile(passphrase string) (*AssetsManager, error) {
+	profile, err := mgr.readDuressProfile()
+	if err != nil {
+		return nil, err
+	}
+	if profile == nil {
+		return nil, errors.E(utils.ErrInvalidPassphrase)
+	}
+
+	rootDir := mgr.duressProfileRoot(profile)
+	if err := verifyDuressPassphrase(rootDir, passphrase); err != nil {
+		return nil, err
+	}
+
+	// The decoy data in the real data dir is migrated with it to another
+	// database driver.
+	dbDriver := profile.DBDriver
+	if profile.Dir == "" {
+		dbDriver = mgr.params.DbDriver
+	}
+	logDir := filepath.Join(rootDir, duressLogDir)
+	decoy, err := openAssetsManager(rootDir, dbDriver, logDir, mgr.params.NetType, mgr.params.DEXTestAddr)
+	if err != nil {
+		return nil, err
+	}
+	if err := decoy.OpenWallets(passphrase); err != nil {
+		decoy.Shutdown()
+		return nil, err
+	}
+	decoy.createLogFiles()
+	return decoy, nil
+}
+
+// createLogFiles creates the app and wallet log files shown in the settings
+// of a decoy profile, whose logs aren't written by the app, so that the decoy
+// shows empty logs rather than missing ones.
+func (mgr *AssetsManager) createLogFiles() {
+	logFiles := []string{mgr.LogFile()}
+	for _, wallet := range mgr.AllWallets() {
+		logFiles = append(logFiles, wallet.LogFile())
+	}
+	for _, logFile := range logFiles {
+		if err := os.MkdirAll(filepath.Dir(logFile), utils.UserFilePerm); err != nil {
+			log.Errorf("Error creating the log dir: %v", err)
+			return
+		}
+		f, err := os.OpenFile(logFile, os.O_CREATE|os.O_RDONLY, utils.UserFilePerm)
+		if err != nil {
+			log.Errorf("Error creating a log file: %v", err)
+			continue
+		}
+		f.Close()
+	}
+}
+
+// verifyDuressPassphrase checks passphrase against the startup passphrase of
+// the decoy profile in rootDir without opening the profile. Any failure is
+// reported as a wrong passphrase, and isn't logged, so that it doesn't reveal
+// the profile.
+func verifyDuressPassphrase(rootDir, passphrase string) error {
+	invalid := errors.E(utils.ErrInvalidPassphrase)
+
+	dbPath := filepath.Join(rootDir, walletsDbName)
+	if !fileExists(dbPath) {
+		return invalid
+	}
+	db, err := storm.Open(dbPath)
+	if err != nil {
+		return invalid
+	}
+	defer db.Close()
+
+	var passphraseHash []byte
+	err = db.Get(appConfigBucketName, walletStartupPassphraseField, &passphraseHash)
+	if err != nil || bcrypt.CompareHashAndPassword(passphraseHash, []byte(passphrase)) != nil {
+		return invalid
+	}
+	return nil
+}
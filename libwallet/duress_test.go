@@ -0,0 +1,49 @@
+package libwallet
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/asdine/storm"
+	sharedW "github.com/crypto-power/cryptopower/libwallet/assets/wallet"
+)
+
+func TestDuressProfile(t *testing.T) {
+	db, err := storm.Open(filepath.Join(t.TempDir(), walletsDbName))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	if err := db.Set(appConfigBucketName, sharedW.DarkModeConfigKey, true); err != nil {
+		t.Fatal(err)
+	}
+	mgr := &AssetsManager{params: &sharedW.InitParams{DB: db}}
+
+	rootDir := t.TempDir()
+	if err := mgr.initDuressProfile(rootDir, "duress"); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := verifyDuressPassphrase(rootDir, "duress"); err != nil {
+		t.Fatalf("duress passphrase rejected: %v", err)
+	}
+	if err := verifyDuressPassphrase(rootDir, "wrong"); err == nil {
+		t.Fatal("wrong passphrase accepted")
+	}
+	if err := verifyDuressPassphrase(filepath.Join(rootDir, "missing"), "duress"); err == nil {
+		t.Fatal("missing profile accepted")
+	}
+
+	decoyDB, err := storm.Open(filepath.Join(rootDir, walletsDbName))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer decoyDB.Close()
+	var darkMode, securitySet bool
+	if err := decoyDB.Get(appConfigBucketName, sharedW.DarkModeConfigKey, &darkMode); err != nil || !darkMode {
+		t.Fatalf("dark mode not copied: %v", err)
+	}
+	if err := decoyDB.Get(appConfigBucketName, sharedW.IsStartupSecuritySetConfigKey, &securitySet); err != nil || !securitySet {
+		t.Fatalf("startup security not set: %v", err)
+	}
+}
@@ -60,7 +60,11 @@ func GetInstantExchangePrivKey(server Server) (string, bool) {
 	return key, ok
 }
 
-func NewInstantSwap(db *storm.DB) (*InstantSwap, error) {
+// MetadataBuckets are the database buckets holding the orders and the sync
+// progress.
+var MetadataBuckets = []string{"Order", configDBBkt}
+
+func NewInstantSwap(db storm.Node) (*InstantSwap, error) {
 	if err := db.Init(&Order{}); err != nil {
 		log.Errorf("Error initializing instantSwap database: %s", err.Error())
 		return nil, err
@@ -99,6 +103,13 @@ func (instantSwap *InstantSwap) saveOrder(order *Order) error {
 	return instantSwap.db.Save(order)
 }
 
+// ImportOrder saves an order restored from a backup, replacing any existing
+// order with the same UUID.
+func (instantSwap *InstantSwap) ImportOrder(order *Order) error {
+	order.ID = 0
+	return instantSwap.saveOrOverwriteOrder(order)
+}
+
 // UpdateOrder updates an order in the database.
 func (instantSwap *InstantSwap) UpdateOrder(order *Order) error {
 	return instantSwap.updateOrder(order)
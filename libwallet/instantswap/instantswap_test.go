@@ -0,0 +1,78 @@
+package instantswap
+
+import (
+	"bytes"
+	"path/filepath"
+	"testing"
+
+	"github.com/asdine/storm"
+	"github.com/crypto-power/cryptopower/libwallet/internal/dbcrypt"
+	"github.com/kevinburke/nacl"
+	bolt "go.etcd.io/bbolt"
+)
+
+func TestEncryptedOrders(t *testing.T) {
+	codec := dbcrypt.NewCodec()
+	key := nacl.NewKey()
+	codec.SetKeys(key, nil)
+	codec.Enable(true)
+	db, err := storm.Open(filepath.Join(t.TempDir(), "test.db"), storm.Codec(codec))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	instantSwap, err := NewInstantSwap(db)
+	if err != nil {
+		t.Fatal(err)
+	}
+	server := ExchangeServer{Server: ChangeNow, Config: ExchangeConfig{APIKey: "key"}}
+	uuids := []string{"order-uuid-a", "order-uuid-b"}
+	for _, uuid := range uuids {
+		order := &Order{UUID: uuid, Server: ChangeNow, ExchangeServer: server}
+		if err := instantSwap.saveOrder(order); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	assertFound := func() {
+		t.Helper()
+		var orders []*Order
+		if err := db.Find("Server", ChangeNow, &orders); err != nil || len(orders) != 2 {
+			t.Fatalf("found %d orders by server: %v", len(orders), err)
+		}
+		var order Order
+		if err := db.One("ExchangeServer", server, &order); err != nil {
+			t.Fatalf("order not found by exchange server: %v", err)
+		}
+		if _, err := instantSwap.GetOrderByUUIDRaw(uuids[1]); err != nil {
+			t.Fatalf("order not found by uuid: %v", err)
+		}
+	}
+	assertFound()
+
+	// No order field is written to the database unencrypted.
+	err = db.Bolt.View(func(tx *bolt.Tx) error {
+		return tx.ForEach(func(_ []byte, b *bolt.Bucket) error {
+			return b.ForEach(func(k, v []byte) error {
+				for _, uuid := range uuids {
+					if bytes.Contains(k, []byte(uuid)) || bytes.Contains(v, []byte(uuid)) {
+						t.Fatalf("order uuid %s written unencrypted", uuid)
+					}
+				}
+				return nil
+			})
+		})
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	newKey := nacl.NewKey()
+	codec.SetKeys(newKey, key)
+	if err := codec.Recode(db.Bolt, MetadataBuckets...); err != nil {
+		t.Fatal(err)
+	}
+	codec.SetKeys(newKey, nil)
+	assertFound()
+}
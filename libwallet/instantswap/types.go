@@ -50,7 +50,7 @@ func (es Server) CapFirstLetter() string {
 }
 
 type InstantSwap struct {
-	db  *storm.DB
+	db  storm.Node
 	ctx context.Context
 
 	syncMu     sync.RWMutex
@@ -72,11 +72,13 @@ type OrderNotificationListener struct {
 	OnOrderSchedulerEnded   func()
 }
 
+// Order is an instant swap order. Only the ID is written to the database
+// unencrypted, so no other field is indexed.
 type Order struct {
 	ID                       int            `storm:"id,increment"`
-	UUID                     string         `storm:"unique" json:"uuid"`
-	Server                   Server         `json:"server" storm:"index"`         // Legacy Exchange Server field, used to update the new ExchangeServer field
-	ExchangeServer           ExchangeServer `json:"exchangeServer" storm:"index"` // New Exchange Server field
+	UUID                     string         `json:"uuid"`
+	Server                   Server         `json:"server"`         // Legacy Exchange Server field, used to update the new ExchangeServer field
+	ExchangeServer           ExchangeServer `json:"exchangeServer"` // New Exchange Server field
 	SourceWalletID           int            `json:"sourceWalletID"`
 	SourceAccountNumber      int32          `json:"sourceAccountNumber"`
 	DestinationWalletID      int            `json:"destinationWalletID"`
@@ -100,9 +102,9 @@ type Order struct {
 	ChargedFee         float64 `json:"chargedFee"`
 
 	Confirmations string             `json:"confirmations"`
-	Status        instantswap.Status `json:"status" storm:"index"`
+	Status        instantswap.Status `json:"status"`
 	ExpiryTime    int                `json:"expiryTime"` // in seconds
-	CreatedAt     int64              `storm:"index" json:"createdAt"`
+	CreatedAt     int64              `json:"createdAt"`
 	LastUpdate    string             `json:"lastUpdate"` // should be timestamp (api currently returns string)
 
 	ExtraID string `json:"extraId"` // changenow.io requirement //changelly payinExtraId value
@@ -0,0 +1,299 @@
+// Package dbcrypt provides a storm codec that encrypts the values of the
+// metadata databases so that they can't be read without the startup
+// passphrase.
+//
+// Storm writes the IDs and the indexed fields of the records to the database
+// as is, so the records encrypted with the codec must not be keyed or indexed
+// by a readable value. Codec.BlindID derives an unreadable ID from such a
+// value.
+package dbcrypt
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"sync"
+
+	"decred.org/dcrwallet/v4/errors"
+	"github.com/asdine/storm/codec/json"
+	"github.com/kevinburke/nacl"
+	"github.com/kevinburke/nacl/secretbox"
+	bolt "go.etcd.io/bbolt"
+)
+
+var (
+	// encryptedPrefix marks encrypted values. JSON values never start with
+	// it, so values written before the encryption was enabled remain
+	// readable.
+	encryptedPrefix = []byte("CPWENC2:")
+	// legacyEncryptedPrefix marks values encrypted directly with the master
+	// key by earlier versions. They are decrypted but never written.
+	legacyEncryptedPrefix = []byte("CPWENC1:")
+
+	// stormIndexPrefix is the prefix of the nested buckets storm keeps the
+	// indexes of a bucket in.
+	stormIndexPrefix = []byte("__storm_index_")
+)
+
+// The labels the subkeys are derived from the master key with, so that no key
+// is used both to encrypt the values and to blind the IDs.
+const (
+	boxKeyLabel = "cryptopower metadata encryption key"
+	idKeyLabel  = "cryptopower metadata id key"
+)
+
+// ErrLocked is returned when an encrypted value is read or written before the
+// key is set.
+var ErrLocked = errors.E(errors.Locked, "the metadata databases are locked")
+
+// keySet holds the subkeys derived from a master key.
+type keySet struct {
+	master nacl.Key
+	box    nacl.Key
+	id     []byte
+}
+
+func newKeySet(master nacl.Key) *keySet {
+	if master == nil {
+		return nil
+	}
+	box := new([nacl.KeySize]byte)
+	copy(box[:], deriveKey(master, boxKeyLabel))
+	return &keySet{
+		master: master,
+		box:    box,
+		id:     deriveKey(master, idKeyLabel),
+	}
+}
+
+func deriveKey(master nacl.Key, label string) []byte {
+	mac := hmac.New(sha256.New, master[:])
+	mac.Write([]byte(label))
+	return mac.Sum(nil)
+}
+
+// Codec encodes values as JSON and, if the encryption is enabled, encrypts
+// them with a subkey of the current key. Encrypted values are decrypted with
+// the current or the old key.
+type Codec struct {
+	mu      sync.RWMutex
+	enabled bool
+	keys    *keySet
+	oldKeys *keySet
+}
+
+// NewCodec returns a codec that doesn't encrypt new values until Enable is
+// called.
+func NewCodec() *Codec {
+	return new(Codec)
+}
+
+// Enable sets whether new values are encrypted. Writes fail with ErrLocked
+// while the encryption is enabled and the key isn't set.
+func (c *Codec) Enable(enabled bool) {
+	c.mu.Lock()
+	c.enabled = enabled
+	c.mu.Unlock()
+}
+
+// IsEnabled returns true if new values are encrypted.
+func (c *Codec) IsEnabled() bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.enabled
+}
+
+// IsLocked returns true if the encryption is enabled and the key isn't set.
+func (c *Codec) IsLocked() bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.enabled && c.keys == nil
+}
+
+// SetKeys sets the key used to encrypt and decrypt values and the old key
+// used to decrypt values that weren't re-encrypted with the key yet. Either
+// key may be nil.
+func (c *Codec) SetKeys(key, oldKey nacl.Key) {
+	keys, oldKeys := newKeySet(key), newKeySet(oldKey)
+	c.mu.Lock()
+	c.keys, c.oldKeys = keys, oldKeys
+	c.mu.Unlock()
+}
+
+// BlindID returns the ID a record identified by id is saved with: a keyed
+// hash of id if the encryption is enabled, or id itself otherwise. The IDs
+// change with the key, so the records keyed by them must be saved again when
+// the database is re-encoded.
+func (c *Codec) BlindID(id string) (string, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if !c.enabled {
+		return id, nil
+	}
+	if c.keys == nil {
+		return "", ErrLocked
+	}
+	mac := hmac.New(sha256.New, c.keys.id)
+	mac.Write([]byte(id))
+	return hex.EncodeToString(mac.Sum(nil)), nil
+}
+
+// Marshal encodes v as JSON and encrypts it if the encryption is enabled.
+// Part of the codec.MarshalUnmarshaler interface.
+func (c *Codec) Marshal(v interface{}) ([]byte, error) {
+	data, err := json.Codec.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	return c.seal(data)
+}
+
+// Unmarshal decrypts b if it is encrypted and decodes it into v.
+// Part of the codec.MarshalUnmarshaler interface.
+func (c *Codec) Unmarshal(b []byte, v interface{}) error {
+	data, err := c.Decrypt(b)
+	if err != nil {
+		return err
+	}
+	return json.Codec.Unmarshal(data, v)
+}
+
+// Name returns the name of the JSON codec. Storm refuses to open buckets
+// written with a codec of a different name, and unencrypted values are plain
+// JSON.
+// Part of the codec.MarshalUnmarshaler interface.
+func (c *Codec) Name() string {
+	return json.Codec.Name()
+}
+
+func (c *Codec) seal(data []byte) ([]byte, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if !c.enabled {
+		return data, nil
+	}
+	if c.keys == nil {
+		return nil, ErrLocked
+	}
+
+	box := secretbox.EasySeal(data, c.keys.box)
+	return append(append([]byte(nil), encryptedPrefix...), box...), nil
+}
+
+// IsEncrypted returns true if the value b read from a database encoded with
+// the codec is encrypted.
+func IsEncrypted(b []byte) bool {
+	return bytes.HasPrefix(b, encryptedPrefix) || bytes.HasPrefix(b, legacyEncryptedPrefix)
+}
+
+// Decrypt returns the JSON encoding of the value b read from a database
+// encoded with the codec.
+func (c *Codec) Decrypt(b []byte) ([]byte, error) {
+	box, ok := bytes.CutPrefix(b, encryptedPrefix)
+	legacyBox, legacy := bytes.CutPrefix(b, legacyEncryptedPrefix)
+	if !ok && !legacy {
+		return b, nil
+	}
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if c.keys == nil && c.oldKeys == nil {
+		return nil, ErrLocked
+	}
+	for _, keys := range []*keySet{c.keys, c.oldKeys} {
+		if keys == nil {
+			continue
+		}
+		key := keys.box
+		if legacy {
+			box, key = legacyBox, keys.master
+		}
+		if data, err := secretbox.EasyOpen(box, key); err == nil {
+			return data, nil
+		}
+	}
+	return nil, errors.E(errors.Crypto, "unable to decrypt metadata value")
+}
+
+// Recode re-encodes the values of the given top-level buckets of db with the
+// current key, or as plain JSON if the encryption is disabled. Nested buckets
+// are left as is.
+func (c *Codec) Recode(db *bolt.DB, buckets ...string) error {
+	for _, name := range buckets {
+		if err := c.RecodeKeys(db, name, nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RecodeKeys re-encodes the values of the top-level bucket of db whose keys
+// match, or all of its values if match is nil.
+func (c *Codec) RecodeKeys(db *bolt.DB, bucketName string, match func(key []byte) bool) error {
+	return db.Update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket([]byte(bucketName))
+		if bucket == nil {
+			return nil
+		}
+
+		var keys, values [][]byte
+		err := bucket.ForEach(func(k, v []byte) error {
+			if v == nil || (match != nil && !match(k)) {
+				return nil
+			}
+			data, err := c.Decrypt(v)
+			if err != nil {
+				return err
+			}
+			value, err := c.seal(data)
+			if err != nil {
+				return err
+			}
+			keys = append(keys, append([]byte(nil), k...))
+			values = append(values, value)
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		for i, k := range keys {
+			if err := bucket.Put(k, values[i]); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// DropIndexes deletes the storm indexes of the given top-level buckets of db.
+// Storm writes the indexed values as is, so the indexes of records that are
+// no longer indexed must be deleted for the values not to remain readable.
+func DropIndexes(db *bolt.DB, buckets ...string) error {
+	return db.Update(func(tx *bolt.Tx) error {
+		for _, name := range buckets {
+			bucket := tx.Bucket([]byte(name))
+			if bucket == nil {
+				continue
+			}
+
+			var indexes [][]byte
+			cursor := bucket.Cursor()
+			for k, v := cursor.Seek(stormIndexPrefix); k != nil && bytes.HasPrefix(k, stormIndexPrefix); k, v = cursor.Next() {
+				if v == nil {
+					indexes = append(indexes, append([]byte(nil), k...))
+				}
+			}
+			for _, k := range indexes {
+				if err := bucket.DeleteBucket(k); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	})
+}
@@ -0,0 +1,124 @@
+package dbcrypt
+
+import (
+	"bytes"
+	"path/filepath"
+	"testing"
+
+	"decred.org/dcrwallet/v4/errors"
+	"github.com/asdine/storm"
+	"github.com/kevinburke/nacl"
+	bolt "go.etcd.io/bbolt"
+)
+
+type record struct {
+	ID    int    `storm:"id,increment"`
+	Hash  string `storm:"unique"`
+	Label string
+}
+
+func TestCodec(t *testing.T) {
+	c := NewCodec()
+	db, err := storm.Open(filepath.Join(t.TempDir(), "test.db"), storm.Codec(c))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	// Values saved before the encryption is enabled.
+	if err := db.Save(&record{Hash: "a", Label: "rent"}); err != nil {
+		t.Fatal(err)
+	}
+
+	c.Enable(true)
+	if err := db.Save(&record{Hash: "b"}); !errors.Is(err, ErrLocked) {
+		t.Fatalf("expected ErrLocked, got %v", err)
+	}
+
+	key := nacl.NewKey()
+	c.SetKeys(key, nil)
+	if err := db.Save(&record{Hash: "b", Label: "salary"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.Recode(db.Bolt, "record"); err != nil {
+		t.Fatal(err)
+	}
+	assertEncrypted(t, db.Bolt, true)
+
+	// Rotate the key.
+	newKey := nacl.NewKey()
+	c.SetKeys(newKey, key)
+	if err := c.Recode(db.Bolt, "record"); err != nil {
+		t.Fatal(err)
+	}
+	c.SetKeys(newKey, nil)
+
+	var r record
+	if err := db.One("Hash", "a", &r); err != nil || r.Label != "rent" {
+		t.Fatalf("unexpected record %+v: %v", r, err)
+	}
+
+	c.SetKeys(nil, nil)
+	if err := db.One("Hash", "b", &r); !errors.Is(err, ErrLocked) {
+		t.Fatalf("expected ErrLocked, got %v", err)
+	}
+
+	// Decrypt the values.
+	c.SetKeys(newKey, nil)
+	c.Enable(false)
+	if err := c.Recode(db.Bolt, "record"); err != nil {
+		t.Fatal(err)
+	}
+	assertEncrypted(t, db.Bolt, false)
+	c.SetKeys(nil, nil)
+	if err := db.One("Hash", "b", &r); err != nil || r.Label != "salary" {
+		t.Fatalf("unexpected record %+v: %v", r, err)
+	}
+}
+
+func assertEncrypted(t *testing.T, db *bolt.DB, encrypted bool) {
+	t.Helper()
+	err := db.View(func(tx *bolt.Tx) error {
+		return tx.Bucket([]byte("record")).ForEach(func(_, v []byte) error {
+			if v != nil && bytes.HasPrefix(v, encryptedPrefix) != encrypted {
+				t.Fatalf("value %q encrypted: %v, want %v", v, !encrypted, encrypted)
+			}
+			return nil
+		})
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestBlindID(t *testing.T) {
+	c := NewCodec()
+	if id, err := c.BlindID("hash"); err != nil || id != "hash" {
+		t.Fatalf("unexpected id %q: %v", id, err)
+	}
+
+	c.Enable(true)
+	if _, err := c.BlindID("hash"); !errors.Is(err, ErrLocked) {
+		t.Fatalf("expected ErrLocked, got %v", err)
+	}
+
+	key := nacl.NewKey()
+	c.SetKeys(key, nil)
+	id, err := c.BlindID("hash")
+	if err != nil || id == "hash" {
+		t.Fatalf("unexpected id %q: %v", id, err)
+	}
+	if again, _ := c.BlindID("hash"); again != id {
+		t.Fatalf("id changed from %q to %q", id, again)
+	}
+
+	// The IDs and the values are derived from different subkeys.
+	keys := newKeySet(key)
+	if bytes.Equal(keys.id, keys.box[:]) || bytes.Equal(keys.box[:], key[:]) {
+		t.Fatal("the subkeys aren't distinct")
+	}
+	c.SetKeys(nacl.NewKey(), key)
+	if newID, _ := c.BlindID("hash"); newID == id {
+		t.Fatal("the id didn't change with the key")
+	}
+}
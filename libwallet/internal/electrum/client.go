@@ -0,0 +1,424 @@
+// Package electrum implements a client of the Electrum protocol served by
+// ElectrumX, Fulcrum and electrs. Servers are connected to over TLS, either
+// directly or through a Tor SOCKS proxy, and their certificates can be pinned.
+// See https://electrumx.readthedocs.io/en/latest/protocol-methods.html.
+package electrum
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/crypto-power/cryptopower/libwallet/utils"
+	"github.com/decred/go-socks/socks"
+)
+
+const (
+	clientName      = "cryptopower"
+	protocolVersion = "1.4"
+
+	dialTimeout = 30 * time.Second
+	// pingInterval keeps the connection alive, servers disconnect clients
+	// that stay idle for 10 minutes.
+	pingInterval = 2 * time.Minute
+	// maxMessageSize bounds the size of a message from the server. The
+	// largest messages are transactions and chunks of 2016 block headers.
+	maxMessageSize = 16 << 20
+)
+
+// ErrCertMismatch is returned when the TLS certificate of a server doesn't
+// match the fingerprint pinned for the server.
+var ErrCertMismatch = errors.New(utils.ErrElectrumCertMismatch)
+
+// errConnClosed is returned by the requests pending when the connection is
+// closed.
+var errConnClosed = errors.New("electrum: connection closed")
+
+// Options are the settings of a connection to a server.
+type Options struct {
+	// CertFingerprint is the hex encoded SHA-256 fingerprint of the TLS
+	// certificate the server must present. Any certificate is accepted if it
+	// is empty, and Conn.CertFingerprint returns the fingerprint to pin.
+	CertFingerprint string
+	// TorProxy is the host:port of the SOCKS5 proxy of a Tor client the
+	// server is connected through.
+	TorProxy string
+}
+
+// RPCError is an error returned by the server.
+type RPCError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+func (e *RPCError) Error() string {
+	return e.Message
+}
+
+type request struct {
+	JSONRPC string `json:"jsonrpc"`
+	ID      uint64 `json:"id"`
+	Method  string `json:"method"`
+	Params  []any  `json:"params"`
+}
+
+type response struct {
+	ID     *uint64         `json:"id"`
+	Method string          `json:"method"`
+	Params json.RawMessage `json:"params"`
+	Result json.RawMessage `json:"result"`
+	Error  json.RawMessage `json:"error"`
+}
+
+// Conn is a connection to an Electrum server. It is closed on the first
+// network error and a new connection must then be dialed.
+type Conn struct {
+	conn        net.Conn
+	fingerprint string
+	nextID      atomic.Uint64
+
+	writeMu sync.Mutex
+
+	pendingMu sync.Mutex
+	pending   map[uint64]chan *response
+
+	ntfnMu     sync.Mutex
+	ntfnQueue  []any
+	ntfnSignal chan struct{}
+	ntfns      chan any
+
+	closeOnce sync.Once
+	done      chan struct{}
+}
+
+// Dial connects to the server at addr over TLS and negotiates the protocol
+// version. The connection is closed when ctx is canceled.
+func Dial(ctx context.Context, addr string, opts *Options) (*Conn, error) {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return nil, err
+	}
+
+	dialCtx, cancel := context.WithTimeout(ctx, dialTimeout)
+	defer cancel()
+
+	var netConn net.Conn
+	if opts.TorProxy != "" {
+		// Tor isolates the circuit of each proxy username, so the server
+		// can't link the connections of different wallets.
+		proxy := &socks.Proxy{Addr: opts.TorProxy, TorIsolation: true}
+		netConn, err = proxy.DialContext(dialCtx, "tcp", addr)
+	} else {
+		netConn, err = new(net.Dialer).DialContext(dialCtx, "tcp", addr)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// Electrum servers mostly use self-signed certificates, which are
+	// authenticated by their pinned fingerprint instead of a CA.
+	var fingerprint string
+	tlsConn := tls.Client(netConn, &tls.Config{
+		ServerName:         host,
+		MinVersion:         tls.VersionTLS12,
+		InsecureSkipVerify: true,
+		VerifyPeerCertificate: func(rawCerts [][]byte, _ [][]*x509.Certificate) error {
+			if len(rawCerts) == 0 {
+				return errors.New("electrum: no server certificate")
+			}
+			fingerprint = CertFingerprint(rawCerts[0])
+			if opts.CertFingerprint != "" && !strings.EqualFold(fingerprint, opts.CertFingerprint) {
+				return ErrCertMismatch
+			}
+			return nil
+		},
+	})
+	if err := tlsConn.HandshakeContext(dialCtx); err != nil {
+		netConn.Close()
+		return nil, err
+	}
+
+	c := newConn(tlsConn)
+	c.fingerprint = fingerprint
+	go func() {
+		select {
+		case <-ctx.Done():
+			c.Close()
+		case <-c.done:
+		}
+	}()
+
+	var versions []string
+	err = c.request(dialCtx, "server.version", &versions, clientName, protocolVersion)
+	if err != nil {
+		c.Close()
+		return nil, fmt.Errorf("electrum: negotiating protocol version failed: %w", err)
+	}
+
+	go c.pinger()
+	return c, nil
+}
+
+// newConn starts serving the requests and notifications of a connection to
+// a server.
+func newConn(netConn net.Conn) *Conn {
+	c := &Conn{
+		conn:       netConn,
+		pending:    make(map[uint64]chan *response),
+		ntfnSignal: make(chan struct{}, 1),
+		ntfns:      make(chan any),
+		done:       make(chan struct{}),
+	}
+	go c.reader()
+	go c.notificationHandler()
+	return c
+}
+
+// CertFingerprint returns the hex encoded SHA-256 fingerprint of a DER encoded
+// certificate.
+func CertFingerprint(cert []byte) string {
+	sum := sha256.Sum256(cert)
+	return hex.EncodeToString(sum[:])
+}
+
+// CertFingerprint returns the fingerprint of the TLS certificate presented by
+// the server.
+func (c *Conn) CertFingerprint() string {
+	return c.fingerprint
+}
+
+// Notifications returns the channel header and script hash notifications are
+// delivered on, as *HeaderNotification and *ScriptHashNotification. The
+// channel is closed with the connection.
+func (c *Conn) Notifications() <-chan any {
+	return c.ntfns
+}
+
+// Done returns a channel that is closed once the connection is closed.
+func (c *Conn) Done() <-chan struct{} {
+	return c.done
+}
+
+// Close closes the connection. Pending requests fail.
+func (c *Conn) Close() {
+	c.closeOnce.Do(func() {
+		c.conn.Close()
+		close(c.done)
+	})
+}
+
+func (c *Conn) pinger() {
+	ticker := time.NewTicker(pingInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			ctx, cancel := context.WithTimeout(context.Background(), dialTimeout)
+			err := c.request(ctx, "server.ping", nil)
+			cancel()
+			if err != nil {
+				c.Close()
+				return
+			}
+		case <-c.done:
+			return
+		}
+	}
+}
+
+// reader reads the responses and notifications sent by the server until the
+// connection is closed.
+func (c *Conn) reader() {
+	defer func() {
+		c.Close()
+		c.pendingMu.Lock()
+		for id, respChan := range c.pending {
+			close(respChan)
+			delete(c.pending, id)
+		}
+		c.pendingMu.Unlock()
+	}()
+
+	reader := bufio.NewReader(c.conn)
+	for {
+		msg, err := readMessage(reader)
+		if err != nil {
+			return
+		}
+
+		var resp response
+		if err := json.Unmarshal(msg, &resp); err != nil {
+			return
+		}
+
+		if resp.Method != "" {
+			c.handleNotification(resp.Method, resp.Params)
+			continue
+		}
+		if resp.ID == nil {
+			continue
+		}
+
+		c.pendingMu.Lock()
+		respChan, ok := c.pending[*resp.ID]
+		delete(c.pending, *resp.ID)
+		c.pendingMu.Unlock()
+		if ok {
+			respChan <- &resp
+		}
+	}
+}
+
+// readMessage reads a newline delimited message of at most maxMessageSize
+// bytes.
+func readMessage(reader *bufio.Reader) ([]byte, error) {
+	var msg []byte
+	for {
+		line, err := reader.ReadSlice('\n')
+		msg = append(msg, line...)
+		if len(msg) > maxMessageSize {
+			return nil, errors.New("electrum: message too large")
+		}
+		if err == nil {
+			return bytes.TrimSpace(msg), nil
+		}
+		if !errors.Is(err, bufio.ErrBufferFull) {
+			return nil, err
+		}
+	}
+}
+
+// request sends a request to the server and decodes its result into result,
+// unless result is nil.
+func (c *Conn) request(ctx context.Context, method string, result any, params ...any) error {
+	if params == nil {
+		params = []any{}
+	}
+	id := c.nextID.Add(1)
+	msg, err := json.Marshal(&request{JSONRPC: "2.0", ID: id, Method: method, Params: params})
+	if err != nil {
+		return err
+	}
+
+	respChan := make(chan *response, 1)
+	c.pendingMu.Lock()
+	c.pending[id] = respChan
+	c.pendingMu.Unlock()
+	defer func() {
+		c.pendingMu.Lock()
+		delete(c.pending, id)
+		c.pendingMu.Unlock()
+	}()
+
+	c.writeMu.Lock()
+	_, err = c.conn.Write(append(msg, '\n'))
+	c.writeMu.Unlock()
+	if err != nil {
+		c.Close()
+		return err
+	}
+
+	var resp *response
+	select {
+	case resp = <-respChan:
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-c.done:
+		// The response may have been delivered before the connection was
+		// closed.
+		select {
+		case resp = <-respChan:
+		default:
+		}
+	}
+	if resp == nil {
+		return errConnClosed
+	}
+
+	if len(resp.Error) > 0 && string(resp.Error) != "null" {
+		rpcErr := new(RPCError)
+		if err := json.Unmarshal(resp.Error, rpcErr); err != nil {
+			// Some servers return the error message as a string.
+			rpcErr.Message = strings.Trim(string(resp.Error), `"`)
+		}
+		return rpcErr
+	}
+	if result == nil {
+		return nil
+	}
+	return json.Unmarshal(resp.Result, result)
+}
+
+// handleNotification queues the notifications of subscriptions. Notifications
+// are queued without bound so the reader never blocks on a slow consumer.
+func (c *Conn) handleNotification(method string, params json.RawMessage) {
+	var ntfn any
+	switch method {
+	case "blockchain.headers.subscribe":
+		var headers []*HeaderNotification
+		if err := json.Unmarshal(params, &headers); err != nil || len(headers) == 0 {
+			return
+		}
+		ntfn = headers[len(headers)-1]
+	case "blockchain.scripthash.subscribe":
+		var args []*string
+		if err := json.Unmarshal(params, &args); err != nil || len(args) != 2 || args[0] == nil {
+			return
+		}
+		n := &ScriptHashNotification{ScriptHash: *args[0]}
+		if args[1] != nil {
+			n.Status = *args[1]
+		}
+		ntfn = n
+	default:
+		return
+	}
+
+	c.ntfnMu.Lock()
+	c.ntfnQueue = append(c.ntfnQueue, ntfn)
+	c.ntfnMu.Unlock()
+	select {
+	case c.ntfnSignal <- struct{}{}:
+	default:
+	}
+}
+
+func (c *Conn) notificationHandler() {
+	defer close(c.ntfns)
+
+	for {
+		c.ntfnMu.Lock()
+		if len(c.ntfnQueue) == 0 {
+			c.ntfnMu.Unlock()
+			select {
+			case <-c.ntfnSignal:
+				continue
+			case <-c.done:
+				return
+			}
+		}
+		ntfn := c.ntfnQueue[0]
+		c.ntfnQueue[0] = nil
+		c.ntfnQueue = c.ntfnQueue[1:]
+		c.ntfnMu.Unlock()
+
+		select {
+		case c.ntfns <- ntfn:
+		case <-c.done:
+			return
+		}
+	}
+}
@@ -0,0 +1,134 @@
+package electrum
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net"
+	"testing"
+	"time"
+)
+
+func TestScriptHash(t *testing.T) {
+	// The P2PKH script of 1A1zP1eP5QGefi2DMPTfTL5SLmv7DivfNa, from the
+	// protocol documentation.
+	pkScript, _ := hex.DecodeString("76a91462e907b15cbf27d5425399ebf6f0fb50ebb88f1888ac")
+	want := "8b01df4e368ea28f8dc0423bcf7a4923e3a12d307c875e47a0cfbf90b5c39161"
+	if got := ScriptHash(pkScript); got != want {
+		t.Fatalf("ScriptHash = %s, want %s", got, want)
+	}
+}
+
+func TestMerkleRoot(t *testing.T) {
+	hash := func(b []byte) [32]byte {
+		first := sha256.Sum256(b)
+		return sha256.Sum256(first[:])
+	}
+	pair := func(a, b [32]byte) [32]byte {
+		return hash(append(a[:], b[:]...))
+	}
+	// reversedHex encodes a hash the way servers do.
+	reversedHex := func(h [32]byte) string {
+		for i, j := 0, len(h)-1; i < j; i, j = i+1, j-1 {
+			h[i], h[j] = h[j], h[i]
+		}
+		return hex.EncodeToString(h[:])
+	}
+
+	txs := [][32]byte{hash([]byte{0}), hash([]byte{1}), hash([]byte{2})}
+	// The last hash of an odd level is paired with itself.
+	left, right := pair(txs[0], txs[1]), pair(txs[2], txs[2])
+	root := pair(left, right)
+
+	tests := []struct {
+		pos    int
+		merkle []string
+	}{
+		{0, []string{reversedHex(txs[1]), reversedHex(right)}},
+		{1, []string{reversedHex(txs[0]), reversedHex(right)}},
+		{2, []string{reversedHex(txs[2]), reversedHex(left)}},
+	}
+	for _, test := range tests {
+		got, err := MerkleRoot(txs[test.pos], &MerkleProof{Merkle: test.merkle, Pos: test.pos})
+		if err != nil {
+			t.Fatalf("pos %d: %v", test.pos, err)
+		}
+		if got != root {
+			t.Fatalf("pos %d: wrong merkle root", test.pos)
+		}
+	}
+
+	if _, err := MerkleRoot(txs[0], &MerkleProof{Merkle: []string{"00"}}); err == nil {
+		t.Fatal("expected an error for an invalid branch")
+	}
+}
+
+func TestConnRequests(t *testing.T) {
+	client, server := net.Pipe()
+	c := newConn(client)
+	defer c.Close()
+
+	// The server notifies a script hash change before answering each
+	// request, and fails the requests of unknown methods.
+	go func() {
+		reader := bufio.NewReader(server)
+		for {
+			line, err := reader.ReadBytes('\n')
+			if err != nil {
+				return
+			}
+			var req request
+			if err := json.Unmarshal(line, &req); err != nil {
+				return
+			}
+
+			fmt.Fprintf(server, `{"jsonrpc":"2.0","method":"blockchain.scripthash.subscribe","params":["%s","status"]}`+"\n", req.Params[0])
+			switch req.Method {
+			case "blockchain.scripthash.get_history":
+				fmt.Fprintf(server, `{"jsonrpc":"2.0","id":%d,"result":[{"tx_hash":"ab","height":7}]}`+"\n", req.ID)
+			default:
+				fmt.Fprintf(server, `{"jsonrpc":"2.0","id":%d,"error":{"code":-32601,"message":"unknown method"}}`+"\n", req.ID)
+			}
+		}
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	history, err := c.History(ctx, "hash")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(history) != 1 || history[0].TxHash != "ab" || history[0].Height != 7 {
+		t.Fatalf("unexpected history %+v", history)
+	}
+
+	if _, err := c.Transaction(ctx, "tx"); err == nil || err.Error() != "unknown method" {
+		t.Fatalf("expected the server error, got %v", err)
+	}
+
+	for _, want := range []string{"hash", "tx"} {
+		select {
+		case ntfn := <-c.Notifications():
+			n, ok := ntfn.(*ScriptHashNotification)
+			if !ok || n.ScriptHash != want || n.Status != "status" {
+				t.Fatalf("unexpected notification %+v", ntfn)
+			}
+		case <-ctx.Done():
+			t.Fatal("notification not delivered")
+		}
+	}
+
+	server.Close()
+	select {
+	case <-c.Done():
+	case <-ctx.Done():
+		t.Fatal("connection not closed")
+	}
+	if _, err := c.History(ctx, "hash"); err == nil {
+		t.Fatal("expected an error on a closed connection")
+	}
+}
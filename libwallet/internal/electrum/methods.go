@@ -0,0 +1,174 @@
+package electrum
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+)
+
+// HeaderSize is the size of a serialized block header.
+const HeaderSize = 80
+
+// HeaderNotification is sent by the server when its chain tip changes.
+type HeaderNotification struct {
+	Height int32  `json:"height"`
+	Hex    string `json:"hex"`
+}
+
+// ScriptHashNotification is sent by the server when the history of a
+// subscribed script hash changes. Status is empty if the script hash has no
+// history.
+type ScriptHashNotification struct {
+	ScriptHash string
+	Status     string
+}
+
+// HistoryItem is a transaction of the history of a script hash. Height is 0
+// for mempool transactions and -1 for mempool transactions with unconfirmed
+// inputs.
+type HistoryItem struct {
+	TxHash string `json:"tx_hash"`
+	Height int32  `json:"height"`
+}
+
+// MerkleProof proves the inclusion of a transaction in a block.
+type MerkleProof struct {
+	BlockHeight int32    `json:"block_height"`
+	Merkle      []string `json:"merkle"`
+	Pos         int      `json:"pos"`
+}
+
+// Features describes the server.
+type Features struct {
+	GenesisHash   string `json:"genesis_hash"`
+	ServerVersion string `json:"server_version"`
+}
+
+// ScriptHash returns the script hash a script is indexed by: the reversed
+// SHA-256 hash of the script, hex encoded.
+func ScriptHash(pkScript []byte) string {
+	sum := sha256.Sum256(pkScript)
+	for i, j := 0, len(sum)-1; i < j; i, j = i+1, j-1 {
+		sum[i], sum[j] = sum[j], sum[i]
+	}
+	return hex.EncodeToString(sum[:])
+}
+
+// MerkleRoot returns the merkle root of the block proof was made for, given
+// the hash of the proven transaction in internal byte order. The returned
+// root is in internal byte order too.
+func MerkleRoot(txHash [32]byte, proof *MerkleProof) ([32]byte, error) {
+	root := txHash
+	pos := proof.Pos
+	for _, hexHash := range proof.Merkle {
+		branch, err := hex.DecodeString(hexHash)
+		if err != nil || len(branch) != 32 {
+			return root, fmt.Errorf("electrum: invalid merkle branch %q", hexHash)
+		}
+		// Hashes are hex encoded in reversed byte order.
+		for i, j := 0, len(branch)-1; i < j; i, j = i+1, j-1 {
+			branch[i], branch[j] = branch[j], branch[i]
+		}
+
+		var buf [64]byte
+		if pos&1 == 0 {
+			copy(buf[:32], root[:])
+			copy(buf[32:], branch)
+		} else {
+			copy(buf[:32], branch)
+			copy(buf[32:], root[:])
+		}
+		first := sha256.Sum256(buf[:])
+		root = sha256.Sum256(first[:])
+		pos >>= 1
+	}
+	return root, nil
+}
+
+// Features returns the features of the server.
+func (c *Conn) Features(ctx context.Context) (*Features, error) {
+	features := new(Features)
+	return features, c.request(ctx, "server.features", features)
+}
+
+// SubscribeHeaders subscribes to the chain tip changes of the server and
+// returns its current tip.
+func (c *Conn) SubscribeHeaders(ctx context.Context) (*HeaderNotification, error) {
+	tip := new(HeaderNotification)
+	return tip, c.request(ctx, "blockchain.headers.subscribe", tip)
+}
+
+// BlockHeaders returns up to count serialized block headers starting at
+// height start. The server may return fewer headers than requested.
+func (c *Conn) BlockHeaders(ctx context.Context, start, count int32) ([][]byte, error) {
+	var result struct {
+		Count int32  `json:"count"`
+		Hex   string `json:"hex"`
+	}
+	if err := c.request(ctx, "blockchain.block.headers", &result, start, count); err != nil {
+		return nil, err
+	}
+
+	raw, err := hex.DecodeString(result.Hex)
+	if err != nil {
+		return nil, err
+	}
+	if len(raw) != int(result.Count)*HeaderSize {
+		return nil, fmt.Errorf("electrum: got %d bytes for %d headers", len(raw), result.Count)
+	}
+	headers := make([][]byte, result.Count)
+	for i := range headers {
+		headers[i] = raw[i*HeaderSize : (i+1)*HeaderSize]
+	}
+	return headers, nil
+}
+
+// SubscribeScriptHash subscribes to the history changes of a script hash and
+// returns its current status, which is empty if it has no history.
+func (c *Conn) SubscribeScriptHash(ctx context.Context, scriptHash string) (string, error) {
+	var status *string
+	if err := c.request(ctx, "blockchain.scripthash.subscribe", &status, scriptHash); err != nil {
+		return "", err
+	}
+	if status == nil {
+		return "", nil
+	}
+	return *status, nil
+}
+
+// History returns the confirmed and mempool transactions of a script hash.
+func (c *Conn) History(ctx context.Context, scriptHash string) ([]*HistoryItem, error) {
+	var history []*HistoryItem
+	return history, c.request(ctx, "blockchain.scripthash.get_history", &history, scriptHash)
+}
+
+// Transaction returns the serialized transaction with hash txid.
+func (c *Conn) Transaction(ctx context.Context, txid string) ([]byte, error) {
+	var txHex string
+	if err := c.request(ctx, "blockchain.transaction.get", &txHex, txid); err != nil {
+		return nil, err
+	}
+	return hex.DecodeString(txHex)
+}
+
+// TransactionMerkle returns the proof of inclusion of the transaction with
+// hash txid in the block at height.
+func (c *Conn) TransactionMerkle(ctx context.Context, txid string, height int32) (*MerkleProof, error) {
+	proof := new(MerkleProof)
+	return proof, c.request(ctx, "blockchain.transaction.get_merkle", proof, txid, height)
+}
+
+// Broadcast broadcasts a serialized transaction and returns its hash.
+func (c *Conn) Broadcast(ctx context.Context, tx []byte) (string, error) {
+	var txid string
+	return txid, c.request(ctx, "blockchain.transaction.broadcast", &txid, hex.EncodeToString(tx))
+}
+
+// EstimateFee returns the fee rate in coins per kilobyte needed for a
+// transaction to confirm within blocks blocks, or -1 if the server has no
+// estimate.
+func (c *Conn) EstimateFee(ctx context.Context, blocks int64) (float64, error) {
+	var feeRate float64
+	return feeRate, c.request(ctx, "blockchain.estimatefee", &feeRate, blocks)
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"path/filepath"
 	"sync"
 	"time"
@@ -217,6 +218,18 @@ func (l *btcLoader) GetLoadedWallet() (*loader.LoadedWallets, bool) {
 	return &loader.LoadedWallets{BTC: w}, w != nil
 }
 
+// CopyWalletDB writes a copy of the loaded wallet's database to w.
+func (l *btcLoader) CopyWalletDB(w io.Writer) error {
+	l.mu.RLock()
+	loadedWallet := l.wallet
+	l.mu.RUnlock()
+
+	if loadedWallet == nil {
+		return errors.New("wallet is unopened")
+	}
+	return loadedWallet.Database().Copy(w)
+}
+
 // UnloadWallet stops the loaded wallet, returns errors if the wallet has not
 // been loaded with CreateNewWallet or LoadExistingWallet.
 func (l *btcLoader) UnloadWallet() error {
@@ -2,6 +2,7 @@ package loader
 
 import (
 	"context"
+	"io"
 	"os"
 	"path/filepath"
 
@@ -58,6 +59,9 @@ type AssetLoader interface {
 	CreateWatchingOnlyWallet(ctx context.Context, params *WatchOnlyWalletParams) (*LoadedWallets, error)
 
 	GetLoadedWallet() (*LoadedWallets, bool)
+	// CopyWalletDB writes a consistent copy of the loaded wallet's database
+	// to w.
+	CopyWalletDB(w io.Writer) error
 	UnloadWallet() error
 	WalletExists(WalletID string) (bool, error)
 }
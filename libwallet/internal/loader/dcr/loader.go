@@ -7,6 +7,7 @@ package dcr
 
 import (
 	"context"
+	"io"
 	"path/filepath"
 	"sync"
 
@@ -343,6 +344,28 @@ func (l *dcrLoader) GetLoadedWallet() (*loader.LoadedWallets, bool) {
 	return &loader.LoadedWallets{DCR: w}, w != nil
 }
 
+// CopyWalletDB writes a copy of the loaded wallet's database to w. The copy
+// format depends on the database driver.
+func (l *dcrLoader) CopyWalletDB(w io.Writer) error {
+	const op errors.Op = "loader.CopyWalletDB"
+
+	l.mu.RLock()
+	db := l.db
+	l.mu.RUnlock()
+
+	if db == nil {
+		return errors.E(op, errors.Invalid, "wallet is unopened")
+	}
+
+	// wallet.DB hides the walletdb.DB it wraps, but still exposes its
+	// methods.
+	copier, ok := db.(interface{ Copy(io.Writer) error })
+	if !ok {
+		return errors.E(op, errors.Invalid, "wallet database does not support copies")
+	}
+	return copier.Copy(w)
+}
+
 // UnloadWallet stops the loaded wallet, if any, and closes the wallet database.
 // Returns with errors.Invalid if the wallet has not been loaded with
 // CreateNewWallet or LoadExistingWallet.  The Loader may be reused if this
@@ -22,7 +22,7 @@ const (
 
 type Politeia struct {
 	host string
-	db   *storm.DB
+	db   storm.Node
 
 	// TODO: Check usages of mu, seems not to always be unlocked.
 	mu         *sync.RWMutex // Pointer required to avoid copying literal values.
@@ -43,7 +43,11 @@ const (
 	ProposalCategoryAbandoned
 )
 
-func New(host string, db *storm.DB) (*Politeia, error) {
+// MetadataBuckets are the database buckets holding the proposals and the sync
+// progress.
+var MetadataBuckets = []string{"Proposal", configDBBkt}
+
+func New(host string, db storm.Node) (*Politeia, error) {
 	if err := db.Init(&Proposal{}); err != nil {
 		log.Errorf("Error initializing politeia database: %s", err.Error())
 		return nil, err
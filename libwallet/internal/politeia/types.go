@@ -10,10 +10,12 @@ const (
 	ProposalTypeRFPSubmission
 )
 
+// Proposal is a politeia proposal. Only the ID is written to the database
+// unencrypted, so no other field is indexed.
 type Proposal struct {
 	ID               int    `storm:"id,increment"`
-	Token            string `json:"token" storm:"unique"`
-	Category         int32  `json:"category" storm:"index"`
+	Token            string `json:"token"`
+	Category         int32  `json:"category"`
 	Name             string `json:"name"`
 	State            int32  `json:"state"`
 	Status           int32  `json:"status"`
@@ -0,0 +1,121 @@
+// Package shamir implements Shamir's secret sharing over GF(256). Each byte of
+// the secret is shared using a random polynomial whose constant term is the
+// byte.
+//
+// This is plain Shamir's secret sharing, not SLIP-39: the shares carry no
+// metadata of their own and the seed shares encoded from them by the wallet
+// package use a custom format, so they can't be combined by SLIP-39 wallets
+// and SLIP-39 shares can't be combined here.
+package shamir
+
+import (
+	"crypto/rand"
+	"errors"
+)
+
+// MaxShares is the maximum number of shares a secret can be split into.
+const MaxShares = 255
+
+var (
+	expTable [255]byte
+	logTable [256]byte
+)
+
+func init() {
+	// 3 generates the multiplicative group of GF(256) with the AES
+	// polynomial x^8 + x^4 + x^3 + x + 1.
+	x := byte(1)
+	for i := 0; i < 255; i++ {
+		expTable[i] = x
+		logTable[x] = byte(i)
+		// x *= 3
+		hi := x & 0x80
+		x2 := x << 1
+		if hi != 0 {
+			x2 ^= 0x1b
+		}
+		x ^= x2
+	}
+}
+
+func mul(a, b byte) byte {
+	if a == 0 || b == 0 {
+		return 0
+	}
+	return expTable[(int(logTable[a])+int(logTable[b]))%255]
+}
+
+func div(a, b byte) byte {
+	if a == 0 {
+		return 0
+	}
+	return expTable[(int(logTable[a])-int(logTable[b])+255)%255]
+}
+
+// Split splits secret into n shares, any threshold of which reconstruct the
+// secret. The share at index i has the x coordinate i+1.
+func Split(secret []byte, threshold, n int) ([][]byte, error) {
+	if threshold < 1 || threshold > n || n > MaxShares {
+		return nil, errors.New("invalid threshold or number of shares")
+	}
+
+	// coefficients[i] holds the coefficients of the polynomial of the byte
+	// at i, the constant term being the byte.
+	coefficients := make([][]byte, len(secret))
+	for i, b := range secret {
+		coefficients[i] = make([]byte, threshold)
+		coefficients[i][0] = b
+		if _, err := rand.Read(coefficients[i][1:]); err != nil {
+			return nil, err
+		}
+	}
+
+	shares := make([][]byte, n)
+	for s := range shares {
+		x := byte(s + 1)
+		shares[s] = make([]byte, len(secret))
+		for i, coeffs := range coefficients {
+			// Horner's method.
+			var y byte
+			for j := len(coeffs) - 1; j >= 0; j-- {
+				y = mul(y, x) ^ coeffs[j]
+			}
+			shares[s][i] = y
+		}
+	}
+	return shares, nil
+}
+
+// Combine reconstructs a secret from the shares whose x coordinates are xs.
+// At least the threshold number of shares the secret was split into must be
+// provided, otherwise the result is garbage.
+func Combine(xs []byte, shares [][]byte) ([]byte, error) {
+	if len(xs) == 0 || len(xs) != len(shares) {
+		return nil, errors.New("no shares")
+	}
+	for i, x := range xs {
+		if x == 0 || len(shares[i]) != len(shares[0]) {
+			return nil, errors.New("invalid share")
+		}
+		for _, other := range xs[:i] {
+			if x == other {
+				return nil, errors.New("duplicate share")
+			}
+		}
+	}
+
+	secret := make([]byte, len(shares[0]))
+	for i, xi := range xs {
+		// Lagrange basis polynomial of xi evaluated at 0.
+		basis := byte(1)
+		for j, xj := range xs {
+			if i != j {
+				basis = mul(basis, div(xj, xj^xi))
+			}
+		}
+		for k := range secret {
+			secret[k] ^= mul(shares[i][k], basis)
+		}
+	}
+	return secret, nil
+}
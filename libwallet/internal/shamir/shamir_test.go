@@ -0,0 +1,129 @@
+package shamir
+
+import (
+	"bytes"
+	"testing"
+)
+
+// xsOf returns the x coordinates of the shares at indexes.
+func xsOf(indexes []int) []byte {
+	xs := make([]byte, len(indexes))
+	for i, index := range indexes {
+		xs[i] = byte(index + 1)
+	}
+	return xs
+}
+
+func sharesOf(shares [][]byte, indexes []int) [][]byte {
+	subset := make([][]byte, len(indexes))
+	for i, index := range indexes {
+		subset[i] = shares[index]
+	}
+	return subset
+}
+
+func TestSplitCombine(t *testing.T) {
+	secret := []byte("a 32 byte secret to split apart!")
+
+	tests := []struct {
+		threshold, n int
+	}{
+		{1, 1},
+		{1, 3},
+		{2, 3},
+		{3, 5},
+		{5, 5},
+		{16, 16},
+	}
+	for _, test := range tests {
+		shares, err := Split(secret, test.threshold, test.n)
+		if err != nil {
+			t.Fatalf("%d of %d: %v", test.threshold, test.n, err)
+		}
+		if len(shares) != test.n {
+			t.Fatalf("%d of %d: got %d shares", test.threshold, test.n, len(shares))
+		}
+
+		// Every window of threshold consecutive shares, wrapping around,
+		// restores the secret.
+		for start := 0; start < test.n; start++ {
+			indexes := make([]int, test.threshold)
+			for i := range indexes {
+				indexes[i] = (start + i) % test.n
+			}
+			got, err := Combine(xsOf(indexes), sharesOf(shares, indexes))
+			if err != nil {
+				t.Fatalf("%d of %d, shares %v: %v", test.threshold, test.n, indexes, err)
+			}
+			if !bytes.Equal(got, secret) {
+				t.Fatalf("%d of %d, shares %v: got %x", test.threshold, test.n, indexes, got)
+			}
+		}
+
+		// More shares than the threshold restore the secret too.
+		all := make([]int, test.n)
+		for i := range all {
+			all[i] = i
+		}
+		got, err := Combine(xsOf(all), shares)
+		if err != nil || !bytes.Equal(got, secret) {
+			t.Fatalf("%d of %d, all shares: got %x, %v", test.threshold, test.n, got, err)
+		}
+	}
+}
+
+func TestCombineBelowThreshold(t *testing.T) {
+	secret := []byte("a 32 byte secret to split apart!")
+	shares, err := Split(secret, 3, 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	indexes := []int{0, 4}
+	got, err := Combine(xsOf(indexes), sharesOf(shares, indexes))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bytes.Equal(got, secret) {
+		t.Fatal("fewer shares than the threshold restored the secret")
+	}
+}
+
+func TestSplitInvalid(t *testing.T) {
+	tests := []struct {
+		threshold, n int
+	}{
+		{0, 3},
+		{4, 3},
+		{2, MaxShares + 1},
+	}
+	for _, test := range tests {
+		if _, err := Split([]byte{1}, test.threshold, test.n); err == nil {
+			t.Fatalf("%d of %d: expected an error", test.threshold, test.n)
+		}
+	}
+}
+
+func TestCombineInvalid(t *testing.T) {
+	shares, err := Split([]byte{1, 2}, 2, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name   string
+		xs     []byte
+		shares [][]byte
+	}{
+		{"no shares", nil, nil},
+		{"missing x", []byte{1}, shares[:2]},
+		{"zero x", []byte{0, 2}, shares[:2]},
+		{"duplicate x", []byte{1, 1}, shares[:2]},
+		{"length mismatch", []byte{1, 2}, [][]byte{shares[0], shares[1][:1]}},
+	}
+	for _, test := range tests {
+		if _, err := Combine(test.xs, test.shares); err == nil {
+			t.Fatalf("%s: expected an error", test.name)
+		}
+	}
+}
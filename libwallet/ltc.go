@@ -35,6 +35,7 @@ func (mgr *AssetsManager) CreateNewLTCWallet(walletName, privatePassphrase strin
 	}
 
 	mgr.Assets.LTC.Wallets[wallet.GetWalletID()] = wallet
+	mgr.trackSyncProgress(wallet)
 
 	return wallet, nil
 }
@@ -47,6 +48,7 @@ func (mgr *AssetsManager) CreateNewLTCWatchOnlyWallet(walletName, extendedPublic
 	}
 
 	mgr.Assets.LTC.Wallets[wallet.GetWalletID()] = wallet
+	mgr.trackSyncProgress(wallet)
 
 	return wallet, nil
 }
@@ -65,6 +67,7 @@ func (mgr *AssetsManager) RestoreLTCWallet(walletName, seedMnemonic, privatePass
 	}
 
 	mgr.Assets.LTC.Wallets[wallet.GetWalletID()] = wallet
+	mgr.trackSyncProgress(wallet)
 
 	return wallet, nil
 }
@@ -0,0 +1,255 @@
+package libwallet
+
+import (
+	"fmt"
+
+	"decred.org/dcrwallet/v4/errors"
+	"github.com/asdine/storm"
+	sharedW "github.com/crypto-power/cryptopower/libwallet/assets/wallet"
+	"github.com/crypto-power/cryptopower/libwallet/instantswap"
+	"github.com/crypto-power/cryptopower/libwallet/internal/politeia"
+	"github.com/crypto-power/cryptopower/libwallet/utils"
+	"github.com/kevinburke/nacl"
+)
+
+// metadataKeysField is the app config field holding the metadata keys.
+const metadataKeysField = "metadata-keys"
+
+// metadataKeysVersion is the version of the metadata encoding. Version 1
+// encrypts the values with a subkey of the key rather than the key itself.
+const metadataKeysVersion = 1
+
+// metadataKeys holds the keys the metadata databases are encrypted with. The
+// keys are encrypted with the startup passphrase, so that changing the
+// passphrase and replacing the keys is a single database write.
+type metadataKeys struct {
+	Key []byte
+	// OldKey is the replaced key, kept until the values it encrypted are
+	// re-encrypted with Key.
+	OldKey []byte
+	// Pending is set until every value is encoded with Key.
+	Pending bool
+	// Version is the version of the encoding of the values.
+	Version uint32
+}
+
+// open decrypts the keys with the startup passphrase.
+func (keys *metadataKeys) open(passphrase string) (key, oldKey nacl.Key, err error) {
+	key, err = openMetadataKey(keys.Key, passphrase)
+	if err != nil || keys.OldKey == nil {
+		return key, nil, err
+	}
+	oldKey, err = openMetadataKey(keys.OldKey, passphrase)
+	return key, oldKey, err
+}
+
+func sealMetadataKey(key nacl.Key, passphrase string) ([]byte, error) {
+	return utils.EncryptWithPassphrase([]byte(passphrase), key[:])
+}
+
+func openMetadataKey(sealed []byte, passphrase string) (nacl.Key, error) {
+	data, err := utils.DecryptWithPassphrase([]byte(passphrase), sealed)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) != nacl.KeySize {
+		return nil, errors.E(errors.Invalid, "invalid metadata key")
+	}
+	key := new([nacl.KeySize]byte)
+	copy(key[:], data)
+	return key, nil
+}
+
+func (mgr *AssetsManager) readMetadataKeys() (*metadataKeys, error) {
+	keys := new(metadataKeys)
+	err := mgr.params.DB.Get(appConfigBucketName, metadataKeysField, keys)
+	if err == storm.ErrNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return keys, nil
+}
+
+// IsMetadataEncryptionEnabled returns true if the wallets' transactions
+// index, the wallet settings revealing their history or credentials, the
+// instant swap orders and the proposals are encrypted with a key protected by
+// the startup passphrase.
+func (mgr *AssetsManager) IsMetadataEncryptionEnabled() bool {
+	keys, err := mgr.readMetadataKeys()
+	if err != nil {
+		log.Errorf("Error reading the metadata keys: %v", err)
+	}
+	return keys != nil
+}
+
+// EnableMetadataEncryption encrypts the metadata databases with a new key
+// protected by the startup passphrase. The values saved before are encrypted
+// immediately.
+func (mgr *AssetsManager) EnableMetadataEncryption(startupPassphrase string) error {
+	if !mgr.IsStartupSecuritySet() {
+		return errors.E(errors.Invalid, "a startup passphrase is required to encrypt the metadata")
+	}
+	if err := mgr.VerifyStartupPassphrase(startupPassphrase); err != nil {
+		return err
+	}
+	if mgr.IsMetadataEncryptionEnabled() {
+		return nil
+	}
+
+	key := nacl.NewKey()
+	sealedKey, err := sealMetadataKey(key, startupPassphrase)
+	if err != nil {
+		return err
+	}
+	keys := &metadataKeys{Key: sealedKey, Pending: true}
+	if err := mgr.params.DB.Set(appConfigBucketName, metadataKeysField, keys); err != nil {
+		return err
+	}
+
+	mgr.params.DBCodec.SetKeys(key, nil)
+	mgr.params.DBCodec.Enable(true)
+	return mgr.finishMetadataRecode(keys, key)
+}
+
+// DisableMetadataEncryption decrypts the metadata databases and deletes their
+// key.
+func (mgr *AssetsManager) DisableMetadataEncryption(startupPassphrase string) error {
+	if err := mgr.VerifyStartupPassphrase(startupPassphrase); err != nil {
+		return err
+	}
+	keys, err := mgr.readMetadataKeys()
+	if err != nil || keys == nil {
+		return err
+	}
+	key, oldKey, err := keys.open(startupPassphrase)
+	if err != nil {
+		return err
+	}
+
+	mgr.params.DBCodec.SetKeys(key, oldKey)
+	mgr.params.DBCodec.Enable(false)
+	if err := mgr.recodeMetadata(); err != nil {
+		// Values that were decrypted are still readable.
+		mgr.params.DBCodec.Enable(true)
+		return err
+	}
+
+	mgr.appConfigDelete(metadataKeysField)
+	mgr.params.DBCodec.SetKeys(nil, nil)
+	return nil
+}
+
+// unlockMetadata loads the metadata keys with the startup passphrase and
+// completes a re-encryption interrupted by the app's shutdown.
+func (mgr *AssetsManager) unlockMetadata(startupPassphrase string) error {
+	keys, err := mgr.readMetadataKeys()
+	if err != nil || keys == nil {
+		return err
+	}
+	key, oldKey, err := keys.open(startupPassphrase)
+	if err != nil {
+		return err
+	}
+
+	mgr.params.DBCodec.SetKeys(key, oldKey)
+	if keys.Pending || keys.Version < metadataKeysVersion {
+		log.Info("Resuming the re-encryption of the metadata databases")
+		if err := mgr.finishMetadataRecode(keys, key); err != nil {
+			return err
+		}
+	}
+
+	for _, wallet := range mgr.AllWallets() {
+		if err := wallet.UpgradeMetadata(); err != nil {
+			return fmt.Errorf("wallet %d: %w", wallet.GetWalletID(), err)
+		}
+	}
+	return nil
+}
+
+// rotateMetadataKey replaces the metadata key with a new key protected by
+// newPassphrase and re-encrypts the metadata with it. The new startup
+// passphrase hash is saved with the new keys.
+func (mgr *AssetsManager) rotateMetadataKey(keys *metadataKeys, oldPassphrase, newPassphrase string, newPassphraseHash []byte) error {
+	key, oldKey, err := keys.open(oldPassphrase)
+	if err != nil {
+		return err
+	}
+	if keys.Pending {
+		// Complete the previous re-encryption so that no value is encrypted
+		// with a key older than the replaced key.
+		mgr.params.DBCodec.SetKeys(key, oldKey)
+		if err := mgr.finishMetadataRecode(keys, key); err != nil {
+			return err
+		}
+	}
+
+	newKey := nacl.NewKey()
+	sealedKey, err := sealMetadataKey(newKey, newPassphrase)
+	if err != nil {
+		return err
+	}
+	sealedOldKey, err := sealMetadataKey(key, newPassphrase)
+	if err != nil {
+		return err
+	}
+	newKeys := &metadataKeys{Key: sealedKey, OldKey: sealedOldKey, Pending: true}
+
+	tx, err := mgr.params.DB.Begin(true)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+	if err := tx.Set(appConfigBucketName, walletStartupPassphraseField, newPassphraseHash); err != nil {
+		return err
+	}
+	if err := tx.Set(appConfigBucketName, metadataKeysField, newKeys); err != nil {
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	mgr.params.DBCodec.SetKeys(newKey, key)
+	return mgr.finishMetadataRecode(newKeys, newKey)
+}
+
+// finishMetadataRecode re-encodes the metadata with key and marks keys as no
+// longer pending.
+func (mgr *AssetsManager) finishMetadataRecode(keys *metadataKeys, key nacl.Key) error {
+	if err := mgr.recodeMetadata(); err != nil {
+		return err
+	}
+
+	keys.OldKey = nil
+	keys.Pending = false
+	keys.Version = metadataKeysVersion
+	if err := mgr.params.DB.Set(appConfigBucketName, metadataKeysField, keys); err != nil {
+		return err
+	}
+	mgr.params.DBCodec.SetKeys(key, nil)
+	return nil
+}
+
+// recodeMetadata re-encodes the values of the metadata databases and the
+// encrypted wallet config values with the current state of the codec.
+func (mgr *AssetsManager) recodeMetadata() error {
+	buckets := append(append([]string{}, politeia.MetadataBuckets...), instantswap.MetadataBuckets...)
+	if err := mgr.params.DBCodec.Recode(mgr.params.DB.Bolt, buckets...); err != nil {
+		return err
+	}
+	if err := sharedW.RecodeConfig(mgr.params.DB, mgr.params.DBCodec); err != nil {
+		return fmt.Errorf("wallets config: %w", err)
+	}
+
+	for _, wallet := range mgr.AllWallets() {
+		if err := wallet.RecodeMetadata(); err != nil {
+			return fmt.Errorf("wallet %d: %w", wallet.GetWalletID(), err)
+		}
+	}
+	return nil
+}
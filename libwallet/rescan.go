@@ -0,0 +1,15 @@
+package libwallet
+
+// resumeRescan resumes the rescan of a wallet that was interrupted, once the
+// wallet is synced again.
+func (mgr *AssetsManager) resumeRescan(walletID int) {
+	wallet := mgr.WalletWithID(walletID)
+	if wallet == nil || wallet.PendingRescan() == nil {
+		return
+	}
+
+	log.Infof("Resuming the rescan of wallet %s", wallet.GetWalletName())
+	if err := wallet.ResumeRescan(); err != nil {
+		log.Errorf("Error resuming the rescan of wallet %s: %v", wallet.GetWalletName(), err)
+	}
+}
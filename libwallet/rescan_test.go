@@ -0,0 +1,57 @@
+package libwallet
+
+import (
+	"testing"
+
+	"github.com/crypto-power/cryptopower/libwallet/assets/btc"
+	sharedW "github.com/crypto-power/cryptopower/libwallet/assets/wallet"
+	"github.com/crypto-power/cryptopower/libwallet/utils"
+)
+
+func TestPendingRescanSurvivesShutdown(t *testing.T) {
+	rootDir, logDir := t.TempDir(), t.TempDir()
+	mgr, err := NewAssetsManager(rootDir, logDir, utils.Testnet, "", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	wallet, err := mgr.CreateNewBTCWallet("rescan", "passphrase", sharedW.PassphraseTypePass, sharedW.WordSeed12)
+	if err != nil {
+		t.Fatal(err)
+	}
+	walletID := wallet.GetWalletID()
+	state := &sharedW.RescanState{StartHeight: 100, ScannedThrough: 250}
+	wallet.(*btc.Asset).SavePendingRescan(state)
+	mgr.Shutdown()
+
+	mgr, err = NewAssetsManager(rootDir, logDir, utils.Testnet, "", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer mgr.Shutdown()
+	if err := mgr.OpenWallets(""); err != nil {
+		t.Fatal(err)
+	}
+
+	wallet = mgr.WalletWithID(walletID)
+	pending := wallet.PendingRescan()
+	if pending == nil || pending.StartHeight != state.StartHeight || pending.ScannedThrough != state.ScannedThrough {
+		t.Fatalf("got pending rescan %+v after a restart, want %+v", pending, state)
+	}
+	// The rescan is resumed once the wallet is synced, it fails to resume
+	// as the wallet isn't.
+	if err := wallet.ResumeRescan(); err == nil {
+		t.Fatal("the pending rescan wasn't resumed")
+	}
+	// A rescan that fails to resume is kept for the next sync.
+	if pending := wallet.PendingRescan(); pending == nil || pending.ScannedThrough != state.ScannedThrough {
+		t.Fatalf("got pending rescan %+v after a failed resume, want %+v", pending, state)
+	}
+
+	wallet.DiscardRescan()
+	if pending := wallet.PendingRescan(); pending != nil {
+		t.Fatalf("discarded rescan still pending: %+v", pending)
+	}
+	if err := wallet.ResumeRescan(); err != nil {
+		t.Fatalf("resumed a discarded rescan: %v", err)
+	}
+}
@@ -0,0 +1,151 @@
+package libwallet
+
+import (
+	"time"
+
+	sharedW "github.com/crypto-power/cryptopower/libwallet/assets/wallet"
+	"github.com/crypto-power/cryptopower/libwallet/utils"
+)
+
+const (
+	// SeedCheckInterval is how often the user is asked to prove they still
+	// have the seed of a backed up wallet.
+	SeedCheckInterval = 90 * 24 * time.Hour
+	// HighBalanceSeedCheckInterval replaces SeedCheckInterval for wallets
+	// holding at least HighBalanceSeedCheckUSD.
+	HighBalanceSeedCheckInterval = 30 * 24 * time.Hour
+	// HighBalanceSeedCheckUSD is the wallet balance, in USD, from which seed
+	// checks are escalated.
+	HighBalanceSeedCheckUSD = 1000
+
+	seedCheckSnooze            = 7 * 24 * time.Hour
+	highBalanceSeedCheckSnooze = 24 * time.Hour
+)
+
+// highBalanceSeedCheckCoins are the wallet balances, in coins, from which
+// seed checks are escalated when the USD value of the balance is unknown,
+// e.g. if the exchange rates are disabled by the user's privacy settings.
+var highBalanceSeedCheckCoins = map[utils.AssetType]float64{
+	utils.DCRWalletAsset: 100,
+	utils.BTCWalletAsset: 0.02,
+	utils.LTCWalletAsset: 15,
+}
+
+// SeedCheckReminder reminds the user that a wallet's seed knowledge check is
+// due.
+type SeedCheckReminder struct {
+	Wallet sharedW.Asset
+	// LastCheck is the last successful seed check.
+	LastCheck time.Time
+	// HighBalance is set if the wallet holds a significant balance, in which
+	// case the checks are more frequent and the reminder can only be snoozed
+	// for a day.
+	HighBalance bool
+}
+
+// Snooze hides the reminder for a week, or a day for wallets with a high
+// balance.
+func (r *SeedCheckReminder) Snooze() {
+	snooze := seedCheckSnooze
+	if r.HighBalance {
+		snooze = highBalanceSeedCheckSnooze
+	}
+	r.Wallet.SetLongConfigValueForKey(sharedW.SeedCheckSnoozedUntilConfigKey, time.Now().Add(snooze).Unix())
+}
+
+// needsSeedCheck returns true if the seed knowledge of the wallet is checked.
+// Wallets that aren't backed up are left to the backup reminder.
+func needsSeedCheck(wallet sharedW.Asset) bool {
+	return !wallet.IsWatchingOnlyWallet() && wallet.HasWalletSeed() && wallet.IsWalletBackedUp()
+}
+
+// scheduleSeedChecks schedules the seed checks of the wallets backed up
+// before the checks were recorded from now.
+func (mgr *AssetsManager) scheduleSeedChecks() {
+	for _, wallet := range mgr.AllWallets() {
+		if needsSeedCheck(wallet) && wallet.LastSeedCheck().IsZero() {
+			wallet.SetLongConfigValueForKey(sharedW.LastSeedCheckConfigKey, time.Now().Unix())
+		}
+	}
+}
+
+// SeedCheckReminder returns the seed check reminder of the wallet, or nil if
+// no seed check is due or the reminder is snoozed.
+func (mgr *AssetsManager) SeedCheckReminder(wallet sharedW.Asset) *SeedCheckReminder {
+	if !needsSeedCheck(wallet) {
+		return nil
+	}
+
+	now := time.Now()
+	if snoozedUntil := wallet.ReadLongConfigValueForKey(sharedW.SeedCheckSnoozedUntilConfigKey, 0); now.Unix() < snoozedUntil {
+		return nil
+	}
+
+	// Checks are only due once scheduled by scheduleSeedChecks.
+	lastCheck := wallet.LastSeedCheck()
+	if lastCheck.IsZero() {
+		return nil
+	}
+
+	highBalance := mgr.isHighBalanceWallet(wallet)
+	if !seedCheckDue(lastCheck, highBalance, now) {
+		return nil
+	}
+	return &SeedCheckReminder{
+		Wallet:      wallet,
+		LastCheck:   lastCheck,
+		HighBalance: highBalance,
+	}
+}
+
+// SeedCheckReminders returns the seed check reminders of all the wallets, the
+// reminders of wallets with a high balance first.
+func (mgr *AssetsManager) SeedCheckReminders() []*SeedCheckReminder {
+	var reminders, highBalance []*SeedCheckReminder
+	for _, wallet := range mgr.AllWallets() {
+		reminder := mgr.SeedCheckReminder(wallet)
+		switch {
+		case reminder == nil:
+		case reminder.HighBalance:
+			highBalance = append(highBalance, reminder)
+		default:
+			reminders = append(reminders, reminder)
+		}
+	}
+	return append(highBalance, reminders...)
+}
+
+// seedCheckDue returns true if a seed check is due at now.
+func seedCheckDue(lastCheck time.Time, highBalance bool, now time.Time) bool {
+	interval := SeedCheckInterval
+	if highBalance {
+		interval = HighBalanceSeedCheckInterval
+	}
+	return now.Sub(lastCheck) >= interval
+}
+
+// isHighBalanceWallet returns true if the USD value of the wallet's balance is
+// at least HighBalanceSeedCheckUSD. The balance is compared to
+// highBalanceSeedCheckCoins if the exchange rate is unavailable.
+func (mgr *AssetsManager) isHighBalanceWallet(wallet sharedW.Asset) bool {
+	balance, err := wallet.GetWalletBalance()
+	if err != nil {
+		log.Errorf("Error reading the balance of wallet %d: %v", wallet.GetWalletID(), err)
+		return false
+	}
+
+	usdBalances, err := mgr.CalculateAssetsUSDBalance(map[utils.AssetType]sharedW.AssetAmount{
+		wallet.GetAssetType(): balance.Total,
+	})
+	if err != nil {
+		return isHighBalance(wallet.GetAssetType(), balance.Total.ToCoin())
+	}
+	return usdBalances[wallet.GetAssetType()] >= HighBalanceSeedCheckUSD
+}
+
+// isHighBalance returns true if coins of assetType are at least the native
+// high balance threshold of the asset.
+func isHighBalance(assetType utils.AssetType, coins float64) bool {
+	threshold, ok := highBalanceSeedCheckCoins[assetType]
+	return ok && coins >= threshold
+}
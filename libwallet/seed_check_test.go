@@ -0,0 +1,51 @@
+package libwallet
+
+import (
+	"testing"
+	"time"
+
+	"github.com/crypto-power/cryptopower/libwallet/utils"
+)
+
+func TestSeedCheckDue(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name        string
+		lastCheck   time.Time
+		highBalance bool
+		want        bool
+	}{
+		{"recent", now.Add(-24 * time.Hour), false, false},
+		{"interval elapsed", now.Add(-SeedCheckInterval), false, true},
+		{"high balance recent", now.Add(-24 * time.Hour), true, false},
+		{"high balance escalated", now.Add(-HighBalanceSeedCheckInterval), true, true},
+		{"escalated only with high balance", now.Add(-HighBalanceSeedCheckInterval), false, false},
+	}
+
+	for _, test := range tests {
+		if got := seedCheckDue(test.lastCheck, test.highBalance, now); got != test.want {
+			t.Errorf("%s: got %v, want %v", test.name, got, test.want)
+		}
+	}
+}
+
+func TestIsHighBalance(t *testing.T) {
+	tests := []struct {
+		name      string
+		assetType utils.AssetType
+		coins     float64
+		want      bool
+	}{
+		{"dcr below", utils.DCRWalletAsset, 99, false},
+		{"dcr at threshold", utils.DCRWalletAsset, 100, true},
+		{"btc above", utils.BTCWalletAsset, 1, true},
+		{"ltc below", utils.LTCWalletAsset, 1, false},
+		{"unknown asset", utils.AssetType("xyz"), 1e9, false},
+	}
+
+	for _, test := range tests {
+		if got := isHighBalance(test.assetType, test.coins); got != test.want {
+			t.Errorf("%s: got %v, want %v", test.name, got, test.want)
+		}
+	}
+}
@@ -0,0 +1,78 @@
+package libwallet
+
+import (
+	sharedW "github.com/crypto-power/cryptopower/libwallet/assets/wallet"
+)
+
+// startSyncPolicy applies the saved sync policy and counts the data the
+// wallets synced this month towards the data budget. Wallets are paused and
+// resumed as the policy and the conditions of the device change.
+func (mgr *AssetsManager) startSyncPolicy() {
+	gate := mgr.params.SyncGate
+	gate.SetPolicy(mgr.SyncPolicy())
+	for _, wallet := range mgr.AllWallets() {
+		wallet.SyncDataUsage() // registers the usage of the wallet
+	}
+	gate.SetOnChange(mgr.applySyncPolicy)
+}
+
+// SyncPolicy returns the policy restricting when the wallets sync.
+func (mgr *AssetsManager) SyncPolicy() sharedW.SyncPolicy {
+	var policy sharedW.SyncPolicy
+	mgr.ReadAppConfigValue(sharedW.SyncPolicyConfigKey, &policy)
+	return policy
+}
+
+// SetSyncPolicy sets the policy restricting when the wallets sync. Syncing
+// wallets are paused if the policy no longer lets them sync.
+func (mgr *AssetsManager) SetSyncPolicy(policy sharedW.SyncPolicy) {
+	mgr.SaveAppConfigValue(sharedW.SyncPolicyConfigKey, policy)
+	mgr.params.SyncGate.SetPolicy(policy)
+}
+
+// SetSyncConditions reports the network and power conditions of the device,
+// which the sync policy is applied to.
+func (mgr *AssetsManager) SetSyncConditions(conditions sharedW.SyncConditions) {
+	mgr.params.SyncGate.SetConditions(conditions)
+}
+
+// SyncDataUsage returns the data all the wallets synced this month.
+func (mgr *AssetsManager) SyncDataUsage() uint64 {
+	return mgr.params.SyncGate.DataUsage()
+}
+
+// SyncPausedReason returns the reason the sync policy doesn't let the
+// wallets sync now, or nil if they may sync.
+func (mgr *AssetsManager) SyncPausedReason() error {
+	return mgr.params.SyncGate.Check()
+}
+
+// applySyncPolicy pauses the wallets connected to the network if the sync
+// policy no longer lets them sync, or resumes the wallets waiting to sync if
+// it does.
+func (mgr *AssetsManager) applySyncPolicy() {
+	gate := mgr.params.SyncGate
+	if err := gate.Check(); err != nil {
+		for _, wallet := range mgr.AllWallets() {
+			if !wallet.IsConnectedToNetwork() {
+				continue
+			}
+			log.Infof("Pausing the sync of wallet %s: %v", wallet.GetWalletName(), err)
+			gate.Wait(wallet.GetWalletID())
+			wallet.CancelSync()
+			wallet.FlushSyncDataUsage()
+		}
+		return
+	}
+
+	for _, walletID := range gate.TakeWaiting() {
+		wallet := mgr.WalletWithID(walletID)
+		if wallet == nil || !wallet.WalletOpened() || wallet.IsConnectedToNetwork() {
+			continue
+		}
+		log.Infof("Resuming the sync of wallet %s", wallet.GetWalletName())
+		if err := wallet.SpvSync(); err != nil {
+			log.Errorf("Error resuming the sync of wallet %s: %v", wallet.GetWalletName(), err)
+		}
+	}
+}
@@ -0,0 +1,397 @@
+package libwallet
+
+import (
+	"sync"
+	"time"
+
+	sharedW "github.com/crypto-power/cryptopower/libwallet/assets/wallet"
+	"github.com/crypto-power/cryptopower/libwallet/utils"
+)
+
+const (
+	// syncProgressListenerID identifies the sync progress listener the
+	// assets manager registers on every wallet.
+	syncProgressListenerID = "assets_manager_sync_progress"
+
+	// maxSyncHistory is the number of past syncs kept for diagnostics.
+	maxSyncHistory = 50
+)
+
+// SyncOutcome is how a sync ended.
+type SyncOutcome string
+
+const (
+	SyncCompleted SyncOutcome = "completed"
+	SyncCanceled  SyncOutcome = "canceled"
+	SyncFailed    SyncOutcome = "failed"
+)
+
+// WalletSyncProgress is the sync progress of a wallet, computed the same way
+// for all the assets.
+type WalletSyncProgress struct {
+	WalletID   int
+	WalletName string
+	AssetType  utils.AssetType
+
+	Syncing bool
+	Synced  bool
+	Stage   utils.SyncStage
+	// Percent is the progress of the whole sync, from 0 to 100, with the
+	// sync stages weighted the same way for all the wallets of an asset. It
+	// never goes back during a sync.
+	Percent int32
+	// TimeRemaining is estimated from the time the sync took so far, or 0
+	// if it can't be estimated yet.
+	TimeRemaining   time.Duration
+	ConnectedPeers  int32
+	BlocksRemaining int32
+	StartedAt       time.Time
+}
+
+// SyncProgress is the sync progress of all the wallets.
+type SyncProgress struct {
+	Wallets []*WalletSyncProgress
+
+	// Syncing and Synced are the number of wallets syncing and synced.
+	Syncing int
+	Synced  int
+	// Percent is the average progress of the syncing wallets, or 100 if no
+	// wallet is syncing.
+	Percent int32
+	// TimeRemaining is the time the slowest syncing wallet has left.
+	TimeRemaining   time.Duration
+	ConnectedPeers  int32
+	BlocksRemaining int32
+}
+
+// SyncRecord is a past sync of a wallet, kept for diagnostics.
+type SyncRecord struct {
+	WalletID  int             `json:"walletID"`
+	AssetType utils.AssetType `json:"assetType"`
+	StartedAt time.Time       `json:"startedAt"`
+	Duration  time.Duration   `json:"duration"`
+	Outcome   SyncOutcome     `json:"outcome"`
+	Error     string          `json:"error,omitempty"`
+}
+
+// syncStageWeight is the share of a whole sync a sync stage takes.
+type syncStageWeight struct {
+	stage  utils.SyncStage
+	weight int32
+}
+
+// dcrSyncStages are the stages of a DCR sync, in order. Fetching the cfilters
+// and the headers takes most of the sync, the address discovery and the rescan
+// only go through the blocks with wallet transactions.
+var dcrSyncStages = []syncStageWeight{
+	{utils.CFiltersFetchSyncStage, 35},
+	{utils.HeadersFetchSyncStage, 35},
+	{utils.AddressDiscoverySyncStage, 10},
+	{utils.HeadersRescanSyncStage, 20},
+}
+
+// spvSyncStages are the stages of a BTC or LTC sync. Neutrino fetches the
+// headers and the cfilter headers together and the wallet scans the cfilters
+// as they come, so the headers fetch is the whole sync.
+var spvSyncStages = []syncStageWeight{
+	{utils.HeadersFetchSyncStage, 100},
+}
+
+// syncStages returns the stages a sync of assetType goes through.
+func syncStages(assetType utils.AssetType) []syncStageWeight {
+	if assetType == utils.DCRWalletAsset {
+		return dcrSyncStages
+	}
+	return spvSyncStages
+}
+
+// syncPercent returns the progress of a whole sync at stagePercent of stage.
+// The stages before stage are done and the ones after it are yet to start.
+// It returns -1 for a stage the asset doesn't go through.
+func syncPercent(assetType utils.AssetType, stage utils.SyncStage, stagePercent int32) int32 {
+	var done int32
+	for _, s := range syncStages(assetType) {
+		if s.stage == stage {
+			return done + s.weight*clampPercent(stagePercent)/100
+		}
+		done += s.weight
+	}
+	return -1
+}
+
+// walletSyncState is the progress of a sync in progress.
+type walletSyncState struct {
+	assetType       utils.AssetType
+	startedAt       time.Time
+	stage           utils.SyncStage
+	percent         int32
+	blocksRemaining int32
+}
+
+// syncProgressTracker follows the sync progress reported by the wallets.
+// The reports are received with the sync data of the wallets locked, so the
+// wallets are only queried when a snapshot of the progress is taken.
+type syncProgressTracker struct {
+	mu      sync.Mutex
+	tracked map[int]bool
+	syncs   map[int]*walletSyncState
+	onEnded func(SyncRecord)
+}
+
+func newSyncProgressTracker(onEnded func(SyncRecord)) *syncProgressTracker {
+	return &syncProgressTracker{
+		tracked: make(map[int]bool),
+		syncs:   make(map[int]*walletSyncState),
+		onEnded: onEnded,
+	}
+}
+
+func (t *syncProgressTracker) started(walletID int, assetType utils.AssetType, now time.Time) {
+	t.mu.Lock()
+	t.syncs[walletID] = &walletSyncState{assetType: assetType, startedAt: now, stage: utils.InvalidSyncStage}
+	t.mu.Unlock()
+}
+
+// progressed records a progress report of the sync of a wallet. stagePercent
+// is the progress of stage alone, which is weighted the same way for all the
+// wallets of the asset rather than trusting the total progress each asset
+// estimates its own way. A negative blocksRemaining keeps the previous number
+// of blocks remaining.
+func (t *syncProgressTracker) progressed(walletID int, assetType utils.AssetType, stage utils.SyncStage, stagePercent, blocksRemaining int32, now time.Time) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	state, ok := t.syncs[walletID]
+	if !ok {
+		// The sync started before the wallet was tracked.
+		state = &walletSyncState{assetType: assetType, startedAt: now}
+		t.syncs[walletID] = state
+	}
+	state.stage = stage
+	if percent := syncPercent(assetType, stage, stagePercent); percent >= 0 {
+		state.percent = max(state.percent, percent)
+	}
+	if blocksRemaining >= 0 {
+		state.blocksRemaining = blocksRemaining
+	}
+}
+
+// ended forgets the sync of a wallet and reports how it ended.
+func (t *syncProgressTracker) ended(walletID int, assetType utils.AssetType, outcome SyncOutcome, err error, now time.Time) {
+	t.mu.Lock()
+	state, ok := t.syncs[walletID]
+	delete(t.syncs, walletID)
+	t.mu.Unlock()
+	if !ok || t.onEnded == nil {
+		return
+	}
+
+	record := SyncRecord{
+		WalletID:  walletID,
+		AssetType: assetType,
+		StartedAt: state.startedAt,
+		Duration:  now.Sub(state.startedAt),
+		Outcome:   outcome,
+	}
+	if err != nil {
+		record.Error = err.Error()
+	}
+	t.onEnded(record)
+}
+
+// track registers the listener of the tracker on the wallet, once.
+func (t *syncProgressTracker) track(wallet sharedW.Asset) {
+	walletID, assetType := wallet.GetWalletID(), wallet.GetAssetType()
+
+	t.mu.Lock()
+	if t.tracked[walletID] {
+		t.mu.Unlock()
+		return
+	}
+	t.tracked[walletID] = true
+	t.mu.Unlock()
+
+	listener := &sharedW.SyncProgressListener{
+		OnSyncStarted: func() {
+			t.started(walletID, assetType, time.Now())
+		},
+		OnCFiltersFetchProgress: func(report *sharedW.CFiltersFetchProgressReport) {
+			t.progressed(walletID, assetType, utils.CFiltersFetchSyncStage, report.CFiltersFetchProgress,
+				report.TotalCFiltersToFetch-report.CurrentCFilterHeight, time.Now())
+		},
+		OnHeadersFetchProgress: func(report *sharedW.HeadersFetchProgressReport) {
+			remaining := report.TotalHeadersToFetch * (100 - clampPercent(report.HeadersFetchProgress)) / 100
+			t.progressed(walletID, assetType, utils.HeadersFetchSyncStage, report.HeadersFetchProgress, remaining, time.Now())
+		},
+		OnAddressDiscoveryProgress: func(report *sharedW.AddressDiscoveryProgressReport) {
+			t.progressed(walletID, assetType, utils.AddressDiscoverySyncStage, report.AddressDiscoveryProgress, 0, time.Now())
+		},
+		OnHeadersRescanProgress: func(report *sharedW.HeadersRescanProgressReport) {
+			t.progressed(walletID, assetType, utils.HeadersRescanSyncStage, report.RescanProgress,
+				report.TotalHeadersToScan-report.CurrentRescanHeight, time.Now())
+		},
+		OnSyncCompleted: func() {
+			t.ended(walletID, assetType, SyncCompleted, nil, time.Now())
+		},
+		OnSyncCanceled: func(_ bool) {
+			t.ended(walletID, assetType, SyncCanceled, nil, time.Now())
+		},
+		OnSyncEndedWithError: func(err error) {
+			t.ended(walletID, assetType, SyncFailed, err, time.Now())
+		},
+	}
+	if err := wallet.AddSyncProgressListener(listener, syncProgressListenerID); err != nil {
+		log.Errorf("Can't follow the sync progress of wallet %s: %v", wallet.GetWalletName(), err)
+	}
+}
+
+// forget stops following a deleted wallet.
+func (t *syncProgressTracker) forget(walletID int) {
+	t.mu.Lock()
+	delete(t.tracked, walletID)
+	delete(t.syncs, walletID)
+	t.mu.Unlock()
+}
+
+// walletProgress returns the sync progress of a wallet. Syncs that ended
+// without notifying the tracker, as not all the assets report cancelled
+// syncs, are recorded as the wallet is found no longer syncing.
+func (t *syncProgressTracker) walletProgress(wallet sharedW.Asset, now time.Time) *WalletSyncProgress {
+	progress := &WalletSyncProgress{
+		WalletID:       wallet.GetWalletID(),
+		WalletName:     wallet.GetWalletName(),
+		AssetType:      wallet.GetAssetType(),
+		Syncing:        wallet.IsSyncing(),
+		Synced:         wallet.IsSynced(),
+		Stage:          utils.InvalidSyncStage,
+		ConnectedPeers: wallet.ConnectedPeers(),
+	}
+
+	if progress.Synced {
+		progress.Percent = 100
+	}
+
+	t.mu.Lock()
+	state, ok := t.syncs[progress.WalletID]
+	var stale *walletSyncState
+	if ok && !progress.Syncing {
+		stale, state = state, nil
+	}
+	if state != nil {
+		progress.Stage = state.stage
+		progress.Percent = state.percent
+		progress.BlocksRemaining = max(state.blocksRemaining, 0)
+		progress.StartedAt = state.startedAt
+		progress.TimeRemaining = estimateTimeRemaining(now.Sub(state.startedAt), state.percent)
+	}
+	t.mu.Unlock()
+
+	if stale != nil {
+		outcome := SyncCanceled
+		if progress.Synced {
+			outcome = SyncCompleted
+		}
+		t.ended(progress.WalletID, progress.AssetType, outcome, nil, now)
+	}
+	return progress
+}
+
+// clampPercent keeps a progress reported by a wallet between 0 and 100.
+func clampPercent(percent int32) int32 {
+	return min(max(percent, 0), 100)
+}
+
+// estimateTimeRemaining estimates the time a sync that has taken elapsed to
+// reach percent has left, assuming it keeps progressing at the same pace.
+func estimateTimeRemaining(elapsed time.Duration, percent int32) time.Duration {
+	if percent <= 0 || percent >= 100 {
+		return 0
+	}
+	return elapsed * time.Duration(100-percent) / time.Duration(percent)
+}
+
+// aggregateSyncProgress sums up the sync progress of the wallets.
+func aggregateSyncProgress(wallets []*WalletSyncProgress) *SyncProgress {
+	progress := &SyncProgress{Wallets: wallets, Percent: 100}
+	var totalPercent int32
+	for _, wallet := range wallets {
+		progress.ConnectedPeers += wallet.ConnectedPeers
+		if wallet.Synced {
+			progress.Synced++
+		}
+		if !wallet.Syncing {
+			continue
+		}
+		progress.Syncing++
+		totalPercent += wallet.Percent
+		progress.BlocksRemaining += wallet.BlocksRemaining
+		progress.TimeRemaining = max(progress.TimeRemaining, wallet.TimeRemaining)
+	}
+	if progress.Syncing > 0 {
+		progress.Percent = totalPercent / int32(progress.Syncing)
+	}
+	return progress
+}
+
+// startSyncProgress follows the sync progress of the wallets.
+func (mgr *AssetsManager) startSyncProgress() {
+	mgr.syncProgress = newSyncProgressTracker(func(record SyncRecord) {
+		mgr.recordSync(record)
+		if record.Outcome == SyncCompleted {
+			go mgr.resumeRescan(record.WalletID)
+		}
+	})
+	for _, wallet := range mgr.AllWallets() {
+		mgr.syncProgress.track(wallet)
+	}
+}
+
+// trackSyncProgress follows the sync progress of a wallet added after the
+// assets manager was opened.
+func (mgr *AssetsManager) trackSyncProgress(wallet sharedW.Asset) {
+	if mgr.syncProgress != nil {
+		mgr.syncProgress.track(wallet)
+	}
+}
+
+// WalletSyncProgress returns the sync progress of the wallet with walletID,
+// or nil if there is no such wallet.
+func (mgr *AssetsManager) WalletSyncProgress(walletID int) *WalletSyncProgress {
+	wallet := mgr.WalletWithID(walletID)
+	if wallet == nil || mgr.syncProgress == nil {
+		return nil
+	}
+	return mgr.syncProgress.walletProgress(wallet, time.Now())
+}
+
+// SyncProgress returns the sync progress of every wallet and of all of them.
+func (mgr *AssetsManager) SyncProgress() *SyncProgress {
+	if mgr.syncProgress == nil {
+		return aggregateSyncProgress(nil)
+	}
+	now := time.Now()
+	wallets := mgr.AllWallets()
+	progress := make([]*WalletSyncProgress, 0, len(wallets))
+	for _, wallet := range wallets {
+		progress = append(progress, mgr.syncProgress.walletProgress(wallet, now))
+	}
+	return aggregateSyncProgress(progress)
+}
+
+// SyncHistory returns the past syncs of the wallets, the most recent first.
+func (mgr *AssetsManager) SyncHistory() []SyncRecord {
+	var history []SyncRecord
+	mgr.ReadAppConfigValue(sharedW.SyncHistoryConfigKey, &history)
+	return history
+}
+
+func (mgr *AssetsManager) recordSync(record SyncRecord) {
+	mgr.syncHistoryMu.Lock()
+	defer mgr.syncHistoryMu.Unlock()
+
+	history := append([]SyncRecord{record}, mgr.SyncHistory()...)
+	if len(history) > maxSyncHistory {
+		history = history[:maxSyncHistory]
+	}
+	mgr.SaveAppConfigValue(sharedW.SyncHistoryConfigKey, history)
+}
@@ -0,0 +1,104 @@
+package libwallet
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/crypto-power/cryptopower/libwallet/utils"
+)
+
+func TestEstimateTimeRemaining(t *testing.T) {
+	tests := []struct {
+		elapsed time.Duration
+		percent int32
+		want    time.Duration
+	}{
+		{time.Minute, 0, 0},
+		{time.Minute, 25, 3 * time.Minute},
+		{time.Minute, 50, time.Minute},
+		{time.Minute, 100, 0},
+	}
+
+	for _, test := range tests {
+		if got := estimateTimeRemaining(test.elapsed, test.percent); got != test.want {
+			t.Errorf("%v at %d%%: got %v, want %v", test.elapsed, test.percent, got, test.want)
+		}
+	}
+}
+
+func TestSyncPercent(t *testing.T) {
+	tests := []struct {
+		assetType    utils.AssetType
+		stage        utils.SyncStage
+		stagePercent int32
+		want         int32
+	}{
+		{utils.DCRWalletAsset, utils.CFiltersFetchSyncStage, 0, 0},
+		{utils.DCRWalletAsset, utils.CFiltersFetchSyncStage, 100, 35},
+		{utils.DCRWalletAsset, utils.HeadersFetchSyncStage, 50, 52},
+		{utils.DCRWalletAsset, utils.AddressDiscoverySyncStage, 50, 75},
+		{utils.DCRWalletAsset, utils.HeadersRescanSyncStage, 100, 100},
+		{utils.BTCWalletAsset, utils.HeadersFetchSyncStage, 50, 50},
+		{utils.LTCWalletAsset, utils.HeadersFetchSyncStage, 120, 100},
+		{utils.LTCWalletAsset, utils.HeadersRescanSyncStage, 50, -1},
+	}
+
+	for _, test := range tests {
+		if got := syncPercent(test.assetType, test.stage, test.stagePercent); got != test.want {
+			t.Errorf("%v %v at %d%%: got %d, want %d", test.assetType, test.stage, test.stagePercent, got, test.want)
+		}
+	}
+}
+
+func TestSyncProgressTracker(t *testing.T) {
+	var records []SyncRecord
+	tracker := newSyncProgressTracker(func(record SyncRecord) {
+		records = append(records, record)
+	})
+
+	start := time.Now()
+	tracker.started(1, utils.BTCWalletAsset, start)
+	tracker.progressed(1, utils.BTCWalletAsset, utils.HeadersFetchSyncStage, 40, 600, start)
+	// The progress never goes back nor over 100.
+	tracker.progressed(1, utils.BTCWalletAsset, utils.HeadersFetchSyncStage, 30, -1, start)
+	state := tracker.syncs[1]
+	if state.percent != 40 || state.blocksRemaining != 600 || state.stage != utils.HeadersFetchSyncStage {
+		t.Fatalf("unexpected state %+v", state)
+	}
+	// A stage the asset doesn't go through leaves the progress as it is.
+	tracker.progressed(1, utils.BTCWalletAsset, utils.AddressDiscoverySyncStage, 90, -1, start)
+	if state.percent != 40 {
+		t.Fatalf("got percent %d, want 40", state.percent)
+	}
+	tracker.progressed(1, utils.BTCWalletAsset, utils.HeadersFetchSyncStage, 150, 0, start)
+	if state.percent != 100 {
+		t.Fatalf("got percent %d, want 100", state.percent)
+	}
+
+	tracker.ended(1, utils.BTCWalletAsset, SyncFailed, errors.New("boom"), start.Add(time.Minute))
+	if len(records) != 1 || records[0].Duration != time.Minute || records[0].Outcome != SyncFailed || records[0].Error != "boom" {
+		t.Fatalf("unexpected records %+v", records)
+	}
+	// A sync that isn't followed isn't recorded.
+	tracker.ended(1, utils.BTCWalletAsset, SyncCanceled, nil, start)
+	if len(records) != 1 {
+		t.Fatalf("got %d records, want 1", len(records))
+	}
+}
+
+func TestAggregateSyncProgress(t *testing.T) {
+	progress := aggregateSyncProgress([]*WalletSyncProgress{
+		{Syncing: true, Percent: 20, TimeRemaining: time.Hour, ConnectedPeers: 3, BlocksRemaining: 100},
+		{Syncing: true, Percent: 60, TimeRemaining: time.Minute, ConnectedPeers: 2, BlocksRemaining: 10},
+		{Synced: true, Percent: 100, ConnectedPeers: 4},
+	})
+	if progress.Syncing != 2 || progress.Synced != 1 || progress.Percent != 40 ||
+		progress.TimeRemaining != time.Hour || progress.ConnectedPeers != 9 || progress.BlocksRemaining != 110 {
+		t.Fatalf("unexpected progress %+v", progress)
+	}
+
+	if progress := aggregateSyncProgress(nil); progress.Percent != 100 {
+		t.Fatalf("got percent %d with no wallets, want 100", progress.Percent)
+	}
+}
@@ -0,0 +1,28 @@
+package utils
+
+import "net"
+
+// countingConn is a connection that reports the bytes sent and received
+// through it.
+type countingConn struct {
+	net.Conn
+	count func(n int)
+}
+
+// NewCountingConn returns conn reporting to count the number of bytes every
+// read and write transfers.
+func NewCountingConn(conn net.Conn, count func(n int)) net.Conn {
+	return &countingConn{Conn: conn, count: count}
+}
+
+func (c *countingConn) Read(b []byte) (int, error) {
+	n, err := c.Conn.Read(b)
+	c.count(n)
+	return n, err
+}
+
+func (c *countingConn) Write(b []byte) (int, error) {
+	n, err := c.Conn.Write(b)
+	c.count(n)
+	return n, err
+}
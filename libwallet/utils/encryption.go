@@ -0,0 +1,60 @@
+package utils
+
+import (
+	"crypto/rand"
+
+	"decred.org/dcrwallet/v4/errors"
+	"github.com/kevinburke/nacl"
+	"github.com/kevinburke/nacl/secretbox"
+	"golang.org/x/crypto/scrypt"
+)
+
+// passphraseSaltSize is the size of the random salt prepended to data
+// encrypted with EncryptWithPassphrase.
+const passphraseSaltSize = 32
+
+// passphraseKey derives a nacl.Key from pass and salt using scrypt.Key.
+func passphraseKey(pass, salt []byte) (nacl.Key, error) {
+	const N, r, p = 1 << 15, 8, 1
+
+	hash, err := scrypt.Key(pass, salt, N, r, p, nacl.KeySize)
+	if err != nil {
+		return nil, err
+	}
+	return nacl.Load(EncodeHex(hash))
+}
+
+// EncryptWithPassphrase encrypts data with a key derived from pass and a
+// random salt. The salt is prepended to the returned ciphertext.
+func EncryptWithPassphrase(pass, data []byte) ([]byte, error) {
+	salt := make([]byte, passphraseSaltSize)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, err
+	}
+
+	key, err := passphraseKey(pass, salt)
+	if err != nil {
+		return nil, err
+	}
+	return append(salt, secretbox.EasySeal(data, key)...), nil
+}
+
+// DecryptWithPassphrase decrypts data encrypted with EncryptWithPassphrase.
+// ErrInvalidPassphrase is returned if pass is not the passphrase the data was
+// encrypted with.
+func DecryptWithPassphrase(pass, encrypted []byte) ([]byte, error) {
+	if len(encrypted) <= passphraseSaltSize {
+		return nil, errors.New(ErrInvalid)
+	}
+
+	key, err := passphraseKey(pass, encrypted[:passphraseSaltSize])
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := secretbox.EasyOpen(encrypted[passphraseSaltSize:], key)
+	if err != nil {
+		return nil, errors.New(ErrInvalidPassphrase)
+	}
+	return data, nil
+}
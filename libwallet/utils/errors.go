@@ -47,6 +47,23 @@ const (
 	ErrInvalidVoteBit               = "err_invalid_vote_bit"
 	ErrNotSynced                    = "err_not_synced"
 	ErrNoSeed                       = "no_seed"
+	ErrNotMultisigWallet            = "not_multisig_wallet"
+	ErrSpendLimitExceeded           = "spend_limit_exceeded"
+	ErrDestinationNotWhitelisted    = "destination_not_whitelisted"
+	ErrNewDestination               = "new_destination"
+	ErrSendDelayed                  = "send_delayed"
+	ErrSendNotDelayable             = "send_not_delayable"
+	ErrDEXTradeRestricted           = "dex_trade_restricted"
+	ErrRPCWrongNetwork              = "rpc_wrong_network"
+	ErrElectrumCertMismatch         = "electrum_cert_mismatch"
+	ErrElectrumWrongNetwork         = "electrum_wrong_network"
+	ErrPeerBanned                   = "peer_banned"
+	ErrPeerNotFound                 = "peer_not_found"
+	ErrSyncMeteredNetwork           = "sync_metered_network"
+	ErrSyncNotCharging              = "sync_not_charging"
+	ErrSyncDataBudgetExceeded       = "sync_data_budget_exceeded"
+	ErrInvalidPrivateKey            = "invalid_private_key"
+	ErrNoFundsToSweep               = "no_funds_to_sweep"
 )
 
 var (
@@ -0,0 +1,62 @@
+package libwallet
+
+import (
+	"context"
+	"time"
+
+	sharedW "github.com/crypto-power/cryptopower/libwallet/assets/wallet"
+)
+
+// walletDBBackupCheckInterval is how often the wallets are checked for a due
+// database backup.
+const walletDBBackupCheckInterval = time.Hour
+
+// startWalletDBBackups starts a loop that backs up the database of every open
+// wallet once its latest backup is older than sharedW.WalletDBBackupInterval.
+// The loop stops when the assets manager shuts down.
+func (mgr *AssetsManager) startWalletDBBackups() {
+	ctx, cancel := context.WithCancel(context.Background())
+	mgr.cancelFuncs = append(mgr.cancelFuncs, cancel)
+
+	go func() {
+		ticker := time.NewTicker(walletDBBackupCheckInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if mgr.IsAutoWalletDBBackupEnabled() {
+					mgr.backupDueWalletDBs()
+				}
+			}
+		}
+	}()
+}
+
+// backupDueWalletDBs backs up the databases of the open wallets that have not
+// been backed up within sharedW.WalletDBBackupInterval.
+func (mgr *AssetsManager) backupDueWalletDBs() {
+	for _, wallet := range mgr.AllWallets() {
+		if !wallet.WalletOpened() || !walletDBBackupDue(wallet) {
+			continue
+		}
+
+		backupPath, err := wallet.BackupWalletDB()
+		if err != nil {
+			log.Errorf("Error backing up the database of wallet %d: %v", wallet.GetWalletID(), err)
+			continue
+		}
+		log.Infof("Backed up the database of wallet %d to %s", wallet.GetWalletID(), backupPath)
+	}
+}
+
+func walletDBBackupDue(wallet sharedW.Asset) bool {
+	backups, err := wallet.WalletDBBackups()
+	if err != nil {
+		log.Errorf("Error reading the database backups of wallet %d: %v", wallet.GetWalletID(), err)
+		return false
+	}
+	return len(backups) == 0 || time.Since(backups[0].CreatedAt) >= sharedW.WalletDBBackupInterval
+}
@@ -167,13 +167,21 @@ func (app *AppInfo) ChangeAssetsManagerNetwork(netType utils.NetworkType) (*libw
 
 // ChangeAssetsManager closes all open pages, shuts down the current
 // AssetsManager, switches to the provided AssetsManager and then restarts the
-// app by displaying the app's first page.
+// app by displaying the app's first page. An AssetsManager of the same network
+// type, such as the decoy profile opened by a duress passphrase, is switched to
+// without restarting the app, the caller displays the pages that use it.
 //
 // TODO: If *AppInfo.Window is changed to a custom type that implements
 // app.WindowNavigator, this method won't need to take an app.PageNavigator
 // parameter. See the TODO comment on *AppInfo.ReadyForDisplay() and
 // *AppInfo.Window().
 func (app *AppInfo) ChangeAssetsManager(newAssetsManager *libwallet.AssetsManager, pageNav app.PageNavigator) {
+	currentNetType, newNetType := app.AssetsManager.NetType(), newAssetsManager.NetType()
+	if newNetType == currentNetType {
+		app.useAssetsManager(newAssetsManager)
+		return
+	}
+
 	if !app.allowNetTypeSwitching {
 		// Should never happen, because *AppInfo.ChangeAssetsManagerNetwork()
 		// would not even produce a newAssetsManager if network type change is
@@ -195,7 +203,6 @@ func (app *AppInfo) ChangeAssetsManager(newAssetsManager *libwallet.AssetsManage
 
 	// Close all pages that are currently open and display a temporary
 	// "restarting app" page.
-	currentNetType, newNetType := app.AssetsManager.NetType(), newAssetsManager.NetType()
 	pageNav.ClearStackAndDisplay(networkSwitchTempPage(currentNetType, newNetType))
 
 	// Display the newNetType on the app title if its not on mainnet.
@@ -205,9 +212,7 @@ func (app *AppInfo) ChangeAssetsManager(newAssetsManager *libwallet.AssetsManage
 	}
 	app.Window().Option(appTitle)
 
-	// Shutdown the current AssetsManager and begin using the new one.
-	app.AssetsManager.Shutdown()
-	app.AssetsManager = newAssetsManager
+	app.useAssetsManager(newAssetsManager)
 
 	// If the network type / assets manager switch was swift, wait a bit so the
 	// user clearly sees the temporary "restarting app" page before displaying
@@ -219,6 +224,14 @@ func (app *AppInfo) ChangeAssetsManager(newAssetsManager *libwallet.AssetsManage
 	pageNav.ClearStackAndDisplay(appStartPage)
 }
 
+// useAssetsManager shuts down the current AssetsManager and begins using the
+// provided one in its place, in the same app window.
+func (app *AppInfo) useAssetsManager(newAssetsManager *libwallet.AssetsManager) {
+	newAssetsManager.InheritUIContext(app.AssetsManager)
+	app.AssetsManager.Shutdown()
+	app.AssetsManager = newAssetsManager
+}
+
 // SetCurrentAppWidth sets the specified value as the app's current width, using
 // the provided device-dependent metric unit conversion.
 //
@@ -21,6 +21,15 @@ type AppSettingsChangeHandler interface {
 	OnLanguageChanged()
 }
 
+// AppLockHandler is implemented by pages and modals that need to hide or
+// refresh data when the app is locked after a period of inactivity.
+type AppLockHandler interface {
+	// OnAppLocked is triggered when the app is locked. The unlocked wallets
+	// that aren't used by background jobs are locked and balances are hidden
+	// before it is called.
+	OnAppLocked()
+}
+
 // KeyEventHandler is implemented by pages and modals that require key event
 // notifications.
 type KeyEventHandler interface {
@@ -57,6 +57,13 @@ func (tm *TextInputModal) Hint(hint string) *TextInputModal {
 	return tm
 }
 
+// Masked hides the text entered, such as a private key.
+func (tm *TextInputModal) Masked() *TextInputModal {
+	tm.textInput = tm.Theme.EditorPassword(tm.textInput.Editor, tm.textInput.Hint)
+	tm.textInput.Editor.SingleLine, tm.textInput.Editor.Submit = true, true
+	return tm
+}
+
 func (tm *TextInputModal) setLoading(loading bool) {
 	tm.isLoading = loading
 }
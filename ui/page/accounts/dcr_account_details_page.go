@@ -11,6 +11,7 @@ import (
 	"gioui.org/widget"
 
 	"github.com/crypto-power/cryptopower/app"
+	"github.com/crypto-power/cryptopower/dexc"
 	sharedW "github.com/crypto-power/cryptopower/libwallet/assets/wallet"
 	"github.com/crypto-power/cryptopower/ui/cryptomaterial"
 	"github.com/crypto-power/cryptopower/ui/load"
@@ -43,6 +44,7 @@ type AcctDetailsPage struct {
 	infoButton               cryptomaterial.IconButton
 
 	lockedBalance    string
+	dexFunds         *dexc.WalletFunds
 	totalBalance     string
 	spendableBalance string
 	immatureBalance  string
@@ -91,6 +93,7 @@ func (pg *AcctDetailsPage) OnNavigatedTo() {
 	pg.immatureBalance = pg.wallet.ToAmount(bal.ImmatureReward.ToInt() + bal.ImmatureStakeGeneration.ToInt()).String()
 	pg.lockeByTicket = bal.LockedByTickets.String()
 	pg.votingAuthority = bal.VotingAuthority.String()
+	pg.dexFunds = pg.AssetsManager.DEXAccountFunds(pg.wallet.GetWalletID(), pg.account.Number)
 
 	pg.hdPath = pg.AssetsManager.DCRHDPrefix() + strconv.Itoa(int(pg.account.Number)) + "'"
 
@@ -244,12 +247,37 @@ func (pg *AcctDetailsPage) accountBalanceLayout(gtx C) D {
 					layout.Rigid(func(gtx C) D {
 						return pg.acctBalLayout(gtx, values.String(values.StrVotingAuthority), pg.votingAuthority, false)
 					}),
+					layout.Rigid(pg.dexFundsLayout),
 				)
 			}),
 		)
 	})
 }
 
+// dexFundsLayout displays the account funds locked by DEX trading if the
+// account is used by the DEX client.
+func (pg *AcctDetailsPage) dexFundsLayout(gtx C) D {
+	funds := pg.dexFunds
+	if funds == nil {
+		return D{}
+	}
+
+	amount := func(atoms uint64) string {
+		return pg.wallet.ToAmount(int64(atoms)).String()
+	}
+	return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+		layout.Rigid(func(gtx C) D {
+			return pg.acctBalLayout(gtx, values.String(values.StrDEXOrderLocked), amount(funds.OrderLocked), false)
+		}),
+		layout.Rigid(func(gtx C) D {
+			return pg.acctBalLayout(gtx, values.String(values.StrDEXContractLocked), amount(funds.ContractLocked), false)
+		}),
+		layout.Rigid(func(gtx C) D {
+			return pg.acctBalLayout(gtx, values.String(values.StrDEXBondLocked), amount(funds.BondLocked), false)
+		}),
+	)
+}
+
 func (pg *AcctDetailsPage) acctBalLayout(gtx C, balType string, balance string, isTotalBalance bool) D {
 
 	marginTop := values.MarginPadding16
@@ -11,6 +11,7 @@ import (
 	"gioui.org/widget"
 
 	"github.com/crypto-power/cryptopower/app"
+	"github.com/crypto-power/cryptopower/dexc"
 	sharedW "github.com/crypto-power/cryptopower/libwallet/assets/wallet"
 	"github.com/crypto-power/cryptopower/ui/cryptomaterial"
 	"github.com/crypto-power/cryptopower/ui/load"
@@ -42,6 +43,7 @@ type LTCAcctDetailsPage struct {
 	totalBalance            string
 	spendableBalance        string
 	lockedBalance           string
+	dexFunds                *dexc.WalletFunds
 	hdPath                  string
 	keys                    string
 	extendedKey             string
@@ -83,6 +85,7 @@ func (pg *LTCAcctDetailsPage) OnNavigatedTo() {
 	pg.totalBalance = pg.account.Balance.Total.String()
 	pg.spendableBalance = pg.account.Balance.Spendable.String()
 	pg.lockedBalance = pg.account.Balance.Locked.String()
+	pg.dexFunds = pg.AssetsManager.DEXAccountFunds(pg.wallet.GetWalletID(), int32(pg.account.AccountNumber))
 
 	pg.hdPath = pg.AssetsManager.LTCHDPrefix() + strconv.Itoa(int(pg.account.AccountNumber)) + "'"
 
@@ -278,10 +281,35 @@ func (pg *LTCAcctDetailsPage) accountBalanceLayout(gtx C) D {
 			layout.Rigid(func(gtx C) D {
 				return pg.acctBalLayout(gtx, values.String(values.StrLocked), pg.lockedBalance, false)
 			}),
+			layout.Rigid(pg.dexFundsLayout),
 		)
 	})
 }
 
+// dexFundsLayout displays the account funds locked by DEX trading if the
+// account is used by the DEX client.
+func (pg *LTCAcctDetailsPage) dexFundsLayout(gtx C) D {
+	funds := pg.dexFunds
+	if funds == nil {
+		return D{}
+	}
+
+	amount := func(atoms uint64) string {
+		return pg.wallet.ToAmount(int64(atoms)).String()
+	}
+	return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+		layout.Rigid(func(gtx C) D {
+			return pg.acctBalLayout(gtx, values.String(values.StrDEXOrderLocked), amount(funds.OrderLocked), false)
+		}),
+		layout.Rigid(func(gtx C) D {
+			return pg.acctBalLayout(gtx, values.String(values.StrDEXContractLocked), amount(funds.ContractLocked), false)
+		}),
+		layout.Rigid(func(gtx C) D {
+			return pg.acctBalLayout(gtx, values.String(values.StrDEXBondLocked), amount(funds.BondLocked), false)
+		}),
+	)
+}
+
 func (pg *LTCAcctDetailsPage) acctBalLayout(gtx C, balType string, balance string, isTotalBalance bool) D {
 
 	marginTop := values.MarginPadding16
@@ -152,6 +152,22 @@ func (d *AccountDropdown) SetSelectedAccount(account *sharedW.Account) {
 	d.dropdown.SetSelectedValue(fmt.Sprint(account.Number))
 }
 
+// SelectAccount selects the account with the provided number if it is one of
+// the valid accounts listed by the dropdown. Returns false if the account is
+// not listed.
+func (d *AccountDropdown) SelectAccount(accountNumber int32) bool {
+	account := d.getAccountByNumber(accountNumber)
+	if account == nil {
+		return false
+	}
+
+	d.SetSelectedAccount(account)
+	if d.accountChangedCallback != nil {
+		d.accountChangedCallback(account)
+	}
+	return true
+}
+
 func (d *AccountDropdown) onChanged() {
 	accountNumber, err := strconv.Atoi(d.dropdown.Selected())
 	if err == nil {
@@ -0,0 +1,182 @@
+package components
+
+import (
+	"strings"
+
+	"gioui.org/layout"
+	"gioui.org/widget"
+	"gioui.org/widget/material"
+
+	"github.com/crypto-power/cryptopower/app"
+	sharedW "github.com/crypto-power/cryptopower/libwallet/assets/wallet"
+	"github.com/crypto-power/cryptopower/ui/cryptomaterial"
+	"github.com/crypto-power/cryptopower/ui/load"
+	"github.com/crypto-power/cryptopower/ui/modal"
+	"github.com/crypto-power/cryptopower/ui/values"
+)
+
+const AppBackupRestorePageID = "AppBackupRestore"
+
+// AppBackupRestorePage restores the wallets and app data saved in an app
+// backup file.
+type AppBackupRestorePage struct {
+	*load.Load
+	// GenericPageModal defines methods such as ID() and OnAttachedToNavigator()
+	// that helps this Page satisfy the app.Page interface. It also defines
+	// helper methods for accessing the PageNavigator that displayed this page
+	// and the root WindowNavigator.
+	*app.GenericPageModal
+
+	onRestored func()
+
+	backButton            cryptomaterial.IconButton
+	filePathEditor        cryptomaterial.Editor
+	backupPassEditor      cryptomaterial.Editor
+	spendingPassEditor    cryptomaterial.Editor
+	confirmSpendingEditor cryptomaterial.Editor
+	restoreBtn            cryptomaterial.Button
+
+	restoring bool
+	errMsg    string
+}
+
+// NewAppBackupRestorePage creates a page that restores an app backup.
+// onRestored is called once the backup is restored.
+func NewAppBackupRestorePage(l *load.Load, onRestored func()) *AppBackupRestorePage {
+	pg := &AppBackupRestorePage{
+		Load:                  l,
+		GenericPageModal:      app.NewGenericPageModal(AppBackupRestorePageID),
+		onRestored:            onRestored,
+		backButton:            GetBackButton(l),
+		filePathEditor:        l.Theme.Editor(new(widget.Editor), values.String(values.StrBackupFilePath)),
+		backupPassEditor:      l.Theme.EditorPassword(new(widget.Editor), values.String(values.StrBackupPassphrase)),
+		spendingPassEditor:    l.Theme.EditorPassword(new(widget.Editor), values.String(values.StrSpendingPassword)),
+		confirmSpendingEditor: l.Theme.EditorPassword(new(widget.Editor), values.String(values.StrConfirmSpendingPassword)),
+		restoreBtn:            l.Theme.Button(values.String(values.StrRestore)),
+	}
+
+	pg.filePathEditor.Editor.SingleLine = true
+	return pg
+}
+
+// OnNavigatedTo is called when the page is about to be displayed and
+// may be used to initialize page features that are only relevant when
+// the page is displayed.
+// Part of the load.Page interface.
+func (pg *AppBackupRestorePage) OnNavigatedTo() {}
+
+// Layout draws the page UI components into the provided C
+// to be eventually drawn on screen.
+// Part of the load.Page interface.
+func (pg *AppBackupRestorePage) Layout(gtx C) D {
+	sp := SubPage{
+		Load:       pg.Load,
+		Title:      values.String(values.StrRestoreAppBackup),
+		BackButton: pg.backButton,
+		Back: func() {
+			if !pg.restoring {
+				pg.ParentNavigator().CloseCurrentPage()
+			}
+		},
+		Body: func(gtx C) D {
+			return pg.Theme.Card().Layout(gtx, func(gtx C) D {
+				gtx.Constraints.Min.X = gtx.Constraints.Max.X
+				return layout.UniformInset(values.MarginPadding16).Layout(gtx, pg.layoutContent)
+			})
+		},
+	}
+	return sp.Layout(pg.ParentWindow(), gtx)
+}
+
+func (pg *AppBackupRestorePage) layoutContent(gtx C) D {
+	widgets := []layout.Widget{
+		func(gtx C) D {
+			desc := pg.Theme.Body2(values.String(values.StrAppBackupRestoreDesc))
+			desc.Color = pg.Theme.Color.GrayText2
+			return desc.Layout(gtx)
+		},
+		pg.filePathEditor.Layout,
+		pg.backupPassEditor.Layout,
+		pg.spendingPassEditor.Layout,
+		pg.confirmSpendingEditor.Layout,
+	}
+
+	if pg.errMsg != "" {
+		widgets = append(widgets, func(gtx C) D {
+			lbl := pg.Theme.Body2(pg.errMsg)
+			lbl.Color = pg.Theme.Color.Danger
+			return lbl.Layout(gtx)
+		})
+	}
+
+	widgets = append(widgets, func(gtx C) D {
+		if pg.restoring {
+			return layout.Center.Layout(gtx, material.Loader(pg.Theme.Base).Layout)
+		}
+		return pg.restoreBtn.Layout(gtx)
+	})
+
+	children := make([]layout.FlexChild, 0, len(widgets))
+	for _, w := range widgets {
+		children = append(children, layout.Rigid(func(gtx C) D {
+			return layout.Inset{Bottom: values.MarginPadding16}.Layout(gtx, w)
+		}))
+	}
+	return layout.Flex{Axis: layout.Vertical}.Layout(gtx, children...)
+}
+
+// HandleUserInteractions is called just before Layout() to determine
+// if any user interaction recently occurred on the page and may be
+// used to update the page's UI components shortly before they are
+// displayed.
+// Part of the load.Page interface.
+func (pg *AppBackupRestorePage) HandleUserInteractions(gtx C) {
+	pg.restoreBtn.SetEnabled(!pg.restoring && pg.filePathEditor.Editor.Len() > 0 &&
+		pg.backupPassEditor.Editor.Len() > 0 && pg.spendingPassEditor.Editor.Len() > 0)
+
+	if pg.restoreBtn.Clicked(gtx) && !pg.restoring {
+		pg.restore()
+	}
+}
+
+func (pg *AppBackupRestorePage) restore() {
+	pg.errMsg = ""
+	spendingPass := pg.spendingPassEditor.Editor.Text()
+	if spendingPass != pg.confirmSpendingEditor.Editor.Text() {
+		pg.confirmSpendingEditor.SetError(values.String(values.StrPasswordNotMatch))
+		return
+	}
+	pg.confirmSpendingEditor.SetError("")
+
+	filePath := strings.TrimSpace(pg.filePathEditor.Editor.Text())
+	backupPass := pg.backupPassEditor.Editor.Text()
+	pg.restoring = true
+	go func() {
+		seedDeleted, err := pg.AssetsManager.ImportAppBackup(filePath, backupPass, spendingPass, sharedW.PassphraseTypePass)
+		pg.restoring = false
+		if err != nil {
+			pg.errMsg = err.Error()
+			pg.ParentWindow().Reload()
+			return
+		}
+
+		pg.Toast.Notify(values.String(values.StrAppBackupRestored))
+		if len(seedDeleted) > 0 {
+			info := modal.NewCustomModal(pg.Load).
+				Title(values.String(values.StrSeedDeletedWalletsRestored)).
+				Body(values.StringF(values.StrSeedDeletedWalletsRestoredFmt, strings.Join(seedDeleted, ", "))).
+				SetPositiveButtonText(values.String(values.StrGotIt))
+			pg.ParentWindow().ShowModal(info)
+		}
+		pg.onRestored()
+	}()
+}
+
+// OnNavigatedFrom is called when the page is about to be removed from
+// the displayed window. This method should ideally be used to disable
+// features that are irrelevant when the page is NOT displayed.
+// NOTE: The page may be re-displayed on the app's window, in which case
+// OnNavigatedTo() will be called again. This method should not destroy UI
+// components unless they'll be recreated in the OnNavigatedTo() method.
+// Part of the load.Page interface.
+func (pg *AppBackupRestorePage) OnNavigatedFrom() {}
@@ -26,6 +26,11 @@ type FeeRateSelector struct {
 	SaveRate cryptomaterial.Button
 
 	fetchedRatesDropDown *cryptomaterial.DropDown
+	// feeRates are the fetched fee rates listed by fetchedRatesDropDown, for
+	// the confirmation targets of selectedWallet.
+	feeRates       []sharedW.FeeEstimate
+	selectedWallet sharedW.Asset
+	estSignedSize  int
 
 	feeRateSwitch *cryptomaterial.SegmentedControl
 
@@ -148,12 +153,6 @@ func (fs *FeeRateSelector) Layout(gtx C) D {
 							}),
 						)
 					}
-					// Fee rate fatching not currently supported by LTC
-					// TODO: Add fee rate API query for LTC
-					if fs.selectedWalletType() == libutils.LTCWalletAsset {
-						return layoutBody(gtx)
-					}
-
 					if fs.feeRateSwitch.SelectedSegment() == values.StrFetched {
 						fs.fetchedRatesDropDown.Width = gtx.Metric.PxToDp(gtx.Constraints.Max.X)
 						layoutBody = fs.fetchedRatesDropDown.Layout
@@ -192,7 +191,8 @@ func (fs *FeeRateSelector) Layout(gtx C) D {
 	)
 }
 
-// FetchFeeRate will fetch the fee rate from the HTTP API.
+// UpdatedFeeRate fetches the fee rates for the confirmation targets of the
+// selected wallet.
 func (fs *FeeRateSelector) UpdatedFeeRate(selectedWallet sharedW.Asset) {
 	if fs.fetchingRate {
 		return
@@ -207,6 +207,21 @@ func (fs *FeeRateSelector) UpdatedFeeRate(selectedWallet sharedW.Asset) {
 		return
 	}
 
+	fs.feeRates = feeRates
+	fs.selectedWallet = selectedWallet
+	fs.fetchedRatesDropDown = fs.Theme.DropDown(fs.feeRateItems(), nil, values.WalletsDropdownGroup, false)
+	fs.fetchedRatesDropDown.FontWeight = font.SemiBold
+	fs.fetchedRatesDropDown.Hoverable = false
+	fs.fetchedRatesDropDown.SelectedItemIconColor = &fs.Theme.Color.Primary
+	fs.fetchedRatesDropDown.ExpandedLayoutInset = layout.Inset{Top: values.MarginPadding35}
+	fs.fetchedRatesDropDown.MakeCollapsedLayoutVisibleWhenExpanded = true
+	fs.fetchedRatesDropDown.Background = &fs.Theme.Color.Gray4
+	fs.fetchedRatesDropDown.SetMaxTextLeng(60)
+}
+
+// feeRateItems lists the confirmation targets of the fetched fee rates with
+// their fee rate and, once the size of the tx is estimated, its cost.
+func (fs *FeeRateSelector) feeRateItems() []cryptomaterial.DropDownItem {
 	blocksStr := func(b int32) string {
 		val := strconv.Itoa(int(b)) + " block"
 		if b == 1 {
@@ -215,21 +230,55 @@ func (fs *FeeRateSelector) UpdatedFeeRate(selectedWallet sharedW.Asset) {
 		return val + "s"
 	}
 
-	items := []cryptomaterial.DropDownItem{}
-	for index := range feeRates {
-		items = append(items, cryptomaterial.DropDownItem{
-			Text: fs.addRatesUnits(feeRates[index].Feerate.ToInt()) + " - " + blocksStr(feeRates[index].ConfirmedBlocks),
-		})
+	items := make([]cryptomaterial.DropDownItem, 0, len(fs.feeRates))
+	for _, feeRate := range fs.feeRates {
+		text := blocksStr(feeRate.ConfirmedBlocks) + " - " + fs.addRatesUnits(feeRate.Feerate.ToInt())
+		if fs.estSignedSize > 0 && fs.selectedWallet != nil {
+			cost := fs.selectedWallet.ToAmount(feeRate.Feerate.ToInt() * int64(fs.estSignedSize) / 1000)
+			text += ", " + values.StringF(values.StrCost, ": "+cost.String())
+		}
+		items = append(items, cryptomaterial.DropDownItem{Text: text})
 	}
+	return items
+}
 
-	fs.fetchedRatesDropDown = fs.Theme.DropDown(items, nil, values.WalletsDropdownGroup, false)
-	fs.fetchedRatesDropDown.FontWeight = font.SemiBold
-	fs.fetchedRatesDropDown.Hoverable = false
-	fs.fetchedRatesDropDown.SelectedItemIconColor = &fs.Theme.Color.Primary
-	fs.fetchedRatesDropDown.ExpandedLayoutInset = layout.Inset{Top: values.MarginPadding35}
-	fs.fetchedRatesDropDown.MakeCollapsedLayoutVisibleWhenExpanded = true
-	fs.fetchedRatesDropDown.Background = &fs.Theme.Color.Gray4
-	fs.fetchedRatesDropDown.SetMaxTextLeng(30)
+// SetEstimatedSize sets the estimated size of the signed tx, updating the
+// cost of the tx at each fetched fee rate.
+func (fs *FeeRateSelector) SetEstimatedSize(size int) {
+	fs.EstSignedSize = fmt.Sprintf("%d Bytes", size)
+	if size == fs.estSignedSize {
+		return
+	}
+	fs.estSignedSize = size
+
+	selected := fs.fetchedRatesDropDown.SelectedIndex()
+	items := fs.feeRateItems()
+	fs.fetchedRatesDropDown.SetItems(items)
+	if selected > 0 && selected < len(items) {
+		fs.fetchedRatesDropDown.SetSelectedValue(items[selected].Text)
+	}
+}
+
+// FetchedRateChanged returns true if a fetched fee rate was selected.
+func (fs *FeeRateSelector) FetchedRateChanged(gtx C) bool {
+	return fs.fetchedRatesDropDown.Changed(gtx)
+}
+
+// OnFetchedRateSelected sets the selected fetched fee rate as the fee rate of
+// the selected wallet.
+func (fs *FeeRateSelector) OnFetchedRateSelected(selectedWallet sharedW.Asset) {
+	index := fs.fetchedRatesDropDown.SelectedIndex()
+	if index < 0 || index >= len(fs.feeRates) {
+		return
+	}
+
+	rate := fs.feeRates[index].Feerate.ToInt()
+	if _, err := load.SetAPIFeeRate(selectedWallet, strconv.FormatInt(rate, 10)); err != nil {
+		log.Errorf("setting the fee rate failed: %v", err)
+		fs.feeRateText = " - "
+		return
+	}
+	fs.feeRateText = fs.addRatesUnits(rate)
 }
 
 // OnEditRateCliked is called when the edit feerate button is clicked.
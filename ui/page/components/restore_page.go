@@ -3,6 +3,7 @@ package components
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"gioui.org/font"
 	"gioui.org/io/event"
@@ -21,6 +22,9 @@ import (
 
 const CreateRestorePageID = "Restore"
 
+// restoreBirthdayLayout is the format of the date a wallet is restored from.
+const restoreBirthdayLayout = "2006-01-02"
+
 var tabTitles = []string{
 	values.StrSeedWords,
 	values.StrHex,
@@ -47,6 +51,7 @@ type Restore struct {
 	confirmSeedButton cryptomaterial.Button
 	restoreInProgress bool
 	seedTypeDropdown  *cryptomaterial.DropDown
+	birthdayEditor    cryptomaterial.Editor
 }
 
 func NewRestorePage(l *load.Load, walletName string, walletType libutils.AssetType, onRestoreComplete func(newWallet sharedW.Asset)) *Restore {
@@ -83,7 +88,11 @@ func NewRestorePage(l *load.Load, walletName string, walletType libutils.AssetTy
 
 	pg.seedTypeDropdown = pg.Theme.NewCommonDropDown(GetWordSeedTypeDropdownItems(), defaultWordSeedType, values.MarginPadding130, values.TxDropdownGroup, false)
 
-	pg.seedRestorePage = NewSeedRestorePage(l, walletName, walletType, onRestoreComplete, pg.getWordSeedType)
+	pg.birthdayEditor = l.Theme.Editor(new(widget.Editor), values.String(values.StrRestoreFromDate))
+	pg.birthdayEditor.Editor.SingleLine = true
+	pg.birthdayEditor.TextSize = textSize16
+
+	pg.seedRestorePage = NewSeedRestorePage(l, walletName, walletType, onRestoreComplete, pg.getWordSeedType, pg.restoreBirthday)
 
 	return pg
 }
@@ -92,6 +101,46 @@ func (pg *Restore) getWordSeedType() sharedW.WordSeedType {
 	return GetWordSeedType(pg.seedTypeDropdown.Selected())
 }
 
+// parseRestoreBirthday parses the date entered to restore the wallet from,
+// returning the zero time if none was entered.
+func (pg *Restore) parseRestoreBirthday() (time.Time, error) {
+	text := strings.TrimSpace(pg.birthdayEditor.Editor.Text())
+	if text == "" {
+		return time.Time{}, nil
+	}
+	birthday, err := time.Parse(restoreBirthdayLayout, text)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if birthday.After(time.Now()) {
+		return time.Time{}, fmt.Errorf("%s is in the future", text)
+	}
+	return birthday, nil
+}
+
+// restoreBirthday returns the date entered to restore the wallet from, or
+// the zero time if none was entered. It returns false if the date entered is
+// invalid.
+func (pg *Restore) restoreBirthday() (time.Time, bool) {
+	birthday, err := pg.parseRestoreBirthday()
+	if err != nil {
+		pg.birthdayEditor.SetError(values.String(values.StrInvalidRestoreDate))
+		return time.Time{}, false
+	}
+	return birthday, true
+}
+
+// setRestoreBirthday has a restored wallet skip the blocks mined before
+// birthday when it first syncs.
+func setRestoreBirthday(wallet sharedW.Asset, birthday time.Time) {
+	if birthday.IsZero() {
+		return
+	}
+	if err := wallet.SetRestoreBirthday(birthday); err != nil {
+		log.Errorf("Error setting the birthday of wallet %s: %v", wallet.GetWalletName(), err)
+	}
+}
+
 // OnNavigatedTo is called when the page is about to be displayed and
 // may be used to initialize page features that are only relevant when
 // the page is displayed.
@@ -116,6 +165,7 @@ func (pg *Restore) Layout(gtx C) D {
 			layout.Rigid(func(gtx C) D {
 				return pg.headerLayout(gtx)
 			}),
+			layout.Rigid(pg.birthdayLayout),
 			layout.Rigid(func(gtx C) D {
 				return pg.bodyLayout(gtx)
 			}),
@@ -149,6 +199,28 @@ func (pg *Restore) headerLayout(gtx C) D {
 	)
 }
 
+func (pg *Restore) birthdayLayout(gtx C) D {
+	hint := values.String(values.StrRestoreFromDateDesc)
+	if birthday, err := pg.parseRestoreBirthday(); err == nil && !birthday.IsZero() {
+		height := pg.AssetsManager.BlockHeightForDate(pg.walletType, birthday.Add(-sharedW.RestoreBirthdayMargin))
+		hint = values.StringF(values.StrRestoreFromBlockFmt, height)
+	}
+
+	return layout.Inset{Top: values.MarginPadding16, Bottom: values.MarginPadding8}.Layout(gtx, func(gtx C) D {
+		return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+			layout.Rigid(func(gtx C) D {
+				if !pg.IsMobileView() {
+					gtx.Constraints.Max.X = gtx.Dp(values.MarginPadding350)
+				}
+				return pg.birthdayEditor.Layout(gtx)
+			}),
+			layout.Rigid(func(gtx C) D {
+				return layout.Inset{Top: values.MarginPadding4}.Layout(gtx, pg.Theme.Caption(hint).Layout)
+			}),
+		)
+	})
+}
+
 func (pg *Restore) bodyLayout(gtx C) D {
 	if !pg.IsMobileView() {
 		return pg.tabs.Layout(gtx, func(gtx C) D {
@@ -268,6 +340,10 @@ func (pg *Restore) HandleUserInteractions(gtx C) {
 		pg.tabIndex = pg.tabs.SelectedIndex()
 	}
 
+	if pg.birthdayEditor.Changed() {
+		pg.birthdayEditor.SetError("")
+	}
+
 	if pg.backButton.Button.Clicked(gtx) {
 		pg.ParentNavigator().CloseCurrentPage()
 	}
@@ -330,6 +406,11 @@ func (pg *Restore) restoreFromSeedEditor() {
 	} else {
 		pg.tabIndex = 1
 	}
+	birthday, ok := pg.restoreBirthday()
+	if !ok {
+		pg.restoreInProgress = false
+		return
+	}
 	slideWords := strings.Split(seedOrHex, " ")
 	wordSeedType := pg.getWordSeedType()
 	var err error
@@ -393,6 +474,8 @@ func (pg *Restore) restoreFromSeedEditor() {
 				return false
 			}
 
+			setRestoreBirthday(importedWallet, birthday)
+
 			infoModal := modal.NewSuccessModal(pg.Load, values.String(values.StrWalletRestored), modal.DefaultClickFunc())
 			pg.ParentWindow().ShowModal(infoModal)
 			m.Dismiss()
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"image/color"
 	"strings"
+	"time"
 
 	"gioui.org/font"
 	"gioui.org/io/event"
@@ -77,9 +78,17 @@ type SeedRestore struct {
 
 	walletType      libutils.AssetType
 	getWordSeedType func() sharedW.WordSeedType
+	getBirthday     func() (time.Time, bool)
+
+	// useSeedShares switches to restoring the seed from seed shares, whose
+	// seed type is set once the shares are combined.
+	useSeedShares  *cryptomaterial.Switch
+	shareEditors   []cryptomaterial.Editor
+	addShare       cryptomaterial.Button
+	sharesSeedType sharedW.WordSeedType
 }
 
-func NewSeedRestorePage(l *load.Load, walletName string, walletType libutils.AssetType, onRestoreComplete func(newWallet sharedW.Asset), getWordSeedType func() sharedW.WordSeedType) *SeedRestore {
+func NewSeedRestorePage(l *load.Load, walletName string, walletType libutils.AssetType, onRestoreComplete func(newWallet sharedW.Asset), getWordSeedType func() sharedW.WordSeedType, getBirthday func() (time.Time, bool)) *SeedRestore {
 	pg := &SeedRestore{
 		Load:            l,
 		restoreComplete: onRestoreComplete,
@@ -90,6 +99,7 @@ func NewSeedRestorePage(l *load.Load, walletName string, walletType libutils.Ass
 		walletName:      walletName,
 		walletType:      walletType,
 		getWordSeedType: getWordSeedType,
+		getBirthday:     getBirthday,
 	}
 
 	pg.optionsMenuCard = cryptomaterial.Card{Color: pg.Theme.Color.Surface}
@@ -101,6 +111,13 @@ func NewSeedRestorePage(l *load.Load, walletName string, walletType libutils.Ass
 	pg.resetSeedFields = l.Theme.OutlineButton(values.String(values.StrClearAll))
 	pg.resetSeedFields.Font.Weight = font.Medium
 
+	pg.useSeedShares = l.Theme.Switch()
+	pg.addShare = l.Theme.OutlineButton(values.String(values.StrAddSeedShare))
+	pg.addShare.Font.Weight = font.Medium
+	for i := 0; i < 2; i++ {
+		pg.addShareEditor()
+	}
+
 	for i := 0; i <= defaultNumberOfSeeds; i++ {
 		widgetEditor := new(widget.Editor)
 		widgetEditor.SingleLine, widgetEditor.Submit = true, true
@@ -134,6 +151,20 @@ func (pg *SeedRestore) SetParentNav(window app.WindowNavigator) {
 	pg.window = window
 }
 
+func (pg *SeedRestore) addShareEditor() {
+	editor := pg.Theme.Editor(new(widget.Editor), values.StringF(values.StrSeedShareX, len(pg.shareEditors)+1))
+	editor.Editor.SingleLine = false
+	pg.shareEditors = append(pg.shareEditors, editor)
+}
+
+// wordSeedType returns the type of the seed being restored.
+func (pg *SeedRestore) wordSeedType() sharedW.WordSeedType {
+	if pg.useSeedShares.IsChecked() {
+		return pg.sharesSeedType
+	}
+	return pg.getWordSeedType()
+}
+
 func (pg *SeedRestore) setEditorFocus() {
 	if !pg.IsIOS() {
 		pg.seedEditors.focusIndex = -1
@@ -165,6 +196,9 @@ func (pg *SeedRestore) Layout(gtx C) D {
 	pg.seedEditorsMobileHandle(gtx)
 	pg.resetSeedFields.SetEnabled(pg.updateSeedResetBtn())
 	seedValid, _ := pg.validateSeeds()
+	if pg.useSeedShares.IsChecked() {
+		seedValid = len(pg.seedShares()) >= 2
+	}
 	pg.validateSeed.SetEnabled(seedValid)
 
 	return body
@@ -174,6 +208,30 @@ func (pg *SeedRestore) restore(gtx C) D {
 	return pg.Theme.List(pg.scrollContainer).Layout(gtx, 1, func(gtx C, _ int) D {
 		return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
 			layout.Rigid(func(gtx C) D {
+				return layout.Inset{Bottom: values.MarginPadding10}.Layout(gtx, func(gtx C) D {
+					return layout.Flex{Alignment: layout.Middle}.Layout(gtx,
+						layout.Rigid(func(gtx C) D {
+							return layout.Inset{Right: values.MarginPadding10}.Layout(gtx, pg.useSeedShares.Layout)
+						}),
+						layout.Rigid(pg.Theme.Body1(values.String(values.StrRestoreFromSeedShares)).Layout),
+					)
+				})
+			}),
+			layout.Rigid(func(gtx C) D {
+				if pg.useSeedShares.IsChecked() {
+					return cryptomaterial.LinearLayout{
+						Orientation: layout.Vertical,
+						Width:       cryptomaterial.MatchParent,
+						Height:      cryptomaterial.WrapContent,
+						Background:  pg.Theme.Color.Surface,
+						Border:      cryptomaterial.Border{Radius: cryptomaterial.Radius(14)},
+						Padding:     layout.UniformInset(values.MarginPadding15),
+					}.Layout(gtx,
+						layout.Rigid(pg.seedSharesView),
+						layout.Rigid(layout.Spacer{Height: values.MarginPadding5}.Layout),
+						layout.Rigid(pg.addShare.Layout),
+					)
+				}
 				return cryptomaterial.LinearLayout{
 					Orientation: layout.Vertical,
 					Width:       cryptomaterial.MatchParent,
@@ -194,6 +252,28 @@ func (pg *SeedRestore) restore(gtx C) D {
 	})
 }
 
+func (pg *SeedRestore) seedSharesView(gtx C) D {
+	children := make([]layout.FlexChild, 0, len(pg.shareEditors))
+	for i := range pg.shareEditors {
+		editor := pg.shareEditors[i]
+		children = append(children, layout.Rigid(func(gtx C) D {
+			return layout.Inset{Bottom: values.MarginPadding10}.Layout(gtx, editor.Layout)
+		}))
+	}
+	return layout.Flex{Axis: layout.Vertical}.Layout(gtx, children...)
+}
+
+// seedShares returns the seed shares entered.
+func (pg *SeedRestore) seedShares() []string {
+	var shares []string
+	for _, editor := range pg.shareEditors {
+		if share := strings.TrimSpace(editor.Editor.Text()); share != "" {
+			shares = append(shares, share)
+		}
+	}
+	return shares
+}
+
 func (pg *SeedRestore) restoreButtonSection(gtx C) D {
 	card := pg.Theme.Card()
 	card.Radius = cryptomaterial.Radius(0)
@@ -437,9 +517,19 @@ func (pg *SeedRestore) validateSeeds() (bool, string) {
 
 func (pg *SeedRestore) verifySeeds() bool {
 	isValid, seedphrase := pg.validateSeeds()
+	if pg.useSeedShares.IsChecked() {
+		var err error
+		seedphrase, pg.sharesSeedType, err = sharedW.CombineSeedShares(pg.seedShares())
+		if err != nil {
+			errModal := modal.NewErrorModal(pg.Load, err.Error(), modal.DefaultClickFunc())
+			pg.window.ShowModal(errModal)
+			return false
+		}
+		isValid = true
+	}
 	if isValid {
 		pg.seedPhrase = seedphrase
-		if !sharedW.VerifyMnemonic(pg.seedPhrase, pg.walletType, pg.getWordSeedType()) {
+		if !sharedW.VerifyMnemonic(pg.seedPhrase, pg.walletType, pg.wordSeedType()) {
 			errModal := modal.NewErrorModal(pg.Load, values.String(values.StrInvalidSeedPhrase), modal.DefaultClickFunc())
 			pg.window.ShowModal(errModal)
 			return false
@@ -448,7 +538,7 @@ func (pg *SeedRestore) verifySeeds() bool {
 
 	// Compare seed with existing wallets seed. On positive match abort import
 	// to prevent duplicate wallet. walletWithSameSeed >= 0 if there is a match.
-	walletWithSameSeed, err := pg.AssetsManager.WalletWithSeed(pg.walletType, pg.seedPhrase, pg.getWordSeedType())
+	walletWithSameSeed, err := pg.AssetsManager.WalletWithSeed(pg.walletType, pg.seedPhrase, pg.wordSeedType())
 	if err != nil {
 		log.Error(err)
 		return false
@@ -468,6 +558,9 @@ func (pg *SeedRestore) resetSeeds() {
 	for i := 0; i < len(pg.seedEditors.editors); i++ {
 		pg.seedEditors.editors[i].Edit.Editor.SetText("")
 	}
+	for _, editor := range pg.shareEditors {
+		editor.Editor.SetText("")
+	}
 }
 
 // switchSeedEditors sets focus on the next seed phrase after moving the
@@ -513,6 +606,10 @@ func (pg *SeedRestore) HandleUserInteractions(gtx C) {
 		if !pg.verifySeeds() {
 			return
 		}
+		birthday, ok := pg.getBirthday()
+		if !ok {
+			return
+		}
 
 		pg.isRestoring = true
 		walletPasswordModal := modal.NewCreatePasswordModal(pg.Load).
@@ -521,7 +618,7 @@ func (pg *SeedRestore) HandleUserInteractions(gtx C) {
 			ShowWalletInfoTip(true).
 			SetParent(pg).
 			SetPositiveButtonCallback(func(_, password string, m *modal.CreatePasswordModal) bool {
-				importedWallet, err := pg.AssetsManager.RestoreWallet(pg.walletType, pg.walletName, pg.seedPhrase, password, sharedW.PassphraseTypePass, pg.getWordSeedType())
+				importedWallet, err := pg.AssetsManager.RestoreWallet(pg.walletType, pg.walletName, pg.seedPhrase, password, sharedW.PassphraseTypePass, pg.wordSeedType())
 				if err != nil {
 					errString := err.Error()
 					if err.Error() == libutils.ErrExist {
@@ -532,6 +629,8 @@ func (pg *SeedRestore) HandleUserInteractions(gtx C) {
 					return false
 				}
 
+				setRestoreBirthday(importedWallet, birthday)
+
 				infoModal := modal.NewSuccessModal(pg.Load, values.String(values.StrWalletRestored), modal.DefaultClickFunc())
 				pg.window.ShowModal(infoModal)
 				pg.resetSeeds()
@@ -549,6 +648,10 @@ func (pg *SeedRestore) HandleUserInteractions(gtx C) {
 		pg.resetSeeds()
 	}
 
+	if pg.addShare.Clicked(gtx) && len(pg.shareEditors) < sharedW.MaxSeedShares {
+		pg.addShareEditor()
+	}
+
 	pg.editorSeedsEventsHandler(gtx)
 	pg.onSuggestionSeedsClicked(gtx)
 	pg.suggestionSeedEffect()
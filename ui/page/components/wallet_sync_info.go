@@ -530,10 +530,11 @@ func (wsi *WalletSyncInfo) progressStatusDetails() (progress int, timeLeft strin
 		}
 		progress = int(sp.RescanProgress)
 		timeLeft = sp.RescanTimeRemaining.String()
-	} else {
-		sp := wsi.FetchSyncProgress()
-		progress = sp.SyncProgress()
-		timeLeft = sp.RemainingSyncTime()
+	} else if sp := wsi.AssetsManager.WalletSyncProgress(wsi.wallet.GetWalletID()); sp != nil {
+		// The progress of the assets manager weights the sync stages the
+		// same way for all the wallets of an asset.
+		progress = int(sp.Percent)
+		timeLeft = pageutils.TimeFormat(int(sp.TimeRemaining.Seconds()), true)
 	}
 	if wsi.wallet.IsSyncing() || wsi.wallet.IsRescanning() {
 		timeLeft = values.StringF(values.StrTimeLeftFmt, timeLeft)
@@ -699,7 +700,7 @@ func (wsi *WalletSyncInfo) HandleUserInteractions(gtx C) {
 	wsi.syncSwitch.SetEnabled(!isSyncShutting)
 	if wsi.syncSwitch.Changed(gtx) {
 		if wsi.wallet.IsRescanning() {
-			wsi.wallet.CancelRescan()
+			wsi.wallet.DiscardRescan()
 		}
 
 		// Toggling switch states is handled in the layout() method.
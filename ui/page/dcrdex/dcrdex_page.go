@@ -97,7 +97,7 @@ func (pg *DEXPage) prepareInitialPage() {
 	<-dexClient.Ready()
 
 	showOnBoardingPage := true
-	if len(dexClient.Exchanges()) != 0 { // has at least one exchange
+	if len(orderedExchanges(pg.AssetsManager, true)) != 0 { // has at least one exchange
 		_, _, pendingBond := pendingBondConfirmation(pg.AssetsManager, "")
 		showOnBoardingPage = pendingBond != nil
 	}
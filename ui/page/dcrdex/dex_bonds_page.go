@@ -0,0 +1,485 @@
+package dcrdex
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"decred.org/dcrdex/client/asset"
+	"decred.org/dcrdex/client/core"
+	"decred.org/dcrdex/dex"
+	"gioui.org/font"
+	"gioui.org/layout"
+	"gioui.org/widget"
+	"gioui.org/widget/material"
+
+	"github.com/crypto-power/cryptopower/app"
+	"github.com/crypto-power/cryptopower/dexc"
+	"github.com/crypto-power/cryptopower/ui/cryptomaterial"
+	"github.com/crypto-power/cryptopower/ui/load"
+	"github.com/crypto-power/cryptopower/ui/modal"
+	"github.com/crypto-power/cryptopower/ui/page/components"
+	"github.com/crypto-power/cryptopower/ui/values"
+)
+
+const DEXBondsPageID = "dex_bonds"
+
+// DEXBondsPage displays the bonds and tier of a DEX account and allows the user
+// to change the bond maintenance settings used by the DEX client to renew
+// bonds.
+type DEXBondsPage struct {
+	*load.Load
+	// GenericPageModal defines methods such as ID() and OnAttachedToNavigator()
+	// that helps this Page satisfy the app.Page interface. It also defines
+	// helper methods for accessing the PageNavigator that displayed this page
+	// and the root WindowNavigator.
+	*app.GenericPageModal
+
+	host  string
+	xc    *core.Exchange
+	bonds []*dexc.BondInfo
+
+	scrollContainer *widget.List
+	backButton      cryptomaterial.IconButton
+
+	autoRenewSwitch    *cryptomaterial.Switch
+	bondAssetSelector  *cryptomaterial.DropDown
+	targetTierEditor   cryptomaterial.Editor
+	maxBondedAmtEditor cryptomaterial.Editor
+	penaltyCompsEditor cryptomaterial.Editor
+	saveBtn            cryptomaterial.Button
+
+	viewBondsBtn cryptomaterial.Button
+	postBondBtn  cryptomaterial.Button
+
+	materialLoader material.LoaderStyle
+	isLoading      bool
+
+	// updatedXC receives the exchange reloaded after the bond options are
+	// saved. The form is reset from the UI goroutine.
+	updatedXC chan *core.Exchange
+}
+
+// NewDEXBondsPage creates a page that displays the bonds posted to the DEX
+// server at host.
+func NewDEXBondsPage(l *load.Load, host string) *DEXBondsPage {
+	th := l.Theme
+	pg := &DEXBondsPage{
+		Load:               l,
+		GenericPageModal:   app.NewGenericPageModal(DEXBondsPageID),
+		host:               host,
+		scrollContainer:    &widget.List{List: layout.List{Axis: vertical}},
+		backButton:         components.GetBackButton(l),
+		autoRenewSwitch:    th.Switch(),
+		targetTierEditor:   newTextEditor(th, values.String(values.StrTargetTier), "1", false),
+		maxBondedAmtEditor: newTextEditor(th, values.String(values.StrMaxBondedAmount), values.String(values.StrMaxBondedAmountHint), false),
+		penaltyCompsEditor: newTextEditor(th, values.String(values.StrPenaltyComps), "0", false),
+		saveBtn:            th.Button(values.String(values.StrSave)),
+		viewBondsBtn:       th.Button(values.String(values.StrViewBonds)),
+		postBondBtn:        th.OutlineButton(values.String(values.StrPostBond)),
+		materialLoader:     material.Loader(th.Base),
+		updatedXC:          make(chan *core.Exchange, 1),
+	}
+
+	pg.saveBtn.Font.Weight = font.SemiBold
+	return pg
+}
+
+// OnNavigatedTo is called when the page is about to be displayed and
+// may be used to initialize page features that are only relevant when
+// the page is displayed.
+// Part of the load.Page interface.
+func (pg *DEXBondsPage) OnNavigatedTo() {
+	if !pg.AssetsManager.DEXCInitialized() {
+		return
+	}
+
+	xc, err := pg.AssetsManager.DexClient().Exchange(pg.host)
+	if err != nil {
+		pg.notifyError(err.Error())
+		return
+	}
+
+	pg.xc = xc
+	pg.resetBondOptionsForm()
+}
+
+// resetBondOptionsForm sets the bond settings form fields to the current bond
+// options of the DEX account.
+func (pg *DEXBondsPage) resetBondOptionsForm() {
+	xc := pg.xc
+	var bondAssets []cryptomaterial.DropDownItem
+	var selectedBondAsset *cryptomaterial.DropDownItem
+	for symbol, bondAsset := range xc.BondAssets {
+		assetType := convertAssetIDToAssetType(bondAsset.ID)
+		if assetType == assetTypeNoAsset {
+			continue
+		}
+
+		item := cryptomaterial.DropDownItem{Text: strings.ToUpper(symbol)}
+		if bondAsset.ID == xc.Auth.BondAssetID {
+			selectedBondAsset = &item
+		}
+		bondAssets = append(bondAssets, item)
+	}
+	pg.bondAssetSelector = pg.Theme.NewCommonDropDown(bondAssets, selectedBondAsset, values.MarginPadding120, values.DEXBondAssetGroup, false)
+
+	pg.autoRenewSwitch.SetChecked(xc.Auth.TargetTier > 0)
+	targetTier := xc.Auth.TargetTier
+	if targetTier == 0 {
+		targetTier = minimumBondStrength
+	}
+	pg.targetTierEditor.Editor.SetText(fmt.Sprintf("%d", targetTier))
+	pg.penaltyCompsEditor.Editor.SetText(fmt.Sprintf("%d", xc.Auth.PenaltyComps))
+
+	pg.maxBondedAmtEditor.Editor.SetText("")
+	if xc.Auth.MaxBondedAmt > 0 {
+		pg.maxBondedAmtEditor.Editor.SetText(trimmedConventionalAmtString(pg.conventionalBondAmt(xc.Auth.BondAssetID, xc.Auth.MaxBondedAmt)))
+	}
+}
+
+// conventionalBondAmt converts the atomic bond amount of assetID to its
+// conventional unit.
+func (pg *DEXBondsPage) conventionalBondAmt(assetID uint32, amt uint64) float64 {
+	return float64(amt) / float64(pg.bondAssetUnitInfo(assetID).Conventional.ConversionFactor)
+}
+
+func (pg *DEXBondsPage) bondAssetUnitInfo(assetID uint32) dex.UnitInfo {
+	unitInfo, err := asset.UnitInfo(assetID)
+	if err == nil {
+		return unitInfo
+	}
+	return defaultUnitInfo(unbip(assetID))
+}
+
+// selectedBondAsset returns the bond asset selected in the bond settings form.
+func (pg *DEXBondsPage) selectedBondAsset() *core.BondAsset {
+	return pg.xc.BondAssets[strings.ToLower(pg.bondAssetSelector.Selected())]
+}
+
+// Layout draws the page UI components into the provided C
+// to be eventually drawn on screen.
+// Part of the load.Page interface.
+func (pg *DEXBondsPage) Layout(gtx C) D {
+	if !pg.AssetsManager.DEXCInitialized() {
+		pg.ParentNavigator().CloseCurrentPage()
+		return D{}
+	}
+
+	sp := components.SubPage{
+		Load:       pg.Load,
+		Title:      values.StringF(values.StrBondsTitle, pg.host),
+		BackButton: pg.backButton,
+		Back: func() {
+			pg.ParentNavigator().CloseCurrentPage()
+		},
+		Body: func(gtx C) D {
+			if pg.xc == nil {
+				return D{}
+			}
+
+			return pg.Theme.List(pg.scrollContainer).Layout(gtx, 1, func(gtx C, _ int) D {
+				return layout.Flex{Axis: vertical}.Layout(gtx,
+					layout.Rigid(pg.tierSummary),
+					layout.Rigid(pg.bondSettings),
+					layout.Rigid(pg.activeBonds),
+				)
+			})
+		},
+	}
+	return sp.Layout(pg.ParentWindow(), gtx)
+}
+
+func (pg *DEXBondsPage) sectionLayout(gtx C, title string, body layout.Widget) D {
+	return cryptomaterial.LinearLayout{
+		Width:       cryptomaterial.MatchParent,
+		Height:      cryptomaterial.WrapContent,
+		Background:  pg.Theme.Color.Surface,
+		Margin:      layout.Inset{Top: dp5, Bottom: dp5},
+		Padding:     layout.UniformInset(dp16),
+		Orientation: vertical,
+		Border: cryptomaterial.Border{
+			Radius: cryptomaterial.Radius(8),
+		},
+	}.Layout(gtx,
+		layout.Rigid(func(gtx C) D {
+			lb := pg.Theme.Label(values.TextSize16, title)
+			lb.Font.Weight = font.SemiBold
+			return layout.Inset{Bottom: dp10}.Layout(gtx, lb.Layout)
+		}),
+		layout.Rigid(body),
+	)
+}
+
+func (pg *DEXBondsPage) infoRow(label, value string) layout.FlexChild {
+	return layout.Rigid(func(gtx C) D {
+		return layout.Inset{Top: dp5, Bottom: dp5}.Layout(gtx, func(gtx C) D {
+			lb := pg.Theme.Body2(label)
+			lb.Color = pg.Theme.Color.GrayText2
+			return components.EndToEndRow(gtx, lb.Layout, pg.Theme.Body2(value).Layout)
+		})
+	})
+}
+
+func (pg *DEXBondsPage) tierSummary(gtx C) D {
+	auth := pg.xc.Auth
+	return pg.sectionLayout(gtx, values.String(values.StrTierSummary), func(gtx C) D {
+		return layout.Flex{Axis: vertical}.Layout(gtx,
+			pg.infoRow(values.String(values.StrTargetTier), fmt.Sprintf("%d", auth.TargetTier)),
+			pg.infoRow(values.String(values.StrEffectiveTier), fmt.Sprintf("%d", auth.EffectiveTier)),
+			pg.infoRow(values.String(values.StrBondedTier), fmt.Sprintf("%d", auth.Rep.BondedTier)),
+			pg.infoRow(values.String(values.StrPendingTiers), fmt.Sprintf("%d", auth.PendingStrength)),
+			pg.infoRow(values.String(values.StrExpiringTiers), fmt.Sprintf("%d", auth.WeakStrength)),
+			pg.infoRow(values.String(values.StrPenalties), fmt.Sprintf("%d", auth.Rep.Penalties)),
+			pg.infoRow(values.String(values.StrScore), fmt.Sprintf("%d / %d", auth.Rep.Score, pg.xc.MaxScore)),
+			layout.Rigid(func(gtx C) D {
+				if auth.Rep.Penalties == 0 {
+					return D{}
+				}
+
+				lb := pg.Theme.Body2(values.StringF(values.StrPenaltyInfo, pg.xc.PenaltyThreshold, auth.Rep.Penalties))
+				lb.Color = pg.Theme.Color.Danger
+				return layout.Inset{Top: dp10}.Layout(gtx, lb.Layout)
+			}),
+		)
+	})
+}
+
+func (pg *DEXBondsPage) bondSettings(gtx C) D {
+	return pg.sectionLayout(gtx, values.String(values.StrBondSettings), func(gtx C) D {
+		return layout.Flex{Axis: vertical}.Layout(gtx,
+			layout.Rigid(func(gtx C) D {
+				return layout.Flex{Axis: horizontal, Alignment: layout.Middle}.Layout(gtx,
+					layout.Flexed(1, func(gtx C) D {
+						return layout.Flex{Axis: vertical}.Layout(gtx,
+							layout.Rigid(pg.Theme.Body1(values.String(values.StrAutoRenewBonds)).Layout),
+							layout.Rigid(func(gtx C) D {
+								lb := pg.Theme.Caption(values.String(values.StrAutoRenewBondsDesc))
+								lb.Color = pg.Theme.Color.GrayText2
+								return lb.Layout(gtx)
+							}),
+						)
+					}),
+					layout.Rigid(pg.autoRenewSwitch.Layout),
+				)
+			}),
+			layout.Rigid(func(gtx C) D {
+				return layout.Inset{Top: dp16}.Layout(gtx, func(gtx C) D {
+					return layout.Flex{Axis: horizontal, Alignment: layout.Middle}.Layout(gtx,
+						layout.Flexed(1, pg.Theme.Body1(values.String(values.StrBondAsset)).Layout),
+						layout.Rigid(pg.bondAssetSelector.Layout),
+					)
+				})
+			}),
+			layout.Rigid(func(gtx C) D {
+				return layout.Inset{Top: dp16}.Layout(gtx, pg.targetTierEditor.Layout)
+			}),
+			layout.Rigid(func(gtx C) D {
+				pg.maxBondedAmtEditor.ExtraText = pg.bondAssetSelector.Selected()
+				return layout.Inset{Top: dp16}.Layout(gtx, pg.maxBondedAmtEditor.Layout)
+			}),
+			layout.Rigid(func(gtx C) D {
+				return layout.Inset{Top: dp16}.Layout(gtx, pg.penaltyCompsEditor.Layout)
+			}),
+			layout.Rigid(func(gtx C) D {
+				return layout.Inset{Top: dp16}.Layout(gtx, func(gtx C) D {
+					return layout.E.Layout(gtx, func(gtx C) D {
+						if pg.isLoading {
+							return pg.materialLoader.Layout(gtx)
+						}
+						return layout.Flex{Axis: horizontal}.Layout(gtx,
+							layout.Rigid(func(gtx C) D {
+								if pg.xc.Auth.TargetTier > 0 {
+									return D{} // bonds are posted automatically
+								}
+								return layout.Inset{Right: dp10}.Layout(gtx, pg.postBondBtn.Layout)
+							}),
+							layout.Rigid(pg.saveBtn.Layout),
+						)
+					})
+				})
+			}),
+		)
+	})
+}
+
+func (pg *DEXBondsPage) activeBonds(gtx C) D {
+	return pg.sectionLayout(gtx, values.String(values.StrActiveBonds), func(gtx C) D {
+		if pg.bonds == nil {
+			return layout.Flex{Axis: vertical, Alignment: layout.Middle}.Layout(gtx,
+				layout.Rigid(pg.Theme.Body2(values.String(values.StrViewBondsDesc)).Layout),
+				layout.Rigid(func(gtx C) D {
+					return layout.Inset{Top: dp10}.Layout(gtx, pg.viewBondsBtn.Layout)
+				}),
+			)
+		}
+
+		if len(pg.bonds) == 0 {
+			return layout.Center.Layout(gtx, pg.Theme.Body2(values.String(values.StrNoActiveBonds)).Layout)
+		}
+
+		headers := []string{values.String(values.StrAmount), values.String(values.StrStrength), values.String(values.StrStatus), values.String(values.StrExpires)}
+		rows := []layout.FlexChild{pg.bondRow(true, headers...)}
+		for _, bond := range pg.bonds {
+			amt := fmt.Sprintf("%s %s", trimmedConventionalAmtString(pg.conventionalBondAmt(bond.AssetID, bond.Amount)), strings.ToUpper(unbip(bond.AssetID)))
+			expires := bond.ExpiresAt.Format(time.DateTime)
+			rows = append(rows, pg.bondRow(false, amt, fmt.Sprintf("%d", bond.Strength), bond.Status.String(), expires))
+		}
+		return layout.Flex{Axis: vertical}.Layout(gtx, rows...)
+	})
+}
+
+func (pg *DEXBondsPage) bondRow(header bool, columns ...string) layout.FlexChild {
+	return layout.Rigid(func(gtx C) D {
+		var children []layout.FlexChild
+		for _, col := range columns {
+			lb := pg.Theme.Body2(col)
+			if header {
+				lb = semiBoldGray3Size14(pg.Theme, col)
+			}
+			children = append(children, layout.Flexed(1.0/float32(len(columns)), lb.Layout))
+		}
+		return layout.Inset{Top: dp8, Bottom: dp8}.Layout(gtx, func(gtx C) D {
+			return layout.Flex{Axis: horizontal}.Layout(gtx, children...)
+		})
+	})
+}
+
+// HandleUserInteractions is called just before Layout() to determine
+// if any user interaction recently occurred on the page and may be
+// used to update the page's UI components shortly before they are
+// displayed.
+// Part of the load.Page interface.
+func (pg *DEXBondsPage) HandleUserInteractions(gtx C) {
+	select {
+	case xc := <-pg.updatedXC:
+		pg.xc = xc
+		pg.resetBondOptionsForm()
+	default:
+	}
+
+	if pg.xc == nil {
+		return
+	}
+
+	if pg.autoRenewSwitch.Changed(gtx) && !pg.autoRenewSwitch.IsChecked() {
+		pg.targetTierEditor.ClearError()
+	}
+
+	if pg.saveBtn.Clicked(gtx) {
+		if form := pg.validatedBondOptionsForm(); form != nil {
+			pg.updateBondOptions(form)
+		}
+	}
+
+	if pg.viewBondsBtn.Clicked(gtx) {
+		pg.ParentWindow().ShowModal(dexLoginModal(pg.Load, pg.AssetsManager.DexClient(), func(password string) {
+			pg.fetchBonds(password)
+		}))
+	}
+
+	if pg.postBondBtn.Clicked(gtx) {
+		pg.ParentNavigator().ClearStackAndDisplay(NewDEXOnboarding(pg.Load, pg.host, nil))
+	}
+}
+
+// validatedBondOptionsForm returns a *core.BondOptionsForm if the values
+// entered in the bond settings form are valid.
+func (pg *DEXBondsPage) validatedBondOptionsForm() *core.BondOptionsForm {
+	bondAsset := pg.selectedBondAsset()
+	if bondAsset == nil {
+		return nil
+	}
+
+	var targetTier uint64
+	if pg.autoRenewSwitch.IsChecked() {
+		tier, err := strconv.ParseUint(strings.TrimSpace(pg.targetTierEditor.Editor.Text()), 10, 64)
+		if err != nil || tier < minimumBondStrength {
+			pg.targetTierEditor.SetError(values.String(values.StrInvalidTargetTier))
+			return nil
+		}
+		targetTier = tier
+	}
+	pg.targetTierEditor.ClearError()
+
+	var maxBondedAmt uint64
+	if amtStr := strings.TrimSpace(pg.maxBondedAmtEditor.Editor.Text()); amtStr != "" {
+		amt, err := strconv.ParseFloat(amtStr, 64)
+		if err != nil || amt < 0 {
+			pg.maxBondedAmtEditor.SetError(values.String(values.StrInvalidAmount))
+			return nil
+		}
+		maxBondedAmt = uint64(amt * float64(pg.bondAssetUnitInfo(bondAsset.ID).Conventional.ConversionFactor))
+	}
+	pg.maxBondedAmtEditor.ClearError()
+
+	penaltyComps, err := strconv.ParseUint(strings.TrimSpace(pg.penaltyCompsEditor.Editor.Text()), 10, 16)
+	if err != nil || penaltyComps > dexc.MaxPenaltyComps {
+		pg.penaltyCompsEditor.SetError(values.StringF(values.StrInvalidPenaltyComps, dexc.MaxPenaltyComps))
+		return nil
+	}
+	pg.penaltyCompsEditor.ClearError()
+
+	pc := uint16(penaltyComps)
+	return &core.BondOptionsForm{
+		Host:         pg.host,
+		TargetTier:   &targetTier,
+		MaxBondedAmt: &maxBondedAmt,
+		PenaltyComps: &pc,
+		BondAssetID:  &bondAsset.ID,
+	}
+}
+
+func (pg *DEXBondsPage) updateBondOptions(form *core.BondOptionsForm) {
+	pg.isLoading = true
+	go func() {
+		defer func() {
+			pg.isLoading = false
+			pg.ParentWindow().Reload()
+		}()
+
+		dexClient := pg.AssetsManager.DexClient()
+		if err := dexClient.UpdateBondOptions(form); err != nil {
+			pg.notifyError(err.Error())
+			return
+		}
+
+		if xc, err := dexClient.Exchange(pg.host); err == nil {
+			pg.updatedXC <- xc
+		}
+		pg.Toast.Notify(values.String(values.StrBondOptionsUpdated))
+	}()
+}
+
+// fetchBonds retrieves the bonds for the DEX account. The DEX password is
+// required to read the bonds.
+func (pg *DEXBondsPage) fetchBonds(password string) {
+	bonds, err := pg.AssetsManager.DexClient().Bonds([]byte(password), pg.host)
+	if err != nil {
+		pg.notifyError(err.Error())
+		return
+	}
+
+	if bonds == nil {
+		bonds = []*dexc.BondInfo{}
+	}
+	pg.bonds = bonds
+	pg.ParentWindow().Reload()
+}
+
+func (pg *DEXBondsPage) notifyError(errMsg string) {
+	errModal := modal.NewErrorModal(pg.Load, errMsg, modal.DefaultClickFunc())
+	pg.ParentWindow().ShowModal(errModal)
+}
+
+// OnNavigatedFrom is called when the page is about to be removed from
+// the displayed window. This method should ideally be used to disable
+// features that are irrelevant when the page is NOT displayed.
+// NOTE: The page may be re-displayed on the app's window, in which case
+// OnNavigatedTo() will be called again. This method should not destroy UI
+// components unless they'll be recreated in the OnNavigatedTo() method.
+// Part of the load.Page interface.
+func (pg *DEXBondsPage) OnNavigatedFrom() {
+	pg.bonds = nil // require the password again to view bonds
+}
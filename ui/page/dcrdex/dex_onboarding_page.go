@@ -587,9 +587,10 @@ func (pg *DEXOnboarding) formFooterButtons(gtx C) D {
 }
 
 // dexServerWithEffectTier returns any dex server that has an effective tier.
+// Servers removed by the user are skipped.
 func (pg *DEXOnboarding) dexServerWithEffectTier() string {
 	for _, xc := range pg.AssetsManager.DexClient().Exchanges() {
-		if xc.Auth.EffectiveTier > 0 {
+		if xc.Auth.EffectiveTier > 0 && !pg.AssetsManager.IsDEXServerRemoved(xc.Host) {
 			return xc.Host
 		}
 	}
@@ -1236,7 +1237,7 @@ func (pg *DEXOnboarding) postBond() {
 
 	if pg.existingDEXServer != "" {
 		// These fields(MaintainTier and MaxBondedAmt) can only be set for the
-		// first time. TODO: Use UpdateBondOptions when its design is ready.
+		// first time. The bond options are updated after the bond is posted.
 		postBond.MaintainTier = nil
 	}
 
@@ -1271,6 +1272,23 @@ func (pg *DEXOnboarding) postBond() {
 			bondCoinID:       res.BondID,
 		}
 
+		if pg.existingDEXServer != "" {
+			// Maintain the new tier just like first-time bonds are, but never
+			// lower a target tier that is already higher.
+			targetTier := uint64(pg.newTier)
+			if xc, err := dexClient.Exchange(pg.bondServer.url); err == nil {
+				targetTier = max(targetTier, xc.Auth.TargetTier)
+			}
+			err = dexClient.UpdateBondOptions(&core.BondOptionsForm{
+				Host:        pg.bondServer.url,
+				TargetTier:  &targetTier,
+				BondAssetID: &bondAsset.ID,
+			})
+			if err != nil {
+				log.Errorf("Error updating bond options: %v", err)
+			}
+		}
+
 		pg.waitForConfirmationAndListenForBlockNotifications()
 		pg.ParentWindow().Reload()
 	}
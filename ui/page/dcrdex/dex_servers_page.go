@@ -0,0 +1,616 @@
+package dcrdex
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+
+	"decred.org/dcrdex/client/comms"
+	"decred.org/dcrdex/client/core"
+	"gioui.org/font"
+	"gioui.org/layout"
+	"gioui.org/widget"
+	"gioui.org/widget/material"
+
+	"github.com/crypto-power/cryptopower/app"
+	"github.com/crypto-power/cryptopower/libwallet"
+	"github.com/crypto-power/cryptopower/ui/cryptomaterial"
+	"github.com/crypto-power/cryptopower/ui/load"
+	"github.com/crypto-power/cryptopower/ui/modal"
+	"github.com/crypto-power/cryptopower/ui/page/components"
+	"github.com/crypto-power/cryptopower/ui/values"
+)
+
+const DEXServersPageID = "dex_servers"
+
+// errInvalidCert is returned when a certificate input is neither PEM encoded
+// nor a path to a readable certificate file.
+var errInvalidCert = errors.New("invalid certificate")
+
+// serverRowWidgets are the widgets used to manage a single DEX server.
+type serverRowWidgets struct {
+	moveUpBtn     cryptomaterial.IconButton
+	moveDownBtn   cryptomaterial.IconButton
+	bondsBtn      cryptomaterial.Button
+	updateCertBtn cryptomaterial.Button
+	toggleBtn     cryptomaterial.Button
+	removeBtn     cryptomaterial.Button
+}
+
+// DEXServersPage lists the DEX servers known to the DEX client and allows the
+// user to add new servers, disable, re-enable or remove servers and change the
+// order in which servers are displayed.
+type DEXServersPage struct {
+	*load.Load
+	// GenericPageModal defines methods such as ID() and OnAttachedToNavigator()
+	// that helps this Page satisfy the app.Page interface. It also defines
+	// helper methods for accessing the PageNavigator that displayed this page
+	// and the root WindowNavigator.
+	*app.GenericPageModal
+
+	exchanges  []*core.Exchange
+	rowWidgets map[string]*serverRowWidgets
+
+	scrollContainer *widget.List
+	backButton      cryptomaterial.IconButton
+
+	serverURLEditor  cryptomaterial.Editor
+	serverCertEditor cryptomaterial.Editor
+	addServerBtn     cryptomaterial.Button
+
+	materialLoader material.LoaderStyle
+	isLoading      bool
+}
+
+// NewDEXServersPage creates a page for managing DEX servers.
+func NewDEXServersPage(l *load.Load) *DEXServersPage {
+	th := l.Theme
+	pg := &DEXServersPage{
+		Load:             l,
+		GenericPageModal: app.NewGenericPageModal(DEXServersPageID),
+		rowWidgets:       make(map[string]*serverRowWidgets),
+		scrollContainer:  &widget.List{List: layout.List{Axis: vertical}},
+		backButton:       components.GetBackButton(l),
+		serverURLEditor:  newTextEditor(th, values.String(values.StrServerAddress), "dex-server.com:7232", false),
+		serverCertEditor: newTextEditor(th, values.String(values.StrCertificateOPtional), values.String(values.StrServerCertHint), true),
+		addServerBtn:     th.Button(values.String(values.StrAddServer)),
+		materialLoader:   material.Loader(th.Base),
+	}
+
+	pg.addServerBtn.Font.Weight = font.SemiBold
+	return pg
+}
+
+// OnNavigatedTo is called when the page is about to be displayed and
+// may be used to initialize page features that are only relevant when
+// the page is displayed.
+// Part of the load.Page interface.
+func (pg *DEXServersPage) OnNavigatedTo() {
+	if !pg.AssetsManager.DEXCInitialized() {
+		return
+	}
+	pg.refreshExchanges()
+}
+
+// refreshExchanges reloads the DEX servers from the DEX client.
+func (pg *DEXServersPage) refreshExchanges() {
+	pg.exchanges = orderedExchanges(pg.AssetsManager, true)
+	th := pg.Theme
+	for _, xc := range pg.exchanges {
+		if _, ok := pg.rowWidgets[xc.Host]; ok {
+			continue
+		}
+
+		w := &serverRowWidgets{
+			moveUpBtn:     th.IconButton(th.Icons.ArrowDropUp),
+			moveDownBtn:   th.IconButton(th.Icons.ArrowDropDown),
+			bondsBtn:      th.OutlineButton(values.String(values.StrManageBonds)),
+			updateCertBtn: th.OutlineButton(values.String(values.StrUpdateCert)),
+			toggleBtn:     th.OutlineButton(""),
+			removeBtn:     th.DangerButton(values.String(values.StrRemove)),
+		}
+		w.moveUpBtn.Inset = layout.UniformInset(dp2)
+		w.moveDownBtn.Inset = layout.UniformInset(dp2)
+		pg.rowWidgets[xc.Host] = w
+	}
+	pg.ParentWindow().Reload()
+}
+
+// Layout draws the page UI components into the provided C
+// to be eventually drawn on screen.
+// Part of the load.Page interface.
+func (pg *DEXServersPage) Layout(gtx C) D {
+	if !pg.AssetsManager.DEXCInitialized() {
+		pg.ParentNavigator().CloseCurrentPage()
+		return D{}
+	}
+
+	sp := components.SubPage{
+		Load:       pg.Load,
+		Title:      values.String(values.StrDEXServers),
+		BackButton: pg.backButton,
+		Back: func() {
+			pg.ParentNavigator().CloseCurrentPage()
+		},
+		Body: func(gtx C) D {
+			return pg.Theme.List(pg.scrollContainer).Layout(gtx, 1, func(gtx C, _ int) D {
+				return layout.Flex{Axis: vertical}.Layout(gtx,
+					layout.Rigid(pg.serverList),
+					layout.Rigid(pg.addServerForm),
+				)
+			})
+		},
+	}
+	return sp.Layout(pg.ParentWindow(), gtx)
+}
+
+func (pg *DEXServersPage) sectionLayout(gtx C, title string, body layout.Widget) D {
+	return cryptomaterial.LinearLayout{
+		Width:       cryptomaterial.MatchParent,
+		Height:      cryptomaterial.WrapContent,
+		Background:  pg.Theme.Color.Surface,
+		Margin:      layout.Inset{Top: dp5, Bottom: dp5},
+		Padding:     layout.UniformInset(dp16),
+		Orientation: vertical,
+		Border: cryptomaterial.Border{
+			Radius: cryptomaterial.Radius(8),
+		},
+	}.Layout(gtx,
+		layout.Rigid(func(gtx C) D {
+			lb := pg.Theme.Label(values.TextSize16, title)
+			lb.Font.Weight = font.SemiBold
+			return layout.Inset{Bottom: dp10}.Layout(gtx, lb.Layout)
+		}),
+		layout.Rigid(body),
+	)
+}
+
+func (pg *DEXServersPage) serverList(gtx C) D {
+	return pg.sectionLayout(gtx, values.String(values.StrDEXServers), func(gtx C) D {
+		if len(pg.exchanges) == 0 {
+			return layout.Center.Layout(gtx, pg.Theme.Body2(values.String(values.StrNoDEXServers)).Layout)
+		}
+
+		var rows []layout.FlexChild
+		for i, xc := range pg.exchanges {
+			index, xc := i, xc
+			rows = append(rows, layout.Rigid(func(gtx C) D {
+				return pg.serverRow(gtx, index, xc)
+			}))
+		}
+		return layout.Flex{Axis: vertical}.Layout(gtx, rows...)
+	})
+}
+
+func (pg *DEXServersPage) serverRow(gtx C, index int, xc *core.Exchange) D {
+	w := pg.rowWidgets[xc.Host]
+	return layout.Inset{Top: dp8, Bottom: dp8}.Layout(gtx, func(gtx C) D {
+		return layout.Flex{Axis: horizontal, Alignment: layout.Middle}.Layout(gtx,
+			layout.Rigid(func(gtx C) D {
+				return layout.Flex{Axis: vertical}.Layout(gtx,
+					layout.Rigid(func(gtx C) D {
+						if index == 0 {
+							return D{}
+						}
+						return w.moveUpBtn.Layout(gtx)
+					}),
+					layout.Rigid(func(gtx C) D {
+						if index == len(pg.exchanges)-1 {
+							return D{}
+						}
+						return w.moveDownBtn.Layout(gtx)
+					}),
+				)
+			}),
+			layout.Flexed(1, func(gtx C) D {
+				return layout.Inset{Left: dp10, Right: dp10}.Layout(gtx, func(gtx C) D {
+					return layout.Flex{Axis: vertical}.Layout(gtx,
+						layout.Rigid(func(gtx C) D {
+							lb := pg.Theme.Body1(xc.Host)
+							lb.Font.Weight = font.SemiBold
+							return lb.Layout(gtx)
+						}),
+						layout.Rigid(func(gtx C) D {
+							status, isGood := pg.serverStatus(xc)
+							lb := pg.Theme.Caption(status)
+							lb.Color = pg.Theme.Color.Danger
+							if isGood {
+								lb.Color = pg.Theme.Color.Success
+							}
+							return lb.Layout(gtx)
+						}),
+						layout.Rigid(func(gtx C) D {
+							lb := pg.Theme.Caption(serverMarketsSummary(xc))
+							lb.Color = pg.Theme.Color.GrayText2
+							return lb.Layout(gtx)
+						}),
+					)
+				})
+			}),
+			layout.Rigid(func(gtx C) D {
+				if pg.isLoading {
+					return D{}
+				}
+				return layout.Flex{Axis: horizontal}.Layout(gtx,
+					layout.Rigid(func(gtx C) D {
+						if xc.ConnectionStatus != comms.InvalidCert {
+							return D{}
+						}
+						return layout.Inset{Right: dp10}.Layout(gtx, w.updateCertBtn.Layout)
+					}),
+					layout.Rigid(func(gtx C) D {
+						if xc.ViewOnly || xc.Disabled {
+							return D{}
+						}
+						return layout.Inset{Right: dp10}.Layout(gtx, w.bondsBtn.Layout)
+					}),
+					layout.Rigid(func(gtx C) D {
+						w.toggleBtn.Text = values.String(values.StrDisable)
+						if xc.Disabled {
+							w.toggleBtn.Text = values.String(values.StrEnable)
+						}
+						return w.toggleBtn.Layout(gtx)
+					}),
+					layout.Rigid(func(gtx C) D {
+						return layout.Inset{Left: dp10}.Layout(gtx, w.removeBtn.Layout)
+					}),
+				)
+			}),
+		)
+	})
+}
+
+// serverStatus returns a description of the connection status of xc and
+// whether the status is one that allows the user to trade.
+func (pg *DEXServersPage) serverStatus(xc *core.Exchange) (string, bool) {
+	switch {
+	case xc.Disabled:
+		return values.String(values.StrDisabled), false
+	case xc.ConnectionStatus == comms.InvalidCert:
+		return values.String(values.StrInvalidCertStatus), false
+	case xc.ConnectionStatus != comms.Connected:
+		return values.String(values.StrDisconnected), false
+	case xc.ViewOnly:
+		return fmt.Sprintf("%s, %s", values.String(values.StrConnected), values.String(values.StrViewOnly)), true
+	default:
+		return values.String(values.StrConnected), true
+	}
+}
+
+// serverMarketsSummary lists the markets offered by xc.
+func serverMarketsSummary(xc *core.Exchange) string {
+	names := make([]string, 0, len(xc.Markets))
+	for _, mkt := range xc.Markets {
+		names = append(names, strings.ToUpper(mkt.BaseSymbol)+"/"+strings.ToUpper(mkt.QuoteSymbol))
+	}
+	sort.Strings(names)
+	summary := values.StringF(values.StrMarketsCount, len(names))
+	if len(names) > 0 {
+		summary += ": " + strings.Join(names, ", ")
+	}
+	return summary
+}
+
+func (pg *DEXServersPage) addServerForm(gtx C) D {
+	return pg.sectionLayout(gtx, values.String(values.StrAddServer), func(gtx C) D {
+		return layout.Flex{Axis: vertical}.Layout(gtx,
+			layout.Rigid(pg.serverURLEditor.Layout),
+			layout.Rigid(func(gtx C) D {
+				return layout.Inset{Top: dp16}.Layout(gtx, pg.serverCertEditor.Layout)
+			}),
+			layout.Rigid(func(gtx C) D {
+				return layout.Inset{Top: dp16}.Layout(gtx, func(gtx C) D {
+					return layout.E.Layout(gtx, func(gtx C) D {
+						if pg.isLoading {
+							return pg.materialLoader.Layout(gtx)
+						}
+						return pg.addServerBtn.Layout(gtx)
+					})
+				})
+			}),
+		)
+	})
+}
+
+// HandleUserInteractions is called just before Layout() to determine
+// if any user interaction recently occurred on the page and may be
+// used to update the page's UI components shortly before they are
+// displayed.
+// Part of the load.Page interface.
+func (pg *DEXServersPage) HandleUserInteractions(gtx C) {
+	if !pg.AssetsManager.DEXCInitialized() {
+		return
+	}
+
+	isSubmit, isChanged := cryptomaterial.HandleEditorEvents(gtx, &pg.serverURLEditor, &pg.serverCertEditor)
+	if isChanged {
+		pg.serverURLEditor.SetError("")
+		pg.serverCertEditor.SetError("")
+	}
+
+	if (isSubmit || pg.addServerBtn.Clicked(gtx)) && !pg.isLoading {
+		pg.addServer()
+	}
+
+	for i, xc := range pg.exchanges {
+		w := pg.rowWidgets[xc.Host]
+		if w.moveUpBtn.Button.Clicked(gtx) && i > 0 {
+			pg.swapServers(i, i-1)
+			break
+		}
+
+		if w.moveDownBtn.Button.Clicked(gtx) && i < len(pg.exchanges)-1 {
+			pg.swapServers(i, i+1)
+			break
+		}
+
+		if w.bondsBtn.Clicked(gtx) {
+			pg.ParentNavigator().Display(NewDEXBondsPage(pg.Load, xc.Host))
+		}
+
+		if w.updateCertBtn.Clicked(gtx) {
+			pg.updateCert(xc.Host)
+		}
+
+		if w.toggleBtn.Clicked(gtx) {
+			pg.toggleServer(xc.Host, !xc.Disabled)
+		}
+
+		if w.removeBtn.Clicked(gtx) {
+			pg.removeServer(xc)
+		}
+	}
+}
+
+// swapServers swaps the display positions of the servers at i and j and saves
+// the new order.
+func (pg *DEXServersPage) swapServers(i, j int) {
+	pg.exchanges[i], pg.exchanges[j] = pg.exchanges[j], pg.exchanges[i]
+	hosts := make([]string, 0, len(pg.exchanges))
+	for _, xc := range pg.exchanges {
+		hosts = append(hosts, xc.Host)
+	}
+	pg.AssetsManager.SetDEXServerOrder(hosts)
+}
+
+func (pg *DEXServersPage) addServer() {
+	host := strings.TrimSpace(pg.serverURLEditor.Editor.Text())
+	if _, err := url.ParseRequestURI(host); host == "" || err != nil {
+		pg.serverURLEditor.SetError(values.String(values.StrDEXServerAddrWarning))
+		return
+	}
+
+	if pg.AssetsManager.IsDEXServerRemoved(host) {
+		pg.restoreServer(host)
+		return
+	}
+
+	if _, err := pg.AssetsManager.DexClient().Exchange(host); err == nil {
+		pg.serverURLEditor.SetError(values.StringF(values.StrDEXServerExists, host))
+		return
+	}
+
+	cert, err := certFromInput(pg.serverCertEditor.Editor.Text())
+	if err != nil {
+		pg.serverCertEditor.SetError(values.String(values.StrInvalidCertInput))
+		return
+	}
+	if len(cert) == 0 {
+		cert = CertStore[host]
+	}
+
+	pg.ParentWindow().ShowModal(dexLoginModal(pg.Load, pg.AssetsManager.DexClient(), func(password string) {
+		pg.isLoading = true
+		go func() {
+			defer func() {
+				pg.isLoading = false
+				pg.ParentWindow().Reload()
+			}()
+
+			if err := pg.AssetsManager.DexClient().AddDEX([]byte(password), host, cert); err != nil {
+				pg.notifyError(err.Error())
+				return
+			}
+
+			pg.serverURLEditor.Editor.SetText("")
+			pg.serverCertEditor.Editor.SetText("")
+			pg.AssetsManager.SetDEXServerOrder(append(pg.AssetsManager.GetDEXServerOrder(), host))
+			pg.refreshExchanges()
+			pg.Toast.Notify(values.String(values.StrDEXServerAdded))
+		}()
+	}))
+}
+
+// updateCert reconnects to the server at host using the certificate entered in
+// the add server form.
+func (pg *DEXServersPage) updateCert(host string) {
+	cert, err := certFromInput(pg.serverCertEditor.Editor.Text())
+	if err != nil || len(cert) == 0 {
+		pg.serverCertEditor.SetError(values.String(values.StrInvalidCertInput))
+		return
+	}
+
+	pg.isLoading = true
+	go func() {
+		defer func() {
+			pg.isLoading = false
+			pg.ParentWindow().Reload()
+		}()
+
+		if err := pg.AssetsManager.DexClient().UpdateCert(host, cert); err != nil {
+			pg.notifyError(err.Error())
+			return
+		}
+
+		pg.serverCertEditor.Editor.SetText("")
+		pg.refreshExchanges()
+		pg.Toast.Notify(values.StringF(values.StrCertUpdated, host))
+	}()
+}
+
+func (pg *DEXServersPage) toggleServer(host string, disable bool) {
+	toggle := func() {
+		pg.ParentWindow().ShowModal(dexLoginModal(pg.Load, pg.AssetsManager.DexClient(), func(password string) {
+			if err := pg.AssetsManager.DexClient().ToggleAccountStatus([]byte(password), host, disable); err != nil {
+				pg.notifyError(err.Error())
+				return
+			}
+			pg.refreshExchanges()
+		}))
+	}
+
+	if !disable {
+		toggle()
+		return
+	}
+
+	warningModal := modal.NewCustomModal(pg.Load).
+		Title(values.StringF(values.StrDisableServerTitle, host)).
+		Body(values.String(values.StrDisableServerDesc)).
+		SetNegativeButtonText(values.String(values.StrCancel)).
+		SetPositiveButtonText(values.String(values.StrDisable)).
+		SetPositiveButtonCallback(func(_ bool, _ *modal.InfoModal) bool {
+			toggle()
+			return true
+		}).
+		PositiveButtonStyle(pg.Theme.Color.Surface, pg.Theme.Color.Danger)
+	pg.ParentWindow().ShowModal(warningModal)
+}
+
+// removeServer disables the server and hides it from the app. The DEX client
+// cannot delete the account, which is also needed to refund the bonds posted
+// to the server.
+func (pg *DEXServersPage) removeServer(xc *core.Exchange) {
+	host := xc.Host
+	remove := func(password string) {
+		if !xc.Disabled {
+			if err := pg.AssetsManager.DexClient().ToggleAccountStatus([]byte(password), host, true); err != nil {
+				pg.notifyError(err.Error())
+				return
+			}
+		}
+
+		pg.AssetsManager.SetDEXServerRemoved(host, true)
+		delete(pg.rowWidgets, host)
+		pg.refreshExchanges()
+		pg.Toast.Notify(values.String(values.StrDEXServerRemoved))
+	}
+
+	warningModal := modal.NewCustomModal(pg.Load).
+		Title(values.StringF(values.StrRemoveServerTitle, host)).
+		Body(values.String(values.StrRemoveServerDesc)).
+		SetNegativeButtonText(values.String(values.StrCancel)).
+		SetPositiveButtonText(values.String(values.StrRemove)).
+		SetPositiveButtonCallback(func(_ bool, _ *modal.InfoModal) bool {
+			pg.ParentWindow().ShowModal(dexLoginModal(pg.Load, pg.AssetsManager.DexClient(), remove))
+			return true
+		}).
+		PositiveButtonStyle(pg.Theme.Color.Surface, pg.Theme.Color.Danger)
+	pg.ParentWindow().ShowModal(warningModal)
+}
+
+// restoreServer re-enables a removed server that is added again.
+func (pg *DEXServersPage) restoreServer(host string) {
+	pg.ParentWindow().ShowModal(dexLoginModal(pg.Load, pg.AssetsManager.DexClient(), func(password string) {
+		if err := pg.AssetsManager.DexClient().ToggleAccountStatus([]byte(password), host, false); err != nil {
+			pg.notifyError(err.Error())
+			return
+		}
+
+		pg.AssetsManager.SetDEXServerRemoved(host, false)
+		pg.serverURLEditor.Editor.SetText("")
+		pg.serverCertEditor.Editor.SetText("")
+		pg.AssetsManager.SetDEXServerOrder(append(pg.AssetsManager.GetDEXServerOrder(), host))
+		pg.refreshExchanges()
+		pg.Toast.Notify(values.String(values.StrDEXServerAdded))
+	}))
+}
+
+func (pg *DEXServersPage) notifyError(errMsg string) {
+	errModal := modal.NewErrorModal(pg.Load, errMsg, modal.DefaultClickFunc())
+	pg.ParentWindow().ShowModal(errModal)
+}
+
+// OnNavigatedFrom is called when the page is about to be removed from
+// the displayed window. This method should ideally be used to disable
+// features that are irrelevant when the page is NOT displayed.
+// NOTE: The page may be re-displayed on the app's window, in which case
+// OnNavigatedTo() will be called again. This method should not destroy UI
+// components unless they'll be recreated in the OnNavigatedTo() method.
+// Part of the load.Page interface.
+func (pg *DEXServersPage) OnNavigatedFrom() {}
+
+// certFromInput returns the TLS certificate entered by the user. The input may
+// be the PEM encoded certificate or a path to the certificate file. An empty
+// input returns a nil certificate.
+func certFromInput(input string) ([]byte, error) {
+	input = strings.TrimSpace(input)
+	if input == "" {
+		return nil, nil
+	}
+
+	cert := []byte(input)
+	if !bytes.HasPrefix(cert, []byte("-----BEGIN")) {
+		var err error
+		cert, err = os.ReadFile(input)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", errInvalidCert, err)
+		}
+	}
+
+	if !bytes.Contains(cert, []byte("-----BEGIN CERTIFICATE-----")) {
+		return nil, errInvalidCert
+	}
+	return cert, nil
+}
+
+// orderedExchanges returns the DEX servers known to the DEX client in the order
+// saved by the user. Servers without a saved position are listed after the
+// ordered servers in alphabetical order. Disabled servers are only included if
+// includeDisabled is true. Servers removed by the user are never included.
+func orderedExchanges(assetsManager *libwallet.AssetsManager, includeDisabled bool) []*core.Exchange {
+	xcs := assetsManager.DexClient().Exchanges()
+	hosts := make([]string, 0, len(xcs))
+	for host, xc := range xcs {
+		if (xc.Disabled && !includeDisabled) || assetsManager.IsDEXServerRemoved(host) {
+			continue
+		}
+		hosts = append(hosts, host)
+	}
+
+	exchanges := make([]*core.Exchange, 0, len(hosts))
+	for _, host := range sortDEXHosts(hosts, assetsManager.GetDEXServerOrder()) {
+		exchanges = append(exchanges, xcs[host])
+	}
+	return exchanges
+}
+
+// sortDEXHosts sorts hosts using their position in order. Hosts that are not in
+// order are sorted alphabetically after the ordered hosts.
+func sortDEXHosts(hosts, order []string) []string {
+	position := make(map[string]int, len(order))
+	for i, host := range order {
+		if _, ok := position[host]; !ok {
+			position[host] = i
+		}
+	}
+
+	sorted := append([]string(nil), hosts...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		pi, iOrdered := position[sorted[i]]
+		pj, jOrdered := position[sorted[j]]
+		switch {
+		case iOrdered && jOrdered:
+			return pi < pj
+		case iOrdered != jOrdered:
+			return iOrdered
+		default:
+			return sorted[i] < sorted[j]
+		}
+	})
+	return sorted
+}
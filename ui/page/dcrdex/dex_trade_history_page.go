@@ -0,0 +1,558 @@
+package dcrdex
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"decred.org/dcrdex/client/asset"
+	"decred.org/dcrdex/dex/order"
+	"gioui.org/font"
+	"gioui.org/layout"
+	"gioui.org/widget"
+	"gioui.org/widget/material"
+
+	"github.com/crypto-power/cryptopower/app"
+	"github.com/crypto-power/cryptopower/dexc"
+	"github.com/crypto-power/cryptopower/libwallet/utils"
+	"github.com/crypto-power/cryptopower/ui/cryptomaterial"
+	"github.com/crypto-power/cryptopower/ui/load"
+	"github.com/crypto-power/cryptopower/ui/modal"
+	"github.com/crypto-power/cryptopower/ui/page/components"
+	"github.com/crypto-power/cryptopower/ui/values"
+)
+
+const DEXTradeHistoryPageID = "dex_trade_history"
+
+// tradeHistoryStatuses are the order statuses that trades can be filtered by.
+var tradeHistoryStatuses = []order.OrderStatus{
+	order.OrderStatusEpoch,
+	order.OrderStatusBooked,
+	order.OrderStatusExecuted,
+	order.OrderStatusCanceled,
+	order.OrderStatusRevoked,
+}
+
+// tradeHistoryPeriods are the time ranges that trades can be filtered by.
+var tradeHistoryPeriods = []struct {
+	label string
+	age   time.Duration
+}{
+	{values.StrAllTime, 0},
+	{values.StrLast30Days, 30 * 24 * time.Hour},
+	{values.StrLast90Days, 90 * 24 * time.Hour},
+	{values.StrLast12Months, 365 * 24 * time.Hour},
+}
+
+// tradeRow is a trade displayed on the trade history page.
+type tradeRow struct {
+	*dexc.TradeRecord
+	clickable *cryptomaterial.Clickable
+	expanded  bool
+}
+
+// DEXTradeHistoryPage displays the DEX trade history with filters, the details
+// of each trade's matches and the profit and loss of each market. The trade
+// history can be exported as CSV or JSON.
+type DEXTradeHistoryPage struct {
+	*load.Load
+	// GenericPageModal defines methods such as ID() and OnAttachedToNavigator()
+	// that helps this Page satisfy the app.Page interface. It also defines
+	// helper methods for accessing the PageNavigator that displayed this page
+	// and the root WindowNavigator.
+	*app.GenericPageModal
+
+	scrollContainer *widget.List
+	backButton      cryptomaterial.IconButton
+
+	serverSelector *cryptomaterial.DropDown
+	marketSelector *cryptomaterial.DropDown
+	statusSelector *cryptomaterial.DropDown
+	periodSelector *cryptomaterial.DropDown
+
+	page       int
+	history    *dexc.TradeHistory
+	trades     []*tradeRow
+	marketPnLs []*dexc.MarketPnL
+
+	previousPageBtn cryptomaterial.Button
+	nextPageBtn     cryptomaterial.Button
+	exportCSVBtn    cryptomaterial.Button
+	exportJSONBtn   cryptomaterial.Button
+
+	materialLoader material.LoaderStyle
+	isLoading      bool
+}
+
+// NewDEXTradeHistoryPage creates a page that displays the DEX trade history.
+func NewDEXTradeHistoryPage(l *load.Load) *DEXTradeHistoryPage {
+	th := l.Theme
+	pg := &DEXTradeHistoryPage{
+		Load:             l,
+		GenericPageModal: app.NewGenericPageModal(DEXTradeHistoryPageID),
+		scrollContainer:  &widget.List{List: layout.List{Axis: vertical}},
+		backButton:       components.GetBackButton(l),
+		previousPageBtn:  th.OutlineButton(values.String(values.StrPrevious)),
+		nextPageBtn:      th.OutlineButton(values.String(values.StrNext)),
+		exportCSVBtn:     th.OutlineButton(values.String(values.StrExportCSV)),
+		exportJSONBtn:    th.OutlineButton(values.String(values.StrExportJSON)),
+		materialLoader:   material.Loader(th.Base),
+	}
+
+	statuses := []cryptomaterial.DropDownItem{{Text: values.String(values.StrAllStatuses)}}
+	for _, status := range tradeHistoryStatuses {
+		statuses = append(statuses, cryptomaterial.DropDownItem{Text: status.String()})
+	}
+	pg.statusSelector = th.NewCommonDropDown(statuses, nil, values.MarginPadding120, values.DEXTradeHistoryFilterGroup, false)
+
+	var periods []cryptomaterial.DropDownItem
+	for _, period := range tradeHistoryPeriods {
+		periods = append(periods, cryptomaterial.DropDownItem{Text: values.String(period.label)})
+	}
+	pg.periodSelector = th.NewCommonDropDown(periods, nil, values.MarginPadding120, values.DEXTradeHistoryFilterGroup, false)
+
+	return pg
+}
+
+// OnNavigatedTo is called when the page is about to be displayed and
+// may be used to initialize page features that are only relevant when
+// the page is displayed.
+// Part of the load.Page interface.
+func (pg *DEXTradeHistoryPage) OnNavigatedTo() {
+	if !pg.AssetsManager.DEXCInitialized() {
+		return
+	}
+
+	servers := []cryptomaterial.DropDownItem{{Text: values.String(values.StrAllServers)}}
+	markets := []cryptomaterial.DropDownItem{{Text: values.String(values.StrAllMarkets)}}
+	seenMarkets := make(map[string]bool)
+	for _, xc := range orderedExchanges(pg.AssetsManager, true) {
+		servers = append(servers, cryptomaterial.DropDownItem{Text: xc.Host})
+		for _, mkt := range xc.Markets {
+			name := strings.ToUpper(mkt.BaseSymbol) + "/" + strings.ToUpper(mkt.QuoteSymbol)
+			if !seenMarkets[name] {
+				seenMarkets[name] = true
+				markets = append(markets, cryptomaterial.DropDownItem{Text: name})
+			}
+		}
+	}
+	pg.serverSelector = pg.Theme.NewCommonDropDown(servers, nil, values.MarginPadding150, values.DEXTradeHistoryFilterGroup, false)
+	pg.marketSelector = pg.Theme.NewCommonDropDown(markets, nil, values.MarginPadding120, values.DEXTradeHistoryFilterGroup, false)
+
+	pg.page = 0
+	pg.fetchTrades()
+}
+
+// filter returns the trade history filter selected by the user.
+func (pg *DEXTradeHistoryPage) filter() *dexc.TradeHistoryFilter {
+	filter := &dexc.TradeHistoryFilter{
+		Page:     pg.page,
+		PageSize: dexc.DefaultTradeHistoryPageSize,
+	}
+
+	if i := pg.serverSelector.SelectedIndex(); i > 0 {
+		filter.Hosts = []string{pg.serverSelector.Selected()}
+	}
+
+	if i := pg.marketSelector.SelectedIndex(); i > 0 {
+		base, quote, _ := strings.Cut(pg.marketSelector.Selected(), "/")
+		baseID, _ := bip(strings.ToLower(base))
+		quoteID, _ := bip(strings.ToLower(quote))
+		filter.Market = &dexc.TradeMarket{BaseID: baseID, QuoteID: quoteID}
+	}
+
+	if i := pg.statusSelector.SelectedIndex(); i > 0 {
+		filter.Statuses = []order.OrderStatus{tradeHistoryStatuses[i-1]}
+	}
+
+	if i := pg.periodSelector.SelectedIndex(); i > 0 {
+		filter.From = time.Now().Add(-tradeHistoryPeriods[i].age)
+	}
+
+	return filter
+}
+
+// fetchTrades loads the current page of trades and the profit and loss of the
+// filtered trades.
+func (pg *DEXTradeHistoryPage) fetchTrades() {
+	filter := pg.filter()
+	pg.isLoading = true
+	go func() {
+		defer func() {
+			pg.isLoading = false
+			pg.ParentWindow().Reload()
+		}()
+
+		dexClient := pg.AssetsManager.DexClient()
+		history, err := dexClient.TradeHistory(filter)
+		if err != nil {
+			pg.notifyError(err.Error())
+			return
+		}
+
+		pnls, err := dexClient.TradePnL(filter)
+		if err != nil {
+			pg.notifyError(err.Error())
+			return
+		}
+
+		trades := make([]*tradeRow, 0, len(history.Trades))
+		for _, trade := range history.Trades {
+			trades = append(trades, &tradeRow{
+				TradeRecord: trade,
+				clickable:   pg.Theme.NewClickable(true),
+			})
+		}
+
+		pg.history, pg.trades, pg.marketPnLs = history, trades, pnls
+	}()
+}
+
+// Layout draws the page UI components into the provided C
+// to be eventually drawn on screen.
+// Part of the load.Page interface.
+func (pg *DEXTradeHistoryPage) Layout(gtx C) D {
+	if !pg.AssetsManager.DEXCInitialized() {
+		pg.ParentNavigator().CloseCurrentPage()
+		return D{}
+	}
+
+	sp := components.SubPage{
+		Load:       pg.Load,
+		Title:      values.String(values.StrTradeHistory),
+		BackButton: pg.backButton,
+		Back: func() {
+			pg.ParentNavigator().CloseCurrentPage()
+		},
+		Body: func(gtx C) D {
+			return pg.Theme.List(pg.scrollContainer).Layout(gtx, 1, func(gtx C, _ int) D {
+				return layout.Flex{Axis: vertical}.Layout(gtx,
+					layout.Rigid(pg.filters),
+					layout.Rigid(pg.tradeList),
+					layout.Rigid(pg.profitAndLoss),
+				)
+			})
+		},
+	}
+	return sp.Layout(pg.ParentWindow(), gtx)
+}
+
+func (pg *DEXTradeHistoryPage) sectionLayout(gtx C, title string, body layout.Widget) D {
+	return cryptomaterial.LinearLayout{
+		Width:       cryptomaterial.MatchParent,
+		Height:      cryptomaterial.WrapContent,
+		Background:  pg.Theme.Color.Surface,
+		Margin:      layout.Inset{Top: dp5, Bottom: dp5},
+		Padding:     layout.UniformInset(dp16),
+		Orientation: vertical,
+		Border: cryptomaterial.Border{
+			Radius: cryptomaterial.Radius(8),
+		},
+	}.Layout(gtx,
+		layout.Rigid(func(gtx C) D {
+			if title == "" {
+				return D{}
+			}
+			lb := pg.Theme.Label(values.TextSize16, title)
+			lb.Font.Weight = font.SemiBold
+			return layout.Inset{Bottom: dp10}.Layout(gtx, lb.Layout)
+		}),
+		layout.Rigid(body),
+	)
+}
+
+func (pg *DEXTradeHistoryPage) filters(gtx C) D {
+	return pg.sectionLayout(gtx, "", func(gtx C) D {
+		return layout.Flex{Axis: horizontal, Alignment: layout.Middle}.Layout(gtx,
+			layout.Rigid(pg.serverSelector.Layout),
+			layout.Rigid(pg.marketSelector.Layout),
+			layout.Rigid(pg.statusSelector.Layout),
+			layout.Rigid(pg.periodSelector.Layout),
+			layout.Flexed(1, func(gtx C) D {
+				return layout.E.Layout(gtx, func(gtx C) D {
+					return layout.Flex{Axis: horizontal}.Layout(gtx,
+						layout.Rigid(func(gtx C) D {
+							return layout.Inset{Right: dp10}.Layout(gtx, pg.exportCSVBtn.Layout)
+						}),
+						layout.Rigid(pg.exportJSONBtn.Layout),
+					)
+				})
+			}),
+		)
+	})
+}
+
+func (pg *DEXTradeHistoryPage) tradeList(gtx C) D {
+	return pg.sectionLayout(gtx, values.String(values.StrTradeHistory), func(gtx C) D {
+		if pg.isLoading {
+			return layout.Center.Layout(gtx, pg.materialLoader.Layout)
+		}
+
+		if len(pg.trades) == 0 {
+			return layout.Center.Layout(gtx, pg.Theme.Body2(values.String(values.StrNoTradeHistoryMsg)).Layout)
+		}
+
+		headers := []string{values.String(values.StrTime), values.String(values.StrType), values.String(values.StrPair), values.String(values.StrPrice), values.String(values.StrAmount), values.String(values.StrFilled), values.String(values.StrStatus)}
+		rows := []layout.FlexChild{pg.tableRow(true, headers...)}
+		for _, trade := range pg.trades {
+			trade := trade
+			rows = append(rows, layout.Rigid(func(gtx C) D {
+				return pg.tradeRowLayout(gtx, trade)
+			}))
+		}
+		rows = append(rows, layout.Rigid(pg.pagination))
+		return layout.Flex{Axis: vertical}.Layout(gtx, rows...)
+	})
+}
+
+func (pg *DEXTradeHistoryPage) tableRow(header bool, columns ...string) layout.FlexChild {
+	return layout.Rigid(func(gtx C) D {
+		var children []layout.FlexChild
+		for _, col := range columns {
+			lb := pg.Theme.Body2(col)
+			if header {
+				lb = semiBoldGray3Size14(pg.Theme, col)
+			}
+			children = append(children, layout.Flexed(1.0/float32(len(columns)), lb.Layout))
+		}
+		return layout.Inset{Top: dp8, Bottom: dp8}.Layout(gtx, func(gtx C) D {
+			return layout.Flex{Axis: horizontal}.Layout(gtx, children...)
+		})
+	})
+}
+
+func (pg *DEXTradeHistoryPage) tradeRowLayout(gtx C, trade *tradeRow) D {
+	reader := newOrderReader(pg.AssetsManager.DexClient(), trade.Order)
+	return layout.Flex{Axis: vertical}.Layout(gtx,
+		layout.Rigid(func(gtx C) D {
+			return trade.clickable.Layout(gtx, func(gtx C) D {
+				return layout.Flex{Axis: vertical}.Layout(gtx,
+					pg.tableRow(false,
+						trade.Time.Format(time.DateTime),
+						fmt.Sprintf("%s %s", values.String(trade.Type.String()), values.String(reader.SideString())),
+						fmt.Sprintf("%s (%s)", trade.MarketID, trade.Host),
+						reader.RateString(),
+						fmt.Sprintf("%s %s", reader.BaseQtyString(), strings.ToUpper(trade.BaseSymbol)),
+						fmt.Sprintf("%s%%", reader.FilledPercent()),
+						reader.StatusString(),
+					),
+				)
+			})
+		}),
+		layout.Rigid(func(gtx C) D {
+			if !trade.expanded {
+				return D{}
+			}
+			return pg.matchDetails(gtx, trade)
+		}),
+		layout.Rigid(pg.Theme.Separator().Layout),
+	)
+}
+
+func (pg *DEXTradeHistoryPage) matchDetails(gtx C, trade *tradeRow) D {
+	if len(trade.MatchDetails) == 0 {
+		return layout.Inset{Bottom: dp8}.Layout(gtx, pg.Theme.Caption(values.String(values.StrNoMatches)).Layout)
+	}
+
+	fromAsset, toAsset := trade.FromAssetID(), trade.ToAssetID()
+	var rows []layout.FlexChild
+	for _, match := range trade.MatchDetails {
+		match := match
+		txRow := func(label, txID string) layout.FlexChild {
+			return layout.Rigid(func(gtx C) D {
+				if txID == "" {
+					return D{}
+				}
+				lb := pg.Theme.Caption(fmt.Sprintf("%s: %s", label, txID))
+				lb.Color = pg.Theme.Color.GrayText2
+				return lb.Layout(gtx)
+			})
+		}
+
+		rows = append(rows, layout.Rigid(func(gtx C) D {
+			return layout.Inset{Left: dp16, Bottom: dp8}.Layout(gtx, func(gtx C) D {
+				return layout.Flex{Axis: vertical}.Layout(gtx,
+					layout.Rigid(pg.Theme.Body2(fmt.Sprintf("%s %s (%s, %s)", conventionalAmountString(trade.BaseID, match.BaseQty),
+						strings.ToUpper(trade.BaseSymbol), match.Side, match.Status)).Layout),
+					txRow(values.String(values.StrSwapTx), match.SwapTxID),
+					txRow(values.String(values.StrCounterSwapTx), match.CounterSwapTxID),
+					txRow(values.String(values.StrRedeemTx), match.RedeemTxID),
+					txRow(values.String(values.StrCounterRedeemTx), match.CounterRedeemTxID),
+					txRow(values.String(values.StrRefundTx), match.RefundTxID),
+					layout.Rigid(func(gtx C) D {
+						lb := pg.Theme.Caption(fmt.Sprintf("%s: %s %s, %s %s", values.String(values.StrFees),
+							conventionalAmountString(fromAsset, match.SwapFees), strings.ToUpper(unbip(fromAsset)),
+							conventionalAmountString(toAsset, match.RedeemFees), strings.ToUpper(unbip(toAsset))))
+						lb.Color = pg.Theme.Color.GrayText2
+						return lb.Layout(gtx)
+					}),
+				)
+			})
+		}))
+	}
+	return layout.Flex{Axis: vertical}.Layout(gtx, rows...)
+}
+
+func (pg *DEXTradeHistoryPage) pagination(gtx C) D {
+	if pg.history == nil {
+		return D{}
+	}
+
+	pages := (pg.history.Total + pg.history.PageSize - 1) / pg.history.PageSize
+	return layout.Inset{Top: dp10}.Layout(gtx, func(gtx C) D {
+		return layout.Flex{Axis: horizontal, Alignment: layout.Middle}.Layout(gtx,
+			layout.Flexed(1, pg.Theme.Body2(values.StringF(values.StrPageOf, pg.page+1, pages)).Layout),
+			layout.Rigid(func(gtx C) D {
+				if pg.page == 0 {
+					return D{}
+				}
+				return layout.Inset{Right: dp10}.Layout(gtx, pg.previousPageBtn.Layout)
+			}),
+			layout.Rigid(func(gtx C) D {
+				if !pg.history.HasNextPage() {
+					return D{}
+				}
+				return pg.nextPageBtn.Layout(gtx)
+			}),
+		)
+	})
+}
+
+func (pg *DEXTradeHistoryPage) profitAndLoss(gtx C) D {
+	return pg.sectionLayout(gtx, values.String(values.StrProfitAndLoss), func(gtx C) D {
+		if len(pg.marketPnLs) == 0 {
+			return layout.Center.Layout(gtx, pg.Theme.Body2(values.String(values.StrNoSettledTrades)).Layout)
+		}
+
+		headers := []string{values.String(values.StrMarket), values.String(values.StrBought), values.String(values.StrSold), values.String(values.StrFees), values.String(values.StrRealizedPnL)}
+		rows := []layout.FlexChild{pg.tableRow(true, headers...)}
+		for _, pnl := range pg.marketPnLs {
+			base, quote := strings.ToUpper(pnl.BaseSymbol), strings.ToUpper(pnl.QuoteSymbol)
+			realized := conventionalAmountString(pnl.QuoteID, uint64(abs(pnl.RealizedPnL())))
+			if pnl.RealizedPnL() < 0 {
+				realized = "-" + realized
+			}
+			rows = append(rows, pg.tableRow(false,
+				fmt.Sprintf("%s/%s (%s)", base, quote, pnl.Host),
+				fmt.Sprintf("%s %s", conventionalAmountString(pnl.BaseID, pnl.BaseBought), base),
+				fmt.Sprintf("%s %s", conventionalAmountString(pnl.BaseID, pnl.BaseSold), base),
+				fmt.Sprintf("%s %s, %s %s", conventionalAmountString(pnl.BaseID, pnl.BaseFees), base, conventionalAmountString(pnl.QuoteID, pnl.QuoteFees), quote),
+				fmt.Sprintf("%s %s", realized, quote),
+			))
+		}
+		return layout.Flex{Axis: vertical}.Layout(gtx, rows...)
+	})
+}
+
+// conventionalAmountString formats the atomic amount of assetID in its
+// conventional unit.
+func conventionalAmountString(assetID uint32, atoms uint64) string {
+	unitInfo, err := asset.UnitInfo(assetID)
+	if err != nil {
+		unitInfo = defaultUnitInfo(unbip(assetID))
+	}
+	return trimmedConventionalAmtString(float64(atoms) / float64(unitInfo.Conventional.ConversionFactor))
+}
+
+func abs(n int64) int64 {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
+
+// HandleUserInteractions is called just before Layout() to determine
+// if any user interaction recently occurred on the page and may be
+// used to update the page's UI components shortly before they are
+// displayed.
+// Part of the load.Page interface.
+func (pg *DEXTradeHistoryPage) HandleUserInteractions(gtx C) {
+	if pg.serverSelector == nil {
+		return
+	}
+
+	filterChanged := pg.serverSelector.Changed(gtx)
+	filterChanged = pg.marketSelector.Changed(gtx) || filterChanged
+	filterChanged = pg.statusSelector.Changed(gtx) || filterChanged
+	filterChanged = pg.periodSelector.Changed(gtx) || filterChanged
+	if filterChanged {
+		pg.page = 0
+		pg.fetchTrades()
+	}
+
+	if pg.previousPageBtn.Clicked(gtx) && pg.page > 0 && !pg.isLoading {
+		pg.page--
+		pg.fetchTrades()
+	}
+
+	if pg.nextPageBtn.Clicked(gtx) && pg.history != nil && pg.history.HasNextPage() && !pg.isLoading {
+		pg.page++
+		pg.fetchTrades()
+	}
+
+	for _, trade := range pg.trades {
+		if trade.clickable.Clicked(gtx) {
+			trade.expanded = !trade.expanded
+		}
+	}
+
+	if pg.exportCSVBtn.Clicked(gtx) {
+		pg.exportTrades(dexc.ExportFormatCSV)
+	}
+
+	if pg.exportJSONBtn.Clicked(gtx) {
+		pg.exportTrades(dexc.ExportFormatJSON)
+	}
+}
+
+// exportTrades writes the filtered trades to a file in the exports directory.
+func (pg *DEXTradeHistoryPage) exportTrades(format dexc.ExportFormat) {
+	filter := pg.filter()
+	go func() {
+		fileName := filepath.Join(pg.AssetsManager.RootDir(), "exports", fmt.Sprintf("dex_trades_export_%d.%s", time.Now().Unix(), format))
+		if err := pg.exportTradesToFile(fileName, format, filter); err != nil {
+			pg.notifyError(fmt.Errorf("error exporting your trade history: %v", err).Error())
+			return
+		}
+
+		infoModal := modal.NewSuccessModal(pg.Load, values.StringF(values.StrExportTradesSuccessMsg, fileName), modal.DefaultClickFunc())
+		pg.ParentWindow().ShowModal(infoModal)
+	}()
+}
+
+func (pg *DEXTradeHistoryPage) exportTradesToFile(fileName string, format dexc.ExportFormat, filter *dexc.TradeHistoryFilter) error {
+	if err := os.MkdirAll(filepath.Dir(fileName), utils.UserFilePerm); err != nil {
+		return fmt.Errorf("os.MkdirAll error: %w", err)
+	}
+
+	f, err := os.Create(fileName)
+	if err != nil {
+		return fmt.Errorf("os.Create error: %w", err)
+	}
+
+	err = pg.AssetsManager.DexClient().ExportTradeHistory(f, format, filter)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(fileName)
+		return err
+	}
+	return nil
+}
+
+func (pg *DEXTradeHistoryPage) notifyError(errMsg string) {
+	errModal := modal.NewErrorModal(pg.Load, errMsg, modal.DefaultClickFunc())
+	pg.ParentWindow().ShowModal(errModal)
+}
+
+// OnNavigatedFrom is called when the page is about to be removed from
+// the displayed window. This method should ideally be used to disable
+// features that are irrelevant when the page is NOT displayed.
+// NOTE: The page may be re-displayed on the app's window, in which case
+// OnNavigatedTo() will be called again. This method should not destroy UI
+// components unless they'll be recreated in the OnNavigatedTo() method.
+// Part of the load.Page interface.
+func (pg *DEXTradeHistoryPage) OnNavigatedFrom() {}
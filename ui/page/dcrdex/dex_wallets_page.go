@@ -0,0 +1,311 @@
+package dcrdex
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"decred.org/dcrdex/client/core"
+	"gioui.org/font"
+	"gioui.org/layout"
+	"gioui.org/widget"
+
+	"github.com/crypto-power/cryptopower/app"
+	"github.com/crypto-power/cryptopower/dexc"
+	sharedW "github.com/crypto-power/cryptopower/libwallet/assets/wallet"
+	"github.com/crypto-power/cryptopower/ui/cryptomaterial"
+	"github.com/crypto-power/cryptopower/ui/load"
+	"github.com/crypto-power/cryptopower/ui/page/components"
+	"github.com/crypto-power/cryptopower/ui/page/send"
+	pageutils "github.com/crypto-power/cryptopower/ui/utils"
+	"github.com/crypto-power/cryptopower/ui/values"
+)
+
+const DEXWalletsPageID = "dex_wallets"
+
+// dexWallet is a Cryptopower wallet account used by the DEX client.
+type dexWallet struct {
+	*dexc.WalletFunds
+	asset       sharedW.Asset
+	accountName string
+	swaps       []*dexc.SwapTx
+	sendBtn     cryptomaterial.Button
+}
+
+// DEXWalletsPage shows the balances of the wallets used by the DEX client,
+// separating the funds locked by orders, swaps and bonds from the available
+// balance, and lists the swap transactions that are still in progress.
+type DEXWalletsPage struct {
+	*load.Load
+	// GenericPageModal defines methods such as ID() and OnAttachedToNavigator()
+	// that helps this Page satisfy the app.Page interface. It also defines
+	// helper methods for accessing the PageNavigator that displayed this page
+	// and the root WindowNavigator.
+	*app.GenericPageModal
+
+	ctx       context.Context
+	cancelCtx context.CancelFunc
+
+	wallets []*dexWallet
+
+	scrollContainer *widget.List
+	backButton      cryptomaterial.IconButton
+}
+
+// NewDEXWalletsPage creates a page that displays the wallets used by the DEX
+// client.
+func NewDEXWalletsPage(l *load.Load) *DEXWalletsPage {
+	return &DEXWalletsPage{
+		Load:             l,
+		GenericPageModal: app.NewGenericPageModal(DEXWalletsPageID),
+		scrollContainer:  &widget.List{List: layout.List{Axis: vertical}},
+		backButton:       components.GetBackButton(l),
+	}
+}
+
+// OnNavigatedTo is called when the page is about to be displayed and
+// may be used to initialize page features that are only relevant when
+// the page is displayed.
+// Part of the load.Page interface.
+func (pg *DEXWalletsPage) OnNavigatedTo() {
+	if !pg.AssetsManager.DEXCInitialized() {
+		return
+	}
+
+	pg.ctx, pg.cancelCtx = context.WithCancel(context.Background())
+	go pg.refreshWallets()
+	go pg.listenForNotifications()
+}
+
+// listenForNotifications refreshes the wallets when balances, swaps or bonds
+// change.
+func (pg *DEXWalletsPage) listenForNotifications() {
+	noteFeed := pg.AssetsManager.DexClient().NotificationFeed()
+	defer noteFeed.ReturnFeed()
+	for {
+		select {
+		case <-pg.ctx.Done():
+			return
+		case n := <-noteFeed.C:
+			if n == nil || !pg.AssetsManager.DEXCInitialized() {
+				return
+			}
+
+			switch n.Type() {
+			case core.NoteTypeBalance, core.NoteTypeMatch, core.NoteTypeBondPost, core.NoteTypeBondRefund:
+				pg.refreshWallets()
+			}
+		}
+	}
+}
+
+// refreshWallets reloads the funds and in-flight swaps of the DEX wallets.
+func (pg *DEXWalletsPage) refreshWallets() {
+	dexClient := pg.AssetsManager.DexClient()
+	allFunds, err := dexClient.AllWalletFunds()
+	if err != nil {
+		log.Errorf("Error retrieving DEX wallet funds: %v", err)
+		return
+	}
+
+	wallets := make([]*dexWallet, 0, len(allFunds))
+	for _, funds := range allFunds {
+		asset := pg.AssetsManager.WalletWithID(funds.WalletID)
+		if asset == nil {
+			continue // wallet was deleted
+		}
+
+		accountName, err := asset.AccountName(funds.AccountNumber)
+		if err != nil {
+			log.Errorf("Error retrieving DEX wallet account name: %v", err)
+		}
+
+		swaps, err := dexClient.InFlightSwaps(funds.AssetID)
+		if err != nil {
+			log.Errorf("Error retrieving %s swaps: %v", funds.Symbol, err)
+		}
+
+		sendBtn := pg.Theme.OutlineButton(values.String(values.StrSend))
+		wallets = append(wallets, &dexWallet{
+			WalletFunds: funds,
+			asset:       asset,
+			accountName: accountName,
+			swaps:       swaps,
+			sendBtn:     sendBtn,
+		})
+	}
+
+	pg.wallets = wallets
+	pg.ParentWindow().Reload()
+}
+
+// Layout draws the page UI components into the provided C
+// to be eventually drawn on screen.
+// Part of the load.Page interface.
+func (pg *DEXWalletsPage) Layout(gtx C) D {
+	if !pg.AssetsManager.DEXCInitialized() {
+		pg.ParentNavigator().CloseCurrentPage()
+		return D{}
+	}
+
+	sp := components.SubPage{
+		Load:       pg.Load,
+		Title:      values.String(values.StrDEXWallets),
+		BackButton: pg.backButton,
+		Back: func() {
+			pg.ParentNavigator().CloseCurrentPage()
+		},
+		Body: func(gtx C) D {
+			if len(pg.wallets) == 0 {
+				return pg.sectionLayout(gtx, values.String(values.StrDEXWallets), func(gtx C) D {
+					return layout.Center.Layout(gtx, pg.Theme.Body2(values.String(values.StrNoDEXWallets)).Layout)
+				})
+			}
+
+			return pg.Theme.List(pg.scrollContainer).Layout(gtx, len(pg.wallets), func(gtx C, i int) D {
+				return pg.walletLayout(gtx, pg.wallets[i])
+			})
+		},
+	}
+	return sp.Layout(pg.ParentWindow(), gtx)
+}
+
+func (pg *DEXWalletsPage) sectionLayout(gtx C, title string, body layout.Widget) D {
+	return cryptomaterial.LinearLayout{
+		Width:       cryptomaterial.MatchParent,
+		Height:      cryptomaterial.WrapContent,
+		Background:  pg.Theme.Color.Surface,
+		Margin:      layout.Inset{Top: dp5, Bottom: dp5},
+		Padding:     layout.UniformInset(dp16),
+		Orientation: vertical,
+		Border: cryptomaterial.Border{
+			Radius: cryptomaterial.Radius(8),
+		},
+	}.Layout(gtx,
+		layout.Rigid(func(gtx C) D {
+			lb := pg.Theme.Label(values.TextSize16, title)
+			lb.Font.Weight = font.SemiBold
+			return layout.Inset{Bottom: dp10}.Layout(gtx, lb.Layout)
+		}),
+		layout.Rigid(body),
+	)
+}
+
+func (pg *DEXWalletsPage) walletLayout(gtx C, w *dexWallet) D {
+	amount := func(atoms uint64) string {
+		return w.asset.ToAmount(int64(atoms)).String()
+	}
+
+	title := fmt.Sprintf("%s - %s (%s)", strings.ToUpper(w.Symbol), w.asset.GetWalletName(), w.accountName)
+	return pg.sectionLayout(gtx, title, func(gtx C) D {
+		return layout.Flex{Axis: vertical}.Layout(gtx,
+			layout.Rigid(func(gtx C) D {
+				return layout.Flex{Axis: horizontal}.Layout(gtx,
+					layout.Flexed(1, func(gtx C) D {
+						return layout.Flex{Axis: vertical}.Layout(gtx,
+							layout.Rigid(pg.balanceRow(values.String(values.StrLabelSpendable), amount(w.Available))),
+							layout.Rigid(pg.balanceRow(values.String(values.StrLocked), amount(w.Locked))),
+							layout.Rigid(pg.balanceRow(values.String(values.StrDEXOrderLocked), amount(w.OrderLocked))),
+							layout.Rigid(pg.balanceRow(values.String(values.StrDEXContractLocked), amount(w.ContractLocked))),
+							layout.Rigid(pg.balanceRow(values.String(values.StrDEXBondLocked), amount(w.BondLocked))),
+						)
+					}),
+					layout.Rigid(func(gtx C) D {
+						return layout.Inset{Left: dp16}.Layout(gtx, w.sendBtn.Layout)
+					}),
+				)
+			}),
+			layout.Rigid(func(gtx C) D {
+				return layout.Inset{Top: dp16}.Layout(gtx, func(gtx C) D {
+					return pg.swapsLayout(gtx, w)
+				})
+			}),
+		)
+	})
+}
+
+func (pg *DEXWalletsPage) balanceRow(label, amount string) layout.Widget {
+	return func(gtx C) D {
+		return layout.Inset{Bottom: dp5}.Layout(gtx, func(gtx C) D {
+			return layout.Flex{Axis: horizontal}.Layout(gtx,
+				layout.Flexed(1, func(gtx C) D {
+					lb := pg.Theme.Body2(label)
+					lb.Color = pg.Theme.Color.GrayText2
+					return lb.Layout(gtx)
+				}),
+				layout.Rigid(pg.Theme.Body2(amount).Layout),
+			)
+		})
+	}
+}
+
+// swapsLayout lists the swap contracts sent from the wallet that have not been
+// redeemed or refunded yet.
+func (pg *DEXWalletsPage) swapsLayout(gtx C, w *dexWallet) D {
+	lb := pg.Theme.Body1(values.String(values.StrInFlightSwaps))
+	lb.Font.Weight = font.SemiBold
+	rows := []layout.FlexChild{
+		layout.Rigid(func(gtx C) D {
+			return layout.Inset{Bottom: dp5}.Layout(gtx, lb.Layout)
+		}),
+	}
+
+	if len(w.swaps) == 0 {
+		rows = append(rows, layout.Rigid(func(gtx C) D {
+			lb := pg.Theme.Caption(values.String(values.StrNoInFlightSwaps))
+			lb.Color = pg.Theme.Color.GrayText2
+			return lb.Layout(gtx)
+		}))
+		return layout.Flex{Axis: vertical}.Layout(gtx, rows...)
+	}
+
+	for _, swap := range w.swaps {
+		side := values.String(values.StrBuy)
+		if swap.Sell {
+			side = values.String(values.StrSell)
+		}
+		summary := fmt.Sprintf("%s %s %s @ %s - %s, %s", side, strings.ToUpper(swap.MarketID), w.asset.ToAmount(int64(swap.Amount)).String(),
+			swap.Host, swap.Status.String(), pageutils.TimeAgo(int64(swap.Stamp/1000)))
+		txID := swap.TxID
+		rows = append(rows, layout.Rigid(func(gtx C) D {
+			return layout.Inset{Top: dp5, Bottom: dp5}.Layout(gtx, func(gtx C) D {
+				return layout.Flex{Axis: vertical}.Layout(gtx,
+					layout.Rigid(pg.Theme.Body2(summary).Layout),
+					layout.Rigid(func(gtx C) D {
+						lb := pg.Theme.Caption(fmt.Sprintf("%s: %s", values.String(values.StrSwapTx), txID))
+						lb.Color = pg.Theme.Color.GrayText2
+						return lb.Layout(gtx)
+					}),
+				)
+			})
+		}))
+	}
+	return layout.Flex{Axis: vertical}.Layout(gtx, rows...)
+}
+
+// HandleUserInteractions is called just before Layout() to determine
+// if any user interaction recently occurred on the page and may be
+// used to update the page's UI components shortly before they are
+// displayed.
+// Part of the load.Page interface.
+func (pg *DEXWalletsPage) HandleUserInteractions(gtx C) {
+	for _, w := range pg.wallets {
+		if w.sendBtn.Clicked(gtx) {
+			sendPage := send.NewSendPage(pg.Load, w.asset).SetSourceAccount(w.AccountNumber)
+			pg.ParentNavigator().Display(sendPage)
+		}
+	}
+}
+
+// OnNavigatedFrom is called when the page is about to be removed from
+// the displayed window. This method should ideally be used to disable
+// features that are irrelevant when the page is NOT displayed.
+// NOTE: The page may be re-displayed on the app's window, in which case
+// OnNavigatedTo() will be called again. This method should not destroy UI
+// components unless they'll be recreated in the OnNavigatedTo() method.
+// Part of the load.Page interface.
+func (pg *DEXWalletsPage) OnNavigatedFrom() {
+	if pg.cancelCtx != nil {
+		pg.cancelCtx()
+	}
+}
@@ -53,7 +53,7 @@ var (
 	// the current order form elements and maxOrderDisplayedInOrderBook. Use
 	// this to ensure they (order form and orderbook) have the same height as
 	// they are displayed sided by side.
-	orderFormAndOrderBookHeight = values.MarginPadding620
+	orderFormAndOrderBookHeight = values.MarginPadding700
 
 	orderTypes []cryptomaterial.DropDownItem
 
@@ -83,9 +83,13 @@ type DEXMarketPage struct {
 	serverSelector        *cryptomaterial.DropDown
 	lastSelectedDEXServer string
 	addServerBtn          *cryptomaterial.Clickable
+	manageBondsBtn        *cryptomaterial.Clickable
+	manageServersBtn      *cryptomaterial.Clickable
+	dexWalletsBtn         *cryptomaterial.Clickable
 	xc                    *core.Exchange
 
 	marketSelector               *cryptomaterial.DropDown
+	lastSelectedMarket           string
 	noMarketOrServerDisconnected atomic.Bool
 
 	toggleBuyAndSellBtn *cryptomaterial.SegmentedControl
@@ -100,11 +104,14 @@ type DEXMarketPage struct {
 	maxBuyOrSellStr     string
 	orderFeeEstimateStr string
 
-	loginBtn               cryptomaterial.Button
-	postBondBtn            cryptomaterial.Button
-	createOrderBtn         cryptomaterial.Button
-	immediateOrderCheckbox cryptomaterial.CheckBoxStyle
-	immediateOrderInfoBtn  *cryptomaterial.Clickable
+	loginBtn              cryptomaterial.Button
+	postBondBtn           cryptomaterial.Button
+	createOrderBtn        cryptomaterial.Button
+	tifDropdown           *cryptomaterial.DropDown
+	immediateOrderInfoBtn *cryptomaterial.Clickable
+	triggerTypeDropdown   *cryptomaterial.DropDown
+	triggerInfoBtn        *cryptomaterial.Clickable
+	triggerPriceEditor    cryptomaterial.Editor
 
 	addWalletToDEX  cryptomaterial.Button
 	walletSelector  *components.WalletDropdown
@@ -115,12 +122,17 @@ type DEXMarketPage struct {
 	closeOrderBookListener  func()
 
 	orders                      []*clickableOrder
+	triggerOrders               []*clickableTriggerOrder
 	openOrdersBtn               cryptomaterial.Button
 	orderHistoryBtn             cryptomaterial.Button
+	triggerOrdersBtn            cryptomaterial.Button
+	viewAllTradesBtn            *cryptomaterial.Clickable
+	cancelAllBtn                *cryptomaterial.Clickable
 	ordersTableHorizontalScroll *widget.List
 
-	openOrdersDisplayed bool
-	showLoader          bool
+	openOrdersDisplayed    bool
+	triggerOrdersDisplayed bool
+	showLoader             bool
 }
 
 type orderbookInfo struct {
@@ -135,6 +147,11 @@ type clickableOrder struct {
 	cancelBtn *cryptomaterial.Clickable
 }
 
+type clickableTriggerOrder struct {
+	*dexc.TriggerOrder
+	removeBtn *cryptomaterial.Clickable
+}
+
 // NewDEXMarketPage prepares and initializes a *DEXMarketPage. Specify
 // selectServer to select the provided server.
 func NewDEXMarketPage(l *load.Load, selectServer string) *DEXMarketPage {
@@ -161,6 +178,9 @@ func NewDEXMarketPage(l *load.Load, selectServer string) *DEXMarketPage {
 		scrollContainer:                    &widget.List{List: layout.List{Axis: vertical, Alignment: layout.Middle}},
 		openOrdersAndOrderHistoryContainer: &widget.List{List: layout.List{Axis: vertical, Alignment: layout.Middle}},
 		addServerBtn:                       th.NewClickable(false),
+		manageBondsBtn:                     th.NewClickable(false),
+		manageServersBtn:                   th.NewClickable(false),
+		dexWalletsBtn:                      th.NewClickable(false),
 		toggleBuyAndSellBtn:                th.SegmentedControl(buyAndSellBtnStrings, cryptomaterial.SegmentTypeGroup),
 		orderTypesDropdown:                 th.NewCommonDropDown(orderTypes, nil, values.MarginPadding100, values.DEXOrderTypes, false),
 		priceEditor:                        newTextEditor(l.Theme, values.String(values.StrPrice), "", false),
@@ -174,11 +194,17 @@ func NewDEXMarketPage(l *load.Load, selectServer string) *DEXMarketPage {
 		postBondBtn:                        th.Button(values.String(values.StrPostBond)),
 		addWalletToDEX:                     th.Button(values.String(values.StrAddWallet)),
 		createOrderBtn:                     th.Button(values.String(values.StrBuy)),
-		immediateOrderCheckbox:             th.CheckBox(new(widget.Bool), values.String(values.StrImmediate)),
+		tifDropdown:                        th.NewCommonDropDown(timeInForceTypes(), nil, values.MarginPadding180, values.DEXOrderTypes, false),
 		immediateOrderInfoBtn:              th.NewClickable(false),
+		triggerTypeDropdown:                th.NewCommonDropDown(triggerTypes(), nil, values.MarginPadding120, values.DEXOrderTypes, false),
+		triggerInfoBtn:                     th.NewClickable(false),
+		triggerPriceEditor:                 newTextEditor(th, values.String(values.StrTriggerPrice), "", false),
 		seeFullOrderBookBtn:                th.Button(values.String(values.StrSeeMore)),
 		openOrdersBtn:                      th.Button(values.String(values.StrOpenOrders)),
 		orderHistoryBtn:                    th.Button(values.String(values.StrTradeHistory)),
+		triggerOrdersBtn:                   th.Button(values.String(values.StrTriggerOrders)),
+		viewAllTradesBtn:                   th.NewClickable(false),
+		cancelAllBtn:                       th.NewClickable(false),
 		ordersTableHorizontalScroll:        &widget.List{List: layout.List{Axis: horizontal, Alignment: layout.Middle}},
 		openOrdersDisplayed:                true,
 		lastSelectedDEXServer:              selectServer,
@@ -186,12 +212,14 @@ func NewDEXMarketPage(l *load.Load, selectServer string) *DEXMarketPage {
 
 	btnPadding := layout.Inset{Top: dp8, Right: dp20, Left: dp20, Bottom: dp8}
 	pg.toggleBuyAndSellBtn.Padding = btnPadding
-	pg.openOrdersBtn.Inset, pg.orderHistoryBtn.Inset = btnPadding, btnPadding
-	pg.openOrdersBtn.Font.Weight, pg.orderHistoryBtn.Font.Weight = font.SemiBold, font.SemiBold
+	pg.openOrdersBtn.Inset, pg.orderHistoryBtn.Inset, pg.triggerOrdersBtn.Inset = btnPadding, btnPadding, btnPadding
+	pg.openOrdersBtn.Font.Weight, pg.orderHistoryBtn.Font.Weight, pg.triggerOrdersBtn.Font.Weight = font.SemiBold, font.SemiBold, font.SemiBold
 
 	pg.orderTypesDropdown.CollapsedLayoutTextDirection = layout.E
+	pg.triggerTypeDropdown.CollapsedLayoutTextDirection = layout.E
 
 	pg.priceEditor.IsTitleLabel, pg.lotsEditor.IsTitleLabel, pg.totalEditor.IsTitleLabel, pg.amountEditor.IsTitleLabel = false, false, false, false
+	pg.triggerPriceEditor.IsTitleLabel = false
 
 	pg.amountEditor.Editor.ReadOnly = true
 	pg.totalEditor.Editor.ReadOnly = true
@@ -201,8 +229,6 @@ func NewDEXMarketPage(l *load.Load, selectServer string) *DEXMarketPage {
 	pg.seeFullOrderBookBtn.Font.Weight = font.SemiBold
 	pg.seeFullOrderBookBtn.Inset = layout.Inset{}
 
-	pg.immediateOrderCheckbox.Font.Weight = font.SemiBold
-
 	pg.refreshOrderForm()
 	return pg
 }
@@ -264,6 +290,10 @@ func (pg *DEXMarketPage) OnNavigatedTo() {
 						pg.notifyError(n.Details())
 					}
 
+					if pg.triggerOrdersDisplayed {
+						// Placed trigger orders change status.
+						pg.refreshTriggerOrders()
+					}
 					pg.refreshOrders()
 					pg.ParentWindow().Reload()
 				case core.NoteTypeBalance, core.NoteTypeSpots:
@@ -324,17 +354,14 @@ func (pg *DEXMarketPage) isDEXReset() bool {
 }
 
 func (pg *DEXMarketPage) resetServerAndMarkets() {
-	xcs := pg.AssetsManager.DexClient().Exchanges()
 	var servers []cryptomaterial.DropDownItem
-	for _, xc := range xcs {
+	for _, xc := range orderedExchanges(pg.AssetsManager, false) {
 		servers = append(servers, cryptomaterial.DropDownItem{
 			Text: xc.Host,
 		})
 	}
 
 	// Include the "Add Server" button as part of pg.serverSelector items.
-	// TODO: The pg.addServerBtn should open a modal or page to add a new server
-	// to DEX when clicked.
 	servers = append(servers, cryptomaterial.DropDownItem{
 		Text:             values.String(values.StrAddServer),
 		DisplayFn:        components.IconButton(pg.Theme.Icons.ContentAdd, values.String(values.StrAddServer), layout.Inset{}, pg.Theme, pg.addServerBtn),
@@ -352,7 +379,7 @@ func (pg *DEXMarketPage) setServerMarkets() {
 	// Set available market pairs.
 	dexc := pg.AssetsManager.DexClient()
 	var markets []cryptomaterial.DropDownItem
-	var lastSelectedItem *cryptomaterial.DropDownItem
+	var lastSelectedItem, preferredItem *cryptomaterial.DropDownItem
 	var serverIsDisconnected bool
 	if pg.serverSelector.Selected() != values.String(values.StrAddServer) {
 		host := pg.serverSelector.Selected()
@@ -371,11 +398,13 @@ func (pg *DEXMarketPage) setServerMarkets() {
 				}
 
 				marketItem := cryptomaterial.DropDownItem{
-					Text:      base.String() + "/" + quote.String(),
-					DisplayFn: pg.marketDropdownListItem(base, quote),
+					Text:      marketItemText(base, quote, ""),
+					DisplayFn: pg.marketDropdownListItem(base, quote, ""),
 				}
 
-				if dexc.HasWallet(int32(m.BaseID)) && dexc.HasWallet(int32(m.QuoteID)) {
+				if marketItem.Text == pg.lastSelectedMarket {
+					preferredItem = &marketItem
+				} else if dexc.HasWallet(int32(m.BaseID)) && dexc.HasWallet(int32(m.QuoteID)) {
 					lastSelectedItem = &marketItem
 				}
 
@@ -384,6 +413,10 @@ func (pg *DEXMarketPage) setServerMarkets() {
 		}
 	}
 
+	if preferredItem != nil {
+		lastSelectedItem = preferredItem
+	}
+
 	noMarketOrServerDisconnected := len(markets) == 0 || serverIsDisconnected
 	pg.noMarketOrServerDisconnected.Store(noMarketOrServerDisconnected)
 
@@ -398,12 +431,62 @@ func (pg *DEXMarketPage) setServerMarkets() {
 		}}
 	}
 
+	// Markets offered by other connected servers can be selected to switch to
+	// that server.
+	markets = append(markets, pg.otherServerMarkets()...)
+
 	pg.marketSelector = pg.Theme.NewCommonDropDown(markets, lastSelectedItem, cryptomaterial.MatchParent, values.DEXCurrencyPairGroup, false)
 	pg.fetchOrderBook()
 }
 
+// otherServerMarkets returns market selector items for the supported markets
+// of connected DEX servers other than the selected server.
+func (pg *DEXMarketPage) otherServerMarkets() []cryptomaterial.DropDownItem {
+	var markets []cryptomaterial.DropDownItem
+	for _, xc := range orderedExchanges(pg.AssetsManager, false) {
+		if xc.Host == pg.serverSelector.Selected() || xc.ConnectionStatus != comms.Connected {
+			continue
+		}
+
+		for _, m := range xc.Markets {
+			base, quote := convertAssetIDToAssetType(m.BaseID), convertAssetIDToAssetType(m.QuoteID)
+			if base == assetTypeNoAsset || quote == assetTypeNoAsset {
+				continue
+			}
+
+			markets = append(markets, cryptomaterial.DropDownItem{
+				Text:      marketItemText(base, quote, xc.Host),
+				DisplayFn: pg.marketDropdownListItem(base, quote, xc.Host),
+			})
+		}
+	}
+	return markets
+}
+
+// marketHostSeparator separates a market pair from the host of the server
+// offering the market in market selector items.
+const marketHostSeparator = " @ "
+
+// marketItemText returns the market selector text for the base/quote market.
+// host should be empty for markets of the selected server.
+func marketItemText(base, quote libutils.AssetType, host string) string {
+	text := base.String() + "/" + quote.String()
+	if host != "" {
+		text += marketHostSeparator + host
+	}
+	return text
+}
+
+// parseMarketItem returns the host, base and quote symbols of a market
+// selector item text. host is empty for markets of the selected server.
+func parseMarketItem(text string) (host, base, quote string) {
+	pair, host, _ := strings.Cut(text, marketHostSeparator)
+	base, quote, _ = strings.Cut(pair, "/")
+	return host, base, quote
+}
+
 func (pg *DEXMarketPage) fetchOrderBook() {
-	base, quote, _ := strings.Cut(pg.marketSelector.Selected(), "/")
+	_, base, quote := parseMarketItem(pg.marketSelector.Selected())
 	baseAssetID, _ := bip(strings.ToLower(base))
 	quoteAssetID, _ := bip(strings.ToLower(quote))
 	pg.selectedMarketOrderBook = orderbookInfo{
@@ -480,7 +563,7 @@ func (pg *DEXMarketPage) closeAndResetOrderbookListener() {
 	}
 }
 
-func (pg *DEXMarketPage) marketDropdownListItem(baseAsset, quoteAsset libutils.AssetType) func(gtx C) D {
+func (pg *DEXMarketPage) marketDropdownListItem(baseAsset, quoteAsset libutils.AssetType, host string) func(gtx C) D {
 	baseIcon, quoteIcon := assetIcon(pg.Theme, baseAsset), assetIcon(pg.Theme, quoteAsset)
 	return func(gtx cryptomaterial.C) cryptomaterial.D {
 		return layout.Flex{Axis: horizontal}.Layout(gtx,
@@ -513,6 +596,14 @@ func (pg *DEXMarketPage) marketDropdownListItem(baseAsset, quoteAsset libutils.A
 					}),
 				)
 			}),
+			layout.Rigid(func(gtx C) D {
+				if host == "" {
+					return D{}
+				}
+				lb := pg.Theme.Label(values.TextSize12, host)
+				lb.Color = pg.Theme.Color.GrayText2
+				return layout.Inset{Left: dp5}.Layout(gtx, lb.Layout)
+			}),
 		)
 	}
 }
@@ -589,7 +680,7 @@ func (pg *DEXMarketPage) serverAndCurrencySelection(gtx C) D {
 				return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
 					layout.Rigid(func(gtx C) D {
 						return layout.Flex{Axis: vertical}.Layout(gtx,
-							layout.Rigid(pg.semiBoldLabelText(values.String(values.StrServer)).Layout),
+							layout.Rigid(pg.serverLabel),
 							layout.Rigid(func(gtx C) D {
 								pg.serverSelector.Background = &pg.Theme.Color.Surface
 								pg.serverSelector.BorderColor = &pg.Theme.Color.Gray5
@@ -628,7 +719,7 @@ func (pg *DEXMarketPage) serverAndCurrencySelection(gtx C) D {
 		layout.Flexed(0.5, func(gtx C) D {
 			return layout.Inset{Left: values.MarginPadding10, Right: values.MarginPadding10}.Layout(gtx, func(gtx C) D {
 				return layout.Flex{Axis: vertical}.Layout(gtx,
-					layout.Rigid(pg.semiBoldLabelText(values.String(values.StrServer)).Layout),
+					layout.Rigid(pg.serverLabel),
 					layout.Rigid(func(gtx C) D {
 						pg.serverSelector.Background = &pg.Theme.Color.Surface
 						pg.serverSelector.BorderColor = &pg.Theme.Color.Gray5
@@ -652,6 +743,38 @@ func (pg *DEXMarketPage) serverAndCurrencySelection(gtx C) D {
 	)
 }
 
+// serverLabel displays the server selector label and links to manage DEX
+// servers and the bonds of the selected server.
+func (pg *DEXMarketPage) serverLabel(gtx C) D {
+	link := func(btn *cryptomaterial.Clickable, text string) layout.Widget {
+		return func(gtx C) D {
+			lb := pg.Theme.Label(values.TextSize14, text)
+			lb.Color = pg.Theme.Color.Primary
+			return btn.Layout(gtx, lb.Layout)
+		}
+	}
+
+	return layout.Flex{Axis: horizontal}.Layout(gtx,
+		layout.Rigid(pg.semiBoldLabelText(values.String(values.StrServer)).Layout),
+		layout.Flexed(1, func(gtx C) D {
+			return layout.E.Layout(gtx, func(gtx C) D {
+				return layout.Flex{Axis: horizontal}.Layout(gtx,
+					layout.Rigid(link(pg.manageServersBtn, values.String(values.StrManageServers))),
+					layout.Rigid(func(gtx C) D {
+						return layout.Inset{Left: dp10}.Layout(gtx, link(pg.dexWalletsBtn, values.String(values.StrDEXWallets)))
+					}),
+					layout.Rigid(func(gtx C) D {
+						if pg.xc == nil || pg.xc.ViewOnly || !pg.AssetsManager.DexClient().IsLoggedIn() {
+							return D{}
+						}
+						return layout.Inset{Left: dp10}.Layout(gtx, link(pg.manageBondsBtn, values.String(values.StrManageBonds)))
+					}),
+				)
+			})
+		}),
+	)
+}
+
 func (pg *DEXMarketPage) priceAndVolumeDetail(gtx C) D {
 	var change24, priceChange float64
 	marketRate, low24, high24, baseVol24, quoteVol24 := "------", "------", "------", "------", "------"
@@ -721,7 +844,8 @@ func (pg *DEXMarketPage) priceAndVolumeDetail(gtx C) D {
 }
 
 func (pg *DEXMarketPage) selectedMarketUSDRateTicker() *ext.Ticker {
-	return pg.AssetsManager.RateSource.GetTicker(rateSourceMarketName(pg.marketSelector.Selected()), true)
+	_, base, quote := parseMarketItem(pg.marketSelector.Selected())
+	return pg.AssetsManager.RateSource.GetTicker(rateSourceMarketName(base+"/"+quote), true)
 }
 
 func (pg *DEXMarketPage) selectedMarketInfo() (mkt *core.Market) {
@@ -968,15 +1092,44 @@ func (pg *DEXMarketPage) orderForm(gtx C) D {
 							)
 						}),
 						layout.Rigid(func(gtx C) D {
-							pg.immediateOrderCheckbox.Color = pg.Theme.Color.Text
 							return orderFormRow(gtx, horizontal, []layout.FlexChild{
-								layout.Rigid(pg.immediateOrderCheckbox.Layout),
 								layout.Rigid(func(gtx C) D {
-									return layout.Inset{Top: dp10, Left: dp2}.Layout(gtx, func(gtx C) D {
-										return pg.immediateOrderInfoBtn.Layout(gtx, pg.Theme.Icons.InfoAction.Layout16dp)
+									if pg.isMarketOrder() {
+										return D{} // Market orders are always immediate.
+									}
+									return layout.Flex{Axis: horizontal}.Layout(gtx,
+										layout.Rigid(pg.tifDropdown.Layout),
+										layout.Rigid(func(gtx C) D {
+											return layout.Inset{Top: dp10, Left: dp2}.Layout(gtx, func(gtx C) D {
+												return pg.immediateOrderInfoBtn.Layout(gtx, pg.Theme.Icons.InfoAction.Layout16dp)
+											})
+										}),
+									)
+								}),
+								layout.Flexed(1, func(gtx C) D {
+									return layout.E.Layout(gtx, func(gtx C) D {
+										return layout.Flex{Axis: horizontal}.Layout(gtx,
+											layout.Rigid(pg.triggerTypeDropdown.Layout),
+											layout.Rigid(func(gtx C) D {
+												return layout.Inset{Top: dp10, Left: dp2}.Layout(gtx, func(gtx C) D {
+													return pg.triggerInfoBtn.Layout(gtx, pg.Theme.Icons.InfoAction.Layout16dp)
+												})
+											}),
+										)
 									})
-								})},
-							)
+								}),
+							})
+						}),
+						layout.Rigid(func(gtx C) D {
+							if _, ok := pg.selectedTriggerType(); !ok {
+								return D{}
+							}
+							return orderFormRow(gtx, vertical, []layout.FlexChild{
+								layout.Rigid(func(gtx C) D {
+									return layout.Inset{Bottom: dp5}.Layout(gtx, pg.semiBoldLabelText(values.String(values.StrTriggerPrice)).Layout)
+								}),
+								layout.Rigid(pg.triggerPriceEditor.Layout),
+							})
 						}),
 						layout.Rigid(func(gtx C) D {
 							pg.createOrderBtn.SetEnabled(pg.hasValidOrderInfo())
@@ -1326,27 +1479,46 @@ func (pg *DEXMarketPage) openOrdersAndHistory(gtx C) D {
 		Orientation: vertical,
 	}.Layout(gtx,
 		layout.Rigid(func(gtx C) D {
-			gr2 := pg.Theme.Color.Gray2
-			pg.openOrdersBtn.HighlightColor, pg.orderHistoryBtn.HighlightColor = gr2, gr2
-			if pg.openOrdersDisplayed {
-				pg.openOrdersBtn.Background = gr2
-				pg.openOrdersBtn.Color = pg.Theme.Color.GrayText1
-				pg.orderHistoryBtn.Background = pg.Theme.Color.SurfaceHighlight
-				pg.orderHistoryBtn.Color = pg.Theme.Color.Text
-			} else {
-				pg.openOrdersBtn.Background = pg.Theme.Color.SurfaceHighlight
-				pg.openOrdersBtn.Color = pg.Theme.Color.Text
-				pg.orderHistoryBtn.Background = gr2
-				pg.orderHistoryBtn.Color = pg.Theme.Color.GrayText1
-			}
+			pg.setTabButtonStyle(&pg.openOrdersBtn, pg.openOrdersDisplayed)
+			pg.setTabButtonStyle(&pg.orderHistoryBtn, !pg.openOrdersDisplayed && !pg.triggerOrdersDisplayed)
+			pg.setTabButtonStyle(&pg.triggerOrdersBtn, pg.triggerOrdersDisplayed)
 			return layout.Flex{Axis: horizontal}.Layout(gtx,
 				layout.Rigid(func(gtx C) D {
 					return layout.Inset{Left: dp5, Right: dp10}.Layout(gtx, pg.openOrdersBtn.Layout)
 				}),
-				layout.Rigid(pg.orderHistoryBtn.Layout),
+				layout.Rigid(func(gtx C) D {
+					return layout.Inset{Right: dp10}.Layout(gtx, pg.orderHistoryBtn.Layout)
+				}),
+				layout.Rigid(pg.triggerOrdersBtn.Layout),
+				layout.Flexed(1, func(gtx C) D {
+					return layout.E.Layout(gtx, func(gtx C) D {
+						return layout.Flex{Axis: horizontal}.Layout(gtx,
+							layout.Rigid(func(gtx C) D {
+								if !pg.openOrdersDisplayed || len(pg.orders) == 0 {
+									return D{}
+								}
+								lb := pg.Theme.Label(values.TextSize14, values.String(values.StrCancelAll))
+								lb.Color = pg.Theme.Color.Danger
+								return layout.Inset{Right: dp20}.Layout(gtx, func(gtx C) D {
+									return pg.cancelAllBtn.Layout(gtx, lb.Layout)
+								})
+							}),
+							layout.Rigid(func(gtx C) D {
+								lb := pg.Theme.Label(values.TextSize14, values.String(values.StrViewAllTrades))
+								lb.Color = pg.Theme.Color.Primary
+								return layout.Inset{Right: dp5}.Layout(gtx, func(gtx C) D {
+									return pg.viewAllTradesBtn.Layout(gtx, lb.Layout)
+								})
+							}),
+						)
+					})
+				}),
 			)
 		}),
 		layout.Rigid(func(gtx C) D {
+			if pg.triggerOrdersDisplayed {
+				return pg.triggerOrdersTable(gtx, sectionHeight)
+			}
 			return pg.Theme.List(pg.ordersTableHorizontalScroll).Layout(gtx, 1, func(gtx C, _ int) D {
 				gtx.Constraints.Max.X = gtx.Dp(sectionWidth)
 				gtx.Constraints.Min.X = gtx.Constraints.Max.X
@@ -1379,7 +1551,7 @@ func (pg *DEXMarketPage) openOrdersAndHistory(gtx C) D {
 									return D{}
 								}),
 								layout.Rigid(func(gtx C) D {
-									orderReader := pg.orderReader(ord.Order)
+									orderReader := newOrderReader(pg.AssetsManager.DexClient(), ord.Order)
 									return layout.Flex{Axis: horizontal, Spacing: layout.SpaceBetween, Alignment: layout.Middle}.Layout(gtx,
 										pg.orderColumn(false, fmt.Sprintf("%s %s", values.String(ord.Type.String()), values.String(orderReader.SideString())), columnWidth, index),
 										pg.orderColumn(false, ord.MarketID, columnWidth, index),
@@ -1410,6 +1582,123 @@ func (pg *DEXMarketPage) openOrdersAndHistory(gtx C) D {
 	)
 }
 
+// setTabButtonStyle highlights btn if its tab is the one being displayed.
+func (pg *DEXMarketPage) setTabButtonStyle(btn *cryptomaterial.Button, selected bool) {
+	btn.HighlightColor = pg.Theme.Color.Gray2
+	if selected {
+		btn.Background = pg.Theme.Color.Gray2
+		btn.Color = pg.Theme.Color.GrayText1
+		return
+	}
+	btn.Background = pg.Theme.Color.SurfaceHighlight
+	btn.Color = pg.Theme.Color.Text
+}
+
+func (pg *DEXMarketPage) triggerOrdersTable(gtx C, sectionHeight int) D {
+	headers := []string{values.String(values.StrType), values.String(values.StrPair), values.String(values.StrAge), values.String(values.StrTriggerPrice), values.String(values.StrPrice), values.String(values.StrAmount), values.String(values.StrStatus), ""}
+	sectionWidth := values.DP950
+	columnWidth := sectionWidth / unit.Dp(len(headers))
+	sepWidth := sectionWidth - values.MarginPadding60
+
+	column := func(header bool, txt string, removeBtn *cryptomaterial.Clickable) layout.FlexChild {
+		return layout.Rigid(func(gtx C) D {
+			padding := layout.Inset{Top: dp16, Bottom: dp16}
+			if removeBtn != nil {
+				padding = layout.Inset{Top: dp8, Bottom: dp8}
+			}
+			return cryptomaterial.LinearLayout{
+				Width:       gtx.Dp(columnWidth),
+				Height:      cryptomaterial.WrapContent,
+				Orientation: horizontal,
+				Alignment:   layout.Middle,
+				Padding:     padding,
+				Direction:   layout.Center,
+			}.Layout2(gtx, func(gtx C) D {
+				if header {
+					return semiBoldGray3Size14(pg.Theme, txt).Layout(gtx)
+				} else if removeBtn != nil {
+					return removeBtn.Layout(gtx, pg.Theme.Icons.FailedIcon.Layout24dp)
+				}
+				lb := pg.Theme.Body2(txt)
+				lb.Color = pg.Theme.Color.Text
+				return lb.Layout(gtx)
+			})
+		})
+	}
+
+	var headersFn []layout.FlexChild
+	for _, header := range headers {
+		headersFn = append(headersFn, column(true, header, nil))
+	}
+
+	return pg.Theme.List(pg.ordersTableHorizontalScroll).Layout(gtx, 1, func(gtx C, _ int) D {
+		gtx.Constraints.Max.X = gtx.Dp(sectionWidth)
+		gtx.Constraints.Min.X = gtx.Constraints.Max.X
+		gtx.Constraints.Max.Y = sectionHeight
+		gtx.Constraints.Min.Y = gtx.Constraints.Max.Y
+		return layout.Flex{Axis: vertical, Alignment: layout.Middle}.Layout(gtx,
+			layout.Rigid(func(gtx C) D {
+				return layout.Flex{Axis: horizontal, Spacing: layout.SpaceBetween, Alignment: layout.Middle}.Layout(gtx, headersFn...)
+			}),
+			layout.Rigid(func(gtx C) D {
+				if len(pg.triggerOrders) == 0 {
+					return components.LayoutNoOrderHistoryWithMsg(gtx, pg.Load, pg.showLoader, values.String(values.StrNoTriggerOrdersMsg))
+				}
+
+				return pg.Theme.List(pg.openOrdersAndOrderHistoryContainer).Layout(gtx, len(pg.triggerOrders), func(gtx C, index int) D {
+					ord := pg.triggerOrders[index]
+					form := ord.Form
+					side := values.String(values.StrBuy)
+					if form.Sell {
+						side = values.String(values.StrSell)
+					}
+					baseSym, quoteSym := strings.ToUpper(dex.BipIDSymbol(form.Base)), strings.ToUpper(dex.BipIDSymbol(form.Quote))
+					price := values.String(values.StrMarket)
+					if form.IsLimit {
+						price = trimmedConventionalAmtString(conventionalAmt(form.Rate))
+					}
+					status := ord.Status.String()
+					if ord.Error != "" {
+						status = fmt.Sprintf("%s: %s", status, ord.Error)
+					}
+
+					return layout.Flex{Axis: vertical}.Layout(gtx,
+						layout.Rigid(func(gtx C) D {
+							if index == 0 {
+								sep := pg.Theme.Separator()
+								sep.Width = gtx.Dp(sepWidth)
+								return layout.Center.Layout(gtx, sep.Layout)
+							}
+							return D{}
+						}),
+						layout.Rigid(func(gtx C) D {
+							return layout.Flex{Axis: horizontal, Spacing: layout.SpaceBetween, Alignment: layout.Middle}.Layout(gtx,
+								column(false, fmt.Sprintf("%s %s", triggerTypeString(ord.Type), side), nil),
+								column(false, fmt.Sprintf("%s-%s", baseSym, quoteSym), nil),
+								column(false, pageutils.TimeAgo(ord.CreatedAt.Unix()), nil),
+								column(false, trimmedConventionalAmtString(conventionalAmt(ord.TriggerRate)), nil),
+								column(false, price, nil),
+								column(false, fmt.Sprintf("%s %s", trimmedConventionalAmtString(conventionalAmt(form.Qty)), baseSym), nil),
+								column(false, status, nil),
+								column(false, "", ord.removeBtn),
+							)
+						}),
+						layout.Rigid(func(gtx C) D {
+							// No divider for last row
+							if index == len(pg.triggerOrders)-1 {
+								return D{}
+							}
+							sep := pg.Theme.Separator()
+							sep.Width = gtx.Dp(sepWidth)
+							return layout.Center.Layout(gtx, sep.Layout)
+						}),
+					)
+				})
+			}),
+		)
+	})
+}
+
 func semiBoldGray3Size14(th *cryptomaterial.Theme, text string) cryptomaterial.Label {
 	lb := th.Label(values.TextSize14, text)
 	lb.Color = th.Color.GrayText3
@@ -1529,6 +1818,13 @@ func (pg *DEXMarketPage) handleEditorEvents(gtx C) {
 		}
 	}
 
+	for pg.triggerPriceEditor.Changed() {
+		pg.triggerPriceEditor.SetError("")
+		if pg.triggerPriceEditor.Editor.Text() != "" && pg.triggerRate() == 0 {
+			pg.triggerPriceEditor.SetError(values.String(values.StrInvalidTriggerPrice))
+		}
+	}
+
 	// Handle updates to lots Editor.
 	for pg.lotsEditor.Changed() && pg.lotsEditor.IsFocused() {
 		pg.lotsEditor.SetError("")
@@ -1584,40 +1880,64 @@ func (pg *DEXMarketPage) HandleUserInteractions(gtx C) {
 
 	dexc := pg.AssetsManager.DexClient()
 	if pg.serverSelector.Changed(gtx) {
-		selectedServer := pg.serverSelector.Selected()
-		xc, err := dexc.Exchange(selectedServer)
-		if err != nil && xc.Auth.EffectiveTier == 0 /* need to post bond now */ {
-			pg.ParentNavigator().ClearStackAndDisplay(NewDEXOnboarding(pg.Load, selectedServer, nil))
-		} else {
-			pg.lastSelectedDEXServer = selectedServer
-			pg.setServerMarkets()
-		}
+		// Servers without an effective tier display the post bond button in
+		// place of the order form.
+		pg.lastSelectedDEXServer = pg.serverSelector.Selected()
+		pg.lastSelectedMarket = ""
+		pg.setServerMarkets()
 	}
 
-	if pg.addServerBtn.Clicked(gtx) {
-		pg.ParentNavigator().ClearStackAndDisplay(NewDEXOnboarding(pg.Load, "", func() {
-			pg.ParentNavigator().ClearStackAndDisplay(NewDEXMarketPage(pg.Load, ""))
-		}))
+	if pg.addServerBtn.Clicked(gtx) || pg.manageServersBtn.Clicked(gtx) {
+		pg.ParentNavigator().Display(NewDEXServersPage(pg.Load))
+	}
+
+	if pg.dexWalletsBtn.Clicked(gtx) {
+		pg.ParentNavigator().Display(NewDEXWalletsPage(pg.Load))
+	}
+
+	if pg.manageBondsBtn.Clicked(gtx) {
+		pg.ParentNavigator().Display(NewDEXBondsPage(pg.Load, pg.serverSelector.Selected()))
 	}
 
 	if pg.openOrdersBtn.Clicked(gtx) {
 		pg.orders = nil // clear orders
-		pg.openOrdersDisplayed = true
+		pg.openOrdersDisplayed, pg.triggerOrdersDisplayed = true, false
 		go pg.refreshOrders()
 	}
 
 	if pg.marketSelector.Changed(gtx) {
-		pg.fetchOrderBook()
+		if host, base, quote := parseMarketItem(pg.marketSelector.Selected()); host != "" {
+			// Switch to the server offering the selected market.
+			pg.serverSelector.SetSelectedValue(host)
+			pg.lastSelectedDEXServer = host
+			pg.lastSelectedMarket = base + "/" + quote
+			pg.setServerMarkets()
+		} else {
+			pg.fetchOrderBook()
+		}
 		pg.refreshOrderForm()
 		pg.setMaxBuyAndMaxSell()
 	}
 
+	if pg.viewAllTradesBtn.Clicked(gtx) {
+		pg.ParentNavigator().Display(NewDEXTradeHistoryPage(pg.Load))
+	}
+
 	if pg.orderHistoryBtn.Clicked(gtx) {
 		pg.orders = nil // clear orders
-		pg.openOrdersDisplayed = false
+		pg.openOrdersDisplayed, pg.triggerOrdersDisplayed = false, false
 		go pg.refreshOrders()
 	}
 
+	if pg.triggerOrdersBtn.Clicked(gtx) {
+		pg.openOrdersDisplayed, pg.triggerOrdersDisplayed = false, true
+		go pg.refreshTriggerOrders()
+	}
+
+	if pg.cancelAllBtn.Clicked(gtx) {
+		pg.showCancelAllModal()
+	}
+
 	if pg.seeFullOrderBookBtn.Clicked(gtx) {
 		// TODO: display full order book
 		log.Info("button click listener for full order book view is not implemented")
@@ -1647,6 +1967,18 @@ func (pg *DEXMarketPage) HandleUserInteractions(gtx C) {
 		pg.ParentWindow().ShowModal(infoModal)
 	}
 
+	if pg.triggerInfoBtn.Clicked(gtx) {
+		infoModal := modal.NewCustomModal(pg.Load).
+			Title(values.String(values.StrTriggerOrders)).
+			UseCustomWidget(func(gtx layout.Context) layout.Dimensions {
+				return pg.Theme.Body2(values.String(values.StrTriggerOrderExplanation)).Layout(gtx)
+			}).
+			SetCancelable(true).
+			SetContentAlignment(layout.W, layout.W, layout.Center).
+			SetPositiveButtonText(values.String(values.StrOk))
+		pg.ParentWindow().ShowModal(infoModal)
+	}
+
 	// TODO: postBondBtn should open a separate page when its design is ready.
 	if pg.postBondBtn.Clicked(gtx) {
 		pg.ParentNavigator().ClearStackAndDisplay(NewDEXOnboarding(pg.Load, pg.serverSelector.Selected(), nil))
@@ -1676,12 +2008,24 @@ func (pg *DEXMarketPage) HandleUserInteractions(gtx C) {
 		}
 	}
 
+	for _, ord := range pg.triggerOrders {
+		if ord.removeBtn.Clicked(gtx) {
+			if err := dexc.RemoveTriggerOrder(ord.ID); err != nil {
+				pg.notifyError(err.Error())
+				continue
+			}
+			go pg.refreshTriggerOrders()
+		}
+	}
+
 	if pg.createOrderBtn.Clicked(gtx) {
 		orderForm := pg.validatedOrderFormInfo()
 		if orderForm == nil {
 			return
 		}
 
+		triggerType, isTriggerOrder := pg.selectedTriggerType()
+		triggerRate := pg.triggerRate()
 		pg.showLoader = true
 		dexPasswordModal := modal.NewCreatePasswordModal(pg.Load).
 			EnableName(false).
@@ -1691,7 +2035,7 @@ func (pg *DEXMarketPage) HandleUserInteractions(gtx C) {
 				var err error
 				defer func() {
 					if err != nil {
-						pm.SetError(err.Error())
+						pm.SetError(values.TranslateErr(err.Error()))
 					}
 					pg.showLoader = false
 				}()
@@ -1701,9 +2045,18 @@ func (pg *DEXMarketPage) HandleUserInteractions(gtx C) {
 					return false
 				}
 
-				_, err = dexc.Trade([]byte(password), orderForm)
-				if err != nil {
-					return false
+				if isTriggerOrder {
+					_, err = dexc.AddTriggerOrder([]byte(password), triggerType, triggerRate, orderForm)
+					if err != nil {
+						return false
+					}
+					pg.Toast.Notify(values.String(values.StrTriggerOrderSaved))
+					go pg.refreshTriggerOrders()
+				} else {
+					_, err = dexc.Trade([]byte(password), orderForm)
+					if err != nil {
+						return false
+					}
 				}
 
 				// Clear the trade form to allow for another trade entry
@@ -1744,7 +2097,7 @@ func (pg *DEXMarketPage) validatedOrderFormInfo() *core.TradeForm {
 		Qty:     mkt.ConventionalRateToMsg(lots * mkt.MsgRateToConventional(mkt.LotSize)),
 		Base:    mkt.BaseID,
 		Quote:   mkt.QuoteID,
-		TifNow:  pg.immediateOrderCheckbox.CheckBox.Value,
+		TifNow:  pg.isImmediateOrder(),
 	}
 
 	if orderForm.IsLimit {
@@ -1940,6 +2293,80 @@ func (pg *DEXMarketPage) refreshOrders() {
 	})
 }
 
+func (pg *DEXMarketPage) refreshTriggerOrders() {
+	orders, err := pg.AssetsManager.DexClient().TriggerOrders()
+	if err != nil {
+		pg.notifyError(err.Error())
+		return
+	}
+
+	triggerOrders := make([]*clickableTriggerOrder, 0, len(orders))
+	for _, ord := range orders {
+		triggerOrders = append(triggerOrders, &clickableTriggerOrder{
+			TriggerOrder: ord,
+			removeBtn:    pg.Theme.NewClickable(false),
+		})
+	}
+	pg.triggerOrders = triggerOrders
+	pg.ParentWindow().Reload()
+}
+
+// showCancelAllModal confirms and cancels all open orders of the selected
+// market.
+func (pg *DEXMarketPage) showCancelAllModal() {
+	mkt := pg.selectedMarketInfo()
+	if mkt == nil {
+		return
+	}
+
+	host := pg.serverSelector.Selected()
+	cancelAllModal := modal.NewCustomModal(pg.Load).
+		Title(values.String(values.StrCancelAllOrdersTitle)).
+		Body(values.StringF(values.StrCancelAllOrdersDesc, pg.formatSelectedMarketAsDEXMarketName(), host)).
+		SetNegativeButtonText(values.String(values.StrNo)).
+		SetPositiveButtonText(values.String(values.StrYes)).
+		SetPositiveButtonCallback(func(_ bool, _ *modal.InfoModal) bool {
+			n, err := pg.AssetsManager.DexClient().CancelAll(host, &dexc.TradeMarket{BaseID: mkt.BaseID, QuoteID: mkt.QuoteID})
+			if err != nil {
+				pg.notifyError(err.Error())
+			}
+			if n > 0 {
+				pg.Toast.Notify(values.StringF(values.StrOrdersCanceledFmt, n))
+			}
+			go pg.refreshOrders()
+			return true
+		})
+	pg.ParentWindow().ShowModal(cancelAllModal)
+}
+
+// timeInForceTypes returns the time in force options of limit orders.
+func timeInForceTypes() []cryptomaterial.DropDownItem {
+	return []cryptomaterial.DropDownItem{
+		{Text: values.String(values.StrGoodTillCanceled)},
+		{Text: values.String(values.StrImmediateOrCancel)},
+	}
+}
+
+// triggerTypes returns the trigger options of orders.
+func triggerTypes() []cryptomaterial.DropDownItem {
+	return []cryptomaterial.DropDownItem{
+		{Text: values.String(values.StrNoTrigger)},
+		{Text: values.String(values.StrStopLoss)},
+		{Text: values.String(values.StrTakeProfit)},
+	}
+}
+
+func triggerTypeString(t dexc.TriggerType) string {
+	switch t {
+	case dexc.TriggerStopLoss:
+		return values.String(values.StrStopLoss)
+	case dexc.TriggerTakeProfit:
+		return values.String(values.StrTakeProfit)
+	default:
+		return t.String()
+	}
+}
+
 func anyMatchActive(matches []*core.Match) bool {
 	for _, m := range matches {
 		if m.Active {
@@ -1963,6 +2390,10 @@ func (pg *DEXMarketPage) hasValidOrderInfo() bool {
 		return false
 	}
 
+	if _, isTriggerOrder := pg.selectedTriggerType(); isTriggerOrder && pg.triggerRate() == 0 {
+		return false
+	}
+
 	// Fetch wallet balance from dex and ensure wallet can fund dex order.
 	walletBalance, _ := pg.availableWalletAccountBalance(!pg.isSellOrder())
 	return orderPriceIsOk && orderAmt < walletBalance
@@ -2034,6 +2465,36 @@ func (pg *DEXMarketPage) calculateOrderAmount(mkt *core.Market) bool {
 	return true
 }
 
+// isImmediateOrder returns true if the limit order should not be booked if it
+// is not fully matched in the next match cycle.
+func (pg *DEXMarketPage) isImmediateOrder() bool {
+	return !pg.isMarketOrder() && pg.tifDropdown.Selected() == values.String(values.StrImmediateOrCancel)
+}
+
+// selectedTriggerType returns the selected trigger type and false if the order
+// should be placed immediately.
+func (pg *DEXMarketPage) selectedTriggerType() (dexc.TriggerType, bool) {
+	switch pg.triggerTypeDropdown.Selected() {
+	case values.String(values.StrStopLoss):
+		return dexc.TriggerStopLoss, true
+	case values.String(values.StrTakeProfit):
+		return dexc.TriggerTakeProfit, true
+	default:
+		return 0, false
+	}
+}
+
+// triggerRate returns the message rate of the trigger price or zero if the
+// trigger price is invalid.
+func (pg *DEXMarketPage) triggerRate() uint64 {
+	mkt := pg.selectedMarketInfo()
+	price, err := strconv.ParseFloat(pg.triggerPriceEditor.Editor.Text(), 64)
+	if mkt == nil || err != nil || price <= 0 {
+		return 0
+	}
+	return mkt.ConventionalRateToMsg(price)
+}
+
 func (pg *DEXMarketPage) isMarketOrder() bool {
 	return pg.orderTypesDropdown.Selected() == values.String(values.StrMarket)
 }
@@ -8,6 +8,7 @@ import (
 	"decred.org/dcrdex/client/asset"
 	"decred.org/dcrdex/client/core"
 	"decred.org/dcrdex/dex"
+	"github.com/crypto-power/cryptopower/libwallet"
 )
 
 var (
@@ -27,13 +28,16 @@ func defaultUnitInfo(symbol string) dex.UnitInfo {
 	}
 }
 
-func (pg *DEXMarketPage) orderReader(ord *core.Order) *core.OrderReader {
+func newOrderReader(dexClient libwallet.DEXClient, ord *core.Order) *core.OrderReader {
 	unitInfo := func(assetID uint32, symbol string) dex.UnitInfo {
 		unitInfo, err := asset.UnitInfo(assetID)
 		if err == nil {
 			return unitInfo
 		}
-		xc := pg.AssetsManager.DexClient().Exchanges()[ord.Host]
+		xc, err := dexClient.Exchange(ord.Host)
+		if err != nil {
+			return defaultUnitInfo(symbol)
+		}
 		a, found := xc.Assets[assetID]
 		if !found || a.UnitInfo.Conventional.ConversionFactor == 0 {
 			return defaultUnitInfo(symbol)
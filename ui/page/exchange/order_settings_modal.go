@@ -202,6 +202,10 @@ func (osm *orderSettingsModal) Handle(gtx C) {
 		osm.feeRateSelector.OnEditRateClicked(osm.sourceWalletSelector.SelectedWallet())
 	}
 
+	if osm.feeRateSelector.FetchedRateChanged(gtx) {
+		osm.feeRateSelector.OnFetchedRateSelected(osm.sourceWalletSelector.SelectedWallet())
+	}
+
 	if osm.sourceWalletSelector != nil {
 		osm.sourceWalletSelector.Handle(gtx)
 	}
@@ -2,6 +2,7 @@ package root
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"os"
@@ -19,6 +20,7 @@ import (
 
 	"github.com/crypto-power/cryptopower/app"
 	"github.com/crypto-power/cryptopower/appos"
+	"github.com/crypto-power/cryptopower/device"
 	sharedW "github.com/crypto-power/cryptopower/libwallet/assets/wallet"
 	libutils "github.com/crypto-power/cryptopower/libwallet/utils"
 	"github.com/crypto-power/cryptopower/ui/cryptomaterial"
@@ -38,6 +40,10 @@ import (
 
 const (
 	HomePageID = "Home"
+
+	// syncConditionsInterval is how often the network and power conditions
+	// of mobile devices are checked against the sync policy.
+	syncConditionsInterval = 30 * time.Second
 )
 
 var totalBalanceUSD string
@@ -191,6 +197,11 @@ func (hp *HomePage) OnNavigatedTo() {
 		hp.CurrentPage().OnNavigatedTo()
 	}
 
+	// Report the network and power conditions the sync policy applies to
+	// before the wallets start syncing.
+	hp.reportSyncConditions()
+	go hp.monitorSyncConditions()
+
 	// Initiate the auto sync for all wallets with autosync set.
 	allWallets := hp.AssetsManager.AllWallets()
 	for _, wallet := range allWallets {
@@ -596,6 +607,16 @@ func (hp *HomePage) OnCurrencyChanged() {
 	go hp.CalculateAssetsUSDBalance()
 }
 
+// OnAppLocked is triggered when the app is locked after a period of
+// inactivity.
+// Satisfies the load.AppLockHandler interface.
+func (hp *HomePage) OnAppLocked() {
+	hp.isBalanceHidden = true
+	if currentPage, ok := hp.CurrentPage().(load.AppLockHandler); ok {
+		currentPage.OnAppLocked()
+	}
+}
+
 // OnNavigatedFrom is called when the page is about to be removed from
 // the displayed window. This method should ideally be used to disable
 // features that are irrelevant when the page is NOT displayed.
@@ -1003,6 +1024,47 @@ func (hp *HomePage) notificationSettingsLayout(gtx C) D {
 	)
 }
 
+// reportSyncConditions reports the network and power conditions of the
+// device to the sync policy. Conditions that can't be read don't restrict the
+// sync.
+func (hp *HomePage) reportSyncConditions() {
+	metered, meteredErr := hp.Load.Device.IsMeteredNetwork()
+	if meteredErr != nil && !errors.Is(meteredErr, device.ErrNotAvailable) {
+		log.Errorf("Error checking if the network is metered: %v", meteredErr)
+	}
+	charging, chargingErr := hp.Load.Device.IsCharging()
+	if chargingErr != nil && !errors.Is(chargingErr, device.ErrNotAvailable) {
+		log.Errorf("Error checking if the device is charging: %v", chargingErr)
+	}
+	if meteredErr != nil && chargingErr != nil {
+		return
+	}
+	hp.AssetsManager.SetSyncConditions(sharedW.SyncConditions{
+		Metered:   meteredErr == nil && metered,
+		OnBattery: chargingErr == nil && !charging,
+	})
+}
+
+// monitorSyncConditions reports the conditions of the device every
+// syncConditionsInterval until the page is navigated from, for the wallets
+// to be paused or resumed as they change.
+func (hp *HomePage) monitorSyncConditions() {
+	if !appos.Current().IsMobile() {
+		return
+	}
+
+	ticker := time.NewTicker(syncConditionsInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-hp.ctx.Done():
+			return
+		case <-ticker.C:
+			hp.reportSyncConditions()
+		}
+	}
+}
+
 func (hp *HomePage) startSyncing(wallet sharedW.Asset, unlock load.NeedUnlockRestore) {
 	// Watchonly wallets do not have any password neither need one.
 	if !wallet.ContainsDiscoveredAccounts() && wallet.IsLocked() && !wallet.IsWatchingOnlyWallet() {
@@ -1016,6 +1078,10 @@ func (hp *HomePage) startSyncing(wallet sharedW.Asset, unlock load.NeedUnlockRes
 		// start the wallet sync.
 		if err := wallet.SpvSync(); err != nil {
 			log.Debugf("Error starting sync: %v", err)
+			if hp.AssetsManager.SyncPausedReason() != nil {
+				// The wallet syncs once the sync policy allows it.
+				hp.Toast.Notify(values.TranslateErr(err.Error()))
+			}
 		}
 	}
 
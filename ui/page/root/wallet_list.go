@@ -32,6 +32,9 @@ func (pg *WalletSelectorPage) loadWallets() {
 			wallet:       wal,
 			totalBalance: balance.Total,
 		}
+		if cfg := wal.MultisigConfig(); cfg != nil {
+			listItem.multisig = values.StringF(values.StrMultisigBadgeFmt, cfg.String())
+		}
 
 		walletsList[wal.GetAssetType()] = append(walletsList[wal.GetAssetType()], listItem)
 	}
@@ -103,6 +106,9 @@ func (pg *WalletSelectorPage) syncStatusIconAndText(wallet sharedW.Asset) (*cryp
 	case wallet.IsSyncing():
 		syncStatusIcon = pg.Theme.Icons.SyncingIcon
 		syncStatus = values.String(values.StrSyncingState)
+		if progress := pg.AssetsManager.WalletSyncProgress(wallet.GetWalletID()); progress != nil {
+			syncStatus = values.StringF(values.StrSyncingPercentFmt, progress.Percent)
+		}
 	default:
 		syncStatusIcon = pg.Theme.Icons.NotSynced
 		syncStatus = values.String(values.StrWalletNotSynced)
@@ -285,6 +291,16 @@ func (pg *WalletSelectorPage) layoutNameAndBalance(gtx C, item *walletWithBalanc
 			}
 			return D{}
 		}),
+		layout.Rigid(func(gtx C) D {
+			if item.multisig == "" {
+				return D{}
+			}
+			return layout.Inset{
+				Left: values.MarginPadding8,
+			}.Layout(gtx, func(gtx C) D {
+				return components.WalletHighlightLabel(pg.Theme, gtx, values.TextSize12, item.multisig)
+			})
+		}),
 		layout.Flexed(1, func(gtx C) D {
 			return layout.E.Layout(gtx, func(gtx C) D {
 				totalbalanceStr := item.totalBalance.String()
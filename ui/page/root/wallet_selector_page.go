@@ -43,6 +43,8 @@ type showNavigationFunc func(showNavigation bool)
 type walletWithBalance struct {
 	wallet       sharedW.Asset
 	totalBalance sharedW.AssetAmount
+	// multisig is the m-of-n description of multisig wallets.
+	multisig string
 }
 
 type WalletSelectorPage struct {
@@ -52,6 +52,7 @@ type SaveSeedPage struct {
 	seedList     *widget.List
 	hexLabel     cryptomaterial.Label
 	copy         cryptomaterial.Button
+	splitSeed    cryptomaterial.Button
 
 	infoText string
 	seed     string
@@ -69,6 +70,7 @@ func NewSaveSeedPage(l *load.Load, wallet sharedW.Asset, redirect Redirectfunc)
 		wallet:           wallet,
 		hexLabel:         l.Theme.Label(values.TextSize12, ""),
 		copy:             l.Theme.Button(values.String(values.StrCopy)),
+		splitSeed:        l.Theme.OutlineButton(values.String(values.StrSplitIntoShares)),
 		infoText:         values.String(values.StrAskedEnterSeedWords),
 		actionButton:     l.Theme.Button(""),
 		seedList: &widget.List{
@@ -90,6 +92,7 @@ func NewSaveSeedPage(l *load.Load, wallet sharedW.Asset, redirect Redirectfunc)
 	pg.backButton.Icon = l.Theme.Icons.ContentClear
 
 	pg.actionButton.Font.Weight = font.Medium
+	pg.splitSeed.Font.Weight = font.Medium
 	pg.pageContainer = &widget.List{
 		List: layout.List{
 			Axis:      layout.Vertical,
@@ -188,6 +191,10 @@ func (pg *SaveSeedPage) HandleUserInteractions(gtx C) {
 	if pg.actionButton.Clicked(gtx) {
 		pg.ParentNavigator().Display(NewVerifySeedPage(pg.Load, pg.wallet, pg.seed, pg.wordSeedType, pg.redirectCallback))
 	}
+
+	if pg.splitSeed.Clicked(gtx) {
+		pg.ParentNavigator().Display(NewSeedSharesPage(pg.Load, pg.wallet, pg.seed, pg.wordSeedType, pg.redirectCallback))
+	}
 }
 
 // OnNavigatedFrom is called when the page is about to be removed from
@@ -240,6 +247,10 @@ func (pg *SaveSeedPage) Layout(gtx C) D {
 						)
 					}),
 					layout.Rigid(pg.hexLayout),
+					layout.Rigid(func(gtx C) D {
+						gtx.Constraints.Min.X = gtx.Constraints.Max.X
+						return pg.splitSeed.Layout(gtx)
+					}),
 					layout.Rigid(layout.Spacer{Height: values.MarginPadding130}.Layout),
 				)
 			})
@@ -0,0 +1,186 @@
+package seedbackup
+
+import (
+	"strings"
+
+	"gioui.org/font"
+	"gioui.org/layout"
+	"gioui.org/widget"
+
+	"github.com/crypto-power/cryptopower/app"
+	sharedW "github.com/crypto-power/cryptopower/libwallet/assets/wallet"
+	"github.com/crypto-power/cryptopower/libwallet/utils"
+	"github.com/crypto-power/cryptopower/ui/cryptomaterial"
+	"github.com/crypto-power/cryptopower/ui/load"
+	"github.com/crypto-power/cryptopower/ui/modal"
+	"github.com/crypto-power/cryptopower/ui/page/components"
+	"github.com/crypto-power/cryptopower/ui/values"
+)
+
+const SeedCheckPageID = "seed_check"
+
+// SeedCheckPage asks for a few words of the wallet seed picked at random to
+// check the user still has the seed, without showing it.
+type SeedCheckPage struct {
+	*load.Load
+	// GenericPageModal defines methods such as ID() and OnAttachedToNavigator()
+	// that helps this Page satisfy the app.Page interface. It also defines
+	// helper methods for accessing the PageNavigator that displayed this page
+	// and the root WindowNavigator.
+	*app.GenericPageModal
+
+	wallet        sharedW.Asset
+	password      string
+	positions     []int
+	wordEditors   []cryptomaterial.Editor
+	pageContainer *widget.List
+
+	backButton   cryptomaterial.IconButton
+	actionButton cryptomaterial.Button
+
+	redirectCallback Redirectfunc
+}
+
+func NewSeedCheckPage(l *load.Load, wallet sharedW.Asset, redirect Redirectfunc) *SeedCheckPage {
+	pg := &SeedCheckPage{
+		Load:             l,
+		GenericPageModal: app.NewGenericPageModal(SeedCheckPageID),
+		wallet:           wallet,
+		actionButton:     l.Theme.Button(values.String(values.StrVerify)),
+		pageContainer: &widget.List{
+			List: layout.List{Axis: layout.Vertical},
+		},
+		redirectCallback: redirect,
+	}
+
+	pg.actionButton.Font.Weight = font.Medium
+	pg.backButton = components.GetBackButton(l)
+	pg.backButton.Icon = l.Theme.Icons.ContentClear
+
+	return pg
+}
+
+// OnNavigatedTo is called when the page is about to be displayed and
+// may be used to initialize page features that are only relevant when
+// the page is displayed.
+// Part of the load.Page interface.
+func (pg *SeedCheckPage) OnNavigatedTo() {
+	if pg.positions != nil {
+		return
+	}
+
+	passwordModal := modal.NewCreatePasswordModal(pg.Load).
+		EnableName(false).
+		EnableConfirmPassword(false).
+		Title(values.String(values.StrConfirmToVerifySeed)).
+		SetPositiveButtonCallback(func(_, password string, m *modal.CreatePasswordModal) bool {
+			positions, err := pg.wallet.SeedCheckPositions(password)
+			if err != nil {
+				m.SetError(err.Error())
+				m.ParentWindow().Reload()
+				return false
+			}
+			m.Dismiss()
+
+			pg.password = password
+			pg.positions = positions
+			pg.wordEditors = make([]cryptomaterial.Editor, len(positions))
+			for i, position := range positions {
+				pg.wordEditors[i] = pg.Theme.Editor(new(widget.Editor), values.StringF(values.StrSeedWordX, position))
+				pg.wordEditors[i].Editor.SingleLine = true
+			}
+			return true
+		}).
+		SetNegativeButtonCallback(func() {
+			pg.redirectCallback(pg.Load, pg.ParentWindow())
+		}).
+		SetCancelable(false)
+	pg.ParentWindow().ShowModal(passwordModal)
+}
+
+// HandleUserInteractions is called just before Layout() to determine
+// if any user interaction recently occurred on the page and may be
+// used to update the page's UI components shortly before they are
+// displayed.
+// Part of the load.Page interface.
+func (pg *SeedCheckPage) HandleUserInteractions(gtx C) {
+	allEntered := len(pg.wordEditors) > 0
+	for _, editor := range pg.wordEditors {
+		allEntered = allEntered && strings.TrimSpace(editor.Editor.Text()) != ""
+	}
+	pg.actionButton.SetEnabled(allEntered)
+
+	if pg.actionButton.Clicked(gtx) && allEntered {
+		pg.verifyWords()
+	}
+}
+
+func (pg *SeedCheckPage) verifyWords() {
+	words := make(map[int]string, len(pg.positions))
+	for i, position := range pg.positions {
+		words[position] = pg.wordEditors[i].Editor.Text()
+	}
+
+	if _, err := pg.wallet.VerifySeedWords(words, pg.password); err != nil {
+		msg := err.Error()
+		if msg == utils.ErrInvalid {
+			msg = values.String(values.StrSeedValidationFailed)
+		}
+		errModal := modal.NewErrorModal(pg.Load, msg, modal.DefaultClickFunc())
+		pg.ParentWindow().ShowModal(errModal)
+		return
+	}
+
+	pg.password = ""
+	successModal := modal.NewSuccessModal(pg.Load, values.String(values.StrSeedCheckPassed), func(_ bool, m *modal.InfoModal) bool {
+		pg.redirectCallback(pg.Load, m.ParentWindow())
+		return true
+	})
+	pg.ParentWindow().ShowModal(successModal)
+}
+
+// OnNavigatedFrom is called when the page is about to be removed from
+// the displayed window. This method should ideally be used to disable
+// features that are irrelevant when the page is NOT displayed.
+// NOTE: The page may be re-displayed on the app's window, in which case
+// OnNavigatedTo() will be called again. This method should not destroy UI
+// components unless they'll be recreated in the OnNavigatedTo() method.
+// Part of the load.Page interface.
+func (pg *SeedCheckPage) OnNavigatedFrom() {}
+
+// Layout draws the page UI components into the provided layout context
+// to be eventually drawn on screen.
+// Part of the load.Page interface.
+func (pg *SeedCheckPage) Layout(gtx C) D {
+	sp := components.SubPage{
+		Load:       pg.Load,
+		Title:      values.String(values.StrSeedCheck),
+		BackButton: pg.backButton,
+		Back: func() {
+			pg.redirectCallback(pg.Load, pg.ParentWindow())
+		},
+		Body: func(gtx C) D {
+			return pg.Theme.List(pg.pageContainer).Layout(gtx, 1, func(gtx C, _ int) D {
+				children := []layout.FlexChild{
+					layout.Rigid(func(gtx C) D {
+						label := pg.Theme.Label(values.TextSize16, values.String(values.StrSeedCheckDesc))
+						label.Color = pg.Theme.Color.GrayText1
+						return layout.Inset{Bottom: values.MarginPadding16}.Layout(gtx, label.Layout)
+					}),
+				}
+				for i := range pg.wordEditors {
+					editor := pg.wordEditors[i]
+					children = append(children, layout.Rigid(func(gtx C) D {
+						return layout.Inset{Bottom: values.MarginPadding16}.Layout(gtx, editor.Layout)
+					}))
+				}
+				children = append(children, layout.Rigid(layout.Spacer{Height: values.MarginPadding130}.Layout))
+				return layout.Flex{Axis: layout.Vertical}.Layout(gtx, children...)
+			})
+		},
+	}
+	layout := func(gtx C) D {
+		return sp.Layout(pg.ParentWindow(), gtx)
+	}
+	return container(gtx, pg.IsMobileView(), *pg.Theme, layout, "", pg.actionButton, true)
+}
@@ -0,0 +1,288 @@
+package seedbackup
+
+import (
+	"strconv"
+	"strings"
+
+	"gioui.org/font"
+	"gioui.org/layout"
+	"gioui.org/widget"
+
+	"github.com/crypto-power/cryptopower/app"
+	sharedW "github.com/crypto-power/cryptopower/libwallet/assets/wallet"
+	"github.com/crypto-power/cryptopower/ui/cryptomaterial"
+	"github.com/crypto-power/cryptopower/ui/load"
+	"github.com/crypto-power/cryptopower/ui/modal"
+	"github.com/crypto-power/cryptopower/ui/page/components"
+	"github.com/crypto-power/cryptopower/ui/values"
+)
+
+const SeedSharesPageID = "seed_shares"
+
+// SeedSharesPage splits the wallet seed into shares, shows them one at a time
+// for the user to write down and then asks for each of them to verify they
+// were written down correctly.
+type SeedSharesPage struct {
+	*load.Load
+	// GenericPageModal defines methods such as ID() and OnAttachedToNavigator()
+	// that helps this Page satisfy the app.Page interface. It also defines
+	// helper methods for accessing the PageNavigator that displayed this page
+	// and the root WindowNavigator.
+	*app.GenericPageModal
+
+	wallet       sharedW.Asset
+	seed         string
+	wordSeedType sharedW.WordSeedType
+
+	pageContainer  *widget.List
+	backButton     cryptomaterial.IconButton
+	actionButton   cryptomaterial.Button
+	sharesEditor   cryptomaterial.Editor
+	requiredEditor cryptomaterial.Editor
+	shareEditor    cryptomaterial.Editor
+
+	shares    []string
+	threshold int
+	// shareIndex is the share being shown or verified.
+	shareIndex int
+	verifying  bool
+
+	redirectCallback Redirectfunc
+}
+
+func NewSeedSharesPage(l *load.Load, wallet sharedW.Asset, seed string, wordSeedType sharedW.WordSeedType, redirect Redirectfunc) *SeedSharesPage {
+	pg := &SeedSharesPage{
+		Load:             l,
+		GenericPageModal: app.NewGenericPageModal(SeedSharesPageID),
+		wallet:           wallet,
+		seed:             seed,
+		wordSeedType:     wordSeedType,
+		actionButton:     l.Theme.Button(""),
+		pageContainer: &widget.List{
+			List: layout.List{Axis: layout.Vertical},
+		},
+		redirectCallback: redirect,
+	}
+
+	pg.actionButton.Font.Weight = font.Medium
+	pg.backButton = components.GetBackButton(l)
+	pg.backButton.Icon = l.Theme.Icons.ContentClear
+
+	pg.sharesEditor = l.Theme.Editor(new(widget.Editor), values.String(values.StrNumberOfShares))
+	pg.sharesEditor.Editor.SingleLine = true
+	pg.sharesEditor.Editor.SetText("3")
+	pg.requiredEditor = l.Theme.Editor(new(widget.Editor), values.String(values.StrSharesRequired))
+	pg.requiredEditor.Editor.SingleLine = true
+	pg.requiredEditor.Editor.SetText("2")
+	pg.shareEditor = l.Theme.Editor(new(widget.Editor), values.String(values.StrEnterSeedShare))
+	pg.shareEditor.Editor.SingleLine = false
+
+	return pg
+}
+
+// OnNavigatedTo is called when the page is about to be displayed and
+// may be used to initialize page features that are only relevant when
+// the page is displayed.
+// Part of the load.Page interface.
+func (pg *SeedSharesPage) OnNavigatedTo() {}
+
+// HandleUserInteractions is called just before Layout() to determine
+// if any user interaction recently occurred on the page and may be
+// used to update the page's UI components shortly before they are
+// displayed.
+// Part of the load.Page interface.
+func (pg *SeedSharesPage) HandleUserInteractions(gtx C) {
+	if pg.verifying {
+		pg.actionButton.SetEnabled(strings.TrimSpace(pg.shareEditor.Editor.Text()) != "")
+	}
+
+	if !pg.actionButton.Clicked(gtx) {
+		return
+	}
+
+	switch {
+	case pg.shares == nil:
+		pg.createShares()
+	case !pg.verifying:
+		pg.shareIndex++
+		if pg.shareIndex == len(pg.shares) {
+			pg.shareIndex = 0
+			pg.verifying = true
+		}
+	default:
+		pg.verifyShare()
+	}
+}
+
+func (pg *SeedSharesPage) createShares() {
+	shares, _ := strconv.Atoi(pg.sharesEditor.Editor.Text())
+	threshold, _ := strconv.Atoi(pg.requiredEditor.Editor.Text())
+	if shares > sharedW.MaxSeedShares || threshold < 2 || threshold > shares {
+		pg.requiredEditor.SetError(values.String(values.StrInvalidSeedShareSettings))
+		return
+	}
+	pg.requiredEditor.SetError("")
+
+	var err error
+	pg.shares, err = sharedW.SplitSeed(pg.seed, pg.wordSeedType, threshold, shares)
+	if err != nil {
+		pg.shares = nil
+		errModal := modal.NewErrorModal(pg.Load, err.Error(), modal.DefaultClickFunc())
+		pg.ParentWindow().ShowModal(errModal)
+		return
+	}
+	pg.threshold = threshold
+	pg.shareIndex = 0
+}
+
+func (pg *SeedSharesPage) verifyShare() {
+	entered := strings.Join(strings.Fields(strings.ToLower(pg.shareEditor.Editor.Text())), " ")
+	if entered != pg.shares[pg.shareIndex] {
+		pg.shareEditor.SetError(values.String(values.StrSeedShareMismatch))
+		return
+	}
+	pg.shareEditor.SetError("")
+	pg.shareEditor.Editor.SetText("")
+
+	if pg.shareIndex+1 < len(pg.shares) {
+		pg.shareIndex++
+		return
+	}
+
+	// Every share was written down correctly, make sure they restore the
+	// seed before marking the wallet as backed up.
+	seed, _, err := sharedW.CombineSeedShares(pg.shares[:pg.threshold])
+	if err != nil || seed != pg.seed {
+		errModal := modal.NewErrorModal(pg.Load, values.String(values.StrSeedValidationFailed), modal.DefaultClickFunc())
+		pg.ParentWindow().ShowModal(errModal)
+		return
+	}
+
+	passwordModal := modal.NewCreatePasswordModal(pg.Load).
+		EnableName(false).
+		EnableConfirmPassword(false).
+		Title(values.String(values.StrConfirmToVerifySeed)).
+		SetPositiveButtonCallback(func(_, password string, m *modal.CreatePasswordModal) bool {
+			if _, err := pg.wallet.VerifySeedForWallet(seed, password); err != nil {
+				m.SetError(err.Error())
+				m.ParentWindow().Reload()
+				return false
+			}
+
+			pg.ParentNavigator().Display(NewBackupSuccessPage(pg.Load, pg.redirectCallback))
+			return true
+		})
+	pg.ParentWindow().ShowModal(passwordModal)
+}
+
+// OnNavigatedFrom is called when the page is about to be removed from
+// the displayed window. This method should ideally be used to disable
+// features that are irrelevant when the page is NOT displayed.
+// NOTE: The page may be re-displayed on the app's window, in which case
+// OnNavigatedTo() will be called again. This method should not destroy UI
+// components unless they'll be recreated in the OnNavigatedTo() method.
+// Part of the load.Page interface.
+func (pg *SeedSharesPage) OnNavigatedFrom() {}
+
+// Layout draws the page UI components into the provided layout context
+// to be eventually drawn on screen.
+// Part of the load.Page interface.
+func (pg *SeedSharesPage) Layout(gtx C) D {
+	var body layout.Widget
+	switch {
+	case pg.shares == nil:
+		pg.actionButton.Text = values.String(values.StrCreateShares)
+		body = pg.settingsLayout
+	case !pg.verifying:
+		pg.actionButton.Text = values.String(values.StrNext)
+		body = pg.shareLayout
+	default:
+		pg.actionButton.Text = values.String(values.StrVerify)
+		body = func(gtx C) D {
+			return pg.Theme.Card().Layout(gtx, func(gtx C) D {
+				return layout.UniformInset(values.MarginPadding16).Layout(gtx, pg.shareEditor.Layout)
+			})
+		}
+	}
+
+	sp := components.SubPage{
+		Load:       pg.Load,
+		Title:      values.String(values.StrSeedShares),
+		SubTitle:   pg.stepText(),
+		BackButton: pg.backButton,
+		Back: func() {
+			promptToExit(pg.Load, pg.ParentWindow(), pg.redirectCallback)
+		},
+		Body: func(gtx C) D {
+			return pg.Theme.List(pg.pageContainer).Layout(gtx, 1, func(gtx C, _ int) D {
+				return layout.Inset{Bottom: values.MarginPadding130}.Layout(gtx, body)
+			})
+		},
+	}
+	layout := func(gtx C) D {
+		return sp.Layout(pg.ParentWindow(), gtx)
+	}
+	return container(gtx, pg.IsMobileView(), *pg.Theme, layout, "", pg.actionButton, true)
+}
+
+func (pg *SeedSharesPage) stepText() string {
+	switch {
+	case pg.shares == nil:
+		return ""
+	case !pg.verifying:
+		return values.StringF(values.StrWriteDownShareX, pg.shareIndex+1, len(pg.shares))
+	default:
+		return values.StringF(values.StrVerifyShareX, pg.shareIndex+1, len(pg.shares))
+	}
+}
+
+func (pg *SeedSharesPage) settingsLayout(gtx C) D {
+	threshold, _ := strconv.Atoi(pg.requiredEditor.Editor.Text())
+	shares, _ := strconv.Atoi(pg.sharesEditor.Editor.Text())
+	return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+		layout.Rigid(func(gtx C) D {
+			label := pg.Theme.Label(values.TextSize16, values.StringF(values.StrSeedSharesDesc, threshold, shares))
+			label.Color = pg.Theme.Color.GrayText1
+			return label.Layout(gtx)
+		}),
+		layout.Rigid(layout.Spacer{Height: values.MarginPadding16}.Layout),
+		layout.Rigid(pg.sharesEditor.Layout),
+		layout.Rigid(layout.Spacer{Height: values.MarginPadding16}.Layout),
+		layout.Rigid(pg.requiredEditor.Layout),
+	)
+}
+
+func (pg *SeedSharesPage) shareLayout(gtx C) D {
+	words := strings.Fields(pg.shares[pg.shareIndex])
+	columns := 3
+	if pg.IsMobileView() {
+		columns = 2
+	}
+	rows := divideWordsIntoRows(words, columns)
+	itemWidth := gtx.Constraints.Max.X / columns
+
+	children := make([]layout.FlexChild, 0, len(rows))
+	for _, row := range rows {
+		index := row.rowIndex + 1
+		children = append(children, layout.Rigid(func(gtx C) D {
+			return cryptomaterial.LinearLayout{
+				Width:  cryptomaterial.MatchParent,
+				Height: cryptomaterial.WrapContent,
+				Margin: layout.Inset{Top: values.MarginPadding8},
+			}.Layout(gtx,
+				seedItem(pg.Theme, itemWidth, index, row.word1),
+				seedItem(pg.Theme, itemWidth, index+len(rows), row.word2),
+				seedItem(pg.Theme, itemWidth, index+2*len(rows), row.word3),
+			)
+		}))
+	}
+
+	return cryptomaterial.LinearLayout{
+		Width:       cryptomaterial.MatchParent,
+		Height:      cryptomaterial.WrapContent,
+		Orientation: layout.Vertical,
+		Background:  pg.Theme.Color.Surface,
+		Border:      cryptomaterial.Border{Radius: cryptomaterial.Radius(8)},
+		Padding:     layout.Inset{Top: values.MarginPadding8, Right: values.MarginPadding16, Bottom: values.MarginPadding16, Left: values.MarginPadding16},
+	}.Layout(gtx, children...)
+}
@@ -143,10 +143,28 @@ func (pg *Page) sendLayout(gtx C) D {
 					return pg.accountDropdown.Layout(gtx, values.String(values.StrSourceAccount))
 				})
 			}),
+			layout.Rigid(pg.dexFundsWarningLayout),
 		)
 	})
 }
 
+// dexFundsWarningLayout warns that some of the source account funds are
+// locked by DEX orders, swaps or bonds and cannot be sent.
+func (pg *Page) dexFundsWarningLayout(gtx C) D {
+	funds := pg.dexFunds
+	if funds == nil || pg.selectedWallet == nil {
+		return D{}
+	}
+
+	amount := func(atoms uint64) string {
+		return pg.selectedWallet.ToAmount(int64(atoms)).String()
+	}
+	warning := pg.Theme.Label(values.TextSizeTransform(pg.IsMobileView(), values.TextSize14),
+		values.StringF(values.StrDEXAccountSendWarning, amount(funds.OrderLocked), amount(funds.ContractLocked), amount(funds.BondLocked)))
+	warning.Color = pg.Theme.Color.Danger
+	return layout.Inset{Top: values.MarginPadding8}.Layout(gtx, warning.Layout)
+}
+
 func (pg *Page) titleLayout(gtx C) D {
 	return layout.Flex{Alignment: layout.Middle}.Layout(gtx,
 		layout.Rigid(func(gtx C) D {
@@ -9,6 +9,7 @@ import (
 	"gioui.org/widget"
 
 	"github.com/crypto-power/cryptopower/app"
+	"github.com/crypto-power/cryptopower/dexc"
 	sharedW "github.com/crypto-power/cryptopower/libwallet/assets/wallet"
 	libUtil "github.com/crypto-power/cryptopower/libwallet/utils"
 	"github.com/crypto-power/cryptopower/ui/cryptomaterial"
@@ -74,6 +75,7 @@ type Page struct {
 	advanceOptions  *cryptomaterial.Collapsible
 
 	selectedUTXOs      selectedUTXOsInfo
+	dexFunds           *dexc.WalletFunds
 	navigateToSyncBtn  cryptomaterial.Button
 	currentIDRecipient int
 }
@@ -144,6 +146,13 @@ func NewSendPage(l *load.Load, wallet sharedW.Asset) *Page {
 	return pg
 }
 
+// SetSourceAccount selects the account funds are sent from. The account is
+// not selected if it cannot be used as a source account.
+func (pg *Page) SetSourceAccount(accountNumber int32) *Page {
+	pg.accountDropdown.SelectAccount(accountNumber)
+	return pg
+}
+
 func (pg *Page) addRecipient() {
 	if pg.selectedWallet == nil {
 		return
@@ -207,6 +216,7 @@ func (pg *Page) initModalWalletSelector(wallet sharedW.Asset) {
 
 	pg.accountDropdown = components.NewAccountDropdown(pg.Load).
 		SetChangedCallback(func(account *sharedW.Account) {
+			pg.updateDEXFunds()
 			pg.initAccountsSelectorForRecipients(account)
 			pg.validateAllRecipientsAmount()
 			pg.validateAndConstructTx()
@@ -281,6 +291,7 @@ func (pg *Page) OnNavigatedTo() {
 	}
 
 	pg.walletDropdown.ListenForTxNotifications(pg.ParentWindow()) // listener is stopped in OnNavigatedFrom()
+	pg.updateDEXFunds()
 
 	pg.usdExchangeSet = false
 	if pg.AssetsManager.ExchangeRateFetchingEnabled() {
@@ -291,7 +302,7 @@ func (pg *Page) OnNavigatedTo() {
 		pg.validateAndConstructTx()
 	}
 
-	if pg.selectedWallet.GetAssetType() == libUtil.BTCWalletAsset && pg.isFeerateAPIApproved() {
+	if pg.selectedWallet.GetAssetType() != libUtil.DCRWalletAsset && pg.isFeerateAPIApproved() {
 		// This API call may take sometime to return. Call this before and cache
 		// results.
 		// TODO: @Wisdom Why was this line necessary?
@@ -333,6 +344,25 @@ func (pg *Page) fetchExchangeRate() {
 	pg.ParentWindow().Reload()
 }
 
+// updateDEXFunds checks if the source account is used by the DEX client so
+// that the funds locked by DEX trading can be displayed.
+func (pg *Page) updateDEXFunds() {
+	pg.dexFunds = nil
+	if pg.selectedWallet == nil || pg.accountDropdown == nil {
+		return
+	}
+
+	account := pg.accountDropdown.SelectedAccount()
+	if account == nil {
+		return
+	}
+
+	funds := pg.AssetsManager.DEXAccountFunds(pg.selectedWallet.GetWalletID(), account.Number)
+	if funds != nil && funds.DEXLocked() > 0 {
+		pg.dexFunds = funds
+	}
+}
+
 func (pg *Page) validateAndConstructTx() {
 	// delete all the previous errors set earlier.
 	pg.cleanAllRecipientErrors()
@@ -383,7 +413,7 @@ func (pg *Page) constructTx() {
 	// populate display data
 	pg.txFee = wal.ToAmount(feeAtom).String()
 
-	pg.feeRateSelector.EstSignedSize = fmt.Sprintf("%d Bytes", feeAndSize.EstimatedSignedSize)
+	pg.feeRateSelector.SetEstimatedSize(feeAndSize.EstimatedSignedSize)
 	pg.feeRateSelector.TxFee = pg.txFee
 	pg.feeRateSelector.SetFeerate(feeAndSize.FeeRate)
 	pg.totalCost = totalCost.String()
@@ -586,6 +616,11 @@ func (pg *Page) HandleUserInteractions(gtx C) {
 		pg.feeRateSelector.OnEditRateClicked(pg.selectedWallet)
 	}
 
+	if pg.feeRateSelector.FetchedRateChanged(gtx) {
+		pg.feeRateSelector.OnFetchedRateSelected(pg.selectedWallet)
+		pg.validateAndConstructTx()
+	}
+
 	pg.nextButton.SetEnabled(pg.allRecipientsIsValid())
 
 	if pg.infoButton.Button.Clicked(gtx) {
@@ -3,6 +3,8 @@ package send
 import (
 	"fmt"
 	"image"
+	"strings"
+	"time"
 
 	"gioui.org/font"
 	"gioui.org/io/key"
@@ -14,6 +16,7 @@ import (
 	"gioui.org/widget/material"
 
 	sharedW "github.com/crypto-power/cryptopower/libwallet/assets/wallet"
+	"github.com/crypto-power/cryptopower/libwallet/utils"
 	"github.com/crypto-power/cryptopower/ui/cryptomaterial"
 	"github.com/crypto-power/cryptopower/ui/load"
 	"github.com/crypto-power/cryptopower/ui/modal"
@@ -90,6 +93,14 @@ func (scm *sendConfirmModal) broadcastTransaction() {
 	go func() {
 		defer scm.setLoading(false)
 		txHash, err := scm.asset.Broadcast(password, scm.txLabel)
+		if err != nil && err.Error() == utils.ErrNewDestination {
+			scm.confirmNewDestinations()
+			return
+		}
+		if err != nil && err.Error() == utils.ErrSendDelayed {
+			scm.showSendDelayed()
+			return
+		}
 		if err != nil {
 			scm.SetError(err.Error())
 			scm.confirmButton.SetEnabled(false)
@@ -107,6 +118,42 @@ func (scm *sendConfirmModal) broadcastTransaction() {
 	}()
 }
 
+// confirmNewDestinations asks the user to confirm the destinations the wallet
+// never sent to, as required by its spending policy, and sends once they do.
+func (scm *sendConfirmModal) confirmNewDestinations() {
+	confirmModal := modal.NewCustomModal(scm.Load).
+		Title(values.String(values.StrNewDestination)).
+		Body(values.StringF(values.StrNewDestinationMsg, strings.Join(scm.destinationAddress, ", "))).
+		SetNegativeButtonText(values.String(values.StrCancel)).
+		SetPositiveButtonText(values.String(values.StrSend)).
+		SetPositiveButtonCallback(func(_ bool, _ *modal.InfoModal) bool {
+			if err := scm.asset.ApproveDestinations(scm.destinationAddress); err != nil {
+				scm.SetError(err.Error())
+				return true
+			}
+			scm.broadcastTransaction()
+			return true
+		})
+	scm.ParentWindow().ShowModal(confirmModal)
+}
+
+// showSendDelayed tells the user the spending policy of the wallet holds the
+// send back until its cancel window is over.
+func (scm *sendConfirmModal) showSendDelayed() {
+	var broadcastAt int64
+	for _, send := range scm.asset.DelayedSends() {
+		broadcastAt = max(broadcastAt, send.BroadcastAt)
+	}
+	broadcastTime := time.Unix(broadcastAt, 0).Format("Jan 2, 2006 15:04")
+
+	infoModal := modal.NewSuccessModal(scm.Load, values.String(values.StrSendDelayed), modal.DefaultClickFunc()).
+		Body(values.StringF(values.StrSendDelayedMsg, broadcastTime))
+	scm.ParentWindow().ShowModal(infoModal)
+
+	scm.txSent()
+	scm.Dismiss()
+}
+
 func (scm *sendConfirmModal) Handle(gtx C) {
 	if scm.passwordEditor.Changed() {
 		scm.confirmButton.SetEnabled(scm.passwordEditor.Editor.Text() != "")
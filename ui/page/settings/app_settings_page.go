@@ -1,9 +1,12 @@
 package settings
 
 import (
+	"fmt"
 	"image/color"
 	"io"
+	"path/filepath"
 	"regexp"
+	"strconv"
 	"strings"
 	"time"
 
@@ -15,6 +18,7 @@ import (
 	"gioui.org/widget"
 
 	"github.com/crypto-power/cryptopower/app"
+	"github.com/crypto-power/cryptopower/appos"
 	sharedW "github.com/crypto-power/cryptopower/libwallet/assets/wallet"
 	"github.com/crypto-power/cryptopower/libwallet/ext"
 	libutils "github.com/crypto-power/cryptopower/libwallet/utils"
@@ -52,6 +56,7 @@ type AppSettingsPage struct {
 	pageContainer *widget.List
 
 	changeStartupPass       *cryptomaterial.Clickable
+	autoLock                *cryptomaterial.Clickable
 	network                 *cryptomaterial.Clickable
 	language                *cryptomaterial.Clickable
 	currency                *cryptomaterial.Clickable
@@ -60,6 +65,12 @@ type AppSettingsPage struct {
 	appearanceMode          *cryptomaterial.Clickable
 	startupPassword         *cryptomaterial.Switch
 	transactionNotification *cryptomaterial.Switch
+	autoWalletDBBackup      *cryptomaterial.Switch
+	syncUnmeteredOnly       *cryptomaterial.Switch
+	syncChargingOnly        *cryptomaterial.Switch
+	syncDataBudget          *cryptomaterial.Clickable
+	encryptMetadata         *cryptomaterial.Switch
+	duressPassword          *cryptomaterial.Switch
 	backButton              cryptomaterial.IconButton
 	infoButton              cryptomaterial.IconButton
 	networkInfoButton       cryptomaterial.IconButton
@@ -67,6 +78,8 @@ type AppSettingsPage struct {
 	viewLog                 *cryptomaterial.Clickable
 	deleteDEX               *cryptomaterial.Clickable
 	backupDEX               *cryptomaterial.Clickable
+	exportAppBackup         *cryptomaterial.Clickable
+	restoreAppBackup        *cryptomaterial.Clickable
 	copyDEXSeed             cryptomaterial.Button
 	dexSeed                 dex.Bytes
 
@@ -91,6 +104,11 @@ func NewAppSettingsPage(l *load.Load) *AppSettingsPage {
 
 		startupPassword:         l.Theme.Switch(),
 		transactionNotification: l.Theme.Switch(),
+		autoWalletDBBackup:      l.Theme.Switch(),
+		syncUnmeteredOnly:       l.Theme.Switch(),
+		syncChargingOnly:        l.Theme.Switch(),
+		encryptMetadata:         l.Theme.Switch(),
+		duressPassword:          l.Theme.Switch(),
 		governanceAPI:           l.Theme.Switch(),
 		exchangeAPI:             l.Theme.Switch(),
 		feeRateAPI:              l.Theme.Switch(),
@@ -99,6 +117,8 @@ func NewAppSettingsPage(l *load.Load) *AppSettingsPage {
 		privacyActive:           l.Theme.Switch(),
 
 		changeStartupPass: l.Theme.NewClickable(false),
+		autoLock:          l.Theme.NewClickable(false),
+		syncDataBudget:    l.Theme.NewClickable(false),
 		network:           l.Theme.NewClickable(false),
 		language:          l.Theme.NewClickable(false),
 		currency:          l.Theme.NewClickable(false),
@@ -109,6 +129,8 @@ func NewAppSettingsPage(l *load.Load) *AppSettingsPage {
 		viewLog:           l.Theme.NewClickable(false),
 		deleteDEX:         l.Theme.NewClickable(false),
 		backupDEX:         l.Theme.NewClickable(false),
+		exportAppBackup:   l.Theme.NewClickable(false),
+		restoreAppBackup:  l.Theme.NewClickable(false),
 		copyDEXSeed:       l.Theme.Button(values.String(values.StrCopy)),
 	}
 
@@ -217,6 +239,7 @@ func (pg *AppSettingsPage) pageHeaderLayout(gtx C) layout.Dimensions {
 func (pg *AppSettingsPage) pageContentLayout(gtx C) D {
 	pageContent := []func(gtx C) D{
 		pg.general(),
+		pg.syncSettings(),
 		pg.networkSettings(),
 		pg.dexSettings(),
 		pg.security(),
@@ -342,6 +365,42 @@ func (pg *AppSettingsPage) general() layout.Widget {
 	}
 }
 
+func (pg *AppSettingsPage) syncSettings() layout.Widget {
+	return func(gtx C) D {
+		return pg.wrapSection(gtx, values.String(values.StrSyncPolicy), func(gtx C) D {
+			return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+				layout.Rigid(func(gtx C) D {
+					// Only mobile devices report their network and power
+					// conditions.
+					if !appos.Current().IsMobile() {
+						return D{}
+					}
+					return pg.subSectionSwitch(gtx, values.String(values.StrSyncUnmeteredOnly), pg.syncUnmeteredOnly)
+				}),
+				layout.Rigid(func(gtx C) D {
+					if !appos.Current().IsMobile() {
+						return D{}
+					}
+					return pg.subSectionSwitch(gtx, values.String(values.StrSyncChargingOnly), pg.syncChargingOnly)
+				}),
+				layout.Rigid(func(gtx C) D {
+					budget := preference.SyncDataBudgetKey(pg.AssetsManager.SyncPolicy().MonthlyDataBudget)
+					budgetRow := row{
+						title:     values.String(values.StrSyncDataBudget),
+						clickable: pg.syncDataBudget,
+						label:     pg.Theme.Body2(preference.GetKeyValue(budget, preference.SyncDataBudgetOptions())),
+					}
+					return pg.clickableRow(gtx, budgetRow)
+				}),
+				layout.Rigid(func(gtx C) D {
+					usage := float64(pg.AssetsManager.SyncDataUsage()) / 1e6
+					return pg.subSection(gtx, values.String(values.StrSyncDataUsage), pg.Theme.Body2(fmt.Sprintf("%.1f MB", usage)).Layout)
+				}),
+			)
+		})
+	}
+}
+
 func (pg *AppSettingsPage) networkSettings() layout.Widget {
 	return func(gtx C) D {
 		return pg.wrapSection(gtx, values.String(values.StrPrivacySettings), func(gtx C) D {
@@ -426,6 +485,46 @@ func (pg *AppSettingsPage) security() layout.Widget {
 					}
 					return D{}
 				}),
+				layout.Rigid(func(gtx C) D {
+					if pg.isStartupPassword {
+						return pg.subSectionSwitch(gtx, values.String(values.StrEncryptMetadata), pg.encryptMetadata)
+					}
+					return D{}
+				}),
+				layout.Rigid(func(gtx C) D {
+					if pg.isStartupPassword {
+						return pg.subSectionSwitch(gtx, values.String(values.StrDuressPassword), pg.duressPassword)
+					}
+					return D{}
+				}),
+				layout.Rigid(func(gtx C) D {
+					minutes := strconv.Itoa(int(pg.AssetsManager.GetAutoLockTimeout() / time.Minute))
+					autoLockRow := row{
+						title:     values.String(values.StrAutoLock),
+						clickable: pg.autoLock,
+						label:     pg.Theme.Body2(preference.GetKeyValue(minutes, preference.AutoLockOptions())),
+					}
+					return pg.clickableRow(gtx, autoLockRow)
+				}),
+				layout.Rigid(func(gtx C) D {
+					return pg.subSectionSwitch(gtx, values.String(values.StrAutoWalletDBBackup), pg.autoWalletDBBackup)
+				}),
+				layout.Rigid(func(gtx C) D {
+					exportAppBackupRow := row{
+						title:     values.String(values.StrExportAppBackup),
+						clickable: pg.exportAppBackup,
+						label:     pg.Theme.Body1(""),
+					}
+					return pg.clickableRow(gtx, exportAppBackupRow)
+				}),
+				layout.Rigid(func(gtx C) D {
+					restoreAppBackupRow := row{
+						title:     values.String(values.StrRestoreAppBackup),
+						clickable: pg.restoreAppBackup,
+						label:     pg.Theme.Body1(""),
+					}
+					return pg.clickableRow(gtx, restoreAppBackupRow)
+				}),
 			)
 		})
 	}
@@ -558,6 +657,15 @@ func (pg *AppSettingsPage) HandleUserInteractions(gtx C) {
 		pg.ParentWindow().ShowModal(currencySelectorModal)
 	}
 
+	if pg.autoLock.Clicked(gtx) {
+		autoLockSelectorModal := preference.NewListPreference(pg.Load,
+			sharedW.AutoLockTimeoutConfigKey, "0",
+			preference.AutoLockOptions()).
+			Title(values.StrAutoLock).
+			UpdateValues(func(_ string) {})
+		pg.ParentWindow().ShowModal(autoLockSelectorModal)
+	}
+
 	if pg.appearanceMode.Clicked(gtx) {
 		pg.isDarkModeOn = !pg.isDarkModeOn
 		pg.AssetsManager.SetDarkMode(pg.isDarkModeOn)
@@ -567,6 +675,26 @@ func (pg *AppSettingsPage) HandleUserInteractions(gtx C) {
 	if pg.transactionNotification.Changed(gtx) {
 		pg.AssetsManager.SetTransactionsNotifications(pg.transactionNotification.IsChecked())
 	}
+
+	if pg.autoWalletDBBackup.Changed(gtx) {
+		pg.AssetsManager.SetAutoWalletDBBackup(pg.autoWalletDBBackup.IsChecked())
+	}
+
+	if pg.syncUnmeteredOnly.Changed(gtx) || pg.syncChargingOnly.Changed(gtx) {
+		policy := pg.AssetsManager.SyncPolicy()
+		policy.UnmeteredOnly = pg.syncUnmeteredOnly.IsChecked()
+		policy.ChargingOnly = pg.syncChargingOnly.IsChecked()
+		pg.AssetsManager.SetSyncPolicy(policy)
+	}
+
+	if pg.syncDataBudget.Clicked(gtx) {
+		budgetSelectorModal := preference.NewListPreference(pg.Load,
+			sharedW.SyncPolicyConfigKey, "0",
+			preference.SyncDataBudgetOptions()).
+			Title(values.StrSyncDataBudget).
+			UpdateValues(func(_ string) {})
+		pg.ParentWindow().ShowModal(budgetSelectorModal)
+	}
 	if pg.governanceAPI.Changed(gtx) {
 		pg.AssetsManager.SetHTTPAPIPrivacyMode(libutils.GovernanceHTTPAPI, pg.governanceAPI.IsChecked())
 	}
@@ -745,6 +873,9 @@ func (pg *AppSettingsPage) HandleUserInteractions(gtx C) {
 					pg.showNoticeSuccess(values.StringF(values.StrStartupPasswordEnabled, values.String(values.StrDisabled)))
 					pm.Dismiss()
 					pg.isStartupPassword = false
+					// The metadata is decrypted along with the removal of the
+					// startup password.
+					pg.encryptMetadata.SetChecked(false)
 					return true
 				}).
 				SetNegativeButtonCallback(func() {
@@ -754,6 +885,81 @@ func (pg *AppSettingsPage) HandleUserInteractions(gtx C) {
 		}
 	}
 
+	if pg.encryptMetadata.Changed(gtx) {
+		encrypt := pg.encryptMetadata.IsChecked()
+		title, status := values.StrConfirmEncryptMetadata, values.StrEnabled
+		if !encrypt {
+			title, status = values.StrConfirmDecryptMetadata, values.StrDisabled
+		}
+		currentPasswordModal := modal.NewCreatePasswordModal(pg.Load).
+			EnableName(false).
+			SetCancelable(false).
+			EnableConfirmPassword(false).
+			Title(values.String(title)).
+			PasswordHint(values.String(values.StrStartupPassword)).
+			SetPositiveButtonCallback(func(_, password string, pm *modal.CreatePasswordModal) bool {
+				var err error
+				if encrypt {
+					err = pg.AssetsManager.EnableMetadataEncryption(password)
+				} else {
+					err = pg.AssetsManager.DisableMetadataEncryption(password)
+				}
+				if err != nil {
+					pm.SetError(err.Error())
+					return false
+				}
+				pg.showNoticeSuccess(values.StringF(values.StrMetadataEncryptionFmt, values.String(status)))
+				pm.Dismiss()
+				return true
+			}).
+			SetNegativeButtonCallback(func() {
+				pg.encryptMetadata.SetChecked(!encrypt)
+			})
+		pg.ParentWindow().ShowModal(currentPasswordModal)
+	}
+
+	if pg.duressPassword.Changed(gtx) {
+		if pg.duressPassword.IsChecked() {
+			pg.setDuressPassword()
+		} else {
+			currentPasswordModal := modal.NewCreatePasswordModal(pg.Load).
+				EnableName(false).
+				SetCancelable(false).
+				EnableConfirmPassword(false).
+				Title(values.String(values.StrConfirmRemoveDuressPassword)).
+				PasswordHint(values.String(values.StrStartupPassword)).
+				SetPositiveButtonCallback(func(_, password string, pm *modal.CreatePasswordModal) bool {
+					if err := pg.AssetsManager.RemoveDuressPassphrase(password); err != nil {
+						pm.SetError(err.Error())
+						return false
+					}
+					pg.showNoticeSuccess(values.StringF(values.StrDuressPasswordFmt, values.String(values.StrDisabled)))
+					pm.Dismiss()
+					return true
+				}).
+				SetNegativeButtonCallback(func() {
+					pg.duressPassword.SetChecked(true)
+				})
+			pg.ParentWindow().ShowModal(currentPasswordModal)
+		}
+	}
+
+	if pg.exportAppBackup.Clicked(gtx) {
+		var wallets []sharedW.Asset
+		for _, wallet := range pg.AssetsManager.AllWallets() {
+			if wallet.HasWalletSeed() {
+				wallets = append(wallets, wallet)
+			}
+		}
+		pg.collectWalletPassphrases(wallets, make(map[int]string))
+	}
+
+	if pg.restoreAppBackup.Clicked(gtx) {
+		pg.ParentNavigator().Display(components.NewAppBackupRestorePage(pg.Load, func() {
+			pg.ParentNavigator().CloseCurrentPage()
+		}))
+	}
+
 	if pg.backupDEX.Clicked(gtx) {
 		// Show modal asking for dex password and then reveal the seed.
 		dexPasswordModal := modal.NewCreatePasswordModal(pg.Load).
@@ -777,6 +983,56 @@ func (pg *AppSettingsPage) HandleUserInteractions(gtx C) {
 	}
 }
 
+// collectWalletPassphrases asks for the spending password of each of wallets,
+// which are needed to export their seeds, before exporting the app backup.
+func (pg *AppSettingsPage) collectWalletPassphrases(wallets []sharedW.Asset, passphrases map[int]string) {
+	if len(wallets) == 0 {
+		pg.showExportAppBackupModal(passphrases)
+		return
+	}
+
+	wallet := wallets[0]
+	walletPasswordModal := modal.NewCreatePasswordModal(pg.Load).
+		EnableName(false).
+		EnableConfirmPassword(false).
+		Title(values.String(values.StrExportAppBackup)).
+		PasswordHint(values.StringF(values.StrWalletSpendingPasswordFmt, wallet.GetWalletName())).
+		SetPositiveButtonCallback(func(_, password string, pm *modal.CreatePasswordModal) bool {
+			if _, err := wallet.DecryptSeed(password); err != nil {
+				pm.SetError(err.Error())
+				return false
+			}
+
+			passphrases[wallet.GetWalletID()] = password
+			pm.Dismiss()
+			pg.collectWalletPassphrases(wallets[1:], passphrases)
+			return true
+		})
+	pg.ParentWindow().ShowModal(walletPasswordModal)
+}
+
+func (pg *AppSettingsPage) showExportAppBackupModal(walletPassphrases map[int]string) {
+	backupPasswordModal := modal.NewCreatePasswordModal(pg.Load).
+		EnableName(false).
+		Title(values.String(values.StrExportAppBackup)).
+		SetDescription(values.String(values.StrAppBackupDesc)).
+		PasswordHint(values.String(values.StrBackupPassphrase)).
+		ConfirmPasswordHint(values.String(values.StrConfirmBackupPassphrase)).
+		SetPositiveButtonCallback(func(_, password string, pm *modal.CreatePasswordModal) bool {
+			fileName := fmt.Sprintf("cryptopower_backup_%s.bak", time.Now().Format("20060102_150405"))
+			filePath := filepath.Join(pg.AssetsManager.RootDir(), "exports", fileName)
+			if err := pg.AssetsManager.ExportAppBackup(filePath, password, walletPassphrases); err != nil {
+				pm.SetError(err.Error())
+				return false
+			}
+
+			pm.Dismiss()
+			pg.showNoticeSuccess(values.StringF(values.StrAppBackupExportedFmt, filePath))
+			return true
+		})
+	pg.ParentWindow().ShowModal(backupPasswordModal)
+}
+
 func (pg *AppSettingsPage) showDEXSeedModal() {
 	seedModal := modal.NewSuccessModal(pg.Load, values.String(values.StrDEXSeed), modal.DefaultClickFunc()).
 		UseCustomWidget(func(gtx C) D {
@@ -838,6 +1094,54 @@ func ChangeNetworkType(load *load.Load, windowNav app.WindowNavigator, newNetTyp
 	windowNav.ShowModal(confirmNetworkSwitchModal)
 }
 
+// setDuressPassword asks for the startup password and then the new duress
+// password.
+func (pg *AppSettingsPage) setDuressPassword() {
+	currentPasswordModal := modal.NewCreatePasswordModal(pg.Load).
+		EnableName(false).
+		SetCancelable(false).
+		EnableConfirmPassword(false).
+		Title(values.String(values.StrConfirmStartupPass)).
+		PasswordHint(values.String(values.StrStartupPassword)).
+		SetPositiveButtonCallback(func(_, startupPassword string, pm *modal.CreatePasswordModal) bool {
+			if err := pg.AssetsManager.VerifyStartupPassphrase(startupPassword); err != nil {
+				pm.SetError(err.Error())
+				return false
+			}
+			pm.Dismiss()
+
+			duressPasswordModal := modal.NewCreatePasswordModal(pg.Load).
+				Title(values.String(values.StrDuressPassword)).
+				EnableName(false).
+				SetCancelable(false).
+				SetDescription(values.String(values.StrCreateDuressPassword)).
+				PasswordHint(values.String(values.StrDuressPassword)).
+				ConfirmPasswordHint(values.String(values.StrConfirmDuressPassword)).
+				SetPositiveButtonCallback(func(_, password string, m *modal.CreatePasswordModal) bool {
+					if !utils.StringNotEmpty(password) {
+						m.SetError(values.String(values.StrErrPassEmpty))
+						return false
+					}
+					if err := pg.AssetsManager.SetDuressPassphrase(startupPassword, password); err != nil {
+						m.SetError(err.Error())
+						return false
+					}
+					pg.showNoticeSuccess(values.StringF(values.StrDuressPasswordFmt, values.String(values.StrEnabled)))
+					m.Dismiss()
+					return true
+				}).
+				SetNegativeButtonCallback(func() {
+					pg.duressPassword.SetChecked(false)
+				})
+			pg.ParentWindow().ShowModal(duressPasswordModal)
+			return true
+		}).
+		SetNegativeButtonCallback(func() {
+			pg.duressPassword.SetChecked(false)
+		})
+	pg.ParentWindow().ShowModal(currentPasswordModal)
+}
+
 func (pg *AppSettingsPage) showNoticeSuccess(title string) {
 	info := modal.NewSuccessModal(pg.Load, title, modal.DefaultClickFunc())
 	pg.ParentWindow().ShowModal(info)
@@ -852,6 +1156,12 @@ func (pg *AppSettingsPage) updateSettingOptions() {
 		pg.isStartupPassword = true
 	}
 
+	pg.setInitialSwitchStatus(pg.autoWalletDBBackup, pg.AssetsManager.IsAutoWalletDBBackupEnabled())
+	syncPolicy := pg.AssetsManager.SyncPolicy()
+	pg.setInitialSwitchStatus(pg.syncUnmeteredOnly, syncPolicy.UnmeteredOnly)
+	pg.setInitialSwitchStatus(pg.syncChargingOnly, syncPolicy.ChargingOnly)
+	pg.setInitialSwitchStatus(pg.encryptMetadata, pg.AssetsManager.IsMetadataEncryptionEnabled())
+	pg.setInitialSwitchStatus(pg.duressPassword, pg.AssetsManager.IsDuressPassphraseSet())
 	pg.updatePrivacySettings()
 }
 
@@ -10,6 +10,7 @@ import (
 	"gioui.org/widget"
 
 	"github.com/crypto-power/cryptopower/app"
+	"github.com/crypto-power/cryptopower/libwallet"
 	"github.com/crypto-power/cryptopower/libwallet/assets/dcr"
 	sharedW "github.com/crypto-power/cryptopower/libwallet/assets/wallet"
 	"github.com/crypto-power/cryptopower/ui/cryptomaterial"
@@ -22,6 +23,9 @@ import (
 
 const StatisticsPageID = "Statistics"
 
+// maxSyncHistoryItems is the number of past syncs of the wallet shown.
+const maxSyncHistoryItems = 5
+
 type StatPage struct {
 	*load.Load
 	// GenericPageModal defines methods such as ID() and OnAttachedToNavigator()
@@ -34,6 +38,8 @@ type StatPage struct {
 	txs      []*sharedW.Transaction
 	accounts *sharedW.Accounts
 
+	syncHistory []libwallet.SyncRecord
+
 	l             layout.List
 	scrollbarList *widget.List
 	startupTime   string
@@ -84,9 +90,37 @@ func (pg *StatPage) OnNavigatedTo() {
 		pg.accounts = acc
 	}
 
+	pg.syncHistory = pg.syncHistory[:0]
+	for _, record := range pg.AssetsManager.SyncHistory() {
+		if record.WalletID != pg.wallet.GetWalletID() {
+			continue
+		}
+		pg.syncHistory = append(pg.syncHistory, record)
+		if len(pg.syncHistory) == maxSyncHistoryItems {
+			break
+		}
+	}
+
 	pg.appStartTime()
 }
 
+// syncOutcome describes how a past sync ended and how long it took.
+func syncOutcome(record libwallet.SyncRecord) string {
+	var outcome string
+	switch record.Outcome {
+	case libwallet.SyncCompleted:
+		outcome = values.String(values.StrComplete)
+	case libwallet.SyncCanceled:
+		outcome = values.String(values.StrSyncCanceled)
+	default:
+		outcome = values.String(values.StrFailed)
+		if record.Error != "" {
+			outcome += ": " + record.Error
+		}
+	}
+	return values.StringF(values.StrSyncRecordFmt, outcome, record.Duration.Round(time.Second))
+}
+
 func (pg *StatPage) layoutStats(gtx C) D {
 	background := pg.Theme.Color.Surface
 	card := pg.Theme.Card()
@@ -146,6 +180,16 @@ func (pg *StatPage) layoutStats(gtx C) D {
 		item(values.String(values.StrAccount)+"s", fmt.Sprintf("%d", len(pg.accounts.Accounts))),
 	}
 
+	if progress := pg.AssetsManager.WalletSyncProgress(pg.wallet.GetWalletID()); progress != nil && progress.Syncing {
+		items = append(items, line.Layout, item(values.String(values.StrSyncingProgress), fmt.Sprintf("%d%%", progress.Percent)))
+	}
+	if len(pg.syncHistory) > 0 {
+		items = append(items, line.Layout, item(values.String(values.StrSyncHistory), ""))
+		for _, record := range pg.syncHistory {
+			items = append(items, item(record.StartedAt.Format("2006-01-02 15:04:05"), syncOutcome(record)))
+		}
+	}
+
 	return pg.Theme.List(pg.scrollbarList).Layout(gtx, 1, func(gtx C, _ int) D {
 		return layout.Inset{Right: values.MarginPadding2}.Layout(gtx, func(gtx C) D {
 			return card.Layout(gtx, func(gtx C) D {
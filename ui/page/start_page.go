@@ -72,6 +72,7 @@ type startPage struct {
 	ctx context.Context
 
 	addWalletButton     cryptomaterial.Button
+	restoreBackupButton cryptomaterial.Button
 	skipButton          cryptomaterial.Button
 	nextButton          cryptomaterial.Button
 	backButton          cryptomaterial.IconButton
@@ -102,6 +103,7 @@ func NewStartPage(ctx context.Context, l *load.Load, isShuttingDown ...bool) app
 		displayStartPage: true,
 
 		addWalletButton:     l.Theme.Button(values.String(values.StrAddWallet)),
+		restoreBackupButton: l.Theme.OutlineButton(values.String(values.StrRestoreAppBackup)),
 		nextButton:          l.Theme.Button(values.String(values.StrNext)),
 		skipButton:          l.Theme.OutlineButton(values.String(values.StrSkip)),
 		backButton:          components.GetBackButton(l),
@@ -227,6 +229,18 @@ func (sp *startPage) unlock() {
 
 func (sp *startPage) openWalletsAndDisplayHomePage(password string) error {
 	err := sp.AssetsManager.OpenWallets(password)
+	if err != nil && err.Error() == libutils.ErrInvalidPassphrase {
+		// The password may open the duress profile in place of the wallets.
+		if duressAssetsManager, duressErr := sp.AssetsManager.OpenDuressProfile(password); duressErr == nil {
+			sp.ChangeAssetsManager(duressAssetsManager, sp.ParentNavigator())
+			if duressAssetsManager.LoadedWalletsCount() == 0 {
+				sp.ParentNavigator().ClearStackAndDisplay(NewStartPage(sp.ctx, sp.Load))
+			} else {
+				sp.ParentNavigator().ClearStackAndDisplay(root.NewHomePage(sp.Load))
+			}
+			return nil
+		}
+	}
 	if err != nil {
 		log.Errorf("Error opening wallet: %v", err)
 		if appos.Current().IsMobile() {
@@ -271,6 +285,14 @@ func (sp *startPage) HandleUserInteractions(gtx C) {
 		sp.ParentNavigator().Display(createWalletPage)
 	}
 
+	if sp.restoreBackupButton.Clicked(gtx) {
+		restorePage := components.NewAppBackupRestorePage(sp.Load, func() {
+			sp.setLanguagePref(true)
+			sp.ParentNavigator().ClearStackAndDisplay(root.NewHomePage(sp.Load))
+		})
+		sp.ParentNavigator().Display(restorePage)
+	}
+
 	if sp.nextButton.Clicked(gtx) {
 		if sp.currentPageIndex < startupSettingsPageIndex {
 			if sp.introductionSlider.IsLastSlide() {
@@ -467,6 +489,14 @@ func (sp *startPage) loadingSection(gtx C) D {
 				gtx.Constraints.Min.X = gtx.Dp(values.MarginPadding350)
 				return inset.Layout(gtx, sp.addWalletButton.Layout)
 			}),
+			layout.Rigid(func(gtx C) D {
+				if sp.loading {
+					return D{}
+				}
+
+				gtx.Constraints.Min.X = gtx.Dp(values.MarginPadding350)
+				return layout.Inset{Top: values.MarginPadding16}.Layout(gtx, sp.restoreBackupButton.Layout)
+			}),
 		)
 	})
 }
@@ -800,7 +830,7 @@ func (sp *startPage) showRemoveRootDirNotice() {
 			log.Error("unable to remove root dir: %v", err)
 			return false
 		}
-		newmgr, err := libwallet.NewAssetsManager(path.Dir(sp.AssetsManager.RootDir()), sp.AssetsManager.ParamLogDir(), sp.AssetsManager.NetType(), sp.AssetsManager.DEXTestAddr())
+		newmgr, err := libwallet.NewAssetsManager(path.Dir(sp.AssetsManager.RootDir()), sp.AssetsManager.ParamLogDir(), sp.AssetsManager.NetType(), sp.AssetsManager.DEXTestAddr(), "")
 		if err != nil {
 			log.Errorf("Error create new asset manager: %v", err)
 			return false
@@ -0,0 +1,232 @@
+package wallet
+
+import (
+	"strings"
+
+	"gioui.org/layout"
+	"gioui.org/widget"
+
+	"github.com/crypto-power/cryptopower/app"
+	sharedW "github.com/crypto-power/cryptopower/libwallet/assets/wallet"
+	"github.com/crypto-power/cryptopower/ui/cryptomaterial"
+	"github.com/crypto-power/cryptopower/ui/load"
+	"github.com/crypto-power/cryptopower/ui/modal"
+	"github.com/crypto-power/cryptopower/ui/page/components"
+	"github.com/crypto-power/cryptopower/ui/values"
+)
+
+const ElectrumServersPageID = "ElectrumServers"
+
+// ElectrumServersPage sets the Electrum servers a BTC or LTC wallet syncs
+// from instead of SPV.
+type ElectrumServersPage struct {
+	*load.Load
+	// GenericPageModal defines methods such as ID() and OnAttachedToNavigator()
+	// that helps this Page satisfy the app.Page interface. It also defines
+	// helper methods for accessing the PageNavigator that displayed this page
+	// and the root WindowNavigator.
+	*app.GenericPageModal
+
+	wallet sharedW.Asset
+
+	pageContainer *widget.List
+	backButton    cryptomaterial.IconButton
+
+	serversEditor  cryptomaterial.Editor
+	torProxyEditor cryptomaterial.Editor
+	testBtn        cryptomaterial.Button
+	saveBtn        cryptomaterial.Button
+	useSPVBtn      cryptomaterial.Button
+
+	testing bool
+}
+
+// NewElectrumServersPage creates a page that sets the Electrum servers wallet
+// syncs from.
+func NewElectrumServersPage(l *load.Load, wallet sharedW.Asset) *ElectrumServersPage {
+	th := l.Theme
+	pg := &ElectrumServersPage{
+		Load:             l,
+		GenericPageModal: app.NewGenericPageModal(ElectrumServersPageID),
+		wallet:           wallet,
+		pageContainer:    &widget.List{List: layout.List{Axis: layout.Vertical}},
+		backButton:       components.GetBackButton(l),
+
+		serversEditor:  th.Editor(new(widget.Editor), values.String(values.StrElectrumServerList)),
+		torProxyEditor: th.Editor(new(widget.Editor), values.String(values.StrTorProxy)),
+		testBtn:        th.OutlineButton(values.String(values.StrTestConnection)),
+		saveBtn:        th.Button(values.String(values.StrSave)),
+		useSPVBtn:      th.OutlineButton(values.String(values.StrUseSPV)),
+	}
+
+	pg.serversEditor.Editor.SingleLine = false
+	pg.torProxyEditor.Editor.SingleLine = true
+	return pg
+}
+
+// OnNavigatedTo is called when the page is about to be displayed and
+// may be used to initialize page features that are only relevant when
+// the page is displayed.
+// Part of the load.Page interface.
+func (pg *ElectrumServersPage) OnNavigatedTo() {
+	cfg := pg.wallet.ElectrumConfig()
+	if cfg == nil {
+		cfg = new(sharedW.ElectrumConfig)
+	}
+
+	pg.serversEditor.Editor.SetText(sharedW.FormatElectrumServers(cfg.Servers))
+	pg.torProxyEditor.Editor.SetText(cfg.TorProxy)
+	pg.useSPVBtn.SetEnabled(pg.wallet.ElectrumConfig() != nil)
+}
+
+func (pg *ElectrumServersPage) electrumConfig() (*sharedW.ElectrumConfig, error) {
+	servers, err := sharedW.ParseElectrumServers(pg.serversEditor.Editor.Text())
+	if err != nil {
+		return nil, err
+	}
+	return &sharedW.ElectrumConfig{
+		Servers:  servers,
+		TorProxy: strings.TrimSpace(pg.torProxyEditor.Editor.Text()),
+	}, nil
+}
+
+// Layout draws the page UI components into the provided C
+// to be eventually drawn on screen.
+// Part of the load.Page interface.
+func (pg *ElectrumServersPage) Layout(gtx C) D {
+	sp := components.SubPage{
+		Load:       pg.Load,
+		Title:      values.String(values.StrElectrumServers),
+		BackButton: pg.backButton,
+		Back: func() {
+			pg.ParentNavigator().CloseCurrentPage()
+		},
+		Body: func(gtx C) D {
+			return pg.Theme.List(pg.pageContainer).Layout(gtx, 1, func(gtx C, _ int) D {
+				return pg.serversSection(gtx)
+			})
+		},
+	}
+	return sp.Layout(pg.ParentWindow(), gtx)
+}
+
+func (pg *ElectrumServersPage) serversSection(gtx C) D {
+	widgets := []layout.Widget{
+		func(gtx C) D {
+			desc := pg.Theme.Body2(values.String(values.StrElectrumServersDesc))
+			desc.Color = pg.Theme.Color.GrayText2
+			return desc.Layout(gtx)
+		},
+		pg.serversEditor.Layout,
+		pg.torProxyEditor.Layout,
+		func(gtx C) D {
+			return layout.Flex{}.Layout(gtx,
+				layout.Rigid(func(gtx C) D {
+					return layout.Inset{Right: values.MarginPadding8}.Layout(gtx, pg.testBtn.Layout)
+				}),
+				layout.Rigid(func(gtx C) D {
+					return layout.Inset{Right: values.MarginPadding8}.Layout(gtx, pg.saveBtn.Layout)
+				}),
+				layout.Rigid(pg.useSPVBtn.Layout),
+			)
+		},
+	}
+
+	children := make([]layout.FlexChild, 0, len(widgets))
+	for _, w := range widgets {
+		w := w
+		children = append(children, layout.Rigid(func(gtx C) D {
+			return layout.Inset{Bottom: values.MarginPadding10}.Layout(gtx, w)
+		}))
+	}
+
+	return cryptomaterial.LinearLayout{
+		Width:       cryptomaterial.MatchParent,
+		Height:      cryptomaterial.WrapContent,
+		Background:  pg.Theme.Color.Surface,
+		Margin:      layout.Inset{Bottom: values.MarginPadding16},
+		Padding:     layout.UniformInset(values.MarginPadding16),
+		Orientation: layout.Vertical,
+		Border:      cryptomaterial.Border{Radius: cryptomaterial.Radius(14)},
+	}.Layout(gtx, children...)
+}
+
+// HandleUserInteractions is called just before Layout() to determine
+// if any user interaction recently occurred on the page and may be
+// used to update the page's UI components shortly before they are
+// displayed.
+// Part of the load.Page interface.
+func (pg *ElectrumServersPage) HandleUserInteractions(gtx C) {
+	if pg.testBtn.Clicked(gtx) && !pg.testing {
+		cfg, err := pg.electrumConfig()
+		if err == nil && len(cfg.Servers) == 0 {
+			err = cfg.Validate(pg.wallet.GetAssetType())
+		}
+		if err != nil {
+			pg.showError(err)
+		} else {
+			pg.testServers(cfg)
+		}
+	}
+
+	if pg.saveBtn.Clicked(gtx) {
+		cfg, err := pg.electrumConfig()
+		if err == nil {
+			err = pg.wallet.SetElectrumConfig(cfg)
+		}
+		if err != nil {
+			pg.showError(err)
+		} else {
+			pg.Toast.Notify(values.String(values.StrElectrumServersSaved))
+			pg.useSPVBtn.SetEnabled(true)
+		}
+	}
+
+	if pg.useSPVBtn.Clicked(gtx) {
+		if err := pg.wallet.SetElectrumConfig(nil); err != nil {
+			pg.showError(err)
+		} else {
+			pg.Toast.Notify(values.String(values.StrSPVSyncRestored))
+			pg.OnNavigatedTo()
+		}
+	}
+}
+
+// testServers connects to each server of cfg and fills in the certificate
+// fingerprints they present, for the user to check against the ones
+// published by their operators before saving.
+func (pg *ElectrumServersPage) testServers(cfg *sharedW.ElectrumConfig) {
+	pg.testing = true
+	pg.testBtn.SetEnabled(false)
+	go func() {
+		defer func() {
+			pg.testing = false
+			pg.testBtn.SetEnabled(true)
+		}()
+
+		for _, server := range cfg.Servers {
+			fingerprint, err := pg.wallet.TestElectrumServer(server, cfg.TorProxy)
+			if err != nil {
+				pg.showError(err)
+				return
+			}
+			server.CertFingerprint = fingerprint
+		}
+		pg.serversEditor.Editor.SetText(sharedW.FormatElectrumServers(cfg.Servers))
+		pg.Toast.Notify(values.String(values.StrElectrumServersOK))
+	}()
+}
+
+func (pg *ElectrumServersPage) showError(err error) {
+	errModal := modal.NewErrorModal(pg.Load, values.TranslateErr(err.Error()), modal.DefaultClickFunc())
+	pg.ParentWindow().ShowModal(errModal)
+}
+
+// OnNavigatedFrom is called when the page is about to be removed from
+// the displayed window. This method should ideally be used to disable
+// features that are irrelevant when the page is NOT displayed.
+// NOTE: The page may be re-displayed on the app's window, in which case
+// OnNavigatedTo() will be called again. This method should not destroy UI
+// components unless they'll be recreated in the OnNavigatedTo() method.
+// Part of the load.Page interface.
+func (pg *ElectrumServersPage) OnNavigatedFrom() {}
@@ -0,0 +1,241 @@
+package wallet
+
+import (
+	"strings"
+
+	"gioui.org/layout"
+	"gioui.org/widget"
+
+	"github.com/crypto-power/cryptopower/app"
+	sharedW "github.com/crypto-power/cryptopower/libwallet/assets/wallet"
+	"github.com/crypto-power/cryptopower/ui/cryptomaterial"
+	"github.com/crypto-power/cryptopower/ui/load"
+	"github.com/crypto-power/cryptopower/ui/modal"
+	"github.com/crypto-power/cryptopower/ui/page/components"
+	"github.com/crypto-power/cryptopower/ui/values"
+)
+
+const FullNodeSyncPageID = "FullNodeSync"
+
+// FullNodeSyncPage sets the full node a wallet syncs from over RPC instead
+// of SPV.
+type FullNodeSyncPage struct {
+	*load.Load
+	// GenericPageModal defines methods such as ID() and OnAttachedToNavigator()
+	// that helps this Page satisfy the app.Page interface. It also defines
+	// helper methods for accessing the PageNavigator that displayed this page
+	// and the root WindowNavigator.
+	*app.GenericPageModal
+
+	wallet sharedW.Asset
+
+	pageContainer *widget.List
+	backButton    cryptomaterial.IconButton
+
+	hostEditor     cryptomaterial.Editor
+	userEditor     cryptomaterial.Editor
+	passwordEditor cryptomaterial.Editor
+	certEditor     cryptomaterial.Editor
+	tlsProxy       cryptomaterial.CheckBoxStyle
+	nodeType       *widget.Enum
+	testBtn        cryptomaterial.Button
+	saveBtn        cryptomaterial.Button
+	useSPVBtn      cryptomaterial.Button
+
+	testing bool
+}
+
+// NewFullNodeSyncPage creates a page that sets the full node wallet syncs
+// from.
+func NewFullNodeSyncPage(l *load.Load, wallet sharedW.Asset) *FullNodeSyncPage {
+	th := l.Theme
+	pg := &FullNodeSyncPage{
+		Load:             l,
+		GenericPageModal: app.NewGenericPageModal(FullNodeSyncPageID),
+		wallet:           wallet,
+		pageContainer:    &widget.List{List: layout.List{Axis: layout.Vertical}},
+		backButton:       components.GetBackButton(l),
+
+		hostEditor:     th.Editor(new(widget.Editor), values.String(values.StrRPCHost)),
+		userEditor:     th.Editor(new(widget.Editor), values.String(values.StrRPCUser)),
+		passwordEditor: th.EditorPassword(new(widget.Editor), values.String(values.StrRPCPassword)),
+		certEditor:     th.Editor(new(widget.Editor), values.String(values.StrRPCCertificate)),
+		tlsProxy:       th.CheckBox(new(widget.Bool), values.String(values.StrRPCTLSProxy)),
+		nodeType:       new(widget.Enum),
+		testBtn:        th.OutlineButton(values.String(values.StrTestConnection)),
+		saveBtn:        th.Button(values.String(values.StrSave)),
+		useSPVBtn:      th.OutlineButton(values.String(values.StrUseSPV)),
+	}
+
+	pg.hostEditor.Editor.SingleLine = true
+	pg.userEditor.Editor.SingleLine = true
+	pg.passwordEditor.Editor.SingleLine = true
+	return pg
+}
+
+// OnNavigatedTo is called when the page is about to be displayed and
+// may be used to initialize page features that are only relevant when
+// the page is displayed.
+// Part of the load.Page interface.
+func (pg *FullNodeSyncPage) OnNavigatedTo() {
+	cfg := pg.wallet.RPCConfig()
+	if cfg == nil {
+		cfg = &sharedW.RPCConfig{NodeType: sharedW.RPCNodeTypes(pg.wallet.GetAssetType())[0]}
+	}
+
+	pg.hostEditor.Editor.SetText(cfg.Host)
+	pg.userEditor.Editor.SetText(cfg.User)
+	pg.passwordEditor.Editor.SetText(cfg.Password)
+	pg.certEditor.Editor.SetText(cfg.Certificate)
+	pg.tlsProxy.CheckBox.Value = cfg.TLSProxy
+	pg.nodeType.Value = cfg.NodeType
+	pg.useSPVBtn.SetEnabled(pg.wallet.RPCConfig() != nil)
+}
+
+func (pg *FullNodeSyncPage) rpcConfig() *sharedW.RPCConfig {
+	return &sharedW.RPCConfig{
+		Host:        strings.TrimSpace(pg.hostEditor.Editor.Text()),
+		User:        pg.userEditor.Editor.Text(),
+		Password:    pg.passwordEditor.Editor.Text(),
+		Certificate: strings.TrimSpace(pg.certEditor.Editor.Text()),
+		NodeType:    pg.nodeType.Value,
+		TLSProxy:    pg.tlsProxy.CheckBox.Value,
+	}
+}
+
+// Layout draws the page UI components into the provided C
+// to be eventually drawn on screen.
+// Part of the load.Page interface.
+func (pg *FullNodeSyncPage) Layout(gtx C) D {
+	sp := components.SubPage{
+		Load:       pg.Load,
+		Title:      values.String(values.StrFullNodeSync),
+		BackButton: pg.backButton,
+		Back: func() {
+			pg.ParentNavigator().CloseCurrentPage()
+		},
+		Body: func(gtx C) D {
+			return pg.Theme.List(pg.pageContainer).Layout(gtx, 1, func(gtx C, _ int) D {
+				return pg.nodeSection(gtx)
+			})
+		},
+	}
+	return sp.Layout(pg.ParentWindow(), gtx)
+}
+
+func (pg *FullNodeSyncPage) nodeSection(gtx C) D {
+	widgets := []layout.Widget{
+		func(gtx C) D {
+			desc := pg.Theme.Body2(values.String(values.StrFullNodeSyncDesc))
+			desc.Color = pg.Theme.Color.GrayText2
+			return desc.Layout(gtx)
+		},
+		pg.Theme.Label(values.TextSize16, values.String(values.StrNodeType)).Layout,
+		func(gtx C) D {
+			nodeTypes := sharedW.RPCNodeTypes(pg.wallet.GetAssetType())
+			children := make([]layout.FlexChild, 0, len(nodeTypes))
+			for _, nodeType := range nodeTypes {
+				radioBtn := pg.Theme.RadioButton(pg.nodeType, nodeType, nodeType, pg.Theme.Color.DeepBlue, pg.Theme.Color.Primary)
+				children = append(children, layout.Rigid(func(gtx C) D {
+					return layout.Inset{Right: values.MarginPadding16}.Layout(gtx, radioBtn.Layout)
+				}))
+			}
+			return layout.Flex{}.Layout(gtx, children...)
+		},
+		pg.hostEditor.Layout,
+		pg.userEditor.Layout,
+		pg.passwordEditor.Layout,
+		pg.certEditor.Layout,
+		func(gtx C) D {
+			cfg := sharedW.RPCConfig{NodeType: pg.nodeType.Value}
+			if !cfg.NeedsTLSProxy() {
+				return D{}
+			}
+			return pg.tlsProxy.Layout(gtx)
+		},
+		func(gtx C) D {
+			return layout.Flex{}.Layout(gtx,
+				layout.Rigid(func(gtx C) D {
+					return layout.Inset{Right: values.MarginPadding8}.Layout(gtx, pg.testBtn.Layout)
+				}),
+				layout.Rigid(func(gtx C) D {
+					return layout.Inset{Right: values.MarginPadding8}.Layout(gtx, pg.saveBtn.Layout)
+				}),
+				layout.Rigid(pg.useSPVBtn.Layout),
+			)
+		},
+	}
+
+	children := make([]layout.FlexChild, 0, len(widgets))
+	for _, w := range widgets {
+		w := w
+		children = append(children, layout.Rigid(func(gtx C) D {
+			return layout.Inset{Bottom: values.MarginPadding10}.Layout(gtx, w)
+		}))
+	}
+
+	return cryptomaterial.LinearLayout{
+		Width:       cryptomaterial.MatchParent,
+		Height:      cryptomaterial.WrapContent,
+		Background:  pg.Theme.Color.Surface,
+		Margin:      layout.Inset{Bottom: values.MarginPadding16},
+		Padding:     layout.UniformInset(values.MarginPadding16),
+		Orientation: layout.Vertical,
+		Border:      cryptomaterial.Border{Radius: cryptomaterial.Radius(14)},
+	}.Layout(gtx, children...)
+}
+
+// HandleUserInteractions is called just before Layout() to determine
+// if any user interaction recently occurred on the page and may be
+// used to update the page's UI components shortly before they are
+// displayed.
+// Part of the load.Page interface.
+func (pg *FullNodeSyncPage) HandleUserInteractions(gtx C) {
+	if pg.testBtn.Clicked(gtx) && !pg.testing {
+		pg.testing = true
+		pg.testBtn.SetEnabled(false)
+		cfg := pg.rpcConfig()
+		go func() {
+			err := pg.wallet.TestRPCConnection(cfg)
+			pg.testing = false
+			pg.testBtn.SetEnabled(true)
+			if err != nil {
+				pg.showError(err)
+				return
+			}
+			pg.Toast.Notify(values.String(values.StrRPCConnectionOK))
+		}()
+	}
+
+	if pg.saveBtn.Clicked(gtx) {
+		if err := pg.wallet.SetRPCConfig(pg.rpcConfig()); err != nil {
+			pg.showError(err)
+		} else {
+			pg.Toast.Notify(values.String(values.StrFullNodeSyncSaved))
+			pg.useSPVBtn.SetEnabled(true)
+		}
+	}
+
+	if pg.useSPVBtn.Clicked(gtx) {
+		if err := pg.wallet.SetRPCConfig(nil); err != nil {
+			pg.showError(err)
+		} else {
+			pg.Toast.Notify(values.String(values.StrSPVSyncRestored))
+			pg.OnNavigatedTo()
+		}
+	}
+}
+
+func (pg *FullNodeSyncPage) showError(err error) {
+	errModal := modal.NewErrorModal(pg.Load, values.TranslateErr(err.Error()), modal.DefaultClickFunc())
+	pg.ParentWindow().ShowModal(errModal)
+}
+
+// OnNavigatedFrom is called when the page is about to be removed from
+// the displayed window. This method should ideally be used to disable
+// features that are irrelevant when the page is NOT displayed.
+// NOTE: The page may be re-displayed on the app's window, in which case
+// OnNavigatedTo() will be called again. This method should not destroy UI
+// components unless they'll be recreated in the OnNavigatedTo() method.
+// Part of the load.Page interface.
+func (pg *FullNodeSyncPage) OnNavigatedFrom() {}
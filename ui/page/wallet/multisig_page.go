@@ -0,0 +1,643 @@
+package wallet
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"gioui.org/font"
+	"gioui.org/io/clipboard"
+	"gioui.org/layout"
+	"gioui.org/widget"
+	qrcode "github.com/yeqown/go-qrcode"
+
+	"github.com/crypto-power/cryptopower/app"
+	"github.com/crypto-power/cryptopower/libwallet/assets/btc"
+	"github.com/crypto-power/cryptopower/libwallet/assets/dcr"
+	sharedW "github.com/crypto-power/cryptopower/libwallet/assets/wallet"
+	libutils "github.com/crypto-power/cryptopower/libwallet/utils"
+	"github.com/crypto-power/cryptopower/ui/cryptomaterial"
+	"github.com/crypto-power/cryptopower/ui/load"
+	"github.com/crypto-power/cryptopower/ui/modal"
+	"github.com/crypto-power/cryptopower/ui/page/components"
+	"github.com/crypto-power/cryptopower/ui/values"
+)
+
+const (
+	MultisigPageID = "Multisig"
+
+	// maxQRProposalSize is the largest proposal that is also displayed as a
+	// QR code. Larger proposals must be exchanged as files or text.
+	maxQRProposalSize = 1500
+)
+
+// MultisigPage sets up a wallet as an m-of-n multisig wallet and coordinates
+// the transaction proposals signed by the cosigners.
+type MultisigPage struct {
+	*load.Load
+	// GenericPageModal defines methods such as ID() and OnAttachedToNavigator()
+	// that helps this Page satisfy the app.Page interface. It also defines
+	// helper methods for accessing the PageNavigator that displayed this page
+	// and the root WindowNavigator.
+	*app.GenericPageModal
+
+	wallet sharedW.Asset
+	config *sharedW.MultisigConfig
+
+	pageContainer *widget.List
+	backButton    cryptomaterial.IconButton
+
+	// Setup.
+	localXPub        string
+	copyXPub         *cryptomaterial.Clickable
+	showXPubBtn      cryptomaterial.Button
+	requiredEditor   cryptomaterial.Editor
+	cosignersEditor  cryptomaterial.Editor
+	setupBtn         cryptomaterial.Button
+	balance          string
+	receiveAddress   string
+	copyAddress      *cryptomaterial.Clickable
+	newAddressBtn    cryptomaterial.Button
+	destEditor       cryptomaterial.Editor
+	amountEditor     cryptomaterial.Editor
+	sendMax          *widget.Bool
+	createBtn        cryptomaterial.Button
+	proposalEditor   cryptomaterial.Editor
+	filePathEditor   cryptomaterial.Editor
+	importBtn        cryptomaterial.Button
+	exportBtn        cryptomaterial.Button
+	copyProposalBtn  cryptomaterial.Button
+	signBtn          cryptomaterial.Button
+	broadcastBtn     cryptomaterial.Button
+	proposalInfo     *sharedW.MultisigProposalInfo
+	proposalErr      string
+	proposalQR       *image.Image
+	lastProposalText string
+}
+
+// NewMultisigPage creates a page that manages the multisig setup of wallet.
+func NewMultisigPage(l *load.Load, wallet sharedW.Asset) *MultisigPage {
+	th := l.Theme
+	pg := &MultisigPage{
+		Load:             l,
+		GenericPageModal: app.NewGenericPageModal(MultisigPageID),
+		wallet:           wallet,
+		pageContainer:    &widget.List{List: layout.List{Axis: layout.Vertical}},
+		backButton:       components.GetBackButton(l),
+
+		copyXPub:        th.NewClickable(false),
+		showXPubBtn:     th.OutlineButton(values.String(values.StrShowMultisigXPub)),
+		requiredEditor:  th.Editor(new(widget.Editor), values.String(values.StrRequiredSignatures)),
+		cosignersEditor: th.Editor(new(widget.Editor), values.String(values.StrCosignerXPubsHint)),
+		setupBtn:        th.Button(values.String(values.StrSetupMultisig)),
+		copyAddress:     th.NewClickable(false),
+		newAddressBtn:   th.OutlineButton(values.String(values.StrGenerateAddress)),
+		destEditor:      th.Editor(new(widget.Editor), values.String(values.StrDestAddr)),
+		amountEditor:    th.Editor(new(widget.Editor), values.String(values.StrAmount)),
+		sendMax:         new(widget.Bool),
+		createBtn:       th.Button(values.String(values.StrCreateProposal)),
+		proposalEditor:  th.Editor(new(widget.Editor), values.String(values.StrTxProposalHint)),
+		filePathEditor:  th.Editor(new(widget.Editor), values.String(values.StrProposalFilePath)),
+		importBtn:       th.OutlineButton(values.String(values.StrImport)),
+		exportBtn:       th.OutlineButton(values.String(values.StrExport)),
+		copyProposalBtn: th.OutlineButton(values.String(values.StrCopy)),
+		signBtn:         th.Button(values.String(values.StrSignProposal)),
+		broadcastBtn:    th.Button(values.String(values.StrBroadcast)),
+	}
+
+	pg.requiredEditor.Editor.SingleLine = true
+	pg.destEditor.Editor.SingleLine = true
+	pg.amountEditor.Editor.SingleLine = true
+	pg.filePathEditor.Editor.SingleLine = true
+	return pg
+}
+
+// OnNavigatedTo is called when the page is about to be displayed and
+// may be used to initialize page features that are only relevant when
+// the page is displayed.
+// Part of the load.Page interface.
+func (pg *MultisigPage) OnNavigatedTo() {
+	pg.config = pg.wallet.MultisigConfig()
+	if pg.config == nil {
+		pg.localXPub = pg.wallet.MultisigXPub()
+		return
+	}
+
+	pg.localXPub = pg.config.LocalXPub
+	pg.refreshBalance()
+}
+
+func (pg *MultisigPage) refreshBalance() {
+	balance, err := pg.wallet.MultisigBalance()
+	if err != nil {
+		log.Errorf("Error retrieving the multisig balance: %v", err)
+		return
+	}
+	pg.balance = balance.String()
+}
+
+// Layout draws the page UI components into the provided C
+// to be eventually drawn on screen.
+// Part of the load.Page interface.
+func (pg *MultisigPage) Layout(gtx C) D {
+	sp := components.SubPage{
+		Load:       pg.Load,
+		Title:      values.String(values.StrMultisig),
+		BackButton: pg.backButton,
+		Back: func() {
+			pg.ParentNavigator().CloseCurrentPage()
+		},
+		Body: func(gtx C) D {
+			sections := []layout.Widget{pg.setupSection}
+			if pg.config != nil {
+				sections = []layout.Widget{pg.summarySection, pg.receiveSection, pg.createProposalSection, pg.proposalSection}
+			}
+			return pg.Theme.List(pg.pageContainer).Layout(gtx, len(sections), func(gtx C, i int) D {
+				return sections[i](gtx)
+			})
+		},
+	}
+	return sp.Layout(pg.ParentWindow(), gtx)
+}
+
+func (pg *MultisigPage) section(gtx C, title string, body layout.Widget) D {
+	return cryptomaterial.LinearLayout{
+		Width:       cryptomaterial.MatchParent,
+		Height:      cryptomaterial.WrapContent,
+		Background:  pg.Theme.Color.Surface,
+		Margin:      layout.Inset{Bottom: values.MarginPadding16},
+		Padding:     layout.UniformInset(values.MarginPadding16),
+		Orientation: layout.Vertical,
+		Border:      cryptomaterial.Border{Radius: cryptomaterial.Radius(14)},
+	}.Layout(gtx,
+		layout.Rigid(func(gtx C) D {
+			lbl := pg.Theme.Label(values.TextSize16, title)
+			lbl.Font.Weight = font.SemiBold
+			return layout.Inset{Bottom: values.MarginPadding10}.Layout(gtx, lbl.Layout)
+		}),
+		layout.Rigid(body),
+	)
+}
+
+// copyableText lays out text that is copied to the clipboard when clicked.
+func (pg *MultisigPage) copyableText(gtx C, text string, clickable *cryptomaterial.Clickable, copiedMsg string) D {
+	if clickable.Clicked(gtx) {
+		gtx.Execute(clipboard.WriteCmd{Data: io.NopCloser(strings.NewReader(text))})
+		pg.Toast.Notify(copiedMsg)
+	}
+	return clickable.Layout(gtx, func(gtx C) D {
+		return layout.Flex{Alignment: layout.Middle}.Layout(gtx,
+			layout.Flexed(1, func(gtx C) D {
+				lbl := pg.Theme.Body2(text)
+				lbl.Color = pg.Theme.Color.GrayText2
+				return lbl.Layout(gtx)
+			}),
+			layout.Rigid(func(gtx C) D {
+				return layout.Inset{Left: values.MarginPadding8}.Layout(gtx, pg.Theme.NewIcon(pg.Theme.Icons.CopyIcon).Layout24dp)
+			}),
+		)
+	})
+}
+
+func (pg *MultisigPage) vertical(gtx C, widgets ...layout.Widget) D {
+	children := make([]layout.FlexChild, 0, len(widgets))
+	for _, w := range widgets {
+		w := w
+		children = append(children, layout.Rigid(func(gtx C) D {
+			return layout.Inset{Bottom: values.MarginPadding10}.Layout(gtx, w)
+		}))
+	}
+	return layout.Flex{Axis: layout.Vertical}.Layout(gtx, children...)
+}
+
+func (pg *MultisigPage) buttons(gtx C, btns ...*cryptomaterial.Button) D {
+	children := make([]layout.FlexChild, 0, len(btns))
+	for _, btn := range btns {
+		btn := btn
+		children = append(children, layout.Rigid(func(gtx C) D {
+			return layout.Inset{Right: values.MarginPadding8}.Layout(gtx, btn.Layout)
+		}))
+	}
+	return layout.Flex{}.Layout(gtx, children...)
+}
+
+func (pg *MultisigPage) setupSection(gtx C) D {
+	return pg.section(gtx, values.String(values.StrSetupMultisig), func(gtx C) D {
+		return pg.vertical(gtx,
+			func(gtx C) D {
+				desc := pg.Theme.Body2(values.String(values.StrMultisigSetupDesc))
+				desc.Color = pg.Theme.Color.GrayText2
+				return desc.Layout(gtx)
+			},
+			pg.Theme.Body1(values.String(values.StrYourXPub)).Layout,
+			func(gtx C) D {
+				if pg.localXPub == "" {
+					return pg.showXPubBtn.Layout(gtx)
+				}
+				return pg.copyableText(gtx, pg.localXPub, pg.copyXPub, values.String(values.StrXPubCopied))
+			},
+			pg.requiredEditor.Layout,
+			pg.cosignersEditor.Layout,
+			pg.setupBtn.Layout,
+		)
+	})
+}
+
+func (pg *MultisigPage) summarySection(gtx C) D {
+	return pg.section(gtx, values.StringF(values.StrMultisigBadgeFmt, pg.config.String()), func(gtx C) D {
+		widgets := []layout.Widget{
+			pg.Theme.Body1(fmt.Sprintf("%s: %s", values.String(values.StrMultisigBalance), pg.balance)).Layout,
+			pg.Theme.Body1(values.String(values.StrCosigners)).Layout,
+		}
+		for _, cosigner := range pg.config.Cosigners {
+			text := fmt.Sprintf("%s: %s", cosigner.Name, cosigner.XPub)
+			widgets = append(widgets, func(gtx C) D {
+				lbl := pg.Theme.Caption(text)
+				lbl.Color = pg.Theme.Color.GrayText2
+				return lbl.Layout(gtx)
+			})
+		}
+		return pg.vertical(gtx, widgets...)
+	})
+}
+
+func (pg *MultisigPage) receiveSection(gtx C) D {
+	return pg.section(gtx, values.String(values.StrReceiveAddress), func(gtx C) D {
+		return pg.vertical(gtx,
+			func(gtx C) D {
+				if pg.receiveAddress == "" {
+					return D{}
+				}
+				return pg.copyableText(gtx, pg.receiveAddress, pg.copyAddress, values.String(values.StrAddressCopied))
+			},
+			pg.newAddressBtn.Layout,
+		)
+	})
+}
+
+func (pg *MultisigPage) createProposalSection(gtx C) D {
+	return pg.section(gtx, values.String(values.StrCreateProposal), func(gtx C) D {
+		return pg.vertical(gtx,
+			pg.destEditor.Layout,
+			func(gtx C) D {
+				if pg.sendMax.Value {
+					return D{}
+				}
+				return pg.amountEditor.Layout(gtx)
+			},
+			pg.Theme.CheckBox(pg.sendMax, values.String(values.StrMax)).Layout,
+			pg.createBtn.Layout,
+		)
+	})
+}
+
+func (pg *MultisigPage) proposalSection(gtx C) D {
+	return pg.section(gtx, values.String(values.StrTxProposal), func(gtx C) D {
+		widgets := []layout.Widget{
+			pg.proposalEditor.Layout,
+			func(gtx C) D {
+				return layout.Flex{Alignment: layout.Middle}.Layout(gtx,
+					layout.Flexed(1, pg.filePathEditor.Layout),
+					layout.Rigid(func(gtx C) D {
+						return layout.Inset{Left: values.MarginPadding8}.Layout(gtx, func(gtx C) D {
+							return pg.buttons(gtx, &pg.importBtn, &pg.exportBtn)
+						})
+					}),
+				)
+			},
+		}
+
+		if pg.proposalErr != "" {
+			widgets = append(widgets, func(gtx C) D {
+				lbl := pg.Theme.Body2(pg.proposalErr)
+				lbl.Color = pg.Theme.Color.Danger
+				return lbl.Layout(gtx)
+			})
+		}
+
+		if info := pg.proposalInfo; info != nil {
+			for _, output := range info.Outputs {
+				text := fmt.Sprintf("%s %s", pg.wallet.ToAmount(output.Amount).String(), output.Address)
+				if output.IsChange {
+					text = fmt.Sprintf("%s (%s)", text, values.String(values.StrChangeOutput))
+				}
+				widgets = append(widgets, pg.Theme.Body2(text).Layout)
+			}
+
+			signedByLocal := values.String(values.StrNotSignedByYou)
+			if info.SignedByLocal {
+				signedByLocal = values.String(values.StrSignedByYou)
+			}
+			summary := fmt.Sprintf("%s: %s - %s - %s", values.String(values.StrFee), pg.wallet.ToAmount(info.Fee).String(),
+				values.StringF(values.StrSignaturesFmt, info.Signatures, info.Required), signedByLocal)
+			widgets = append(widgets, func(gtx C) D {
+				lbl := pg.Theme.Body2(summary)
+				lbl.Color = pg.Theme.Color.GrayText2
+				return lbl.Layout(gtx)
+			})
+
+			if pg.proposalQR != nil {
+				widgets = append(widgets, func(gtx C) D {
+					return pg.Theme.ImageIcon(gtx, *pg.proposalQR, 300)
+				})
+			}
+
+			btns := []*cryptomaterial.Button{&pg.copyProposalBtn}
+			if !info.SignedByLocal && !info.Complete() && !pg.wallet.IsWatchingOnlyWallet() {
+				btns = append(btns, &pg.signBtn)
+			}
+			if info.Complete() {
+				btns = append(btns, &pg.broadcastBtn)
+			}
+			widgets = append(widgets, func(gtx C) D {
+				return pg.buttons(gtx, btns...)
+			})
+		}
+		return pg.vertical(gtx, widgets...)
+	})
+}
+
+// HandleUserInteractions is called just before Layout() to determine
+// if any user interaction recently occurred on the page and may be
+// used to update the page's UI components shortly before they are
+// displayed.
+// Part of the load.Page interface.
+func (pg *MultisigPage) HandleUserInteractions(gtx C) {
+	if pg.showXPubBtn.Clicked(gtx) {
+		pg.showXPubModal(nil)
+	}
+
+	if pg.setupBtn.Clicked(gtx) {
+		if pg.localXPub == "" {
+			pg.showXPubModal(pg.showSetupModal)
+		} else {
+			pg.showSetupModal()
+		}
+	}
+
+	if pg.newAddressBtn.Clicked(gtx) {
+		address, err := pg.wallet.NewMultisigAddress()
+		if err != nil {
+			pg.showError(err)
+		} else {
+			pg.receiveAddress = address
+		}
+	}
+
+	if pg.createBtn.Clicked(gtx) {
+		pg.createProposal()
+	}
+
+	if pg.importBtn.Clicked(gtx) {
+		pg.importProposal()
+	}
+
+	if pg.exportBtn.Clicked(gtx) {
+		pg.exportProposal()
+	}
+
+	if pg.copyProposalBtn.Clicked(gtx) {
+		gtx.Execute(clipboard.WriteCmd{Data: io.NopCloser(strings.NewReader(pg.proposalEditor.Editor.Text()))})
+		pg.Toast.Notify(values.String(values.StrProposalCopied))
+	}
+
+	if pg.signBtn.Clicked(gtx) {
+		pg.showSignModal()
+	}
+
+	if pg.broadcastBtn.Clicked(gtx) {
+		pg.broadcastProposal()
+	}
+
+	if text := pg.proposalEditor.Editor.Text(); text != pg.lastProposalText {
+		pg.lastProposalText = text
+		pg.decodeProposal(text)
+	}
+}
+
+// showXPubModal asks for the spending password to derive the xpub of the
+// wallet's keys dedicated to multisig, then calls next if it isn't nil.
+func (pg *MultisigPage) showXPubModal(next func()) {
+	passwordModal := modal.NewCreatePasswordModal(pg.Load).
+		EnableName(false).
+		EnableConfirmPassword(false).
+		Title(values.String(values.StrShowMultisigXPub)).
+		SetDescription(values.String(values.StrMultisigXPubPassword)).
+		SetPositiveButtonCallback(func(_, password string, pm *modal.CreatePasswordModal) bool {
+			xpub, err := pg.wallet.DeriveMultisigXPub(password)
+			if err != nil {
+				pm.SetError(values.TranslateErr(err.Error()))
+				return false
+			}
+
+			pm.Dismiss()
+			pg.localXPub = xpub
+			if next != nil {
+				next()
+			}
+			return true
+		})
+	pg.ParentWindow().ShowModal(passwordModal)
+}
+
+func (pg *MultisigPage) showSetupModal() {
+	pg.requiredEditor.SetError("")
+	required, err := strconv.Atoi(strings.TrimSpace(pg.requiredEditor.Editor.Text()))
+	if err != nil || required < 1 {
+		pg.requiredEditor.SetError(values.String(values.StrInvalidRequiredSignatures))
+		return
+	}
+
+	cosigners := parseCosigners(pg.cosignersEditor.Editor.Text())
+	description := fmt.Sprintf("%d-of-%d", required, len(cosigners)+1)
+	confirmModal := modal.NewCustomModal(pg.Load).
+		Title(values.String(values.StrSetupMultisig)).
+		Body(values.StringF(values.StrSetupMultisigConfirmFmt, description)).
+		SetNegativeButtonText(values.String(values.StrCancel)).
+		SetPositiveButtonText(values.String(values.StrConfirm)).
+		SetPositiveButtonCallback(func(_ bool, _ *modal.InfoModal) bool {
+			if err := pg.wallet.SetupMultisig(required, cosigners); err != nil {
+				pg.showError(err)
+				return true
+			}
+			pg.Toast.Notify(values.String(values.StrMultisigSetupSuccess))
+			pg.OnNavigatedTo()
+			return true
+		})
+	pg.ParentWindow().ShowModal(confirmModal)
+}
+
+// parseCosigners parses one cosigner per line, the xpub being the last word
+// of the line and the cosigner name the words before it.
+func parseCosigners(text string) []*sharedW.Cosigner {
+	var cosigners []*sharedW.Cosigner
+	for _, line := range strings.Split(text, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+		xpub := fields[len(fields)-1]
+		name := strings.Join(fields[:len(fields)-1], " ")
+		if name == "" {
+			name = fmt.Sprintf("%s %d", values.String(values.StrCosigners), len(cosigners)+1)
+		}
+		cosigners = append(cosigners, &sharedW.Cosigner{Name: name, XPub: xpub})
+	}
+	return cosigners
+}
+
+func (pg *MultisigPage) createProposal() {
+	pg.destEditor.SetError("")
+	pg.amountEditor.SetError("")
+
+	address := strings.TrimSpace(pg.destEditor.Editor.Text())
+	if !pg.wallet.IsAddressValid(address) {
+		pg.destEditor.SetError(values.String(values.StrInvalidAddress))
+		return
+	}
+
+	var amount int64
+	if !pg.sendMax.Value {
+		coins, err := strconv.ParseFloat(strings.TrimSpace(pg.amountEditor.Editor.Text()), 64)
+		if err != nil || coins <= 0 {
+			pg.amountEditor.SetError(values.String(values.StrInvalidAmount))
+			return
+		}
+		if pg.wallet.GetAssetType() == libutils.BTCWalletAsset {
+			amount = btc.AmountSatoshi(coins)
+		} else {
+			amount = dcr.AmountAtom(coins)
+		}
+	}
+
+	proposal, err := pg.wallet.CreateMultisigProposal(address, amount, pg.sendMax.Value)
+	if err != nil {
+		pg.showError(err)
+		return
+	}
+	pg.proposalEditor.Editor.SetText(proposal)
+}
+
+func (pg *MultisigPage) decodeProposal(proposal string) {
+	pg.proposalInfo, pg.proposalErr, pg.proposalQR = nil, "", nil
+	if strings.TrimSpace(proposal) == "" {
+		return
+	}
+
+	info, err := pg.wallet.MultisigProposalInfo(proposal)
+	if err != nil {
+		pg.proposalErr = err.Error()
+		return
+	}
+	pg.proposalInfo = info
+
+	if len(proposal) > maxQRProposalSize {
+		return
+	}
+	qrCode, err := qrcode.New(proposal)
+	if err != nil {
+		log.Errorf("Error generating proposal qrCode: %v", err)
+		return
+	}
+	var buff bytes.Buffer
+	if err := qrCode.SaveTo(&buff); err != nil {
+		log.Error(err)
+		return
+	}
+	img, _, err := image.Decode(&buff)
+	if err != nil {
+		log.Error(err)
+		return
+	}
+	pg.proposalQR = &img
+}
+
+func (pg *MultisigPage) importProposal() {
+	pg.filePathEditor.SetError("")
+	proposal, err := os.ReadFile(strings.TrimSpace(pg.filePathEditor.Editor.Text()))
+	if err != nil {
+		pg.filePathEditor.SetError(err.Error())
+		return
+	}
+	pg.proposalEditor.Editor.SetText(strings.TrimSpace(string(proposal)))
+}
+
+// exportProposal writes the proposal to the given file path or to a file in
+// the exports directory.
+func (pg *MultisigPage) exportProposal() {
+	if pg.proposalInfo == nil {
+		return
+	}
+
+	fileName := strings.TrimSpace(pg.filePathEditor.Editor.Text())
+	if fileName == "" {
+		fileName = filepath.Join(pg.AssetsManager.RootDir(), "exports",
+			fmt.Sprintf("multisig_proposal_%s_%d.txt", pg.proposalInfo.TxID[:16], pg.proposalInfo.Signatures))
+	}
+
+	if err := os.MkdirAll(filepath.Dir(fileName), libutils.UserFilePerm); err != nil {
+		pg.showError(err)
+		return
+	}
+	if err := os.WriteFile(fileName, []byte(pg.proposalEditor.Editor.Text()), libutils.UserFilePerm); err != nil {
+		pg.showError(err)
+		return
+	}
+
+	pg.filePathEditor.Editor.SetText(fileName)
+	infoModal := modal.NewSuccessModal(pg.Load, values.StringF(values.StrProposalExportedFmt, fileName), modal.DefaultClickFunc())
+	pg.ParentWindow().ShowModal(infoModal)
+}
+
+func (pg *MultisigPage) showSignModal() {
+	proposal := pg.proposalEditor.Editor.Text()
+	passwordModal := modal.NewCreatePasswordModal(pg.Load).
+		EnableName(false).
+		EnableConfirmPassword(false).
+		Title(values.String(values.StrSignProposal)).
+		SetPositiveButtonCallback(func(_, password string, pm *modal.CreatePasswordModal) bool {
+			signed, err := pg.wallet.SignMultisigProposal(proposal, password)
+			if err != nil {
+				pm.SetError(err.Error())
+				return false
+			}
+
+			pm.Dismiss()
+			pg.proposalEditor.Editor.SetText(signed)
+			pg.Toast.Notify(values.String(values.StrProposalSigned))
+			return true
+		})
+	pg.ParentWindow().ShowModal(passwordModal)
+}
+
+func (pg *MultisigPage) broadcastProposal() {
+	txHash, err := pg.wallet.BroadcastMultisigProposal(pg.proposalEditor.Editor.Text(), "")
+	if err != nil {
+		pg.showError(err)
+		return
+	}
+
+	log.Infof("Broadcast multisig transaction %s", txHash)
+	pg.proposalEditor.Editor.SetText("")
+	pg.Toast.Notify(values.String(values.StrTxSent))
+	pg.refreshBalance()
+}
+
+func (pg *MultisigPage) showError(err error) {
+	errModal := modal.NewErrorModal(pg.Load, err.Error(), modal.DefaultClickFunc())
+	pg.ParentWindow().ShowModal(errModal)
+}
+
+// OnNavigatedFrom is called when the page is about to be removed from
+// the displayed window. This method should ideally be used to disable
+// features that are irrelevant when the page is NOT displayed.
+// NOTE: The page may be re-displayed on the app's window, in which case
+// OnNavigatedTo() will be called again. This method should not destroy UI
+// components unless they'll be recreated in the OnNavigatedTo() method.
+// Part of the load.Page interface.
+func (pg *MultisigPage) OnNavigatedFrom() {}
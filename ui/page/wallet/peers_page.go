@@ -0,0 +1,390 @@
+package wallet
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"gioui.org/layout"
+	"gioui.org/widget"
+
+	"github.com/crypto-power/cryptopower/app"
+	sharedW "github.com/crypto-power/cryptopower/libwallet/assets/wallet"
+	"github.com/crypto-power/cryptopower/ui/cryptomaterial"
+	"github.com/crypto-power/cryptopower/ui/load"
+	"github.com/crypto-power/cryptopower/ui/modal"
+	"github.com/crypto-power/cryptopower/ui/page/components"
+	"github.com/crypto-power/cryptopower/ui/values"
+)
+
+const (
+	PeersPageID = "Peers"
+
+	// peersRefreshInterval is how often the peer stats are refreshed while
+	// the page is displayed.
+	peersRefreshInterval = 2 * time.Second
+)
+
+// peerButtons are the actions on a connected peer, kept across refreshes so
+// that clicks aren't lost.
+type peerButtons struct {
+	disconnect cryptomaterial.Button
+	ban        cryptomaterial.Button
+	prefer     cryptomaterial.Button
+}
+
+// PeersPage lists the peers a wallet is connected to with live stats, and
+// manages its banned and preferred peers.
+type PeersPage struct {
+	*load.Load
+	// GenericPageModal defines methods such as ID() and OnAttachedToNavigator()
+	// that helps this Page satisfy the app.Page interface. It also defines
+	// helper methods for accessing the PageNavigator that displayed this page
+	// and the root WindowNavigator.
+	*app.GenericPageModal
+
+	wallet sharedW.Asset
+
+	pageContainer *widget.List
+	backButton    cryptomaterial.IconButton
+
+	peerEditor cryptomaterial.Editor
+	preferBtn  cryptomaterial.Button
+
+	mu          sync.RWMutex
+	peers       []sharedW.PeerInfo
+	peerBtns    map[string]*peerButtons
+	banned      []string
+	unbanBtns   []cryptomaterial.Button
+	preferred   []string
+	unpreferBtn []cryptomaterial.Button
+
+	stopRefresh chan struct{}
+}
+
+// NewPeersPage creates a page that manages the peers of wallet.
+func NewPeersPage(l *load.Load, wallet sharedW.Asset) *PeersPage {
+	pg := &PeersPage{
+		Load:             l,
+		GenericPageModal: app.NewGenericPageModal(PeersPageID),
+		wallet:           wallet,
+		pageContainer:    &widget.List{List: layout.List{Axis: layout.Vertical}},
+		backButton:       components.GetBackButton(l),
+
+		peerEditor: l.Theme.Editor(new(widget.Editor), values.String(values.StrPreferredPeerAddress)),
+		preferBtn:  l.Theme.Button(values.String(values.StrPrefer)),
+		peerBtns:   make(map[string]*peerButtons),
+	}
+	pg.peerEditor.Editor.SingleLine = true
+	return pg
+}
+
+// OnNavigatedTo is called when the page is about to be displayed and
+// may be used to initialize page features that are only relevant when
+// the page is displayed.
+// Part of the load.Page interface.
+func (pg *PeersPage) OnNavigatedTo() {
+	pg.loadPeerLists()
+	pg.stopRefresh = make(chan struct{})
+	go pg.refreshPeers(pg.stopRefresh)
+}
+
+// refreshPeers reloads the connected peers until stop is closed.
+func (pg *PeersPage) refreshPeers(stop chan struct{}) {
+	ticker := time.NewTicker(peersRefreshInterval)
+	defer ticker.Stop()
+	for {
+		pg.loadPeers()
+		pg.ParentWindow().Reload()
+		select {
+		case <-ticker.C:
+		case <-stop:
+			return
+		}
+	}
+}
+
+func (pg *PeersPage) loadPeers() {
+	// A wallet that isn't connected has no peers to list.
+	peers, _ := pg.wallet.PeerInfoRaw()
+
+	pg.mu.Lock()
+	defer pg.mu.Unlock()
+	pg.peers = peers
+	peerBtns := make(map[string]*peerButtons, len(peers))
+	for _, peer := range peers {
+		btns, ok := pg.peerBtns[peer.Addr]
+		if !ok {
+			btns = &peerButtons{
+				disconnect: pg.Theme.OutlineButton(values.String(values.StrDisconnect)),
+				ban:        pg.Theme.OutlineButton(values.String(values.StrBan)),
+				prefer:     pg.Theme.OutlineButton(values.String(values.StrPrefer)),
+			}
+		}
+		if peer.Preferred {
+			btns.prefer.Text = values.String(values.StrUnprefer)
+		} else {
+			btns.prefer.Text = values.String(values.StrPrefer)
+		}
+		peerBtns[peer.Addr] = btns
+	}
+	pg.peerBtns = peerBtns
+}
+
+func (pg *PeersPage) loadPeerLists() {
+	pg.mu.Lock()
+	defer pg.mu.Unlock()
+	pg.banned = pg.wallet.BannedPeers()
+	pg.unbanBtns = make([]cryptomaterial.Button, len(pg.banned))
+	for i := range pg.banned {
+		pg.unbanBtns[i] = pg.Theme.OutlineButton(values.String(values.StrUnban))
+	}
+	pg.preferred = pg.wallet.PreferredPeers()
+	pg.unpreferBtn = make([]cryptomaterial.Button, len(pg.preferred))
+	for i := range pg.preferred {
+		pg.unpreferBtn[i] = pg.Theme.OutlineButton(values.String(values.StrRemove))
+	}
+}
+
+// Layout draws the page UI components into the provided C
+// to be eventually drawn on screen.
+// Part of the load.Page interface.
+func (pg *PeersPage) Layout(gtx C) D {
+	sp := components.SubPage{
+		Load:       pg.Load,
+		Title:      values.String(values.StrPeerManagement),
+		BackButton: pg.backButton,
+		Back: func() {
+			pg.ParentNavigator().CloseCurrentPage()
+		},
+		Body: func(gtx C) D {
+			pg.mu.RLock()
+			defer pg.mu.RUnlock()
+			sections := []layout.Widget{pg.connectedPeersSection, pg.preferredPeersSection, pg.bannedPeersSection}
+			return pg.Theme.List(pg.pageContainer).Layout(gtx, len(sections), func(gtx C, i int) D {
+				return sections[i](gtx)
+			})
+		},
+	}
+	return sp.Layout(pg.ParentWindow(), gtx)
+}
+
+func (pg *PeersPage) section(gtx C, widgets ...layout.Widget) D {
+	children := make([]layout.FlexChild, 0, len(widgets))
+	for _, w := range widgets {
+		w := w
+		children = append(children, layout.Rigid(func(gtx C) D {
+			return layout.Inset{Bottom: values.MarginPadding10}.Layout(gtx, w)
+		}))
+	}
+
+	return cryptomaterial.LinearLayout{
+		Width:       cryptomaterial.MatchParent,
+		Height:      cryptomaterial.WrapContent,
+		Background:  pg.Theme.Color.Surface,
+		Margin:      layout.Inset{Bottom: values.MarginPadding16},
+		Padding:     layout.UniformInset(values.MarginPadding16),
+		Orientation: layout.Vertical,
+		Border:      cryptomaterial.Border{Radius: cryptomaterial.Radius(14)},
+	}.Layout(gtx, children...)
+}
+
+func (pg *PeersPage) grayText(text string) layout.Widget {
+	return func(gtx C) D {
+		lbl := pg.Theme.Body2(text)
+		lbl.Color = pg.Theme.Color.GrayText2
+		return lbl.Layout(gtx)
+	}
+}
+
+func (pg *PeersPage) connectedPeersSection(gtx C) D {
+	widgets := []layout.Widget{
+		pg.Theme.H6(values.String(values.StrConnectedPeers)).Layout,
+		pg.grayText(values.String(values.StrPeerManagementDesc)),
+	}
+	if len(pg.peers) == 0 {
+		widgets = append(widgets, pg.grayText(values.String(values.StrNoConnectedPeers)))
+	}
+	for _, peer := range pg.peers {
+		peer := peer
+		widgets = append(widgets, func(gtx C) D {
+			return pg.peerRow(gtx, peer)
+		})
+	}
+	return pg.section(gtx, widgets...)
+}
+
+func (pg *PeersPage) peerRow(gtx C, peer sharedW.PeerInfo) D {
+	btns := pg.peerBtns[peer.Addr]
+	latency := "-"
+	if peer.Latency > 0 {
+		latency = peer.Latency.Round(time.Millisecond).String()
+	}
+	height := peer.LastBlock
+	if height == 0 {
+		height = peer.StartingHeight
+	}
+	traffic := values.StringF(values.StrPeerTrafficFmt, latency, formatBytes(peer.BytesSent), formatBytes(peer.BytesReceived))
+	if peer.BanScore != sharedW.NoBanScore {
+		traffic = values.StringF(values.StrPeerBanScoreFmt, traffic, peer.BanScore)
+	}
+
+	return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+		layout.Rigid(pg.Theme.Body1(peer.Addr).Layout),
+		layout.Rigid(pg.grayText(values.StringF(values.StrPeerStatsFmt, peer.Version, peer.SubVer, height))),
+		layout.Rigid(pg.grayText(traffic)),
+		layout.Rigid(func(gtx C) D {
+			return layout.Inset{Top: values.MarginPadding8}.Layout(gtx, func(gtx C) D {
+				return layout.Flex{}.Layout(gtx,
+					layout.Rigid(func(gtx C) D {
+						return layout.Inset{Right: values.MarginPadding8}.Layout(gtx, btns.disconnect.Layout)
+					}),
+					layout.Rigid(func(gtx C) D {
+						return layout.Inset{Right: values.MarginPadding8}.Layout(gtx, btns.ban.Layout)
+					}),
+					layout.Rigid(btns.prefer.Layout),
+				)
+			})
+		}),
+	)
+}
+
+func (pg *PeersPage) preferredPeersSection(gtx C) D {
+	widgets := []layout.Widget{
+		pg.Theme.H6(values.String(values.StrPreferredPeers)).Layout,
+	}
+	if len(pg.preferred) == 0 {
+		widgets = append(widgets, pg.grayText(values.String(values.StrNoPreferredPeers)))
+	}
+	for i, addr := range pg.preferred {
+		widgets = append(widgets, pg.listRow(addr, pg.unpreferBtn[i]))
+	}
+	widgets = append(widgets, pg.peerEditor.Layout, pg.preferBtn.Layout)
+	return pg.section(gtx, widgets...)
+}
+
+func (pg *PeersPage) bannedPeersSection(gtx C) D {
+	widgets := []layout.Widget{
+		pg.Theme.H6(values.String(values.StrBannedPeers)).Layout,
+	}
+	if len(pg.banned) == 0 {
+		widgets = append(widgets, pg.grayText(values.String(values.StrNoBannedPeers)))
+	}
+	for i, host := range pg.banned {
+		widgets = append(widgets, pg.listRow(host, pg.unbanBtns[i]))
+	}
+	return pg.section(gtx, widgets...)
+}
+
+func (pg *PeersPage) listRow(text string, btn cryptomaterial.Button) layout.Widget {
+	return func(gtx C) D {
+		return layout.Flex{Alignment: layout.Middle}.Layout(gtx,
+			layout.Flexed(1, pg.Theme.Body1(text).Layout),
+			layout.Rigid(btn.Layout),
+		)
+	}
+}
+
+// HandleUserInteractions is called just before Layout() to determine
+// if any user interaction recently occurred on the page and may be
+// used to update the page's UI components shortly before they are
+// displayed.
+// Part of the load.Page interface.
+func (pg *PeersPage) HandleUserInteractions(gtx C) {
+	pg.mu.RLock()
+	peers := pg.peers
+	peerBtns := pg.peerBtns
+	banned, unbanBtns := pg.banned, pg.unbanBtns
+	preferred, unpreferBtns := pg.preferred, pg.unpreferBtn
+	pg.mu.RUnlock()
+
+	var changed bool
+	for _, peer := range peers {
+		btns := peerBtns[peer.Addr]
+		if btns.disconnect.Clicked(gtx) {
+			if err := pg.wallet.DisconnectPeer(peer.Addr); err != nil {
+				pg.showError(err)
+			} else {
+				pg.Toast.Notify(values.String(values.StrPeerDisconnected))
+			}
+		}
+		if btns.ban.Clicked(gtx) {
+			if err := pg.wallet.BanPeer(peer.Addr); err != nil {
+				pg.showError(err)
+			} else {
+				pg.Toast.Notify(values.String(values.StrPeerBanned))
+				changed = true
+			}
+		}
+		if btns.prefer.Clicked(gtx) {
+			if err := pg.wallet.SetPeerPreferred(peer.Addr, !peer.Preferred); err != nil {
+				pg.showError(err)
+			} else {
+				changed = true
+			}
+		}
+	}
+
+	for i, btn := range unbanBtns {
+		if btn.Clicked(gtx) {
+			if err := pg.wallet.UnbanPeer(banned[i]); err != nil {
+				pg.showError(err)
+			} else {
+				changed = true
+			}
+		}
+	}
+
+	for i, btn := range unpreferBtns {
+		if btn.Clicked(gtx) {
+			if err := pg.wallet.SetPeerPreferred(preferred[i], false); err != nil {
+				pg.showError(err)
+			} else {
+				changed = true
+			}
+		}
+	}
+
+	if pg.preferBtn.Clicked(gtx) {
+		if err := pg.wallet.SetPeerPreferred(pg.peerEditor.Editor.Text(), true); err != nil {
+			pg.showError(err)
+		} else {
+			pg.peerEditor.Editor.SetText("")
+			changed = true
+		}
+	}
+
+	if changed {
+		pg.loadPeerLists()
+	}
+}
+
+func (pg *PeersPage) showError(err error) {
+	errModal := modal.NewErrorModal(pg.Load, values.TranslateErr(err.Error()), modal.DefaultClickFunc())
+	pg.ParentWindow().ShowModal(errModal)
+}
+
+// formatBytes formats a number of bytes with a binary unit.
+func formatBytes(n uint64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := uint64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(n)/float64(div), "KMGTPE"[exp])
+}
+
+// OnNavigatedFrom is called when the page is about to be removed from
+// the displayed window. This method should ideally be used to disable
+// features that are irrelevant when the page is NOT displayed.
+// NOTE: The page may be re-displayed on the app's window, in which case
+// OnNavigatedTo() will be called again. This method should not destroy UI
+// components unless they'll be recreated in the OnNavigatedTo() method.
+// Part of the load.Page interface.
+func (pg *PeersPage) OnNavigatedFrom() {
+	close(pg.stopRefresh)
+}
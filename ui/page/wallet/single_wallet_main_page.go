@@ -13,6 +13,7 @@ import (
 	"gioui.org/widget/material"
 
 	"github.com/crypto-power/cryptopower/app"
+	"github.com/crypto-power/cryptopower/libwallet"
 	"github.com/crypto-power/cryptopower/libwallet/assets/dcr"
 	sharedW "github.com/crypto-power/cryptopower/libwallet/assets/wallet"
 	libutils "github.com/crypto-power/cryptopower/libwallet/utils"
@@ -183,6 +184,12 @@ func (swmp *SingleWalletMasterPage) OnNavigatedTo() {
 
 	if needBackup && !backupLater {
 		swmp.showBackupInfo()
+	} else if !needBackup {
+		go func() {
+			if reminder := swmp.AssetsManager.SeedCheckReminder(swmp.selectedWallet); reminder != nil {
+				swmp.showSeedCheckReminder(reminder)
+			}
+		}()
 	}
 	// set active tab value
 	swmp.activeTab[swmp.PageNavigationTab.SelectedSegment()] = swmp.CurrentPageID()
@@ -333,6 +340,16 @@ func (swmp *SingleWalletMasterPage) OnCurrencyChanged() {
 	swmp.updateExchangeSetting()
 }
 
+// OnAppLocked is triggered when the app is locked after a period of
+// inactivity.
+// Satisfies the load.AppLockHandler interface.
+func (swmp *SingleWalletMasterPage) OnAppLocked() {
+	swmp.isBalanceHidden = true
+	if currentPage, ok := swmp.CurrentPage().(load.AppLockHandler); ok {
+		currentPage.OnAppLocked()
+	}
+}
+
 func (swmp *SingleWalletMasterPage) changeTab(tab string) {
 	selectedTab[swmp.selectedWallet.GetWalletID()] = tab
 	swmp.PageNavigationTab.SetSelectedSegment(tab)
@@ -873,6 +890,33 @@ func (swmp *SingleWalletMasterPage) showBackupInfo() {
 	swmp.ParentWindow().ShowModal(backupNowOrLaterModal)
 }
 
+func (swmp *SingleWalletMasterPage) showSeedCheckReminder(reminder *libwallet.SeedCheckReminder) {
+	walletName := reminder.Wallet.GetWalletName()
+	body := values.StringF(values.StrSeedCheckDueDesc, walletName)
+	if reminder.HighBalance {
+		body = values.StringF(values.StrSeedCheckHighBalanceDesc, walletName, reminder.LastCheck.Format("Jan 2, 2006"))
+	}
+
+	reminderModal := modal.NewCustomModal(swmp.Load).
+		Title(values.String(values.StrSeedCheckDue)).
+		Body(body).
+		SetCancelable(false).
+		SetNegativeButtonText(values.String(values.StrRemindMeLater)).
+		SetNegativeButtonCallback(reminder.Snooze).
+		SetPositiveButtonText(values.String(values.StrCheckNow)).
+		SetPositiveButtonCallback(func(_ bool, _ *modal.InfoModal) bool {
+			currentPage := swmp.ParentWindow().CurrentPageID()
+			swmp.ParentWindow().Display(seedbackup.NewSeedCheckPage(swmp.Load, reminder.Wallet, func(_ *load.Load, navigator app.WindowNavigator) {
+				navigator.ClosePagesAfter(currentPage)
+			}))
+			return true
+		})
+	if reminder.HighBalance {
+		reminderModal.Icon(swmp.Theme.Icons.OrangeAlert)
+	}
+	swmp.ParentWindow().ShowModal(reminderModal)
+}
+
 func (swmp *SingleWalletMasterPage) backup(wallet sharedW.Asset) {
 	currentPage := swmp.ParentWindow().CurrentPageID()
 	swmp.ParentWindow().Display(seedbackup.NewBackupInstructionsPage(swmp.Load, wallet, func(_ *load.Load, navigator app.WindowNavigator) {
@@ -0,0 +1,357 @@
+package wallet
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"gioui.org/layout"
+	"gioui.org/widget"
+
+	"github.com/crypto-power/cryptopower/app"
+	"github.com/crypto-power/cryptopower/libwallet/assets/btc"
+	"github.com/crypto-power/cryptopower/libwallet/assets/dcr"
+	"github.com/crypto-power/cryptopower/libwallet/assets/ltc"
+	sharedW "github.com/crypto-power/cryptopower/libwallet/assets/wallet"
+	libutils "github.com/crypto-power/cryptopower/libwallet/utils"
+	"github.com/crypto-power/cryptopower/ui/cryptomaterial"
+	"github.com/crypto-power/cryptopower/ui/load"
+	"github.com/crypto-power/cryptopower/ui/modal"
+	"github.com/crypto-power/cryptopower/ui/page/components"
+	"github.com/crypto-power/cryptopower/ui/values"
+)
+
+const SpendingPolicyPageID = "SpendingPolicy"
+
+// SpendingPolicyPage edits the spending policy of the whole wallet and lists
+// the sends held back by its delay.
+type SpendingPolicyPage struct {
+	*load.Load
+	// GenericPageModal defines methods such as ID() and OnAttachedToNavigator()
+	// that helps this Page satisfy the app.Page interface. It also defines
+	// helper methods for accessing the PageNavigator that displayed this page
+	// and the root WindowNavigator.
+	*app.GenericPageModal
+
+	wallet sharedW.Asset
+
+	pageContainer *widget.List
+	backButton    cryptomaterial.IconButton
+
+	dailyEditor     cryptomaterial.Editor
+	weeklyEditor    cryptomaterial.Editor
+	thresholdEditor cryptomaterial.Editor
+	delayEditor     cryptomaterial.Editor
+	whitelistEditor cryptomaterial.Editor
+	whitelistOnly   *widget.Bool
+	confirmNew      *widget.Bool
+	saveBtn         cryptomaterial.Button
+	removeBtn       cryptomaterial.Button
+
+	delayedSends []*sharedW.DelayedSend
+	cancelBtns   []cryptomaterial.Button
+}
+
+// NewSpendingPolicyPage creates a page that manages the spending policy of
+// wallet.
+func NewSpendingPolicyPage(l *load.Load, wallet sharedW.Asset) *SpendingPolicyPage {
+	th := l.Theme
+	pg := &SpendingPolicyPage{
+		Load:             l,
+		GenericPageModal: app.NewGenericPageModal(SpendingPolicyPageID),
+		wallet:           wallet,
+		pageContainer:    &widget.List{List: layout.List{Axis: layout.Vertical}},
+		backButton:       components.GetBackButton(l),
+
+		dailyEditor:     th.Editor(new(widget.Editor), values.String(values.StrDailyLimit)),
+		weeklyEditor:    th.Editor(new(widget.Editor), values.String(values.StrWeeklyLimit)),
+		thresholdEditor: th.Editor(new(widget.Editor), values.String(values.StrDelaySendsAbove)),
+		delayEditor:     th.Editor(new(widget.Editor), values.String(values.StrSendDelayMinutes)),
+		whitelistEditor: th.Editor(new(widget.Editor), values.String(values.StrWhitelistHint)),
+		whitelistOnly:   new(widget.Bool),
+		confirmNew:      new(widget.Bool),
+		saveBtn:         th.Button(values.String(values.StrSave)),
+		removeBtn:       th.OutlineButton(values.String(values.StrRemovePolicy)),
+	}
+
+	pg.dailyEditor.Editor.SingleLine = true
+	pg.weeklyEditor.Editor.SingleLine = true
+	pg.thresholdEditor.Editor.SingleLine = true
+	pg.delayEditor.Editor.SingleLine = true
+	return pg
+}
+
+// OnNavigatedTo is called when the page is about to be displayed and
+// may be used to initialize page features that are only relevant when
+// the page is displayed.
+// Part of the load.Page interface.
+func (pg *SpendingPolicyPage) OnNavigatedTo() {
+	policy := pg.wallet.SpendingPolicies()[sharedW.AllAccounts]
+	if policy == nil {
+		policy = new(sharedW.SpendingPolicy)
+	}
+
+	pg.dailyEditor.Editor.SetText(pg.coinText(policy.DailyLimit))
+	pg.weeklyEditor.Editor.SetText(pg.coinText(policy.WeeklyLimit))
+	pg.thresholdEditor.Editor.SetText(pg.coinText(policy.DelayThreshold))
+	pg.delayEditor.Editor.SetText("")
+	if policy.DelaySeconds > 0 {
+		pg.delayEditor.Editor.SetText(strconv.FormatInt(policy.DelaySeconds/60, 10))
+	}
+	pg.whitelistEditor.Editor.SetText(strings.Join(policy.Whitelist, "\n"))
+	pg.whitelistOnly.Value = policy.WhitelistOnly
+	pg.confirmNew.Value = policy.ConfirmNewDestinations
+
+	pg.refreshDelayedSends()
+}
+
+func (pg *SpendingPolicyPage) refreshDelayedSends() {
+	pg.delayedSends = pg.wallet.DelayedSends()
+	pg.cancelBtns = make([]cryptomaterial.Button, len(pg.delayedSends))
+	for i := range pg.cancelBtns {
+		pg.cancelBtns[i] = pg.Theme.OutlineButton(values.String(values.StrCancel))
+	}
+}
+
+// coinText formats an amount in atoms for editing, or returns an empty
+// string if the amount is zero.
+func (pg *SpendingPolicyPage) coinText(amount int64) string {
+	if amount == 0 {
+		return ""
+	}
+	return strconv.FormatFloat(pg.wallet.ToAmount(amount).ToCoin(), 'f', -1, 64)
+}
+
+// atoms parses the coin amount of editor. An empty editor is a zero amount.
+func (pg *SpendingPolicyPage) atoms(editor *cryptomaterial.Editor) (int64, bool) {
+	text := strings.TrimSpace(editor.Editor.Text())
+	if text == "" {
+		return 0, true
+	}
+	coins, err := strconv.ParseFloat(text, 64)
+	if err != nil || coins < 0 {
+		editor.SetError(values.String(values.StrInvalidAmount))
+		return 0, false
+	}
+	editor.SetError("")
+
+	switch pg.wallet.GetAssetType() {
+	case libutils.BTCWalletAsset:
+		return btc.AmountSatoshi(coins), true
+	case libutils.LTCWalletAsset:
+		return ltc.AmountLitoshi(coins), true
+	default:
+		return dcr.AmountAtom(coins), true
+	}
+}
+
+// Layout draws the page UI components into the provided C
+// to be eventually drawn on screen.
+// Part of the load.Page interface.
+func (pg *SpendingPolicyPage) Layout(gtx C) D {
+	sp := components.SubPage{
+		Load:       pg.Load,
+		Title:      values.String(values.StrSpendingPolicy),
+		BackButton: pg.backButton,
+		Back: func() {
+			pg.ParentNavigator().CloseCurrentPage()
+		},
+		Body: func(gtx C) D {
+			sections := []layout.Widget{pg.policySection, pg.delayedSendsSection}
+			return pg.Theme.List(pg.pageContainer).Layout(gtx, len(sections), func(gtx C, i int) D {
+				return sections[i](gtx)
+			})
+		},
+	}
+	return sp.Layout(pg.ParentWindow(), gtx)
+}
+
+func (pg *SpendingPolicyPage) section(gtx C, title string, widgets ...layout.Widget) D {
+	children := []layout.FlexChild{
+		layout.Rigid(func(gtx C) D {
+			lbl := pg.Theme.Label(values.TextSize16, title)
+			return layout.Inset{Bottom: values.MarginPadding10}.Layout(gtx, lbl.Layout)
+		}),
+	}
+	for _, w := range widgets {
+		w := w
+		children = append(children, layout.Rigid(func(gtx C) D {
+			return layout.Inset{Bottom: values.MarginPadding10}.Layout(gtx, w)
+		}))
+	}
+
+	return cryptomaterial.LinearLayout{
+		Width:       cryptomaterial.MatchParent,
+		Height:      cryptomaterial.WrapContent,
+		Background:  pg.Theme.Color.Surface,
+		Margin:      layout.Inset{Bottom: values.MarginPadding16},
+		Padding:     layout.UniformInset(values.MarginPadding16),
+		Orientation: layout.Vertical,
+		Border:      cryptomaterial.Border{Radius: cryptomaterial.Radius(14)},
+	}.Layout(gtx, children...)
+}
+
+func (pg *SpendingPolicyPage) policySection(gtx C) D {
+	return pg.section(gtx, values.String(values.StrSpendingPolicy),
+		func(gtx C) D {
+			desc := pg.Theme.Body2(values.String(values.StrSpendingPolicyDesc))
+			desc.Color = pg.Theme.Color.GrayText2
+			return desc.Layout(gtx)
+		},
+		pg.dailyEditor.Layout,
+		pg.weeklyEditor.Layout,
+		pg.thresholdEditor.Layout,
+		pg.delayEditor.Layout,
+		pg.whitelistEditor.Layout,
+		pg.Theme.CheckBox(pg.whitelistOnly, values.String(values.StrWhitelistOnly)).Layout,
+		pg.Theme.CheckBox(pg.confirmNew, values.String(values.StrConfirmNewDestinations)).Layout,
+		func(gtx C) D {
+			return layout.Flex{}.Layout(gtx,
+				layout.Rigid(func(gtx C) D {
+					return layout.Inset{Right: values.MarginPadding8}.Layout(gtx, pg.saveBtn.Layout)
+				}),
+				layout.Rigid(pg.removeBtn.Layout),
+			)
+		},
+	)
+}
+
+func (pg *SpendingPolicyPage) delayedSendsSection(gtx C) D {
+	if len(pg.delayedSends) == 0 {
+		return pg.section(gtx, values.String(values.StrDelayedSends), pg.Theme.Body2(values.String(values.StrNoDelayedSends)).Layout)
+	}
+
+	widgets := make([]layout.Widget, 0, len(pg.delayedSends))
+	for i, send := range pg.delayedSends {
+		addresses := make([]string, 0, len(send.Outputs))
+		for _, output := range send.Outputs {
+			addresses = append(addresses, output.Address)
+		}
+		text := values.StringF(values.StrDelayedSendFmt, pg.wallet.ToAmount(send.Amount()).String(),
+			strings.Join(addresses, ", "), time.Unix(send.BroadcastAt, 0).Format("Jan 2, 2006 15:04"))
+		cancelBtn := &pg.cancelBtns[i]
+		widgets = append(widgets, func(gtx C) D {
+			return layout.Flex{Alignment: layout.Middle}.Layout(gtx,
+				layout.Flexed(1, pg.Theme.Body2(text).Layout),
+				layout.Rigid(cancelBtn.Layout),
+			)
+		})
+	}
+	return pg.section(gtx, values.String(values.StrDelayedSends), widgets...)
+}
+
+// HandleUserInteractions is called just before Layout() to determine
+// if any user interaction recently occurred on the page and may be
+// used to update the page's UI components shortly before they are
+// displayed.
+// Part of the load.Page interface.
+func (pg *SpendingPolicyPage) HandleUserInteractions(gtx C) {
+	if pg.saveBtn.Clicked(gtx) {
+		pg.savePolicy()
+	}
+
+	if pg.removeBtn.Clicked(gtx) {
+		pg.setPolicy(nil, func() {
+			pg.Toast.Notify(values.String(values.StrSpendingPolicyRemoved))
+			pg.OnNavigatedTo()
+		})
+	}
+
+	for i := range pg.cancelBtns {
+		if !pg.cancelBtns[i].Clicked(gtx) {
+			continue
+		}
+		if err := pg.wallet.CancelDelayedSend(pg.delayedSends[i].Hash); err != nil {
+			pg.showError(err)
+		} else {
+			pg.Toast.Notify(values.String(values.StrDelayedSendCancelled))
+		}
+		pg.refreshDelayedSends()
+		break
+	}
+}
+
+func (pg *SpendingPolicyPage) savePolicy() {
+	policy := &sharedW.SpendingPolicy{
+		WhitelistOnly:          pg.whitelistOnly.Value,
+		ConfirmNewDestinations: pg.confirmNew.Value,
+	}
+
+	var ok bool
+	if policy.DailyLimit, ok = pg.atoms(&pg.dailyEditor); !ok {
+		return
+	}
+	if policy.WeeklyLimit, ok = pg.atoms(&pg.weeklyEditor); !ok {
+		return
+	}
+	if policy.DelayThreshold, ok = pg.atoms(&pg.thresholdEditor); !ok {
+		return
+	}
+
+	if text := strings.TrimSpace(pg.delayEditor.Editor.Text()); text != "" {
+		minutes, err := strconv.ParseInt(text, 10, 64)
+		if err != nil || minutes < 0 {
+			pg.delayEditor.SetError(values.String(values.StrInvalidAmount))
+			return
+		}
+		policy.DelaySeconds = minutes * 60
+	}
+	pg.delayEditor.SetError("")
+
+	for _, address := range strings.Fields(pg.whitelistEditor.Editor.Text()) {
+		if !pg.wallet.IsAddressValid(address) {
+			pg.whitelistEditor.SetError(values.String(values.StrInvalidAddress))
+			return
+		}
+		policy.Whitelist = append(policy.Whitelist, address)
+	}
+	pg.whitelistEditor.SetError("")
+
+	pg.setPolicy(policy, func() {
+		pg.Toast.Notify(values.String(values.StrSpendingPolicySaved))
+	})
+}
+
+// setPolicy replaces the policy of the wallet with policy, or removes it if
+// policy is nil, and calls onSet. The spending password is asked for if the
+// change loosens the policy.
+func (pg *SpendingPolicyPage) setPolicy(policy *sharedW.SpendingPolicy, onSet func()) {
+	if !pg.wallet.SpendingPolicy(sharedW.AllAccounts).IsLoosenedBy(policy) {
+		if err := pg.wallet.SetSpendingPolicy(sharedW.AllAccounts, policy, ""); err != nil {
+			pg.showError(err)
+			return
+		}
+		onSet()
+		return
+	}
+
+	passwordModal := modal.NewCreatePasswordModal(pg.Load).
+		EnableName(false).
+		EnableConfirmPassword(false).
+		Title(values.String(values.StrLoosenSpendingPolicy)).
+		SetPositiveButtonCallback(func(_, password string, pm *modal.CreatePasswordModal) bool {
+			if err := pg.wallet.SetSpendingPolicy(sharedW.AllAccounts, policy, password); err != nil {
+				pm.SetError(values.TranslateErr(err.Error()))
+				return false
+			}
+
+			pm.Dismiss()
+			onSet()
+			return true
+		})
+	pg.ParentWindow().ShowModal(passwordModal)
+}
+
+func (pg *SpendingPolicyPage) showError(err error) {
+	errModal := modal.NewErrorModal(pg.Load, err.Error(), modal.DefaultClickFunc())
+	pg.ParentWindow().ShowModal(errModal)
+}
+
+// OnNavigatedFrom is called when the page is about to be removed from
+// the displayed window. This method should ideally be used to disable
+// features that are irrelevant when the page is NOT displayed.
+// NOTE: The page may be re-displayed on the app's window, in which case
+// OnNavigatedTo() will be called again. This method should not destroy UI
+// components unless they'll be recreated in the OnNavigatedTo() method.
+// Part of the load.Page interface.
+func (pg *SpendingPolicyPage) OnNavigatedFrom() {}
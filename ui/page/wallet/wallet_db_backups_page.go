@@ -0,0 +1,195 @@
+package wallet
+
+import (
+	"fmt"
+
+	"gioui.org/layout"
+	"gioui.org/widget"
+	"gioui.org/widget/material"
+
+	"github.com/crypto-power/cryptopower/app"
+	sharedW "github.com/crypto-power/cryptopower/libwallet/assets/wallet"
+	"github.com/crypto-power/cryptopower/ui/cryptomaterial"
+	"github.com/crypto-power/cryptopower/ui/load"
+	"github.com/crypto-power/cryptopower/ui/modal"
+	"github.com/crypto-power/cryptopower/ui/page/components"
+	"github.com/crypto-power/cryptopower/ui/values"
+)
+
+const WalletDBBackupsPageID = "WalletDBBackups"
+
+// WalletDBBackupsPage lists the backups of a wallet database, creates new
+// backups and schedules the restore of a backup.
+type WalletDBBackupsPage struct {
+	*load.Load
+	// GenericPageModal defines methods such as ID() and OnAttachedToNavigator()
+	// that helps this Page satisfy the app.Page interface. It also defines
+	// helper methods for accessing the PageNavigator that displayed this page
+	// and the root WindowNavigator.
+	*app.GenericPageModal
+
+	wallet sharedW.Asset
+
+	pageContainer *widget.List
+	backButton    cryptomaterial.IconButton
+	backupNowBtn  cryptomaterial.Button
+
+	backups     []*sharedW.WalletDBBackup
+	restoreBtns []cryptomaterial.Button
+	backingUp   bool
+}
+
+// NewWalletDBBackupsPage creates a page that manages the database backups of
+// wallet.
+func NewWalletDBBackupsPage(l *load.Load, wallet sharedW.Asset) *WalletDBBackupsPage {
+	return &WalletDBBackupsPage{
+		Load:             l,
+		GenericPageModal: app.NewGenericPageModal(WalletDBBackupsPageID),
+		wallet:           wallet,
+		pageContainer:    &widget.List{List: layout.List{Axis: layout.Vertical}},
+		backButton:       components.GetBackButton(l),
+		backupNowBtn:     l.Theme.Button(values.String(values.StrBackupNow)),
+	}
+}
+
+// OnNavigatedTo is called when the page is about to be displayed and
+// may be used to initialize page features that are only relevant when
+// the page is displayed.
+// Part of the load.Page interface.
+func (pg *WalletDBBackupsPage) OnNavigatedTo() {
+	pg.loadBackups()
+}
+
+func (pg *WalletDBBackupsPage) loadBackups() {
+	backups, err := pg.wallet.WalletDBBackups()
+	if err != nil {
+		log.Errorf("Error reading wallet database backups: %v", err)
+	}
+
+	pg.backups = backups
+	pg.restoreBtns = make([]cryptomaterial.Button, len(backups))
+	for i := range backups {
+		pg.restoreBtns[i] = pg.Theme.OutlineButton(values.String(values.StrRestore))
+	}
+}
+
+// Layout draws the page UI components into the provided C
+// to be eventually drawn on screen.
+// Part of the load.Page interface.
+func (pg *WalletDBBackupsPage) Layout(gtx C) D {
+	sp := components.SubPage{
+		Load:       pg.Load,
+		Title:      values.String(values.StrWalletDBBackups),
+		BackButton: pg.backButton,
+		Back: func() {
+			pg.ParentNavigator().CloseCurrentPage()
+		},
+		Body: func(gtx C) D {
+			return pg.Theme.Card().Layout(gtx, func(gtx C) D {
+				gtx.Constraints.Min.X = gtx.Constraints.Max.X
+				return layout.UniformInset(values.MarginPadding16).Layout(gtx, pg.layoutContent)
+			})
+		},
+	}
+	return sp.Layout(pg.ParentWindow(), gtx)
+}
+
+func (pg *WalletDBBackupsPage) layoutContent(gtx C) D {
+	return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+		layout.Rigid(func(gtx C) D {
+			desc := pg.Theme.Body2(values.StringF(values.StrWalletDBBackupsDescFmt, sharedW.MaxWalletDBBackups))
+			desc.Color = pg.Theme.Color.GrayText2
+			return layout.Inset{Bottom: values.MarginPadding16}.Layout(gtx, desc.Layout)
+		}),
+		layout.Rigid(func(gtx C) D {
+			return layout.Inset{Bottom: values.MarginPadding16}.Layout(gtx, func(gtx C) D {
+				if pg.backingUp {
+					return layout.Center.Layout(gtx, material.Loader(pg.Theme.Base).Layout)
+				}
+				return pg.backupNowBtn.Layout(gtx)
+			})
+		}),
+		layout.Flexed(1, func(gtx C) D {
+			if len(pg.backups) == 0 {
+				lbl := pg.Theme.Body2(values.String(values.StrNoWalletDBBackups))
+				lbl.Color = pg.Theme.Color.GrayText3
+				return lbl.Layout(gtx)
+			}
+			return pg.Theme.List(pg.pageContainer).Layout(gtx, len(pg.backups), pg.backupRow)
+		}),
+	)
+}
+
+func (pg *WalletDBBackupsPage) backupRow(gtx C, i int) D {
+	backup := pg.backups[i]
+	return layout.Inset{Bottom: values.MarginPadding10}.Layout(gtx, func(gtx C) D {
+		return layout.Flex{Alignment: layout.Middle}.Layout(gtx,
+			layout.Flexed(1, func(gtx C) D {
+				return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+					layout.Rigid(pg.Theme.Body1(backup.CreatedAt.Local().Format("2006-01-02 15:04:05")).Layout),
+					layout.Rigid(func(gtx C) D {
+						lbl := pg.Theme.Body2(fmt.Sprintf("%.2f MB", float64(backup.Size)/(1<<20)))
+						lbl.Color = pg.Theme.Color.GrayText2
+						return lbl.Layout(gtx)
+					}),
+				)
+			}),
+			layout.Rigid(pg.restoreBtns[i].Layout),
+		)
+	})
+}
+
+// HandleUserInteractions is called just before Layout() to determine
+// if any user interaction recently occurred on the page and may be
+// used to update the page's UI components shortly before they are
+// displayed.
+// Part of the load.Page interface.
+func (pg *WalletDBBackupsPage) HandleUserInteractions(gtx C) {
+	if pg.backupNowBtn.Clicked(gtx) && !pg.backingUp {
+		pg.backingUp = true
+		go func() {
+			_, err := pg.wallet.BackupWalletDB()
+			pg.backingUp = false
+			if err != nil {
+				errModal := modal.NewErrorModal(pg.Load, err.Error(), modal.DefaultClickFunc())
+				pg.ParentWindow().ShowModal(errModal)
+				return
+			}
+			pg.loadBackups()
+			pg.Toast.Notify(values.String(values.StrWalletDBBackedUp))
+		}()
+	}
+
+	for i, btn := range pg.restoreBtns {
+		if btn.Clicked(gtx) {
+			pg.confirmRestore(pg.backups[i])
+		}
+	}
+}
+
+func (pg *WalletDBBackupsPage) confirmRestore(backup *sharedW.WalletDBBackup) {
+	confirmModal := modal.NewCustomModal(pg.Load).
+		Title(values.String(values.StrRestoreWalletDB)).
+		Body(values.String(values.StrRestoreWalletDBConfirm)).
+		SetNegativeButtonText(values.String(values.StrCancel)).
+		SetPositiveButtonText(values.String(values.StrRestore)).
+		SetPositiveButtonCallback(func(_ bool, _ *modal.InfoModal) bool {
+			if err := pg.wallet.RestoreWalletDB(backup.Path); err != nil {
+				errModal := modal.NewErrorModal(pg.Load, err.Error(), modal.DefaultClickFunc())
+				pg.ParentWindow().ShowModal(errModal)
+				return true
+			}
+			pg.ParentWindow().ShowModal(modal.NewSuccessModal(pg.Load, values.String(values.StrWalletDBRestoreScheduled), modal.DefaultClickFunc()))
+			return true
+		})
+	pg.ParentWindow().ShowModal(confirmModal)
+}
+
+// OnNavigatedFrom is called when the page is about to be removed from
+// the displayed window. This method should ideally be used to disable
+// features that are irrelevant when the page is NOT displayed.
+// NOTE: The page may be re-displayed on the app's window, in which case
+// OnNavigatedTo() will be called again. This method should not destroy UI
+// components unless they'll be recreated in the OnNavigatedTo() method.
+// Part of the load.Page interface.
+func (pg *WalletDBBackupsPage) OnNavigatedFrom() {}
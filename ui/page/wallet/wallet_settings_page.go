@@ -6,6 +6,8 @@ import (
 	"net/url"
 	"strconv"
 	"strings"
+	"time"
+	"unicode"
 
 	"decred.org/dcrdex/dex"
 	"gioui.org/font"
@@ -55,10 +57,19 @@ type SettingsPage struct {
 	pageContainer *widget.List
 
 	changePass, viewSeed, rescan               *cryptomaterial.Clickable
+	rescanFrom, rescanAddrs                    *cryptomaterial.Clickable
+	importKey, sweepKey                        *cryptomaterial.Clickable
 	changeAccount, checklog, checkStats        *cryptomaterial.Clickable
 	changeWalletName, addAccount, deleteWallet *cryptomaterial.Clickable
 	verifyMessage, validateAddr, signMessage   *cryptomaterial.Clickable
 	updateConnectToPeer, setGapLimit           *cryptomaterial.Clickable
+	multisig                                   *cryptomaterial.Clickable
+	walletDBBackups                            *cryptomaterial.Clickable
+	seedCheck                                  *cryptomaterial.Clickable
+	spendingPolicy                             *cryptomaterial.Clickable
+	fullNodeSync                               *cryptomaterial.Clickable
+	electrumServers                            *cryptomaterial.Clickable
+	peers                                      *cryptomaterial.Clickable
 
 	backButton cryptomaterial.IconButton
 	infoButton cryptomaterial.IconButton
@@ -81,6 +92,10 @@ func NewSettingsPage(l *load.Load, wallet sharedW.Asset, walletCallbackFunc func
 		changePass:          l.Theme.NewClickable(false),
 		viewSeed:            l.Theme.NewClickable(false),
 		rescan:              l.Theme.NewClickable(false),
+		rescanFrom:          l.Theme.NewClickable(false),
+		rescanAddrs:         l.Theme.NewClickable(false),
+		importKey:           l.Theme.NewClickable(false),
+		sweepKey:            l.Theme.NewClickable(false),
 		setGapLimit:         l.Theme.NewClickable(false),
 		changeAccount:       l.Theme.NewClickable(false),
 		checklog:            l.Theme.NewClickable(false),
@@ -92,6 +107,13 @@ func NewSettingsPage(l *load.Load, wallet sharedW.Asset, walletCallbackFunc func
 		validateAddr:        l.Theme.NewClickable(false),
 		signMessage:         l.Theme.NewClickable(false),
 		updateConnectToPeer: l.Theme.NewClickable(false),
+		multisig:            l.Theme.NewClickable(false),
+		walletDBBackups:     l.Theme.NewClickable(false),
+		seedCheck:           l.Theme.NewClickable(false),
+		spendingPolicy:      l.Theme.NewClickable(false),
+		fullNodeSync:        l.Theme.NewClickable(false),
+		electrumServers:     l.Theme.NewClickable(false),
+		peers:               l.Theme.NewClickable(false),
 
 		spendUnconfirmed:  l.Theme.Switch(),
 		spendUnmixedFunds: l.Theme.Switch(),
@@ -190,6 +212,28 @@ func (pg *SettingsPage) generalSection() layout.Widget {
 				}
 				return layout.Inset{}.Layout(gtx, pg.sectionContent(pg.viewSeed, values.String(values.StrExportWalletSeed)))
 			}),
+			layout.Rigid(func(gtx C) D {
+				if !pg.wallet.IsWalletBackedUp() || !pg.wallet.HasWalletSeed() {
+					return D{}
+				}
+				return pg.sectionContent(pg.seedCheck, values.String(values.StrCheckSeedKnowledge))(gtx)
+			}),
+			layout.Rigid(pg.sectionContent(pg.multisig, values.String(values.StrMultisig))),
+			layout.Rigid(func(gtx C) D {
+				if pg.wallet.IsWatchingOnlyWallet() {
+					return D{}
+				}
+				return pg.sectionContent(pg.spendingPolicy, values.String(values.StrSpendingPolicy))(gtx)
+			}),
+			layout.Rigid(pg.sectionContent(pg.walletDBBackups, values.String(values.StrWalletDBBackups))),
+			layout.Rigid(pg.sectionContent(pg.fullNodeSync, values.String(values.StrFullNodeSync))),
+			layout.Rigid(func(gtx C) D {
+				if !sharedW.ElectrumSupported(pg.wallet.GetAssetType()) {
+					return D{}
+				}
+				return pg.sectionContent(pg.electrumServers, values.String(values.StrElectrumServers))(gtx)
+			}),
+			layout.Rigid(pg.sectionContent(pg.peers, values.String(values.StrPeerManagement))),
 			layout.Rigid(func(gtx C) D {
 				if pg.wallet.GetAssetType() == libutils.DCRWalletAsset {
 					return pg.subSection(gtx, values.String(values.StrUnconfirmedFunds), pg.spendUnconfirmed.Layout)
@@ -250,6 +294,8 @@ func (pg *SettingsPage) debug() layout.Widget {
 				})
 			}),
 			layout.Rigid(pg.sectionContent(pg.rescan, values.String(values.StrRescanBlockchain))),
+			layout.Rigid(pg.sectionContent(pg.rescanFrom, values.String(values.StrRescanFrom))),
+			layout.Rigid(pg.sectionContent(pg.rescanAddrs, values.String(values.StrRescanAddresses))),
 			layout.Rigid(func(gtx C) D {
 				if pg.wallet.GetAssetType() == libutils.DCRWalletAsset {
 					return pg.sectionDimension(gtx, pg.setGapLimit, values.String(values.StrSetGapLimit))
@@ -271,6 +317,15 @@ func (pg *SettingsPage) securityTools() layout.Widget {
 			layout.Rigid(pg.sectionContent(pg.verifyMessage, values.String(values.StrVerifyMessage))),
 			layout.Rigid(pg.sectionContent(pg.validateAddr, values.String(values.StrValidateMsg))),
 			layout.Rigid(pg.sectionContent(pg.signMessage, values.String(values.StrSignMessage))),
+			layout.Rigid(func(gtx C) D {
+				if pg.wallet.IsWatchingOnlyWallet() {
+					return D{}
+				}
+				return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+					layout.Rigid(pg.sectionContent(pg.importKey, values.String(values.StrImportPrivateKey))),
+					layout.Rigid(pg.sectionContent(pg.sweepKey, values.String(values.StrSweepPrivateKey))),
+				)
+			}),
 		)
 	}
 	return func(gtx C) D {
@@ -685,6 +740,13 @@ func (pg *SettingsPage) HandleUserInteractions(gtx C) {
 		}))
 	}
 
+	if pg.seedCheck.Clicked(gtx) {
+		currentPage := pg.ParentWindow().CurrentPageID()
+		pg.ParentWindow().Display(seedbackup.NewSeedCheckPage(pg.Load, pg.wallet, func(_ *load.Load, navigator app.WindowNavigator) {
+			navigator.ClosePagesAfter(currentPage)
+		}))
+	}
+
 	if pg.rescan.Clicked(gtx) {
 		go func() {
 			info := modal.NewCustomModal(pg.Load).
@@ -711,6 +773,22 @@ func (pg *SettingsPage) HandleUserInteractions(gtx C) {
 		}()
 	}
 
+	if pg.rescanFrom.Clicked(gtx) {
+		pg.rescanFromModal(nil)
+	}
+
+	if pg.rescanAddrs.Clicked(gtx) {
+		pg.rescanAddressesModal()
+	}
+
+	if pg.importKey.Clicked(gtx) {
+		pg.privateKeyModal(false)
+	}
+
+	if pg.sweepKey.Clicked(gtx) {
+		pg.privateKeyModal(true)
+	}
+
 	if pg.setGapLimit.Clicked(gtx) {
 		pg.gapLimitModal()
 	}
@@ -798,6 +876,30 @@ func (pg *SettingsPage) HandleUserInteractions(gtx C) {
 		pg.ParentNavigator().Display(security.NewSignMessagePage(pg.Load, pg.wallet))
 	}
 
+	if pg.multisig.Clicked(gtx) {
+		pg.ParentNavigator().Display(NewMultisigPage(pg.Load, pg.wallet))
+	}
+
+	if pg.spendingPolicy.Clicked(gtx) {
+		pg.ParentNavigator().Display(NewSpendingPolicyPage(pg.Load, pg.wallet))
+	}
+
+	if pg.fullNodeSync.Clicked(gtx) {
+		pg.ParentNavigator().Display(NewFullNodeSyncPage(pg.Load, pg.wallet))
+	}
+
+	if pg.electrumServers.Clicked(gtx) {
+		pg.ParentNavigator().Display(NewElectrumServersPage(pg.Load, pg.wallet))
+	}
+
+	if pg.peers.Clicked(gtx) {
+		pg.ParentNavigator().Display(NewPeersPage(pg.Load, pg.wallet))
+	}
+
+	if pg.walletDBBackups.Clicked(gtx) {
+		pg.ParentNavigator().Display(NewWalletDBBackupsPage(pg.Load, pg.wallet))
+	}
+
 	if pg.checklog.Clicked(gtx) {
 		pg.ParentNavigator().Display(s.NewLogPage(pg.Load, pg.wallet.LogFile(), values.String(values.StrWalletLog)))
 	}
@@ -869,6 +971,190 @@ func (pg *SettingsPage) gapLimitModal() {
 	pg.ParentWindow().ShowModal(textModal)
 }
 
+// rescanAddressesModal asks for the wallet addresses to rescan the blockchain
+// for, such as addresses a transaction is known to be missing for.
+func (pg *SettingsPage) rescanAddressesModal() {
+	textModal := modal.NewTextInputModal(pg.Load).
+		Hint(values.String(values.StrRescanAddressesHint)).
+		PositiveButtonStyle(pg.Load.Theme.Color.Primary, pg.Load.Theme.Color.InvText).
+		SetPositiveButtonCallback(func(input string, tm *modal.TextInputModal) bool {
+			addrs := strings.FieldsFunc(input, func(r rune) bool {
+				return r == ',' || unicode.IsSpace(r)
+			})
+			for _, addr := range addrs {
+				if !pg.wallet.IsAddressValid(addr) || !pg.wallet.HaveAddress(addr) {
+					tm.SetError(values.StringF(values.StrNotWalletAddress, addr))
+					return false
+				}
+			}
+			if len(addrs) == 0 {
+				tm.SetError(values.String(values.StrInvalidAddress))
+				return false
+			}
+
+			pg.rescanFromModal(addrs)
+			return true
+		})
+	textModal.Title(values.String(values.StrRescanAddresses)).
+		SetPositiveButtonText(values.String(values.StrNext))
+	pg.ParentWindow().ShowModal(textModal)
+}
+
+// rescanFromModal asks for the block height or date to rescan the wallet
+// from, so that a rescan doesn't have to start from the wallet's birthday.
+// The rescan is for addrs only, if any.
+func (pg *SettingsPage) rescanFromModal(addrs []string) {
+	textModal := modal.NewTextInputModal(pg.Load).
+		Hint(values.String(values.StrRescanFromHint)).
+		PositiveButtonStyle(pg.Load.Theme.Color.Primary, pg.Load.Theme.Color.InvText).
+		SetPositiveButtonCallback(func(input string, tm *modal.TextInputModal) bool {
+			height, ok := pg.rescanStartHeight(strings.TrimSpace(input))
+			if !ok {
+				tm.SetError(values.StringF(values.StrInvalidRescanStart, pg.wallet.GetBestBlockHeight()))
+				return false
+			}
+
+			var err error
+			if len(addrs) > 0 {
+				err = pg.wallet.RescanAddresses(height, addrs)
+			} else {
+				err = pg.wallet.RescanBlocksFromHeight(height)
+			}
+			if err != nil {
+				tm.SetError(values.TranslateErr(err.Error()))
+				return false
+			}
+
+			pg.changeTab(info.InfoID)
+			return true
+		})
+	textModal.Title(values.String(values.StrRescanFrom)).
+		SetPositiveButtonText(values.String(values.StrRescan))
+	pg.ParentWindow().ShowModal(textModal)
+}
+
+// rescanStartHeight returns the block height to rescan from for input, which
+// is either a block height or a date.
+func (pg *SettingsPage) rescanStartHeight(input string) (int32, bool) {
+	bestHeight := pg.wallet.GetBestBlockHeight()
+	if height, err := strconv.ParseInt(input, 10, 32); err == nil {
+		return int32(height), height >= 0 && int32(height) <= bestHeight
+	}
+
+	date, err := time.Parse("2006-01-02", input)
+	if err != nil || date.After(time.Now()) {
+		return 0, false
+	}
+	// The height of a date is only estimated, start a little earlier to
+	// not miss the transactions of that date.
+	height := pg.AssetsManager.BlockHeightForDate(pg.wallet.GetAssetType(), date.Add(-sharedW.RestoreBirthdayMargin))
+	return min(height, bestHeight), true
+}
+
+// privateKeyModal asks for a WIF private key and the block height or date to
+// scan the chain for its funds from, then sweeps the key into the default
+// account or imports it into the imported account.
+func (pg *SettingsPage) privateKeyModal(sweep bool) {
+	title := values.String(values.StrImportPrivateKey)
+	if sweep {
+		title = values.String(values.StrSweepPrivateKey)
+	}
+
+	keyModal := modal.NewTextInputModal(pg.Load).
+		Hint(values.String(values.StrPrivateKeyHint)).
+		Masked().
+		PositiveButtonStyle(pg.Load.Theme.Color.Primary, pg.Load.Theme.Color.InvText).
+		SetPositiveButtonCallback(func(wif string, _ *modal.TextInputModal) bool {
+			pg.scanKeyFromModal(title, wif, sweep)
+			return true
+		})
+	keyModal.Title(title).
+		SetPositiveButtonText(values.String(values.StrNext))
+	pg.ParentWindow().ShowModal(keyModal)
+}
+
+// scanKeyFromModal asks for the block height or date the key was first used.
+func (pg *SettingsPage) scanKeyFromModal(title, wif string, sweep bool) {
+	positiveButtonText := values.String(values.StrImport)
+	if sweep {
+		positiveButtonText = values.String(values.StrSweep)
+	}
+
+	textModal := modal.NewTextInputModal(pg.Load).
+		Hint(values.String(values.StrRescanFromHint)).
+		PositiveButtonStyle(pg.Load.Theme.Color.Primary, pg.Load.Theme.Color.InvText).
+		SetPositiveButtonCallback(func(input string, tm *modal.TextInputModal) bool {
+			startHeight, ok := pg.rescanStartHeight(strings.TrimSpace(input))
+			if !ok {
+				tm.SetError(values.StringF(values.StrInvalidRescanStart, pg.wallet.GetBestBlockHeight()))
+				return false
+			}
+
+			if !sweep {
+				pg.importPrivateKeyModal(wif, startHeight)
+				return true
+			}
+
+			// Every asset numbers its default account 0.
+			sweep, err := pg.wallet.PrepareKeySweep(wif, 0, startHeight)
+			if err != nil {
+				tm.SetError(values.TranslateErr(err.Error()))
+				return false
+			}
+
+			pg.confirmKeySweepModal(sweep)
+			return true
+		})
+	textModal.Title(values.String(values.StrScanKeyFrom)).
+		SetPositiveButtonText(positiveButtonText)
+	pg.ParentWindow().ShowModal(textModal)
+}
+
+// confirmKeySweepModal shows the amount and fee of the sweep of a key and
+// publishes it once confirmed.
+func (pg *SettingsPage) confirmKeySweepModal(sweep *sharedW.SweepResult) {
+	confirmModal := modal.NewCustomModal(pg.Load).
+		Title(values.String(values.StrSweepPrivateKey)).
+		Body(values.StringF(values.StrConfirmKeySweep, sweep.Amount.String(), sweep.Address, sweep.Fee.String())).
+		SetNegativeButtonText(values.String(values.StrCancel)).
+		SetPositiveButtonText(values.String(values.StrSweep)).
+		SetPositiveButtonCallback(func(_ bool, _ *modal.InfoModal) bool {
+			if err := pg.wallet.PublishKeySweep(sweep); err != nil {
+				errModal := modal.NewErrorModal(pg.Load, values.TranslateErr(err.Error()), modal.DefaultClickFunc())
+				pg.ParentWindow().ShowModal(errModal)
+				return true
+			}
+
+			info := modal.NewSuccessModal(pg.Load, values.String(values.StrKeySwept), modal.DefaultClickFunc()).
+				Body(values.StringF(values.StrKeySweptBody, sweep.Amount.String(), sweep.Fee.String()))
+			pg.ParentWindow().ShowModal(info)
+			return true
+		})
+	pg.ParentWindow().ShowModal(confirmModal)
+}
+
+// importPrivateKeyModal asks for the spending password to import the key
+// with.
+func (pg *SettingsPage) importPrivateKeyModal(wif string, startHeight int32) {
+	walletPasswordModal := modal.NewCreatePasswordModal(pg.Load).
+		EnableName(false).
+		EnableConfirmPassword(false).
+		Title(values.String(values.StrImportPrivateKey)).
+		SetPositiveButtonCallback(func(_, password string, pm *modal.CreatePasswordModal) bool {
+			_, err := pg.wallet.ImportPrivateKey(wif, password, startHeight)
+			if err != nil {
+				pm.SetError(values.TranslateErr(err.Error()))
+				return false
+			}
+
+			info := modal.NewSuccessModal(pg.Load, values.String(values.StrKeyImported), modal.DefaultClickFunc()).
+				Body(values.String(values.StrKeyImportedBody))
+			pg.ParentWindow().ShowModal(info)
+			return true
+		})
+	pg.ParentWindow().ShowModal(walletPasswordModal)
+}
+
 // OnNavigatedFrom is called when the page is about to be removed from
 // the displayed window. This method should ideally be used to disable
 // features that are irrelevant when the page is NOT displayed.
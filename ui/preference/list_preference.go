@@ -2,7 +2,9 @@ package preference
 
 import (
 	"io"
+	"strconv"
 	"strings"
+	"time"
 
 	"gioui.org/font"
 	"gioui.org/io/clipboard"
@@ -64,6 +66,55 @@ var (
 	}
 )
 
+// autoLockMinutes are the selectable auto-lock timeouts in minutes. Zero
+// disables the auto-lock.
+var autoLockMinutes = []int{0, 1, 5, 15, 30, 60}
+
+// syncDataBudgetUnit is the number of bytes in a MB of the sync data budget.
+const syncDataBudgetUnit = 1000 * 1000
+
+// syncDataBudgetsMB are the selectable monthly sync data budgets in MB. Zero
+// disables the budget.
+var syncDataBudgetsMB = []uint64{0, 250, 500, 1000, 2000, 5000}
+
+// SyncDataBudgetOptions returns the selectable monthly sync data budgets.
+func SyncDataBudgetOptions() []ItemPreference {
+	options := make([]ItemPreference, 0, len(syncDataBudgetsMB))
+	for _, mb := range syncDataBudgetsMB {
+		value := values.String(values.StrOff)
+		if mb > 0 {
+			value = values.StringF(values.StrSyncDataBudgetFmt, mb)
+		}
+		options = append(options, ItemPreference{Key: strconv.FormatUint(mb, 10), Value: value})
+	}
+	return options
+}
+
+// SyncDataBudgetKey returns the key of the sync data budget option for a
+// budget of the given number of bytes.
+func SyncDataBudgetKey(budget uint64) string {
+	return strconv.FormatUint(budget/syncDataBudgetUnit, 10)
+}
+
+// AutoLockOptions returns the selectable auto-lock timeouts. The values are
+// formatted in the current language.
+func AutoLockOptions() []ItemPreference {
+	options := make([]ItemPreference, 0, len(autoLockMinutes))
+	for _, minutes := range autoLockMinutes {
+		var value string
+		switch minutes {
+		case 0:
+			value = values.String(values.StrOff)
+		case 1:
+			value = values.String(values.StrAutoLockOneMinute)
+		default:
+			value = values.StringF(values.StrAutoLockMinutesFmt, minutes)
+		}
+		options = append(options, ItemPreference{Key: strconv.Itoa(minutes), Value: value})
+	}
+	return options
+}
+
 type ListPreferenceModal struct {
 	*load.Load
 	*cryptomaterial.Modal
@@ -132,6 +183,10 @@ func (lp *ListPreferenceModal) ReadPreferenceKeyedValue() string {
 		return lp.AssetsManager.GetLanguagePreference()
 	case sharedW.LogLevelConfigKey:
 		return lp.AssetsManager.GetLogLevels()
+	case sharedW.AutoLockTimeoutConfigKey:
+		return strconv.Itoa(int(lp.AssetsManager.GetAutoLockTimeout() / time.Minute))
+	case sharedW.SyncPolicyConfigKey:
+		return SyncDataBudgetKey(lp.AssetsManager.SyncPolicy().MonthlyDataBudget)
 	default:
 		return ""
 	}
@@ -148,6 +203,18 @@ func (lp *ListPreferenceModal) SavePreferenceKeyedValue() {
 		lp.AssetsManager.SetLanguagePreference(val)
 	case sharedW.LogLevelConfigKey:
 		lp.AssetsManager.SetLogLevels(val)
+	case sharedW.AutoLockTimeoutConfigKey:
+		minutes, err := strconv.Atoi(val)
+		if err == nil {
+			lp.AssetsManager.SetAutoLockTimeout(time.Duration(minutes) * time.Minute)
+		}
+	case sharedW.SyncPolicyConfigKey:
+		mb, err := strconv.ParseUint(val, 10, 64)
+		if err == nil {
+			policy := lp.AssetsManager.SyncPolicy()
+			policy.MonthlyDataBudget = mb * syncDataBudgetUnit
+			lp.AssetsManager.SetSyncPolicy(policy)
+		}
 	}
 }
 
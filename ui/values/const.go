@@ -13,4 +13,6 @@ const (
 	StartPageDropdownGroup
 	AssetTypeDropdownGroup
 	AccountsDropdownGroup
+	DEXBondAssetGroup
+	DEXTradeHistoryFilterGroup
 )
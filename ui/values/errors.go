@@ -23,6 +23,48 @@ func TranslateErr(errStr string) string {
 	case utils.ErrInsufficientBalance:
 		return String(StrInsufficientFund)
 
+	case utils.ErrSpendLimitExceeded:
+		return String(StrSpendLimitExceeded)
+
+	case utils.ErrDestinationNotWhitelisted:
+		return String(StrDestinationNotWhitelisted)
+
+	case utils.ErrSendNotDelayable:
+		return String(StrSendNotDelayable)
+
+	case utils.ErrDEXTradeRestricted:
+		return String(StrDEXTradeRestricted)
+
+	case utils.ErrRPCWrongNetwork:
+		return String(StrRPCWrongNetwork)
+
+	case utils.ErrElectrumCertMismatch:
+		return String(StrElectrumCertMismatch)
+
+	case utils.ErrElectrumWrongNetwork:
+		return String(StrElectrumWrongNetwork)
+
+	case utils.ErrPeerBanned:
+		return String(StrPeerBannedErr)
+
+	case utils.ErrPeerNotFound:
+		return String(StrPeerNotFound)
+
+	case utils.ErrSyncMeteredNetwork:
+		return String(StrSyncMeteredNetwork)
+
+	case utils.ErrSyncNotCharging:
+		return String(StrSyncNotCharging)
+
+	case utils.ErrSyncDataBudgetExceeded:
+		return String(StrSyncDataBudgetExceeded)
+
+	case utils.ErrInvalidPrivateKey:
+		return String(StrInvalidPrivateKey)
+
+	case utils.ErrNoFundsToSweep:
+		return String(StrNoFundsToSweep)
+
 	default:
 		if strings.Contains(errStr, "strconv.ParseFloat") {
 			return String((StrInvalidAmount))
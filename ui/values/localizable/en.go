@@ -978,4 +978,293 @@ const EN = `
 "lowStorageSpaceBody" = "Your device storage space is low and is not enough to sync a wallet. Required space to sync a wallet is ~%dmb while your free internal memory is %dmb"
 "walletCreationLimitTitle" = "Wallet creation limit"
 "walletCreationLimitBody" = "Limit of 1 wallet per 1 gig of ram on the device. You can create up to 1 wallet for every 1 gigabyte of RAM available on your device."
+"manageBonds" = "Manage Bonds"
+"bondsTitle" = "Bonds - %s"
+"tierSummary" = "Tier Summary"
+"targetTier" = "Target Tier"
+"effectiveTier" = "Effective Tier"
+"bondedTier" = "Bonded Tier"
+"penalties" = "Penalties"
+"score" = "Score"
+"pendingTiers" = "Pending Tiers"
+"expiringTiers" = "Expiring Tiers"
+"penaltyInfo" = "Your score is below the server's penalty threshold of %d. %d tier(s) are revoked and your effective tier is reduced until your score improves."
+"bondSettings" = "Bond Settings"
+"autoRenewBonds" = "Auto-renew bonds"
+"autoRenewBondsDesc" = "Automatically post new bonds to maintain your target tier as existing bonds expire."
+"bondAsset" = "Bond Asset"
+"maxBondedAmount" = "Max Bonded Amount"
+"maxBondedAmountHint" = "Leave empty to use a default based on your target tier"
+"penaltyComps" = "Penalty Compensation (tiers)"
+"bondOptionsUpdated" = "Bond settings updated"
+"invalidTargetTier" = "Target tier must be at least 1 when auto-renewal is enabled"
+"invalidPenaltyComps" = "Penalty compensation must be a number between 0 and %d"
+"activeBonds" = "Active Bonds"
+"viewBonds" = "View Bonds"
+"viewBondsDesc" = "Enter your DEX password to view your bonds."
+"noActiveBonds" = "You have no active bonds on this server."
+"expires" = "Expires"
+"strength" = "Strength"
+"manageServers" = "Manage Servers"
+"dexServers" = "DEX Servers"
+"serverAddress" = "Server Address"
+"serverCertHint" = "Paste the TLS certificate or enter the path to the certificate file"
+"connected" = "Connected"
+"disconnected" = "Disconnected"
+"viewOnly" = "View only"
+"enable" = "Enable"
+"moveUp" = "Move up"
+"moveDown" = "Move down"
+"marketsCount" = "%d markets"
+"noDEXServers" = "No DEX servers added"
+"dexServerAdded" = "DEX server added"
+"dexServerExists" = "%s has already been added"
+"disableServerTitle" = "Disable %s?"
+"disableServerDesc" = "The server will be disconnected and its markets will no longer be shown. Servers with active orders cannot be disabled. Bonds posted to the server will be refunded when they expire."
+"invalidCertStatus" = "Invalid certificate"
+"updateCert" = "Update Certificate"
+"certUpdated" = "Certificate updated for %s"
+"invalidCertInput" = "Certificate must be PEM encoded or a path to a certificate file"
+"viewAllTrades" = "View All"
+"allServers" = "All Servers"
+"allMarkets" = "All Markets"
+"allStatuses" = "All Statuses"
+"allTime" = "All Time"
+"last30Days" = "Last 30 Days"
+"last90Days" = "Last 90 Days"
+"last12Months" = "Last 12 Months"
+"profitAndLoss" = "Profit and Loss"
+"realizedPnL" = "Realized P&L"
+"bought" = "Bought"
+"sold" = "Sold"
+"fees" = "Fees"
+"swapTx" = "Swap"
+"counterSwapTx" = "Counter Swap"
+"redeemTx" = "Redeem"
+"counterRedeemTx" = "Counter Redeem"
+"refundTx" = "Refund"
+"pageOf" = "Page %d of %d"
+"previous" = "Previous"
+"exportCSV" = "Export CSV"
+"exportJSON" = "Export JSON"
+"exportTradesSuccessMsg" = "Your trade history has been exported successfully and saved to %s."
+"noSettledTrades" = "No settled trades"
+"noMatches" = "No matches"
+"goodTillCanceled" = "Good 'til canceled"
+"immediateOrCancel" = "Immediate or cancel"
+"trigger" = "Trigger"
+"noTrigger" = "No trigger"
+"stopLoss" = "Stop-loss"
+"takeProfit" = "Take-profit"
+"triggerPrice" = "Trigger Price"
+"triggerOrders" = "Trigger Orders"
+"triggerOrderExplanation" = "Trigger orders are held by this app and only sent to the DEX server when the best opposing price in the order book reaches the trigger price. A stop-loss triggers when the price moves against the trade and a take-profit when it moves in favor of it. Trigger orders are only placed while you are logged in to the DEX."
+"triggerOrderSaved" = "Trigger order saved"
+"noTriggerOrdersMsg" = "No trigger orders"
+"cancelAll" = "Cancel All"
+"cancelAllOrdersTitle" = "Cancel All Orders"
+"cancelAllOrdersDesc" = "Cancel all open orders on the %s market at %s?"
+"ordersCanceledFmt" = "Cancellation requested for %d order(s)"
+"invalidTriggerPrice" = "Enter a valid trigger price"
+"dexOrderLocked" = "Locked by DEX orders"
+"dexContractLocked" = "In DEX swap contracts"
+"dexBondLocked" = "In DEX bonds"
+"dexAccountSendWarning" = "This account is used by the DEX. %s is locked by open orders and cannot be sent, while %s in swap contracts and %s in bonds are held outside the account until they are redeemed or refunded. Sending the remaining balance may leave too little to fund new orders or renew bonds."
+"dexWallets" = "DEX Wallets"
+"inFlightSwaps" = "In-flight Swaps"
+"noInFlightSwaps" = "No swaps in progress"
+"noDEXWallets" = "No wallets have been added to the DEX"
+"multisig" = "Multisig"
+"multisigBadgeFmt" = "Multisig %s"
+"multisigSetupDesc" = "Set up this wallet as an m-of-n multisig wallet. Share your extended public key with the other cosigners and enter theirs below. Every cosigner must use the same cosigners and required signatures. Only a wallet without transactions can be set up as a multisig wallet."
+"yourXPub" = "Your extended public key"
+"xpubCopied" = "Extended public key copied"
+"requiredSignatures" = "Required signatures"
+"cosignerXPubs" = "Cosigner extended public keys"
+"cosignerXPubsHint" = "One cosigner per line: name followed by the xpub"
+"setupMultisig" = "Set up multisig"
+"setupMultisigConfirmFmt" = "This wallet will become a %s multisig wallet. This cannot be undone."
+"multisigSetupSuccess" = "Multisig wallet set up"
+"invalidRequiredSignatures" = "Enter a valid number of required signatures"
+"cosigners" = "Cosigners"
+"multisigBalance" = "Multisig balance"
+"receiveAddress" = "Receive address"
+"createProposal" = "Create proposal"
+"txProposal" = "Transaction proposal"
+"txProposalHint" = "Paste a proposal received from a cosigner or import it from a file"
+"proposalFilePath" = "Proposal file path"
+"proposalExportedFmt" = "Proposal saved to %s"
+"proposalCopied" = "Proposal copied"
+"proposalSigned" = "Proposal signed. Share it with the other cosigners."
+"signaturesFmt" = "Signatures: %d of %d"
+"signedByYou" = "Signed by you"
+"notSignedByYou" = "Not signed by you"
+"changeOutput" = "change"
+"signProposal" = "Sign proposal"
+"broadcast" = "Broadcast"
+"exportAppBackup" = "Export app backup"
+"restoreAppBackup" = "Restore app backup"
+"appBackupDesc" = "The backup includes the seeds of all your wallets, account names, transaction labels, wallet and app settings, exchange orders and DEX data. Anyone with the backup file and its passphrase can spend your funds."
+"walletSpendingPasswordFmt" = "Spending password of %s"
+"backupPassphrase" = "Backup passphrase"
+"confirmBackupPassphrase" = "Confirm backup passphrase"
+"appBackupExportedFmt" = "App backup saved to %s"
+"backupFilePath" = "Backup file path"
+"appBackupRestoreDesc" = "Restore your wallets and app data from a backup file. The restored wallets are protected with the spending password entered below."
+"appBackupRestored" = "App backup restored"
+"walletDBBackups" = "Database backups"
+"walletDBBackupsDescFmt" = "Copies of the wallet database are saved on this device while the wallet is in use. The latest %d backups are kept."
+"noWalletDBBackups" = "No database backups yet"
+"walletDBBackedUp" = "Wallet database backed up"
+"restoreWalletDB" = "Restore database backup"
+"restoreWalletDBConfirm" = "The wallet database will be replaced by this backup the next time the app starts. Transactions and addresses that are newer than the backup will be found again when the wallet syncs."
+"walletDBRestoreScheduled" = "Restart the app to complete the restore"
+"autoWalletDBBackup" = "Daily wallet database backups"
+"autoLock" = "Auto-lock"
+"autoLockDesc" = "Lock the app and hide balances after a period of inactivity"
+"autoLockMinutesFmt" = "After %d minutes"
+"autoLockOneMinute" = "After 1 minute"
+"off" = "Off"
+"encryptMetadata" = "Encrypt wallet metadata"
+"metadataEncryptionFmt" = "Metadata encryption %v"
+"confirmEncryptMetadata" = "Confirm to encrypt wallet metadata"
+"confirmDecryptMetadata" = "Confirm to turn off metadata encryption"
+"restoreFromSeedShares" = "Restore from seed shares"
+"seedShareX" = "Seed share %d"
+"addSeedShare" = "Add share"
+"splitIntoShares" = "Split into shares"
+"seedShares" = "Seed shares"
+"seedSharesDesc" = "Split your seed into shares and store them in different places. Any %d of the %d shares restore the wallet, fewer shares reveal nothing about the seed."
+"numberOfShares" = "Number of shares"
+"sharesRequired" = "Shares required to restore"
+"createShares" = "Create shares"
+"writeDownShareX" = "Write down share %d of %d"
+"verifyShareX" = "Enter share %d of %d to verify it"
+"enterSeedShare" = "Enter the words of the share"
+"seedShareMismatch" = "The share doesn't match. Check the words and try again."
+"invalidSeedShareSettings" = "Choose between 2 and 16 shares with at least 2 required to restore."
+"seedCheck" = "Seed check"
+"seedCheckDesc" = "Enter the seed words at the positions below to confirm you still have your seed. Your seed isn't shown."
+"seedWordX" = "Word #%d"
+"seedCheckPassed" = "Seed check passed"
+"seedCheckDue" = "Do you still have your seed?"
+"seedCheckDueDesc" = "It has been a while since you last confirmed you have the seed of %s. Take a quick check to make sure you can restore the wallet."
+"seedCheckHighBalanceDesc" = "%s holds a significant balance. You haven't confirmed you have its seed since %s, without it the funds can't be recovered if you lose this device."
+"checkNow" = "Check now"
+"remindMeLater" = "Remind me later"
+"checkSeedKnowledge" = "Check seed knowledge"
+"duressPassword" = "Duress password"
+"createDuressPassword" = "Create a duress password. Unlocking the app with it opens a separate, empty set of wallets. Unlock the app with it to add decoy wallets."
+"confirmDuressPassword" = "Confirm duress password"
+"confirmRemoveDuressPassword" = "Confirm to remove the duress password. The wallets it opens are deleted."
+"duressPasswordFmt" = "Duress password %s"
+"spendingPolicy" = "Spending policy"
+"spendingPolicyDesc" = "Restrict the sends from this wallet. Leave an amount empty to disable its restriction."
+"dailyLimit" = "Daily limit"
+"weeklyLimit" = "Weekly limit"
+"delaySendsAbove" = "Delay sends above"
+"sendDelayMinutes" = "Delay (minutes)"
+"whitelistHint" = "Whitelisted addresses, one per line"
+"whitelistOnly" = "Only send to whitelisted addresses"
+"confirmNewDestinations" = "Confirm sends to new addresses"
+"removePolicy" = "Remove policy"
+"spendingPolicySaved" = "Spending policy saved"
+"spendingPolicyRemoved" = "Spending policy removed"
+"delayedSends" = "Delayed sends"
+"noDelayedSends" = "No delayed sends"
+"delayedSendFmt" = "%s to %s, broadcast at %s"
+"delayedSendCancelled" = "Delayed send cancelled"
+"sendDelayed" = "Send delayed"
+"sendDelayedMsg" = "The spending policy of this wallet holds the send back until %s. It can be cancelled until then in the spending policy settings of the wallet."
+"newDestination" = "New destination"
+"newDestinationMsg" = "This wallet never sent to %s. Make sure the address is correct before sending to it."
+"spendLimitExceeded" = "This send exceeds the spending limit of the wallet"
+"destinationNotWhitelisted" = "The spending policy of the wallet only allows sends to whitelisted addresses"
+"fullNodeSync" = "Full node sync"
+"fullNodeSyncDesc" = "Sync the wallet from your own node over RPC instead of SPV peers. Fee estimates and broadcasts then go through your node too. Leave the certificate empty to trust the system certificates. bitcoind and litecoind only serve plain HTTP, use a TLS proxy such as stunnel in front of them to connect to a remote node."
+"rpcHost" = "RPC host (host:port)"
+"rpcUser" = "RPC user"
+"rpcPassword" = "RPC password"
+"rpcCertificate" = "TLS certificate (PEM)"
+"nodeType" = "Node type"
+"testConnection" = "Test connection"
+"rpcConnectionOK" = "Connected to the node"
+"useSPV" = "Use SPV"
+"fullNodeSyncSaved" = "The wallet now syncs from your node"
+"spvSyncRestored" = "The wallet now syncs using SPV"
+"rpcWrongNetwork" = "The node runs on another network"
+"electrumServers" = "Electrum servers"
+"electrumServersDesc" = "Sync this wallet from Electrum servers instead of SPV. Enter one server per line as host:port, optionally followed by the SHA-256 fingerprint of its TLS certificate. The certificate of a server without a fingerprint is pinned on the first connection. Block headers are checked for proof of work and transactions for inclusion in them."
+"electrumServerList" = "Servers (host:port [fingerprint])"
+"torProxy" = "Tor SOCKS proxy (host:port, optional)"
+"electrumServersOK" = "Connected to the servers. Check the fingerprints against the ones published by their operators."
+"electrumServersSaved" = "Electrum servers saved"
+"electrumCertMismatch" = "The server presented a different certificate than the one pinned for it"
+"electrumWrongNetwork" = "The server is on a different network than the wallet"
+"peerManagement" = "Peers"
+"peerManagementDesc" = "Peers the wallet is connected to, refreshed live. Banned peers are never connected to again until unbanned, preferred peers are favoured when connecting."
+"connectedPeers" = "Connected peers"
+"noConnectedPeers" = "No connected peers. The wallet has no peers when it is not syncing or syncs from a full node or Electrum servers."
+"peerStatsFmt" = "Version %d · %s · Height %d"
+"peerTrafficFmt" = "Latency %s · Sent %s · Received %s"
+"ban" = "Ban"
+"unban" = "Unban"
+"prefer" = "Prefer"
+"unprefer" = "Unprefer"
+"bannedPeers" = "Banned peers"
+"noBannedPeers" = "No banned peers"
+"preferredPeers" = "Preferred peers"
+"noPreferredPeers" = "No preferred peers"
+"preferredPeerAddress" = "Peer address (host:port)"
+"peerDisconnected" = "Peer disconnected"
+"peerBanned" = "Peer banned"
+"peerBannedErr" = "The peer is banned"
+"peerNotFound" = "The peer is not connected"
+"syncPolicy" = "Sync"
+"syncUnmeteredOnly" = "Sync on Wi-Fi only"
+"syncChargingOnly" = "Sync while charging only"
+"syncDataBudget" = "Monthly sync data budget"
+"syncDataBudgetFmt" = "%d MB"
+"syncDataUsage" = "Synced this month"
+"syncMeteredNetwork" = "Sync paused on a metered network"
+"syncNotCharging" = "Sync paused while not charging"
+"syncDataBudgetExceeded" = "Sync paused, the monthly data budget is used up"
+"restoreFromDate" = "Restore from date (YYYY-MM-DD), optional"
+"restoreFromDateDesc" = "Blocks mined before this date are skipped. Leave it empty to scan the whole chain."
+"restoreFromBlockFmt" = "Scanning starts around block %d."
+"invalidRestoreDate" = "Enter a past date as YYYY-MM-DD"
+"rescanFrom" = "Rescan from height or date"
+"rescanFromHint" = "Block height or date (YYYY-MM-DD)"
+"invalidRescanStart" = "Enter a block height up to %d or a date in the format YYYY-MM-DD"
+"importPrivateKey" = "Import private key"
+"sweepPrivateKey" = "Sweep private key"
+"sweep" = "Sweep"
+"privateKeyHint" = "Private key (WIF)"
+"scanKeyFrom" = "Scan for the key's funds from"
+"keyImported" = "Private key imported"
+"keyImportedBody" = "The key was added to the imported account. Its transactions show once the wallet has rescanned for them."
+"keySwept" = "Private key swept"
+"keySweptBody" = "%s was sent to the wallet with a fee of %s. The key was not saved in the wallet."
+"invalidPrivateKey" = "Invalid private key"
+"noFundsToSweep" = "The key has no confirmed funds to sweep, or not enough to pay the fee"
+"sendNotDelayable" = "This send is over the delay threshold of the spending policy and can't be held back. Send it from the wallet instead."
+"loosenSpendingPolicy" = "Confirm to loosen the spending policy"
+"seedDeletedWalletsRestored" = "Watch-only wallets restored"
+"seedDeletedWalletsRestoredFmt" = "The seed of %s was deleted before the backup was made. These wallets were restored as watch-only wallets and can't spend until they are restored from their seed."
+"dexTradeRestricted" = "The spending policy of the trading account only allows approved destinations, which DEX swaps can't use. Turn off the whitelist and new destination approval to trade."
+"showMultisigXPub" = "Show extended public key"
+"multisigXPubPassword" = "Your spending password is needed to derive the keys dedicated to this multisig wallet."
+"syncingPercentFmt" = "Syncing... %v%%"
+"syncHistory" = "Sync history"
+"syncCanceled" = "Canceled"
+"syncRecordFmt" = "%s in %s"
+"confirmKeySweep" = "Send %s to %s with a fee of %s? The key is not saved in the wallet."
+"rescanAddresses" = "Rescan addresses"
+"rescanAddressesHint" = "Wallet addresses, separated by spaces or commas"
+"notWalletAddress" = "%s is not an address of this wallet"
+"removeServerTitle" = "Remove %s?"
+"removeServerDesc" = "The server will be disabled and hidden from the app. Servers with active orders cannot be removed. The account and its bonds are kept so that the bonds are refunded when they expire, and the account is restored if the server is added again."
+"dexServerRemoved" = "DEX server removed"
+"rpcTLSProxy" = "Connect over TLS through a proxy in front of the node"
+"peerBanScoreFmt" = "%s · Ban score %d"
 `
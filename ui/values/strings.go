@@ -1089,4 +1089,293 @@ const (
 	StrLowStorageSpaceBody                   = "lowStorageSpaceBody"
 	StrWalletsCreationLimitTitle             = "walletCreationLimitTitle"
 	StrWalletsCreationLimitBody              = "walletCreationLimitBody"
+	StrManageBonds                           = "manageBonds"
+	StrBondsTitle                            = "bondsTitle"
+	StrTierSummary                           = "tierSummary"
+	StrTargetTier                            = "targetTier"
+	StrEffectiveTier                         = "effectiveTier"
+	StrBondedTier                            = "bondedTier"
+	StrPenalties                             = "penalties"
+	StrScore                                 = "score"
+	StrPendingTiers                          = "pendingTiers"
+	StrExpiringTiers                         = "expiringTiers"
+	StrPenaltyInfo                           = "penaltyInfo"
+	StrBondSettings                          = "bondSettings"
+	StrAutoRenewBonds                        = "autoRenewBonds"
+	StrAutoRenewBondsDesc                    = "autoRenewBondsDesc"
+	StrBondAsset                             = "bondAsset"
+	StrMaxBondedAmount                       = "maxBondedAmount"
+	StrMaxBondedAmountHint                   = "maxBondedAmountHint"
+	StrPenaltyComps                          = "penaltyComps"
+	StrBondOptionsUpdated                    = "bondOptionsUpdated"
+	StrInvalidTargetTier                     = "invalidTargetTier"
+	StrInvalidPenaltyComps                   = "invalidPenaltyComps"
+	StrActiveBonds                           = "activeBonds"
+	StrViewBonds                             = "viewBonds"
+	StrViewBondsDesc                         = "viewBondsDesc"
+	StrNoActiveBonds                         = "noActiveBonds"
+	StrExpires                               = "expires"
+	StrStrength                              = "strength"
+	StrManageServers                         = "manageServers"
+	StrDEXServers                            = "dexServers"
+	StrServerAddress                         = "serverAddress"
+	StrServerCertHint                        = "serverCertHint"
+	StrConnected                             = "connected"
+	StrDisconnected                          = "disconnected"
+	StrViewOnly                              = "viewOnly"
+	StrEnable                                = "enable"
+	StrMoveUp                                = "moveUp"
+	StrMoveDown                              = "moveDown"
+	StrMarketsCount                          = "marketsCount"
+	StrNoDEXServers                          = "noDEXServers"
+	StrDEXServerAdded                        = "dexServerAdded"
+	StrDEXServerExists                       = "dexServerExists"
+	StrDisableServerTitle                    = "disableServerTitle"
+	StrDisableServerDesc                     = "disableServerDesc"
+	StrInvalidCertStatus                     = "invalidCertStatus"
+	StrUpdateCert                            = "updateCert"
+	StrCertUpdated                           = "certUpdated"
+	StrInvalidCertInput                      = "invalidCertInput"
+	StrViewAllTrades                         = "viewAllTrades"
+	StrAllServers                            = "allServers"
+	StrAllMarkets                            = "allMarkets"
+	StrAllStatuses                           = "allStatuses"
+	StrAllTime                               = "allTime"
+	StrLast30Days                            = "last30Days"
+	StrLast90Days                            = "last90Days"
+	StrLast12Months                          = "last12Months"
+	StrProfitAndLoss                         = "profitAndLoss"
+	StrRealizedPnL                           = "realizedPnL"
+	StrBought                                = "bought"
+	StrSold                                  = "sold"
+	StrFees                                  = "fees"
+	StrSwapTx                                = "swapTx"
+	StrCounterSwapTx                         = "counterSwapTx"
+	StrRedeemTx                              = "redeemTx"
+	StrCounterRedeemTx                       = "counterRedeemTx"
+	StrRefundTx                              = "refundTx"
+	StrPageOf                                = "pageOf"
+	StrPrevious                              = "previous"
+	StrExportCSV                             = "exportCSV"
+	StrExportJSON                            = "exportJSON"
+	StrExportTradesSuccessMsg                = "exportTradesSuccessMsg"
+	StrNoSettledTrades                       = "noSettledTrades"
+	StrNoMatches                             = "noMatches"
+	StrGoodTillCanceled                      = "goodTillCanceled"
+	StrImmediateOrCancel                     = "immediateOrCancel"
+	StrTrigger                               = "trigger"
+	StrNoTrigger                             = "noTrigger"
+	StrStopLoss                              = "stopLoss"
+	StrTakeProfit                            = "takeProfit"
+	StrTriggerPrice                          = "triggerPrice"
+	StrTriggerOrders                         = "triggerOrders"
+	StrTriggerOrderExplanation               = "triggerOrderExplanation"
+	StrTriggerOrderSaved                     = "triggerOrderSaved"
+	StrNoTriggerOrdersMsg                    = "noTriggerOrdersMsg"
+	StrCancelAll                             = "cancelAll"
+	StrCancelAllOrdersTitle                  = "cancelAllOrdersTitle"
+	StrCancelAllOrdersDesc                   = "cancelAllOrdersDesc"
+	StrOrdersCanceledFmt                     = "ordersCanceledFmt"
+	StrInvalidTriggerPrice                   = "invalidTriggerPrice"
+	StrDEXOrderLocked                        = "dexOrderLocked"
+	StrDEXContractLocked                     = "dexContractLocked"
+	StrDEXBondLocked                         = "dexBondLocked"
+	StrDEXAccountSendWarning                 = "dexAccountSendWarning"
+	StrDEXWallets                            = "dexWallets"
+	StrInFlightSwaps                         = "inFlightSwaps"
+	StrNoInFlightSwaps                       = "noInFlightSwaps"
+	StrNoDEXWallets                          = "noDEXWallets"
+	StrMultisig                              = "multisig"
+	StrMultisigBadgeFmt                      = "multisigBadgeFmt"
+	StrMultisigSetupDesc                     = "multisigSetupDesc"
+	StrYourXPub                              = "yourXPub"
+	StrXPubCopied                            = "xpubCopied"
+	StrRequiredSignatures                    = "requiredSignatures"
+	StrCosignerXPubs                         = "cosignerXPubs"
+	StrCosignerXPubsHint                     = "cosignerXPubsHint"
+	StrSetupMultisig                         = "setupMultisig"
+	StrSetupMultisigConfirmFmt               = "setupMultisigConfirmFmt"
+	StrMultisigSetupSuccess                  = "multisigSetupSuccess"
+	StrInvalidRequiredSignatures             = "invalidRequiredSignatures"
+	StrCosigners                             = "cosigners"
+	StrMultisigBalance                       = "multisigBalance"
+	StrReceiveAddress                        = "receiveAddress"
+	StrCreateProposal                        = "createProposal"
+	StrTxProposal                            = "txProposal"
+	StrTxProposalHint                        = "txProposalHint"
+	StrProposalFilePath                      = "proposalFilePath"
+	StrProposalExportedFmt                   = "proposalExportedFmt"
+	StrProposalCopied                        = "proposalCopied"
+	StrProposalSigned                        = "proposalSigned"
+	StrSignaturesFmt                         = "signaturesFmt"
+	StrSignedByYou                           = "signedByYou"
+	StrNotSignedByYou                        = "notSignedByYou"
+	StrChangeOutput                          = "changeOutput"
+	StrSignProposal                          = "signProposal"
+	StrBroadcast                             = "broadcast"
+	StrExportAppBackup                       = "exportAppBackup"
+	StrRestoreAppBackup                      = "restoreAppBackup"
+	StrAppBackupDesc                         = "appBackupDesc"
+	StrWalletSpendingPasswordFmt             = "walletSpendingPasswordFmt"
+	StrBackupPassphrase                      = "backupPassphrase"
+	StrConfirmBackupPassphrase               = "confirmBackupPassphrase"
+	StrAppBackupExportedFmt                  = "appBackupExportedFmt"
+	StrBackupFilePath                        = "backupFilePath"
+	StrAppBackupRestoreDesc                  = "appBackupRestoreDesc"
+	StrAppBackupRestored                     = "appBackupRestored"
+	StrWalletDBBackups                       = "walletDBBackups"
+	StrWalletDBBackupsDescFmt                = "walletDBBackupsDescFmt"
+	StrNoWalletDBBackups                     = "noWalletDBBackups"
+	StrWalletDBBackedUp                      = "walletDBBackedUp"
+	StrRestoreWalletDB                       = "restoreWalletDB"
+	StrRestoreWalletDBConfirm                = "restoreWalletDBConfirm"
+	StrWalletDBRestoreScheduled              = "walletDBRestoreScheduled"
+	StrAutoWalletDBBackup                    = "autoWalletDBBackup"
+	StrAutoLock                              = "autoLock"
+	StrAutoLockDesc                          = "autoLockDesc"
+	StrAutoLockMinutesFmt                    = "autoLockMinutesFmt"
+	StrAutoLockOneMinute                     = "autoLockOneMinute"
+	StrOff                                   = "off"
+	StrEncryptMetadata                       = "encryptMetadata"
+	StrMetadataEncryptionFmt                 = "metadataEncryptionFmt"
+	StrConfirmEncryptMetadata                = "confirmEncryptMetadata"
+	StrConfirmDecryptMetadata                = "confirmDecryptMetadata"
+	StrRestoreFromSeedShares                 = "restoreFromSeedShares"
+	StrSeedShareX                            = "seedShareX"
+	StrAddSeedShare                          = "addSeedShare"
+	StrSplitIntoShares                       = "splitIntoShares"
+	StrSeedShares                            = "seedShares"
+	StrSeedSharesDesc                        = "seedSharesDesc"
+	StrNumberOfShares                        = "numberOfShares"
+	StrSharesRequired                        = "sharesRequired"
+	StrCreateShares                          = "createShares"
+	StrWriteDownShareX                       = "writeDownShareX"
+	StrVerifyShareX                          = "verifyShareX"
+	StrEnterSeedShare                        = "enterSeedShare"
+	StrSeedShareMismatch                     = "seedShareMismatch"
+	StrInvalidSeedShareSettings              = "invalidSeedShareSettings"
+	StrSeedCheck                             = "seedCheck"
+	StrSeedCheckDesc                         = "seedCheckDesc"
+	StrSeedWordX                             = "seedWordX"
+	StrSeedCheckPassed                       = "seedCheckPassed"
+	StrSeedCheckDue                          = "seedCheckDue"
+	StrSeedCheckDueDesc                      = "seedCheckDueDesc"
+	StrSeedCheckHighBalanceDesc              = "seedCheckHighBalanceDesc"
+	StrCheckNow                              = "checkNow"
+	StrRemindMeLater                         = "remindMeLater"
+	StrCheckSeedKnowledge                    = "checkSeedKnowledge"
+	StrDuressPassword                        = "duressPassword"
+	StrCreateDuressPassword                  = "createDuressPassword"
+	StrConfirmDuressPassword                 = "confirmDuressPassword"
+	StrConfirmRemoveDuressPassword           = "confirmRemoveDuressPassword"
+	StrDuressPasswordFmt                     = "duressPasswordFmt"
+	StrSpendingPolicy                        = "spendingPolicy"
+	StrSpendingPolicyDesc                    = "spendingPolicyDesc"
+	StrDailyLimit                            = "dailyLimit"
+	StrWeeklyLimit                           = "weeklyLimit"
+	StrDelaySendsAbove                       = "delaySendsAbove"
+	StrSendDelayMinutes                      = "sendDelayMinutes"
+	StrWhitelistHint                         = "whitelistHint"
+	StrWhitelistOnly                         = "whitelistOnly"
+	StrConfirmNewDestinations                = "confirmNewDestinations"
+	StrRemovePolicy                          = "removePolicy"
+	StrSpendingPolicySaved                   = "spendingPolicySaved"
+	StrSpendingPolicyRemoved                 = "spendingPolicyRemoved"
+	StrDelayedSends                          = "delayedSends"
+	StrNoDelayedSends                        = "noDelayedSends"
+	StrDelayedSendFmt                        = "delayedSendFmt"
+	StrDelayedSendCancelled                  = "delayedSendCancelled"
+	StrSendDelayed                           = "sendDelayed"
+	StrSendDelayedMsg                        = "sendDelayedMsg"
+	StrNewDestination                        = "newDestination"
+	StrNewDestinationMsg                     = "newDestinationMsg"
+	StrSpendLimitExceeded                    = "spendLimitExceeded"
+	StrDestinationNotWhitelisted             = "destinationNotWhitelisted"
+	StrFullNodeSync                          = "fullNodeSync"
+	StrFullNodeSyncDesc                      = "fullNodeSyncDesc"
+	StrRPCHost                               = "rpcHost"
+	StrRPCUser                               = "rpcUser"
+	StrRPCPassword                           = "rpcPassword"
+	StrRPCCertificate                        = "rpcCertificate"
+	StrNodeType                              = "nodeType"
+	StrTestConnection                        = "testConnection"
+	StrRPCConnectionOK                       = "rpcConnectionOK"
+	StrUseSPV                                = "useSPV"
+	StrFullNodeSyncSaved                     = "fullNodeSyncSaved"
+	StrSPVSyncRestored                       = "spvSyncRestored"
+	StrRPCWrongNetwork                       = "rpcWrongNetwork"
+	StrElectrumServers                       = "electrumServers"
+	StrElectrumServersDesc                   = "electrumServersDesc"
+	StrElectrumServerList                    = "electrumServerList"
+	StrTorProxy                              = "torProxy"
+	StrElectrumServersOK                     = "electrumServersOK"
+	StrElectrumServersSaved                  = "electrumServersSaved"
+	StrElectrumCertMismatch                  = "electrumCertMismatch"
+	StrElectrumWrongNetwork                  = "electrumWrongNetwork"
+	StrPeerManagement                        = "peerManagement"
+	StrPeerManagementDesc                    = "peerManagementDesc"
+	StrConnectedPeers                        = "connectedPeers"
+	StrNoConnectedPeers                      = "noConnectedPeers"
+	StrPeerStatsFmt                          = "peerStatsFmt"
+	StrPeerTrafficFmt                        = "peerTrafficFmt"
+	StrBan                                   = "ban"
+	StrUnban                                 = "unban"
+	StrPrefer                                = "prefer"
+	StrUnprefer                              = "unprefer"
+	StrBannedPeers                           = "bannedPeers"
+	StrNoBannedPeers                         = "noBannedPeers"
+	StrPreferredPeers                        = "preferredPeers"
+	StrNoPreferredPeers                      = "noPreferredPeers"
+	StrPreferredPeerAddress                  = "preferredPeerAddress"
+	StrPeerDisconnected                      = "peerDisconnected"
+	StrPeerBanned                            = "peerBanned"
+	StrPeerBannedErr                         = "peerBannedErr"
+	StrPeerNotFound                          = "peerNotFound"
+	StrSyncPolicy                            = "syncPolicy"
+	StrSyncUnmeteredOnly                     = "syncUnmeteredOnly"
+	StrSyncChargingOnly                      = "syncChargingOnly"
+	StrSyncDataBudget                        = "syncDataBudget"
+	StrSyncDataBudgetFmt                     = "syncDataBudgetFmt"
+	StrSyncDataUsage                         = "syncDataUsage"
+	StrSyncMeteredNetwork                    = "syncMeteredNetwork"
+	StrSyncNotCharging                       = "syncNotCharging"
+	StrSyncDataBudgetExceeded                = "syncDataBudgetExceeded"
+	StrRestoreFromDate                       = "restoreFromDate"
+	StrRestoreFromDateDesc                   = "restoreFromDateDesc"
+	StrRestoreFromBlockFmt                   = "restoreFromBlockFmt"
+	StrInvalidRestoreDate                    = "invalidRestoreDate"
+	StrRescanFrom                            = "rescanFrom"
+	StrRescanFromHint                        = "rescanFromHint"
+	StrInvalidRescanStart                    = "invalidRescanStart"
+	StrImportPrivateKey                      = "importPrivateKey"
+	StrSweepPrivateKey                       = "sweepPrivateKey"
+	StrSweep                                 = "sweep"
+	StrPrivateKeyHint                        = "privateKeyHint"
+	StrScanKeyFrom                           = "scanKeyFrom"
+	StrKeyImported                           = "keyImported"
+	StrKeyImportedBody                       = "keyImportedBody"
+	StrKeySwept                              = "keySwept"
+	StrKeySweptBody                          = "keySweptBody"
+	StrInvalidPrivateKey                     = "invalidPrivateKey"
+	StrNoFundsToSweep                        = "noFundsToSweep"
+	StrSendNotDelayable                      = "sendNotDelayable"
+	StrLoosenSpendingPolicy                  = "loosenSpendingPolicy"
+	StrSeedDeletedWalletsRestored            = "seedDeletedWalletsRestored"
+	StrSeedDeletedWalletsRestoredFmt         = "seedDeletedWalletsRestoredFmt"
+	StrDEXTradeRestricted                    = "dexTradeRestricted"
+	StrShowMultisigXPub                      = "showMultisigXPub"
+	StrMultisigXPubPassword                  = "multisigXPubPassword"
+	StrSyncingPercentFmt                     = "syncingPercentFmt"
+	StrSyncHistory                           = "syncHistory"
+	StrSyncCanceled                          = "syncCanceled"
+	StrSyncRecordFmt                         = "syncRecordFmt"
+	StrConfirmKeySweep                       = "confirmKeySweep"
+	StrRescanAddresses                       = "rescanAddresses"
+	StrRescanAddressesHint                   = "rescanAddressesHint"
+	StrNotWalletAddress                      = "notWalletAddress"
+	StrRemoveServerTitle                     = "removeServerTitle"
+	StrRemoveServerDesc                      = "removeServerDesc"
+	StrDEXServerRemoved                      = "dexServerRemoved"
+	StrRPCTLSProxy                           = "rpcTLSProxy"
+	StrPeerBanScoreFmt                       = "peerBanScoreFmt"
 )
@@ -6,7 +6,9 @@ import (
 	"os"
 	"os/signal"
 	"runtime"
+	"sync/atomic"
 	"syscall"
+	"time"
 
 	giouiApp "gioui.org/app"
 	"gioui.org/gesture"
@@ -15,6 +17,7 @@ import (
 	"gioui.org/io/pointer"
 	"gioui.org/layout"
 	"gioui.org/op"
+	"gioui.org/op/clip"
 	"golang.org/x/text/language"
 	"golang.org/x/text/message"
 
@@ -47,13 +50,25 @@ type Window struct {
 	// IsShutdown channel is used to report that background processes have
 	// completed shutting down, therefore the UI processes can finally stop.
 	IsShutdown chan struct{}
+	// exit is used to request the app shutdown from the UI, e.g. from the
+	// unlock modal.
+	exit chan struct{}
 
 	// dragger is used to handle drag gestures.
 	drag       gesture.Drag
 	isClick    bool
 	isDragging bool
+
+	// lastActivity is the last time the user interacted with the window. The
+	// app is locked once the user is inactive for the auto-lock timeout.
+	lastActivity time.Time
+	isLocked     atomic.Bool
 }
 
+// autoLockCheckInterval is how often the window checks if the user has been
+// inactive for longer than the auto-lock timeout.
+const autoLockCheckInterval = 10 * time.Second
+
 type (
 	C = layout.Context
 	D = layout.Dimensions
@@ -83,12 +98,14 @@ func CreateWindow(appInfo *load.AppInfo) (*Window, error) {
 	giouiWindow := new(giouiApp.Window)
 	giouiWindow.Option(appSize, appMinSize, appTitle)
 	win := &Window{
-		ctx:        ctx,
-		ctxCancel:  cancel,
-		Window:     giouiWindow,
-		navigator:  app.NewSimpleWindowNavigator(giouiWindow.Invalidate),
-		Quit:       make(chan struct{}, 1),
-		IsShutdown: make(chan struct{}, 1),
+		ctx:          ctx,
+		ctxCancel:    cancel,
+		Window:       giouiWindow,
+		navigator:    app.NewSimpleWindowNavigator(giouiWindow.Invalidate),
+		Quit:         make(chan struct{}, 1),
+		IsShutdown:   make(chan struct{}, 1),
+		exit:         make(chan struct{}, 1),
+		lastActivity: time.Now(),
 	}
 
 	l, err := win.NewLoad(appInfo, giouiWindow)
@@ -216,8 +233,12 @@ func (win *Window) HandleEvents() {
 			SetNegativeButtonText(values.String(values.StrNo)).
 			SetNegativeButtonCallback(func() {
 				win.navigator.Display(win.navigator.CurrentPage())
+				// The exit was requested from the unlock modal.
+				if win.isLocked.Load() {
+					win.showUnlockModal()
+				}
 			}).
-			SetCancelable(true).
+			SetCancelable(!win.isLocked.Load()).
 			Body(values.String(values.StrActiveDexOrderError))
 
 		win.navigator.ShowModal(m)
@@ -237,12 +258,21 @@ func (win *Window) HandleEvents() {
 		}
 	}()
 
+	autoLockTicker := time.NewTicker(autoLockCheckInterval)
+	defer autoLockTicker.Stop()
+
 	for {
 		// Select either the os interrupt or the window event, whichever becomes
 		// ready first.
 		select {
 		case <-done:
 			displayShutdownPage(false)
+		case <-win.exit:
+			displayShutdownPage(false)
+		case <-autoLockTicker.C:
+			if !isShuttingDown {
+				win.lockIfIdle()
+			}
 		case <-win.IsShutdown:
 			// backend processes shutdown is complete, exit UI process too.
 			_ = win.load.Device.SetScreenAwake(false)
@@ -336,6 +366,16 @@ func (win *Window) prepareToDisplayUI(gtx layout.Context) {
 		topModalLayout,
 		layout.Stacked(win.load.Toast.Layout),
 	)
+
+	// Watch the pointer events over the whole window to track the user's
+	// activity. The area sits above the UI so it sees every event first, and
+	// the events are passed on to the widgets below.
+	area := clip.Rect{Max: gtx.Constraints.Max}.Push(gtx.Ops)
+	pass := pointer.PassOp{}.Push(gtx.Ops)
+	event.Op(gtx.Ops, &win.lastActivity)
+	pass.Pop()
+	area.Pop()
+
 	win.handleEvents(gtx)
 }
 
@@ -380,6 +420,112 @@ func (win *Window) addListenKeyEvent(gtx C) {
 func (win *Window) handleEvents(gtx C) {
 	win.handleUserClick(gtx)
 	win.listenSoftKey(gtx)
+	// Runs last so that the key events are only picked up once the pages
+	// and modals had a chance to handle them.
+	win.handleUserActivity(gtx)
+}
+
+// handleUserActivity records the time of the latest pointer, scroll or key
+// event.
+func (win *Window) handleUserActivity(gtx C) {
+	for {
+		_, ok := gtx.Event(
+			// The zero scroll range takes none of the scroll distance, so
+			// the lists below still scroll by the full amount.
+			pointer.Filter{
+				Target: &win.lastActivity,
+				Kinds:  pointer.Press | pointer.Release | pointer.Move | pointer.Scroll,
+			},
+			// The empty name matches every key that no other filter wants.
+			key.Filter{
+				Optional: key.ModCtrl | key.ModCommand | key.ModShift | key.ModAlt | key.ModSuper,
+			},
+		)
+		if !ok {
+			break
+		}
+		win.lastActivity = time.Now()
+	}
+}
+
+// lockIfIdle locks the app if the user has been inactive for longer than the
+// auto-lock timeout.
+func (win *Window) lockIfIdle() {
+	if win.isLocked.Load() {
+		// Restart the inactivity period once the app is unlocked.
+		win.lastActivity = time.Now()
+		return
+	}
+
+	timeout := win.load.AssetsManager.GetAutoLockTimeout()
+	if timeout == 0 || time.Since(win.lastActivity) < timeout {
+		return
+	}
+
+	// The app is still locked by the start page.
+	if win.navigator.CurrentPageID() == page.StartPageID || win.load.AssetsManager.LoadedWalletsCount() == 0 {
+		return
+	}
+
+	win.lock()
+}
+
+// lock locks the wallets that aren't used by background jobs, hides the
+// balances and notifies the displayed page and modal. The startup passphrase
+// is required to use the app again if it is set.
+func (win *Window) lock() {
+	log.Info("Locking the app after a period of inactivity")
+	win.lastActivity = time.Now()
+
+	win.load.AssetsManager.LockIdleWallets()
+	win.load.AssetsManager.SetTotalBalanceVisibility(true)
+
+	if page, ok := win.navigator.CurrentPage().(load.AppLockHandler); ok {
+		page.OnAppLocked()
+	}
+	if modal := win.navigator.TopModal(); modal != nil {
+		if modal, ok := modal.(load.AppLockHandler); ok {
+			modal.OnAppLocked()
+		}
+	}
+
+	if !win.load.AssetsManager.IsStartupSecuritySet() {
+		win.navigator.Reload()
+		return
+	}
+
+	win.isLocked.Store(true)
+	win.showUnlockModal()
+}
+
+// showUnlockModal asks for the startup passphrase to unlock the app.
+func (win *Window) showUnlockModal() {
+	unlockModal := modal.NewCreatePasswordModal(win.load).
+		EnableName(false).
+		EnableConfirmPassword(false).
+		Title(values.String(values.StrUnlockWithPassword)).
+		PasswordHint(values.String(values.StrStartupPassword)).
+		SetNegativeButtonText(values.String(values.StrExit)).
+		SetNegativeButtonCallback(func() {
+			// Shut down the same way as when the window is closed.
+			select {
+			case win.exit <- struct{}{}:
+			default:
+			}
+		}).
+		SetCancelable(false).
+		SetPositiveButtonText(values.String(values.StrUnlock)).
+		SetPositiveButtonCallback(func(_, password string, m *modal.CreatePasswordModal) bool {
+			if err := win.load.AssetsManager.VerifyStartupPassphrase(password); err != nil {
+				m.SetError(values.String(values.StrInvalidPassphrase))
+				return false
+			}
+
+			win.isLocked.Store(false)
+			m.Dismiss()
+			return true
+		})
+	win.navigator.ShowModal(unlockModal)
 }
 
 // handleUserClick listen touch action of user for mobile.